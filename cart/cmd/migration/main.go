@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+
+	"route256/cart/internal/config"
+	"route256/cart/migrations"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+func main() {
+	// Flags
+	var action string
+	var configPath string
+	var dsn string
+
+	flag.StringVar(&action, "action", "up", "Migration action: up, down or status")
+	flag.StringVar(&configPath, "config", "", "Path to config file")
+	flag.StringVar(&dsn, "dsn", "", "Database DSN, used instead of config")
+	flag.Parse()
+
+	// DSN from config
+	if dsn == "" && configPath != "" {
+		cfg := config.NewConfig()
+		if err := cfg.ReadConfig(configPath); err != nil {
+			log.Fatalf("Failed to read config, error: %s", err)
+		}
+		dsn = cfg.Database.GetDSN()
+	}
+
+	// Check DSN
+	if dsn == "" {
+		log.Fatal("Config or database DSN must be provided")
+	}
+
+	// Database connect
+	ctx := context.Background()
+	db, err := NewConnect(ctx, dsn)
+	if err != nil {
+		log.Fatalf("Failed to connect to the database, error: %s", err)
+	}
+	defer db.Close()
+
+	// Configure goose
+	goose.SetBaseFS(migrations.EmbedFS)
+	if err := goose.SetDialect("postgres"); err != nil {
+		log.Fatalf("Error setting goose dialect: %v", err)
+	}
+
+	// Run migration
+	switch action {
+	case "up":
+		err = goose.Up(db, ".")
+		if err != nil {
+			log.Fatalf("Error applying migrations up: %v", err)
+		}
+		log.Println("Migrations successfully applied up.")
+	case "down":
+		err = goose.Down(db, ".")
+		if err != nil {
+			log.Fatalf("Error rolling back migrations down: %v", err)
+		}
+		log.Println("Migrations successfully rolled back down.")
+	case "status":
+		err = goose.Status(db, ".")
+		if err != nil {
+			log.Fatalf("Error getting migrations status: %v", err)
+		}
+	default:
+		log.Fatalf("Unknown migration action: %s. Use 'up', 'down' or 'status'.", action)
+	}
+}
+
+// NewConnect return *sql.DB connection to database
+func NewConnect(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
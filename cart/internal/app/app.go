@@ -20,12 +20,15 @@ import (
 	"route256/cart/internal/pkg/cacher"
 	grpc_mw "route256/cart/internal/pkg/mw/grpc"
 	cart_repository "route256/cart/internal/repository/cart"
+	cart_postgres_repository "route256/cart/internal/repository/cart_postgres"
+	cart_redis_repository "route256/cart/internal/repository/cart_redis"
 	cart_service "route256/cart/internal/service/cart"
 	"route256/utils/tracer"
 
 	oteltrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -35,17 +38,33 @@ import (
 const shutdownTimeout = 5 * time.Second
 const stdout = "stdout"
 
+// Types of cart repository.
+const (
+	repositoryMemory   = "memory"
+	repositoryPostgres = "postgres"
+	repositoryRedis    = "redis"
+)
+
+// Prefix of keys of Redis cart repository and interval of forgetting items of expired carts.
+const (
+	redisCartPrefix      = "cart"
+	redisCleanupInterval = time.Minute
+)
+
 type App struct {
 	config          *config.Config
 	logger          *logger.Logger
 	tracer          *oteltrace.TracerProvider
 	server          *server.Server
 	cartService     *cart_service.CartService
+	cartRepository  cart_service.ICartRepository
 	metricsListener net.Listener
 	lomsClient      *loms_service.LomsClient
 	productClient   *product_service.Client
 	connGrpc        *grpc.ClientConn
 	redisClient     *redis.Client
+	dbPool          *pgxpool.Pool
+	cancel          context.CancelFunc
 }
 
 // NewApp
@@ -87,9 +106,6 @@ func NewApp(ctx context.Context) (*App, error) {
 		logger.Errorw(ctx, "Failed to initialize tracer", "error", err)
 	}
 
-	// Init repository
-	cartRepository := cart_repository.NewCartRepository()
-
 	// Init Redis
 	redisAddr := fmt.Sprintf("%s:%s", cfg.Redis.GetHost(), cfg.Redis.GetPort())
 	redisOptions := &redis.Options{
@@ -102,6 +118,12 @@ func NewApp(ctx context.Context) (*App, error) {
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
+	// Init repository
+	cartRepository, dbPool, err := newCartRepository(ctx, cfg, redisClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init cart repository: %w", err)
+	}
+
 	// Cacher
 	cacheTTL := time.Duration(cfg.Redis.GetTTL()) * time.Second
 	redisCacher := cacher.NewRedisCacher(redisClient, cacheTTL)
@@ -132,22 +154,55 @@ func NewApp(ctx context.Context) (*App, error) {
 	srv := server.NewServer(&cfg.Server, cartService)
 
 	return &App{
-		config:        cfg,
-		logger:        log,
-		tracer:        tr,
-		server:        srv,
-		cartService:   cartService,
-		lomsClient:    loms,
-		productClient: productService,
-		connGrpc:      connGrpc,
-		redisClient:   redisClient,
+		config:         cfg,
+		logger:         log,
+		tracer:         tr,
+		server:         srv,
+		cartService:    cartService,
+		cartRepository: cartRepository,
+		lomsClient:     loms,
+		productClient:  productService,
+		connGrpc:       connGrpc,
+		redisClient:    redisClient,
+		dbPool:         dbPool,
 	}, nil
 }
 
+// newCartRepository creates cart repository of configured type, pool is nil unless repository is stored in PostgreSQL.
+func newCartRepository(ctx context.Context, cfg *config.Config, redisClient *redis.Client) (cart_service.ICartRepository, *pgxpool.Pool, error) {
+	switch cfg.Repository.GetType() {
+	case repositoryMemory:
+		return cart_repository.NewCartRepository(), nil, nil
+	case repositoryPostgres:
+		pool, err := pgxpool.New(ctx, cfg.Database.GetDSN())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create database pool: %w", err)
+		}
+		if err := pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		return cart_postgres_repository.NewCartRepository(pool), pool, nil
+	case repositoryRedis:
+		ttl := time.Duration(cfg.Redis.GetCartTTL()) * time.Second
+		return cart_redis_repository.NewCartRepository(redisClient, redisCartPrefix, ttl), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown repository type: %s", cfg.Repository.GetType())
+	}
+}
+
 func (a *App) Run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+
 	// Start metrics and profiling
 	go a.startMetricsServer(a.config.Metrics.GetURI())
 
+	// Forget items of expired carts
+	if repo, ok := a.cartRepository.(*cart_redis_repository.Repository); ok {
+		go repo.RunCleanup(ctx, redisCleanupInterval)
+	}
+
 	// Run server
 	if err := a.server.Run(); err != nil {
 		logger.Errorw(context.Background(), "Failed to start server", "error", err)
@@ -161,6 +216,11 @@ func (a *App) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
+	// Stop background jobs
+	if a.cancel != nil {
+		a.cancel()
+	}
+
 	// Shutdown server
 	if err := a.server.Shutdown(ctx); err != nil {
 		logger.Errorw(ctx, "Failed to shutdown server", "error", err)
@@ -191,6 +251,11 @@ func (a *App) Shutdown() error {
 
 	a.connGrpc.Close()
 
+	// Close database
+	if a.dbPool != nil {
+		a.dbPool.Close()
+	}
+
 	return nil
 }
 
@@ -39,6 +39,12 @@ func (s *Server) AddProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		writeJSONError(ctx, w, http.StatusBadRequest, err.Error())
@@ -59,11 +65,12 @@ func (s *Server) AddProduct(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call service
-	err = s.cartService.AddProduct(ctx, UID, SKU, req.Count)
+	newVersion, err := s.cartService.AddProduct(ctx, UID, SKU, req.Count, version)
 	if err != nil {
 		writeJSONError(ctx, w, getStatusCodeFromError(err), err.Error())
 		return
 	}
 
+	setETag(w, newVersion)
 	setResponseHeaders(w, http.StatusOK)
 }
@@ -1,11 +1,16 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"route256/cart/internal/models"
 	"strconv"
 
+	internal_errors "route256/cart/internal/pkg/errors"
+	"route256/utils/logger"
+
 	"go.opentelemetry.io/otel"
 )
 
@@ -31,8 +36,19 @@ func (s *Server) Checkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Call service
-	orderID, err := s.cartService.Checkout(ctx, models.UID(UID))
+	orderID, err := s.cartService.Checkout(ctx, models.UID(UID), version)
+	var checkoutErr *internal_errors.CheckoutError
+	if errors.As(err, &checkoutErr) {
+		writeCheckoutError(ctx, w, checkoutErr)
+		return
+	}
 	if err != nil {
 		writeJSONError(ctx, w, getStatusCodeFromError(err), err.Error())
 		return
@@ -51,3 +67,24 @@ func (s *Server) Checkout(w http.ResponseWriter, r *http.Request) {
 	setResponseHeaders(w, http.StatusOK)
 	w.Write(rawRes)
 }
+
+// writeCheckoutError function for write list of cart items which can not be ordered.
+func writeCheckoutError(ctx context.Context, w http.ResponseWriter, checkoutErr *internal_errors.CheckoutError) {
+	res := models.CheckoutErrorResponse{
+		Message:  checkoutErr.Error(),
+		Problems: checkoutErr.Problems,
+	}
+
+	rawRes, err := json.Marshal(res)
+	if err != nil {
+		writeJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Prices of cart are updated, retry of checkout needs new version
+	setETag(w, checkoutErr.Version)
+	setResponseHeaders(w, getStatusCodeFromError(checkoutErr))
+	if _, err := w.Write(rawRes); err != nil {
+		logger.Errorw(ctx, "Response writing failed", "error", err.Error())
+	}
+}
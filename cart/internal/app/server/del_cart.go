@@ -29,12 +29,19 @@ func (s *Server) DelCart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Call service
-	err = s.cartService.DelCart(ctx, UID)
+	newVersion, err := s.cartService.DelCart(ctx, UID, version)
 	if err != nil {
 		writeJSONError(ctx, w, getStatusCodeFromError(err), err.Error())
 		return
 	}
 
+	setETag(w, newVersion)
 	setResponseHeaders(w, http.StatusNoContent)
 }
@@ -36,12 +36,19 @@ func (s *Server) DelProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Call service
-	err = s.cartService.DelProduct(ctx, UID, SKU)
+	newVersion, err := s.cartService.DelProduct(ctx, UID, SKU, version)
 	if err != nil {
 		writeJSONError(ctx, w, getStatusCodeFromError(err), err.Error())
 		return
 	}
 
+	setETag(w, newVersion)
 	setResponseHeaders(w, http.StatusNoContent)
 }
@@ -32,12 +32,19 @@ func (s *Server) GetCart(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call service
-	items, totalPrice, err := s.cartService.GetCart(ctx, UID)
+	items, totalPrice, version, err := s.cartService.GetCart(ctx, UID)
 	if err != nil {
 		writeJSONError(ctx, w, getStatusCodeFromError(err), err.Error())
 		return
 	}
 
+	// Cart is not sent if client has the same version
+	setETag(w, version)
+	if matchIfNoneMatch(r, version) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	res := models.GetCartResponse{
 		Items:      items,
 		TotalPrice: totalPrice,
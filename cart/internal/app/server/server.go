@@ -14,11 +14,12 @@ type IConfig interface {
 }
 
 type ICartService interface {
-	AddProduct(ctx context.Context, UID models.UID, SKU models.SKU, Count uint16) error
-	DelProduct(ctx context.Context, UID models.UID, SKU models.SKU) error
-	DelCart(ctx context.Context, UID models.UID) error
-	GetCart(ctx context.Context, UID models.UID) ([]models.CartItemResponse, uint32, error)
-	Checkout(ctx context.Context, UID models.UID) (int64, error)
+	AddProduct(ctx context.Context, UID models.UID, SKU models.SKU, Count uint16, version uint64) (uint64, error)
+	SetProductCount(ctx context.Context, UID models.UID, SKU models.SKU, Count uint16, version uint64) (uint64, error)
+	DelProduct(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (uint64, error)
+	DelCart(ctx context.Context, UID models.UID, version uint64) (uint64, error)
+	GetCart(ctx context.Context, UID models.UID) ([]models.CartItemResponse, uint32, uint64, error)
+	Checkout(ctx context.Context, UID models.UID, version uint64) (int64, error)
 }
 
 type Server struct {
@@ -46,6 +47,7 @@ func (s *Server) Run() error {
 	// Set handler
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /user/{user_id}/cart/{sku_id}", s.AddProduct)
+	mux.HandleFunc("PUT /user/{user_id}/cart/{sku_id}", s.SetProduct)
 	mux.HandleFunc("DELETE /user/{user_id}/cart/{sku_id}", s.DelProduct)
 	mux.HandleFunc("DELETE /user/{user_id}/cart", s.DelCart)
 	mux.HandleFunc("GET /user/{user_id}/cart", s.GetCart)
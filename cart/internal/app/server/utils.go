@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+
 	internal_errors "route256/cart/internal/pkg/errors"
 	"route256/utils/logger"
 
@@ -43,6 +46,8 @@ func getStatusCodeFromError(err error) int {
 		return http.StatusForbidden // 403
 	case errors.Is(err, internal_errors.ErrNotFound):
 		return http.StatusNotFound // 404
+	case errors.Is(err, internal_errors.ErrConflict):
+		return http.StatusConflict // 409
 	case errors.Is(err, internal_errors.ErrPreconditionFailed):
 		return http.StatusPreconditionFailed // 412
 	case errors.Is(err, internal_errors.ErrInternalServerError):
@@ -51,3 +56,49 @@ func getStatusCodeFromError(err error) int {
 		return http.StatusInternalServerError // 500
 	}
 }
+
+// formatETag function for format cart version as ETag.
+func formatETag(version uint64) string {
+	return strconv.Quote(strconv.FormatUint(version, 10))
+}
+
+// setETag function for set cart version as ETag, deleted cart and cart without version have zero version and no ETag.
+func setETag(w http.ResponseWriter, version uint64) {
+	if version == 0 {
+		return
+	}
+	w.Header().Set("ETag", formatETag(version))
+}
+
+// matchIfNoneMatch function for check whether If-None-Match header contains cart version or "*".
+func matchIfNoneMatch(r *http.Request, version uint64) bool {
+	value := r.Header.Get("If-None-Match")
+	if value == "" {
+		return false
+	}
+
+	etag := formatETag(version)
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseIfMatch function for get expected cart version from If-Match header, zero is returned if header is absent or "*".
+func parseIfMatch(r *http.Request) (uint64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(value, `"`), 10, 64)
+	if err != nil || version == 0 {
+		return 0, fmt.Errorf("invalid If-Match header: %s", value)
+	}
+
+	return version, nil
+}
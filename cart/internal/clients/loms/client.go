@@ -22,8 +22,8 @@ func NewLomsClient(conn *grpc.ClientConn) *LomsClient {
 	}
 }
 
-// OrderCreate create order with items for user.
-func (c *LomsClient) OrderCreate(ctx context.Context, user int64, items []models.CartItem) (orderID int64, err error) {
+// OrderCreate create order with items for user, retried request with the same idempotencyKey creates order only once.
+func (c *LomsClient) OrderCreate(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (orderID int64, err error) {
 	// Tracer
 	ctx, span := otel.Tracer("LomsClient").Start(ctx, "OrderCreate")
 	defer span.End()
@@ -43,8 +43,9 @@ func (c *LomsClient) OrderCreate(ctx context.Context, user int64, items []models
 	// Call client
 	var res *loms.OrderCreateResponse
 	res, err = c.client.OrderCreate(ctx, &loms.OrderCreateRequest{
-		User:  user,
-		Items: lomsItems,
+		User:           user,
+		Items:          lomsItems,
+		IdempotencyKey: idempotencyKey,
 	})
 
 	if err != nil {
@@ -79,3 +80,42 @@ func (c *LomsClient) StocksInfo(ctx context.Context, SKU models.SKU) (count int6
 
 	return int64(res.Count), nil
 }
+
+// StocksInfoBatch requests available stocks for several SKUs in one call, SKUs unknown to loms are returned separately.
+func (c *LomsClient) StocksInfoBatch(ctx context.Context, SKUs []models.SKU) (counts map[models.SKU]int64, unknown []models.SKU, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsClient").Start(ctx, "StocksInfoBatch")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogExternalRequest("LomsClient.StocksInfoBatch", start, &err)
+
+	req := &loms.StocksInfoBatchRequest{
+		Skus: make([]uint32, 0, len(SKUs)),
+	}
+	for _, SKU := range SKUs {
+		req.Skus = append(req.Skus, uint32(SKU))
+	}
+
+	// Call client
+	var res *loms.StocksInfoBatchResponse
+	res, err = c.client.StocksInfoBatch(ctx, req)
+
+	if err != nil {
+		err = fmt.Errorf("failed to get stocks info: %w", err)
+		return nil, nil, err
+	}
+
+	counts = make(map[models.SKU]int64, len(res.Stocks))
+	for _, stock := range res.Stocks {
+		counts[models.SKU(stock.Sku)] = int64(stock.Available)
+	}
+
+	unknown = make([]models.SKU, 0, len(res.UnknownSkus))
+	for _, SKU := range res.UnknownSkus {
+		unknown = append(unknown, models.SKU(SKU))
+	}
+
+	return counts, unknown, nil
+}
@@ -131,6 +131,11 @@ func (c *Client) GetProduct(ctx context.Context, SKU models.SKU) (product *model
 	return product, nil
 }
 
+// RefreshProduct function for executes a request to the Product Service, client has no cache so it is the same as GetProduct.
+func (c *Client) RefreshProduct(ctx context.Context, SKU models.SKU) (*models.GetProductResponse, error) {
+	return c.GetProduct(ctx, SKU)
+}
+
 // prepareRequest
 func (c *Client) prepareRequest(ctx context.Context, SKU models.SKU) (*http.Request, error) {
 	reqBody := models.GetProductRequest{
@@ -155,10 +160,14 @@ func (c *Client) prepareRequest(ctx context.Context, SKU models.SKU) (*http.Requ
 	return req, nil
 }
 
-// handleResponse
+// handleResponse function for decode product, unknown SKU is ErrPreconditionFailed and failure of Product Service
+// is ErrInternalServerError.
 func (c *Client) handleResponse(resp *http.Response) (*models.GetProductResponse, error) {
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("product not found: %w", internal_errors.ErrPreconditionFailed)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("product service returned status code %d: %w", resp.StatusCode, internal_errors.ErrPreconditionFailed)
+		return nil, fmt.Errorf("product service returned status code %d: %w", resp.StatusCode, internal_errors.ErrInternalServerError)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -0,0 +1,73 @@
+package product_service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"route256/cart/internal/clients/product_service"
+	"route256/cart/internal/config"
+	"route256/cart/internal/models"
+	internal_errors "route256/cart/internal/pkg/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestClient_GetProduct function for tests statuses of Product Service are mapped to errors.
+func TestClient_GetProduct(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		expectedProduct *models.GetProductResponse
+		expectedErr     error
+	}{
+		{
+			name:            "product found",
+			status:          http.StatusOK,
+			body:            `{"name":"Книга","price":100}`,
+			expectedProduct: &models.GetProductResponse{Name: "Книга", Price: 100},
+		},
+		{
+			name:        "product not found",
+			status:      http.StatusNotFound,
+			expectedErr: internal_errors.ErrPreconditionFailed,
+		},
+		{
+			name:        "product service failure",
+			status:      http.StatusInternalServerError,
+			expectedErr: internal_errors.ErrInternalServerError,
+		},
+		{
+			name:        "product service unavailable",
+			status:      http.StatusServiceUnavailable,
+			expectedErr: internal_errors.ErrInternalServerError,
+		},
+	}
+
+	// One client for all cases, so rate limiter is filled once
+	var status int
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := product_service.NewClient(&config.ProductService{ApiURI: server.URL})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body = tt.status, tt.body
+
+			product, err := client.GetProduct(context.Background(), 1001)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedProduct, product)
+		})
+	}
+}
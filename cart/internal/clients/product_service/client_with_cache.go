@@ -48,3 +48,20 @@ func (c *ClientWithCache) GetProduct(ctx context.Context, SKU models.SKU) (*mode
 
 	return product, nil
 }
+
+// RefreshProduct retrieves product information bypassing cache and updates cache.
+func (c *ClientWithCache) RefreshProduct(ctx context.Context, SKU models.SKU) (*models.GetProductResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("ProductServiceClientWithCache").Start(ctx, "RefreshProduct")
+	defer span.End()
+
+	product, err := c.client.GetProduct(ctx, SKU)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save in cache
+	c.cacher.Set(SKU, product)
+
+	return product, nil
+}
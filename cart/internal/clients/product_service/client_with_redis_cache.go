@@ -77,3 +77,21 @@ func (c *ClientWithRedisCache) GetProduct(ctx context.Context, SKU models.SKU) (
 
 	return v.(*models.GetProductResponse), nil
 }
+
+// RefreshProduct retrieves a product by SKU bypassing the cache and updates the cache.
+func (c *ClientWithRedisCache) RefreshProduct(ctx context.Context, SKU models.SKU) (*models.GetProductResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("ProductServiceClientWithRedisCache").Start(ctx, "RefreshProduct")
+	defer span.End()
+
+	product, err := c.client.GetProduct(ctx, SKU)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.cacher.Set(ctx, SKU, product); err != nil {
+		logger.Errorw(ctx, "Cache Set error", "error", err)
+	}
+
+	return product, nil
+}
@@ -1,5 +1,7 @@
 package models
 
+import internal_errors "route256/cart/internal/pkg/errors"
+
 // User ID.
 type UID = int64
 
@@ -10,6 +12,8 @@ type SKU = int64
 type CartItem struct {
 	SKU   SKU    `json:"sku_id"`
 	Count uint16 `json:"count"`
+	// Price of product when item was last added, zero if unknown
+	Price uint32 `json:"price"`
 }
 
 // Struct for cart item response.
@@ -28,6 +32,14 @@ type AddProductRequest struct {
 type AddProductResponse struct {
 }
 
+// Set count of product in user cart by SKU, zero count deletes product.
+type SetProductRequest struct {
+	Count *uint16 `json:"count" validate:"required"`
+}
+
+type SetProductResponse struct {
+}
+
 // Del product from user cart by SKU.
 type DelProductRequest struct {
 }
@@ -60,6 +72,11 @@ type CheckoutResponse struct {
 	OrderID int64 `json:"orderID"`
 }
 
+type CheckoutErrorResponse struct {
+	Message  string                            `json:"message"`
+	Problems []internal_errors.CheckoutProblem `json:"problems"`
+}
+
 // Product service.
 type GetProductRequest struct {
 	Token string `json:"token"`
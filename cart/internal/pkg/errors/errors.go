@@ -1,12 +1,50 @@
 package internal_errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrBadRequest          = errors.New("bad request")
 	ErrUnauthorized        = errors.New("unauthorized")
 	ErrForbidden           = errors.New("forbidden")
 	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
 	ErrPreconditionFailed  = errors.New("precondition failed")
 	ErrInternalServerError = errors.New("internal server error")
 )
+
+// Reasons why cart item can not be ordered.
+const (
+	CheckoutProblemProductNotFound   = "product_not_found"
+	CheckoutProblemInsufficientStock = "insufficient_stock"
+	CheckoutProblemPriceChanged      = "price_changed"
+)
+
+// Cart item which can not be ordered, item with several problems is listed once for each reason.
+type CheckoutProblem struct {
+	SKU       int64  `json:"sku_id"`
+	Reason    string `json:"reason"`
+	Count     uint16 `json:"count"`
+	Available int64  `json:"available"`
+	CartPrice uint32 `json:"cart_price,omitempty"`
+	Price     uint32 `json:"price,omitempty"`
+}
+
+// CheckoutError lists cart items which can not be ordered.
+type CheckoutError struct {
+	Problems []CheckoutProblem
+	// Version of cart with fresh prices of items, checkout is retried with it after user saw problems
+	Version uint64
+}
+
+// Error returns number of problem items.
+func (e *CheckoutError) Error() string {
+	return fmt.Sprintf("cart has %d problems with items: %s", len(e.Problems), ErrConflict)
+}
+
+// Unwrap makes CheckoutError match ErrConflict.
+func (e *CheckoutError) Unwrap() error {
+	return ErrConflict
+}
@@ -75,6 +75,14 @@ var (
 		},
 	)
 
+	redisItemsGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "app",
+			Name:      "redis_repository_items_total",
+			Help:      "Total number of items in Redis repository",
+		},
+	)
+
 	cacheHitCounter = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "app",
@@ -138,6 +146,11 @@ func SetInMemoryItemsTotal(count int) {
 	inMemoryItemsGauge.Set(float64(count))
 }
 
+// SetRedisItemsTotal sets the total number of items in the Redis repository.
+func SetRedisItemsTotal(count int64) {
+	redisItemsGauge.Set(float64(count))
+}
+
 // LogExternalRequest
 func LogExternalRequest(metricName string, start time.Time, err *error) {
 	duration := time.Since(start)
@@ -5,8 +5,47 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+// metadataCarrier adapts outgoing gRPC metadata to propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+// Get returns first value of key.
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set sets value of key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns all keys of metadata.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// injectTraceContext returns ctx with trace context of its span in outgoing metadata, so server continues the trace.
+func injectTraceContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
 // GrpcUnaryClientInterceptor returns a new unary client interceptor that adds tracing.
 func GrpcUnaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(
@@ -22,7 +61,7 @@ func GrpcUnaryClientInterceptor() grpc.UnaryClientInterceptor {
 		defer span.End()
 
 		// Invoke the original method
-		return invoker(ctx, method, req, reply, cc, opts...)
+		return invoker(injectTraceContext(ctx), method, req, reply, cc, opts...)
 	}
 }
 
@@ -41,6 +80,6 @@ func GrpcStreamClientInterceptor() grpc.StreamClientInterceptor {
 		defer span.End()
 
 		// Invoke the original method
-		return streamer(ctx, desc, cc, method, opts...)
+		return streamer(injectTraceContext(ctx), desc, cc, method, opts...)
 	}
 }
@@ -16,7 +16,7 @@ func TestRepository_AddItem(t *testing.T) {
 		name          string
 		UID           models.UID
 		item          models.CartItem
-		setup         func(repo *Repository)
+		setup         func(t *testing.T, repo cartRepository)
 		expectedSKU   models.SKU
 		expectedCount uint16
 		wantErr       bool
@@ -28,7 +28,7 @@ func TestRepository_AddItem(t *testing.T) {
 				SKU:   1001,
 				Count: 2,
 			},
-			setup:         func(repo *Repository) {},
+			setup:         func(t *testing.T, repo cartRepository) {},
 			expectedSKU:   1001,
 			expectedCount: 2,
 			wantErr:       false,
@@ -40,10 +40,8 @@ func TestRepository_AddItem(t *testing.T) {
 				SKU:   1001,
 				Count: 2,
 			},
-			setup: func(repo *Repository) {
-				repo.storage[1] = map[models.SKU]models.CartItem{
-					1001: {SKU: 1001, Count: 2},
-				}
+			setup: func(t *testing.T, repo cartRepository) {
+				addItems(t, repo, 1, models.CartItem{SKU: 1001, Count: 2})
 			},
 			expectedSKU:   1001,
 			expectedCount: 4,
@@ -56,7 +54,7 @@ func TestRepository_AddItem(t *testing.T) {
 				SKU:   1001,
 				Count: 2,
 			},
-			setup:   func(repo *Repository) {},
+			setup:   func(t *testing.T, repo cartRepository) {},
 			wantErr: true,
 		},
 		{
@@ -66,7 +64,7 @@ func TestRepository_AddItem(t *testing.T) {
 				SKU:   0,
 				Count: 2,
 			},
-			setup:   func(repo *Repository) {},
+			setup:   func(t *testing.T, repo cartRepository) {},
 			wantErr: true,
 		},
 		{
@@ -76,79 +74,77 @@ func TestRepository_AddItem(t *testing.T) {
 				SKU:   1001,
 				Count: 0,
 			},
-			setup:   func(repo *Repository) {},
+			setup:   func(t *testing.T, repo cartRepository) {},
 			wantErr: true,
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Run test parallel
-			t.Parallel()
-
-			// Init repo
-			repo := NewCartRepository()
-
-			// Setup storage
-			tt.setup(repo)
+	for _, impl := range implementations() {
+		for _, tt := range tests {
+			t.Run(impl.name+"/"+tt.name, func(t *testing.T) {
+				// Run test parallel
+				t.Parallel()
 
-			ctx := context.Background()
+				// Init repo
+				repo := impl.new(t)
 
-			// Run function
-			err := repo.AddItem(ctx, tt.UID, tt.item)
+				// Setup storage
+				tt.setup(t, repo)
 
-			// Check want error
-			if tt.wantErr {
-				require.Error(t, err, "Error")
-				return
-			}
-			require.NoError(t, err, "NoError")
+				ctx := context.Background()
 
-			// Check storage
-			storedCart, ok := repo.storage[tt.UID]
-			require.True(t, ok, "cart for this UID must exist")
-			require.NotNil(t, storedCart, "cart must not be nil")
+				// Run function
+				_, err := repo.AddItem(ctx, tt.UID, tt.item, 0)
 
-			storedItem, ok := storedCart[tt.item.SKU]
-			require.True(t, ok, "item must be in cart")
-			require.Equal(t, tt.expectedSKU, storedItem.SKU, "SKU must match")
-			require.Equal(t, tt.expectedCount, storedItem.Count, "Count must match")
-		})
+				// Check want error
+				if tt.wantErr {
+					require.Error(t, err, "Error")
+					return
+				}
+				require.NoError(t, err, "NoError")
+
+				// Check storage
+				storedItem, ok := findItem(t, repo, tt.UID, tt.item.SKU)
+				require.True(t, ok, "item must be in cart")
+				require.Equal(t, tt.expectedSKU, storedItem.SKU, "SKU must match")
+				require.Equal(t, tt.expectedCount, storedItem.Count, "Count must match")
+			})
+		}
 	}
 }
 
 // TestRepository_AddItem_Concurrent tests concurrent calls to AddItem.
 func TestRepository_AddItem_Concurrent(t *testing.T) {
-	// Run test parallel
-	t.Parallel()
-
-	repo := NewCartRepository()
-	ctx := context.Background()
-
-	const numGoroutines = 100
-	const UID models.UID = 1
-	item := models.CartItem{SKU: 1001, Count: 1}
-
-	var wg sync.WaitGroup
-	wg.Add(numGoroutines)
-
-	// Add items
-	for i := 0; i < numGoroutines; i++ {
-		go func() {
-			defer wg.Done()
-			err := repo.AddItem(ctx, UID, item)
-			require.NoError(t, err)
-		}()
-	}
+	for _, impl := range implementations() {
+		t.Run(impl.name, func(t *testing.T) {
+			// Run test parallel
+			t.Parallel()
+
+			repo := impl.new(t)
+			ctx := context.Background()
+
+			const numGoroutines = 100
+			const UID models.UID = 1
+			item := models.CartItem{SKU: 1001, Count: 1}
 
-	wg.Wait()
+			var wg sync.WaitGroup
+			wg.Add(numGoroutines)
 
-	// Verify the final count
-	repo.mu.Lock()
-	storedCart, ok := repo.storage[UID]
-	repo.mu.Unlock()
-	require.True(t, ok)
-	storedItem, ok := storedCart[item.SKU]
-	require.True(t, ok)
-	require.Equal(t, uint16(numGoroutines*int(item.Count)), storedItem.Count)
+			// Add items
+			for i := 0; i < numGoroutines; i++ {
+				go func() {
+					defer wg.Done()
+					_, err := repo.AddItem(ctx, UID, item, 0)
+					require.NoError(t, err)
+				}()
+			}
+
+			wg.Wait()
+
+			// Verify the final count
+			storedItem, ok := findItem(t, repo, UID, item.SKU)
+			require.True(t, ok)
+			require.Equal(t, uint16(numGoroutines*int(item.Count)), storedItem.Count)
+		})
+	}
 }
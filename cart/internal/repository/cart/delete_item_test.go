@@ -16,17 +16,15 @@ func TestRepository_DeleteItem(t *testing.T) {
 		name    string
 		UID     models.UID
 		SKU     models.SKU
-		setup   func(repo *Repository)
+		setup   func(t *testing.T, repo cartRepository)
 		wantErr bool
 	}{
 		{
 			name: "successful delete item",
 			UID:  1,
 			SKU:  1001,
-			setup: func(repo *Repository) {
-				repo.storage[1] = map[models.SKU]models.CartItem{
-					1001: {SKU: 1001, Count: 2},
-				}
+			setup: func(t *testing.T, repo cartRepository) {
+				addItems(t, repo, 1, models.CartItem{SKU: 1001, Count: 2})
 			},
 			wantErr: false,
 		},
@@ -34,99 +32,94 @@ func TestRepository_DeleteItem(t *testing.T) {
 			name:    "invalid UID",
 			UID:     0,
 			SKU:     1001,
-			setup:   func(repo *Repository) {},
+			setup:   func(t *testing.T, repo cartRepository) {},
 			wantErr: true,
 		},
 		{
 			name:    "invalid SKU",
 			UID:     1,
 			SKU:     0,
-			setup:   func(repo *Repository) {},
+			setup:   func(t *testing.T, repo cartRepository) {},
 			wantErr: true,
 		},
 		{
 			name: "item does not exist",
 			UID:  1,
 			SKU:  9999,
-			setup: func(repo *Repository) {
-				repo.storage[1] = map[models.SKU]models.CartItem{
-					1001: {SKU: 1001, Count: 2},
-				}
+			setup: func(t *testing.T, repo cartRepository) {
+				addItems(t, repo, 1, models.CartItem{SKU: 1001, Count: 2})
 			},
 			wantErr: false,
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Run test parallel
-			t.Parallel()
+	for _, impl := range implementations() {
+		for _, tt := range tests {
+			t.Run(impl.name+"/"+tt.name, func(t *testing.T) {
+				// Run test parallel
+				t.Parallel()
 
-			// Init repo
-			repo := NewCartRepository()
+				// Init repo
+				repo := impl.new(t)
 
-			// Setup storage
-			tt.setup(repo)
+				// Setup storage
+				tt.setup(t, repo)
 
-			ctx := context.Background()
+				ctx := context.Background()
 
-			// Run function
-			err := repo.DeleteItem(ctx, tt.UID, tt.SKU)
+				// Run function
+				_, err := repo.DeleteItem(ctx, tt.UID, tt.SKU, 0)
 
-			// Check want error
-			if tt.wantErr {
-				require.Error(t, err, "Error")
-				return
-			}
-			require.NoError(t, err, "NoError")
+				// Check want error
+				if tt.wantErr {
+					require.Error(t, err, "Error")
+					return
+				}
+				require.NoError(t, err, "NoError")
 
-			// Check storage
-			if storedCart, exists := repo.storage[tt.UID]; exists {
-				_, itemExists := storedCart[tt.SKU]
+				// Check storage
+				_, itemExists := findItem(t, repo, tt.UID, tt.SKU)
 				require.False(t, itemExists, "item must be delete")
-			}
-		})
+			})
+		}
 	}
 }
 
 // TestRepository_DeleteItem_Concurrent tests concurrent calls to DeleteItem.
 func TestRepository_DeleteItem_Concurrent(t *testing.T) {
-	// Run test parallel
-	t.Parallel()
+	for _, impl := range implementations() {
+		t.Run(impl.name, func(t *testing.T) {
+			// Run test parallel
+			t.Parallel()
 
-	repo := NewCartRepository()
-	ctx := context.Background()
+			repo := impl.new(t)
+			ctx := context.Background()
 
-	const numGoroutines = 100
-	const UID models.UID = 1
-	SKU := models.SKU(1001)
-	item := models.CartItem{SKU: SKU, Count: 1}
+			const numGoroutines = 100
+			const UID models.UID = 1
+			SKU := models.SKU(1001)
+			item := models.CartItem{SKU: SKU, Count: 1}
 
-	// Add item
-	repo.mu.Lock()
-	if repo.storage[UID] == nil {
-		repo.storage[UID] = make(map[models.SKU]models.CartItem)
-	}
-	repo.storage[UID][SKU] = item
-	repo.mu.Unlock()
-
-	var wg sync.WaitGroup
-	wg.Add(numGoroutines)
-
-	// Concurrently delete items from repository
-	for i := 0; i < numGoroutines; i++ {
-		go func() {
-			defer wg.Done()
-			err := repo.DeleteItem(ctx, UID, SKU)
-			require.NoError(t, err)
-		}()
-	}
+			// Add item
+			addItems(t, repo, UID, item)
 
-	wg.Wait()
+			var wg sync.WaitGroup
+			wg.Add(numGoroutines)
 
-	// Verify the item has been deleted
-	repo.mu.Lock()
-	_, exists := repo.storage[UID][SKU]
-	repo.mu.Unlock()
-	require.False(t, exists, "item should be deleted")
+			// Concurrently delete items from repository
+			for i := 0; i < numGoroutines; i++ {
+				go func() {
+					defer wg.Done()
+					_, err := repo.DeleteItem(ctx, UID, SKU, 0)
+					require.NoError(t, err)
+				}()
+			}
+
+			wg.Wait()
+
+			// Verify the item has been deleted
+			_, exists := findItem(t, repo, UID, SKU)
+			require.False(t, exists, "item should be deleted")
+		})
+	}
 }
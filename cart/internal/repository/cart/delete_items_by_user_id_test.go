@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"route256/cart/internal/models"
+	internal_errors "route256/cart/internal/pkg/errors"
 	"sync"
 	"testing"
 
@@ -15,100 +16,97 @@ func TestRepository_DeleteItemsByUserID(t *testing.T) {
 	tests := []struct {
 		name    string
 		UID     models.UID
-		setup   func(repo *Repository)
+		setup   func(t *testing.T, repo cartRepository)
 		wantErr bool
 	}{
 		{
 			name: "successful delete user cart",
 			UID:  1,
-			setup: func(repo *Repository) {
-				repo.storage[1] = map[models.SKU]models.CartItem{
-					1001: {SKU: 1001, Count: 2},
-				}
+			setup: func(t *testing.T, repo cartRepository) {
+				addItems(t, repo, 1, models.CartItem{SKU: 1001, Count: 2})
 			},
 			wantErr: false,
 		},
 		{
 			name:    "invalid UID",
 			UID:     0,
-			setup:   func(repo *Repository) {},
+			setup:   func(t *testing.T, repo cartRepository) {},
 			wantErr: true,
 		},
 		{
 			name:    "delete empty cart",
 			UID:     2,
-			setup:   func(repo *Repository) {},
+			setup:   func(t *testing.T, repo cartRepository) {},
 			wantErr: false,
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Run test parallel
-			t.Parallel()
+	for _, impl := range implementations() {
+		for _, tt := range tests {
+			t.Run(impl.name+"/"+tt.name, func(t *testing.T) {
+				// Run test parallel
+				t.Parallel()
 
-			// Init repo
-			repo := NewCartRepository()
+				// Init repo
+				repo := impl.new(t)
 
-			// Setup storage
-			tt.setup(repo)
+				// Setup storage
+				tt.setup(t, repo)
 
-			ctx := context.Background()
+				ctx := context.Background()
 
-			// Run function
-			err := repo.DeleteItemsByUserID(ctx, tt.UID)
+				// Run function
+				_, err := repo.DeleteItemsByUserID(ctx, tt.UID, 0)
 
-			// Check want error
-			if tt.wantErr {
-				require.Error(t, err, "Error")
-				return
-			}
-			require.NoError(t, err, "NoError")
+				// Check want error
+				if tt.wantErr {
+					require.Error(t, err, "Error")
+					return
+				}
+				require.NoError(t, err, "NoError")
 
-			// Check storage
-			_, exists := repo.storage[tt.UID]
-			require.False(t, exists, "cart must be delete")
-		})
+				// Check storage
+				_, _, err = repo.GetItemsByUserID(ctx, tt.UID)
+				require.ErrorIs(t, err, internal_errors.ErrNotFound, "cart must be delete")
+			})
+		}
 	}
 }
 
 // TestRepository_DeleteItemsByUserID_Concurrent tests concurrent calls to DeleteItemsByUserID.
 func TestRepository_DeleteItemsByUserID_Concurrent(t *testing.T) {
-	// Run test parallel
-	t.Parallel()
+	for _, impl := range implementations() {
+		t.Run(impl.name, func(t *testing.T) {
+			// Run test parallel
+			t.Parallel()
 
-	repo := NewCartRepository()
-	ctx := context.Background()
+			repo := impl.new(t)
+			ctx := context.Background()
 
-	const numGoroutines = 100
-	const UID models.UID = 1
-	item := models.CartItem{SKU: 1001, Count: 1}
+			const numGoroutines = 100
+			const UID models.UID = 1
+			item := models.CartItem{SKU: 1001, Count: 1}
 
-	// Add item
-	repo.mu.Lock()
-	if repo.storage[UID] == nil {
-		repo.storage[UID] = make(map[models.SKU]models.CartItem)
-	}
-	repo.storage[UID][item.SKU] = item
-	repo.mu.Unlock()
-
-	var wg sync.WaitGroup
-	wg.Add(numGoroutines)
-
-	// Concurrently delete user cart
-	for i := 0; i < numGoroutines; i++ {
-		go func() {
-			defer wg.Done()
-			err := repo.DeleteItemsByUserID(ctx, UID)
-			require.NoError(t, err)
-		}()
-	}
+			// Add item
+			addItems(t, repo, UID, item)
+
+			var wg sync.WaitGroup
+			wg.Add(numGoroutines)
 
-	wg.Wait()
+			// Concurrently delete user cart
+			for i := 0; i < numGoroutines; i++ {
+				go func() {
+					defer wg.Done()
+					_, err := repo.DeleteItemsByUserID(ctx, UID, 0)
+					require.NoError(t, err)
+				}()
+			}
+
+			wg.Wait()
 
-	// Verify that the cart was deleted
-	repo.mu.Lock()
-	_, exists := repo.storage[UID]
-	repo.mu.Unlock()
-	require.False(t, exists, "the cart should be deleted")
+			// Verify that the cart was deleted
+			_, _, err := repo.GetItemsByUserID(ctx, UID)
+			require.ErrorIs(t, err, internal_errors.ErrNotFound, "the cart should be deleted")
+		})
+	}
 }
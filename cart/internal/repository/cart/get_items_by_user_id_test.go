@@ -15,7 +15,7 @@ func TestRepository_GetItemsByUserID(t *testing.T) {
 	tests := []struct {
 		name          string
 		UID           models.UID
-		setup         func(repo *Repository)
+		setup         func(t *testing.T, repo cartRepository)
 		wantErr       bool
 		expectedLen   int
 		expectedItems []models.CartItem
@@ -23,17 +23,15 @@ func TestRepository_GetItemsByUserID(t *testing.T) {
 		{
 			name:        "get from empty cart",
 			UID:         1,
-			setup:       func(repo *Repository) {},
+			setup:       func(t *testing.T, repo cartRepository) {},
 			wantErr:     true,
 			expectedLen: 0,
 		},
 		{
 			name: "successful get user cart with 1 items",
 			UID:  2,
-			setup: func(repo *Repository) {
-				repo.storage[2] = map[models.SKU]models.CartItem{
-					1001: {SKU: 1001, Count: 2},
-				}
+			setup: func(t *testing.T, repo cartRepository) {
+				addItems(t, repo, 2, models.CartItem{SKU: 1001, Count: 2})
 			},
 			wantErr:     false,
 			expectedLen: 1,
@@ -44,12 +42,12 @@ func TestRepository_GetItemsByUserID(t *testing.T) {
 		{
 			name: "successful get user cart with 3 items",
 			UID:  3,
-			setup: func(repo *Repository) {
-				repo.storage[3] = map[models.SKU]models.CartItem{
-					1003: {SKU: 1003, Count: 1},
-					1002: {SKU: 1002, Count: 5},
-					1001: {SKU: 1001, Count: 2},
-				}
+			setup: func(t *testing.T, repo cartRepository) {
+				addItems(t, repo, 3,
+					models.CartItem{SKU: 1003, Count: 1},
+					models.CartItem{SKU: 1002, Count: 5},
+					models.CartItem{SKU: 1001, Count: 2},
+				)
 			},
 			wantErr:     false,
 			expectedLen: 3,
@@ -62,7 +60,7 @@ func TestRepository_GetItemsByUserID(t *testing.T) {
 		{
 			name:          "invalid UID",
 			UID:           0,
-			setup:         func(repo *Repository) {},
+			setup:         func(t *testing.T, repo cartRepository) {},
 			wantErr:       true,
 			expectedLen:   0,
 			expectedItems: []models.CartItem{},
@@ -70,10 +68,8 @@ func TestRepository_GetItemsByUserID(t *testing.T) {
 		{
 			name: "cart for UID not found",
 			UID:  4,
-			setup: func(repo *Repository) {
-				repo.storage[5] = map[models.SKU]models.CartItem{
-					1001: {SKU: 1001, Count: 2},
-				}
+			setup: func(t *testing.T, repo cartRepository) {
+				addItems(t, repo, 5, models.CartItem{SKU: 1001, Count: 2})
 			},
 			wantErr:       true,
 			expectedLen:   0,
@@ -81,73 +77,76 @@ func TestRepository_GetItemsByUserID(t *testing.T) {
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Run test parallel
-			t.Parallel()
+	for _, impl := range implementations() {
+		for _, tt := range tests {
+			t.Run(impl.name+"/"+tt.name, func(t *testing.T) {
+				// Run test parallel
+				t.Parallel()
 
-			// Init repo
-			repo := NewCartRepository()
+				// Init repo
+				repo := impl.new(t)
 
-			// Setup storage
-			tt.setup(repo)
+				// Setup storage
+				tt.setup(t, repo)
 
-			ctx := context.Background()
+				ctx := context.Background()
 
-			// Run function
-			items, err := repo.GetItemsByUserID(ctx, tt.UID)
+				// Run function
+				items, _, err := repo.GetItemsByUserID(ctx, tt.UID)
 
-			// Check want error
-			if tt.wantErr {
-				require.Error(t, err, "Error")
-				return
-			}
-			require.NoError(t, err, "NoError")
+				// Check want error
+				if tt.wantErr {
+					require.Error(t, err, "Error")
+					return
+				}
+				require.NoError(t, err, "NoError")
 
-			// Check items len
-			require.Len(t, items, tt.expectedLen, "len must match")
+				// Check items len
+				require.Len(t, items, tt.expectedLen, "len must match")
 
-			// Check items order
-			for i, expectedItem := range tt.expectedItems {
-				require.Equal(t, expectedItem, items[i], "items must match")
-			}
-		})
+				// Check items order
+				for i, expectedItem := range tt.expectedItems {
+					require.Equal(t, expectedItem, items[i], "items must match")
+				}
+			})
+		}
 	}
 }
 
 // TestRepository_GetItemsByUserID_Concurrent tests concurrent calls to GetItemsByUserID.
 func TestRepository_GetItemsByUserID_Concurrent(t *testing.T) {
-	// Run test parallel
-	t.Parallel()
+	for _, impl := range implementations() {
+		t.Run(impl.name, func(t *testing.T) {
+			// Run test parallel
+			t.Parallel()
 
-	repo := NewCartRepository()
-	ctx := context.Background()
+			repo := impl.new(t)
+			ctx := context.Background()
 
-	const numGoroutines = 100
-	const UID models.UID = 1
-	items := []models.CartItem{
-		{SKU: 1001, Count: 2},
-		{SKU: 1002, Count: 3},
-	}
+			const numGoroutines = 100
+			const UID models.UID = 1
+			items := []models.CartItem{
+				{SKU: 1001, Count: 2},
+				{SKU: 1002, Count: 3},
+			}
 
-	// Add items
-	for _, item := range items {
-		err := repo.AddItem(ctx, UID, item)
-		require.NoError(t, err)
-	}
+			// Add items
+			addItems(t, repo, UID, items...)
 
-	var wg sync.WaitGroup
-	wg.Add(numGoroutines)
-
-	// Concurrently get items from repository
-	for i := 0; i < numGoroutines; i++ {
-		go func() {
-			defer wg.Done()
-			retrievedItems, err := repo.GetItemsByUserID(ctx, UID)
-			require.NoError(t, err)
-			require.Len(t, retrievedItems, len(items))
-		}()
-	}
+			var wg sync.WaitGroup
+			wg.Add(numGoroutines)
+
+			// Concurrently get items from repository
+			for i := 0; i < numGoroutines; i++ {
+				go func() {
+					defer wg.Done()
+					retrievedItems, _, err := repo.GetItemsByUserID(ctx, UID)
+					require.NoError(t, err)
+					require.Len(t, retrievedItems, len(items))
+				}()
+			}
 
-	wg.Wait()
+			wg.Wait()
+		})
+	}
 }
@@ -19,12 +19,17 @@ type Storage = map[models.UID]map[models.SKU]models.CartItem
 type Repository struct {
 	mu      sync.Mutex
 	storage Storage
+	// versions of carts are taken from one counter, so version of cart created again after deletion never
+	// repeats version of deleted cart, and are forgotten together with carts
+	versions    map[models.UID]uint64
+	lastVersion uint64
 }
 
 func NewCartRepository() *Repository {
 	return &Repository{
-		mu:      sync.Mutex{},
-		storage: make(Storage),
+		mu:       sync.Mutex{},
+		storage:  make(Storage),
+		versions: make(map[models.UID]uint64),
 	}
 }
 
@@ -41,7 +46,8 @@ func (r *Repository) TotalItems() int {
 }
 
 // AddItem function for adding item to cart.
-func (r *Repository) AddItem(ctx context.Context, UID models.UID, item models.CartItem) (err error) {
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) AddItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (newVersion uint64, err error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartRepository").Start(ctx, "AddItem")
 	defer span.End()
@@ -52,12 +58,16 @@ func (r *Repository) AddItem(ctx context.Context, UID models.UID, item models.Ca
 	defer metrics.SetInMemoryItemsTotal(r.TotalItems())
 
 	if UID < 1 || item.SKU < 1 || item.Count < 1 {
-		return fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if err := r.checkVersion(UID, version); err != nil {
+		return 0, err
+	}
+
 	if r.storage[UID] == nil {
 		r.storage[UID] = make(map[models.SKU]models.CartItem)
 	}
@@ -68,11 +78,47 @@ func (r *Repository) AddItem(ctx context.Context, UID models.UID, item models.Ca
 	}
 	r.storage[UID][item.SKU] = item
 
-	return nil
+	return r.bumpVersion(UID), nil
+}
+
+// SetItem function for setting count of item in cart, item with zero count is deleted from cart.
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) SetItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartRepository").Start(ctx, "SetItem")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("SetItem", start, &err)
+	defer func() { metrics.SetInMemoryItemsTotal(r.TotalItems()) }()
+
+	if UID < 1 || item.SKU < 1 {
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkVersion(UID, version); err != nil {
+		return 0, err
+	}
+
+	if item.Count == 0 {
+		delete(r.storage[UID], item.SKU)
+	} else {
+		if r.storage[UID] == nil {
+			r.storage[UID] = make(map[models.SKU]models.CartItem)
+		}
+		r.storage[UID][item.SKU] = item
+	}
+
+	return r.bumpVersion(UID), nil
 }
 
 // DeleteItem function for delete item from cart.
-func (r *Repository) DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU) (err error) {
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (newVersion uint64, err error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartRepository").Start(ctx, "DeleteItem")
 	defer span.End()
@@ -83,21 +129,26 @@ func (r *Repository) DeleteItem(ctx context.Context, UID models.UID, SKU models.
 	defer metrics.SetInMemoryItemsTotal(r.TotalItems())
 
 	if UID < 1 || SKU < 1 {
-		return fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if err := r.checkVersion(UID, version); err != nil {
+		return 0, err
+	}
+
 	if r.storage[UID] != nil {
 		delete(r.storage[UID], SKU)
 	}
 
-	return nil
+	return r.bumpVersion(UID), nil
 }
 
 // DeleteItemsByUserID function for delete cart.
-func (r *Repository) DeleteItemsByUserID(ctx context.Context, UID models.UID) (err error) {
+// Cart is deleted only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) DeleteItemsByUserID(ctx context.Context, UID models.UID, version uint64) (newVersion uint64, err error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartRepository").Start(ctx, "DeleteItemsByUserID")
 	defer span.End()
@@ -108,19 +159,23 @@ func (r *Repository) DeleteItemsByUserID(ctx context.Context, UID models.UID) (e
 	defer metrics.SetInMemoryItemsTotal(r.TotalItems())
 
 	if UID < 1 {
-		return fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
+		return 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if err := r.checkVersion(UID, version); err != nil {
+		return 0, err
+	}
+
 	delete(r.storage, UID)
 
-	return nil
+	return r.bumpVersion(UID), nil
 }
 
-// GetItemsByUserID function for getting items from cart.
-func (r *Repository) GetItemsByUserID(ctx context.Context, UID models.UID) (items []models.CartItem, err error) {
+// GetItemsByUserID function for getting items and version of cart.
+func (r *Repository) GetItemsByUserID(ctx context.Context, UID models.UID) (items []models.CartItem, version uint64, err error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartRepository").Start(ctx, "GetItemsByUserID")
 	defer span.End()
@@ -130,7 +185,7 @@ func (r *Repository) GetItemsByUserID(ctx context.Context, UID models.UID) (item
 	defer metrics.LogDBOperation("GetItemsByUserID", start, &err)
 
 	if UID < 1 {
-		return nil, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
+		return nil, 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
 	r.mu.Lock()
@@ -138,7 +193,7 @@ func (r *Repository) GetItemsByUserID(ctx context.Context, UID models.UID) (item
 
 	cart, ok := r.storage[UID]
 	if !ok || len(cart) == 0 {
-		return nil, fmt.Errorf("cart for UID not found in storage: %w", internal_errors.ErrNotFound)
+		return nil, 0, fmt.Errorf("cart for UID not found in storage: %w", internal_errors.ErrNotFound)
 	}
 
 	items = make([]models.CartItem, 0, len(cart))
@@ -150,5 +205,27 @@ func (r *Repository) GetItemsByUserID(ctx context.Context, UID models.UID) (item
 		return items[i].SKU < items[j].SKU
 	})
 
-	return items, nil
+	return items, r.versions[UID], nil
+}
+
+// bumpVersion sets next version of counter to changed cart, empty cart is deleted with its version and has
+// zero version, mutex must be locked.
+func (r *Repository) bumpVersion(UID models.UID) uint64 {
+	if len(r.storage[UID]) == 0 {
+		delete(r.storage, UID)
+		delete(r.versions, UID)
+		return 0
+	}
+
+	r.lastVersion++
+	r.versions[UID] = r.lastVersion
+	return r.lastVersion
+}
+
+// checkVersion returns error if version is not zero and differs from version of cart, mutex must be locked.
+func (r *Repository) checkVersion(UID models.UID, version uint64) error {
+	if version != 0 && version != r.versions[UID] {
+		return fmt.Errorf("cart version %d does not match %d: %w", r.versions[UID], version, internal_errors.ErrPreconditionFailed)
+	}
+	return nil
 }
@@ -20,7 +20,7 @@ func BenchmarkAddItem(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		uid := models.UID(i%numItems + 1)
 		item := models.CartItem{SKU: models.SKU(i + 1), Count: count}
-		err := repo.AddItem(context.Background(), uid, item)
+		_, err := repo.AddItem(context.Background(), uid, item, 0)
 		if err != nil {
 			b.Fatalf("AddItem failed: %v", err)
 		}
@@ -33,13 +33,13 @@ func BenchmarkDeleteItem(b *testing.B) {
 	for i := 0; i < numItems; i++ {
 		uid := models.UID(i + 1)
 		item := models.CartItem{SKU: models.SKU(i + 1), Count: count}
-		_ = repo.AddItem(context.Background(), uid, item)
+		_, _ = repo.AddItem(context.Background(), uid, item, 0)
 	}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		uid := models.UID(i%numItems + 1)
-		err := repo.DeleteItem(context.Background(), uid, models.SKU(i+1))
+		_, err := repo.DeleteItem(context.Background(), uid, models.SKU(i+1), 0)
 		if err != nil {
 			b.Fatalf("DeleteItem failed: %v", err)
 		}
@@ -52,13 +52,13 @@ func BenchmarkGetItemsByUserID(b *testing.B) {
 	for i := 0; i < numItems; i++ {
 		uid := models.UID(i + 1)
 		item := models.CartItem{SKU: models.SKU(i + 1), Count: count}
-		_ = repo.AddItem(context.Background(), uid, item)
+		_, _ = repo.AddItem(context.Background(), uid, item, 0)
 	}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		uid := models.UID(i%numItems + 1)
-		_, err := repo.GetItemsByUserID(context.Background(), uid)
+		_, _, err := repo.GetItemsByUserID(context.Background(), uid)
 		if err != nil && err != internal_errors.ErrNotFound {
 			b.Fatalf("GetItemsByUserID failed: %v", err)
 		}
@@ -71,13 +71,13 @@ func BenchmarkDeleteItemsByUserID(b *testing.B) {
 	for i := 0; i < numItems; i++ {
 		uid := models.UID(i + 1)
 		item := models.CartItem{SKU: models.SKU(i + 1), Count: count}
-		_ = repo.AddItem(context.Background(), uid, item)
+		_, _ = repo.AddItem(context.Background(), uid, item, 0)
 	}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		uid := models.UID(i%numItems + 1)
-		err := repo.DeleteItemsByUserID(context.Background(), uid)
+		_, err := repo.DeleteItemsByUserID(context.Background(), uid, 0)
 		if err != nil {
 			b.Fatalf("DeleteItemsByUserID failed: %v", err)
 		}
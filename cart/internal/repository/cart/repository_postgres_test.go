@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	cart_postgres_repository "route256/cart/internal/repository/cart_postgres"
+	"route256/cart/migrations"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+)
+
+// testDatabaseDSNEnv is env variable with DSN of database for PostgreSQL repository tests,
+// tests of PostgreSQL repository are skipped if it is not set.
+const testDatabaseDSNEnv = "CART_TEST_DATABASE_DSN"
+
+// testSchemaSeq numbers schemas of tests.
+var testSchemaSeq atomic.Int64
+
+// newPostgresRepository creates PostgreSQL repository in new schema, so parallel tests do not share carts.
+func newPostgresRepository(t *testing.T) cartRepository {
+	dsn := os.Getenv(testDatabaseDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDatabaseDSNEnv)
+	}
+
+	ctx := context.Background()
+	schema := fmt.Sprintf("cart_test_%d_%d", os.Getpid(), testSchemaSeq.Add(1))
+
+	// Create schema
+	conn, err := pgx.Connect(ctx, dsn)
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+
+	// Connect to schema
+	cfg, err := pgxpool.ParseConfig(dsn)
+	require.NoError(t, err)
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	require.NoError(t, err)
+
+	db := stdlib.OpenDBFromPool(pool)
+	t.Cleanup(func() {
+		_ = db.Close()
+		pool.Close()
+		_, _ = conn.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE")
+		_ = conn.Close(ctx)
+	})
+
+	// Run migrations
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.EmbedFS)
+	require.NoError(t, err)
+	_, err = provider.Up(ctx)
+	require.NoError(t, err)
+
+	return cart_postgres_repository.NewCartRepository(pool)
+}
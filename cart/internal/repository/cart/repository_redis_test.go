@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"route256/cart/internal/models"
+	cart_redis_repository "route256/cart/internal/repository/cart_redis"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+// testRedisAddrEnv is env variable with address of Redis for Redis repository tests,
+// tests of Redis repository are skipped if it is not set.
+const testRedisAddrEnv = "CART_TEST_REDIS_ADDR"
+
+// testPrefixSeq numbers key prefixes of tests.
+var testPrefixSeq atomic.Int64
+
+// newRedisRepository creates Redis repository with new key prefix, so parallel tests do not share carts.
+func newRedisRepository(t *testing.T) cartRepository {
+	return newRedisRepositoryWithTTL(t, time.Hour)
+}
+
+// newRedisRepositoryWithTTL creates Redis repository which carts expire after ttl.
+func newRedisRepositoryWithTTL(t *testing.T, ttl time.Duration) *cart_redis_repository.Repository {
+	addr := os.Getenv(testRedisAddrEnv)
+	if addr == "" {
+		t.Skipf("%s is not set", testRedisAddrEnv)
+	}
+
+	ctx := context.Background()
+	prefix := fmt.Sprintf("cart_test_%d_%d", os.Getpid(), testPrefixSeq.Add(1))
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	require.NoError(t, client.Ping(ctx).Err())
+	t.Cleanup(func() {
+		keys, _ := client.Keys(ctx, prefix+":*").Result()
+		if len(keys) > 0 {
+			_ = client.Del(ctx, keys...).Err()
+		}
+		_ = client.Close()
+	})
+
+	return cart_redis_repository.NewCartRepository(client, prefix, ttl)
+}
+
+// TestRedisRepository_TotalItems tests count of items is kept on writes and expiration of carts.
+func TestRedisRepository_TotalItems(t *testing.T) {
+	// Run test parallel
+	t.Parallel()
+
+	const ttl = 200 * time.Millisecond
+
+	repo := newRedisRepositoryWithTTL(t, ttl)
+	ctx := context.Background()
+
+	addItems(t, repo, 1, models.CartItem{SKU: 1001, Count: 1}, models.CartItem{SKU: 1002, Count: 1}, models.CartItem{SKU: 1001, Count: 3})
+	addItems(t, repo, 2, models.CartItem{SKU: 1001, Count: 2})
+
+	total, err := repo.TotalItems(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+
+	_, err = repo.DeleteItem(ctx, 1, 1002, 0)
+	require.NoError(t, err)
+	total, err = repo.TotalItems(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+
+	// Cart expires after ttl without writes
+	time.Sleep(2 * ttl)
+	_, _, err = repo.GetItemsByUserID(ctx, 1)
+	require.Error(t, err)
+
+	total, err = repo.Cleanup(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+
+	// Expired cart is started again
+	addItems(t, repo, 1, models.CartItem{SKU: 1003, Count: 1})
+	total, err = repo.TotalItems(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+
+	_, err = repo.DeleteItemsByUserID(ctx, 1, 0)
+	require.NoError(t, err)
+	total, err = repo.TotalItems(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+}
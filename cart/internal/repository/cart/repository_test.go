@@ -1,11 +1,61 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
+	"route256/cart/internal/models"
+
+	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 )
 
 func TestMain(m *testing.M) {
 	goleak.VerifyTestMain(m)
 }
+
+// cartRepository is implemented by every cart repository under test.
+type cartRepository interface {
+	AddItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (uint64, error)
+	SetItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (uint64, error)
+	DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (uint64, error)
+	DeleteItemsByUserID(ctx context.Context, UID models.UID, version uint64) (uint64, error)
+	GetItemsByUserID(ctx context.Context, UID models.UID) ([]models.CartItem, uint64, error)
+}
+
+// implementation of cart repository under test, new creates empty repository.
+type implementation struct {
+	name string
+	new  func(t *testing.T) cartRepository
+}
+
+// implementations returns cart repositories the test suite runs against.
+func implementations() []implementation {
+	return []implementation{
+		{name: "memory", new: func(t *testing.T) cartRepository { return NewCartRepository() }},
+		{name: "postgres", new: newPostgresRepository},
+		{name: "redis", new: newRedisRepository},
+	}
+}
+
+// addItems adds items to cart of UID.
+func addItems(t *testing.T, repo cartRepository, UID models.UID, items ...models.CartItem) {
+	for _, item := range items {
+		_, err := repo.AddItem(context.Background(), UID, item, 0)
+		require.NoError(t, err)
+	}
+}
+
+// findItem returns item of cart of UID by SKU.
+func findItem(t *testing.T, repo cartRepository, UID models.UID, SKU models.SKU) (models.CartItem, bool) {
+	items, _, err := repo.GetItemsByUserID(context.Background(), UID)
+	if err != nil {
+		return models.CartItem{}, false
+	}
+	for _, item := range items {
+		if item.SKU == SKU {
+			return item, true
+		}
+	}
+	return models.CartItem{}, false
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"route256/cart/internal/models"
+	internal_errors "route256/cart/internal/pkg/errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRepository_SetItem function for tests the SetItem method of repository.
+func TestRepository_SetItem(t *testing.T) {
+	// Init test data
+	tests := []struct {
+		name       string
+		UID        models.UID
+		item       models.CartItem
+		version    func(t *testing.T, repo cartRepository) uint64
+		wantErr    error
+		wantExists bool
+	}{
+		{
+			name:       "set new item",
+			UID:        1,
+			item:       models.CartItem{SKU: 1001, Count: 3},
+			version:    func(t *testing.T, repo cartRepository) uint64 { return 0 },
+			wantExists: true,
+		},
+		{
+			name: "override count of item",
+			UID:  1,
+			item: models.CartItem{SKU: 1001, Count: 2},
+			version: func(t *testing.T, repo cartRepository) uint64 {
+				addItems(t, repo, 1, models.CartItem{SKU: 1001, Count: 5})
+				return 0
+			},
+			wantExists: true,
+		},
+		{
+			name: "price of item is kept",
+			UID:  1,
+			item: models.CartItem{SKU: 1001, Count: 2, Price: 350},
+			version: func(t *testing.T, repo cartRepository) uint64 {
+				addItems(t, repo, 1, models.CartItem{SKU: 1001, Count: 5, Price: 300})
+				return 0
+			},
+			wantExists: true,
+		},
+		{
+			name: "zero count deletes item",
+			UID:  1,
+			item: models.CartItem{SKU: 1001, Count: 0},
+			version: func(t *testing.T, repo cartRepository) uint64 {
+				addItems(t, repo, 1, models.CartItem{SKU: 1001, Count: 5}, models.CartItem{SKU: 1002, Count: 1})
+				return 0
+			},
+			wantExists: false,
+		},
+		{
+			name: "matching version",
+			UID:  1,
+			item: models.CartItem{SKU: 1001, Count: 4},
+			version: func(t *testing.T, repo cartRepository) uint64 {
+				version, err := repo.SetItem(context.Background(), 1, models.CartItem{SKU: 1002, Count: 1}, 0)
+				require.NoError(t, err)
+				return version
+			},
+			wantExists: true,
+		},
+		{
+			name: "stale version",
+			UID:  1,
+			item: models.CartItem{SKU: 1001, Count: 4},
+			version: func(t *testing.T, repo cartRepository) uint64 {
+				version, err := repo.SetItem(context.Background(), 1, models.CartItem{SKU: 1002, Count: 1}, 0)
+				require.NoError(t, err)
+				addItems(t, repo, 1, models.CartItem{SKU: 1002, Count: 1})
+				return version
+			},
+			wantErr: internal_errors.ErrPreconditionFailed,
+		},
+		{
+			name:    "invalid UID",
+			UID:     0,
+			item:    models.CartItem{SKU: 1001, Count: 1},
+			version: func(t *testing.T, repo cartRepository) uint64 { return 0 },
+			wantErr: internal_errors.ErrBadRequest,
+		},
+		{
+			name:    "invalid SKU",
+			UID:     1,
+			item:    models.CartItem{SKU: 0, Count: 1},
+			version: func(t *testing.T, repo cartRepository) uint64 { return 0 },
+			wantErr: internal_errors.ErrBadRequest,
+		},
+	}
+
+	for _, impl := range implementations() {
+		for _, tt := range tests {
+			t.Run(impl.name+"/"+tt.name, func(t *testing.T) {
+				// Run test parallel
+				t.Parallel()
+
+				// Init repo
+				repo := impl.new(t)
+
+				// Setup storage
+				version := tt.version(t, repo)
+
+				ctx := context.Background()
+
+				// Run function
+				newVersion, err := repo.SetItem(ctx, tt.UID, tt.item, version)
+
+				// Check want error
+				if tt.wantErr != nil {
+					require.ErrorIs(t, err, tt.wantErr)
+					return
+				}
+				require.NoError(t, err)
+				require.Greater(t, newVersion, version, "version must increase")
+
+				// Check storage
+				item, exists := findItem(t, repo, tt.UID, tt.item.SKU)
+				require.Equal(t, tt.wantExists, exists)
+				if exists {
+					require.Equal(t, tt.item, item)
+				}
+			})
+		}
+	}
+}
+
+// TestRepository_SetItem_Concurrent tests that concurrent calls to SetItem with same version change cart once.
+func TestRepository_SetItem_Concurrent(t *testing.T) {
+	for _, impl := range implementations() {
+		t.Run(impl.name, func(t *testing.T) {
+			// Run test parallel
+			t.Parallel()
+
+			repo := impl.new(t)
+			ctx := context.Background()
+
+			const numGoroutines = 50
+			const UID models.UID = 1
+
+			version, err := repo.SetItem(ctx, UID, models.CartItem{SKU: 1001, Count: 1}, 0)
+			require.NoError(t, err)
+
+			var (
+				wg        sync.WaitGroup
+				mu        sync.Mutex
+				succeeded int
+			)
+			wg.Add(numGoroutines)
+
+			// Concurrently set item with same version
+			for i := 0; i < numGoroutines; i++ {
+				go func(count uint16) {
+					defer wg.Done()
+					_, err := repo.SetItem(ctx, UID, models.CartItem{SKU: 1001, Count: count}, version)
+					if err != nil {
+						require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+						return
+					}
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+				}(uint16(i + 2))
+			}
+
+			wg.Wait()
+
+			require.Equal(t, 1, succeeded, "only one change must succeed")
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"route256/cart/internal/models"
+	internal_errors "route256/cart/internal/pkg/errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRepository_Version tests that every change of cart sets new version of cart.
+func TestRepository_Version(t *testing.T) {
+	for _, impl := range implementations() {
+		t.Run(impl.name, func(t *testing.T) {
+			// Run test parallel
+			t.Parallel()
+
+			repo := impl.new(t)
+			ctx := context.Background()
+
+			const UID models.UID = 1
+
+			// Every change returns next version
+			first, err := repo.AddItem(ctx, UID, models.CartItem{SKU: 1001, Count: 1}, 0)
+			require.NoError(t, err)
+			second, err := repo.AddItem(ctx, UID, models.CartItem{SKU: 1002, Count: 2}, first)
+			require.NoError(t, err)
+			require.Greater(t, second, first)
+
+			_, version, err := repo.GetItemsByUserID(ctx, UID)
+			require.NoError(t, err)
+			require.Equal(t, second, version)
+
+			// Stale version is rejected and cart is not changed
+			_, err = repo.AddItem(ctx, UID, models.CartItem{SKU: 1003, Count: 1}, first)
+			require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+			_, err = repo.DeleteItem(ctx, UID, 1001, first)
+			require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+			_, err = repo.DeleteItemsByUserID(ctx, UID, first)
+			require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+
+			items, version, err := repo.GetItemsByUserID(ctx, UID)
+			require.NoError(t, err)
+			require.Equal(t, second, version)
+			require.Len(t, items, 2)
+
+			// Deleted cart has no version, version of deleted cart is rejected
+			third, err := repo.DeleteItem(ctx, UID, 1001, second)
+			require.NoError(t, err)
+			require.Greater(t, third, second)
+			fourth, err := repo.DeleteItemsByUserID(ctx, UID, third)
+			require.NoError(t, err)
+			require.Zero(t, fourth)
+			_, err = repo.AddItem(ctx, UID, models.CartItem{SKU: 1001, Count: 1}, third)
+			require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+
+			// Version of cart created again does not repeat versions of deleted cart
+			fifth, err := repo.AddItem(ctx, UID, models.CartItem{SKU: 1001, Count: 1}, 0)
+			require.NoError(t, err)
+			require.Greater(t, fifth, third)
+
+			// Cart left empty by last item is deleted with its version
+			sixth, err := repo.DeleteItem(ctx, UID, 1001, fifth)
+			require.NoError(t, err)
+			require.Zero(t, sixth)
+		})
+	}
+}
+
+// TestRepository_VersionForgotten tests that versions of deleted carts are not kept.
+func TestRepository_VersionForgotten(t *testing.T) {
+	// Run test parallel
+	t.Parallel()
+
+	repo := NewCartRepository()
+	ctx := context.Background()
+
+	for UID := models.UID(1); UID <= 10; UID++ {
+		version, err := repo.AddItem(ctx, UID, models.CartItem{SKU: 1001, Count: 1}, 0)
+		require.NoError(t, err)
+		_, err = repo.SetItem(ctx, UID, models.CartItem{SKU: 1001, Count: 0}, version)
+		require.NoError(t, err)
+	}
+
+	require.Empty(t, repo.storage)
+	require.Empty(t, repo.versions)
+}
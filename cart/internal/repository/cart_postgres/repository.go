@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"route256/cart/internal/models"
+	"time"
+
+	internal_errors "route256/cart/internal/pkg/errors"
+	"route256/cart/internal/pkg/metrics"
+	"route256/cart/internal/repository/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+)
+
+// Repository stores carts in PostgreSQL, carts survive restarts and are shared by all cart replicas.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCartRepository creates a new instance of Repository.
+func NewCartRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{
+		pool: pool,
+	}
+}
+
+// AddItem function for adding item to cart, count of item already in cart is increased.
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) AddItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartPostgresRepository").Start(ctx, "AddItem")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("AddItem", start, &err)
+
+	if UID < 1 || item.SKU < 1 || item.Count < 1 {
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	return r.withVersion(ctx, UID, version, func(q sqlc.Querier) error {
+		err := q.AddCartItem(ctx, &sqlc.AddCartItemParams{
+			UserID: UID,
+			Sku:    item.SKU,
+			Count:  int32(item.Count),
+			Price:  int64(item.Price),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add item: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetItem function for setting count of item in cart, item with zero count is deleted from cart.
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) SetItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartPostgresRepository").Start(ctx, "SetItem")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("SetItem", start, &err)
+
+	if UID < 1 || item.SKU < 1 {
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	return r.withVersion(ctx, UID, version, func(q sqlc.Querier) error {
+		if item.Count == 0 {
+			err := q.DeleteCartItem(ctx, &sqlc.DeleteCartItemParams{
+				UserID: UID,
+				Sku:    item.SKU,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete item: %w", err)
+			}
+			return nil
+		}
+
+		err := q.SetCartItem(ctx, &sqlc.SetCartItemParams{
+			UserID: UID,
+			Sku:    item.SKU,
+			Count:  int32(item.Count),
+			Price:  int64(item.Price),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set item: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteItem function for delete item from cart.
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartPostgresRepository").Start(ctx, "DeleteItem")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("DeleteItem", start, &err)
+
+	if UID < 1 || SKU < 1 {
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	return r.withVersion(ctx, UID, version, func(q sqlc.Querier) error {
+		err := q.DeleteCartItem(ctx, &sqlc.DeleteCartItemParams{
+			UserID: UID,
+			Sku:    SKU,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete item: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteItemsByUserID function for delete cart.
+// Cart is deleted only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) DeleteItemsByUserID(ctx context.Context, UID models.UID, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartPostgresRepository").Start(ctx, "DeleteItemsByUserID")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("DeleteItemsByUserID", start, &err)
+
+	if UID < 1 {
+		return 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	return r.withVersion(ctx, UID, version, func(q sqlc.Querier) error {
+		if err := q.DeleteCartItemsByUserID(ctx, UID); err != nil {
+			return fmt.Errorf("failed to delete cart: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetItemsByUserID function for getting items from cart sorted by SKU and version of cart.
+func (r *Repository) GetItemsByUserID(ctx context.Context, UID models.UID) (items []models.CartItem, version uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartPostgresRepository").Start(ctx, "GetItemsByUserID")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("GetItemsByUserID", start, &err)
+
+	if UID < 1 {
+		return nil, 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Items and version are read from one snapshot
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(tx)
+
+	rows, err := q.GetCartItemsByUserID(ctx, UID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get cart: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, 0, fmt.Errorf("cart for UID not found in storage: %w", internal_errors.ErrNotFound)
+	}
+
+	cartVersion, err := q.GetCartVersion(ctx, UID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, fmt.Errorf("failed to get cart version: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	items = make([]models.CartItem, len(rows))
+	for i, row := range rows {
+		items[i] = models.CartItem{
+			SKU:   row.Sku,
+			Count: uint16(row.Count),
+			Price: uint32(row.Price),
+		}
+	}
+
+	return items, uint64(cartVersion), nil
+}
+
+// withVersion runs fn in transaction and sets next value of version sequence to cart, so version of cart
+// created again after deletion never repeats version of deleted cart. Version row of cart is locked until
+// commit, so changes of cart are serialized. fn is not run if version is not zero and differs from version
+// of cart. Version of cart left empty is deleted and zero is returned.
+func (r *Repository) withVersion(ctx context.Context, UID models.UID, version uint64, fn func(q sqlc.Querier) error) (uint64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(tx)
+
+	// Lock version, missing cart has zero version
+	cartVersion, err := q.LockCartVersion(ctx, UID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock cart version: %w", err)
+	}
+	if version != 0 && uint64(cartVersion) != version {
+		return 0, fmt.Errorf("cart version %d does not match %d: %w", cartVersion, version, internal_errors.ErrPreconditionFailed)
+	}
+
+	// Change cart
+	if err := fn(q); err != nil {
+		return 0, err
+	}
+
+	// Set next version or delete version of empty cart
+	newVersion, err := q.BumpCartVersion(ctx, UID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if err := q.DeleteCartVersion(ctx, UID); err != nil {
+			return 0, fmt.Errorf("failed to delete cart version: %w", err)
+		}
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to update cart version: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return uint64(newVersion), nil
+}
@@ -0,0 +1,473 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"route256/cart/internal/models"
+	"sort"
+	"strconv"
+	"time"
+
+	internal_errors "route256/cart/internal/pkg/errors"
+	"route256/cart/internal/pkg/metrics"
+	"route256/utils/logger"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+)
+
+// cleanupBatch is max number of expired carts removed from item count by one cleanup.
+const cleanupBatch = 1000
+
+// Every script changing cart first forgets item count of cart which expired since it was last
+// written, so count of items is kept in step with expired carts without scan of keyspace.
+// Prices of items are kept in separate hash and version of cart in separate key with the same TTL as cart.
+// KEYS: cart, expiry, sizes, total, version counter, prices, version.
+const forgetExpired = `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+    redis.call('DEL', KEYS[6], KEYS[7])
+    local stale = tonumber(redis.call('HGET', KEYS[3], ARGV[1]) or '0')
+    if stale > 0 then
+        redis.call('DECRBY', KEYS[4], stale)
+    end
+    redis.call('HDEL', KEYS[3], ARGV[1])
+    redis.call('ZREM', KEYS[2], ARGV[1])
+end
+`
+
+// checkVersion stops script with -1 and version of cart if expected version, last of ARGV, is not
+// zero and differs from version of cart. Missing cart has zero version.
+const checkVersion = `
+local version = tonumber(redis.call('GET', KEYS[7]) or '0')
+if ARGV[#ARGV] ~= '0' and tonumber(ARGV[#ARGV]) ~= version then
+    return {-1, version}
+end
+`
+
+// bumpVersion sets next value of version counter to changed cart with TTL of cart, so version of cart
+// created again after deletion never repeats version of deleted cart. Version of deleted cart is
+// deleted and zero is returned. Returns total number of items and new version of cart.
+const bumpVersion = `
+if redis.call('EXISTS', KEYS[1]) == 1 then
+    version = redis.call('INCR', KEYS[5])
+    redis.call('SET', KEYS[7], version, 'PX', redis.call('PTTL', KEYS[1]))
+else
+    version = 0
+    redis.call('DEL', KEYS[7])
+end
+return {tonumber(redis.call('GET', KEYS[4]) or '0'), version}
+`
+
+// addItemScript increments count of item and refreshes TTL of cart, returns -2 and version of cart
+// if count exceeds max count.
+// ARGV: UID, SKU, count, TTL in milliseconds, now in milliseconds, max count, price, expected version.
+var addItemScript = redis.NewScript(forgetExpired + checkVersion + `
+local count = redis.call('HINCRBY', KEYS[1], ARGV[2], ARGV[3])
+if count > tonumber(ARGV[6]) then
+    redis.call('HINCRBY', KEYS[1], ARGV[2], -tonumber(ARGV[3]))
+    return {-2, version}
+end
+if count == tonumber(ARGV[3]) then
+    redis.call('HINCRBY', KEYS[3], ARGV[1], 1)
+    redis.call('INCR', KEYS[4])
+end
+redis.call('HSET', KEYS[6], ARGV[2], ARGV[7])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+redis.call('PEXPIRE', KEYS[6], ARGV[4])
+redis.call('ZADD', KEYS[2], ARGV[5] + ARGV[4], ARGV[1])
+` + bumpVersion)
+
+// setItemScript sets count of item, item with zero count is deleted.
+// ARGV: UID, SKU, count, TTL in milliseconds, now in milliseconds, price, expected version.
+var setItemScript = redis.NewScript(forgetExpired + checkVersion + `
+if ARGV[3] == '0' then
+    redis.call('HDEL', KEYS[6], ARGV[2])
+    if redis.call('HDEL', KEYS[1], ARGV[2]) == 1 then
+        redis.call('DECR', KEYS[4])
+        if redis.call('HINCRBY', KEYS[3], ARGV[1], -1) <= 0 then
+            redis.call('HDEL', KEYS[3], ARGV[1])
+        end
+    end
+else
+    redis.call('HSET', KEYS[6], ARGV[2], ARGV[6])
+    if redis.call('HSET', KEYS[1], ARGV[2], ARGV[3]) == 1 then
+        redis.call('HINCRBY', KEYS[3], ARGV[1], 1)
+        redis.call('INCR', KEYS[4])
+    end
+end
+if redis.call('EXISTS', KEYS[1]) == 1 then
+    redis.call('PEXPIRE', KEYS[1], ARGV[4])
+    redis.call('PEXPIRE', KEYS[6], ARGV[4])
+    redis.call('ZADD', KEYS[2], ARGV[5] + ARGV[4], ARGV[1])
+else
+    redis.call('DEL', KEYS[6])
+    redis.call('ZREM', KEYS[2], ARGV[1])
+end
+` + bumpVersion)
+
+// deleteItemScript deletes item and refreshes TTL of cart if cart is not empty.
+// ARGV: UID, SKU, TTL in milliseconds, now in milliseconds, expected version.
+var deleteItemScript = redis.NewScript(forgetExpired + checkVersion + `
+redis.call('HDEL', KEYS[6], ARGV[2])
+if redis.call('HDEL', KEYS[1], ARGV[2]) == 1 then
+    redis.call('DECR', KEYS[4])
+    if redis.call('HINCRBY', KEYS[3], ARGV[1], -1) <= 0 then
+        redis.call('HDEL', KEYS[3], ARGV[1])
+    end
+end
+if redis.call('EXISTS', KEYS[1]) == 1 then
+    redis.call('PEXPIRE', KEYS[1], ARGV[3])
+    redis.call('PEXPIRE', KEYS[6], ARGV[3])
+    redis.call('ZADD', KEYS[2], ARGV[4] + ARGV[3], ARGV[1])
+else
+    redis.call('DEL', KEYS[6])
+    redis.call('ZREM', KEYS[2], ARGV[1])
+end
+` + bumpVersion)
+
+// deleteCartScript deletes cart.
+// ARGV: UID, expected version.
+var deleteCartScript = redis.NewScript(checkVersion + `
+local size = tonumber(redis.call('HGET', KEYS[3], ARGV[1]) or '0')
+if size > 0 then
+    redis.call('DECRBY', KEYS[4], size)
+end
+redis.call('HDEL', KEYS[3], ARGV[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+redis.call('DEL', KEYS[1], KEYS[6])
+` + bumpVersion)
+
+// assignVersionScript sets next value of version counter to cart written before carts had versions,
+// version of cart is kept if cart already has it. Returns version of cart, zero if cart is missing.
+// KEYS: cart, version counter, version.
+var assignVersionScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+    return 0
+end
+local version = redis.call('GET', KEYS[3])
+if version then
+    return tonumber(version)
+end
+version = redis.call('INCR', KEYS[2])
+redis.call('SET', KEYS[3], version, 'PX', redis.call('PTTL', KEYS[1]))
+return version
+`)
+
+// cleanupScript forgets item count of carts expired before now, carts are read by key built
+// from prefix, so cleanup is run against single Redis only.
+// KEYS: expiry, sizes, total. ARGV: now in milliseconds, batch, prefix of cart keys.
+var cleanupScript = redis.NewScript(`
+local uids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, uid in ipairs(uids) do
+    local ttl = redis.call('PTTL', ARGV[3] .. uid)
+    if ttl > 0 then
+        redis.call('ZADD', KEYS[1], ARGV[1] + ttl, uid)
+    else
+        local stale = tonumber(redis.call('HGET', KEYS[2], uid) or '0')
+        if stale > 0 then
+            redis.call('DECRBY', KEYS[3], stale)
+        end
+        redis.call('HDEL', KEYS[2], uid)
+        redis.call('ZREM', KEYS[1], uid)
+    end
+end
+return tonumber(redis.call('GET', KEYS[3]) or '0')
+`)
+
+// Repository stores each cart as Redis hash of SKU to count, carts not written for ttl expire.
+type Repository struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewCartRepository creates a new instance of Repository, keys of repository start with prefix.
+func NewCartRepository(client *redis.Client, prefix string, ttl time.Duration) *Repository {
+	return &Repository{
+		client: client,
+		prefix: prefix,
+		ttl:    ttl,
+	}
+}
+
+// TotalItems returns total number of items in the repository.
+func (r *Repository) TotalItems(ctx context.Context) (int64, error) {
+	total, err := r.client.Get(ctx, r.totalKey()).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return total, err
+}
+
+// AddItem function for adding item to cart, count of item already in cart is increased.
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) AddItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartRedisRepository").Start(ctx, "AddItem")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("AddItem", start, &err)
+
+	if UID < 1 || item.SKU < 1 || item.Count < 1 {
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	res, err := addItemScript.Run(ctx, r.client, r.keys(UID),
+		UID, item.SKU, item.Count, r.ttl.Milliseconds(), time.Now().UnixMilli(), math.MaxUint16, item.Price, version,
+	).Int64Slice()
+	if err != nil {
+		return 0, fmt.Errorf("failed to add item: %w", err)
+	}
+	if len(res) == 2 && res[0] == -2 {
+		return 0, fmt.Errorf("count of SKU %d exceeds %d: %w", item.SKU, math.MaxUint16, internal_errors.ErrBadRequest)
+	}
+
+	return r.changed(res, version)
+}
+
+// SetItem function for setting count of item in cart, item with zero count is deleted from cart.
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) SetItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartRedisRepository").Start(ctx, "SetItem")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("SetItem", start, &err)
+
+	if UID < 1 || item.SKU < 1 {
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	res, err := setItemScript.Run(ctx, r.client, r.keys(UID),
+		UID, item.SKU, item.Count, r.ttl.Milliseconds(), time.Now().UnixMilli(), item.Price, version,
+	).Int64Slice()
+	if err != nil {
+		return 0, fmt.Errorf("failed to set item: %w", err)
+	}
+
+	return r.changed(res, version)
+}
+
+// DeleteItem function for delete item from cart.
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartRedisRepository").Start(ctx, "DeleteItem")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("DeleteItem", start, &err)
+
+	if UID < 1 || SKU < 1 {
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	res, err := deleteItemScript.Run(ctx, r.client, r.keys(UID),
+		UID, SKU, r.ttl.Milliseconds(), time.Now().UnixMilli(), version,
+	).Int64Slice()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete item: %w", err)
+	}
+
+	return r.changed(res, version)
+}
+
+// DeleteItemsByUserID function for delete cart.
+// Cart is deleted only if version is zero or equals version of cart, new version of cart is returned.
+func (r *Repository) DeleteItemsByUserID(ctx context.Context, UID models.UID, version uint64) (newVersion uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartRedisRepository").Start(ctx, "DeleteItemsByUserID")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("DeleteItemsByUserID", start, &err)
+
+	if UID < 1 {
+		return 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	res, err := deleteCartScript.Run(ctx, r.client, r.keys(UID), UID, version).Int64Slice()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete cart: %w", err)
+	}
+
+	return r.changed(res, version)
+}
+
+// GetItemsByUserID function for getting items from cart sorted by SKU and version of cart.
+func (r *Repository) GetItemsByUserID(ctx context.Context, UID models.UID) (items []models.CartItem, version uint64, err error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartRedisRepository").Start(ctx, "GetItemsByUserID")
+	defer span.End()
+
+	// Start time for metrics
+	start := time.Now()
+	defer metrics.LogDBOperation("GetItemsByUserID", start, &err)
+
+	if UID < 1 {
+		return nil, 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Items, prices and version are read in one transaction
+	var (
+		cartCmd    *redis.StringStringMapCmd
+		pricesCmd  *redis.StringStringMapCmd
+		versionCmd *redis.StringCmd
+	)
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		cartCmd = pipe.HGetAll(ctx, r.cartKey(UID))
+		pricesCmd = pipe.HGetAll(ctx, r.pricesKey(UID))
+		versionCmd = pipe.Get(ctx, r.versionKey(UID))
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, 0, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	cart := cartCmd.Val()
+	if len(cart) == 0 {
+		return nil, 0, fmt.Errorf("cart for UID not found in storage: %w", internal_errors.ErrNotFound)
+	}
+
+	version, err = versionCmd.Uint64()
+	if err == redis.Nil {
+		// Cart written before carts had versions gets version on first read
+		version, err = r.assignVersion(ctx, UID)
+		if err != nil {
+			return nil, 0, err
+		}
+	} else if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse cart version: %w", err)
+	}
+
+	prices := pricesCmd.Val()
+
+	items = make([]models.CartItem, 0, len(cart))
+	for field, value := range cart {
+		SKU, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to parse SKU %q: %w", field, err)
+		}
+		count, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to parse count of SKU %d: %w", SKU, err)
+		}
+		var price uint64
+		if rawPrice, ok := prices[field]; ok {
+			price, err = strconv.ParseUint(rawPrice, 10, 32)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to parse price of SKU %d: %w", SKU, err)
+			}
+		}
+		items = append(items, models.CartItem{SKU: SKU, Count: uint16(count), Price: uint32(price)})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].SKU < items[j].SKU
+	})
+
+	return items, version, nil
+}
+
+// RunCleanup forgets items of expired carts every interval until ctx is done.
+func (r *Repository) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := r.Cleanup(ctx); err != nil && ctx.Err() == nil {
+				logger.Errorw(ctx, "Failed to clean up expired carts", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Cleanup forgets items of carts expired before now, returns total number of items.
+func (r *Repository) Cleanup(ctx context.Context) (int64, error) {
+	keys := []string{r.expiryKey(), r.sizesKey(), r.totalKey()}
+	total, err := cleanupScript.Run(ctx, r.client, keys,
+		time.Now().UnixMilli(), cleanupBatch, r.prefix+":",
+	).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to clean up expired carts: %w", err)
+	}
+	metrics.SetRedisItemsTotal(total)
+
+	return total, nil
+}
+
+// assignVersion sets version to cart without version and returns version of cart.
+func (r *Repository) assignVersion(ctx context.Context, UID models.UID) (uint64, error) {
+	keys := []string{r.cartKey(UID), r.versionSeqKey(), r.versionKey(UID)}
+	version, err := assignVersionScript.Run(ctx, r.client, keys).Uint64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to assign cart version: %w", err)
+	}
+	return version, nil
+}
+
+// changed checks result of script changing cart, sets total number of items and returns new version of cart.
+func (r *Repository) changed(res []int64, version uint64) (uint64, error) {
+	if len(res) != 2 {
+		return 0, fmt.Errorf("unexpected result of script: %v", res)
+	}
+
+	total, current := res[0], res[1]
+	if total < 0 {
+		return 0, fmt.Errorf("cart version %d does not match %d: %w", current, version, internal_errors.ErrPreconditionFailed)
+	}
+	metrics.SetRedisItemsTotal(total)
+
+	return uint64(current), nil
+}
+
+// keys returns keys of scripts for cart of UID.
+func (r *Repository) keys(UID models.UID) []string {
+	return []string{r.cartKey(UID), r.expiryKey(), r.sizesKey(), r.totalKey(), r.versionSeqKey(), r.pricesKey(UID), r.versionKey(UID)}
+}
+
+// cartKey returns key of cart hash of UID.
+func (r *Repository) cartKey(UID models.UID) string {
+	return r.prefix + ":" + strconv.FormatInt(UID, 10)
+}
+
+// expiryKey returns key of sorted set of UIDs by expiration time of their carts.
+func (r *Repository) expiryKey() string {
+	return r.prefix + ":expiry"
+}
+
+// sizesKey returns key of hash of UIDs to number of items in their carts.
+func (r *Repository) sizesKey() string {
+	return r.prefix + ":sizes"
+}
+
+// totalKey returns key of total number of items.
+func (r *Repository) totalKey() string {
+	return r.prefix + ":total"
+}
+
+// versionSeqKey returns key of counter versions of carts are taken from.
+func (r *Repository) versionSeqKey() string {
+	return r.prefix + ":version_seq"
+}
+
+// pricesKey returns key of hash of SKUs to prices of items in cart of UID.
+func (r *Repository) pricesKey(UID models.UID) string {
+	return r.cartKey(UID) + ":prices"
+}
+
+// versionKey returns key of version of cart of UID.
+func (r *Repository) versionKey(UID models.UID) string {
+	return r.cartKey(UID) + ":version"
+}
@@ -0,0 +1,25 @@
+-- name: AddCartItem :exec
+INSERT INTO cart_items (user_id, sku, count, price)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, sku) DO UPDATE
+SET count = cart_items.count + EXCLUDED.count, price = EXCLUDED.price, updated_at = now();
+
+-- name: DeleteCartItem :exec
+DELETE FROM cart_items
+WHERE user_id = $1 AND sku = $2;
+
+-- name: DeleteCartItemsByUserID :exec
+DELETE FROM cart_items
+WHERE user_id = $1;
+
+-- name: GetCartItemsByUserID :many
+SELECT user_id, sku, count, updated_at, price
+FROM cart_items
+WHERE user_id = $1
+ORDER BY sku;
+
+-- name: SetCartItem :exec
+INSERT INTO cart_items (user_id, sku, count, price)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, sku) DO UPDATE
+SET count = EXCLUDED.count, price = EXCLUDED.price, updated_at = now();
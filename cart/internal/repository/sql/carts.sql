@@ -0,0 +1,21 @@
+-- name: BumpCartVersion :one
+UPDATE carts
+SET version = nextval('cart_version_seq')
+WHERE user_id = $1 AND EXISTS (SELECT 1 FROM cart_items WHERE cart_items.user_id = $1)
+RETURNING version;
+
+-- name: DeleteCartVersion :exec
+DELETE FROM carts
+WHERE user_id = $1;
+
+-- name: GetCartVersion :one
+SELECT version
+FROM carts
+WHERE user_id = $1;
+
+-- name: LockCartVersion :one
+INSERT INTO carts (user_id, version)
+VALUES ($1, 0)
+ON CONFLICT (user_id) DO UPDATE
+SET version = carts.version
+RETURNING version;
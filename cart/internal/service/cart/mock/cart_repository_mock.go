@@ -19,33 +19,40 @@ type ICartRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcAddItem          func(ctx context.Context, UID models.UID, item models.CartItem) (err error)
+	funcAddItem          func(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (u1 uint64, err error)
 	funcAddItemOrigin    string
-	inspectFuncAddItem   func(ctx context.Context, UID models.UID, item models.CartItem)
+	inspectFuncAddItem   func(ctx context.Context, UID models.UID, item models.CartItem, version uint64)
 	afterAddItemCounter  uint64
 	beforeAddItemCounter uint64
 	AddItemMock          mICartRepositoryMockAddItem
 
-	funcDeleteItem          func(ctx context.Context, UID models.UID, SKU models.SKU) (err error)
+	funcDeleteItem          func(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (u1 uint64, err error)
 	funcDeleteItemOrigin    string
-	inspectFuncDeleteItem   func(ctx context.Context, UID models.UID, SKU models.SKU)
+	inspectFuncDeleteItem   func(ctx context.Context, UID models.UID, SKU models.SKU, version uint64)
 	afterDeleteItemCounter  uint64
 	beforeDeleteItemCounter uint64
 	DeleteItemMock          mICartRepositoryMockDeleteItem
 
-	funcDeleteItemsByUserID          func(ctx context.Context, UID models.UID) (err error)
+	funcDeleteItemsByUserID          func(ctx context.Context, UID models.UID, version uint64) (u1 uint64, err error)
 	funcDeleteItemsByUserIDOrigin    string
-	inspectFuncDeleteItemsByUserID   func(ctx context.Context, UID models.UID)
+	inspectFuncDeleteItemsByUserID   func(ctx context.Context, UID models.UID, version uint64)
 	afterDeleteItemsByUserIDCounter  uint64
 	beforeDeleteItemsByUserIDCounter uint64
 	DeleteItemsByUserIDMock          mICartRepositoryMockDeleteItemsByUserID
 
-	funcGetItemsByUserID          func(ctx context.Context, UID models.UID) (ca1 []models.CartItem, err error)
+	funcGetItemsByUserID          func(ctx context.Context, UID models.UID) (ca1 []models.CartItem, u1 uint64, err error)
 	funcGetItemsByUserIDOrigin    string
 	inspectFuncGetItemsByUserID   func(ctx context.Context, UID models.UID)
 	afterGetItemsByUserIDCounter  uint64
 	beforeGetItemsByUserIDCounter uint64
 	GetItemsByUserIDMock          mICartRepositoryMockGetItemsByUserID
+
+	funcSetItem          func(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (u1 uint64, err error)
+	funcSetItemOrigin    string
+	inspectFuncSetItem   func(ctx context.Context, UID models.UID, item models.CartItem, version uint64)
+	afterSetItemCounter  uint64
+	beforeSetItemCounter uint64
+	SetItemMock          mICartRepositoryMockSetItem
 }
 
 // NewICartRepositoryMock returns a mock for mm_service.ICartRepository
@@ -68,6 +75,9 @@ func NewICartRepositoryMock(t minimock.Tester) *ICartRepositoryMock {
 	m.GetItemsByUserIDMock = mICartRepositoryMockGetItemsByUserID{mock: m}
 	m.GetItemsByUserIDMock.callArgs = []*ICartRepositoryMockGetItemsByUserIDParams{}
 
+	m.SetItemMock = mICartRepositoryMockSetItem{mock: m}
+	m.SetItemMock.callArgs = []*ICartRepositoryMockSetItemParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
@@ -99,29 +109,33 @@ type ICartRepositoryMockAddItemExpectation struct {
 
 // ICartRepositoryMockAddItemParams contains parameters of the ICartRepository.AddItem
 type ICartRepositoryMockAddItemParams struct {
-	ctx  context.Context
-	UID  models.UID
-	item models.CartItem
+	ctx     context.Context
+	UID     models.UID
+	item    models.CartItem
+	version uint64
 }
 
 // ICartRepositoryMockAddItemParamPtrs contains pointers to parameters of the ICartRepository.AddItem
 type ICartRepositoryMockAddItemParamPtrs struct {
-	ctx  *context.Context
-	UID  *models.UID
-	item *models.CartItem
+	ctx     *context.Context
+	UID     *models.UID
+	item    *models.CartItem
+	version *uint64
 }
 
 // ICartRepositoryMockAddItemResults contains results of the ICartRepository.AddItem
 type ICartRepositoryMockAddItemResults struct {
+	u1  uint64
 	err error
 }
 
 // ICartRepositoryMockAddItemOrigins contains origins of expectations of the ICartRepository.AddItem
 type ICartRepositoryMockAddItemExpectationOrigins struct {
-	origin     string
-	originCtx  string
-	originUID  string
-	originItem string
+	origin        string
+	originCtx     string
+	originUID     string
+	originItem    string
+	originVersion string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -135,7 +149,7 @@ func (mmAddItem *mICartRepositoryMockAddItem) Optional() *mICartRepositoryMockAd
 }
 
 // Expect sets up expected params for ICartRepository.AddItem
-func (mmAddItem *mICartRepositoryMockAddItem) Expect(ctx context.Context, UID models.UID, item models.CartItem) *mICartRepositoryMockAddItem {
+func (mmAddItem *mICartRepositoryMockAddItem) Expect(ctx context.Context, UID models.UID, item models.CartItem, version uint64) *mICartRepositoryMockAddItem {
 	if mmAddItem.mock.funcAddItem != nil {
 		mmAddItem.mock.t.Fatalf("ICartRepositoryMock.AddItem mock is already set by Set")
 	}
@@ -148,7 +162,7 @@ func (mmAddItem *mICartRepositoryMockAddItem) Expect(ctx context.Context, UID mo
 		mmAddItem.mock.t.Fatalf("ICartRepositoryMock.AddItem mock is already set by ExpectParams functions")
 	}
 
-	mmAddItem.defaultExpectation.params = &ICartRepositoryMockAddItemParams{ctx, UID, item}
+	mmAddItem.defaultExpectation.params = &ICartRepositoryMockAddItemParams{ctx, UID, item, version}
 	mmAddItem.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmAddItem.expectations {
 		if minimock.Equal(e.params, mmAddItem.defaultExpectation.params) {
@@ -228,8 +242,31 @@ func (mmAddItem *mICartRepositoryMockAddItem) ExpectItemParam3(item models.CartI
 	return mmAddItem
 }
 
+// ExpectVersionParam4 sets up expected param version for ICartRepository.AddItem
+func (mmAddItem *mICartRepositoryMockAddItem) ExpectVersionParam4(version uint64) *mICartRepositoryMockAddItem {
+	if mmAddItem.mock.funcAddItem != nil {
+		mmAddItem.mock.t.Fatalf("ICartRepositoryMock.AddItem mock is already set by Set")
+	}
+
+	if mmAddItem.defaultExpectation == nil {
+		mmAddItem.defaultExpectation = &ICartRepositoryMockAddItemExpectation{}
+	}
+
+	if mmAddItem.defaultExpectation.params != nil {
+		mmAddItem.mock.t.Fatalf("ICartRepositoryMock.AddItem mock is already set by Expect")
+	}
+
+	if mmAddItem.defaultExpectation.paramPtrs == nil {
+		mmAddItem.defaultExpectation.paramPtrs = &ICartRepositoryMockAddItemParamPtrs{}
+	}
+	mmAddItem.defaultExpectation.paramPtrs.version = &version
+	mmAddItem.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmAddItem
+}
+
 // Inspect accepts an inspector function that has same arguments as the ICartRepository.AddItem
-func (mmAddItem *mICartRepositoryMockAddItem) Inspect(f func(ctx context.Context, UID models.UID, item models.CartItem)) *mICartRepositoryMockAddItem {
+func (mmAddItem *mICartRepositoryMockAddItem) Inspect(f func(ctx context.Context, UID models.UID, item models.CartItem, version uint64)) *mICartRepositoryMockAddItem {
 	if mmAddItem.mock.inspectFuncAddItem != nil {
 		mmAddItem.mock.t.Fatalf("Inspect function is already set for ICartRepositoryMock.AddItem")
 	}
@@ -240,7 +277,7 @@ func (mmAddItem *mICartRepositoryMockAddItem) Inspect(f func(ctx context.Context
 }
 
 // Return sets up results that will be returned by ICartRepository.AddItem
-func (mmAddItem *mICartRepositoryMockAddItem) Return(err error) *ICartRepositoryMock {
+func (mmAddItem *mICartRepositoryMockAddItem) Return(u1 uint64, err error) *ICartRepositoryMock {
 	if mmAddItem.mock.funcAddItem != nil {
 		mmAddItem.mock.t.Fatalf("ICartRepositoryMock.AddItem mock is already set by Set")
 	}
@@ -248,13 +285,13 @@ func (mmAddItem *mICartRepositoryMockAddItem) Return(err error) *ICartRepository
 	if mmAddItem.defaultExpectation == nil {
 		mmAddItem.defaultExpectation = &ICartRepositoryMockAddItemExpectation{mock: mmAddItem.mock}
 	}
-	mmAddItem.defaultExpectation.results = &ICartRepositoryMockAddItemResults{err}
+	mmAddItem.defaultExpectation.results = &ICartRepositoryMockAddItemResults{u1, err}
 	mmAddItem.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
 	return mmAddItem.mock
 }
 
 // Set uses given function f to mock the ICartRepository.AddItem method
-func (mmAddItem *mICartRepositoryMockAddItem) Set(f func(ctx context.Context, UID models.UID, item models.CartItem) (err error)) *ICartRepositoryMock {
+func (mmAddItem *mICartRepositoryMockAddItem) Set(f func(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (u1 uint64, err error)) *ICartRepositoryMock {
 	if mmAddItem.defaultExpectation != nil {
 		mmAddItem.mock.t.Fatalf("Default expectation is already set for the ICartRepository.AddItem method")
 	}
@@ -270,14 +307,14 @@ func (mmAddItem *mICartRepositoryMockAddItem) Set(f func(ctx context.Context, UI
 
 // When sets expectation for the ICartRepository.AddItem which will trigger the result defined by the following
 // Then helper
-func (mmAddItem *mICartRepositoryMockAddItem) When(ctx context.Context, UID models.UID, item models.CartItem) *ICartRepositoryMockAddItemExpectation {
+func (mmAddItem *mICartRepositoryMockAddItem) When(ctx context.Context, UID models.UID, item models.CartItem, version uint64) *ICartRepositoryMockAddItemExpectation {
 	if mmAddItem.mock.funcAddItem != nil {
 		mmAddItem.mock.t.Fatalf("ICartRepositoryMock.AddItem mock is already set by Set")
 	}
 
 	expectation := &ICartRepositoryMockAddItemExpectation{
 		mock:               mmAddItem.mock,
-		params:             &ICartRepositoryMockAddItemParams{ctx, UID, item},
+		params:             &ICartRepositoryMockAddItemParams{ctx, UID, item, version},
 		expectationOrigins: ICartRepositoryMockAddItemExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmAddItem.expectations = append(mmAddItem.expectations, expectation)
@@ -285,8 +322,8 @@ func (mmAddItem *mICartRepositoryMockAddItem) When(ctx context.Context, UID mode
 }
 
 // Then sets up ICartRepository.AddItem return parameters for the expectation previously defined by the When method
-func (e *ICartRepositoryMockAddItemExpectation) Then(err error) *ICartRepositoryMock {
-	e.results = &ICartRepositoryMockAddItemResults{err}
+func (e *ICartRepositoryMockAddItemExpectation) Then(u1 uint64, err error) *ICartRepositoryMock {
+	e.results = &ICartRepositoryMockAddItemResults{u1, err}
 	return e.mock
 }
 
@@ -312,17 +349,17 @@ func (mmAddItem *mICartRepositoryMockAddItem) invocationsDone() bool {
 }
 
 // AddItem implements mm_service.ICartRepository
-func (mmAddItem *ICartRepositoryMock) AddItem(ctx context.Context, UID models.UID, item models.CartItem) (err error) {
+func (mmAddItem *ICartRepositoryMock) AddItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (u1 uint64, err error) {
 	mm_atomic.AddUint64(&mmAddItem.beforeAddItemCounter, 1)
 	defer mm_atomic.AddUint64(&mmAddItem.afterAddItemCounter, 1)
 
 	mmAddItem.t.Helper()
 
 	if mmAddItem.inspectFuncAddItem != nil {
-		mmAddItem.inspectFuncAddItem(ctx, UID, item)
+		mmAddItem.inspectFuncAddItem(ctx, UID, item, version)
 	}
 
-	mm_params := ICartRepositoryMockAddItemParams{ctx, UID, item}
+	mm_params := ICartRepositoryMockAddItemParams{ctx, UID, item, version}
 
 	// Record call args
 	mmAddItem.AddItemMock.mutex.Lock()
@@ -332,7 +369,7 @@ func (mmAddItem *ICartRepositoryMock) AddItem(ctx context.Context, UID models.UI
 	for _, e := range mmAddItem.AddItemMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.u1, e.results.err
 		}
 	}
 
@@ -341,7 +378,7 @@ func (mmAddItem *ICartRepositoryMock) AddItem(ctx context.Context, UID models.UI
 		mm_want := mmAddItem.AddItemMock.defaultExpectation.params
 		mm_want_ptrs := mmAddItem.AddItemMock.defaultExpectation.paramPtrs
 
-		mm_got := ICartRepositoryMockAddItemParams{ctx, UID, item}
+		mm_got := ICartRepositoryMockAddItemParams{ctx, UID, item, version}
 
 		if mm_want_ptrs != nil {
 
@@ -360,6 +397,11 @@ func (mmAddItem *ICartRepositoryMock) AddItem(ctx context.Context, UID models.UI
 					mmAddItem.AddItemMock.defaultExpectation.expectationOrigins.originItem, *mm_want_ptrs.item, mm_got.item, minimock.Diff(*mm_want_ptrs.item, mm_got.item))
 			}
 
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmAddItem.t.Errorf("ICartRepositoryMock.AddItem got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmAddItem.AddItemMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmAddItem.t.Errorf("ICartRepositoryMock.AddItem got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 				mmAddItem.AddItemMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
@@ -369,12 +411,12 @@ func (mmAddItem *ICartRepositoryMock) AddItem(ctx context.Context, UID models.UI
 		if mm_results == nil {
 			mmAddItem.t.Fatal("No results are set for the ICartRepositoryMock.AddItem")
 		}
-		return (*mm_results).err
+		return (*mm_results).u1, (*mm_results).err
 	}
 	if mmAddItem.funcAddItem != nil {
-		return mmAddItem.funcAddItem(ctx, UID, item)
+		return mmAddItem.funcAddItem(ctx, UID, item, version)
 	}
-	mmAddItem.t.Fatalf("Unexpected call to ICartRepositoryMock.AddItem. %v %v %v", ctx, UID, item)
+	mmAddItem.t.Fatalf("Unexpected call to ICartRepositoryMock.AddItem. %v %v %v %v", ctx, UID, item, version)
 	return
 }
 
@@ -472,29 +514,33 @@ type ICartRepositoryMockDeleteItemExpectation struct {
 
 // ICartRepositoryMockDeleteItemParams contains parameters of the ICartRepository.DeleteItem
 type ICartRepositoryMockDeleteItemParams struct {
-	ctx context.Context
-	UID models.UID
-	SKU models.SKU
+	ctx     context.Context
+	UID     models.UID
+	SKU     models.SKU
+	version uint64
 }
 
 // ICartRepositoryMockDeleteItemParamPtrs contains pointers to parameters of the ICartRepository.DeleteItem
 type ICartRepositoryMockDeleteItemParamPtrs struct {
-	ctx *context.Context
-	UID *models.UID
-	SKU *models.SKU
+	ctx     *context.Context
+	UID     *models.UID
+	SKU     *models.SKU
+	version *uint64
 }
 
 // ICartRepositoryMockDeleteItemResults contains results of the ICartRepository.DeleteItem
 type ICartRepositoryMockDeleteItemResults struct {
+	u1  uint64
 	err error
 }
 
 // ICartRepositoryMockDeleteItemOrigins contains origins of expectations of the ICartRepository.DeleteItem
 type ICartRepositoryMockDeleteItemExpectationOrigins struct {
-	origin    string
-	originCtx string
-	originUID string
-	originSKU string
+	origin        string
+	originCtx     string
+	originUID     string
+	originSKU     string
+	originVersion string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -508,7 +554,7 @@ func (mmDeleteItem *mICartRepositoryMockDeleteItem) Optional() *mICartRepository
 }
 
 // Expect sets up expected params for ICartRepository.DeleteItem
-func (mmDeleteItem *mICartRepositoryMockDeleteItem) Expect(ctx context.Context, UID models.UID, SKU models.SKU) *mICartRepositoryMockDeleteItem {
+func (mmDeleteItem *mICartRepositoryMockDeleteItem) Expect(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) *mICartRepositoryMockDeleteItem {
 	if mmDeleteItem.mock.funcDeleteItem != nil {
 		mmDeleteItem.mock.t.Fatalf("ICartRepositoryMock.DeleteItem mock is already set by Set")
 	}
@@ -521,7 +567,7 @@ func (mmDeleteItem *mICartRepositoryMockDeleteItem) Expect(ctx context.Context,
 		mmDeleteItem.mock.t.Fatalf("ICartRepositoryMock.DeleteItem mock is already set by ExpectParams functions")
 	}
 
-	mmDeleteItem.defaultExpectation.params = &ICartRepositoryMockDeleteItemParams{ctx, UID, SKU}
+	mmDeleteItem.defaultExpectation.params = &ICartRepositoryMockDeleteItemParams{ctx, UID, SKU, version}
 	mmDeleteItem.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmDeleteItem.expectations {
 		if minimock.Equal(e.params, mmDeleteItem.defaultExpectation.params) {
@@ -601,8 +647,31 @@ func (mmDeleteItem *mICartRepositoryMockDeleteItem) ExpectSKUParam3(SKU models.S
 	return mmDeleteItem
 }
 
+// ExpectVersionParam4 sets up expected param version for ICartRepository.DeleteItem
+func (mmDeleteItem *mICartRepositoryMockDeleteItem) ExpectVersionParam4(version uint64) *mICartRepositoryMockDeleteItem {
+	if mmDeleteItem.mock.funcDeleteItem != nil {
+		mmDeleteItem.mock.t.Fatalf("ICartRepositoryMock.DeleteItem mock is already set by Set")
+	}
+
+	if mmDeleteItem.defaultExpectation == nil {
+		mmDeleteItem.defaultExpectation = &ICartRepositoryMockDeleteItemExpectation{}
+	}
+
+	if mmDeleteItem.defaultExpectation.params != nil {
+		mmDeleteItem.mock.t.Fatalf("ICartRepositoryMock.DeleteItem mock is already set by Expect")
+	}
+
+	if mmDeleteItem.defaultExpectation.paramPtrs == nil {
+		mmDeleteItem.defaultExpectation.paramPtrs = &ICartRepositoryMockDeleteItemParamPtrs{}
+	}
+	mmDeleteItem.defaultExpectation.paramPtrs.version = &version
+	mmDeleteItem.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmDeleteItem
+}
+
 // Inspect accepts an inspector function that has same arguments as the ICartRepository.DeleteItem
-func (mmDeleteItem *mICartRepositoryMockDeleteItem) Inspect(f func(ctx context.Context, UID models.UID, SKU models.SKU)) *mICartRepositoryMockDeleteItem {
+func (mmDeleteItem *mICartRepositoryMockDeleteItem) Inspect(f func(ctx context.Context, UID models.UID, SKU models.SKU, version uint64)) *mICartRepositoryMockDeleteItem {
 	if mmDeleteItem.mock.inspectFuncDeleteItem != nil {
 		mmDeleteItem.mock.t.Fatalf("Inspect function is already set for ICartRepositoryMock.DeleteItem")
 	}
@@ -613,7 +682,7 @@ func (mmDeleteItem *mICartRepositoryMockDeleteItem) Inspect(f func(ctx context.C
 }
 
 // Return sets up results that will be returned by ICartRepository.DeleteItem
-func (mmDeleteItem *mICartRepositoryMockDeleteItem) Return(err error) *ICartRepositoryMock {
+func (mmDeleteItem *mICartRepositoryMockDeleteItem) Return(u1 uint64, err error) *ICartRepositoryMock {
 	if mmDeleteItem.mock.funcDeleteItem != nil {
 		mmDeleteItem.mock.t.Fatalf("ICartRepositoryMock.DeleteItem mock is already set by Set")
 	}
@@ -621,13 +690,13 @@ func (mmDeleteItem *mICartRepositoryMockDeleteItem) Return(err error) *ICartRepo
 	if mmDeleteItem.defaultExpectation == nil {
 		mmDeleteItem.defaultExpectation = &ICartRepositoryMockDeleteItemExpectation{mock: mmDeleteItem.mock}
 	}
-	mmDeleteItem.defaultExpectation.results = &ICartRepositoryMockDeleteItemResults{err}
+	mmDeleteItem.defaultExpectation.results = &ICartRepositoryMockDeleteItemResults{u1, err}
 	mmDeleteItem.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
 	return mmDeleteItem.mock
 }
 
 // Set uses given function f to mock the ICartRepository.DeleteItem method
-func (mmDeleteItem *mICartRepositoryMockDeleteItem) Set(f func(ctx context.Context, UID models.UID, SKU models.SKU) (err error)) *ICartRepositoryMock {
+func (mmDeleteItem *mICartRepositoryMockDeleteItem) Set(f func(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (u1 uint64, err error)) *ICartRepositoryMock {
 	if mmDeleteItem.defaultExpectation != nil {
 		mmDeleteItem.mock.t.Fatalf("Default expectation is already set for the ICartRepository.DeleteItem method")
 	}
@@ -643,14 +712,14 @@ func (mmDeleteItem *mICartRepositoryMockDeleteItem) Set(f func(ctx context.Conte
 
 // When sets expectation for the ICartRepository.DeleteItem which will trigger the result defined by the following
 // Then helper
-func (mmDeleteItem *mICartRepositoryMockDeleteItem) When(ctx context.Context, UID models.UID, SKU models.SKU) *ICartRepositoryMockDeleteItemExpectation {
+func (mmDeleteItem *mICartRepositoryMockDeleteItem) When(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) *ICartRepositoryMockDeleteItemExpectation {
 	if mmDeleteItem.mock.funcDeleteItem != nil {
 		mmDeleteItem.mock.t.Fatalf("ICartRepositoryMock.DeleteItem mock is already set by Set")
 	}
 
 	expectation := &ICartRepositoryMockDeleteItemExpectation{
 		mock:               mmDeleteItem.mock,
-		params:             &ICartRepositoryMockDeleteItemParams{ctx, UID, SKU},
+		params:             &ICartRepositoryMockDeleteItemParams{ctx, UID, SKU, version},
 		expectationOrigins: ICartRepositoryMockDeleteItemExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmDeleteItem.expectations = append(mmDeleteItem.expectations, expectation)
@@ -658,8 +727,8 @@ func (mmDeleteItem *mICartRepositoryMockDeleteItem) When(ctx context.Context, UI
 }
 
 // Then sets up ICartRepository.DeleteItem return parameters for the expectation previously defined by the When method
-func (e *ICartRepositoryMockDeleteItemExpectation) Then(err error) *ICartRepositoryMock {
-	e.results = &ICartRepositoryMockDeleteItemResults{err}
+func (e *ICartRepositoryMockDeleteItemExpectation) Then(u1 uint64, err error) *ICartRepositoryMock {
+	e.results = &ICartRepositoryMockDeleteItemResults{u1, err}
 	return e.mock
 }
 
@@ -685,17 +754,17 @@ func (mmDeleteItem *mICartRepositoryMockDeleteItem) invocationsDone() bool {
 }
 
 // DeleteItem implements mm_service.ICartRepository
-func (mmDeleteItem *ICartRepositoryMock) DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU) (err error) {
+func (mmDeleteItem *ICartRepositoryMock) DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (u1 uint64, err error) {
 	mm_atomic.AddUint64(&mmDeleteItem.beforeDeleteItemCounter, 1)
 	defer mm_atomic.AddUint64(&mmDeleteItem.afterDeleteItemCounter, 1)
 
 	mmDeleteItem.t.Helper()
 
 	if mmDeleteItem.inspectFuncDeleteItem != nil {
-		mmDeleteItem.inspectFuncDeleteItem(ctx, UID, SKU)
+		mmDeleteItem.inspectFuncDeleteItem(ctx, UID, SKU, version)
 	}
 
-	mm_params := ICartRepositoryMockDeleteItemParams{ctx, UID, SKU}
+	mm_params := ICartRepositoryMockDeleteItemParams{ctx, UID, SKU, version}
 
 	// Record call args
 	mmDeleteItem.DeleteItemMock.mutex.Lock()
@@ -705,7 +774,7 @@ func (mmDeleteItem *ICartRepositoryMock) DeleteItem(ctx context.Context, UID mod
 	for _, e := range mmDeleteItem.DeleteItemMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.u1, e.results.err
 		}
 	}
 
@@ -714,7 +783,7 @@ func (mmDeleteItem *ICartRepositoryMock) DeleteItem(ctx context.Context, UID mod
 		mm_want := mmDeleteItem.DeleteItemMock.defaultExpectation.params
 		mm_want_ptrs := mmDeleteItem.DeleteItemMock.defaultExpectation.paramPtrs
 
-		mm_got := ICartRepositoryMockDeleteItemParams{ctx, UID, SKU}
+		mm_got := ICartRepositoryMockDeleteItemParams{ctx, UID, SKU, version}
 
 		if mm_want_ptrs != nil {
 
@@ -733,6 +802,11 @@ func (mmDeleteItem *ICartRepositoryMock) DeleteItem(ctx context.Context, UID mod
 					mmDeleteItem.DeleteItemMock.defaultExpectation.expectationOrigins.originSKU, *mm_want_ptrs.SKU, mm_got.SKU, minimock.Diff(*mm_want_ptrs.SKU, mm_got.SKU))
 			}
 
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmDeleteItem.t.Errorf("ICartRepositoryMock.DeleteItem got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteItem.DeleteItemMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmDeleteItem.t.Errorf("ICartRepositoryMock.DeleteItem got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 				mmDeleteItem.DeleteItemMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
@@ -742,12 +816,12 @@ func (mmDeleteItem *ICartRepositoryMock) DeleteItem(ctx context.Context, UID mod
 		if mm_results == nil {
 			mmDeleteItem.t.Fatal("No results are set for the ICartRepositoryMock.DeleteItem")
 		}
-		return (*mm_results).err
+		return (*mm_results).u1, (*mm_results).err
 	}
 	if mmDeleteItem.funcDeleteItem != nil {
-		return mmDeleteItem.funcDeleteItem(ctx, UID, SKU)
+		return mmDeleteItem.funcDeleteItem(ctx, UID, SKU, version)
 	}
-	mmDeleteItem.t.Fatalf("Unexpected call to ICartRepositoryMock.DeleteItem. %v %v %v", ctx, UID, SKU)
+	mmDeleteItem.t.Fatalf("Unexpected call to ICartRepositoryMock.DeleteItem. %v %v %v %v", ctx, UID, SKU, version)
 	return
 }
 
@@ -845,26 +919,30 @@ type ICartRepositoryMockDeleteItemsByUserIDExpectation struct {
 
 // ICartRepositoryMockDeleteItemsByUserIDParams contains parameters of the ICartRepository.DeleteItemsByUserID
 type ICartRepositoryMockDeleteItemsByUserIDParams struct {
-	ctx context.Context
-	UID models.UID
+	ctx     context.Context
+	UID     models.UID
+	version uint64
 }
 
 // ICartRepositoryMockDeleteItemsByUserIDParamPtrs contains pointers to parameters of the ICartRepository.DeleteItemsByUserID
 type ICartRepositoryMockDeleteItemsByUserIDParamPtrs struct {
-	ctx *context.Context
-	UID *models.UID
+	ctx     *context.Context
+	UID     *models.UID
+	version *uint64
 }
 
 // ICartRepositoryMockDeleteItemsByUserIDResults contains results of the ICartRepository.DeleteItemsByUserID
 type ICartRepositoryMockDeleteItemsByUserIDResults struct {
+	u1  uint64
 	err error
 }
 
 // ICartRepositoryMockDeleteItemsByUserIDOrigins contains origins of expectations of the ICartRepository.DeleteItemsByUserID
 type ICartRepositoryMockDeleteItemsByUserIDExpectationOrigins struct {
-	origin    string
-	originCtx string
-	originUID string
+	origin        string
+	originCtx     string
+	originUID     string
+	originVersion string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -878,7 +956,7 @@ func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Optional()
 }
 
 // Expect sets up expected params for ICartRepository.DeleteItemsByUserID
-func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Expect(ctx context.Context, UID models.UID) *mICartRepositoryMockDeleteItemsByUserID {
+func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Expect(ctx context.Context, UID models.UID, version uint64) *mICartRepositoryMockDeleteItemsByUserID {
 	if mmDeleteItemsByUserID.mock.funcDeleteItemsByUserID != nil {
 		mmDeleteItemsByUserID.mock.t.Fatalf("ICartRepositoryMock.DeleteItemsByUserID mock is already set by Set")
 	}
@@ -891,7 +969,7 @@ func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Expect(ctx
 		mmDeleteItemsByUserID.mock.t.Fatalf("ICartRepositoryMock.DeleteItemsByUserID mock is already set by ExpectParams functions")
 	}
 
-	mmDeleteItemsByUserID.defaultExpectation.params = &ICartRepositoryMockDeleteItemsByUserIDParams{ctx, UID}
+	mmDeleteItemsByUserID.defaultExpectation.params = &ICartRepositoryMockDeleteItemsByUserIDParams{ctx, UID, version}
 	mmDeleteItemsByUserID.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmDeleteItemsByUserID.expectations {
 		if minimock.Equal(e.params, mmDeleteItemsByUserID.defaultExpectation.params) {
@@ -948,8 +1026,31 @@ func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) ExpectUIDP
 	return mmDeleteItemsByUserID
 }
 
+// ExpectVersionParam3 sets up expected param version for ICartRepository.DeleteItemsByUserID
+func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) ExpectVersionParam3(version uint64) *mICartRepositoryMockDeleteItemsByUserID {
+	if mmDeleteItemsByUserID.mock.funcDeleteItemsByUserID != nil {
+		mmDeleteItemsByUserID.mock.t.Fatalf("ICartRepositoryMock.DeleteItemsByUserID mock is already set by Set")
+	}
+
+	if mmDeleteItemsByUserID.defaultExpectation == nil {
+		mmDeleteItemsByUserID.defaultExpectation = &ICartRepositoryMockDeleteItemsByUserIDExpectation{}
+	}
+
+	if mmDeleteItemsByUserID.defaultExpectation.params != nil {
+		mmDeleteItemsByUserID.mock.t.Fatalf("ICartRepositoryMock.DeleteItemsByUserID mock is already set by Expect")
+	}
+
+	if mmDeleteItemsByUserID.defaultExpectation.paramPtrs == nil {
+		mmDeleteItemsByUserID.defaultExpectation.paramPtrs = &ICartRepositoryMockDeleteItemsByUserIDParamPtrs{}
+	}
+	mmDeleteItemsByUserID.defaultExpectation.paramPtrs.version = &version
+	mmDeleteItemsByUserID.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmDeleteItemsByUserID
+}
+
 // Inspect accepts an inspector function that has same arguments as the ICartRepository.DeleteItemsByUserID
-func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Inspect(f func(ctx context.Context, UID models.UID)) *mICartRepositoryMockDeleteItemsByUserID {
+func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Inspect(f func(ctx context.Context, UID models.UID, version uint64)) *mICartRepositoryMockDeleteItemsByUserID {
 	if mmDeleteItemsByUserID.mock.inspectFuncDeleteItemsByUserID != nil {
 		mmDeleteItemsByUserID.mock.t.Fatalf("Inspect function is already set for ICartRepositoryMock.DeleteItemsByUserID")
 	}
@@ -960,7 +1061,7 @@ func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Inspect(f
 }
 
 // Return sets up results that will be returned by ICartRepository.DeleteItemsByUserID
-func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Return(err error) *ICartRepositoryMock {
+func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Return(u1 uint64, err error) *ICartRepositoryMock {
 	if mmDeleteItemsByUserID.mock.funcDeleteItemsByUserID != nil {
 		mmDeleteItemsByUserID.mock.t.Fatalf("ICartRepositoryMock.DeleteItemsByUserID mock is already set by Set")
 	}
@@ -968,13 +1069,13 @@ func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Return(err
 	if mmDeleteItemsByUserID.defaultExpectation == nil {
 		mmDeleteItemsByUserID.defaultExpectation = &ICartRepositoryMockDeleteItemsByUserIDExpectation{mock: mmDeleteItemsByUserID.mock}
 	}
-	mmDeleteItemsByUserID.defaultExpectation.results = &ICartRepositoryMockDeleteItemsByUserIDResults{err}
+	mmDeleteItemsByUserID.defaultExpectation.results = &ICartRepositoryMockDeleteItemsByUserIDResults{u1, err}
 	mmDeleteItemsByUserID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
 	return mmDeleteItemsByUserID.mock
 }
 
 // Set uses given function f to mock the ICartRepository.DeleteItemsByUserID method
-func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Set(f func(ctx context.Context, UID models.UID) (err error)) *ICartRepositoryMock {
+func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Set(f func(ctx context.Context, UID models.UID, version uint64) (u1 uint64, err error)) *ICartRepositoryMock {
 	if mmDeleteItemsByUserID.defaultExpectation != nil {
 		mmDeleteItemsByUserID.mock.t.Fatalf("Default expectation is already set for the ICartRepository.DeleteItemsByUserID method")
 	}
@@ -990,14 +1091,14 @@ func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) Set(f func
 
 // When sets expectation for the ICartRepository.DeleteItemsByUserID which will trigger the result defined by the following
 // Then helper
-func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) When(ctx context.Context, UID models.UID) *ICartRepositoryMockDeleteItemsByUserIDExpectation {
+func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) When(ctx context.Context, UID models.UID, version uint64) *ICartRepositoryMockDeleteItemsByUserIDExpectation {
 	if mmDeleteItemsByUserID.mock.funcDeleteItemsByUserID != nil {
 		mmDeleteItemsByUserID.mock.t.Fatalf("ICartRepositoryMock.DeleteItemsByUserID mock is already set by Set")
 	}
 
 	expectation := &ICartRepositoryMockDeleteItemsByUserIDExpectation{
 		mock:               mmDeleteItemsByUserID.mock,
-		params:             &ICartRepositoryMockDeleteItemsByUserIDParams{ctx, UID},
+		params:             &ICartRepositoryMockDeleteItemsByUserIDParams{ctx, UID, version},
 		expectationOrigins: ICartRepositoryMockDeleteItemsByUserIDExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmDeleteItemsByUserID.expectations = append(mmDeleteItemsByUserID.expectations, expectation)
@@ -1005,8 +1106,8 @@ func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) When(ctx c
 }
 
 // Then sets up ICartRepository.DeleteItemsByUserID return parameters for the expectation previously defined by the When method
-func (e *ICartRepositoryMockDeleteItemsByUserIDExpectation) Then(err error) *ICartRepositoryMock {
-	e.results = &ICartRepositoryMockDeleteItemsByUserIDResults{err}
+func (e *ICartRepositoryMockDeleteItemsByUserIDExpectation) Then(u1 uint64, err error) *ICartRepositoryMock {
+	e.results = &ICartRepositoryMockDeleteItemsByUserIDResults{u1, err}
 	return e.mock
 }
 
@@ -1032,17 +1133,17 @@ func (mmDeleteItemsByUserID *mICartRepositoryMockDeleteItemsByUserID) invocation
 }
 
 // DeleteItemsByUserID implements mm_service.ICartRepository
-func (mmDeleteItemsByUserID *ICartRepositoryMock) DeleteItemsByUserID(ctx context.Context, UID models.UID) (err error) {
+func (mmDeleteItemsByUserID *ICartRepositoryMock) DeleteItemsByUserID(ctx context.Context, UID models.UID, version uint64) (u1 uint64, err error) {
 	mm_atomic.AddUint64(&mmDeleteItemsByUserID.beforeDeleteItemsByUserIDCounter, 1)
 	defer mm_atomic.AddUint64(&mmDeleteItemsByUserID.afterDeleteItemsByUserIDCounter, 1)
 
 	mmDeleteItemsByUserID.t.Helper()
 
 	if mmDeleteItemsByUserID.inspectFuncDeleteItemsByUserID != nil {
-		mmDeleteItemsByUserID.inspectFuncDeleteItemsByUserID(ctx, UID)
+		mmDeleteItemsByUserID.inspectFuncDeleteItemsByUserID(ctx, UID, version)
 	}
 
-	mm_params := ICartRepositoryMockDeleteItemsByUserIDParams{ctx, UID}
+	mm_params := ICartRepositoryMockDeleteItemsByUserIDParams{ctx, UID, version}
 
 	// Record call args
 	mmDeleteItemsByUserID.DeleteItemsByUserIDMock.mutex.Lock()
@@ -1052,7 +1153,7 @@ func (mmDeleteItemsByUserID *ICartRepositoryMock) DeleteItemsByUserID(ctx contex
 	for _, e := range mmDeleteItemsByUserID.DeleteItemsByUserIDMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.u1, e.results.err
 		}
 	}
 
@@ -1061,7 +1162,7 @@ func (mmDeleteItemsByUserID *ICartRepositoryMock) DeleteItemsByUserID(ctx contex
 		mm_want := mmDeleteItemsByUserID.DeleteItemsByUserIDMock.defaultExpectation.params
 		mm_want_ptrs := mmDeleteItemsByUserID.DeleteItemsByUserIDMock.defaultExpectation.paramPtrs
 
-		mm_got := ICartRepositoryMockDeleteItemsByUserIDParams{ctx, UID}
+		mm_got := ICartRepositoryMockDeleteItemsByUserIDParams{ctx, UID, version}
 
 		if mm_want_ptrs != nil {
 
@@ -1075,6 +1176,11 @@ func (mmDeleteItemsByUserID *ICartRepositoryMock) DeleteItemsByUserID(ctx contex
 					mmDeleteItemsByUserID.DeleteItemsByUserIDMock.defaultExpectation.expectationOrigins.originUID, *mm_want_ptrs.UID, mm_got.UID, minimock.Diff(*mm_want_ptrs.UID, mm_got.UID))
 			}
 
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmDeleteItemsByUserID.t.Errorf("ICartRepositoryMock.DeleteItemsByUserID got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteItemsByUserID.DeleteItemsByUserIDMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmDeleteItemsByUserID.t.Errorf("ICartRepositoryMock.DeleteItemsByUserID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 				mmDeleteItemsByUserID.DeleteItemsByUserIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
@@ -1084,12 +1190,12 @@ func (mmDeleteItemsByUserID *ICartRepositoryMock) DeleteItemsByUserID(ctx contex
 		if mm_results == nil {
 			mmDeleteItemsByUserID.t.Fatal("No results are set for the ICartRepositoryMock.DeleteItemsByUserID")
 		}
-		return (*mm_results).err
+		return (*mm_results).u1, (*mm_results).err
 	}
 	if mmDeleteItemsByUserID.funcDeleteItemsByUserID != nil {
-		return mmDeleteItemsByUserID.funcDeleteItemsByUserID(ctx, UID)
+		return mmDeleteItemsByUserID.funcDeleteItemsByUserID(ctx, UID, version)
 	}
-	mmDeleteItemsByUserID.t.Fatalf("Unexpected call to ICartRepositoryMock.DeleteItemsByUserID. %v %v", ctx, UID)
+	mmDeleteItemsByUserID.t.Fatalf("Unexpected call to ICartRepositoryMock.DeleteItemsByUserID. %v %v %v", ctx, UID, version)
 	return
 }
 
@@ -1200,6 +1306,7 @@ type ICartRepositoryMockGetItemsByUserIDParamPtrs struct {
 // ICartRepositoryMockGetItemsByUserIDResults contains results of the ICartRepository.GetItemsByUserID
 type ICartRepositoryMockGetItemsByUserIDResults struct {
 	ca1 []models.CartItem
+	u1  uint64
 	err error
 }
 
@@ -1303,7 +1410,7 @@ func (mmGetItemsByUserID *mICartRepositoryMockGetItemsByUserID) Inspect(f func(c
 }
 
 // Return sets up results that will be returned by ICartRepository.GetItemsByUserID
-func (mmGetItemsByUserID *mICartRepositoryMockGetItemsByUserID) Return(ca1 []models.CartItem, err error) *ICartRepositoryMock {
+func (mmGetItemsByUserID *mICartRepositoryMockGetItemsByUserID) Return(ca1 []models.CartItem, u1 uint64, err error) *ICartRepositoryMock {
 	if mmGetItemsByUserID.mock.funcGetItemsByUserID != nil {
 		mmGetItemsByUserID.mock.t.Fatalf("ICartRepositoryMock.GetItemsByUserID mock is already set by Set")
 	}
@@ -1311,13 +1418,13 @@ func (mmGetItemsByUserID *mICartRepositoryMockGetItemsByUserID) Return(ca1 []mod
 	if mmGetItemsByUserID.defaultExpectation == nil {
 		mmGetItemsByUserID.defaultExpectation = &ICartRepositoryMockGetItemsByUserIDExpectation{mock: mmGetItemsByUserID.mock}
 	}
-	mmGetItemsByUserID.defaultExpectation.results = &ICartRepositoryMockGetItemsByUserIDResults{ca1, err}
+	mmGetItemsByUserID.defaultExpectation.results = &ICartRepositoryMockGetItemsByUserIDResults{ca1, u1, err}
 	mmGetItemsByUserID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
 	return mmGetItemsByUserID.mock
 }
 
 // Set uses given function f to mock the ICartRepository.GetItemsByUserID method
-func (mmGetItemsByUserID *mICartRepositoryMockGetItemsByUserID) Set(f func(ctx context.Context, UID models.UID) (ca1 []models.CartItem, err error)) *ICartRepositoryMock {
+func (mmGetItemsByUserID *mICartRepositoryMockGetItemsByUserID) Set(f func(ctx context.Context, UID models.UID) (ca1 []models.CartItem, u1 uint64, err error)) *ICartRepositoryMock {
 	if mmGetItemsByUserID.defaultExpectation != nil {
 		mmGetItemsByUserID.mock.t.Fatalf("Default expectation is already set for the ICartRepository.GetItemsByUserID method")
 	}
@@ -1348,8 +1455,8 @@ func (mmGetItemsByUserID *mICartRepositoryMockGetItemsByUserID) When(ctx context
 }
 
 // Then sets up ICartRepository.GetItemsByUserID return parameters for the expectation previously defined by the When method
-func (e *ICartRepositoryMockGetItemsByUserIDExpectation) Then(ca1 []models.CartItem, err error) *ICartRepositoryMock {
-	e.results = &ICartRepositoryMockGetItemsByUserIDResults{ca1, err}
+func (e *ICartRepositoryMockGetItemsByUserIDExpectation) Then(ca1 []models.CartItem, u1 uint64, err error) *ICartRepositoryMock {
+	e.results = &ICartRepositoryMockGetItemsByUserIDResults{ca1, u1, err}
 	return e.mock
 }
 
@@ -1375,7 +1482,7 @@ func (mmGetItemsByUserID *mICartRepositoryMockGetItemsByUserID) invocationsDone(
 }
 
 // GetItemsByUserID implements mm_service.ICartRepository
-func (mmGetItemsByUserID *ICartRepositoryMock) GetItemsByUserID(ctx context.Context, UID models.UID) (ca1 []models.CartItem, err error) {
+func (mmGetItemsByUserID *ICartRepositoryMock) GetItemsByUserID(ctx context.Context, UID models.UID) (ca1 []models.CartItem, u1 uint64, err error) {
 	mm_atomic.AddUint64(&mmGetItemsByUserID.beforeGetItemsByUserIDCounter, 1)
 	defer mm_atomic.AddUint64(&mmGetItemsByUserID.afterGetItemsByUserIDCounter, 1)
 
@@ -1395,7 +1502,7 @@ func (mmGetItemsByUserID *ICartRepositoryMock) GetItemsByUserID(ctx context.Cont
 	for _, e := range mmGetItemsByUserID.GetItemsByUserIDMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.ca1, e.results.err
+			return e.results.ca1, e.results.u1, e.results.err
 		}
 	}
 
@@ -1427,7 +1534,7 @@ func (mmGetItemsByUserID *ICartRepositoryMock) GetItemsByUserID(ctx context.Cont
 		if mm_results == nil {
 			mmGetItemsByUserID.t.Fatal("No results are set for the ICartRepositoryMock.GetItemsByUserID")
 		}
-		return (*mm_results).ca1, (*mm_results).err
+		return (*mm_results).ca1, (*mm_results).u1, (*mm_results).err
 	}
 	if mmGetItemsByUserID.funcGetItemsByUserID != nil {
 		return mmGetItemsByUserID.funcGetItemsByUserID(ctx, UID)
@@ -1504,6 +1611,411 @@ func (m *ICartRepositoryMock) MinimockGetItemsByUserIDInspect() {
 	}
 }
 
+type mICartRepositoryMockSetItem struct {
+	optional           bool
+	mock               *ICartRepositoryMock
+	defaultExpectation *ICartRepositoryMockSetItemExpectation
+	expectations       []*ICartRepositoryMockSetItemExpectation
+
+	callArgs []*ICartRepositoryMockSetItemParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ICartRepositoryMockSetItemExpectation specifies expectation struct of the ICartRepository.SetItem
+type ICartRepositoryMockSetItemExpectation struct {
+	mock               *ICartRepositoryMock
+	params             *ICartRepositoryMockSetItemParams
+	paramPtrs          *ICartRepositoryMockSetItemParamPtrs
+	expectationOrigins ICartRepositoryMockSetItemExpectationOrigins
+	results            *ICartRepositoryMockSetItemResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ICartRepositoryMockSetItemParams contains parameters of the ICartRepository.SetItem
+type ICartRepositoryMockSetItemParams struct {
+	ctx     context.Context
+	UID     models.UID
+	item    models.CartItem
+	version uint64
+}
+
+// ICartRepositoryMockSetItemParamPtrs contains pointers to parameters of the ICartRepository.SetItem
+type ICartRepositoryMockSetItemParamPtrs struct {
+	ctx     *context.Context
+	UID     *models.UID
+	item    *models.CartItem
+	version *uint64
+}
+
+// ICartRepositoryMockSetItemResults contains results of the ICartRepository.SetItem
+type ICartRepositoryMockSetItemResults struct {
+	u1  uint64
+	err error
+}
+
+// ICartRepositoryMockSetItemOrigins contains origins of expectations of the ICartRepository.SetItem
+type ICartRepositoryMockSetItemExpectationOrigins struct {
+	origin        string
+	originCtx     string
+	originUID     string
+	originItem    string
+	originVersion string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSetItem *mICartRepositoryMockSetItem) Optional() *mICartRepositoryMockSetItem {
+	mmSetItem.optional = true
+	return mmSetItem
+}
+
+// Expect sets up expected params for ICartRepository.SetItem
+func (mmSetItem *mICartRepositoryMockSetItem) Expect(ctx context.Context, UID models.UID, item models.CartItem, version uint64) *mICartRepositoryMockSetItem {
+	if mmSetItem.mock.funcSetItem != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Set")
+	}
+
+	if mmSetItem.defaultExpectation == nil {
+		mmSetItem.defaultExpectation = &ICartRepositoryMockSetItemExpectation{}
+	}
+
+	if mmSetItem.defaultExpectation.paramPtrs != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by ExpectParams functions")
+	}
+
+	mmSetItem.defaultExpectation.params = &ICartRepositoryMockSetItemParams{ctx, UID, item, version}
+	mmSetItem.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmSetItem.expectations {
+		if minimock.Equal(e.params, mmSetItem.defaultExpectation.params) {
+			mmSetItem.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetItem.defaultExpectation.params)
+		}
+	}
+
+	return mmSetItem
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ICartRepository.SetItem
+func (mmSetItem *mICartRepositoryMockSetItem) ExpectCtxParam1(ctx context.Context) *mICartRepositoryMockSetItem {
+	if mmSetItem.mock.funcSetItem != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Set")
+	}
+
+	if mmSetItem.defaultExpectation == nil {
+		mmSetItem.defaultExpectation = &ICartRepositoryMockSetItemExpectation{}
+	}
+
+	if mmSetItem.defaultExpectation.params != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Expect")
+	}
+
+	if mmSetItem.defaultExpectation.paramPtrs == nil {
+		mmSetItem.defaultExpectation.paramPtrs = &ICartRepositoryMockSetItemParamPtrs{}
+	}
+	mmSetItem.defaultExpectation.paramPtrs.ctx = &ctx
+	mmSetItem.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmSetItem
+}
+
+// ExpectUIDParam2 sets up expected param UID for ICartRepository.SetItem
+func (mmSetItem *mICartRepositoryMockSetItem) ExpectUIDParam2(UID models.UID) *mICartRepositoryMockSetItem {
+	if mmSetItem.mock.funcSetItem != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Set")
+	}
+
+	if mmSetItem.defaultExpectation == nil {
+		mmSetItem.defaultExpectation = &ICartRepositoryMockSetItemExpectation{}
+	}
+
+	if mmSetItem.defaultExpectation.params != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Expect")
+	}
+
+	if mmSetItem.defaultExpectation.paramPtrs == nil {
+		mmSetItem.defaultExpectation.paramPtrs = &ICartRepositoryMockSetItemParamPtrs{}
+	}
+	mmSetItem.defaultExpectation.paramPtrs.UID = &UID
+	mmSetItem.defaultExpectation.expectationOrigins.originUID = minimock.CallerInfo(1)
+
+	return mmSetItem
+}
+
+// ExpectItemParam3 sets up expected param item for ICartRepository.SetItem
+func (mmSetItem *mICartRepositoryMockSetItem) ExpectItemParam3(item models.CartItem) *mICartRepositoryMockSetItem {
+	if mmSetItem.mock.funcSetItem != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Set")
+	}
+
+	if mmSetItem.defaultExpectation == nil {
+		mmSetItem.defaultExpectation = &ICartRepositoryMockSetItemExpectation{}
+	}
+
+	if mmSetItem.defaultExpectation.params != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Expect")
+	}
+
+	if mmSetItem.defaultExpectation.paramPtrs == nil {
+		mmSetItem.defaultExpectation.paramPtrs = &ICartRepositoryMockSetItemParamPtrs{}
+	}
+	mmSetItem.defaultExpectation.paramPtrs.item = &item
+	mmSetItem.defaultExpectation.expectationOrigins.originItem = minimock.CallerInfo(1)
+
+	return mmSetItem
+}
+
+// ExpectVersionParam4 sets up expected param version for ICartRepository.SetItem
+func (mmSetItem *mICartRepositoryMockSetItem) ExpectVersionParam4(version uint64) *mICartRepositoryMockSetItem {
+	if mmSetItem.mock.funcSetItem != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Set")
+	}
+
+	if mmSetItem.defaultExpectation == nil {
+		mmSetItem.defaultExpectation = &ICartRepositoryMockSetItemExpectation{}
+	}
+
+	if mmSetItem.defaultExpectation.params != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Expect")
+	}
+
+	if mmSetItem.defaultExpectation.paramPtrs == nil {
+		mmSetItem.defaultExpectation.paramPtrs = &ICartRepositoryMockSetItemParamPtrs{}
+	}
+	mmSetItem.defaultExpectation.paramPtrs.version = &version
+	mmSetItem.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmSetItem
+}
+
+// Inspect accepts an inspector function that has same arguments as the ICartRepository.SetItem
+func (mmSetItem *mICartRepositoryMockSetItem) Inspect(f func(ctx context.Context, UID models.UID, item models.CartItem, version uint64)) *mICartRepositoryMockSetItem {
+	if mmSetItem.mock.inspectFuncSetItem != nil {
+		mmSetItem.mock.t.Fatalf("Inspect function is already set for ICartRepositoryMock.SetItem")
+	}
+
+	mmSetItem.mock.inspectFuncSetItem = f
+
+	return mmSetItem
+}
+
+// Return sets up results that will be returned by ICartRepository.SetItem
+func (mmSetItem *mICartRepositoryMockSetItem) Return(u1 uint64, err error) *ICartRepositoryMock {
+	if mmSetItem.mock.funcSetItem != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Set")
+	}
+
+	if mmSetItem.defaultExpectation == nil {
+		mmSetItem.defaultExpectation = &ICartRepositoryMockSetItemExpectation{mock: mmSetItem.mock}
+	}
+	mmSetItem.defaultExpectation.results = &ICartRepositoryMockSetItemResults{u1, err}
+	mmSetItem.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmSetItem.mock
+}
+
+// Set uses given function f to mock the ICartRepository.SetItem method
+func (mmSetItem *mICartRepositoryMockSetItem) Set(f func(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (u1 uint64, err error)) *ICartRepositoryMock {
+	if mmSetItem.defaultExpectation != nil {
+		mmSetItem.mock.t.Fatalf("Default expectation is already set for the ICartRepository.SetItem method")
+	}
+
+	if len(mmSetItem.expectations) > 0 {
+		mmSetItem.mock.t.Fatalf("Some expectations are already set for the ICartRepository.SetItem method")
+	}
+
+	mmSetItem.mock.funcSetItem = f
+	mmSetItem.mock.funcSetItemOrigin = minimock.CallerInfo(1)
+	return mmSetItem.mock
+}
+
+// When sets expectation for the ICartRepository.SetItem which will trigger the result defined by the following
+// Then helper
+func (mmSetItem *mICartRepositoryMockSetItem) When(ctx context.Context, UID models.UID, item models.CartItem, version uint64) *ICartRepositoryMockSetItemExpectation {
+	if mmSetItem.mock.funcSetItem != nil {
+		mmSetItem.mock.t.Fatalf("ICartRepositoryMock.SetItem mock is already set by Set")
+	}
+
+	expectation := &ICartRepositoryMockSetItemExpectation{
+		mock:               mmSetItem.mock,
+		params:             &ICartRepositoryMockSetItemParams{ctx, UID, item, version},
+		expectationOrigins: ICartRepositoryMockSetItemExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmSetItem.expectations = append(mmSetItem.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ICartRepository.SetItem return parameters for the expectation previously defined by the When method
+func (e *ICartRepositoryMockSetItemExpectation) Then(u1 uint64, err error) *ICartRepositoryMock {
+	e.results = &ICartRepositoryMockSetItemResults{u1, err}
+	return e.mock
+}
+
+// Times sets number of times ICartRepository.SetItem should be invoked
+func (mmSetItem *mICartRepositoryMockSetItem) Times(n uint64) *mICartRepositoryMockSetItem {
+	if n == 0 {
+		mmSetItem.mock.t.Fatalf("Times of ICartRepositoryMock.SetItem mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSetItem.expectedInvocations, n)
+	mmSetItem.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmSetItem
+}
+
+func (mmSetItem *mICartRepositoryMockSetItem) invocationsDone() bool {
+	if len(mmSetItem.expectations) == 0 && mmSetItem.defaultExpectation == nil && mmSetItem.mock.funcSetItem == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSetItem.mock.afterSetItemCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSetItem.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SetItem implements mm_service.ICartRepository
+func (mmSetItem *ICartRepositoryMock) SetItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (u1 uint64, err error) {
+	mm_atomic.AddUint64(&mmSetItem.beforeSetItemCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetItem.afterSetItemCounter, 1)
+
+	mmSetItem.t.Helper()
+
+	if mmSetItem.inspectFuncSetItem != nil {
+		mmSetItem.inspectFuncSetItem(ctx, UID, item, version)
+	}
+
+	mm_params := ICartRepositoryMockSetItemParams{ctx, UID, item, version}
+
+	// Record call args
+	mmSetItem.SetItemMock.mutex.Lock()
+	mmSetItem.SetItemMock.callArgs = append(mmSetItem.SetItemMock.callArgs, &mm_params)
+	mmSetItem.SetItemMock.mutex.Unlock()
+
+	for _, e := range mmSetItem.SetItemMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.u1, e.results.err
+		}
+	}
+
+	if mmSetItem.SetItemMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetItem.SetItemMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetItem.SetItemMock.defaultExpectation.params
+		mm_want_ptrs := mmSetItem.SetItemMock.defaultExpectation.paramPtrs
+
+		mm_got := ICartRepositoryMockSetItemParams{ctx, UID, item, version}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmSetItem.t.Errorf("ICartRepositoryMock.SetItem got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetItem.SetItemMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.UID != nil && !minimock.Equal(*mm_want_ptrs.UID, mm_got.UID) {
+				mmSetItem.t.Errorf("ICartRepositoryMock.SetItem got unexpected parameter UID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetItem.SetItemMock.defaultExpectation.expectationOrigins.originUID, *mm_want_ptrs.UID, mm_got.UID, minimock.Diff(*mm_want_ptrs.UID, mm_got.UID))
+			}
+
+			if mm_want_ptrs.item != nil && !minimock.Equal(*mm_want_ptrs.item, mm_got.item) {
+				mmSetItem.t.Errorf("ICartRepositoryMock.SetItem got unexpected parameter item, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetItem.SetItemMock.defaultExpectation.expectationOrigins.originItem, *mm_want_ptrs.item, mm_got.item, minimock.Diff(*mm_want_ptrs.item, mm_got.item))
+			}
+
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmSetItem.t.Errorf("ICartRepositoryMock.SetItem got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetItem.SetItemMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetItem.t.Errorf("ICartRepositoryMock.SetItem got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmSetItem.SetItemMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSetItem.SetItemMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSetItem.t.Fatal("No results are set for the ICartRepositoryMock.SetItem")
+		}
+		return (*mm_results).u1, (*mm_results).err
+	}
+	if mmSetItem.funcSetItem != nil {
+		return mmSetItem.funcSetItem(ctx, UID, item, version)
+	}
+	mmSetItem.t.Fatalf("Unexpected call to ICartRepositoryMock.SetItem. %v %v %v %v", ctx, UID, item, version)
+	return
+}
+
+// SetItemAfterCounter returns a count of finished ICartRepositoryMock.SetItem invocations
+func (mmSetItem *ICartRepositoryMock) SetItemAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetItem.afterSetItemCounter)
+}
+
+// SetItemBeforeCounter returns a count of ICartRepositoryMock.SetItem invocations
+func (mmSetItem *ICartRepositoryMock) SetItemBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetItem.beforeSetItemCounter)
+}
+
+// Calls returns a list of arguments used in each call to ICartRepositoryMock.SetItem.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetItem *mICartRepositoryMockSetItem) Calls() []*ICartRepositoryMockSetItemParams {
+	mmSetItem.mutex.RLock()
+
+	argCopy := make([]*ICartRepositoryMockSetItemParams, len(mmSetItem.callArgs))
+	copy(argCopy, mmSetItem.callArgs)
+
+	mmSetItem.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetItemDone returns true if the count of the SetItem invocations corresponds
+// the number of defined expectations
+func (m *ICartRepositoryMock) MinimockSetItemDone() bool {
+	if m.SetItemMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.SetItemMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.SetItemMock.invocationsDone()
+}
+
+// MinimockSetItemInspect logs each unmet expectation
+func (m *ICartRepositoryMock) MinimockSetItemInspect() {
+	for _, e := range m.SetItemMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ICartRepositoryMock.SetItem at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterSetItemCounter := mm_atomic.LoadUint64(&m.afterSetItemCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetItemMock.defaultExpectation != nil && afterSetItemCounter < 1 {
+		if m.SetItemMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ICartRepositoryMock.SetItem at\n%s", m.SetItemMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ICartRepositoryMock.SetItem at\n%s with params: %#v", m.SetItemMock.defaultExpectation.expectationOrigins.origin, *m.SetItemMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetItem != nil && afterSetItemCounter < 1 {
+		m.t.Errorf("Expected call to ICartRepositoryMock.SetItem at\n%s", m.funcSetItemOrigin)
+	}
+
+	if !m.SetItemMock.invocationsDone() && afterSetItemCounter > 0 {
+		m.t.Errorf("Expected %d calls to ICartRepositoryMock.SetItem at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.SetItemMock.expectedInvocations), m.SetItemMock.expectedInvocationsOrigin, afterSetItemCounter)
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *ICartRepositoryMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
@@ -1515,6 +2027,8 @@ func (m *ICartRepositoryMock) MinimockFinish() {
 			m.MinimockDeleteItemsByUserIDInspect()
 
 			m.MinimockGetItemsByUserIDInspect()
+
+			m.MinimockSetItemInspect()
 		}
 	})
 }
@@ -1541,5 +2055,6 @@ func (m *ICartRepositoryMock) minimockDone() bool {
 		m.MinimockAddItemDone() &&
 		m.MinimockDeleteItemDone() &&
 		m.MinimockDeleteItemsByUserIDDone() &&
-		m.MinimockGetItemsByUserIDDone()
+		m.MinimockGetItemsByUserIDDone() &&
+		m.MinimockSetItemDone()
 }
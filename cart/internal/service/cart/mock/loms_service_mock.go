@@ -19,9 +19,9 @@ type ILomsServiceMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcOrderCreate          func(ctx context.Context, user int64, items []models.CartItem) (i1 int64, err error)
+	funcOrderCreate          func(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (i1 int64, err error)
 	funcOrderCreateOrigin    string
-	inspectFuncOrderCreate   func(ctx context.Context, user int64, items []models.CartItem)
+	inspectFuncOrderCreate   func(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string)
 	afterOrderCreateCounter  uint64
 	beforeOrderCreateCounter uint64
 	OrderCreateMock          mILomsServiceMockOrderCreate
@@ -32,6 +32,13 @@ type ILomsServiceMock struct {
 	afterStocksInfoCounter  uint64
 	beforeStocksInfoCounter uint64
 	StocksInfoMock          mILomsServiceMockStocksInfo
+
+	funcStocksInfoBatch          func(ctx context.Context, SKUs []models.SKU) (m1 map[models.SKU]int64, sa1 []models.SKU, err error)
+	funcStocksInfoBatchOrigin    string
+	inspectFuncStocksInfoBatch   func(ctx context.Context, SKUs []models.SKU)
+	afterStocksInfoBatchCounter  uint64
+	beforeStocksInfoBatchCounter uint64
+	StocksInfoBatchMock          mILomsServiceMockStocksInfoBatch
 }
 
 // NewILomsServiceMock returns a mock for mm_service.ILomsService
@@ -48,6 +55,9 @@ func NewILomsServiceMock(t minimock.Tester) *ILomsServiceMock {
 	m.StocksInfoMock = mILomsServiceMockStocksInfo{mock: m}
 	m.StocksInfoMock.callArgs = []*ILomsServiceMockStocksInfoParams{}
 
+	m.StocksInfoBatchMock = mILomsServiceMockStocksInfoBatch{mock: m}
+	m.StocksInfoBatchMock.callArgs = []*ILomsServiceMockStocksInfoBatchParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
@@ -79,16 +89,18 @@ type ILomsServiceMockOrderCreateExpectation struct {
 
 // ILomsServiceMockOrderCreateParams contains parameters of the ILomsService.OrderCreate
 type ILomsServiceMockOrderCreateParams struct {
-	ctx   context.Context
-	user  int64
-	items []models.CartItem
+	ctx            context.Context
+	user           int64
+	items          []models.CartItem
+	idempotencyKey string
 }
 
 // ILomsServiceMockOrderCreateParamPtrs contains pointers to parameters of the ILomsService.OrderCreate
 type ILomsServiceMockOrderCreateParamPtrs struct {
-	ctx   *context.Context
-	user  *int64
-	items *[]models.CartItem
+	ctx            *context.Context
+	user           *int64
+	items          *[]models.CartItem
+	idempotencyKey *string
 }
 
 // ILomsServiceMockOrderCreateResults contains results of the ILomsService.OrderCreate
@@ -99,10 +111,11 @@ type ILomsServiceMockOrderCreateResults struct {
 
 // ILomsServiceMockOrderCreateOrigins contains origins of expectations of the ILomsService.OrderCreate
 type ILomsServiceMockOrderCreateExpectationOrigins struct {
-	origin      string
-	originCtx   string
-	originUser  string
-	originItems string
+	origin               string
+	originCtx            string
+	originUser           string
+	originItems          string
+	originIdempotencyKey string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -116,7 +129,7 @@ func (mmOrderCreate *mILomsServiceMockOrderCreate) Optional() *mILomsServiceMock
 }
 
 // Expect sets up expected params for ILomsService.OrderCreate
-func (mmOrderCreate *mILomsServiceMockOrderCreate) Expect(ctx context.Context, user int64, items []models.CartItem) *mILomsServiceMockOrderCreate {
+func (mmOrderCreate *mILomsServiceMockOrderCreate) Expect(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) *mILomsServiceMockOrderCreate {
 	if mmOrderCreate.mock.funcOrderCreate != nil {
 		mmOrderCreate.mock.t.Fatalf("ILomsServiceMock.OrderCreate mock is already set by Set")
 	}
@@ -129,7 +142,7 @@ func (mmOrderCreate *mILomsServiceMockOrderCreate) Expect(ctx context.Context, u
 		mmOrderCreate.mock.t.Fatalf("ILomsServiceMock.OrderCreate mock is already set by ExpectParams functions")
 	}
 
-	mmOrderCreate.defaultExpectation.params = &ILomsServiceMockOrderCreateParams{ctx, user, items}
+	mmOrderCreate.defaultExpectation.params = &ILomsServiceMockOrderCreateParams{ctx, user, items, idempotencyKey}
 	mmOrderCreate.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmOrderCreate.expectations {
 		if minimock.Equal(e.params, mmOrderCreate.defaultExpectation.params) {
@@ -209,8 +222,31 @@ func (mmOrderCreate *mILomsServiceMockOrderCreate) ExpectItemsParam3(items []mod
 	return mmOrderCreate
 }
 
+// ExpectIdempotencyKeyParam4 sets up expected param idempotencyKey for ILomsService.OrderCreate
+func (mmOrderCreate *mILomsServiceMockOrderCreate) ExpectIdempotencyKeyParam4(idempotencyKey string) *mILomsServiceMockOrderCreate {
+	if mmOrderCreate.mock.funcOrderCreate != nil {
+		mmOrderCreate.mock.t.Fatalf("ILomsServiceMock.OrderCreate mock is already set by Set")
+	}
+
+	if mmOrderCreate.defaultExpectation == nil {
+		mmOrderCreate.defaultExpectation = &ILomsServiceMockOrderCreateExpectation{}
+	}
+
+	if mmOrderCreate.defaultExpectation.params != nil {
+		mmOrderCreate.mock.t.Fatalf("ILomsServiceMock.OrderCreate mock is already set by Expect")
+	}
+
+	if mmOrderCreate.defaultExpectation.paramPtrs == nil {
+		mmOrderCreate.defaultExpectation.paramPtrs = &ILomsServiceMockOrderCreateParamPtrs{}
+	}
+	mmOrderCreate.defaultExpectation.paramPtrs.idempotencyKey = &idempotencyKey
+	mmOrderCreate.defaultExpectation.expectationOrigins.originIdempotencyKey = minimock.CallerInfo(1)
+
+	return mmOrderCreate
+}
+
 // Inspect accepts an inspector function that has same arguments as the ILomsService.OrderCreate
-func (mmOrderCreate *mILomsServiceMockOrderCreate) Inspect(f func(ctx context.Context, user int64, items []models.CartItem)) *mILomsServiceMockOrderCreate {
+func (mmOrderCreate *mILomsServiceMockOrderCreate) Inspect(f func(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string)) *mILomsServiceMockOrderCreate {
 	if mmOrderCreate.mock.inspectFuncOrderCreate != nil {
 		mmOrderCreate.mock.t.Fatalf("Inspect function is already set for ILomsServiceMock.OrderCreate")
 	}
@@ -235,7 +271,7 @@ func (mmOrderCreate *mILomsServiceMockOrderCreate) Return(i1 int64, err error) *
 }
 
 // Set uses given function f to mock the ILomsService.OrderCreate method
-func (mmOrderCreate *mILomsServiceMockOrderCreate) Set(f func(ctx context.Context, user int64, items []models.CartItem) (i1 int64, err error)) *ILomsServiceMock {
+func (mmOrderCreate *mILomsServiceMockOrderCreate) Set(f func(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (i1 int64, err error)) *ILomsServiceMock {
 	if mmOrderCreate.defaultExpectation != nil {
 		mmOrderCreate.mock.t.Fatalf("Default expectation is already set for the ILomsService.OrderCreate method")
 	}
@@ -251,14 +287,14 @@ func (mmOrderCreate *mILomsServiceMockOrderCreate) Set(f func(ctx context.Contex
 
 // When sets expectation for the ILomsService.OrderCreate which will trigger the result defined by the following
 // Then helper
-func (mmOrderCreate *mILomsServiceMockOrderCreate) When(ctx context.Context, user int64, items []models.CartItem) *ILomsServiceMockOrderCreateExpectation {
+func (mmOrderCreate *mILomsServiceMockOrderCreate) When(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) *ILomsServiceMockOrderCreateExpectation {
 	if mmOrderCreate.mock.funcOrderCreate != nil {
 		mmOrderCreate.mock.t.Fatalf("ILomsServiceMock.OrderCreate mock is already set by Set")
 	}
 
 	expectation := &ILomsServiceMockOrderCreateExpectation{
 		mock:               mmOrderCreate.mock,
-		params:             &ILomsServiceMockOrderCreateParams{ctx, user, items},
+		params:             &ILomsServiceMockOrderCreateParams{ctx, user, items, idempotencyKey},
 		expectationOrigins: ILomsServiceMockOrderCreateExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmOrderCreate.expectations = append(mmOrderCreate.expectations, expectation)
@@ -293,17 +329,17 @@ func (mmOrderCreate *mILomsServiceMockOrderCreate) invocationsDone() bool {
 }
 
 // OrderCreate implements mm_service.ILomsService
-func (mmOrderCreate *ILomsServiceMock) OrderCreate(ctx context.Context, user int64, items []models.CartItem) (i1 int64, err error) {
+func (mmOrderCreate *ILomsServiceMock) OrderCreate(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (i1 int64, err error) {
 	mm_atomic.AddUint64(&mmOrderCreate.beforeOrderCreateCounter, 1)
 	defer mm_atomic.AddUint64(&mmOrderCreate.afterOrderCreateCounter, 1)
 
 	mmOrderCreate.t.Helper()
 
 	if mmOrderCreate.inspectFuncOrderCreate != nil {
-		mmOrderCreate.inspectFuncOrderCreate(ctx, user, items)
+		mmOrderCreate.inspectFuncOrderCreate(ctx, user, items, idempotencyKey)
 	}
 
-	mm_params := ILomsServiceMockOrderCreateParams{ctx, user, items}
+	mm_params := ILomsServiceMockOrderCreateParams{ctx, user, items, idempotencyKey}
 
 	// Record call args
 	mmOrderCreate.OrderCreateMock.mutex.Lock()
@@ -322,7 +358,7 @@ func (mmOrderCreate *ILomsServiceMock) OrderCreate(ctx context.Context, user int
 		mm_want := mmOrderCreate.OrderCreateMock.defaultExpectation.params
 		mm_want_ptrs := mmOrderCreate.OrderCreateMock.defaultExpectation.paramPtrs
 
-		mm_got := ILomsServiceMockOrderCreateParams{ctx, user, items}
+		mm_got := ILomsServiceMockOrderCreateParams{ctx, user, items, idempotencyKey}
 
 		if mm_want_ptrs != nil {
 
@@ -341,6 +377,11 @@ func (mmOrderCreate *ILomsServiceMock) OrderCreate(ctx context.Context, user int
 					mmOrderCreate.OrderCreateMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
 			}
 
+			if mm_want_ptrs.idempotencyKey != nil && !minimock.Equal(*mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey) {
+				mmOrderCreate.t.Errorf("ILomsServiceMock.OrderCreate got unexpected parameter idempotencyKey, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmOrderCreate.OrderCreateMock.defaultExpectation.expectationOrigins.originIdempotencyKey, *mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey, minimock.Diff(*mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmOrderCreate.t.Errorf("ILomsServiceMock.OrderCreate got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 				mmOrderCreate.OrderCreateMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
@@ -353,9 +394,9 @@ func (mmOrderCreate *ILomsServiceMock) OrderCreate(ctx context.Context, user int
 		return (*mm_results).i1, (*mm_results).err
 	}
 	if mmOrderCreate.funcOrderCreate != nil {
-		return mmOrderCreate.funcOrderCreate(ctx, user, items)
+		return mmOrderCreate.funcOrderCreate(ctx, user, items, idempotencyKey)
 	}
-	mmOrderCreate.t.Fatalf("Unexpected call to ILomsServiceMock.OrderCreate. %v %v %v", ctx, user, items)
+	mmOrderCreate.t.Fatalf("Unexpected call to ILomsServiceMock.OrderCreate. %v %v %v %v", ctx, user, items, idempotencyKey)
 	return
 }
 
@@ -770,6 +811,350 @@ func (m *ILomsServiceMock) MinimockStocksInfoInspect() {
 	}
 }
 
+type mILomsServiceMockStocksInfoBatch struct {
+	optional           bool
+	mock               *ILomsServiceMock
+	defaultExpectation *ILomsServiceMockStocksInfoBatchExpectation
+	expectations       []*ILomsServiceMockStocksInfoBatchExpectation
+
+	callArgs []*ILomsServiceMockStocksInfoBatchParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ILomsServiceMockStocksInfoBatchExpectation specifies expectation struct of the ILomsService.StocksInfoBatch
+type ILomsServiceMockStocksInfoBatchExpectation struct {
+	mock               *ILomsServiceMock
+	params             *ILomsServiceMockStocksInfoBatchParams
+	paramPtrs          *ILomsServiceMockStocksInfoBatchParamPtrs
+	expectationOrigins ILomsServiceMockStocksInfoBatchExpectationOrigins
+	results            *ILomsServiceMockStocksInfoBatchResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ILomsServiceMockStocksInfoBatchParams contains parameters of the ILomsService.StocksInfoBatch
+type ILomsServiceMockStocksInfoBatchParams struct {
+	ctx  context.Context
+	SKUs []models.SKU
+}
+
+// ILomsServiceMockStocksInfoBatchParamPtrs contains pointers to parameters of the ILomsService.StocksInfoBatch
+type ILomsServiceMockStocksInfoBatchParamPtrs struct {
+	ctx  *context.Context
+	SKUs *[]models.SKU
+}
+
+// ILomsServiceMockStocksInfoBatchResults contains results of the ILomsService.StocksInfoBatch
+type ILomsServiceMockStocksInfoBatchResults struct {
+	m1  map[models.SKU]int64
+	sa1 []models.SKU
+	err error
+}
+
+// ILomsServiceMockStocksInfoBatchOrigins contains origins of expectations of the ILomsService.StocksInfoBatch
+type ILomsServiceMockStocksInfoBatchExpectationOrigins struct {
+	origin     string
+	originCtx  string
+	originSKUs string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) Optional() *mILomsServiceMockStocksInfoBatch {
+	mmStocksInfoBatch.optional = true
+	return mmStocksInfoBatch
+}
+
+// Expect sets up expected params for ILomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) Expect(ctx context.Context, SKUs []models.SKU) *mILomsServiceMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("ILomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &ILomsServiceMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("ILomsServiceMock.StocksInfoBatch mock is already set by ExpectParams functions")
+	}
+
+	mmStocksInfoBatch.defaultExpectation.params = &ILomsServiceMockStocksInfoBatchParams{ctx, SKUs}
+	mmStocksInfoBatch.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmStocksInfoBatch.expectations {
+		if minimock.Equal(e.params, mmStocksInfoBatch.defaultExpectation.params) {
+			mmStocksInfoBatch.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmStocksInfoBatch.defaultExpectation.params)
+		}
+	}
+
+	return mmStocksInfoBatch
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ILomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) ExpectCtxParam1(ctx context.Context) *mILomsServiceMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("ILomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &ILomsServiceMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.params != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("ILomsServiceMock.StocksInfoBatch mock is already set by Expect")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs == nil {
+		mmStocksInfoBatch.defaultExpectation.paramPtrs = &ILomsServiceMockStocksInfoBatchParamPtrs{}
+	}
+	mmStocksInfoBatch.defaultExpectation.paramPtrs.ctx = &ctx
+	mmStocksInfoBatch.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmStocksInfoBatch
+}
+
+// ExpectSKUsParam2 sets up expected param SKUs for ILomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) ExpectSKUsParam2(SKUs []models.SKU) *mILomsServiceMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("ILomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &ILomsServiceMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.params != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("ILomsServiceMock.StocksInfoBatch mock is already set by Expect")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs == nil {
+		mmStocksInfoBatch.defaultExpectation.paramPtrs = &ILomsServiceMockStocksInfoBatchParamPtrs{}
+	}
+	mmStocksInfoBatch.defaultExpectation.paramPtrs.SKUs = &SKUs
+	mmStocksInfoBatch.defaultExpectation.expectationOrigins.originSKUs = minimock.CallerInfo(1)
+
+	return mmStocksInfoBatch
+}
+
+// Inspect accepts an inspector function that has same arguments as the ILomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) Inspect(f func(ctx context.Context, SKUs []models.SKU)) *mILomsServiceMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.inspectFuncStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("Inspect function is already set for ILomsServiceMock.StocksInfoBatch")
+	}
+
+	mmStocksInfoBatch.mock.inspectFuncStocksInfoBatch = f
+
+	return mmStocksInfoBatch
+}
+
+// Return sets up results that will be returned by ILomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) Return(m1 map[models.SKU]int64, sa1 []models.SKU, err error) *ILomsServiceMock {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("ILomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &ILomsServiceMockStocksInfoBatchExpectation{mock: mmStocksInfoBatch.mock}
+	}
+	mmStocksInfoBatch.defaultExpectation.results = &ILomsServiceMockStocksInfoBatchResults{m1, sa1, err}
+	mmStocksInfoBatch.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmStocksInfoBatch.mock
+}
+
+// Set uses given function f to mock the ILomsService.StocksInfoBatch method
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) Set(f func(ctx context.Context, SKUs []models.SKU) (m1 map[models.SKU]int64, sa1 []models.SKU, err error)) *ILomsServiceMock {
+	if mmStocksInfoBatch.defaultExpectation != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("Default expectation is already set for the ILomsService.StocksInfoBatch method")
+	}
+
+	if len(mmStocksInfoBatch.expectations) > 0 {
+		mmStocksInfoBatch.mock.t.Fatalf("Some expectations are already set for the ILomsService.StocksInfoBatch method")
+	}
+
+	mmStocksInfoBatch.mock.funcStocksInfoBatch = f
+	mmStocksInfoBatch.mock.funcStocksInfoBatchOrigin = minimock.CallerInfo(1)
+	return mmStocksInfoBatch.mock
+}
+
+// When sets expectation for the ILomsService.StocksInfoBatch which will trigger the result defined by the following
+// Then helper
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) When(ctx context.Context, SKUs []models.SKU) *ILomsServiceMockStocksInfoBatchExpectation {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("ILomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	expectation := &ILomsServiceMockStocksInfoBatchExpectation{
+		mock:               mmStocksInfoBatch.mock,
+		params:             &ILomsServiceMockStocksInfoBatchParams{ctx, SKUs},
+		expectationOrigins: ILomsServiceMockStocksInfoBatchExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmStocksInfoBatch.expectations = append(mmStocksInfoBatch.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ILomsService.StocksInfoBatch return parameters for the expectation previously defined by the When method
+func (e *ILomsServiceMockStocksInfoBatchExpectation) Then(m1 map[models.SKU]int64, sa1 []models.SKU, err error) *ILomsServiceMock {
+	e.results = &ILomsServiceMockStocksInfoBatchResults{m1, sa1, err}
+	return e.mock
+}
+
+// Times sets number of times ILomsService.StocksInfoBatch should be invoked
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) Times(n uint64) *mILomsServiceMockStocksInfoBatch {
+	if n == 0 {
+		mmStocksInfoBatch.mock.t.Fatalf("Times of ILomsServiceMock.StocksInfoBatch mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmStocksInfoBatch.expectedInvocations, n)
+	mmStocksInfoBatch.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmStocksInfoBatch
+}
+
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) invocationsDone() bool {
+	if len(mmStocksInfoBatch.expectations) == 0 && mmStocksInfoBatch.defaultExpectation == nil && mmStocksInfoBatch.mock.funcStocksInfoBatch == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmStocksInfoBatch.mock.afterStocksInfoBatchCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmStocksInfoBatch.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// StocksInfoBatch implements mm_service.ILomsService
+func (mmStocksInfoBatch *ILomsServiceMock) StocksInfoBatch(ctx context.Context, SKUs []models.SKU) (m1 map[models.SKU]int64, sa1 []models.SKU, err error) {
+	mm_atomic.AddUint64(&mmStocksInfoBatch.beforeStocksInfoBatchCounter, 1)
+	defer mm_atomic.AddUint64(&mmStocksInfoBatch.afterStocksInfoBatchCounter, 1)
+
+	mmStocksInfoBatch.t.Helper()
+
+	if mmStocksInfoBatch.inspectFuncStocksInfoBatch != nil {
+		mmStocksInfoBatch.inspectFuncStocksInfoBatch(ctx, SKUs)
+	}
+
+	mm_params := ILomsServiceMockStocksInfoBatchParams{ctx, SKUs}
+
+	// Record call args
+	mmStocksInfoBatch.StocksInfoBatchMock.mutex.Lock()
+	mmStocksInfoBatch.StocksInfoBatchMock.callArgs = append(mmStocksInfoBatch.StocksInfoBatchMock.callArgs, &mm_params)
+	mmStocksInfoBatch.StocksInfoBatchMock.mutex.Unlock()
+
+	for _, e := range mmStocksInfoBatch.StocksInfoBatchMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.m1, e.results.sa1, e.results.err
+		}
+	}
+
+	if mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.Counter, 1)
+		mm_want := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.params
+		mm_want_ptrs := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.paramPtrs
+
+		mm_got := ILomsServiceMockStocksInfoBatchParams{ctx, SKUs}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmStocksInfoBatch.t.Errorf("ILomsServiceMock.StocksInfoBatch got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.SKUs != nil && !minimock.Equal(*mm_want_ptrs.SKUs, mm_got.SKUs) {
+				mmStocksInfoBatch.t.Errorf("ILomsServiceMock.StocksInfoBatch got unexpected parameter SKUs, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.expectationOrigins.originSKUs, *mm_want_ptrs.SKUs, mm_got.SKUs, minimock.Diff(*mm_want_ptrs.SKUs, mm_got.SKUs))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmStocksInfoBatch.t.Errorf("ILomsServiceMock.StocksInfoBatch got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.results
+		if mm_results == nil {
+			mmStocksInfoBatch.t.Fatal("No results are set for the ILomsServiceMock.StocksInfoBatch")
+		}
+		return (*mm_results).m1, (*mm_results).sa1, (*mm_results).err
+	}
+	if mmStocksInfoBatch.funcStocksInfoBatch != nil {
+		return mmStocksInfoBatch.funcStocksInfoBatch(ctx, SKUs)
+	}
+	mmStocksInfoBatch.t.Fatalf("Unexpected call to ILomsServiceMock.StocksInfoBatch. %v %v", ctx, SKUs)
+	return
+}
+
+// StocksInfoBatchAfterCounter returns a count of finished ILomsServiceMock.StocksInfoBatch invocations
+func (mmStocksInfoBatch *ILomsServiceMock) StocksInfoBatchAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStocksInfoBatch.afterStocksInfoBatchCounter)
+}
+
+// StocksInfoBatchBeforeCounter returns a count of ILomsServiceMock.StocksInfoBatch invocations
+func (mmStocksInfoBatch *ILomsServiceMock) StocksInfoBatchBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStocksInfoBatch.beforeStocksInfoBatchCounter)
+}
+
+// Calls returns a list of arguments used in each call to ILomsServiceMock.StocksInfoBatch.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmStocksInfoBatch *mILomsServiceMockStocksInfoBatch) Calls() []*ILomsServiceMockStocksInfoBatchParams {
+	mmStocksInfoBatch.mutex.RLock()
+
+	argCopy := make([]*ILomsServiceMockStocksInfoBatchParams, len(mmStocksInfoBatch.callArgs))
+	copy(argCopy, mmStocksInfoBatch.callArgs)
+
+	mmStocksInfoBatch.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockStocksInfoBatchDone returns true if the count of the StocksInfoBatch invocations corresponds
+// the number of defined expectations
+func (m *ILomsServiceMock) MinimockStocksInfoBatchDone() bool {
+	if m.StocksInfoBatchMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.StocksInfoBatchMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.StocksInfoBatchMock.invocationsDone()
+}
+
+// MinimockStocksInfoBatchInspect logs each unmet expectation
+func (m *ILomsServiceMock) MinimockStocksInfoBatchInspect() {
+	for _, e := range m.StocksInfoBatchMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ILomsServiceMock.StocksInfoBatch at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterStocksInfoBatchCounter := mm_atomic.LoadUint64(&m.afterStocksInfoBatchCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.StocksInfoBatchMock.defaultExpectation != nil && afterStocksInfoBatchCounter < 1 {
+		if m.StocksInfoBatchMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ILomsServiceMock.StocksInfoBatch at\n%s", m.StocksInfoBatchMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ILomsServiceMock.StocksInfoBatch at\n%s with params: %#v", m.StocksInfoBatchMock.defaultExpectation.expectationOrigins.origin, *m.StocksInfoBatchMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcStocksInfoBatch != nil && afterStocksInfoBatchCounter < 1 {
+		m.t.Errorf("Expected call to ILomsServiceMock.StocksInfoBatch at\n%s", m.funcStocksInfoBatchOrigin)
+	}
+
+	if !m.StocksInfoBatchMock.invocationsDone() && afterStocksInfoBatchCounter > 0 {
+		m.t.Errorf("Expected %d calls to ILomsServiceMock.StocksInfoBatch at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.StocksInfoBatchMock.expectedInvocations), m.StocksInfoBatchMock.expectedInvocationsOrigin, afterStocksInfoBatchCounter)
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *ILomsServiceMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
@@ -777,6 +1162,8 @@ func (m *ILomsServiceMock) MinimockFinish() {
 			m.MinimockOrderCreateInspect()
 
 			m.MinimockStocksInfoInspect()
+
+			m.MinimockStocksInfoBatchInspect()
 		}
 	})
 }
@@ -801,5 +1188,6 @@ func (m *ILomsServiceMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockOrderCreateDone() &&
-		m.MinimockStocksInfoDone()
+		m.MinimockStocksInfoDone() &&
+		m.MinimockStocksInfoBatchDone()
 }
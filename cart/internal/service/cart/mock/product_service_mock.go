@@ -25,6 +25,13 @@ type IProductServiceMock struct {
 	afterGetProductCounter  uint64
 	beforeGetProductCounter uint64
 	GetProductMock          mIProductServiceMockGetProduct
+
+	funcRefreshProduct          func(ctx context.Context, SKU models.SKU) (gp1 *models.GetProductResponse, err error)
+	funcRefreshProductOrigin    string
+	inspectFuncRefreshProduct   func(ctx context.Context, SKU models.SKU)
+	afterRefreshProductCounter  uint64
+	beforeRefreshProductCounter uint64
+	RefreshProductMock          mIProductServiceMockRefreshProduct
 }
 
 // NewIProductServiceMock returns a mock for mm_service.IProductService
@@ -38,6 +45,9 @@ func NewIProductServiceMock(t minimock.Tester) *IProductServiceMock {
 	m.GetProductMock = mIProductServiceMockGetProduct{mock: m}
 	m.GetProductMock.callArgs = []*IProductServiceMockGetProductParams{}
 
+	m.RefreshProductMock = mIProductServiceMockRefreshProduct{mock: m}
+	m.RefreshProductMock.callArgs = []*IProductServiceMockRefreshProductParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
@@ -386,11 +396,356 @@ func (m *IProductServiceMock) MinimockGetProductInspect() {
 	}
 }
 
+type mIProductServiceMockRefreshProduct struct {
+	optional           bool
+	mock               *IProductServiceMock
+	defaultExpectation *IProductServiceMockRefreshProductExpectation
+	expectations       []*IProductServiceMockRefreshProductExpectation
+
+	callArgs []*IProductServiceMockRefreshProductParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IProductServiceMockRefreshProductExpectation specifies expectation struct of the IProductService.RefreshProduct
+type IProductServiceMockRefreshProductExpectation struct {
+	mock               *IProductServiceMock
+	params             *IProductServiceMockRefreshProductParams
+	paramPtrs          *IProductServiceMockRefreshProductParamPtrs
+	expectationOrigins IProductServiceMockRefreshProductExpectationOrigins
+	results            *IProductServiceMockRefreshProductResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IProductServiceMockRefreshProductParams contains parameters of the IProductService.RefreshProduct
+type IProductServiceMockRefreshProductParams struct {
+	ctx context.Context
+	SKU models.SKU
+}
+
+// IProductServiceMockRefreshProductParamPtrs contains pointers to parameters of the IProductService.RefreshProduct
+type IProductServiceMockRefreshProductParamPtrs struct {
+	ctx *context.Context
+	SKU *models.SKU
+}
+
+// IProductServiceMockRefreshProductResults contains results of the IProductService.RefreshProduct
+type IProductServiceMockRefreshProductResults struct {
+	gp1 *models.GetProductResponse
+	err error
+}
+
+// IProductServiceMockRefreshProductOrigins contains origins of expectations of the IProductService.RefreshProduct
+type IProductServiceMockRefreshProductExpectationOrigins struct {
+	origin    string
+	originCtx string
+	originSKU string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) Optional() *mIProductServiceMockRefreshProduct {
+	mmRefreshProduct.optional = true
+	return mmRefreshProduct
+}
+
+// Expect sets up expected params for IProductService.RefreshProduct
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) Expect(ctx context.Context, SKU models.SKU) *mIProductServiceMockRefreshProduct {
+	if mmRefreshProduct.mock.funcRefreshProduct != nil {
+		mmRefreshProduct.mock.t.Fatalf("IProductServiceMock.RefreshProduct mock is already set by Set")
+	}
+
+	if mmRefreshProduct.defaultExpectation == nil {
+		mmRefreshProduct.defaultExpectation = &IProductServiceMockRefreshProductExpectation{}
+	}
+
+	if mmRefreshProduct.defaultExpectation.paramPtrs != nil {
+		mmRefreshProduct.mock.t.Fatalf("IProductServiceMock.RefreshProduct mock is already set by ExpectParams functions")
+	}
+
+	mmRefreshProduct.defaultExpectation.params = &IProductServiceMockRefreshProductParams{ctx, SKU}
+	mmRefreshProduct.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmRefreshProduct.expectations {
+		if minimock.Equal(e.params, mmRefreshProduct.defaultExpectation.params) {
+			mmRefreshProduct.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmRefreshProduct.defaultExpectation.params)
+		}
+	}
+
+	return mmRefreshProduct
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IProductService.RefreshProduct
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) ExpectCtxParam1(ctx context.Context) *mIProductServiceMockRefreshProduct {
+	if mmRefreshProduct.mock.funcRefreshProduct != nil {
+		mmRefreshProduct.mock.t.Fatalf("IProductServiceMock.RefreshProduct mock is already set by Set")
+	}
+
+	if mmRefreshProduct.defaultExpectation == nil {
+		mmRefreshProduct.defaultExpectation = &IProductServiceMockRefreshProductExpectation{}
+	}
+
+	if mmRefreshProduct.defaultExpectation.params != nil {
+		mmRefreshProduct.mock.t.Fatalf("IProductServiceMock.RefreshProduct mock is already set by Expect")
+	}
+
+	if mmRefreshProduct.defaultExpectation.paramPtrs == nil {
+		mmRefreshProduct.defaultExpectation.paramPtrs = &IProductServiceMockRefreshProductParamPtrs{}
+	}
+	mmRefreshProduct.defaultExpectation.paramPtrs.ctx = &ctx
+	mmRefreshProduct.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmRefreshProduct
+}
+
+// ExpectSKUParam2 sets up expected param SKU for IProductService.RefreshProduct
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) ExpectSKUParam2(SKU models.SKU) *mIProductServiceMockRefreshProduct {
+	if mmRefreshProduct.mock.funcRefreshProduct != nil {
+		mmRefreshProduct.mock.t.Fatalf("IProductServiceMock.RefreshProduct mock is already set by Set")
+	}
+
+	if mmRefreshProduct.defaultExpectation == nil {
+		mmRefreshProduct.defaultExpectation = &IProductServiceMockRefreshProductExpectation{}
+	}
+
+	if mmRefreshProduct.defaultExpectation.params != nil {
+		mmRefreshProduct.mock.t.Fatalf("IProductServiceMock.RefreshProduct mock is already set by Expect")
+	}
+
+	if mmRefreshProduct.defaultExpectation.paramPtrs == nil {
+		mmRefreshProduct.defaultExpectation.paramPtrs = &IProductServiceMockRefreshProductParamPtrs{}
+	}
+	mmRefreshProduct.defaultExpectation.paramPtrs.SKU = &SKU
+	mmRefreshProduct.defaultExpectation.expectationOrigins.originSKU = minimock.CallerInfo(1)
+
+	return mmRefreshProduct
+}
+
+// Inspect accepts an inspector function that has same arguments as the IProductService.RefreshProduct
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) Inspect(f func(ctx context.Context, SKU models.SKU)) *mIProductServiceMockRefreshProduct {
+	if mmRefreshProduct.mock.inspectFuncRefreshProduct != nil {
+		mmRefreshProduct.mock.t.Fatalf("Inspect function is already set for IProductServiceMock.RefreshProduct")
+	}
+
+	mmRefreshProduct.mock.inspectFuncRefreshProduct = f
+
+	return mmRefreshProduct
+}
+
+// Return sets up results that will be returned by IProductService.RefreshProduct
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) Return(gp1 *models.GetProductResponse, err error) *IProductServiceMock {
+	if mmRefreshProduct.mock.funcRefreshProduct != nil {
+		mmRefreshProduct.mock.t.Fatalf("IProductServiceMock.RefreshProduct mock is already set by Set")
+	}
+
+	if mmRefreshProduct.defaultExpectation == nil {
+		mmRefreshProduct.defaultExpectation = &IProductServiceMockRefreshProductExpectation{mock: mmRefreshProduct.mock}
+	}
+	mmRefreshProduct.defaultExpectation.results = &IProductServiceMockRefreshProductResults{gp1, err}
+	mmRefreshProduct.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmRefreshProduct.mock
+}
+
+// Set uses given function f to mock the IProductService.RefreshProduct method
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) Set(f func(ctx context.Context, SKU models.SKU) (gp1 *models.GetProductResponse, err error)) *IProductServiceMock {
+	if mmRefreshProduct.defaultExpectation != nil {
+		mmRefreshProduct.mock.t.Fatalf("Default expectation is already set for the IProductService.RefreshProduct method")
+	}
+
+	if len(mmRefreshProduct.expectations) > 0 {
+		mmRefreshProduct.mock.t.Fatalf("Some expectations are already set for the IProductService.RefreshProduct method")
+	}
+
+	mmRefreshProduct.mock.funcRefreshProduct = f
+	mmRefreshProduct.mock.funcRefreshProductOrigin = minimock.CallerInfo(1)
+	return mmRefreshProduct.mock
+}
+
+// When sets expectation for the IProductService.RefreshProduct which will trigger the result defined by the following
+// Then helper
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) When(ctx context.Context, SKU models.SKU) *IProductServiceMockRefreshProductExpectation {
+	if mmRefreshProduct.mock.funcRefreshProduct != nil {
+		mmRefreshProduct.mock.t.Fatalf("IProductServiceMock.RefreshProduct mock is already set by Set")
+	}
+
+	expectation := &IProductServiceMockRefreshProductExpectation{
+		mock:               mmRefreshProduct.mock,
+		params:             &IProductServiceMockRefreshProductParams{ctx, SKU},
+		expectationOrigins: IProductServiceMockRefreshProductExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmRefreshProduct.expectations = append(mmRefreshProduct.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IProductService.RefreshProduct return parameters for the expectation previously defined by the When method
+func (e *IProductServiceMockRefreshProductExpectation) Then(gp1 *models.GetProductResponse, err error) *IProductServiceMock {
+	e.results = &IProductServiceMockRefreshProductResults{gp1, err}
+	return e.mock
+}
+
+// Times sets number of times IProductService.RefreshProduct should be invoked
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) Times(n uint64) *mIProductServiceMockRefreshProduct {
+	if n == 0 {
+		mmRefreshProduct.mock.t.Fatalf("Times of IProductServiceMock.RefreshProduct mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmRefreshProduct.expectedInvocations, n)
+	mmRefreshProduct.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmRefreshProduct
+}
+
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) invocationsDone() bool {
+	if len(mmRefreshProduct.expectations) == 0 && mmRefreshProduct.defaultExpectation == nil && mmRefreshProduct.mock.funcRefreshProduct == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmRefreshProduct.mock.afterRefreshProductCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmRefreshProduct.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// RefreshProduct implements mm_service.IProductService
+func (mmRefreshProduct *IProductServiceMock) RefreshProduct(ctx context.Context, SKU models.SKU) (gp1 *models.GetProductResponse, err error) {
+	mm_atomic.AddUint64(&mmRefreshProduct.beforeRefreshProductCounter, 1)
+	defer mm_atomic.AddUint64(&mmRefreshProduct.afterRefreshProductCounter, 1)
+
+	mmRefreshProduct.t.Helper()
+
+	if mmRefreshProduct.inspectFuncRefreshProduct != nil {
+		mmRefreshProduct.inspectFuncRefreshProduct(ctx, SKU)
+	}
+
+	mm_params := IProductServiceMockRefreshProductParams{ctx, SKU}
+
+	// Record call args
+	mmRefreshProduct.RefreshProductMock.mutex.Lock()
+	mmRefreshProduct.RefreshProductMock.callArgs = append(mmRefreshProduct.RefreshProductMock.callArgs, &mm_params)
+	mmRefreshProduct.RefreshProductMock.mutex.Unlock()
+
+	for _, e := range mmRefreshProduct.RefreshProductMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.gp1, e.results.err
+		}
+	}
+
+	if mmRefreshProduct.RefreshProductMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmRefreshProduct.RefreshProductMock.defaultExpectation.Counter, 1)
+		mm_want := mmRefreshProduct.RefreshProductMock.defaultExpectation.params
+		mm_want_ptrs := mmRefreshProduct.RefreshProductMock.defaultExpectation.paramPtrs
+
+		mm_got := IProductServiceMockRefreshProductParams{ctx, SKU}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmRefreshProduct.t.Errorf("IProductServiceMock.RefreshProduct got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRefreshProduct.RefreshProductMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.SKU != nil && !minimock.Equal(*mm_want_ptrs.SKU, mm_got.SKU) {
+				mmRefreshProduct.t.Errorf("IProductServiceMock.RefreshProduct got unexpected parameter SKU, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRefreshProduct.RefreshProductMock.defaultExpectation.expectationOrigins.originSKU, *mm_want_ptrs.SKU, mm_got.SKU, minimock.Diff(*mm_want_ptrs.SKU, mm_got.SKU))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmRefreshProduct.t.Errorf("IProductServiceMock.RefreshProduct got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmRefreshProduct.RefreshProductMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmRefreshProduct.RefreshProductMock.defaultExpectation.results
+		if mm_results == nil {
+			mmRefreshProduct.t.Fatal("No results are set for the IProductServiceMock.RefreshProduct")
+		}
+		return (*mm_results).gp1, (*mm_results).err
+	}
+	if mmRefreshProduct.funcRefreshProduct != nil {
+		return mmRefreshProduct.funcRefreshProduct(ctx, SKU)
+	}
+	mmRefreshProduct.t.Fatalf("Unexpected call to IProductServiceMock.RefreshProduct. %v %v", ctx, SKU)
+	return
+}
+
+// RefreshProductAfterCounter returns a count of finished IProductServiceMock.RefreshProduct invocations
+func (mmRefreshProduct *IProductServiceMock) RefreshProductAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRefreshProduct.afterRefreshProductCounter)
+}
+
+// RefreshProductBeforeCounter returns a count of IProductServiceMock.RefreshProduct invocations
+func (mmRefreshProduct *IProductServiceMock) RefreshProductBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRefreshProduct.beforeRefreshProductCounter)
+}
+
+// Calls returns a list of arguments used in each call to IProductServiceMock.RefreshProduct.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmRefreshProduct *mIProductServiceMockRefreshProduct) Calls() []*IProductServiceMockRefreshProductParams {
+	mmRefreshProduct.mutex.RLock()
+
+	argCopy := make([]*IProductServiceMockRefreshProductParams, len(mmRefreshProduct.callArgs))
+	copy(argCopy, mmRefreshProduct.callArgs)
+
+	mmRefreshProduct.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockRefreshProductDone returns true if the count of the RefreshProduct invocations corresponds
+// the number of defined expectations
+func (m *IProductServiceMock) MinimockRefreshProductDone() bool {
+	if m.RefreshProductMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.RefreshProductMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.RefreshProductMock.invocationsDone()
+}
+
+// MinimockRefreshProductInspect logs each unmet expectation
+func (m *IProductServiceMock) MinimockRefreshProductInspect() {
+	for _, e := range m.RefreshProductMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IProductServiceMock.RefreshProduct at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterRefreshProductCounter := mm_atomic.LoadUint64(&m.afterRefreshProductCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.RefreshProductMock.defaultExpectation != nil && afterRefreshProductCounter < 1 {
+		if m.RefreshProductMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IProductServiceMock.RefreshProduct at\n%s", m.RefreshProductMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IProductServiceMock.RefreshProduct at\n%s with params: %#v", m.RefreshProductMock.defaultExpectation.expectationOrigins.origin, *m.RefreshProductMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcRefreshProduct != nil && afterRefreshProductCounter < 1 {
+		m.t.Errorf("Expected call to IProductServiceMock.RefreshProduct at\n%s", m.funcRefreshProductOrigin)
+	}
+
+	if !m.RefreshProductMock.invocationsDone() && afterRefreshProductCounter > 0 {
+		m.t.Errorf("Expected %d calls to IProductServiceMock.RefreshProduct at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.RefreshProductMock.expectedInvocations), m.RefreshProductMock.expectedInvocationsOrigin, afterRefreshProductCounter)
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *IProductServiceMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
 			m.MinimockGetProductInspect()
+
+			m.MinimockRefreshProductInspect()
 		}
 	})
 }
@@ -414,5 +769,6 @@ func (m *IProductServiceMock) MinimockWait(timeout mm_time.Duration) {
 func (m *IProductServiceMock) minimockDone() bool {
 	done := true
 	return done &&
-		m.MinimockGetProductDone()
+		m.MinimockGetProductDone() &&
+		m.MinimockRefreshProductDone()
 }
@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"route256/cart/internal/models"
 	"route256/cart/internal/pkg/errgroup"
 	internal_errors "route256/cart/internal/pkg/errors"
+	"route256/utils/logger"
 	"sync"
 
 	"go.opentelemetry.io/otel"
@@ -13,20 +15,26 @@ import (
 
 const getCartGoroutineLimit = 10
 
+// removeOrderedItemsAttempts is max number of reads of cart changed concurrently with removal of ordered items.
+const removeOrderedItemsAttempts = 3
+
 type ICartRepository interface {
-	AddItem(ctx context.Context, UID models.UID, item models.CartItem) error
-	DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU) error
-	DeleteItemsByUserID(ctx context.Context, UID models.UID) error
-	GetItemsByUserID(ctx context.Context, UID models.UID) ([]models.CartItem, error)
+	AddItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (uint64, error)
+	SetItem(ctx context.Context, UID models.UID, item models.CartItem, version uint64) (uint64, error)
+	DeleteItem(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (uint64, error)
+	DeleteItemsByUserID(ctx context.Context, UID models.UID, version uint64) (uint64, error)
+	GetItemsByUserID(ctx context.Context, UID models.UID) ([]models.CartItem, uint64, error)
 }
 
 type IProductService interface {
 	GetProduct(ctx context.Context, SKU models.SKU) (*models.GetProductResponse, error)
+	RefreshProduct(ctx context.Context, SKU models.SKU) (*models.GetProductResponse, error)
 }
 
 type ILomsService interface {
-	OrderCreate(ctx context.Context, user int64, items []models.CartItem) (int64, error)
+	OrderCreate(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (int64, error)
 	StocksInfo(ctx context.Context, SKU models.SKU) (int64, error)
+	StocksInfoBatch(ctx context.Context, SKUs []models.SKU) (map[models.SKU]int64, []models.SKU, error)
 }
 
 type CartService struct {
@@ -45,91 +53,136 @@ func NewService(repository ICartRepository, productService IProductService, loms
 }
 
 // AddProduct function for add product into cart.
-func (s *CartService) AddProduct(ctx context.Context, UID models.UID, SKU models.SKU, Count uint16) error {
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (s *CartService) AddProduct(ctx context.Context, UID models.UID, SKU models.SKU, Count uint16, version uint64) (uint64, error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartService").Start(ctx, "AddProduct")
 	defer span.End()
 
 	if UID < 1 || SKU < 1 || Count < 1 {
-		return fmt.Errorf("UID, SKU and Count must be greater than zero: %w", internal_errors.ErrBadRequest)
+		return 0, fmt.Errorf("UID, SKU and Count must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
-	_, err := s.productService.GetProduct(ctx, SKU)
+	product, err := s.productService.GetProduct(ctx, SKU)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	stocks, err := s.lomsService.StocksInfo(ctx, SKU)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	if stocks < int64(Count) {
-		return fmt.Errorf("number of stocks: %d less than required count: %d, err: %w", stocks, Count, internal_errors.ErrBadRequest)
+		return 0, fmt.Errorf("number of stocks: %d less than required count: %d, err: %w", stocks, Count, internal_errors.ErrBadRequest)
 	}
 
 	item := models.CartItem{
 		SKU:   SKU,
 		Count: Count,
+		Price: product.Price,
 	}
 
-	err = s.repository.AddItem(ctx, UID, item)
+	newVersion, err := s.repository.AddItem(ctx, UID, item, version)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return newVersion, nil
+}
+
+// SetProductCount function for set count of product in cart, product with zero count is deleted from cart.
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (s *CartService) SetProductCount(ctx context.Context, UID models.UID, SKU models.SKU, Count uint16, version uint64) (uint64, error) {
+	// Tracer
+	ctx, span := otel.Tracer("CartService").Start(ctx, "SetProductCount")
+	defer span.End()
+
+	if UID < 1 || SKU < 1 {
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	item := models.CartItem{
+		SKU:   SKU,
+		Count: Count,
+	}
+
+	if Count > 0 {
+		product, err := s.productService.GetProduct(ctx, SKU)
+		if err != nil {
+			return 0, err
+		}
+		item.Price = product.Price
+
+		stocks, err := s.lomsService.StocksInfo(ctx, SKU)
+		if err != nil {
+			return 0, err
+		}
+
+		if stocks < int64(Count) {
+			return 0, fmt.Errorf("number of stocks: %d less than required count: %d, err: %w", stocks, Count, internal_errors.ErrBadRequest)
+		}
+	}
+
+	newVersion, err := s.repository.SetItem(ctx, UID, item, version)
+	if err != nil {
+		return 0, err
+	}
+
+	return newVersion, nil
 }
 
 // DelProduct function for delete product from cart.
-func (s *CartService) DelProduct(ctx context.Context, UID models.UID, SKU models.SKU) error {
+// Cart is changed only if version is zero or equals version of cart, new version of cart is returned.
+func (s *CartService) DelProduct(ctx context.Context, UID models.UID, SKU models.SKU, version uint64) (uint64, error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartService").Start(ctx, "DelProduct")
 	defer span.End()
 
 	if UID < 1 || SKU < 1 {
-		return fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+		return 0, fmt.Errorf("UID and SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
-	err := s.repository.DeleteItem(ctx, UID, SKU)
+	newVersion, err := s.repository.DeleteItem(ctx, UID, SKU, version)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return newVersion, nil
 }
 
 // DelCart function for delete user cart.
-func (s *CartService) DelCart(ctx context.Context, UID models.UID) error {
+// Cart is deleted only if version is zero or equals version of cart, new version of cart is returned.
+func (s *CartService) DelCart(ctx context.Context, UID models.UID, version uint64) (uint64, error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartService").Start(ctx, "DelCart")
 	defer span.End()
 
 	if UID < 1 {
-		return fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
+		return 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
-	err := s.repository.DeleteItemsByUserID(ctx, UID)
+	newVersion, err := s.repository.DeleteItemsByUserID(ctx, UID, version)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return newVersion, nil
 }
 
-// GetCart function for get user cart.
-func (s *CartService) GetCart(ctx context.Context, UID models.UID) ([]models.CartItemResponse, uint32, error) {
+// GetCart function for get user cart and its version.
+func (s *CartService) GetCart(ctx context.Context, UID models.UID) ([]models.CartItemResponse, uint32, uint64, error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartService").Start(ctx, "GetCart")
 	defer span.End()
 
 	if UID < 1 {
-		return nil, 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
+		return nil, 0, 0, fmt.Errorf("UID must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
-	cartItems, err := s.repository.GetItemsByUserID(ctx, UID)
+	cartItems, version, err := s.repository.GetItemsByUserID(ctx, UID)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, 0, err
 	}
 
 	var (
@@ -169,32 +222,221 @@ func (s *CartService) GetCart(ctx context.Context, UID models.UID) ([]models.Car
 	}
 
 	if err := g.Wait(); err != nil {
-		return nil, 0, err
+		return nil, 0, 0, err
 	}
 
-	return items, totalPrice, nil
+	return items, totalPrice, version, nil
 }
 
 // Checkout function for create order.
-func (s *CartService) Checkout(ctx context.Context, UID models.UID) (int64, error) {
+// Order is created only if version is zero or equals version of cart, so order has items user saw.
+// Products and stocks of all items are checked first, CheckoutError lists items which can not be ordered.
+func (s *CartService) Checkout(ctx context.Context, UID models.UID, version uint64) (int64, error) {
 	// Tracer
 	ctx, span := otel.Tracer("CartService").Start(ctx, "Checkout")
 	defer span.End()
 
-	cartItems, err := s.repository.GetItemsByUserID(ctx, UID)
+	cartItems, cartVersion, err := s.repository.GetItemsByUserID(ctx, UID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get cart items: %w", err)
 	}
 
-	orderID, err := s.lomsService.OrderCreate(ctx, int64(UID), cartItems)
+	if version != 0 && version != cartVersion {
+		return 0, fmt.Errorf("cart version %d does not match %d: %w", cartVersion, version, internal_errors.ErrPreconditionFailed)
+	}
+
+	products, problems, err := s.checkItems(ctx, cartItems)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check cart items: %w", err)
+	}
+	if len(problems) > 0 {
+		newVersion := s.updatePrices(ctx, UID, cartItems, products, cartVersion)
+		return 0, &internal_errors.CheckoutError{Problems: problems, Version: newVersion}
+	}
+
+	// Key is derived from read version of cart, so repeated checkout of the same cart creates order only once
+	orderID, err := s.lomsService.OrderCreate(ctx, int64(UID), cartItems, checkoutIdempotencyKey(UID, cartVersion))
 	if err != nil {
 		return 0, fmt.Errorf("failed to create order: %w", err)
 	}
 
-	err = s.repository.DeleteItemsByUserID(ctx, UID)
+	// Order has items of read version only, so only ordered items are removed from cart changed while order was created
+	_, err = s.repository.DeleteItemsByUserID(ctx, UID, cartVersion)
+	if errors.Is(err, internal_errors.ErrPreconditionFailed) {
+		logger.Infow(ctx, "Cart changed during checkout, ordered items are removed", "user", UID, "order", orderID)
+		err = s.removeOrderedItems(ctx, UID, cartItems)
+	}
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete items by user ID: %w", err)
 	}
 
 	return orderID, nil
 }
+
+// removeOrderedItems subtracts counts of ordered items from cart. Every item is changed with version of cart
+// it was read with, so changes made concurrently are kept and cart is read again.
+func (s *CartService) removeOrderedItems(ctx context.Context, UID models.UID, ordered []models.CartItem) error {
+	// Counts not yet subtracted
+	remaining := make(map[models.SKU]uint16, len(ordered))
+	for _, item := range ordered {
+		remaining[item.SKU] = item.Count
+	}
+
+	for attempt := 0; attempt < removeOrderedItemsAttempts; attempt++ {
+		items, version, err := s.repository.GetItemsByUserID(ctx, UID)
+		if errors.Is(err, internal_errors.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get cart items: %w", err)
+		}
+
+		err = s.subtractItems(ctx, UID, items, version, remaining)
+		if errors.Is(err, internal_errors.ErrPreconditionFailed) {
+			continue
+		}
+		return err
+	}
+
+	logger.Errorw(ctx, "Cart kept changing during checkout, ordered items are kept", "user", UID)
+	return nil
+}
+
+// subtractItems subtracts remaining counts from items of cart read with version, subtracted counts are removed
+// from remaining. Items removed from cart concurrently are not subtracted.
+func (s *CartService) subtractItems(ctx context.Context, UID models.UID, items []models.CartItem, version uint64, remaining map[models.SKU]uint16) error {
+	for _, item := range items {
+		count, ok := remaining[item.SKU]
+		if !ok {
+			continue
+		}
+
+		item.Count -= min(item.Count, count)
+
+		var err error
+		version, err = s.repository.SetItem(ctx, UID, item, version)
+		if err != nil {
+			return err
+		}
+		delete(remaining, item.SKU)
+	}
+
+	clear(remaining)
+	return nil
+}
+
+// checkoutIdempotencyKey returns idempotency key of order created from cart of version. Every change of cart
+// takes new version, so key differs for every cart content.
+func checkoutIdempotencyKey(UID models.UID, version uint64) string {
+	return fmt.Sprintf("cart-%d-%d", UID, version)
+}
+
+// checkItems fetches fresh products and stocks of items concurrently and returns products of items,
+// nil for unknown product, and problems of items which can not be ordered.
+func (s *CartService) checkItems(ctx context.Context, items []models.CartItem) ([]*models.GetProductResponse, []internal_errors.CheckoutProblem, error) {
+	var (
+		products = make([]*models.GetProductResponse, len(items))
+		stocks   map[models.SKU]int64
+	)
+
+	sem := make(chan struct{}, getCartGoroutineLimit)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	// Stocks of all items in one request
+	g.Go(func() error {
+		SKUs := make([]models.SKU, 0, len(items))
+		for _, item := range items {
+			SKUs = append(SKUs, item.SKU)
+		}
+
+		// Unknown SKUs have no stocks
+		counts, _, err := s.lomsService.StocksInfoBatch(gCtx, SKUs)
+		if err != nil {
+			return err
+		}
+		stocks = counts
+		return nil
+	})
+
+	// Products bypassing cache
+	for i, item := range items {
+		i, item := i, item
+
+		sem <- struct{}{}
+
+		g.Go(func() error {
+			defer func() { <-sem }()
+
+			// Only unknown SKU is problem of item, failure of Product Service fails checkout
+			product, err := s.productService.RefreshProduct(gCtx, item.SKU)
+			if errors.Is(err, internal_errors.ErrPreconditionFailed) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			products[i] = product
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	var problems []internal_errors.CheckoutProblem
+	for i, item := range items {
+		problem := internal_errors.CheckoutProblem{
+			SKU:       item.SKU,
+			Count:     item.Count,
+			Available: stocks[item.SKU],
+			CartPrice: item.Price,
+		}
+
+		product := products[i]
+		if product == nil {
+			problem.Reason = internal_errors.CheckoutProblemProductNotFound
+			problems = append(problems, problem)
+		} else {
+			problem.Price = product.Price
+		}
+
+		if problem.Available < int64(item.Count) {
+			problem.Reason = internal_errors.CheckoutProblemInsufficientStock
+			problems = append(problems, problem)
+		}
+
+		// Price is unknown for items added before prices were kept
+		if product != nil && item.Price != 0 && item.Price != product.Price {
+			problem.Reason = internal_errors.CheckoutProblemPriceChanged
+			problems = append(problems, problem)
+		}
+	}
+
+	return products, problems, nil
+}
+
+// updatePrices saves fresh prices of items into cart, so checkout after user saw new prices succeeds.
+// Cart changed since version is not updated. Version of cart after last saved price is returned.
+func (s *CartService) updatePrices(ctx context.Context, UID models.UID, items []models.CartItem, products []*models.GetProductResponse, version uint64) uint64 {
+	for i, item := range items {
+		product := products[i]
+		if product == nil || item.Price == product.Price {
+			continue
+		}
+
+		item.Price = product.Price
+
+		newVersion, err := s.repository.SetItem(ctx, UID, item, version)
+		if err != nil {
+			logger.Errorw(ctx, "Failed to update price of cart item", "user", UID, "sku", item.SKU, "error", err)
+			return version
+		}
+
+		version = newVersion
+	}
+
+	return version
+}
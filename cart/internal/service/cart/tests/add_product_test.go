@@ -37,10 +37,10 @@ func TestCartService_AddProduct_Table(t *testing.T) {
 					require.Equal(t, models.SKU(100), sku)
 					return int64(5), nil
 				})
-				repoMock.AddItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem) error {
+				repoMock.AddItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem, version uint64) (uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					require.Equal(t, models.CartItem{SKU: 100, Count: 2}, item)
-					return nil
+					require.Equal(t, models.CartItem{SKU: 100, Count: 2, Price: 400}, item)
+					return 1, nil
 				})
 			},
 			expectedErr: nil,
@@ -147,10 +147,10 @@ func TestCartService_AddProduct_Table(t *testing.T) {
 					require.Equal(t, models.SKU(100), sku)
 					return int64(10), nil
 				})
-				repoMock.AddItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem) error {
+				repoMock.AddItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem, version uint64) (uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					require.Equal(t, models.CartItem{SKU: 100, Count: 3}, item)
-					return ErrRepository
+					require.Equal(t, models.CartItem{SKU: 100, Count: 3, Price: 400}, item)
+					return 0, ErrRepository
 				})
 			},
 			expectedErr: ErrRepository,
@@ -166,7 +166,7 @@ func TestCartService_AddProduct_Table(t *testing.T) {
 
 			tt.setupMocks(repoMock, productServiceMock, lomsServiceMock)
 
-			err := service.AddProduct(ctx, tt.UID, tt.SKU, tt.count)
+			_, err := service.AddProduct(ctx, tt.UID, tt.SKU, tt.count, 0)
 
 			if tt.expectedErr != nil {
 				require.Error(t, err)
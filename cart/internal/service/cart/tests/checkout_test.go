@@ -3,46 +3,71 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
 	"route256/cart/internal/models"
 	internal_errors "route256/cart/internal/pkg/errors"
+	repository "route256/cart/internal/repository/cart"
+	service "route256/cart/internal/service/cart"
 	"route256/cart/internal/service/cart/mock"
 
+	"github.com/gojuno/minimock/v3"
+
 	"github.com/stretchr/testify/require"
 )
 
+// setupAvailable function for setup products with price and stocks for all SKUs.
+func setupAvailable(productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock, price uint32, stocks int64) {
+	productServiceMock.RefreshProductMock.Set(func(ctx context.Context, sku models.SKU) (*models.GetProductResponse, error) {
+		return &models.GetProductResponse{Name: "Книга", Price: price}, nil
+	})
+	lomsServiceMock.StocksInfoBatchMock.Set(func(ctx context.Context, skus []models.SKU) (map[models.SKU]int64, []models.SKU, error) {
+		counts := make(map[models.SKU]int64, len(skus))
+		for _, sku := range skus {
+			counts[sku] = stocks
+		}
+		return counts, nil, nil
+	})
+}
+
 // TestCartService_Checkout_Table function for tests the Checkout method of CartService.
 func TestCartService_Checkout_Table(t *testing.T) {
 	tests := []struct {
-		name          string
-		UID           models.UID
-		setupMocks    func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock)
-		expectedOrder int64
-		expectedErr   error
-		errorContains string
+		name             string
+		UID              models.UID
+		version          uint64
+		setupMocks       func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock)
+		expectedOrder    int64
+		expectedErr      error
+		errorContains    string
+		expectedProblems []internal_errors.CheckoutProblem
+		expectedVersion  uint64
 	}{
 		{
 			name: "successful checkout",
 			UID:  1,
 			setupMocks: func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
 				items := []models.CartItem{
-					{SKU: 1001, Count: 2},
-					{SKU: 1002, Count: 3},
+					{SKU: 1001, Count: 2, Price: 100},
+					{SKU: 1002, Count: 3, Price: 100},
 				}
-				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, error) {
+				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					return items, nil
+					return items, 1, nil
 				})
-				lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, itemsParam []models.CartItem) (int64, error) {
+				setupAvailable(productServiceMock, lomsServiceMock, 100, 10)
+				lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, itemsParam []models.CartItem, idempotencyKey string) (int64, error) {
 					require.Equal(t, int64(1), user)
 					require.Equal(t, items, itemsParam)
+					require.NotEmpty(t, idempotencyKey)
 					return int64(2), nil
 				})
-				cartRepoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) error {
+				cartRepoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID, version uint64) (uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					return nil
+					require.Equal(t, uint64(1), version)
+					return 2, nil
 				})
 			},
 			expectedOrder: 2,
@@ -53,9 +78,9 @@ func TestCartService_Checkout_Table(t *testing.T) {
 			name: "error getting cart items",
 			UID:  2,
 			setupMocks: func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
-				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, error) {
+				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
 					require.Equal(t, models.UID(2), uid)
-					return nil, errors.New("db error")
+					return nil, 0, errors.New("db error")
 				})
 			},
 			expectedOrder: 0,
@@ -69,11 +94,12 @@ func TestCartService_Checkout_Table(t *testing.T) {
 				items := []models.CartItem{
 					{SKU: 1003, Count: 1},
 				}
-				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, error) {
+				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
 					require.Equal(t, models.UID(3), uid)
-					return items, nil
+					return items, 1, nil
 				})
-				lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, itemsParam []models.CartItem) (int64, error) {
+				setupAvailable(productServiceMock, lomsServiceMock, 100, 10)
+				lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, itemsParam []models.CartItem, idempotencyKey string) (int64, error) {
 					require.Equal(t, int64(3), user)
 					require.Equal(t, items, itemsParam)
 					return int64(0), errors.New("order create error")
@@ -83,6 +109,135 @@ func TestCartService_Checkout_Table(t *testing.T) {
 			expectedErr:   internal_errors.ErrInternalServerError,
 			errorContains: "failed to create order",
 		},
+		{
+			name:    "stale cart version",
+			UID:     4,
+			version: 1,
+			setupMocks: func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
+					require.Equal(t, models.UID(4), uid)
+					return []models.CartItem{{SKU: 1004, Count: 1}}, 2, nil
+				})
+			},
+			expectedOrder: 0,
+			expectedErr:   internal_errors.ErrPreconditionFailed,
+		},
+		{
+			name:    "ordered items are removed from cart changed during checkout",
+			UID:     5,
+			version: 3,
+			setupMocks: func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				items := []models.CartItem{
+					{SKU: 1005, Count: 1},
+				}
+				// Count of ordered item is increased and new item is added while order is created
+				ordered := false
+				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
+					require.Equal(t, models.UID(5), uid)
+					if ordered {
+						return []models.CartItem{{SKU: 1005, Count: 3}, {SKU: 1006, Count: 1}}, 4, nil
+					}
+					return items, 3, nil
+				})
+				setupAvailable(productServiceMock, lomsServiceMock, 100, 10)
+				lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, itemsParam []models.CartItem, idempotencyKey string) (int64, error) {
+					require.Equal(t, items, itemsParam)
+					ordered = true
+					return int64(7), nil
+				})
+				cartRepoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID, version uint64) (uint64, error) {
+					require.Equal(t, uint64(3), version)
+					return 0, internal_errors.ErrPreconditionFailed
+				})
+				cartRepoMock.SetItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem, version uint64) (uint64, error) {
+					require.Equal(t, models.CartItem{SKU: 1005, Count: 2}, item)
+					require.Equal(t, uint64(4), version)
+					return 5, nil
+				})
+			},
+			expectedOrder: 7,
+		},
+		{
+			name: "items can not be ordered",
+			UID:  6,
+			setupMocks: func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
+					require.Equal(t, models.UID(6), uid)
+					return []models.CartItem{
+						{SKU: 1001, Count: 1, Price: 100},
+						{SKU: 1002, Count: 5, Price: 200},
+						{SKU: 1003, Count: 1, Price: 300},
+						{SKU: 1004, Count: 1, Price: 400},
+					}, 4, nil
+				})
+				productServiceMock.RefreshProductMock.Set(func(ctx context.Context, sku models.SKU) (*models.GetProductResponse, error) {
+					switch sku {
+					case 1001:
+						return &models.GetProductResponse{Name: "Книга", Price: 100}, nil
+					case 1002:
+						return &models.GetProductResponse{Name: "Ручка", Price: 200}, nil
+					case 1003:
+						return &models.GetProductResponse{Name: "Тетрадь", Price: 350}, nil
+					default:
+						return nil, internal_errors.ErrPreconditionFailed
+					}
+				})
+				lomsServiceMock.StocksInfoBatchMock.Set(func(ctx context.Context, skus []models.SKU) (map[models.SKU]int64, []models.SKU, error) {
+					require.Equal(t, []models.SKU{1001, 1002, 1003, 1004}, skus)
+					return map[models.SKU]int64{1001: 10, 1002: 3, 1003: 10}, []models.SKU{1004}, nil
+				})
+				// Fresh price is saved into cart
+				cartRepoMock.SetItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem, version uint64) (uint64, error) {
+					require.Equal(t, models.UID(6), uid)
+					require.Equal(t, models.CartItem{SKU: 1003, Count: 1, Price: 350}, item)
+					require.Equal(t, uint64(4), version)
+					return 5, nil
+				})
+			},
+			expectedErr: internal_errors.ErrConflict,
+			expectedProblems: []internal_errors.CheckoutProblem{
+				{SKU: 1002, Reason: internal_errors.CheckoutProblemInsufficientStock, Count: 5, Available: 3, CartPrice: 200, Price: 200},
+				{SKU: 1003, Reason: internal_errors.CheckoutProblemPriceChanged, Count: 1, Available: 10, CartPrice: 300, Price: 350},
+				{SKU: 1004, Reason: internal_errors.CheckoutProblemProductNotFound, Count: 1, Available: 0, CartPrice: 400},
+				{SKU: 1004, Reason: internal_errors.CheckoutProblemInsufficientStock, Count: 1, Available: 0, CartPrice: 400},
+			},
+			expectedVersion: 5,
+		},
+		{
+			name: "product service failure",
+			UID:  8,
+			setupMocks: func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
+					return []models.CartItem{{SKU: 1001, Count: 1}, {SKU: 1002, Count: 1}}, 1, nil
+				})
+				// Failure of Product Service is not reported as unknown products
+				productServiceMock.RefreshProductMock.Set(func(ctx context.Context, sku models.SKU) (*models.GetProductResponse, error) {
+					return nil, fmt.Errorf("product service returned status code 503: %w", internal_errors.ErrInternalServerError)
+				})
+				lomsServiceMock.StocksInfoBatchMock.Set(func(ctx context.Context, skus []models.SKU) (map[models.SKU]int64, []models.SKU, error) {
+					return map[models.SKU]int64{1001: 10, 1002: 10}, nil, nil
+				})
+			},
+			expectedErr:   internal_errors.ErrInternalServerError,
+			errorContains: "failed to check cart items",
+		},
+		{
+			name: "error getting stocks",
+			UID:  7,
+			setupMocks: func(cartRepoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
+					return []models.CartItem{{SKU: 1001, Count: 1}}, 1, nil
+				})
+				productServiceMock.RefreshProductMock.Set(func(ctx context.Context, sku models.SKU) (*models.GetProductResponse, error) {
+					return &models.GetProductResponse{Name: "Книга", Price: 100}, nil
+				})
+				lomsServiceMock.StocksInfoBatchMock.Set(func(ctx context.Context, skus []models.SKU) (map[models.SKU]int64, []models.SKU, error) {
+					return nil, nil, internal_errors.ErrInternalServerError
+				})
+			},
+			expectedErr:   internal_errors.ErrInternalServerError,
+			errorContains: "failed to check cart items",
+		},
 	}
 
 	for _, tt := range tests {
@@ -94,7 +249,7 @@ func TestCartService_Checkout_Table(t *testing.T) {
 
 			tt.setupMocks(cartRepoMock, productServiceMock, lomsServiceMock)
 
-			orderID, err := service.Checkout(ctx, tt.UID)
+			orderID, err := service.Checkout(ctx, tt.UID, tt.version)
 			if tt.expectedErr != nil {
 				require.Error(t, err)
 				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
@@ -103,6 +258,141 @@ func TestCartService_Checkout_Table(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tt.expectedOrder, orderID)
 			}
+
+			if tt.expectedProblems != nil {
+				var checkoutErr *internal_errors.CheckoutError
+				require.ErrorAs(t, err, &checkoutErr)
+				require.Equal(t, tt.expectedProblems, checkoutErr.Problems)
+				require.Equal(t, tt.expectedVersion, checkoutErr.Version)
+			}
 		})
 	}
 }
+
+// TestCartService_Checkout_IdempotencyKey function for tests repeated checkout of the same cart version creates order
+// with the same idempotency key and changed cart with new key.
+func TestCartService_Checkout_IdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	cartRepoMock, productServiceMock, lomsServiceMock, service := setup(t)
+
+	version := uint64(1)
+	cartRepoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
+		return []models.CartItem{{SKU: 1001, Count: 1, Price: 100}}, version, nil
+	})
+	setupAvailable(productServiceMock, lomsServiceMock, 100, 10)
+	var keys []string
+	lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (int64, error) {
+		keys = append(keys, idempotencyKey)
+		// Response of first request is lost
+		if len(keys) == 1 {
+			return 0, context.DeadlineExceeded
+		}
+		return 1, nil
+	})
+	cartRepoMock.DeleteItemsByUserIDMock.Return(0, nil)
+
+	_, err := service.Checkout(ctx, 1, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = service.Checkout(ctx, 1, 0)
+	require.NoError(t, err)
+
+	version = 2
+	_, err = service.Checkout(ctx, 1, 0)
+	require.NoError(t, err)
+
+	require.Len(t, keys, 3)
+	require.NotEmpty(t, keys[0])
+	require.Equal(t, keys[0], keys[1])
+	require.NotEqual(t, keys[1], keys[2])
+}
+
+// TestCartService_Checkout_RetryAfterPriceChanged function for tests checkout rejected because of changed price
+// returns version of cart with fresh prices and retry with this version creates order.
+func TestCartService_Checkout_RetryAfterPriceChanged(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	productServiceMock := mock.NewIProductServiceMock(ctrl)
+	lomsServiceMock := mock.NewILomsServiceMock(ctrl)
+	repo := repository.NewCartRepository()
+	service := service.NewService(repo, productServiceMock, lomsServiceMock)
+
+	const UID models.UID = 1
+	version, err := repo.AddItem(ctx, UID, models.CartItem{SKU: 1001, Count: 2, Price: 100}, 0)
+	require.NoError(t, err)
+
+	setupAvailable(productServiceMock, lomsServiceMock, 150, 10)
+	lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (int64, error) {
+		require.Equal(t, []models.CartItem{{SKU: 1001, Count: 2, Price: 150}}, items)
+		return 7, nil
+	})
+
+	_, err = service.Checkout(ctx, UID, version)
+	var checkoutErr *internal_errors.CheckoutError
+	require.ErrorAs(t, err, &checkoutErr)
+	require.Equal(t, internal_errors.CheckoutProblemPriceChanged, checkoutErr.Problems[0].Reason)
+	require.NotEqual(t, version, checkoutErr.Version)
+
+	// Version user saw before prices were updated is stale
+	_, err = service.Checkout(ctx, UID, version)
+	require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+
+	orderID, err := service.Checkout(ctx, UID, checkoutErr.Version)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), orderID)
+
+	_, _, err = repo.GetItemsByUserID(ctx, UID)
+	require.ErrorIs(t, err, internal_errors.ErrNotFound)
+}
+
+// TestCartService_Checkout_CartChangedConcurrently function for tests changes of cart made while order is created
+// are kept in repository and ordered items are removed.
+func TestCartService_Checkout_CartChangedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	productServiceMock := mock.NewIProductServiceMock(ctrl)
+	lomsServiceMock := mock.NewILomsServiceMock(ctrl)
+	repo := repository.NewCartRepository()
+	service := service.NewService(repo, productServiceMock, lomsServiceMock)
+
+	const UID models.UID = 1
+	_, err := repo.AddItem(ctx, UID, models.CartItem{SKU: 1001, Count: 2, Price: 100}, 0)
+	require.NoError(t, err)
+	_, err = repo.AddItem(ctx, UID, models.CartItem{SKU: 1002, Count: 1, Price: 100}, 0)
+	require.NoError(t, err)
+
+	setupAvailable(productServiceMock, lomsServiceMock, 100, 10)
+	lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (int64, error) {
+		// User changes cart while order is created
+		_, err := repo.AddItem(ctx, UID, models.CartItem{SKU: 1001, Count: 3, Price: 100}, 0)
+		require.NoError(t, err)
+		_, err = repo.AddItem(ctx, UID, models.CartItem{SKU: 1003, Count: 1, Price: 100}, 0)
+		require.NoError(t, err)
+		_, err = repo.DeleteItem(ctx, UID, 1002, 0)
+		require.NoError(t, err)
+		return 1, nil
+	})
+
+	orderID, err := service.Checkout(ctx, UID, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), orderID)
+
+	// Only changes made during checkout are left
+	items, _, err := repo.GetItemsByUserID(ctx, UID)
+	require.NoError(t, err)
+	require.Equal(t, []models.CartItem{
+		{SKU: 1001, Count: 3, Price: 100},
+		{SKU: 1003, Count: 1, Price: 100},
+	}, items)
+
+	// Cart of ordered items only is deleted
+	lomsServiceMock.OrderCreateMock.Set(func(ctx context.Context, user int64, items []models.CartItem, idempotencyKey string) (int64, error) {
+		_, err := repo.SetItem(ctx, UID, models.CartItem{SKU: 1003, Count: 1, Price: 100}, 0)
+		require.NoError(t, err)
+		return 2, nil
+	})
+
+	_, err = service.Checkout(ctx, UID, 0)
+	require.NoError(t, err)
+	_, _, err = repo.GetItemsByUserID(ctx, UID)
+	require.ErrorIs(t, err, internal_errors.ErrNotFound)
+}
@@ -25,9 +25,9 @@ func TestCartService_DelCart_Table(t *testing.T) {
 			name: "successful delete cart",
 			UID:  1,
 			setupMocks: func(repoMock *mock.ICartRepositoryMock) {
-				repoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) error {
+				repoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID, version uint64) (uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					return nil
+					return 1, nil
 				})
 			},
 			expectedErr: nil,
@@ -36,9 +36,9 @@ func TestCartService_DelCart_Table(t *testing.T) {
 			name: "delete empty cart",
 			UID:  5,
 			setupMocks: func(repoMock *mock.ICartRepositoryMock) {
-				repoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) error {
+				repoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID, version uint64) (uint64, error) {
 					require.Equal(t, models.UID(5), uid)
-					return nil
+					return 1, nil
 				})
 			},
 			expectedErr: nil,
@@ -54,9 +54,9 @@ func TestCartService_DelCart_Table(t *testing.T) {
 			name: "repository error",
 			UID:  1,
 			setupMocks: func(repoMock *mock.ICartRepositoryMock) {
-				repoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) error {
+				repoMock.DeleteItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID, version uint64) (uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					return ErrRepository
+					return 0, ErrRepository
 				})
 			},
 			expectedErr: ErrRepository,
@@ -72,7 +72,7 @@ func TestCartService_DelCart_Table(t *testing.T) {
 
 			tt.setupMocks(repoMock)
 
-			err := service.DelCart(ctx, tt.UID)
+			_, err := service.DelCart(ctx, tt.UID, 0)
 
 			if tt.expectedErr != nil {
 				require.Error(t, err)
@@ -27,10 +27,10 @@ func TestCartService_DelProduct_Table(t *testing.T) {
 			UID:  1,
 			SKU:  100,
 			setupMocks: func(repoMock *mock.ICartRepositoryMock) {
-				repoMock.DeleteItemMock.Set(func(ctx context.Context, uid models.UID, sku models.SKU) error {
+				repoMock.DeleteItemMock.Set(func(ctx context.Context, uid models.UID, sku models.SKU, version uint64) (uint64, error) {
 					require.Equal(t, models.UID(1), uid)
 					require.Equal(t, models.SKU(100), sku)
-					return nil
+					return 1, nil
 				})
 			},
 			expectedErr: nil,
@@ -64,10 +64,10 @@ func TestCartService_DelProduct_Table(t *testing.T) {
 			UID:  1,
 			SKU:  100,
 			setupMocks: func(repoMock *mock.ICartRepositoryMock) {
-				repoMock.DeleteItemMock.Set(func(ctx context.Context, uid models.UID, sku models.SKU) error {
+				repoMock.DeleteItemMock.Set(func(ctx context.Context, uid models.UID, sku models.SKU, version uint64) (uint64, error) {
 					require.Equal(t, models.UID(1), uid)
 					require.Equal(t, models.SKU(100), sku)
-					return ErrRepository
+					return 0, ErrRepository
 				})
 			},
 			expectedErr: ErrRepository,
@@ -83,7 +83,7 @@ func TestCartService_DelProduct_Table(t *testing.T) {
 
 			tt.setupMocks(repoMock)
 
-			err := service.DelProduct(ctx, tt.UID, tt.SKU)
+			_, err := service.DelProduct(ctx, tt.UID, tt.SKU, 0)
 
 			if tt.expectedErr != nil {
 				require.Error(t, err)
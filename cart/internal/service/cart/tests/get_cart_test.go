@@ -28,9 +28,9 @@ func TestCartService_GetCart_Table(t *testing.T) {
 			UID:  1000000,
 			setupMocks: func(ctx context.Context, repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock) {
 				items := []models.CartItem{{SKU: 700, Count: 3}}
-				repoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, error) {
+				repoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
 					require.Equal(t, models.UID(1000000), uid)
-					return items, nil
+					return items, 1, nil
 				})
 
 				var mu sync.Mutex
@@ -55,9 +55,9 @@ func TestCartService_GetCart_Table(t *testing.T) {
 					{SKU: 200, Count: 2},
 					{SKU: 300, Count: 3},
 				}
-				repoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, error) {
+				repoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					return items, nil
+					return items, 1, nil
 				})
 
 				var mu sync.Mutex
@@ -91,9 +91,9 @@ func TestCartService_GetCart_Table(t *testing.T) {
 			name: "repository error",
 			UID:  1,
 			setupMocks: func(ctx context.Context, repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock) {
-				repoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, error) {
+				repoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					return nil, ErrRepository
+					return nil, 0, ErrRepository
 				})
 			},
 			expectedErr: ErrRepository,
@@ -104,9 +104,9 @@ func TestCartService_GetCart_Table(t *testing.T) {
 			UID:  1,
 			setupMocks: func(ctx context.Context, repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock) {
 				items := []models.CartItem{{SKU: 100, Count: 1}}
-				repoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, error) {
+				repoMock.GetItemsByUserIDMock.Set(func(ctx context.Context, uid models.UID) ([]models.CartItem, uint64, error) {
 					require.Equal(t, models.UID(1), uid)
-					return items, nil
+					return items, 1, nil
 				})
 
 				var mu sync.Mutex
@@ -130,7 +130,7 @@ func TestCartService_GetCart_Table(t *testing.T) {
 
 			tt.setupMocks(ctx, repoMock, productServiceMock)
 
-			items, totalPrice, err := service.GetCart(ctx, tt.UID)
+			items, totalPrice, version, err := service.GetCart(ctx, tt.UID)
 
 			if tt.expectedErr != nil {
 				require.Error(t, err)
@@ -140,6 +140,7 @@ func TestCartService_GetCart_Table(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tt.totalPrice, totalPrice)
+				require.Equal(t, uint64(1), version)
 				require.NotNil(t, items)
 			}
 		})
@@ -0,0 +1,164 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"route256/cart/internal/models"
+	internal_errors "route256/cart/internal/pkg/errors"
+	"route256/cart/internal/service/cart/mock"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCartService_SetProductCount_Table function for tests the SetProductCount method of CartService.
+func TestCartService_SetProductCount_Table(t *testing.T) {
+	tests := []struct {
+		name            string
+		UID             models.UID
+		SKU             models.SKU
+		count           uint16
+		version         uint64
+		setupMocks      func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock)
+		expectedVersion uint64
+		expectedErr     error
+		errorContains   string
+	}{
+		{
+			name:    "successful set",
+			UID:     1,
+			SKU:     100,
+			count:   4,
+			version: 2,
+			setupMocks: func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				productServiceMock.GetProductMock.Set(func(ctx context.Context, sku models.SKU) (*models.GetProductResponse, error) {
+					require.Equal(t, models.SKU(100), sku)
+					return &models.GetProductResponse{Name: "Книга", Price: 400}, nil
+				})
+				lomsServiceMock.StocksInfoMock.Set(func(ctx context.Context, sku models.SKU) (int64, error) {
+					require.Equal(t, models.SKU(100), sku)
+					return int64(4), nil
+				})
+				repoMock.SetItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem, version uint64) (uint64, error) {
+					require.Equal(t, models.UID(1), uid)
+					require.Equal(t, models.CartItem{SKU: 100, Count: 4, Price: 400}, item)
+					require.Equal(t, uint64(2), version)
+					return 3, nil
+				})
+			},
+			expectedVersion: 3,
+		},
+		{
+			name:  "zero count deletes without checks",
+			UID:   1,
+			SKU:   100,
+			count: 0,
+			setupMocks: func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				repoMock.SetItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem, version uint64) (uint64, error) {
+					require.Equal(t, models.UID(1), uid)
+					require.Equal(t, models.CartItem{SKU: 100, Count: 0}, item)
+					require.Equal(t, uint64(0), version)
+					return 7, nil
+				})
+			},
+			expectedVersion: 7,
+		},
+		{
+			name:  "bad request with UID 0",
+			UID:   0,
+			SKU:   100,
+			count: 1,
+			setupMocks: func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+			},
+			expectedErr: internal_errors.ErrBadRequest,
+		},
+		{
+			name:  "bad request with SKU 0",
+			UID:   1,
+			SKU:   0,
+			count: 1,
+			setupMocks: func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+			},
+			expectedErr: internal_errors.ErrBadRequest,
+		},
+		{
+			name:  "product service SKU not found",
+			UID:   1,
+			SKU:   100,
+			count: 1,
+			setupMocks: func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				productServiceMock.GetProductMock.Set(func(ctx context.Context, sku models.SKU) (*models.GetProductResponse, error) {
+					require.Equal(t, models.SKU(100), sku)
+					return nil, internal_errors.ErrPreconditionFailed
+				})
+			},
+			expectedErr: internal_errors.ErrPreconditionFailed,
+		},
+		{
+			name:  "insufficient stocks",
+			UID:   1,
+			SKU:   100,
+			count: 5,
+			setupMocks: func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				productServiceMock.GetProductMock.Set(func(ctx context.Context, sku models.SKU) (*models.GetProductResponse, error) {
+					require.Equal(t, models.SKU(100), sku)
+					return &models.GetProductResponse{Name: "Книга", Price: 400}, nil
+				})
+				lomsServiceMock.StocksInfoMock.Set(func(ctx context.Context, sku models.SKU) (int64, error) {
+					require.Equal(t, models.SKU(100), sku)
+					return int64(3), nil
+				})
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "number of stocks: 3 less than required count: 5",
+		},
+		{
+			name:    "version mismatch",
+			UID:     1,
+			SKU:     100,
+			count:   0,
+			version: 5,
+			setupMocks: func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				repoMock.SetItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem, version uint64) (uint64, error) {
+					return 0, internal_errors.ErrPreconditionFailed
+				})
+			},
+			expectedErr: internal_errors.ErrPreconditionFailed,
+		},
+		{
+			name:  "repository error when setting item",
+			UID:   1,
+			SKU:   100,
+			count: 0,
+			setupMocks: func(repoMock *mock.ICartRepositoryMock, productServiceMock *mock.IProductServiceMock, lomsServiceMock *mock.ILomsServiceMock) {
+				repoMock.SetItemMock.Set(func(ctx context.Context, uid models.UID, item models.CartItem, version uint64) (uint64, error) {
+					return 0, ErrRepository
+				})
+			},
+			expectedErr: ErrRepository,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repoMock, productServiceMock, lomsServiceMock, service := setup(t)
+
+			tt.setupMocks(repoMock, productServiceMock, lomsServiceMock)
+
+			version, err := service.SetProductCount(ctx, tt.UID, tt.SKU, tt.count, tt.version)
+
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedVersion, version)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cart_items (
+    user_id BIGINT NOT NULL,
+    sku BIGINT NOT NULL,
+    count INTEGER NOT NULL CHECK (count > 0 AND count <= 65535),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, sku)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE cart_items;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SEQUENCE cart_version_seq;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT setval('cart_version_seq', COALESCE((SELECT MAX(version) FROM carts), 0) + 1, false);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM carts
+WHERE NOT EXISTS (SELECT 1 FROM cart_items WHERE cart_items.user_id = carts.user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP SEQUENCE cart_version_seq;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Carts written before versions were kept get version, so they have ETag
+-- +goose StatementBegin
+INSERT INTO carts (user_id, version)
+SELECT user_id, nextval('cart_version_seq')
+FROM (SELECT DISTINCT user_id FROM cart_items) AS items
+WHERE NOT EXISTS (SELECT 1 FROM carts WHERE carts.user_id = items.user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- Backfilled versions are kept, table of versions is dropped by previous migration
@@ -130,7 +130,7 @@ func (s *TSuite) TestDeleteProductFromCart() {
 	// Add item to cart
 	UID := models.UID(31337)
 	SKU := models.SKU(1076963)
-	err := s.repo.AddItem(ctx, UID, models.CartItem{SKU: SKU, Count: 1})
+	_, err := s.repo.AddItem(ctx, UID, models.CartItem{SKU: SKU, Count: 1}, 0)
 	require.NoError(s.T(), err)
 
 	// Send http request
@@ -145,7 +145,7 @@ func (s *TSuite) TestDeleteProductFromCart() {
 	assert.Equal(s.T(), http.StatusNoContent, resp.StatusCode)
 
 	// Check delete item
-	_, err = s.repo.GetItemsByUserID(ctx, UID)
+	_, _, err = s.repo.GetItemsByUserID(ctx, UID)
 
 	require.Error(s.T(), err)
 	require.True(s.T(), errors.Is(err, internal_errors.ErrNotFound), "error not ErrNotFound")
@@ -157,9 +157,9 @@ func (s *TSuite) TestGetCart() {
 	UID := models.UID(31337)
 	firstSKU := models.SKU(1076963)
 	secondSKU := models.SKU(1148162)
-	err := s.repo.AddItem(context.TODO(), UID, models.CartItem{SKU: firstSKU, Count: 2})
+	_, err := s.repo.AddItem(context.TODO(), UID, models.CartItem{SKU: firstSKU, Count: 2}, 0)
 	require.NoError(s.T(), err)
-	err = s.repo.AddItem(context.TODO(), UID, models.CartItem{SKU: secondSKU, Count: 3})
+	version, err := s.repo.AddItem(context.TODO(), UID, models.CartItem{SKU: secondSKU, Count: 3}, 0)
 	require.NoError(s.T(), err)
 
 	// Send http request
@@ -172,6 +172,7 @@ func (s *TSuite) TestGetCart() {
 	defer resp.Body.Close()
 
 	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(s.T(), `"`+strconv.FormatUint(version, 10)+`"`, resp.Header.Get("ETag"))
 
 	// Check get item
 	var res models.GetCartResponse
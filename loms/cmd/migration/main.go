@@ -2,70 +2,131 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"text/tabwriter"
 
+	"route256/loms/internal/config"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/migrator"
 	"route256/loms/migrations"
-
-	_ "github.com/jackc/pgx/v4/stdlib"
-	"github.com/pressly/goose/v3"
 )
 
 func main() {
 	// Flags
 	var action string
+	var configPath string
 	var dsn string
+	var target string
+	var version int64
 
-	flag.StringVar(&action, "action", "up", "Migration action: up or down")
-	flag.StringVar(&dsn, "dsn", "", "Database DSN")
+	flag.StringVar(&action, "action", "up", "Migration action: up, down, status, redo, up-to or down-to")
+	flag.StringVar(&configPath, "config", "", "Path to config file, migrations are applied to main database and all shards")
+	flag.StringVar(&dsn, "dsn", "", "Database DSN, used instead of config")
+	flag.StringVar(&target, "target", string(migrations.TargetMain), "Target of database given by DSN: main or shard")
+	flag.Int64Var(&version, "version", 0, "Version for up-to and down-to actions")
 	flag.Parse()
 
-	// Check DSN
-	if dsn == "" {
-		log.Fatal("Database DSN must be provided")
+	// Check action
+	if !migrator.Action(action).Valid() {
+		log.Fatalf("Unknown migration action: %s. Use 'up', 'down', 'status', 'redo', 'up-to' or 'down-to'.", action)
+	}
+
+	// Databases
+	databases, err := databasesFromFlags(configPath, dsn, migrations.Target(target))
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// Database connect
 	ctx := context.Background()
-	db, err := NewConnect(ctx, dsn)
+	m, err := migrator.NewMigrator(ctx, migrations.EmbedFS, databases)
 	if err != nil {
-		log.Fatalf("Failed to connect to the database, error: %s", err)
+		log.Fatalf("Failed to initialize migrations, error: %s", err)
 	}
-	defer db.Close()
+	defer m.Close()
 
-	// Configure goose
-	goose.SetBaseFS(migrations.EmbedFS)
+	// Report before action
+	versions, err := m.Versions(ctx)
+	if err != nil {
+		log.Fatalf("Failed to get migration versions, error: %s", err)
+	}
+	printVersions(versions)
 
 	// Run migration
-	switch action {
-	case "up":
-		err = goose.Up(db, ".")
-		if err != nil {
-			log.Fatalf("Error applying migrations up: %v", err)
-		}
-		log.Println("Migrations successfully applied up.")
-	case "down":
-		err = goose.Down(db, ".")
-		if err != nil {
-			log.Fatalf("Error rolling back migrations down: %v", err)
-		}
-		log.Println("Migrations successfully rolled back down.")
-	default:
-		log.Fatalf("Unknown migration action: %s. Use 'up' or 'down'.", action)
+	results, err := m.Run(ctx, migrator.Action(action), version)
+	printResults(results)
+	if errors.Is(err, internal_errors.ErrShardsDiverged) {
+		log.Fatalf("Refusing to run migrations, error: %s", err)
+	}
+	if err != nil {
+		log.Fatalf("Error applying migrations %s, error: %s", action, err)
+	}
+	if action == string(migrator.ActionStatus) {
+		return
 	}
-}
 
-// NewConnect return *sql.DB connection to database
-func NewConnect(ctx context.Context, dsn string) (*sql.DB, error) {
-	db, err := sql.Open("pgx", dsn)
+	// Report after action
+	versions, err = m.Versions(ctx)
 	if err != nil {
-		return nil, err
+		log.Fatalf("Failed to get migration versions, error: %s", err)
+	}
+	fmt.Println()
+	printVersions(versions)
+	log.Printf("Migrations successfully applied: %s.", action)
+}
+
+// databasesFromFlags returns databases from config or a single database given by DSN.
+func databasesFromFlags(configPath, dsn string, target migrations.Target) ([]migrator.Database, error) {
+	if dsn != "" {
+		if target != migrations.TargetMain && target != migrations.TargetShard {
+			return nil, fmt.Errorf("unknown target: %s. Use 'main' or 'shard'", target)
+		}
+		return []migrator.Database{{Name: string(target), Target: target, DSN: dsn}}, nil
+	}
+
+	if configPath == "" {
+		return nil, errors.New("config or database DSN must be provided")
+	}
+
+	cfg := config.NewConfig()
+	if err := cfg.ReadConfig(configPath); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	databases := []migrator.Database{{Name: "main", Target: migrations.TargetMain, DSN: cfg.Database.GetDSN()}}
+	for i, shardDSN := range cfg.Database.GetShards() {
+		databases = append(databases, migrator.Database{
+			Name:   fmt.Sprintf("shard-%d", i),
+			Target: migrations.TargetShard,
+			DSN:    shardDSN,
+		})
 	}
 
-	if err := db.PingContext(ctx); err != nil {
-		return nil, err
+	return databases, nil
+}
+
+// printVersions prints migration version of each database.
+func printVersions(versions []migrator.Version) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATABASE\tTARGET\tVERSION\tLATEST\tAPPLIED\tPENDING")
+	for _, v := range versions {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\n", v.Name, v.Target, v.Current, v.Latest, len(v.Applied), v.Pending)
 	}
+	_ = w.Flush()
+}
 
-	return db, nil
+// printResults prints applied migrations of each database.
+func printResults(results []migrator.Result) {
+	for _, res := range results {
+		for _, migration := range res.Migrations {
+			fmt.Printf("%s: %s\n", res.Name, migration)
+		}
+		if res.Err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", res.Name, res.Err)
+		}
+	}
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"route256/loms/internal/config"
+	db "route256/loms/internal/pkg/db"
+	"route256/loms/internal/pkg/shard_manager"
+	repo_buckets "route256/loms/internal/repository/buckets"
+	repo_outbox "route256/loms/internal/repository/outbox"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Moves sequences and undelivered events of orders left in outbox of main database by previous
+// version of service to shards of orders. Must be run after migrations and before service
+// starts writing events of these orders to shards.
+func main() {
+	// Flags
+	var configPath string
+	var batchSize uint
+
+	flag.StringVar(&configPath, "config", "config.yml", "Path to config file")
+	flag.UintVar(&batchSize, "batch", 1000, "Count of orders moved in one transaction")
+	flag.Parse()
+
+	if batchSize == 0 {
+		log.Fatal("Batch size must be greater than zero")
+	}
+
+	// Read config
+	cfg := config.NewConfig()
+	if err := cfg.ReadConfig(configPath); err != nil {
+		log.Fatalf("Failed to read config, error: %s", err)
+	}
+
+	shardCount := len(cfg.Database.GetShards())
+	if shardCount == 0 {
+		log.Fatal("No shards in config")
+	}
+
+	bucketCount := cfg.Database.GetShardBucketCount()
+	if err := shard_manager.ValidateBucketCount(bucketCount); err != nil {
+		log.Fatal(err)
+	}
+
+	// Database connect
+	ctx := context.Background()
+	pool, err := db.NewConnect(ctx, cfg.Database.GetDSN())
+	if err != nil {
+		log.Fatalf("Failed to connect to the database, error: %s", err)
+	}
+	defer pool.Close()
+
+	shardPools := make([]*pgxpool.Pool, shardCount)
+	for i, dsn := range cfg.Database.GetShards() {
+		shardPools[i], err = db.NewConnect(ctx, dsn)
+		if err != nil {
+			log.Fatalf("Failed to connect to shard %d, error: %s", i, err)
+		}
+		defer shardPools[i].Close()
+	}
+
+	// Bucket map
+	repoBuckets := repo_buckets.NewBucketRepository(pool)
+	if err := repoBuckets.Init(ctx, bucketCount, shardCount); err != nil {
+		log.Fatalf("Failed to initialize bucket map, error: %s", err)
+	}
+	buckets, err := repoBuckets.List(ctx)
+	if err != nil {
+		log.Fatalf("Failed to read bucket map, error: %s", err)
+	}
+	if len(buckets) != bucketCount {
+		log.Fatalf("Bucket map has %d buckets, config has %d", len(buckets), bucketCount)
+	}
+
+	shardManager := shard_manager.NewShardManager(shard_manager.GetMurmur3BucketFn(bucketCount), shardPools, buckets)
+	repoOutbox := repo_outbox.NewOutboxRepository(pool, shardManager)
+
+	// Move batches until main database has no sequences of orders
+	var total int64
+	for {
+		moved, err := repoOutbox.MoveMainOrderEvents(ctx, uint32(batchSize))
+		if err != nil {
+			log.Fatalf("Failed to move order events, moved orders: %d, error: %s", total, err)
+		}
+		if moved == 0 {
+			break
+		}
+		total += moved
+		fmt.Printf("orders moved: %d\n", total)
+	}
+
+	fmt.Printf("\nOrders moved: %d\n", total)
+}
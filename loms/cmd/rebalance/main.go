@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"route256/loms/internal/config"
+	db "route256/loms/internal/pkg/db"
+	"route256/loms/internal/pkg/shard_manager"
+	repo_buckets "route256/loms/internal/repository/buckets"
+	repo_order "route256/loms/internal/repository/orders"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func main() {
+	// Flags
+	var configPath string
+	var target int
+	var bucketList string
+	var retryDelay time.Duration
+	var dryRun bool
+
+	flag.StringVar(&configPath, "config", "config.yml", "Path to config file")
+	flag.IntVar(&target, "to", -1, "Index of target shard in config")
+	flag.StringVar(&bucketList, "buckets", "", "Comma separated buckets to move, by default target gets its even part of buckets")
+	flag.DurationVar(&retryDelay, "retry-delay", time.Second, "Delay before retry of bucket with unsent events")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print buckets to move without moving them")
+	flag.Parse()
+
+	// Read config
+	cfg := config.NewConfig()
+	if err := cfg.ReadConfig(configPath); err != nil {
+		log.Fatalf("Failed to read config, error: %s", err)
+	}
+
+	shardCount := len(cfg.Database.GetShards())
+	if target < 0 || target >= shardCount {
+		log.Fatalf("Target shard must be in range [0, %d)", shardCount)
+	}
+
+	bucketCount := cfg.Database.GetShardBucketCount()
+	if err := shard_manager.ValidateBucketCount(bucketCount); err != nil {
+		log.Fatal(err)
+	}
+
+	// Database connect
+	ctx := context.Background()
+	pool, err := db.NewConnect(ctx, cfg.Database.GetDSN())
+	if err != nil {
+		log.Fatalf("Failed to connect to the database, error: %s", err)
+	}
+	defer pool.Close()
+
+	shardPools := make([]*pgxpool.Pool, shardCount)
+	for i, dsn := range cfg.Database.GetShards() {
+		shardPools[i], err = db.NewConnect(ctx, dsn)
+		if err != nil {
+			log.Fatalf("Failed to connect to shard %d, error: %s", i, err)
+		}
+		defer shardPools[i].Close()
+	}
+
+	// Bucket map
+	repoBuckets := repo_buckets.NewBucketRepository(pool)
+	if err := repoBuckets.Init(ctx, bucketCount, shardCount); err != nil {
+		log.Fatalf("Failed to initialize bucket map, error: %s", err)
+	}
+	buckets, err := repoBuckets.List(ctx)
+	if err != nil {
+		log.Fatalf("Failed to read bucket map, error: %s", err)
+	}
+	if len(buckets) != bucketCount {
+		log.Fatalf("Bucket map has %d buckets, config has %d", len(buckets), bucketCount)
+	}
+
+	moves, err := parseBuckets(bucketList, bucketCount)
+	if err != nil {
+		log.Fatalf("Failed to parse buckets, error: %s", err)
+	}
+	if moves == nil {
+		moves = shard_manager.PlanMoves(buckets, shardCount, shard_manager.ShardIndex(target))
+	}
+
+	if dryRun {
+		for _, bucket := range moves {
+			fmt.Printf("bucket %d: shard %d -> %d\n", bucket, buckets[bucket], target)
+		}
+		fmt.Printf("\nBuckets to move: %d (dry run, nothing moved)\n", len(moves))
+		return
+	}
+
+	// Move buckets one by one, service keeps working with other buckets
+	shardManager := shard_manager.NewShardManager(shard_manager.GetMurmur3BucketFn(bucketCount), shardPools, buckets)
+	repoOrder := repo_order.NewOrderRepository(shardManager)
+	rebalancer := shard_manager.NewRebalancer(shardManager, repoOrder, repoBuckets, retryDelay)
+
+	var total int64
+	for _, bucket := range moves {
+		source := shardManager.GetBucketShard(bucket)
+		moved, err := rebalancer.MoveBucket(ctx, bucket, shard_manager.ShardIndex(target))
+		if err != nil {
+			log.Fatalf("Failed to move bucket %d, error: %s", bucket, err)
+		}
+		total += moved
+		fmt.Printf("bucket %d: shard %d -> %d, orders: %d\n", bucket, source, target, moved)
+	}
+
+	fmt.Printf("\nBuckets moved: %d, orders moved: %d\n", len(moves), total)
+}
+
+// parseBuckets parses comma separated list of buckets, returns nil for empty list.
+func parseBuckets(list string, bucketCount int) ([]shard_manager.Bucket, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	var buckets []shard_manager.Bucket
+	for _, part := range strings.Split(list, ",") {
+		bucket, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket %q: %w", part, err)
+		}
+		if bucket < 0 || bucket >= bucketCount {
+			return nil, fmt.Errorf("bucket %d must be in range [0, %d)", bucket, bucketCount)
+		}
+		buckets = append(buckets, shard_manager.Bucket(bucket))
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"route256/loms/internal/models"
+	db "route256/loms/internal/pkg/db"
+	"route256/loms/internal/pkg/stock_import"
+	repo_outbox "route256/loms/internal/repository/outbox"
+	repo_stocks "route256/loms/internal/repository/stocks"
+	loms_service "route256/loms/internal/service/loms"
+)
+
+func main() {
+	// Flags
+	var dsn string
+	var file string
+	var dryRun bool
+
+	flag.StringVar(&dsn, "dsn", "", "Database DSN")
+	flag.StringVar(&file, "file", "data/stock-data.json", "Path to stock file, JSON or CSV")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print changes without applying them")
+	flag.Parse()
+
+	// Check DSN
+	if dsn == "" {
+		log.Fatal("Database DSN must be provided")
+	}
+
+	// Read file
+	rows, err := stock_import.ParseFile(file)
+	if err != nil {
+		printRowErrors(err)
+		log.Fatalf("Failed to read stock file, error: %s", err)
+	}
+
+	// Database connect
+	ctx := context.Background()
+	pool, err := db.NewConnect(ctx, dsn)
+	if err != nil {
+		log.Fatalf("Failed to connect to the database, error: %s", err)
+	}
+	defer pool.Close()
+
+	// Only stock part of service is used
+	repoStocks := repo_stocks.NewStockRepository(pool)
+	repoOutbox := repo_outbox.NewOutboxRepository(pool, nil)
+	txManager := db.NewTransactionManager(pool)
+	lomsService := loms_service.NewService(nil, repoStocks, repoOutbox, nil, txManager, nil, 0)
+
+	// Import
+	res, err := lomsService.StockImport(ctx, &models.StockImportRequest{
+		Rows:   rows,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		printRowErrors(err)
+		log.Fatalf("Failed to import stocks, error: %s", err)
+	}
+
+	printChanges(res)
+}
+
+// printChanges prints diff of stocks.
+func printChanges(res *models.StockImportResponse) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tSKU\tACTION\tTOTAL_COUNT\tRESERVED")
+	for _, change := range res.Changes {
+		total := fmt.Sprintf("%d", change.NewTotalCount)
+		if change.Action == models.StockImportActionUpdate {
+			total = fmt.Sprintf("%d -> %d", change.OldTotalCount, change.NewTotalCount)
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%d\n", change.Line, change.SKU, change.Action, total, change.Reserved)
+	}
+	_ = w.Flush()
+
+	state := "applied"
+	if !res.Applied {
+		state = "dry run, nothing applied"
+	}
+	fmt.Printf("\nCreated: %d, updated: %d, unchanged: %d (%s)\n", res.Created, res.Updated, res.Unchanged, state)
+}
+
+// printRowErrors prints invalid rows of stock file.
+func printRowErrors(err error) {
+	var rowErrs stock_import.Errors
+	if !errors.As(err, &rowErrs) {
+		return
+	}
+
+	for _, rowErr := range rowErrs {
+		fmt.Fprintf(os.Stderr, "line %d: %s\n", rowErr.Line, rowErr.Message)
+	}
+}
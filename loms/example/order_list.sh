@@ -6,7 +6,9 @@ GRPC_METHOD="loms.Loms/OrderList"
 payload=$(
   cat <<EOF
 {
-
+  "user": 12345,
+  "statuses": ["awaiting payment", "paid"],
+  "pageSize": 20
 }
 EOF
 )
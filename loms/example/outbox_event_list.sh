@@ -0,0 +1,19 @@
+#!/bin/bash
+
+GRPC_HOST="localhost:50051"
+GRPC_METHOD="loms.Loms/OutboxEventList"
+
+payload=$(
+  cat <<EOF
+{
+  "shard": 0,
+  "states": ["dead"],
+  "pageSize": 20
+}
+EOF
+)
+
+grpcurl -plaintext -emit-defaults \
+  -rpc-header 'x-app-name:dev' \
+  -rpc-header 'x-app-version:1' \
+  -d "${payload}" ${GRPC_HOST} ${GRPC_METHOD}
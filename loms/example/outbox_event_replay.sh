@@ -0,0 +1,19 @@
+#!/bin/bash
+
+GRPC_HOST="localhost:50051"
+GRPC_METHOD="loms.Loms/OutboxEventReplay"
+
+payload=$(
+  cat <<EOF
+{
+  "shard": -1,
+  "createdFrom": "2024-11-18T00:00:00Z",
+  "createdTo": "2024-11-18T12:00:00Z"
+}
+EOF
+)
+
+grpcurl -plaintext -emit-defaults \
+  -rpc-header 'x-app-name:dev' \
+  -rpc-header 'x-app-version:1' \
+  -d "${payload}" ${GRPC_HOST} ${GRPC_METHOD}
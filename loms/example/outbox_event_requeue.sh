@@ -0,0 +1,18 @@
+#!/bin/bash
+
+GRPC_HOST="localhost:50051"
+GRPC_METHOD="loms.Loms/OutboxEventRequeue"
+
+payload=$(
+  cat <<EOF
+{
+  "shard": 0,
+  "ids": [101, 102]
+}
+EOF
+)
+
+grpcurl -plaintext -emit-defaults \
+  -rpc-header 'x-app-name:dev' \
+  -rpc-header 'x-app-version:1' \
+  -d "${payload}" ${GRPC_HOST} ${GRPC_METHOD}
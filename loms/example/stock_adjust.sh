@@ -0,0 +1,20 @@
+#!/bin/bash
+
+GRPC_HOST="localhost:50051"
+GRPC_METHOD="loms.Loms/StockAdjust"
+
+payload=$(
+  cat <<EOF
+{
+  "sku": 1003,
+  "delta": -2,
+  "reason": "STOCK_CHANGE_REASON_DAMAGE",
+  "comment": "damaged in warehouse"
+}
+EOF
+)
+
+grpcurl -plaintext -emit-defaults \
+  -rpc-header 'x-app-name:dev' \
+  -rpc-header 'x-app-version:1' \
+  -d "${payload}" ${GRPC_HOST} ${GRPC_METHOD}
\ No newline at end of file
@@ -0,0 +1,19 @@
+#!/bin/bash
+
+GRPC_HOST="localhost:50051"
+GRPC_METHOD="loms.Loms/StockImport"
+
+payload=$(
+  cat <<EOF
+{
+  "content": "sku,total_count,reserved\n1003,200,0\n1004,150,0",
+  "format": "STOCK_IMPORT_FORMAT_CSV",
+  "dryRun": true
+}
+EOF
+)
+
+grpcurl -plaintext -emit-defaults \
+  -rpc-header 'x-app-name:dev' \
+  -rpc-header 'x-app-version:1' \
+  -d "${payload}" ${GRPC_HOST} ${GRPC_METHOD}
\ No newline at end of file
@@ -0,0 +1,20 @@
+#!/bin/bash
+
+GRPC_HOST="localhost:50051"
+GRPC_METHOD="loms.Loms/StockReplenish"
+
+payload=$(
+  cat <<EOF
+{
+  "sku": 1003,
+  "count": 10,
+  "reason": "STOCK_CHANGE_REASON_DELIVERY",
+  "comment": "supplier delivery"
+}
+EOF
+)
+
+grpcurl -plaintext -emit-defaults \
+  -rpc-header 'x-app-name:dev' \
+  -rpc-header 'x-app-version:1' \
+  -d "${payload}" ${GRPC_HOST} ${GRPC_METHOD}
\ No newline at end of file
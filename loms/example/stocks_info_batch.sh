@@ -0,0 +1,17 @@
+#!/bin/bash
+
+GRPC_HOST="localhost:50051"
+GRPC_METHOD="loms.Loms/StocksInfoBatch"
+
+payload=$(
+  cat <<EOF
+{
+  "skus": [1003, 1076963, 9999]
+}
+EOF
+)
+
+grpcurl -plaintext -emit-defaults \
+  -rpc-header 'x-app-name:dev' \
+  -rpc-header 'x-app-version:1' \
+  -d "${payload}" ${GRPC_HOST} ${GRPC_METHOD}
\ No newline at end of file
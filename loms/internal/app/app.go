@@ -15,12 +15,17 @@ import (
 
 	loms_app "route256/loms/internal/app/loms"
 	"route256/loms/internal/config"
+	"route256/loms/internal/models"
 	db "route256/loms/internal/pkg/db"
 	internal_errors "route256/loms/internal/pkg/errors"
 	kafka_producer "route256/loms/internal/pkg/kafka"
+	"route256/loms/internal/pkg/replica"
 	"route256/loms/internal/pkg/shard_manager"
+	"route256/loms/internal/pkg/stock_import"
+	repo_buckets "route256/loms/internal/repository/buckets"
 	repo_order "route256/loms/internal/repository/orders"
 	repo_outbox "route256/loms/internal/repository/outbox"
+	repo_sagas "route256/loms/internal/repository/sagas"
 	repo_stocks "route256/loms/internal/repository/stocks"
 	"route256/loms/internal/server"
 	loms_service "route256/loms/internal/service/loms"
@@ -36,11 +41,21 @@ import (
 
 const quitChannelBufferSize = 1
 const errorChannelBufferSize = 1
-const processOutboxInterval time.Duration = 2 * time.Second
+const cleanupIdempotencyKeysInterval time.Duration = 10 * time.Minute
+const cleanupOutboxInterval time.Duration = 10 * time.Minute
+const cancelExpiredOrdersInterval time.Duration = 30 * time.Second
+const recoverSagasInterval time.Duration = 10 * time.Second
+const sagaStaleAfter time.Duration = 30 * time.Second
+const refreshShardBucketsInterval time.Duration = 5 * time.Second
+const replicaLagInterval time.Duration = time.Second
+const shardHealthCheckInterval time.Duration = 5 * time.Second
+const shardHealthCheckTimeout time.Duration = 2 * time.Second
 const stdout = "stdout"
 
 type App struct {
 	config          *config.Config
+	outboxRepo      *repo_outbox.OutboxRepository
+	bucketsRepo     *repo_buckets.BucketRepository
 	ctx             context.Context
 	cancel          context.CancelFunc
 	pool            *pgxpool.Pool
@@ -52,6 +67,7 @@ type App struct {
 	tracer          *oteltrace.TracerProvider
 	metricsListener net.Listener
 	shardManager    *shard_manager.ShardManager
+	mainReader      *replica.Pool
 }
 
 // NewApp
@@ -114,17 +130,53 @@ func NewApp(ctx context.Context, cancel context.CancelFunc) (*App, error) {
 		shardPools[i] = pool
 	}
 
+	// Initialize bucket map
+	bucketCount := cfg.Database.GetShardBucketCount()
+	if err := shard_manager.ValidateBucketCount(bucketCount); err != nil {
+		return nil, fmt.Errorf("failed to initialize bucket map: %w", err)
+	}
+
+	repoBuckets := repo_buckets.NewBucketRepository(pool)
+	if err := repoBuckets.Init(ctx, bucketCount, shardCount); err != nil {
+		return nil, fmt.Errorf("failed to initialize bucket map: %w", err)
+	}
+	buckets, err := repoBuckets.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bucket map: %w", err)
+	}
+	if len(buckets) != bucketCount {
+		return nil, fmt.Errorf("bucket map has %d buckets, config has %d", len(buckets), bucketCount)
+	}
+
 	// Initialize ShardManager
-	shardFn := shard_manager.GetMurmur3ShardFn(shardCount)
-	shardManager := shard_manager.NewShardManager(shardFn, shardPools, cfg.Database.GetShardBucketCount())
+	bucketFn := shard_manager.GetMurmur3BucketFn(bucketCount)
+	shardManager := shard_manager.NewShardManager(bucketFn, shardPools, buckets)
+
+	// Initialize read replicas
+	replicaMaxLag := time.Duration(cfg.Database.GetReplicaMaxLag()) * time.Millisecond
+	shardReplicas, err := connectReplicas(ctx, cfg.Database.GetShardReplicas())
+	if err != nil {
+		return nil, err
+	}
+	if err := shardManager.SetReplicas(shardReplicas, replicaMaxLag); err != nil {
+		return nil, fmt.Errorf("failed to set shard replicas: %w", err)
+	}
+
+	mainReplicas, err := connectReplicas(ctx, []string{cfg.Database.GetReplica()})
+	if err != nil {
+		return nil, err
+	}
+	mainReader := replica.NewPool("main", pool, mainReplicas[0], replicaMaxLag)
 
 	// TxManager
 	txManager := db.NewTransactionManager(pool)
 
-	// Repository order/stocks/outbox
+	// Repository order/stocks/outbox/sagas
 	repoOrder := repo_order.NewOrderRepository(shardManager)
 	repoStocks := repo_stocks.NewStockRepository(pool)
-	repoOutbox := repo_outbox.NewOutboxRepository(pool)
+	repoStocks.SetReader(mainReader)
+	repoOutbox := repo_outbox.NewOutboxRepository(pool, shardManager)
+	repoSagas := repo_sagas.NewSagaRepository(pool)
 
 	// Kafka producer
 	kafkaProd, err := kafka_producer.NewKafkaProducer(&cfg.Kafka)
@@ -134,7 +186,16 @@ func NewApp(ctx context.Context, cancel context.CancelFunc) (*App, error) {
 	}
 
 	// Loms service
-	lomsService := loms_service.NewService(repoOrder, repoStocks, repoOutbox, txManager, kafkaProd)
+	idempotencyKeyRetention := time.Duration(cfg.Idempotency.GetKeyRetention()) * time.Hour
+	lomsService := loms_service.NewService(repoOrder, repoStocks, repoOutbox, repoSagas, txManager, kafkaProd, idempotencyKeyRetention)
+
+	// Import stocks from file
+	if cfg.Data.GetImportOnStartup() {
+		if err := importStocks(ctx, lomsService, cfg.Data.GetStockFilePath()); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to import stocks: %w", err)
+		}
+	}
 
 	// Loms app
 	lomsApp := loms_app.NewService(lomsService)
@@ -144,6 +205,8 @@ func NewApp(ctx context.Context, cancel context.CancelFunc) (*App, error) {
 
 	return &App{
 		config:       cfg,
+		outboxRepo:   repoOutbox,
+		bucketsRepo:  repoBuckets,
 		ctx:          ctx,
 		cancel:       cancel,
 		pool:         pool,
@@ -154,6 +217,7 @@ func NewApp(ctx context.Context, cancel context.CancelFunc) (*App, error) {
 		logger:       log,
 		tracer:       tr,
 		shardManager: shardManager,
+		mainReader:   mainReader,
 	}, nil
 }
 
@@ -165,6 +229,30 @@ func (a *App) Run() error {
 	// Run outbox
 	go a.startOutboxProcessor()
 
+	// Run outbox retention cleanup
+	go a.startOutboxCleaner()
+
+	// Run idempotency keys cleanup
+	go a.startIdempotencyKeysCleaner()
+
+	// Run cancellation of unpaid orders
+	go a.startExpiredOrdersCanceller()
+
+	// Run recovery of unfinished sagas
+	go a.startSagaRecovery()
+
+	// Run refresh of bucket map changed by rebalancing
+	go a.startShardBucketsRefresher()
+
+	// Run health checks of shards
+	go a.shardManager.RunHealthChecks(a.ctx, shardHealthCheckInterval, shardHealthCheckTimeout)
+
+	// Run replication lag monitors of read replicas
+	go a.mainReader.MonitorLag(a.ctx, replicaLagInterval)
+	for _, reader := range a.shardManager.GetReaders() {
+		go reader.MonitorLag(a.ctx, replicaLagInterval)
+	}
+
 	// Create channel to listen interrupt or terminate signals
 	quitChan := make(chan os.Signal, quitChannelBufferSize)
 	signal.Notify(quitChan, syscall.SIGINT, syscall.SIGTERM)
@@ -196,14 +284,158 @@ func (a *App) Run() error {
 
 // startOutboxProcessor
 func (a *App) startOutboxProcessor() {
-	ticker := time.NewTicker(processOutboxInterval)
+	pollInterval := time.Duration(a.config.Outbox.GetPollInterval()) * time.Millisecond
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// Relay is woken up by NOTIFY on insert into any outbox shard, ticker is fallback if notification is lost
+	wakeup := make(chan struct{}, 1)
+	for _, shard := range a.outboxRepo.Shards() {
+		go a.startOutboxListener(shard, wakeup, pollInterval)
+	}
+
+	opts := models.OutboxRelayOptions{
+		BatchSize:      a.config.Outbox.GetBatchSize(),
+		Concurrency:    a.config.Outbox.GetConcurrency(),
+		MaxAttempts:    a.config.Outbox.GetMaxAttempts(),
+		RetryBaseDelay: time.Duration(a.config.Outbox.GetRetryBaseDelay()) * time.Millisecond,
+		RetryMaxDelay:  time.Duration(a.config.Outbox.GetRetryMaxDelay()) * time.Millisecond,
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-wakeup:
+		case <-a.ctx.Done():
+			return
+		}
+
+		if _, err := a.lomsService.ProcessOutbox(a.ctx, opts); err != nil {
+			logger.Errorw(a.ctx, "Error processing outbox", "error", err)
+		}
+	}
+}
+
+// startOutboxListener
+func (a *App) startOutboxListener(shard models.OutboxShard, wakeup chan<- struct{}, retryInterval time.Duration) {
+	for {
+		if err := a.outboxRepo.ListenNewEvents(a.ctx, shard, wakeup); err != nil {
+			logger.Errorw(a.ctx, "Error listening outbox notifications", "shard", shard, "error", err)
+		}
+
+		select {
+		case <-time.After(retryInterval):
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// startOutboxCleaner
+func (a *App) startOutboxCleaner() {
+	ticker := time.NewTicker(cleanupOutboxInterval)
+	defer ticker.Stop()
+
+	retention := time.Duration(a.config.Outbox.GetRetention()) * time.Hour
+
+	for {
+		select {
+		case <-ticker.C:
+			deleted, err := a.lomsService.CleanupOutbox(a.ctx, retention)
+			if err != nil {
+				logger.Errorw(a.ctx, "Error cleaning up outbox", "error", err)
+				continue
+			}
+			if deleted > 0 {
+				logger.Infow(a.ctx, "Processed outbox events removed", "count", deleted)
+			}
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// startIdempotencyKeysCleaner
+func (a *App) startIdempotencyKeysCleaner() {
+	ticker := time.NewTicker(cleanupIdempotencyKeysInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			deleted, err := a.lomsService.CleanupIdempotencyKeys(a.ctx)
+			if err != nil {
+				logger.Errorw(a.ctx, "Error cleaning up idempotency keys", "error", err)
+				continue
+			}
+			if deleted > 0 {
+				logger.Infow(a.ctx, "Expired idempotency keys removed", "count", deleted)
+			}
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// startExpiredOrdersCanceller
+func (a *App) startExpiredOrdersCanceller() {
+	ticker := time.NewTicker(cancelExpiredOrdersInterval)
+	defer ticker.Stop()
+
+	paymentTimeout := time.Duration(a.config.Orders.GetPaymentTimeout()) * time.Minute
+
+	for {
+		select {
+		case <-ticker.C:
+			cancelled, err := a.lomsService.CancelExpiredOrders(a.ctx, paymentTimeout)
+			if err != nil {
+				logger.Errorw(a.ctx, "Error cancelling expired orders", "error", err)
+			}
+			if cancelled > 0 {
+				logger.Infow(a.ctx, "Expired orders cancelled", "count", cancelled)
+			}
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// startSagaRecovery
+func (a *App) startSagaRecovery() {
+	ticker := time.NewTicker(recoverSagasInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			recovered, err := a.lomsService.RecoverSagas(a.ctx, sagaStaleAfter)
+			if err != nil {
+				logger.Errorw(a.ctx, "Error recovering sagas", "error", err)
+			}
+			if recovered > 0 {
+				logger.Infow(a.ctx, "Sagas recovered", "count", recovered)
+			}
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// startShardBucketsRefresher
+func (a *App) startShardBucketsRefresher() {
+	ticker := time.NewTicker(refreshShardBucketsInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			if err := a.lomsService.ProcessOutbox(a.ctx); err != nil {
-				logger.Errorw(a.ctx, "Error processing outbox", "error", err)
+			buckets, err := a.bucketsRepo.List(a.ctx)
+			if err != nil {
+				logger.Errorw(a.ctx, "Error reading bucket map", "error", err)
+				continue
+			}
+			if err := a.shardManager.SetBuckets(buckets); err != nil {
+				logger.Errorw(a.ctx, "Error updating bucket map", "error", err)
 			}
 		case <-a.ctx.Done():
 			return
@@ -217,6 +449,7 @@ func (a *App) Shutdown() {
 
 	// Shutdown DB conn
 	a.pool.Close()
+	a.mainReader.Close()
 
 	// Shutdown shard conn
 	a.shardManager.CloseShards()
@@ -276,3 +509,41 @@ func (a *App) startMetricsServer(uri string) {
 		logger.Errorw(a.ctx, "Metrics server stopped", "error", err)
 	}
 }
+
+// connectReplicas connects to replicas, replica with empty DSN is nil.
+func connectReplicas(ctx context.Context, dsns []string) ([]*pgxpool.Pool, error) {
+	replicas := make([]*pgxpool.Pool, len(dsns))
+	for i, dsn := range dsns {
+		if dsn == "" {
+			continue
+		}
+		pool, err := db.NewConnect(ctx, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to replica %d: %w", i, err)
+		}
+		replicas[i] = pool
+	}
+	return replicas, nil
+}
+
+// importStocks upserts stocks from file.
+func importStocks(ctx context.Context, lomsService *loms_service.LomsService, path string) error {
+	rows, err := stock_import.ParseFile(path)
+	if err != nil {
+		return err
+	}
+
+	res, err := lomsService.StockImport(ctx, &models.StockImportRequest{Rows: rows})
+	if err != nil {
+		return err
+	}
+
+	logger.Infow(ctx, "Stocks imported",
+		"file", path,
+		"created", res.Created,
+		"updated", res.Updated,
+		"unchanged", res.Unchanged,
+	)
+
+	return nil
+}
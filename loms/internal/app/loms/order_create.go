@@ -9,8 +9,12 @@ import (
 	pb "route256/loms/pkg/api/loms/v1"
 
 	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/metadata"
 )
 
+// idempotencyKeyMetadata is metadata key used when request has no idempotency key field.
+const idempotencyKeyMetadata = "idempotency-key"
+
 // OrderCreate implements the GRPC OrderCreate method.
 func (s *Service) OrderCreate(ctx context.Context, req *pb.OrderCreateRequest) (*pb.OrderCreateResponse, error) {
 	// Tracer
@@ -21,6 +25,9 @@ func (s *Service) OrderCreate(ctx context.Context, req *pb.OrderCreateRequest) (
 	if err != nil {
 		return nil, errorToStatus(err)
 	}
+	if orderCreateRequest.IdempotencyKey == "" {
+		orderCreateRequest.IdempotencyKey = idempotencyKeyFromMetadata(ctx)
+	}
 
 	orderCreateResponse, err := s.LomsService.OrderCreate(ctx, orderCreateRequest)
 	if err != nil {
@@ -45,11 +52,21 @@ func toModelOrderCreateRequest(req *pb.OrderCreateRequest) (*models.OrderCreateR
 	}
 
 	return &models.OrderCreateRequest{
-		User:  models.UID(req.User),
-		Items: items,
+		User:           models.UID(req.User),
+		Items:          items,
+		IdempotencyKey: req.IdempotencyKey,
 	}, nil
 }
 
+// idempotencyKeyFromMetadata returns idempotency key from incoming metadata.
+func idempotencyKeyFromMetadata(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, idempotencyKeyMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
 // toPbOrderCreateResponse convert response.
 func toPbOrderCreateResponse(res *models.OrderCreateResponse) *pb.OrderCreateResponse {
 	if res == nil {
@@ -9,6 +9,7 @@ import (
 	pb "route256/loms/pkg/api/loms/v1"
 
 	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // OrderInfo implements the GRPC OrderInfo method.
@@ -54,9 +55,20 @@ func toPbOrderInfoResponse(res *models.OrderInfoResponse) *pb.OrderInfoResponse
 		}
 	}
 
+	history := make([]*pb.OrderStatusChange, len(res.History))
+	for i, change := range res.History {
+		history[i] = &pb.OrderStatusChange{
+			Status: string(change.Status),
+			Time:   timestamppb.New(change.Time),
+		}
+	}
+
 	return &pb.OrderInfoResponse{
-		Status: string(res.Status),
-		User:   res.User,
-		Items:  items,
+		Status:    string(res.Status),
+		User:      res.User,
+		Items:     items,
+		CreatedAt: timestamppb.New(res.CreatedAt),
+		UpdatedAt: timestamppb.New(res.UpdatedAt),
+		History:   history,
 	}
 }
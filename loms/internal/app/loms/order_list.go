@@ -2,10 +2,14 @@ package loms
 
 import (
 	"context"
+	"fmt"
 
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
 	pb "route256/loms/pkg/api/loms/v1"
 
 	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // OrderList implements the GRPC OrderList method.
@@ -14,13 +18,55 @@ func (s *Service) OrderList(ctx context.Context, req *pb.OrderListRequest) (*pb.
 	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "OrderList")
 	defer span.End()
 
-	orders, err := s.LomsService.OrderList(ctx)
+	orderListRequest, err := toModelOrderListRequest(req)
 	if err != nil {
 		return nil, errorToStatus(err)
 	}
 
-	pbOrders := make([]*pb.Order, len(orders))
-	for i, order := range orders {
+	orderListResponse, err := s.LomsService.OrderList(ctx, orderListRequest)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return toPbOrderListResponse(orderListResponse), nil
+}
+
+// toModelOrderListRequest convert request.
+func toModelOrderListRequest(req *pb.OrderListRequest) (*models.OrderListRequest, error) {
+	if req == nil {
+		return nil, fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest)
+	}
+
+	statuses := make([]models.OrderStatus, len(req.Statuses))
+	for i, status := range req.Statuses {
+		statuses[i] = models.OrderStatus(status)
+	}
+
+	res := &models.OrderListRequest{
+		UserID:       models.UID(req.User),
+		Statuses:     statuses,
+		PageSize:     req.PageSize,
+		Cursor:       req.Cursor,
+		AllowPartial: req.AllowPartial,
+	}
+	if req.CreatedFrom != nil {
+		res.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		res.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	return res, nil
+}
+
+// toPbOrderListResponse convert response.
+func toPbOrderListResponse(res *models.OrderListResponse) *pb.OrderListResponse {
+	if res == nil {
+		return &pb.OrderListResponse{}
+	}
+
+	pbOrders := make([]*pb.Order, len(res.Orders))
+	for i, order := range res.Orders {
 		items := make([]*pb.Item, len(order.Items))
 		for j, item := range order.Items {
 			items[j] = &pb.Item{
@@ -30,14 +76,23 @@ func (s *Service) OrderList(ctx context.Context, req *pb.OrderListRequest) (*pb.
 		}
 
 		pbOrders[i] = &pb.Order{
-			OrderID: order.OrderID,
-			Status:  string(order.Status),
-			User:    order.UserID,
-			Items:   items,
+			OrderID:   order.OrderID,
+			Status:    string(order.Status),
+			User:      order.UserID,
+			Items:     items,
+			CreatedAt: timestamppb.New(order.CreatedAt),
+			UpdatedAt: timestamppb.New(order.UpdatedAt),
 		}
 	}
 
+	unavailableShards := make([]int32, len(res.UnavailableShards))
+	for i, shard := range res.UnavailableShards {
+		unavailableShards[i] = int32(shard)
+	}
+
 	return &pb.OrderListResponse{
-		Orders: pbOrders,
-	}, nil
+		Orders:            pbOrders,
+		NextCursor:        res.NextCursor,
+		UnavailableShards: unavailableShards,
+	}
 }
@@ -0,0 +1,135 @@
+package loms
+
+import (
+	"context"
+	"fmt"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	pb "route256/loms/pkg/api/loms/v1"
+
+	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OutboxEventList implements the gRPC OutboxEventList method.
+func (s *Service) OutboxEventList(ctx context.Context, req *pb.OutboxEventListRequest) (*pb.OutboxEventListResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "OutboxEventList")
+	defer span.End()
+
+	if req == nil {
+		return nil, errorToStatus(fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest))
+	}
+
+	states := make([]models.OutboxEventState, len(req.States))
+	for i, state := range req.States {
+		states[i] = models.OutboxEventState(state)
+	}
+
+	listRequest := &models.OutboxEventListRequest{
+		Shard:    models.OutboxShard(req.Shard),
+		IDs:      req.Ids,
+		States:   states,
+		PageSize: req.PageSize,
+		Cursor:   req.Cursor,
+	}
+	if req.CreatedFrom != nil {
+		listRequest.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		listRequest.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	res, err := s.LomsService.OutboxEventList(ctx, listRequest)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return toPbOutboxEventListResponse(res), nil
+}
+
+// OutboxEventRequeue implements the gRPC OutboxEventRequeue method.
+func (s *Service) OutboxEventRequeue(ctx context.Context, req *pb.OutboxEventRequeueRequest) (*pb.OutboxEventRequeueResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "OutboxEventRequeue")
+	defer span.End()
+
+	if req == nil {
+		return nil, errorToStatus(fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest))
+	}
+
+	res, err := s.LomsService.OutboxEventRequeue(ctx, toModelOutboxEventResetRequest(req.Shard, req.Ids, req.CreatedFrom, req.CreatedTo))
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.OutboxEventRequeueResponse{Count: res.Count}, nil
+}
+
+// OutboxEventReplay implements the gRPC OutboxEventReplay method.
+func (s *Service) OutboxEventReplay(ctx context.Context, req *pb.OutboxEventReplayRequest) (*pb.OutboxEventReplayResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "OutboxEventReplay")
+	defer span.End()
+
+	if req == nil {
+		return nil, errorToStatus(fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest))
+	}
+
+	res, err := s.LomsService.OutboxEventReplay(ctx, toModelOutboxEventResetRequest(req.Shard, req.Ids, req.CreatedFrom, req.CreatedTo))
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.OutboxEventReplayResponse{Count: res.Count}, nil
+}
+
+// toModelOutboxEventResetRequest convert request selecting events to send again.
+func toModelOutboxEventResetRequest(shard int32, ids []int64, createdFrom, createdTo *timestamppb.Timestamp) *models.OutboxEventResetRequest {
+	res := &models.OutboxEventResetRequest{
+		Shard: models.OutboxShard(shard),
+		IDs:   ids,
+	}
+	if createdFrom != nil {
+		res.CreatedFrom = createdFrom.AsTime()
+	}
+	if createdTo != nil {
+		res.CreatedTo = createdTo.AsTime()
+	}
+
+	return res
+}
+
+// toPbOutboxEventListResponse convert response.
+func toPbOutboxEventListResponse(res *models.OutboxEventListResponse) *pb.OutboxEventListResponse {
+	if res == nil {
+		return &pb.OutboxEventListResponse{}
+	}
+
+	pbEvents := make([]*pb.OutboxEvent, len(res.Events))
+	for i, event := range res.Events {
+		pbEvent := &pb.OutboxEvent{
+			Id:            event.ID,
+			Shard:         int32(event.Shard),
+			EventType:     event.EventType,
+			Payload:       event.Payload,
+			OrderID:       event.OrderID,
+			Sequence:      event.Sequence,
+			State:         string(event.State),
+			Attempts:      event.Attempts,
+			LastError:     event.LastError,
+			NextAttemptAt: timestamppb.New(event.NextAttemptAt),
+			CreatedAt:     timestamppb.New(event.CreatedAt),
+		}
+		if !event.ProcessedAt.IsZero() {
+			pbEvent.ProcessedAt = timestamppb.New(event.ProcessedAt)
+		}
+		pbEvents[i] = pbEvent
+	}
+
+	return &pb.OutboxEventListResponse{
+		Events:     pbEvents,
+		NextCursor: res.NextCursor,
+	}
+}
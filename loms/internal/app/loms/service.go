@@ -3,13 +3,16 @@ package loms
 import (
 	"context"
 	"errors"
+	"fmt"
 	"route256/loms/internal/models"
 	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/stock_import"
 	pb "route256/loms/pkg/api/loms/v1"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 type ILomsService interface {
@@ -17,8 +20,16 @@ type ILomsService interface {
 	OrderInfo(ctx context.Context, req *models.OrderInfoRequest) (*models.OrderInfoResponse, error)
 	OrderPay(ctx context.Context, req *models.OrderPayRequest) error
 	OrderCancel(ctx context.Context, req *models.OrderCancelRequest) error
-	OrderList(ctx context.Context) ([]models.Order, error)
+	OrderList(ctx context.Context, req *models.OrderListRequest) (*models.OrderListResponse, error)
 	StocksInfo(ctx context.Context, req *models.StocksInfoRequest) (*models.StocksInfoResponse, error)
+	StocksInfoBatch(ctx context.Context, req *models.StocksInfoBatchRequest) (*models.StocksInfoBatchResponse, error)
+	StockReplenish(ctx context.Context, req *models.StockReplenishRequest) (*models.StockChangeResponse, error)
+	StockSet(ctx context.Context, req *models.StockSetRequest) (*models.StockChangeResponse, error)
+	StockAdjust(ctx context.Context, req *models.StockAdjustRequest) (*models.StockChangeResponse, error)
+	StockImport(ctx context.Context, req *models.StockImportRequest) (*models.StockImportResponse, error)
+	OutboxEventList(ctx context.Context, req *models.OutboxEventListRequest) (*models.OutboxEventListResponse, error)
+	OutboxEventRequeue(ctx context.Context, req *models.OutboxEventResetRequest) (*models.OutboxEventResetResponse, error)
+	OutboxEventReplay(ctx context.Context, req *models.OutboxEventResetRequest) (*models.OutboxEventResetResponse, error)
 }
 
 type Service struct {
@@ -35,21 +46,42 @@ func NewService(lomsService ILomsService) *Service {
 func errorToStatus(err error) error {
 	var st *status.Status
 	switch {
+	case errors.Is(err, internal_errors.ErrIdempotencyKeyReused):
+		st = status.New(codes.AlreadyExists, "idempotency key reused")
 	case errors.Is(err, internal_errors.ErrNotFound):
 		st = status.New(codes.NotFound, "not found")
 	case errors.Is(err, internal_errors.ErrBadRequest):
 		st = status.New(codes.InvalidArgument, "invalid argument")
 	case errors.Is(err, internal_errors.ErrPreconditionFailed):
 		st = status.New(codes.FailedPrecondition, "precondition failed")
+	case errors.Is(err, internal_errors.ErrSagaInProgress):
+		st = status.New(codes.Aborted, "operation in progress")
+	case errors.Is(err, internal_errors.ErrShardUnavailable):
+		st = status.New(codes.Unavailable, "shard unavailable")
 	default:
 		st = status.New(codes.Internal, "internal server error")
 	}
 
-	stDetails, errDetails := st.WithDetails(&errdetails.ErrorInfo{
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
 		Reason:   err.Error(),
 		Domain:   "loms",
 		Metadata: map[string]string{"details": err.Error()},
-	})
+	}}
+
+	// Invalid rows of imported file
+	var rowErrs stock_import.Errors
+	if errors.As(err, &rowErrs) {
+		badRequest := &errdetails.BadRequest{}
+		for _, rowErr := range rowErrs {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("line %d", rowErr.Line),
+				Description: rowErr.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	stDetails, errDetails := st.WithDetails(details...)
 	if errDetails != nil {
 		return st.Err()
 	}
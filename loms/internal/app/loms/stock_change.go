@@ -0,0 +1,112 @@
+package loms
+
+import (
+	"context"
+	"fmt"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	pb "route256/loms/pkg/api/loms/v1"
+
+	"go.opentelemetry.io/otel"
+)
+
+// StockReplenish implements the gRPC StockReplenish method.
+func (s *Service) StockReplenish(ctx context.Context, req *pb.StockReplenishRequest) (*pb.StockReplenishResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "StockReplenish")
+	defer span.End()
+
+	if req == nil {
+		return nil, errorToStatus(fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest))
+	}
+
+	res, err := s.LomsService.StockReplenish(ctx, &models.StockReplenishRequest{
+		SKU:     models.SKU(req.Sku),
+		Count:   req.Count,
+		Reason:  toModelStockChangeReason(req.Reason),
+		Comment: req.Comment,
+	})
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.StockReplenishResponse{Stock: toPbStock(res)}, nil
+}
+
+// StockSet implements the gRPC StockSet method.
+func (s *Service) StockSet(ctx context.Context, req *pb.StockSetRequest) (*pb.StockSetResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "StockSet")
+	defer span.End()
+
+	if req == nil {
+		return nil, errorToStatus(fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest))
+	}
+
+	res, err := s.LomsService.StockSet(ctx, &models.StockSetRequest{
+		SKU:        models.SKU(req.Sku),
+		TotalCount: req.TotalCount,
+		Reason:     toModelStockChangeReason(req.Reason),
+		Comment:    req.Comment,
+	})
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.StockSetResponse{Stock: toPbStock(res)}, nil
+}
+
+// StockAdjust implements the gRPC StockAdjust method.
+func (s *Service) StockAdjust(ctx context.Context, req *pb.StockAdjustRequest) (*pb.StockAdjustResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "StockAdjust")
+	defer span.End()
+
+	if req == nil {
+		return nil, errorToStatus(fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest))
+	}
+
+	res, err := s.LomsService.StockAdjust(ctx, &models.StockAdjustRequest{
+		SKU:     models.SKU(req.Sku),
+		Delta:   req.Delta,
+		Reason:  toModelStockChangeReason(req.Reason),
+		Comment: req.Comment,
+	})
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.StockAdjustResponse{Stock: toPbStock(res)}, nil
+}
+
+// toModelStockChangeReason convert reason, unknown reason is converted to empty one.
+func toModelStockChangeReason(reason pb.StockChangeReason) models.StockChangeReason {
+	switch reason {
+	case pb.StockChangeReason_STOCK_CHANGE_REASON_DELIVERY:
+		return models.StockChangeReasonDelivery
+	case pb.StockChangeReason_STOCK_CHANGE_REASON_RETURN:
+		return models.StockChangeReasonReturn
+	case pb.StockChangeReason_STOCK_CHANGE_REASON_INVENTORY:
+		return models.StockChangeReasonInventory
+	case pb.StockChangeReason_STOCK_CHANGE_REASON_DAMAGE:
+		return models.StockChangeReasonDamage
+	case pb.StockChangeReason_STOCK_CHANGE_REASON_CORRECTION:
+		return models.StockChangeReasonCorrection
+	default:
+		return ""
+	}
+}
+
+// toPbStock convert stock of response.
+func toPbStock(res *models.StockChangeResponse) *pb.Stock {
+	if res == nil {
+		return &pb.Stock{}
+	}
+
+	return &pb.Stock{
+		Sku:        uint32(res.Stock.SKU),
+		TotalCount: res.Stock.TotalCount,
+		Reserved:   res.Stock.Reserved,
+	}
+}
@@ -0,0 +1,70 @@
+package loms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/stock_import"
+	pb "route256/loms/pkg/api/loms/v1"
+
+	"go.opentelemetry.io/otel"
+)
+
+// StockImport implements the gRPC StockImport method.
+func (s *Service) StockImport(ctx context.Context, req *pb.StockImportRequest) (*pb.StockImportResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "StockImport")
+	defer span.End()
+
+	if req == nil {
+		return nil, errorToStatus(fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest))
+	}
+
+	rows, err := stock_import.Parse(strings.NewReader(req.Content), toStockImportFormat(req.Format))
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	res, err := s.LomsService.StockImport(ctx, &models.StockImportRequest{
+		Rows:   rows,
+		DryRun: req.DryRun,
+	})
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	changes := make([]*pb.StockImportChange, len(res.Changes))
+	for i, change := range res.Changes {
+		changes[i] = &pb.StockImportChange{
+			Line:          int32(change.Line),
+			Sku:           uint32(change.SKU),
+			Action:        string(change.Action),
+			OldTotalCount: change.OldTotalCount,
+			NewTotalCount: change.NewTotalCount,
+			Reserved:      change.Reserved,
+		}
+	}
+
+	return &pb.StockImportResponse{
+		Changes:   changes,
+		Created:   int32(res.Created),
+		Updated:   int32(res.Updated),
+		Unchanged: int32(res.Unchanged),
+		Applied:   res.Applied,
+	}, nil
+}
+
+// toStockImportFormat convert format, unknown format is converted to empty one.
+func toStockImportFormat(format pb.StockImportFormat) stock_import.Format {
+	switch format {
+	case pb.StockImportFormat_STOCK_IMPORT_FORMAT_JSON:
+		return stock_import.FormatJSON
+	case pb.StockImportFormat_STOCK_IMPORT_FORMAT_CSV:
+		return stock_import.FormatCSV
+	default:
+		return ""
+	}
+}
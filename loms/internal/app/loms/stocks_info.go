@@ -49,3 +49,44 @@ func toPBStocksInfoResponse(res *models.StocksInfoResponse) *pb.StocksInfoRespon
 		Count: res.Count,
 	}
 }
+
+// StocksInfoBatch implements the gRPC StocksInfoBatch method.
+func (s *Service) StocksInfoBatch(ctx context.Context, req *pb.StocksInfoBatchRequest) (*pb.StocksInfoBatchResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsHandlers").Start(ctx, "StocksInfoBatch")
+	defer span.End()
+
+	if req == nil {
+		return nil, errorToStatus(fmt.Errorf("invalid input data: %w", internal_errors.ErrBadRequest))
+	}
+
+	SKUs := make([]models.SKU, len(req.Skus))
+	for i, SKU := range req.Skus {
+		SKUs[i] = models.SKU(SKU)
+	}
+
+	res, err := s.LomsService.StocksInfoBatch(ctx, &models.StocksInfoBatchRequest{SKUs: SKUs})
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	stocks := make([]*pb.StockInfo, len(res.Stocks))
+	for i, stock := range res.Stocks {
+		stocks[i] = &pb.StockInfo{
+			Sku:        uint32(stock.SKU),
+			Available:  stock.Available,
+			TotalCount: stock.TotalCount,
+			Reserved:   stock.Reserved,
+		}
+	}
+
+	unknownSKUs := make([]uint32, len(res.UnknownSKUs))
+	for i, SKU := range res.UnknownSKUs {
+		unknownSKUs[i] = uint32(SKU)
+	}
+
+	return &pb.StocksInfoBatchResponse{
+		Stocks:      stocks,
+		UnknownSkus: unknownSKUs,
+	}, nil
+}
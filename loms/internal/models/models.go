@@ -37,16 +37,26 @@ const (
 
 // Order represents order containing items.
 type Order struct {
-	OrderID OID
-	Status  OrderStatus
-	UserID  int64
-	Items   []Item
+	OrderID   OID
+	Status    OrderStatus
+	UserID    int64
+	Items     []Item
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	History   []OrderStatusChange
+}
+
+// OrderStatusChange represents single transition of order status.
+type OrderStatusChange struct {
+	Status OrderStatus `json:"status"`
+	Time   time.Time   `json:"time"`
 }
 
 // OrderCreateRequest represents a request to create an order.
 type OrderCreateRequest struct {
-	User  UID    `validate:"gt=0"`
-	Items []Item `validate:"required,dive"`
+	User           UID    `validate:"gt=0"`
+	Items          []Item `validate:"required,dive"`
+	IdempotencyKey string `validate:"max=128"`
 }
 
 // OrderCreateResponse represents a response after creating an order.
@@ -54,6 +64,15 @@ type OrderCreateResponse struct {
 	OrderID OID `json:"orderID"`
 }
 
+// IdempotencyKey represents stored idempotency key of OrderCreate request.
+type IdempotencyKey struct {
+	UserID      UID
+	Key         string
+	RequestHash string
+	OrderID     OID
+	ExpiresAt   time.Time
+}
+
 // OrderInfoRequest represents a request for information about an order.
 type OrderInfoRequest struct {
 	OrderID OID `validate:"gt=0"`
@@ -61,9 +80,12 @@ type OrderInfoRequest struct {
 
 // OrderInfoResponse represents a response containing order information.
 type OrderInfoResponse struct {
-	Status OrderStatus `json:"status"`
-	User   UID         `validate:"gt=0"`
-	Items  []Item      `json:"items"`
+	Status    OrderStatus         `json:"status"`
+	User      UID                 `validate:"gt=0"`
+	Items     []Item              `json:"items"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	History   []OrderStatusChange `json:"history"`
 }
 
 // OrderPayRequest represents a request to pay for an order.
@@ -82,6 +104,39 @@ type OrderCancelRequest struct {
 // OrderCancelResponse represents a response after canceling an order.
 type OrderCancelResponse struct{}
 
+// OrderListRequest represents a request for a page of orders.
+type OrderListRequest struct {
+	UserID       UID
+	Statuses     []OrderStatus
+	CreatedFrom  time.Time
+	CreatedTo    time.Time
+	PageSize     uint32
+	Cursor       string
+	AllowPartial bool
+}
+
+// OrderListResponse represents a page of orders and cursor of the next page.
+type OrderListResponse struct {
+	Orders            []Order `json:"orders"`
+	NextCursor        string  `json:"next_cursor"`
+	UnavailableShards []int   `json:"unavailable_shards"`
+}
+
+// OrderFilter represents filters applied when listing orders.
+type OrderFilter struct {
+	AfterID     OID
+	UserID      UID
+	Statuses    []OrderStatus
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// OrdersPage represents orders read from shards, shards which were not read are listed in UnavailableShards.
+type OrdersPage struct {
+	Orders            []Order
+	UnavailableShards []int
+}
+
 // StocksInfoRequest represents a request for stock information.
 type StocksInfoRequest struct {
 	SKU SKU `validate:"gt=0"`
@@ -92,17 +147,255 @@ type StocksInfoResponse struct {
 	Count uint64 `json:"count"`
 }
 
-// OrderEvent represents kafka message.
+// StocksInfoBatchRequest represents a request for stock information of several SKUs.
+type StocksInfoBatchRequest struct {
+	SKUs []SKU
+}
+
+// StockInfo represents stock information of single SKU.
+type StockInfo struct {
+	SKU        SKU    `json:"sku"`
+	Available  uint64 `json:"available"`
+	TotalCount uint64 `json:"total_count"`
+	Reserved   uint64 `json:"reserved"`
+}
+
+// StocksInfoBatchResponse represents stock information of several SKUs, SKUs without stock are listed separately.
+type StocksInfoBatchResponse struct {
+	Stocks      []StockInfo `json:"stocks"`
+	UnknownSKUs []SKU       `json:"unknown_skus"`
+}
+
+// StockChangeReason represents reason of manual stock change.
+type StockChangeReason string
+
+const (
+	StockChangeReasonDelivery   StockChangeReason = "delivery"
+	StockChangeReasonReturn     StockChangeReason = "return"
+	StockChangeReasonInventory  StockChangeReason = "inventory"
+	StockChangeReasonDamage     StockChangeReason = "damage"
+	StockChangeReasonCorrection StockChangeReason = "correction"
+	StockChangeReasonImport     StockChangeReason = "import"
+)
+
+// Stock event types written in outbox.
+const (
+	StockEventReplenished = "StockReplenished"
+	StockEventSet         = "StockSet"
+	StockEventAdjusted    = "StockAdjusted"
+)
+
+// StockReplenishRequest represents a request to add items to stock.
+type StockReplenishRequest struct {
+	SKU     SKU    `validate:"gt=0"`
+	Count   uint64 `validate:"gt=0"`
+	Reason  StockChangeReason
+	Comment string
+}
+
+// StockSetRequest represents a request to set total count of stock.
+type StockSetRequest struct {
+	SKU        SKU `validate:"gt=0"`
+	TotalCount uint64
+	Reason     StockChangeReason
+	Comment    string
+}
+
+// StockAdjustRequest represents a request to change total count of stock by delta.
+type StockAdjustRequest struct {
+	SKU     SKU `validate:"gt=0"`
+	Delta   int64
+	Reason  StockChangeReason
+	Comment string
+}
+
+// StockChangeResponse represents stock after change.
+type StockChangeResponse struct {
+	Stock Stock `json:"stock"`
+}
+
+// StockImportAction represents what import does with stock.
+type StockImportAction string
+
+const (
+	StockImportActionCreate    StockImportAction = "create"
+	StockImportActionUpdate    StockImportAction = "update"
+	StockImportActionUnchanged StockImportAction = "unchanged"
+)
+
+// StockImportRow represents stock read from line of imported file.
+type StockImportRow struct {
+	Line  int
+	Stock Stock
+}
+
+// StockImportRequest represents a request to import stocks.
+type StockImportRequest struct {
+	Rows   []StockImportRow
+	DryRun bool
+}
+
+// StockImportChange represents change of single stock made by import.
+type StockImportChange struct {
+	Line          int               `json:"line"`
+	SKU           SKU               `json:"sku"`
+	Action        StockImportAction `json:"action"`
+	OldTotalCount uint64            `json:"old_total_count"`
+	NewTotalCount uint64            `json:"new_total_count"`
+	Reserved      uint64            `json:"reserved"`
+}
+
+// StockImportResponse represents result of stocks import.
+type StockImportResponse struct {
+	Changes   []StockImportChange `json:"changes"`
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Unchanged int                 `json:"unchanged"`
+	Applied   bool                `json:"applied"`
+}
+
+// StockEvent represents kafka message about stock change.
+type StockEvent struct {
+	SKU        SKU               `json:"sku"`
+	TotalCount uint64            `json:"total_count"`
+	Reserved   uint64            `json:"reserved"`
+	Delta      int64             `json:"delta,omitempty"`
+	Reason     StockChangeReason `json:"reason"`
+	Comment    string            `json:"comment,omitempty"`
+	Time       time.Time         `json:"time"`
+}
+
+// SagaType represents operation on order coordinated by saga.
+type SagaType string
+
+const (
+	SagaTypeReserve SagaType = "reserve"
+	SagaTypePay     SagaType = "pay"
+	SagaTypeCancel  SagaType = "cancel"
+)
+
+// SagaState represents state of saga.
+type SagaState string
+
+const (
+	SagaStateStarted     SagaState = "started"
+	SagaStateCompleted   SagaState = "completed"
+	SagaStateCompensated SagaState = "compensated"
+)
+
+// Saga represents durable state of operation changing both order shard and stocks database.
+type Saga struct {
+	ID         int64
+	OrderID    OID
+	Type       SagaType
+	FromStatus OrderStatus
+	ToStatus   OrderStatus
+	EventType  string
+	Items      []Item
+	State      SagaState
+	Attempts   int32
+}
+
+// Order event types written in outbox.
+const (
+	OrderEventCreated         = "OrderCreated"
+	OrderEventAwaitingPayment = "OrderAwaitingPayment"
+	OrderEventFailed          = "OrderFailed"
+	OrderEventPayed           = "OrderPayed"
+	OrderEventCancelled       = "OrderCancelled"
+	OrderEventExpired         = "OrderExpired"
+)
+
+// OrderEvent represents event of order stored in outbox, it is sent to Kafka as protobuf OrderEvent.
+// Sequence grows by one for each event of order, so consumer can detect gaps and reordering.
 type OrderEvent struct {
-	OrderID    UID         `json:"order_id"`
-	Status     OrderStatus `json:"status"`
-	Time       time.Time   `json:"time"`
-	Additional string      `json:"additional"`
+	EventID   string      `json:"event_id"`
+	OrderID   OID         `json:"order_id"`
+	UserID    UID         `json:"user_id"`
+	Items     []Item      `json:"items"`
+	Sequence  int64       `json:"sequence"`
+	OldStatus OrderStatus `json:"old_status"`
+	Status    OrderStatus `json:"status"`
+	Time      time.Time   `json:"time"`
 }
 
+// OutboxEventState represents delivery state of outbox event.
+type OutboxEventState string
+
+const (
+	OutboxEventStatePending   OutboxEventState = "pending"
+	OutboxEventStateProcessed OutboxEventState = "processed"
+	OutboxEventStateDead      OutboxEventState = "dead"
+)
+
+// OutboxShard identifies database holding outbox table, order events are stored on shard of order
+// and other events in main database.
+type OutboxShard int
+
+// OutboxShardMain is outbox of main database.
+const OutboxShardMain OutboxShard = -1
+
 // OutboxEvent represents event stored in the outbox table.
 type OutboxEvent struct {
-	ID        int64  `json:"id"`
-	EventType string `json:"event_type"`
-	Payload   string `json:"payload"`
+	ID            int64            `json:"id"`
+	Shard         OutboxShard      `json:"shard"`
+	EventType     string           `json:"event_type"`
+	Payload       string           `json:"payload"`
+	OrderID       OID              `json:"order_id"`
+	Sequence      int64            `json:"sequence"`
+	State         OutboxEventState `json:"state"`
+	Attempts      int32            `json:"attempts"`
+	LastError     string           `json:"last_error"`
+	NextAttemptAt time.Time        `json:"next_attempt_at"`
+	CreatedAt     time.Time        `json:"created_at"`
+	ProcessedAt   time.Time        `json:"processed_at"`
+	TraceParent   string           `json:"trace_parent"`
+}
+
+// OutboxRelayOptions represents parameters of outbox relay.
+type OutboxRelayOptions struct {
+	BatchSize      uint32
+	Concurrency    int
+	MaxAttempts    int32
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// OutboxEventFilter represents filters applied when listing or resetting outbox events.
+type OutboxEventFilter struct {
+	AfterID     int64
+	IDs         []int64
+	States      []OutboxEventState
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// OutboxEventListRequest represents a request for a page of outbox events of one shard.
+type OutboxEventListRequest struct {
+	Shard       OutboxShard
+	IDs         []int64
+	States      []OutboxEventState
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	PageSize    uint32
+	Cursor      string
+}
+
+// OutboxEventListResponse represents a page of outbox events and cursor of the next page.
+type OutboxEventListResponse struct {
+	Events     []OutboxEvent `json:"events"`
+	NextCursor string        `json:"next_cursor"`
+}
+
+// OutboxEventResetRequest selects outbox events of one shard to send again by IDs or creation time range.
+type OutboxEventResetRequest struct {
+	Shard       OutboxShard
+	IDs         []int64
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// OutboxEventResetResponse represents count of outbox events queued for sending.
+type OutboxEventResetResponse struct {
+	Count int64 `json:"count"`
 }
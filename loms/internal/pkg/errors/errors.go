@@ -14,6 +14,15 @@ var (
 	ErrInvalidOrderStatus = errors.New("invalid order status")
 	ErrStockReservation   = errors.New("stock reservation failed")
 
+	ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with different request")
+
+	ErrSagaInProgress = errors.New("another operation on order is in progress")
+	ErrSagaFinished   = errors.New("saga is already finished")
+
 	ErrShardIndexOutOfRange = errors.New("shard index is out of range")
 	ErrNoShardsAvailable    = errors.New("no shards available")
+	ErrBucketBusy           = errors.New("bucket has unsent events")
+	ErrShardsDiverged       = errors.New("shard migrations diverged")
+	ErrShardUnavailable     = errors.New("shard is unavailable")
 )
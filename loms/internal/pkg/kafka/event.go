@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"route256/loms/internal/models"
+	"strconv"
+
+	"route256/utils/events"
+	pb "route256/utils/events/pkg/api/events/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// encodedEvent is outbox event prepared for sending to Kafka.
+type encodedEvent struct {
+	value       []byte
+	contentType string
+	cloudEvent  events.CloudEvent
+}
+
+// encodeEvent returns value of Kafka message for outbox event with its content type and CloudEvents attributes.
+// Order events are encoded with shared protobuf schema, other events are sent as stored JSON.
+func encodeEvent(event models.OutboxEvent) (encodedEvent, error) {
+	if event.OrderID == 0 {
+		return encodeStockEvent(event)
+	}
+
+	var orderEvent models.OrderEvent
+	if err := json.Unmarshal([]byte(event.Payload), &orderEvent); err != nil {
+		return encodedEvent{}, fmt.Errorf("failed to decode order event %d: %w", event.ID, err)
+	}
+
+	value, contentType, err := events.MarshalOrderEvent(toProtoOrderEvent(event.EventType, orderEvent))
+	if err != nil {
+		return encodedEvent{}, err
+	}
+
+	return encodedEvent{
+		value:       value,
+		contentType: contentType,
+		cloudEvent: events.CloudEvent{
+			ID:      orderEvent.EventID,
+			Type:    events.CloudEventType(event.EventType),
+			Time:    orderEvent.Time,
+			Subject: strconv.FormatInt(event.OrderID, 10),
+		},
+	}, nil
+}
+
+// encodeStockEvent returns stored JSON of stock event, events of main outbox are identified by outbox ID.
+func encodeStockEvent(event models.OutboxEvent) (encodedEvent, error) {
+	var stockEvent models.StockEvent
+	if err := json.Unmarshal([]byte(event.Payload), &stockEvent); err != nil {
+		return encodedEvent{}, fmt.Errorf("failed to decode stock event %d: %w", event.ID, err)
+	}
+
+	return encodedEvent{
+		value:       []byte(event.Payload),
+		contentType: events.ContentTypeJSON,
+		cloudEvent: events.CloudEvent{
+			ID:      strconv.FormatInt(event.ID, 10),
+			Type:    events.CloudEventType(event.EventType),
+			Time:    stockEvent.Time,
+			Subject: strconv.FormatInt(int64(stockEvent.SKU), 10),
+		},
+	}, nil
+}
+
+// toProtoOrderEvent converts order event stored in outbox to protobuf OrderEvent.
+func toProtoOrderEvent(eventType string, event models.OrderEvent) *pb.OrderEvent {
+	items := make([]*pb.OrderItem, 0, len(event.Items))
+	for _, item := range event.Items {
+		items = append(items, &pb.OrderItem{
+			Sku:   uint32(item.SKU),
+			Count: uint32(item.Count),
+		})
+	}
+
+	return &pb.OrderEvent{
+		SchemaVersion: events.OrderEventSchemaVersion,
+		EventId:       event.EventID,
+		EventType:     events.OrderEventTypeFromName(eventType),
+		OrderId:       event.OrderID,
+		UserId:        event.UserID,
+		Items:         items,
+		OldStatus:     string(event.OldStatus),
+		NewStatus:     string(event.Status),
+		Sequence:      event.Sequence,
+		Time:          timestamppb.New(event.Time),
+	}
+}
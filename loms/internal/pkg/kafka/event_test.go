@@ -0,0 +1,195 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"route256/loms/internal/models"
+
+	"route256/utils/events"
+	pb "route256/utils/events/pkg/api/events/v1"
+	"route256/utils/tracer"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// Test function for encodeEvent function.
+func TestEncodeEvent_Table(t *testing.T) {
+	tests := []struct {
+		name                string
+		event               models.OutboxEvent
+		expectedContentType string
+		expectedCloudEvent  events.CloudEvent
+		expectedOrderEvent  *pb.OrderEvent
+		errorContains       string
+	}{
+		{
+			name: "order event encoded with protobuf",
+			event: models.OutboxEvent{
+				ID:        1,
+				EventType: models.OrderEventPayed,
+				OrderID:   1001,
+				Payload: `{"event_id":"e1","order_id":1001,"user_id":7,"items":[{"SKU":1005,"Count":5}],` +
+					`"sequence":3,"old_status":"awaiting payment","status":"paid","time":"2024-11-01T12:00:00Z"}`,
+			},
+			expectedContentType: events.ContentTypeProtobuf,
+			expectedCloudEvent: events.CloudEvent{
+				ID:      "e1",
+				Type:    "route256.loms.OrderPayed",
+				Time:    time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC),
+				Subject: "1001",
+			},
+			expectedOrderEvent: &pb.OrderEvent{
+				SchemaVersion: events.OrderEventSchemaVersion,
+				EventId:       "e1",
+				EventType:     pb.OrderEventType_ORDER_EVENT_TYPE_PAYED,
+				OrderId:       1001,
+				UserId:        7,
+				Items:         []*pb.OrderItem{{Sku: 1005, Count: 5}},
+				OldStatus:     "awaiting payment",
+				NewStatus:     "paid",
+				Sequence:      3,
+			},
+		},
+		{
+			name: "stock event sent as JSON",
+			event: models.OutboxEvent{
+				ID:        2,
+				EventType: models.StockEventSet,
+				Payload:   `{"sku":1005,"total_count":10,"time":"2024-11-01T12:00:00Z"}`,
+			},
+			expectedContentType: events.ContentTypeJSON,
+			expectedCloudEvent: events.CloudEvent{
+				ID:      "2",
+				Type:    "route256.loms.StockSet",
+				Time:    time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC),
+				Subject: "1005",
+			},
+		},
+		{
+			name: "broken order event",
+			event: models.OutboxEvent{
+				ID:        3,
+				EventType: models.OrderEventCreated,
+				OrderID:   1002,
+				Payload:   `{`,
+			},
+			errorContains: "failed to decode order event 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := encodeEvent(tt.event)
+			if tt.errorContains != "" {
+				require.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedContentType, encoded.contentType)
+			require.Equal(t, tt.expectedCloudEvent, encoded.cloudEvent)
+
+			if tt.expectedOrderEvent == nil {
+				require.Equal(t, tt.event.Payload, string(encoded.value))
+				return
+			}
+
+			event, err := events.UnmarshalOrderEvent(encoded.contentType, encoded.value)
+			require.NoError(t, err)
+			require.Equal(t, time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC), event.Time.AsTime())
+			event.Time = nil
+			require.True(t, proto.Equal(tt.expectedOrderEvent, event), "event = %v", event)
+		})
+	}
+}
+
+// testKafkaCfg is Kafka config of tests.
+type testKafkaCfg struct {
+	cloudEvents bool
+}
+
+func (c testKafkaCfg) GetBrokers() []string   { return nil }
+func (c testKafkaCfg) GetTopic() string       { return "loms.order-events" }
+func (c testKafkaCfg) GetStockTopic() string  { return "loms.stock-events" }
+func (c testKafkaCfg) GetCloudEvents() bool   { return c.cloudEvents }
+func (c testKafkaCfg) GetEventSource() string { return "/loms" }
+
+// Test function for headersForEvent method of KafkaProducer.
+func TestKafkaProducer_HeadersForEvent_Table(t *testing.T) {
+	encoded := encodedEvent{
+		contentType: events.ContentTypeProtobuf,
+		cloudEvent: events.CloudEvent{
+			ID:      "e1",
+			Type:    "route256.loms.OrderPayed",
+			Time:    time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC),
+			Subject: "1001",
+		},
+	}
+
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name            string
+		cloudEvents     bool
+		traceParent     string
+		expectedHeaders map[string]string
+	}{
+		{
+			name:        "legacy format",
+			cloudEvents: false,
+			expectedHeaders: map[string]string{
+				events.HeaderContentType: events.ContentTypeProtobuf,
+			},
+		},
+		{
+			name:        "trace of request",
+			cloudEvents: false,
+			traceParent: traceParent,
+			expectedHeaders: map[string]string{
+				events.HeaderContentType: events.ContentTypeProtobuf,
+				tracer.HeaderTraceParent: traceParent,
+			},
+		},
+		{
+			name:        "CloudEvents binary content mode",
+			cloudEvents: true,
+			expectedHeaders: map[string]string{
+				events.HeaderContentType:            events.ContentTypeProtobuf,
+				events.HeaderCloudEventsSpecVersion: "1.0",
+				events.HeaderCloudEventsID:          "e1",
+				events.HeaderCloudEventsSource:      "/loms",
+				events.HeaderCloudEventsType:        "route256.loms.OrderPayed",
+				events.HeaderCloudEventsTime:        "2024-11-01T12:00:00Z",
+				events.HeaderCloudEventsSubject:     "1001",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kp := &KafkaProducer{cfg: testKafkaCfg{cloudEvents: tt.cloudEvents}}
+			ctx := tracer.ContextWithTraceParent(context.Background(), tt.traceParent)
+
+			headers := make(map[string]string)
+			for _, header := range kp.headersForEvent(ctx, encoded) {
+				headers[string(header.Key)] = string(header.Value)
+			}
+			require.Equal(t, tt.expectedHeaders, headers)
+
+			// Consumer reads the same event back from headers
+			if tt.cloudEvents {
+				cloudEvent, ok, err := events.ParseCloudEvent(headers)
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, "/loms", cloudEvent.Source)
+				require.Equal(t, encoded.cloudEvent.ID, cloudEvent.ID)
+			}
+		})
+	}
+}
@@ -4,20 +4,42 @@ import (
 	"context"
 	"fmt"
 	"route256/loms/internal/models"
+	shared_events "route256/utils/events"
 	"route256/utils/logger"
+	"route256/utils/tracer"
+	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type IKafkaCfg interface {
 	GetBrokers() []string
 	GetTopic() string
+	GetStockTopic() string
+	GetCloudEvents() bool
+	GetEventSource() string
 }
 
 type KafkaProducer struct {
-	producer sarama.SyncProducer
+	producer sarama.AsyncProducer
 	cfg      IKafkaCfg
+	wg       sync.WaitGroup
+}
+
+// sendResult is delivery result of one outbox event.
+type sendResult struct {
+	eventID int64
+	err     error
+}
+
+// messageMetadata routes delivery result of message to batch it was sent with.
+type messageMetadata struct {
+	eventID int64
+	results chan<- sendResult
 }
 
 // NewKafkaProducer creates a new KafkaProducer instance.
@@ -28,7 +50,7 @@ func NewKafkaProducer(cfg IKafkaCfg) (*KafkaProducer, error) {
 	kafkaConfig.Producer.RequiredAcks = sarama.WaitForAll
 	kafkaConfig.Producer.Retry.Max = 5
 
-	producer, err := sarama.NewSyncProducer(cfg.GetBrokers(), kafkaConfig)
+	producer, err := sarama.NewAsyncProducer(cfg.GetBrokers(), kafkaConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
 	}
@@ -38,31 +60,150 @@ func NewKafkaProducer(cfg IKafkaCfg) (*KafkaProducer, error) {
 		cfg:      cfg,
 	}
 
+	kp.wg.Add(2)
+	go kp.dispatchSuccesses()
+	go kp.dispatchErrors()
+
 	return kp, nil
 }
 
-// SendOutboxEvent send message containing OutboxEvent to Kafka.
-func (kp *KafkaProducer) SendOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
-	key := fmt.Sprintf("%d", event.ID)
+// SendOutboxEvents sends batch of OutboxEvent to Kafka and returns delivery result of each event by its ID,
+// nil result means event was delivered. Events without result were not confirmed before ctx was done.
+// Events of one order are keyed by order ID, so they get into one partition and keep their order.
+// Each message continues trace of request which created event, its traceparent is sent in headers.
+func (kp *KafkaProducer) SendOutboxEvents(ctx context.Context, events []models.OutboxEvent) (map[int64]error, error) {
+	// Buffered for whole batch, so dispatchers never block on abandoned batch
+	results := make(chan sendResult, len(events))
+
+	// Spans of messages are ended on delivery, unconfirmed ones when batch is abandoned
+	spans := make(map[int64]trace.Span, len(events))
+	defer func() {
+		for _, span := range spans {
+			span.End()
+		}
+	}()
+
+	queued := 0
+	delivery := make(map[int64]error, len(events))
+	for _, event := range events {
+		key := fmt.Sprintf("%d", event.ID)
+		if event.OrderID != 0 {
+			key = fmt.Sprintf("%d", event.OrderID)
+		}
+
+		msgCtx, span := otel.Tracer("KafkaProducer").Start(tracer.ContextWithTraceParent(ctx, event.TraceParent), "SendOutboxEvent",
+			trace.WithSpanKind(trace.SpanKindProducer),
+		)
 
-	msg := &sarama.ProducerMessage{
-		Topic:     kp.cfg.GetTopic(),
-		Key:       sarama.StringEncoder(key),
-		Value:     sarama.ByteEncoder(event.Payload),
-		Timestamp: time.Now(),
+		// Event which can not be encoded fails without sending
+		encoded, err := encodeEvent(event)
+		if err != nil {
+			delivery[event.ID] = err
+			endSendSpan(span, err)
+			continue
+		}
+		spans[event.ID] = span
+
+		msg := &sarama.ProducerMessage{
+			Topic:     kp.topicForEvent(event.EventType),
+			Key:       sarama.StringEncoder(key),
+			Value:     sarama.ByteEncoder(encoded.value),
+			Headers:   kp.headersForEvent(msgCtx, encoded),
+			Timestamp: time.Now(),
+			Metadata:  messageMetadata{eventID: event.ID, results: results},
+		}
+
+		select {
+		case kp.producer.Input() <- msg:
+			queued++
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
 
-	partition, offset, err := kp.producer.SendMessage(msg)
-	if err != nil {
-		return fmt.Errorf("failed to send message to Kafka: %w", err)
+	// Wait for delivery of all queued messages
+	for i := 0; i < queued; i++ {
+		select {
+		case res := <-results:
+			delivery[res.eventID] = res.err
+			if span, ok := spans[res.eventID]; ok {
+				endSendSpan(span, res.err)
+				delete(spans, res.eventID)
+			}
+		case <-ctx.Done():
+			return delivery, ctx.Err()
+		}
+	}
+
+	return delivery, nil
+}
+
+// dispatchSuccesses passes delivered messages to their batches.
+func (kp *KafkaProducer) dispatchSuccesses() {
+	defer kp.wg.Done()
+
+	for msg := range kp.producer.Successes() {
+		logger.Debugw(context.Background(), fmt.Sprintf("Message sent to partition %d at offset %d", msg.Partition, msg.Offset))
+		if meta, ok := msg.Metadata.(messageMetadata); ok {
+			meta.results <- sendResult{eventID: meta.eventID}
+		}
 	}
+}
+
+// dispatchErrors passes delivery errors to batches of failed messages.
+func (kp *KafkaProducer) dispatchErrors() {
+	defer kp.wg.Done()
+
+	for prodErr := range kp.producer.Errors() {
+		if meta, ok := prodErr.Msg.Metadata.(messageMetadata); ok {
+			meta.results <- sendResult{eventID: meta.eventID, err: prodErr.Err}
+		}
+	}
+}
+
+// topicForEvent returns topic for event type, stock events are sent to separate topic.
+func (kp *KafkaProducer) topicForEvent(eventType string) string {
+	switch eventType {
+	case models.StockEventReplenished, models.StockEventSet, models.StockEventAdjusted:
+		return kp.cfg.GetStockTopic()
+	default:
+		return kp.cfg.GetTopic()
+	}
+}
 
-	logger.Infow(ctx, fmt.Sprintf("Message sent to partition %d at offset %d\n", partition, offset))
+// headersForEvent returns headers of message with traceparent of span in ctx, with CloudEvents
+// enabled message is sent in CloudEvents binary content mode with event as data.
+func (kp *KafkaProducer) headersForEvent(ctx context.Context, encoded encodedEvent) []sarama.RecordHeader {
+	headers := []sarama.RecordHeader{
+		{Key: []byte(shared_events.HeaderContentType), Value: []byte(encoded.contentType)},
+	}
+	if traceParent := tracer.TraceParent(ctx); traceParent != "" {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(tracer.HeaderTraceParent), Value: []byte(traceParent)})
+	}
+	if !kp.cfg.GetCloudEvents() {
+		return headers
+	}
+
+	cloudEvent := encoded.cloudEvent
+	cloudEvent.Source = kp.cfg.GetEventSource()
+	for key, value := range cloudEvent.Headers() {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
+	return headers
+}
 
-	return nil
+// endSendSpan ends span of message with its delivery result.
+func endSendSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
 }
 
-// Close gracefully close Kafka producer connection.
+// Close gracefully close Kafka producer connection, buffered messages are flushed.
 func (kp *KafkaProducer) Close() error {
-	return kp.producer.Close()
+	err := kp.producer.Close()
+	kp.wg.Wait()
+	return err
 }
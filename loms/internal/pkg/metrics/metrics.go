@@ -45,6 +45,51 @@ var (
 		},
 		[]string{"query_type"},
 	)
+
+	DBReadCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "app",
+			Name:      "db_read_total",
+			Help:      "Total number of database reads by pool and target",
+		},
+		[]string{"pool", "target"},
+	)
+
+	DBReadFallbackCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "app",
+			Name:      "db_read_fallback_total",
+			Help:      "Total number of replica reads retried on primary",
+		},
+		[]string{"pool"},
+	)
+
+	DBReplicaLag = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "app",
+			Name:      "db_replica_lag_seconds",
+			Help:      "Replication lag of replica in seconds, -1 if unknown",
+		},
+		[]string{"pool"},
+	)
+
+	ShardUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "app",
+			Name:      "shard_up",
+			Help:      "Result of last health check of shard, 1 if shard is healthy",
+		},
+		[]string{"shard"},
+	)
+
+	ShardCircuitState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "app",
+			Name:      "shard_circuit_state",
+			Help:      "State of circuit breaker of shard: 0 closed, 1 half-open, 2 open",
+		},
+		[]string{"shard"},
+	)
 )
 
 // IncRequestCounterWithStatus increments the request counter for a handler with status code.
@@ -66,3 +111,32 @@ func IncDBQueryCounter(queryType string) {
 func ObserveDBQueryDuration(queryType string, duration time.Duration) {
 	DBQueryDuration.WithLabelValues(queryType).Observe(duration.Seconds())
 }
+
+// IncDBReadCounter increments DB read counter of pool.
+func IncDBReadCounter(pool, target string) {
+	DBReadCounter.WithLabelValues(pool, target).Inc()
+}
+
+// IncDBReadFallbackCounter increments counter of replica reads retried on primary.
+func IncDBReadFallbackCounter(pool string) {
+	DBReadFallbackCounter.WithLabelValues(pool).Inc()
+}
+
+// SetDBReplicaLag sets replication lag of pool.
+func SetDBReplicaLag(pool string, seconds float64) {
+	DBReplicaLag.WithLabelValues(pool).Set(seconds)
+}
+
+// SetShardUp sets result of health check of shard.
+func SetShardUp(shard string, up bool) {
+	value := 0.0
+	if up {
+		value = 1
+	}
+	ShardUp.WithLabelValues(shard).Set(value)
+}
+
+// SetShardCircuitState sets state of circuit breaker of shard.
+func SetShardCircuitState(shard string, state int) {
+	ShardCircuitState.WithLabelValues(shard).Set(float64(state))
+}
@@ -0,0 +1,259 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sync"
+
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/migrations"
+
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// Action is migration command applied to databases.
+type Action string
+
+const (
+	ActionUp     Action = "up"
+	ActionDown   Action = "down"
+	ActionStatus Action = "status"
+	ActionRedo   Action = "redo"
+	ActionUpTo   Action = "up-to"
+	ActionDownTo Action = "down-to"
+)
+
+// Database is database migrations are applied to.
+type Database struct {
+	Name   string
+	Target migrations.Target
+	DSN    string
+}
+
+// Version is migration state of database.
+type Version struct {
+	Name    string
+	Target  migrations.Target
+	Current int64
+	Latest  int64
+	Pending int
+	Applied []int64
+}
+
+// Result is result of action on database.
+type Result struct {
+	Name       string
+	Migrations []*goose.MigrationResult
+	Err        error
+}
+
+// database holds connection and migrations of target.
+type database struct {
+	Database
+	conn     *sql.DB
+	provider *goose.Provider
+}
+
+// Migrator applies migrations of each target to its databases in parallel.
+type Migrator struct {
+	databases []*database
+}
+
+// NewMigrator connects to databases and collects migrations of their targets from fsys.
+func NewMigrator(ctx context.Context, fsys fs.FS, databases []Database) (*Migrator, error) {
+	m := &Migrator{}
+	for _, db := range databases {
+		conn, err := NewConnect(ctx, db.DSN)
+		if err != nil {
+			m.Close()
+			return nil, fmt.Errorf("failed to connect to %s: %w", db.Name, err)
+		}
+
+		provider, err := goose.NewProvider(goose.DialectPostgres, conn, fsys,
+			goose.WithExcludeNames(migrations.Excludes(db.Target)),
+		)
+		if err != nil {
+			conn.Close()
+			m.Close()
+			return nil, fmt.Errorf("failed to load migrations of %s: %w", db.Name, err)
+		}
+
+		m.databases = append(m.databases, &database{Database: db, conn: conn, provider: provider})
+	}
+	return m, nil
+}
+
+// Versions returns migration state of each database in order of databases.
+func (m *Migrator) Versions(ctx context.Context) ([]Version, error) {
+	versions := make([]Version, len(m.databases))
+	errs := make([]error, len(m.databases))
+
+	m.each(func(i int, db *database) {
+		versions[i], errs[i] = db.version(ctx)
+	})
+
+	return versions, errors.Join(errs...)
+}
+
+// Run applies action to all databases in parallel, version is used by up-to and down-to.
+// Action is refused when shards have diverged.
+func (m *Migrator) Run(ctx context.Context, action Action, version int64) ([]Result, error) {
+	if !action.Valid() {
+		return nil, fmt.Errorf("unknown migration action %q: %w", action, internal_errors.ErrBadRequest)
+	}
+
+	versions, err := m.Versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckDiverged(versions); err != nil {
+		return nil, err
+	}
+	if action == ActionStatus {
+		return nil, nil
+	}
+
+	results := make([]Result, len(m.databases))
+	m.each(func(i int, db *database) {
+		results[i] = Result{Name: db.Name}
+		results[i].Migrations, results[i].Err = db.run(ctx, action, version)
+	})
+
+	var errs []error
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.Name, res.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// Close closes connections to all databases.
+func (m *Migrator) Close() {
+	for _, db := range m.databases {
+		db.conn.Close()
+	}
+}
+
+// each runs fn for every database in parallel.
+func (m *Migrator) each(fn func(i int, db *database)) {
+	var wg sync.WaitGroup
+	for i, db := range m.databases {
+		wg.Add(1)
+		go func(i int, db *database) {
+			defer wg.Done()
+			fn(i, db)
+		}(i, db)
+	}
+	wg.Wait()
+}
+
+// version returns migration state of database.
+func (db *database) version(ctx context.Context) (Version, error) {
+	statuses, err := db.provider.Status(ctx)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to get status of %s: %w", db.Name, err)
+	}
+
+	current, err := db.provider.GetDBVersion(ctx)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to get version of %s: %w", db.Name, err)
+	}
+
+	version := Version{
+		Name:    db.Name,
+		Target:  db.Target,
+		Current: current,
+	}
+	for _, status := range statuses {
+		version.Latest = max(version.Latest, status.Source.Version)
+		if status.State == goose.StateApplied {
+			version.Applied = append(version.Applied, status.Source.Version)
+		} else {
+			version.Pending++
+		}
+	}
+
+	return version, nil
+}
+
+// run applies action to database.
+func (db *database) run(ctx context.Context, action Action, version int64) ([]*goose.MigrationResult, error) {
+	switch action {
+	case ActionUp:
+		return db.provider.Up(ctx)
+	case ActionUpTo:
+		return db.provider.UpTo(ctx, version)
+	case ActionDownTo:
+		return db.provider.DownTo(ctx, version)
+	case ActionDown:
+		res, err := db.provider.Down(ctx)
+		return resultList(res), err
+	case ActionRedo:
+		down, err := db.provider.Down(ctx)
+		if err != nil {
+			return resultList(down), err
+		}
+		up, err := db.provider.UpByOne(ctx)
+		return append(resultList(down), resultList(up)...), err
+	}
+	return nil, fmt.Errorf("unknown migration action %q: %w", action, internal_errors.ErrBadRequest)
+}
+
+// Valid reports whether action is known.
+func (a Action) Valid() bool {
+	switch a {
+	case ActionUp, ActionDown, ActionStatus, ActionRedo, ActionUpTo, ActionDownTo:
+		return true
+	}
+	return false
+}
+
+// CheckDiverged returns ErrShardsDiverged if shards have different applied migrations.
+func CheckDiverged(versions []Version) error {
+	var first *Version
+	for i := range versions {
+		version := &versions[i]
+		if version.Target != migrations.TargetShard {
+			continue
+		}
+		if first == nil {
+			first = version
+			continue
+		}
+		if !slices.Equal(first.Applied, version.Applied) {
+			return fmt.Errorf("%s is at version %d with %d applied, %s is at version %d with %d applied: %w",
+				first.Name, first.Current, len(first.Applied), version.Name, version.Current, len(version.Applied), internal_errors.ErrShardsDiverged)
+		}
+	}
+	return nil
+}
+
+// NewConnect return *sql.DB connection to database
+func NewConnect(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// resultList returns result as list, nil result is skipped.
+func resultList(res *goose.MigrationResult) []*goose.MigrationResult {
+	if res == nil {
+		return nil
+	}
+	return []*goose.MigrationResult{res}
+}
@@ -0,0 +1,70 @@
+package migrator_test
+
+import (
+	"testing"
+
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/migrator"
+	"route256/loms/migrations"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test check of diverged shards
+func TestCheckDiverged_Table(t *testing.T) {
+	tests := []struct {
+		name        string
+		versions    []migrator.Version
+		expectedErr error
+	}{
+		{
+			name: "shards on same version",
+			versions: []migrator.Version{
+				{Name: "main", Target: migrations.TargetMain, Current: 3, Applied: []int64{1, 2, 3}},
+				{Name: "shard-0", Target: migrations.TargetShard, Current: 2, Applied: []int64{1, 2}},
+				{Name: "shard-1", Target: migrations.TargetShard, Current: 2, Applied: []int64{1, 2}},
+			},
+		},
+		{
+			name: "shards on different versions",
+			versions: []migrator.Version{
+				{Name: "shard-0", Target: migrations.TargetShard, Current: 2, Applied: []int64{1, 2}},
+				{Name: "shard-1", Target: migrations.TargetShard, Current: 1, Applied: []int64{1}},
+			},
+			expectedErr: internal_errors.ErrShardsDiverged,
+		},
+		{
+			name: "shards with different applied migrations",
+			versions: []migrator.Version{
+				{Name: "shard-0", Target: migrations.TargetShard, Current: 3, Applied: []int64{1, 3}},
+				{Name: "shard-1", Target: migrations.TargetShard, Current: 3, Applied: []int64{1, 2, 3}},
+			},
+			expectedErr: internal_errors.ErrShardsDiverged,
+		},
+		{
+			name: "single shard",
+			versions: []migrator.Version{
+				{Name: "shard-0", Target: migrations.TargetShard, Current: 1, Applied: []int64{1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := migrator.CheckDiverged(tt.versions)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// Test migrations of one target are not applied to another
+func TestExcludes(t *testing.T) {
+	require.Contains(t, migrations.Excludes(migrations.TargetMain), "20241121090001_drop_shard_buckets_from_shards.sql")
+	require.Contains(t, migrations.Excludes(migrations.TargetShard), "20241121090000_drop_shard_bucket_states_from_main.sql")
+	require.NotContains(t, migrations.Excludes(migrations.TargetMain), "20241121090000_drop_shard_bucket_states_from_main.sql")
+	require.NotContains(t, migrations.Excludes(migrations.TargetShard), "20241120090000_create_shard_buckets_tables.sql")
+}
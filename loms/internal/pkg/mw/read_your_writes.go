@@ -0,0 +1,25 @@
+package mw
+
+import (
+	"context"
+	"strconv"
+
+	"route256/loms/internal/pkg/replica"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// readYourWritesMetadata is metadata key of requests which reads must see writes of previous requests.
+const readYourWritesMetadata = "x-read-your-writes"
+
+// ReadYourWrites create mw serving reads of request by primary if request has read-your-writes metadata.
+func ReadYourWrites(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	values := metadata.ValueFromIncomingContext(ctx, readYourWritesMetadata)
+	if len(values) > 0 {
+		if enabled, err := strconv.ParseBool(values[0]); err == nil && enabled {
+			ctx = replica.WithPrimary(ctx)
+		}
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,159 @@
+package replica
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"route256/loms/internal/pkg/metrics"
+	"route256/utils/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Targets of read in metrics.
+const (
+	targetPrimary = "primary"
+	targetReplica = "replica"
+)
+
+// lagQuery returns replication lag of replica in seconds, caught up replica has no lag
+// even if primary had no writes for a long time.
+const lagQuery = `
+SELECT CASE
+    WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+    ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END::FLOAT8`
+
+// errNoLag is returned for replica which lag was not measured yet.
+var errNoLag = errors.New("replica lag is unknown")
+
+type primaryKey struct{}
+
+// WithPrimary returns context which reads are served by primary, used by requests
+// which must see their own writes.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// IsPrimary reports whether reads of context must be served by primary.
+func IsPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// Pool routes reads to replica of primary. Reads go to primary when replica is not set,
+// lags more than maxLag or fails.
+type Pool struct {
+	name    string
+	primary *pgxpool.Pool
+	replica *pgxpool.Pool
+	maxLag  time.Duration
+
+	// lag of replica in nanoseconds, negative until measured or after failed measure
+	lag atomic.Int64
+}
+
+// NewPool creates a new Pool instance, replica can be nil.
+func NewPool(name string, primary, replica *pgxpool.Pool, maxLag time.Duration) *Pool {
+	p := &Pool{
+		name:    name,
+		primary: primary,
+		replica: replica,
+		maxLag:  maxLag,
+	}
+	p.lag.Store(-1)
+	return p
+}
+
+// Primary returns primary pool.
+func (p *Pool) Primary() *pgxpool.Pool {
+	return p.primary
+}
+
+// Replica returns replica pool, nil if replica is not set.
+func (p *Pool) Replica() *pgxpool.Pool {
+	return p.replica
+}
+
+// Read runs read-only fn on replica, fn is run again on primary if replica can not be used or fn fails.
+// Not found on replica is retried too, so rows written after last replay are found.
+func (p *Pool) Read(ctx context.Context, fn func(pool *pgxpool.Pool) error) error {
+	if !p.useReplica(ctx) {
+		metrics.IncDBReadCounter(p.name, targetPrimary)
+		return fn(p.primary)
+	}
+
+	metrics.IncDBReadCounter(p.name, targetReplica)
+	err := fn(p.replica)
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+
+	metrics.IncDBReadFallbackCounter(p.name)
+	metrics.IncDBReadCounter(p.name, targetPrimary)
+	return fn(p.primary)
+}
+
+// MonitorLag measures lag of replica every interval until ctx is done.
+func (p *Pool) MonitorLag(ctx context.Context, interval time.Duration) {
+	if p.replica == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.measureLag(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Close closes replica pool, primary is closed by its owner.
+func (p *Pool) Close() {
+	if p.replica != nil {
+		p.replica.Close()
+	}
+}
+
+// Lag returns last measured lag of replica.
+func (p *Pool) Lag() (time.Duration, error) {
+	lag := p.lag.Load()
+	if lag < 0 {
+		return 0, errNoLag
+	}
+	return time.Duration(lag), nil
+}
+
+// useReplica reports whether read of context can be served by replica.
+func (p *Pool) useReplica(ctx context.Context) bool {
+	if p.replica == nil || IsPrimary(ctx) {
+		return false
+	}
+	lag, err := p.Lag()
+	return err == nil && lag <= p.maxLag
+}
+
+// measureLag stores lag of replica, replica is not used until next measure if lag can not be read.
+func (p *Pool) measureLag(ctx context.Context) {
+	var seconds float64
+	if err := p.replica.QueryRow(ctx, lagQuery).Scan(&seconds); err != nil {
+		if ctx.Err() == nil {
+			logger.Errorw(ctx, "Error measuring replica lag", "pool", p.name, "error", err)
+		}
+		p.lag.Store(-1)
+		metrics.SetDBReplicaLag(p.name, -1)
+		return
+	}
+
+	lag := time.Duration(seconds * float64(time.Second))
+	p.lag.Store(int64(lag))
+	metrics.SetDBReplicaLag(p.name, lag.Seconds())
+}
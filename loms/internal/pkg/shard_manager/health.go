@@ -0,0 +1,170 @@
+package shard_manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/metrics"
+	"route256/utils/logger"
+)
+
+// Circuit of shard is opened after failureThreshold failures in a row, shard gets one
+// trial request or probe after openTimeout.
+const (
+	failureThreshold = 3
+	openTimeout      = 10 * time.Second
+)
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitHalfOpen
+	CircuitOpen
+)
+
+// circuitBreaker stops requests to shard which keeps failing.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	now      func() time.Time
+}
+
+// newCircuitBreaker creates closed circuitBreaker.
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{now: time.Now}
+}
+
+// allow reports whether request can be sent, open circuit lets one trial request through after openTimeout.
+func (cb *circuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < openTimeout {
+			return false
+		}
+		cb.state = CircuitHalfOpen
+		return true
+	case CircuitHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// success closes circuit.
+func (cb *circuitBreaker) success() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = CircuitClosed
+	cb.failures = 0
+	return cb.state
+}
+
+// failure opens circuit after failureThreshold failures in a row or failed trial request.
+func (cb *circuitBreaker) failure() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= failureThreshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+	return cb.state
+}
+
+// current returns state of circuit.
+func (cb *circuitBreaker) current() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// Available reports whether requests can be sent to shard, returns false while circuit of shard is open.
+func (sm *ShardManager) Available(index ShardIndex) bool {
+	if index < 0 || int(index) >= len(sm.breakers) {
+		return false
+	}
+	return sm.breakers[index].allow()
+}
+
+// CheckAvailable returns ErrShardUnavailable if circuit of shard is open.
+func (sm *ShardManager) CheckAvailable(index ShardIndex) error {
+	if !sm.Available(index) {
+		return fmt.Errorf("shard %d: %w", index, internal_errors.ErrShardUnavailable)
+	}
+	return nil
+}
+
+// ReportResult records result of request to shard, failures open circuit of shard.
+func (sm *ShardManager) ReportResult(index ShardIndex, err error) {
+	if index < 0 || int(index) >= len(sm.breakers) {
+		return
+	}
+
+	var state CircuitState
+	if err == nil {
+		state = sm.breakers[index].success()
+	} else {
+		state = sm.breakers[index].failure()
+	}
+	metrics.SetShardCircuitState(shardName(index), int(state))
+}
+
+// CircuitState returns state of circuit of shard.
+func (sm *ShardManager) CircuitState(index ShardIndex) CircuitState {
+	return sm.breakers[index].current()
+}
+
+// RunHealthChecks pings every shard each interval until ctx is done, results of pings
+// open and close circuits of shards.
+func (sm *ShardManager) RunHealthChecks(ctx context.Context, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sm.checkHealth(ctx, timeout)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth pings all shards in parallel.
+func (sm *ShardManager) checkHealth(ctx context.Context, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for i, pool := range sm.shards {
+		wg.Add(1)
+		go func(index ShardIndex) {
+			defer wg.Done()
+
+			pingCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			err := pool.Ping(pingCtx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logger.Errorw(ctx, "Shard health check failed", "shard", index, "error", err)
+				metrics.SetShardUp(shardName(index), false)
+			} else {
+				metrics.SetShardUp(shardName(index), true)
+			}
+			sm.ReportResult(index, err)
+		}(ShardIndex(i))
+	}
+	wg.Wait()
+}
@@ -0,0 +1,480 @@
+// Code generated by http://github.com/gojuno/minimock (v3.4.0). DO NOT EDIT.
+
+package mock
+
+//go:generate minimock -i route256/loms/internal/pkg/shard_manager.IBucketMover -o bucket_mover_mock.go -n IBucketMoverMock -p mock
+
+import (
+	"context"
+	mm_shard_manager "route256/loms/internal/pkg/shard_manager"
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/gojuno/minimock/v3"
+)
+
+// IBucketMoverMock implements mm_shard_manager.IBucketMover
+type IBucketMoverMock struct {
+	t          minimock.Tester
+	finishOnce sync.Once
+
+	funcMoveBucket          func(ctx context.Context, bucket mm_shard_manager.Bucket, source mm_shard_manager.ShardIndex, target mm_shard_manager.ShardIndex) (i1 int64, err error)
+	funcMoveBucketOrigin    string
+	inspectFuncMoveBucket   func(ctx context.Context, bucket mm_shard_manager.Bucket, source mm_shard_manager.ShardIndex, target mm_shard_manager.ShardIndex)
+	afterMoveBucketCounter  uint64
+	beforeMoveBucketCounter uint64
+	MoveBucketMock          mIBucketMoverMockMoveBucket
+}
+
+// NewIBucketMoverMock returns a mock for mm_shard_manager.IBucketMover
+func NewIBucketMoverMock(t minimock.Tester) *IBucketMoverMock {
+	m := &IBucketMoverMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.MoveBucketMock = mIBucketMoverMockMoveBucket{mock: m}
+	m.MoveBucketMock.callArgs = []*IBucketMoverMockMoveBucketParams{}
+
+	t.Cleanup(m.MinimockFinish)
+
+	return m
+}
+
+type mIBucketMoverMockMoveBucket struct {
+	optional           bool
+	mock               *IBucketMoverMock
+	defaultExpectation *IBucketMoverMockMoveBucketExpectation
+	expectations       []*IBucketMoverMockMoveBucketExpectation
+
+	callArgs []*IBucketMoverMockMoveBucketParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IBucketMoverMockMoveBucketExpectation specifies expectation struct of the IBucketMover.MoveBucket
+type IBucketMoverMockMoveBucketExpectation struct {
+	mock               *IBucketMoverMock
+	params             *IBucketMoverMockMoveBucketParams
+	paramPtrs          *IBucketMoverMockMoveBucketParamPtrs
+	expectationOrigins IBucketMoverMockMoveBucketExpectationOrigins
+	results            *IBucketMoverMockMoveBucketResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IBucketMoverMockMoveBucketParams contains parameters of the IBucketMover.MoveBucket
+type IBucketMoverMockMoveBucketParams struct {
+	ctx    context.Context
+	bucket mm_shard_manager.Bucket
+	source mm_shard_manager.ShardIndex
+	target mm_shard_manager.ShardIndex
+}
+
+// IBucketMoverMockMoveBucketParamPtrs contains pointers to parameters of the IBucketMover.MoveBucket
+type IBucketMoverMockMoveBucketParamPtrs struct {
+	ctx    *context.Context
+	bucket *mm_shard_manager.Bucket
+	source *mm_shard_manager.ShardIndex
+	target *mm_shard_manager.ShardIndex
+}
+
+// IBucketMoverMockMoveBucketResults contains results of the IBucketMover.MoveBucket
+type IBucketMoverMockMoveBucketResults struct {
+	i1  int64
+	err error
+}
+
+// IBucketMoverMockMoveBucketOrigins contains origins of expectations of the IBucketMover.MoveBucket
+type IBucketMoverMockMoveBucketExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originBucket string
+	originSource string
+	originTarget string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) Optional() *mIBucketMoverMockMoveBucket {
+	mmMoveBucket.optional = true
+	return mmMoveBucket
+}
+
+// Expect sets up expected params for IBucketMover.MoveBucket
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) Expect(ctx context.Context, bucket mm_shard_manager.Bucket, source mm_shard_manager.ShardIndex, target mm_shard_manager.ShardIndex) *mIBucketMoverMockMoveBucket {
+	if mmMoveBucket.mock.funcMoveBucket != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Set")
+	}
+
+	if mmMoveBucket.defaultExpectation == nil {
+		mmMoveBucket.defaultExpectation = &IBucketMoverMockMoveBucketExpectation{}
+	}
+
+	if mmMoveBucket.defaultExpectation.paramPtrs != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by ExpectParams functions")
+	}
+
+	mmMoveBucket.defaultExpectation.params = &IBucketMoverMockMoveBucketParams{ctx, bucket, source, target}
+	mmMoveBucket.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmMoveBucket.expectations {
+		if minimock.Equal(e.params, mmMoveBucket.defaultExpectation.params) {
+			mmMoveBucket.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmMoveBucket.defaultExpectation.params)
+		}
+	}
+
+	return mmMoveBucket
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IBucketMover.MoveBucket
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) ExpectCtxParam1(ctx context.Context) *mIBucketMoverMockMoveBucket {
+	if mmMoveBucket.mock.funcMoveBucket != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Set")
+	}
+
+	if mmMoveBucket.defaultExpectation == nil {
+		mmMoveBucket.defaultExpectation = &IBucketMoverMockMoveBucketExpectation{}
+	}
+
+	if mmMoveBucket.defaultExpectation.params != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Expect")
+	}
+
+	if mmMoveBucket.defaultExpectation.paramPtrs == nil {
+		mmMoveBucket.defaultExpectation.paramPtrs = &IBucketMoverMockMoveBucketParamPtrs{}
+	}
+	mmMoveBucket.defaultExpectation.paramPtrs.ctx = &ctx
+	mmMoveBucket.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmMoveBucket
+}
+
+// ExpectBucketParam2 sets up expected param bucket for IBucketMover.MoveBucket
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) ExpectBucketParam2(bucket mm_shard_manager.Bucket) *mIBucketMoverMockMoveBucket {
+	if mmMoveBucket.mock.funcMoveBucket != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Set")
+	}
+
+	if mmMoveBucket.defaultExpectation == nil {
+		mmMoveBucket.defaultExpectation = &IBucketMoverMockMoveBucketExpectation{}
+	}
+
+	if mmMoveBucket.defaultExpectation.params != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Expect")
+	}
+
+	if mmMoveBucket.defaultExpectation.paramPtrs == nil {
+		mmMoveBucket.defaultExpectation.paramPtrs = &IBucketMoverMockMoveBucketParamPtrs{}
+	}
+	mmMoveBucket.defaultExpectation.paramPtrs.bucket = &bucket
+	mmMoveBucket.defaultExpectation.expectationOrigins.originBucket = minimock.CallerInfo(1)
+
+	return mmMoveBucket
+}
+
+// ExpectSourceParam3 sets up expected param source for IBucketMover.MoveBucket
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) ExpectSourceParam3(source mm_shard_manager.ShardIndex) *mIBucketMoverMockMoveBucket {
+	if mmMoveBucket.mock.funcMoveBucket != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Set")
+	}
+
+	if mmMoveBucket.defaultExpectation == nil {
+		mmMoveBucket.defaultExpectation = &IBucketMoverMockMoveBucketExpectation{}
+	}
+
+	if mmMoveBucket.defaultExpectation.params != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Expect")
+	}
+
+	if mmMoveBucket.defaultExpectation.paramPtrs == nil {
+		mmMoveBucket.defaultExpectation.paramPtrs = &IBucketMoverMockMoveBucketParamPtrs{}
+	}
+	mmMoveBucket.defaultExpectation.paramPtrs.source = &source
+	mmMoveBucket.defaultExpectation.expectationOrigins.originSource = minimock.CallerInfo(1)
+
+	return mmMoveBucket
+}
+
+// ExpectTargetParam4 sets up expected param target for IBucketMover.MoveBucket
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) ExpectTargetParam4(target mm_shard_manager.ShardIndex) *mIBucketMoverMockMoveBucket {
+	if mmMoveBucket.mock.funcMoveBucket != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Set")
+	}
+
+	if mmMoveBucket.defaultExpectation == nil {
+		mmMoveBucket.defaultExpectation = &IBucketMoverMockMoveBucketExpectation{}
+	}
+
+	if mmMoveBucket.defaultExpectation.params != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Expect")
+	}
+
+	if mmMoveBucket.defaultExpectation.paramPtrs == nil {
+		mmMoveBucket.defaultExpectation.paramPtrs = &IBucketMoverMockMoveBucketParamPtrs{}
+	}
+	mmMoveBucket.defaultExpectation.paramPtrs.target = &target
+	mmMoveBucket.defaultExpectation.expectationOrigins.originTarget = minimock.CallerInfo(1)
+
+	return mmMoveBucket
+}
+
+// Inspect accepts an inspector function that has same arguments as the IBucketMover.MoveBucket
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) Inspect(f func(ctx context.Context, bucket mm_shard_manager.Bucket, source mm_shard_manager.ShardIndex, target mm_shard_manager.ShardIndex)) *mIBucketMoverMockMoveBucket {
+	if mmMoveBucket.mock.inspectFuncMoveBucket != nil {
+		mmMoveBucket.mock.t.Fatalf("Inspect function is already set for IBucketMoverMock.MoveBucket")
+	}
+
+	mmMoveBucket.mock.inspectFuncMoveBucket = f
+
+	return mmMoveBucket
+}
+
+// Return sets up results that will be returned by IBucketMover.MoveBucket
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) Return(i1 int64, err error) *IBucketMoverMock {
+	if mmMoveBucket.mock.funcMoveBucket != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Set")
+	}
+
+	if mmMoveBucket.defaultExpectation == nil {
+		mmMoveBucket.defaultExpectation = &IBucketMoverMockMoveBucketExpectation{mock: mmMoveBucket.mock}
+	}
+	mmMoveBucket.defaultExpectation.results = &IBucketMoverMockMoveBucketResults{i1, err}
+	mmMoveBucket.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmMoveBucket.mock
+}
+
+// Set uses given function f to mock the IBucketMover.MoveBucket method
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) Set(f func(ctx context.Context, bucket mm_shard_manager.Bucket, source mm_shard_manager.ShardIndex, target mm_shard_manager.ShardIndex) (i1 int64, err error)) *IBucketMoverMock {
+	if mmMoveBucket.defaultExpectation != nil {
+		mmMoveBucket.mock.t.Fatalf("Default expectation is already set for the IBucketMover.MoveBucket method")
+	}
+
+	if len(mmMoveBucket.expectations) > 0 {
+		mmMoveBucket.mock.t.Fatalf("Some expectations are already set for the IBucketMover.MoveBucket method")
+	}
+
+	mmMoveBucket.mock.funcMoveBucket = f
+	mmMoveBucket.mock.funcMoveBucketOrigin = minimock.CallerInfo(1)
+	return mmMoveBucket.mock
+}
+
+// When sets expectation for the IBucketMover.MoveBucket which will trigger the result defined by the following
+// Then helper
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) When(ctx context.Context, bucket mm_shard_manager.Bucket, source mm_shard_manager.ShardIndex, target mm_shard_manager.ShardIndex) *IBucketMoverMockMoveBucketExpectation {
+	if mmMoveBucket.mock.funcMoveBucket != nil {
+		mmMoveBucket.mock.t.Fatalf("IBucketMoverMock.MoveBucket mock is already set by Set")
+	}
+
+	expectation := &IBucketMoverMockMoveBucketExpectation{
+		mock:               mmMoveBucket.mock,
+		params:             &IBucketMoverMockMoveBucketParams{ctx, bucket, source, target},
+		expectationOrigins: IBucketMoverMockMoveBucketExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmMoveBucket.expectations = append(mmMoveBucket.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IBucketMover.MoveBucket return parameters for the expectation previously defined by the When method
+func (e *IBucketMoverMockMoveBucketExpectation) Then(i1 int64, err error) *IBucketMoverMock {
+	e.results = &IBucketMoverMockMoveBucketResults{i1, err}
+	return e.mock
+}
+
+// Times sets number of times IBucketMover.MoveBucket should be invoked
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) Times(n uint64) *mIBucketMoverMockMoveBucket {
+	if n == 0 {
+		mmMoveBucket.mock.t.Fatalf("Times of IBucketMoverMock.MoveBucket mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmMoveBucket.expectedInvocations, n)
+	mmMoveBucket.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmMoveBucket
+}
+
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) invocationsDone() bool {
+	if len(mmMoveBucket.expectations) == 0 && mmMoveBucket.defaultExpectation == nil && mmMoveBucket.mock.funcMoveBucket == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmMoveBucket.mock.afterMoveBucketCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmMoveBucket.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// MoveBucket implements mm_shard_manager.IBucketMover
+func (mmMoveBucket *IBucketMoverMock) MoveBucket(ctx context.Context, bucket mm_shard_manager.Bucket, source mm_shard_manager.ShardIndex, target mm_shard_manager.ShardIndex) (i1 int64, err error) {
+	mm_atomic.AddUint64(&mmMoveBucket.beforeMoveBucketCounter, 1)
+	defer mm_atomic.AddUint64(&mmMoveBucket.afterMoveBucketCounter, 1)
+
+	mmMoveBucket.t.Helper()
+
+	if mmMoveBucket.inspectFuncMoveBucket != nil {
+		mmMoveBucket.inspectFuncMoveBucket(ctx, bucket, source, target)
+	}
+
+	mm_params := IBucketMoverMockMoveBucketParams{ctx, bucket, source, target}
+
+	// Record call args
+	mmMoveBucket.MoveBucketMock.mutex.Lock()
+	mmMoveBucket.MoveBucketMock.callArgs = append(mmMoveBucket.MoveBucketMock.callArgs, &mm_params)
+	mmMoveBucket.MoveBucketMock.mutex.Unlock()
+
+	for _, e := range mmMoveBucket.MoveBucketMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.i1, e.results.err
+		}
+	}
+
+	if mmMoveBucket.MoveBucketMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmMoveBucket.MoveBucketMock.defaultExpectation.Counter, 1)
+		mm_want := mmMoveBucket.MoveBucketMock.defaultExpectation.params
+		mm_want_ptrs := mmMoveBucket.MoveBucketMock.defaultExpectation.paramPtrs
+
+		mm_got := IBucketMoverMockMoveBucketParams{ctx, bucket, source, target}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmMoveBucket.t.Errorf("IBucketMoverMock.MoveBucket got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMoveBucket.MoveBucketMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.bucket != nil && !minimock.Equal(*mm_want_ptrs.bucket, mm_got.bucket) {
+				mmMoveBucket.t.Errorf("IBucketMoverMock.MoveBucket got unexpected parameter bucket, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMoveBucket.MoveBucketMock.defaultExpectation.expectationOrigins.originBucket, *mm_want_ptrs.bucket, mm_got.bucket, minimock.Diff(*mm_want_ptrs.bucket, mm_got.bucket))
+			}
+
+			if mm_want_ptrs.source != nil && !minimock.Equal(*mm_want_ptrs.source, mm_got.source) {
+				mmMoveBucket.t.Errorf("IBucketMoverMock.MoveBucket got unexpected parameter source, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMoveBucket.MoveBucketMock.defaultExpectation.expectationOrigins.originSource, *mm_want_ptrs.source, mm_got.source, minimock.Diff(*mm_want_ptrs.source, mm_got.source))
+			}
+
+			if mm_want_ptrs.target != nil && !minimock.Equal(*mm_want_ptrs.target, mm_got.target) {
+				mmMoveBucket.t.Errorf("IBucketMoverMock.MoveBucket got unexpected parameter target, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMoveBucket.MoveBucketMock.defaultExpectation.expectationOrigins.originTarget, *mm_want_ptrs.target, mm_got.target, minimock.Diff(*mm_want_ptrs.target, mm_got.target))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmMoveBucket.t.Errorf("IBucketMoverMock.MoveBucket got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmMoveBucket.MoveBucketMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmMoveBucket.MoveBucketMock.defaultExpectation.results
+		if mm_results == nil {
+			mmMoveBucket.t.Fatal("No results are set for the IBucketMoverMock.MoveBucket")
+		}
+		return (*mm_results).i1, (*mm_results).err
+	}
+	if mmMoveBucket.funcMoveBucket != nil {
+		return mmMoveBucket.funcMoveBucket(ctx, bucket, source, target)
+	}
+	mmMoveBucket.t.Fatalf("Unexpected call to IBucketMoverMock.MoveBucket. %v %v %v %v", ctx, bucket, source, target)
+	return
+}
+
+// MoveBucketAfterCounter returns a count of finished IBucketMoverMock.MoveBucket invocations
+func (mmMoveBucket *IBucketMoverMock) MoveBucketAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmMoveBucket.afterMoveBucketCounter)
+}
+
+// MoveBucketBeforeCounter returns a count of IBucketMoverMock.MoveBucket invocations
+func (mmMoveBucket *IBucketMoverMock) MoveBucketBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmMoveBucket.beforeMoveBucketCounter)
+}
+
+// Calls returns a list of arguments used in each call to IBucketMoverMock.MoveBucket.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmMoveBucket *mIBucketMoverMockMoveBucket) Calls() []*IBucketMoverMockMoveBucketParams {
+	mmMoveBucket.mutex.RLock()
+
+	argCopy := make([]*IBucketMoverMockMoveBucketParams, len(mmMoveBucket.callArgs))
+	copy(argCopy, mmMoveBucket.callArgs)
+
+	mmMoveBucket.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockMoveBucketDone returns true if the count of the MoveBucket invocations corresponds
+// the number of defined expectations
+func (m *IBucketMoverMock) MinimockMoveBucketDone() bool {
+	if m.MoveBucketMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.MoveBucketMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.MoveBucketMock.invocationsDone()
+}
+
+// MinimockMoveBucketInspect logs each unmet expectation
+func (m *IBucketMoverMock) MinimockMoveBucketInspect() {
+	for _, e := range m.MoveBucketMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IBucketMoverMock.MoveBucket at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterMoveBucketCounter := mm_atomic.LoadUint64(&m.afterMoveBucketCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.MoveBucketMock.defaultExpectation != nil && afterMoveBucketCounter < 1 {
+		if m.MoveBucketMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IBucketMoverMock.MoveBucket at\n%s", m.MoveBucketMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IBucketMoverMock.MoveBucket at\n%s with params: %#v", m.MoveBucketMock.defaultExpectation.expectationOrigins.origin, *m.MoveBucketMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcMoveBucket != nil && afterMoveBucketCounter < 1 {
+		m.t.Errorf("Expected call to IBucketMoverMock.MoveBucket at\n%s", m.funcMoveBucketOrigin)
+	}
+
+	if !m.MoveBucketMock.invocationsDone() && afterMoveBucketCounter > 0 {
+		m.t.Errorf("Expected %d calls to IBucketMoverMock.MoveBucket at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.MoveBucketMock.expectedInvocations), m.MoveBucketMock.expectedInvocationsOrigin, afterMoveBucketCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *IBucketMoverMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockMoveBucketInspect()
+		}
+	})
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *IBucketMoverMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *IBucketMoverMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockMoveBucketDone()
+}
@@ -0,0 +1,448 @@
+// Code generated by http://github.com/gojuno/minimock (v3.4.0). DO NOT EDIT.
+
+package mock
+
+//go:generate minimock -i route256/loms/internal/pkg/shard_manager.IBucketStore -o bucket_store_mock.go -n IBucketStoreMock -p mock
+
+import (
+	"context"
+	mm_shard_manager "route256/loms/internal/pkg/shard_manager"
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/gojuno/minimock/v3"
+)
+
+// IBucketStoreMock implements mm_shard_manager.IBucketStore
+type IBucketStoreMock struct {
+	t          minimock.Tester
+	finishOnce sync.Once
+
+	funcSetShard          func(ctx context.Context, bucket mm_shard_manager.Bucket, shard mm_shard_manager.ShardIndex) (err error)
+	funcSetShardOrigin    string
+	inspectFuncSetShard   func(ctx context.Context, bucket mm_shard_manager.Bucket, shard mm_shard_manager.ShardIndex)
+	afterSetShardCounter  uint64
+	beforeSetShardCounter uint64
+	SetShardMock          mIBucketStoreMockSetShard
+}
+
+// NewIBucketStoreMock returns a mock for mm_shard_manager.IBucketStore
+func NewIBucketStoreMock(t minimock.Tester) *IBucketStoreMock {
+	m := &IBucketStoreMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.SetShardMock = mIBucketStoreMockSetShard{mock: m}
+	m.SetShardMock.callArgs = []*IBucketStoreMockSetShardParams{}
+
+	t.Cleanup(m.MinimockFinish)
+
+	return m
+}
+
+type mIBucketStoreMockSetShard struct {
+	optional           bool
+	mock               *IBucketStoreMock
+	defaultExpectation *IBucketStoreMockSetShardExpectation
+	expectations       []*IBucketStoreMockSetShardExpectation
+
+	callArgs []*IBucketStoreMockSetShardParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IBucketStoreMockSetShardExpectation specifies expectation struct of the IBucketStore.SetShard
+type IBucketStoreMockSetShardExpectation struct {
+	mock               *IBucketStoreMock
+	params             *IBucketStoreMockSetShardParams
+	paramPtrs          *IBucketStoreMockSetShardParamPtrs
+	expectationOrigins IBucketStoreMockSetShardExpectationOrigins
+	results            *IBucketStoreMockSetShardResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IBucketStoreMockSetShardParams contains parameters of the IBucketStore.SetShard
+type IBucketStoreMockSetShardParams struct {
+	ctx    context.Context
+	bucket mm_shard_manager.Bucket
+	shard  mm_shard_manager.ShardIndex
+}
+
+// IBucketStoreMockSetShardParamPtrs contains pointers to parameters of the IBucketStore.SetShard
+type IBucketStoreMockSetShardParamPtrs struct {
+	ctx    *context.Context
+	bucket *mm_shard_manager.Bucket
+	shard  *mm_shard_manager.ShardIndex
+}
+
+// IBucketStoreMockSetShardResults contains results of the IBucketStore.SetShard
+type IBucketStoreMockSetShardResults struct {
+	err error
+}
+
+// IBucketStoreMockSetShardOrigins contains origins of expectations of the IBucketStore.SetShard
+type IBucketStoreMockSetShardExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originBucket string
+	originShard  string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSetShard *mIBucketStoreMockSetShard) Optional() *mIBucketStoreMockSetShard {
+	mmSetShard.optional = true
+	return mmSetShard
+}
+
+// Expect sets up expected params for IBucketStore.SetShard
+func (mmSetShard *mIBucketStoreMockSetShard) Expect(ctx context.Context, bucket mm_shard_manager.Bucket, shard mm_shard_manager.ShardIndex) *mIBucketStoreMockSetShard {
+	if mmSetShard.mock.funcSetShard != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Set")
+	}
+
+	if mmSetShard.defaultExpectation == nil {
+		mmSetShard.defaultExpectation = &IBucketStoreMockSetShardExpectation{}
+	}
+
+	if mmSetShard.defaultExpectation.paramPtrs != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by ExpectParams functions")
+	}
+
+	mmSetShard.defaultExpectation.params = &IBucketStoreMockSetShardParams{ctx, bucket, shard}
+	mmSetShard.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmSetShard.expectations {
+		if minimock.Equal(e.params, mmSetShard.defaultExpectation.params) {
+			mmSetShard.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetShard.defaultExpectation.params)
+		}
+	}
+
+	return mmSetShard
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IBucketStore.SetShard
+func (mmSetShard *mIBucketStoreMockSetShard) ExpectCtxParam1(ctx context.Context) *mIBucketStoreMockSetShard {
+	if mmSetShard.mock.funcSetShard != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Set")
+	}
+
+	if mmSetShard.defaultExpectation == nil {
+		mmSetShard.defaultExpectation = &IBucketStoreMockSetShardExpectation{}
+	}
+
+	if mmSetShard.defaultExpectation.params != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Expect")
+	}
+
+	if mmSetShard.defaultExpectation.paramPtrs == nil {
+		mmSetShard.defaultExpectation.paramPtrs = &IBucketStoreMockSetShardParamPtrs{}
+	}
+	mmSetShard.defaultExpectation.paramPtrs.ctx = &ctx
+	mmSetShard.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmSetShard
+}
+
+// ExpectBucketParam2 sets up expected param bucket for IBucketStore.SetShard
+func (mmSetShard *mIBucketStoreMockSetShard) ExpectBucketParam2(bucket mm_shard_manager.Bucket) *mIBucketStoreMockSetShard {
+	if mmSetShard.mock.funcSetShard != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Set")
+	}
+
+	if mmSetShard.defaultExpectation == nil {
+		mmSetShard.defaultExpectation = &IBucketStoreMockSetShardExpectation{}
+	}
+
+	if mmSetShard.defaultExpectation.params != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Expect")
+	}
+
+	if mmSetShard.defaultExpectation.paramPtrs == nil {
+		mmSetShard.defaultExpectation.paramPtrs = &IBucketStoreMockSetShardParamPtrs{}
+	}
+	mmSetShard.defaultExpectation.paramPtrs.bucket = &bucket
+	mmSetShard.defaultExpectation.expectationOrigins.originBucket = minimock.CallerInfo(1)
+
+	return mmSetShard
+}
+
+// ExpectShardParam3 sets up expected param shard for IBucketStore.SetShard
+func (mmSetShard *mIBucketStoreMockSetShard) ExpectShardParam3(shard mm_shard_manager.ShardIndex) *mIBucketStoreMockSetShard {
+	if mmSetShard.mock.funcSetShard != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Set")
+	}
+
+	if mmSetShard.defaultExpectation == nil {
+		mmSetShard.defaultExpectation = &IBucketStoreMockSetShardExpectation{}
+	}
+
+	if mmSetShard.defaultExpectation.params != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Expect")
+	}
+
+	if mmSetShard.defaultExpectation.paramPtrs == nil {
+		mmSetShard.defaultExpectation.paramPtrs = &IBucketStoreMockSetShardParamPtrs{}
+	}
+	mmSetShard.defaultExpectation.paramPtrs.shard = &shard
+	mmSetShard.defaultExpectation.expectationOrigins.originShard = minimock.CallerInfo(1)
+
+	return mmSetShard
+}
+
+// Inspect accepts an inspector function that has same arguments as the IBucketStore.SetShard
+func (mmSetShard *mIBucketStoreMockSetShard) Inspect(f func(ctx context.Context, bucket mm_shard_manager.Bucket, shard mm_shard_manager.ShardIndex)) *mIBucketStoreMockSetShard {
+	if mmSetShard.mock.inspectFuncSetShard != nil {
+		mmSetShard.mock.t.Fatalf("Inspect function is already set for IBucketStoreMock.SetShard")
+	}
+
+	mmSetShard.mock.inspectFuncSetShard = f
+
+	return mmSetShard
+}
+
+// Return sets up results that will be returned by IBucketStore.SetShard
+func (mmSetShard *mIBucketStoreMockSetShard) Return(err error) *IBucketStoreMock {
+	if mmSetShard.mock.funcSetShard != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Set")
+	}
+
+	if mmSetShard.defaultExpectation == nil {
+		mmSetShard.defaultExpectation = &IBucketStoreMockSetShardExpectation{mock: mmSetShard.mock}
+	}
+	mmSetShard.defaultExpectation.results = &IBucketStoreMockSetShardResults{err}
+	mmSetShard.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmSetShard.mock
+}
+
+// Set uses given function f to mock the IBucketStore.SetShard method
+func (mmSetShard *mIBucketStoreMockSetShard) Set(f func(ctx context.Context, bucket mm_shard_manager.Bucket, shard mm_shard_manager.ShardIndex) (err error)) *IBucketStoreMock {
+	if mmSetShard.defaultExpectation != nil {
+		mmSetShard.mock.t.Fatalf("Default expectation is already set for the IBucketStore.SetShard method")
+	}
+
+	if len(mmSetShard.expectations) > 0 {
+		mmSetShard.mock.t.Fatalf("Some expectations are already set for the IBucketStore.SetShard method")
+	}
+
+	mmSetShard.mock.funcSetShard = f
+	mmSetShard.mock.funcSetShardOrigin = minimock.CallerInfo(1)
+	return mmSetShard.mock
+}
+
+// When sets expectation for the IBucketStore.SetShard which will trigger the result defined by the following
+// Then helper
+func (mmSetShard *mIBucketStoreMockSetShard) When(ctx context.Context, bucket mm_shard_manager.Bucket, shard mm_shard_manager.ShardIndex) *IBucketStoreMockSetShardExpectation {
+	if mmSetShard.mock.funcSetShard != nil {
+		mmSetShard.mock.t.Fatalf("IBucketStoreMock.SetShard mock is already set by Set")
+	}
+
+	expectation := &IBucketStoreMockSetShardExpectation{
+		mock:               mmSetShard.mock,
+		params:             &IBucketStoreMockSetShardParams{ctx, bucket, shard},
+		expectationOrigins: IBucketStoreMockSetShardExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmSetShard.expectations = append(mmSetShard.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IBucketStore.SetShard return parameters for the expectation previously defined by the When method
+func (e *IBucketStoreMockSetShardExpectation) Then(err error) *IBucketStoreMock {
+	e.results = &IBucketStoreMockSetShardResults{err}
+	return e.mock
+}
+
+// Times sets number of times IBucketStore.SetShard should be invoked
+func (mmSetShard *mIBucketStoreMockSetShard) Times(n uint64) *mIBucketStoreMockSetShard {
+	if n == 0 {
+		mmSetShard.mock.t.Fatalf("Times of IBucketStoreMock.SetShard mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSetShard.expectedInvocations, n)
+	mmSetShard.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmSetShard
+}
+
+func (mmSetShard *mIBucketStoreMockSetShard) invocationsDone() bool {
+	if len(mmSetShard.expectations) == 0 && mmSetShard.defaultExpectation == nil && mmSetShard.mock.funcSetShard == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSetShard.mock.afterSetShardCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSetShard.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SetShard implements mm_shard_manager.IBucketStore
+func (mmSetShard *IBucketStoreMock) SetShard(ctx context.Context, bucket mm_shard_manager.Bucket, shard mm_shard_manager.ShardIndex) (err error) {
+	mm_atomic.AddUint64(&mmSetShard.beforeSetShardCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetShard.afterSetShardCounter, 1)
+
+	mmSetShard.t.Helper()
+
+	if mmSetShard.inspectFuncSetShard != nil {
+		mmSetShard.inspectFuncSetShard(ctx, bucket, shard)
+	}
+
+	mm_params := IBucketStoreMockSetShardParams{ctx, bucket, shard}
+
+	// Record call args
+	mmSetShard.SetShardMock.mutex.Lock()
+	mmSetShard.SetShardMock.callArgs = append(mmSetShard.SetShardMock.callArgs, &mm_params)
+	mmSetShard.SetShardMock.mutex.Unlock()
+
+	for _, e := range mmSetShard.SetShardMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmSetShard.SetShardMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetShard.SetShardMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetShard.SetShardMock.defaultExpectation.params
+		mm_want_ptrs := mmSetShard.SetShardMock.defaultExpectation.paramPtrs
+
+		mm_got := IBucketStoreMockSetShardParams{ctx, bucket, shard}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmSetShard.t.Errorf("IBucketStoreMock.SetShard got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetShard.SetShardMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.bucket != nil && !minimock.Equal(*mm_want_ptrs.bucket, mm_got.bucket) {
+				mmSetShard.t.Errorf("IBucketStoreMock.SetShard got unexpected parameter bucket, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetShard.SetShardMock.defaultExpectation.expectationOrigins.originBucket, *mm_want_ptrs.bucket, mm_got.bucket, minimock.Diff(*mm_want_ptrs.bucket, mm_got.bucket))
+			}
+
+			if mm_want_ptrs.shard != nil && !minimock.Equal(*mm_want_ptrs.shard, mm_got.shard) {
+				mmSetShard.t.Errorf("IBucketStoreMock.SetShard got unexpected parameter shard, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetShard.SetShardMock.defaultExpectation.expectationOrigins.originShard, *mm_want_ptrs.shard, mm_got.shard, minimock.Diff(*mm_want_ptrs.shard, mm_got.shard))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetShard.t.Errorf("IBucketStoreMock.SetShard got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmSetShard.SetShardMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSetShard.SetShardMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSetShard.t.Fatal("No results are set for the IBucketStoreMock.SetShard")
+		}
+		return (*mm_results).err
+	}
+	if mmSetShard.funcSetShard != nil {
+		return mmSetShard.funcSetShard(ctx, bucket, shard)
+	}
+	mmSetShard.t.Fatalf("Unexpected call to IBucketStoreMock.SetShard. %v %v %v", ctx, bucket, shard)
+	return
+}
+
+// SetShardAfterCounter returns a count of finished IBucketStoreMock.SetShard invocations
+func (mmSetShard *IBucketStoreMock) SetShardAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetShard.afterSetShardCounter)
+}
+
+// SetShardBeforeCounter returns a count of IBucketStoreMock.SetShard invocations
+func (mmSetShard *IBucketStoreMock) SetShardBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetShard.beforeSetShardCounter)
+}
+
+// Calls returns a list of arguments used in each call to IBucketStoreMock.SetShard.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetShard *mIBucketStoreMockSetShard) Calls() []*IBucketStoreMockSetShardParams {
+	mmSetShard.mutex.RLock()
+
+	argCopy := make([]*IBucketStoreMockSetShardParams, len(mmSetShard.callArgs))
+	copy(argCopy, mmSetShard.callArgs)
+
+	mmSetShard.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetShardDone returns true if the count of the SetShard invocations corresponds
+// the number of defined expectations
+func (m *IBucketStoreMock) MinimockSetShardDone() bool {
+	if m.SetShardMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.SetShardMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.SetShardMock.invocationsDone()
+}
+
+// MinimockSetShardInspect logs each unmet expectation
+func (m *IBucketStoreMock) MinimockSetShardInspect() {
+	for _, e := range m.SetShardMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IBucketStoreMock.SetShard at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterSetShardCounter := mm_atomic.LoadUint64(&m.afterSetShardCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetShardMock.defaultExpectation != nil && afterSetShardCounter < 1 {
+		if m.SetShardMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IBucketStoreMock.SetShard at\n%s", m.SetShardMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IBucketStoreMock.SetShard at\n%s with params: %#v", m.SetShardMock.defaultExpectation.expectationOrigins.origin, *m.SetShardMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetShard != nil && afterSetShardCounter < 1 {
+		m.t.Errorf("Expected call to IBucketStoreMock.SetShard at\n%s", m.funcSetShardOrigin)
+	}
+
+	if !m.SetShardMock.invocationsDone() && afterSetShardCounter > 0 {
+		m.t.Errorf("Expected %d calls to IBucketStoreMock.SetShard at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.SetShardMock.expectedInvocations), m.SetShardMock.expectedInvocationsOrigin, afterSetShardCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *IBucketStoreMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockSetShardInspect()
+		}
+	})
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *IBucketStoreMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *IBucketStoreMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockSetShardDone()
+}
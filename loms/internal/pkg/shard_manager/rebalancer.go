@@ -0,0 +1,115 @@
+package shard_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	internal_errors "route256/loms/internal/pkg/errors"
+)
+
+// IBucketMover copies orders of bucket between shards.
+type IBucketMover interface {
+	MoveBucket(ctx context.Context, bucket Bucket, source, target ShardIndex) (int64, error)
+}
+
+// IBucketStore stores owner shard of bucket.
+type IBucketStore interface {
+	SetShard(ctx context.Context, bucket Bucket, shard ShardIndex) error
+}
+
+// Rebalancer moves buckets to other shards while service is running.
+type Rebalancer struct {
+	shardManager *ShardManager
+	mover        IBucketMover
+	store        IBucketStore
+	retryDelay   time.Duration
+}
+
+// NewRebalancer creates a new Rebalancer instance, busy bucket is retried after retryDelay.
+func NewRebalancer(shardManager *ShardManager, mover IBucketMover, store IBucketStore, retryDelay time.Duration) *Rebalancer {
+	return &Rebalancer{
+		shardManager: shardManager,
+		mover:        mover,
+		store:        store,
+		retryDelay:   retryDelay,
+	}
+}
+
+// MoveBucket moves bucket to target shard and makes target its owner, returns count of moved orders.
+// Bucket with unsent events is retried until events are sent or ctx is done.
+func (r *Rebalancer) MoveBucket(ctx context.Context, bucket Bucket, target ShardIndex) (int64, error) {
+	if int(bucket) < 0 || int(bucket) >= r.shardManager.BucketCount() {
+		return 0, fmt.Errorf("bucket %d is out of range: %w", bucket, internal_errors.ErrBadRequest)
+	}
+	if _, err := r.shardManager.GetShard(target); err != nil {
+		return 0, err
+	}
+
+	source := r.shardManager.GetBucketShard(bucket)
+	if source == target {
+		return 0, nil
+	}
+
+	for {
+		moved, err := r.mover.MoveBucket(ctx, bucket, source, target)
+		if errors.Is(err, internal_errors.ErrBucketBusy) {
+			select {
+			case <-time.After(r.retryDelay):
+				continue
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if err := r.store.SetShard(ctx, bucket, target); err != nil {
+			return 0, err
+		}
+		r.shardManager.SetBucketShard(bucket, target)
+
+		return moved, nil
+	}
+}
+
+// PlanMoves returns buckets to move to target shard, so it owns its even part of buckets.
+// Buckets are taken from shards owning most buckets.
+func PlanMoves(buckets []ShardIndex, shardCount int, target ShardIndex) []Bucket {
+	if shardCount < 1 || int(target) < 0 || int(target) >= shardCount {
+		return nil
+	}
+
+	owned := make([][]Bucket, shardCount)
+	for bucket, shard := range buckets {
+		if int(shard) >= 0 && int(shard) < shardCount {
+			owned[shard] = append(owned[shard], Bucket(bucket))
+		}
+	}
+
+	want := len(buckets) / shardCount
+	var moves []Bucket
+	for len(owned[target]) < want {
+		donor := -1
+		for shard := range owned {
+			if ShardIndex(shard) != target && (donor < 0 || len(owned[shard]) > len(owned[donor])) {
+				donor = shard
+			}
+		}
+		if donor < 0 || len(owned[donor]) <= want {
+			break
+		}
+
+		last := len(owned[donor]) - 1
+		moves = append(moves, owned[donor][last])
+		owned[target] = append(owned[target], owned[donor][last])
+		owned[donor] = owned[donor][:last]
+	}
+
+	sort.Slice(moves, func(i, j int) bool { return moves[i] < moves[j] })
+
+	return moves
+}
@@ -2,71 +2,171 @@ package shard_manager
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/replica"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spaolacci/murmur3"
 )
 
+// OrderIDStep is increment of order ID sequence, bucket of order is added to sequence value,
+// so bucket count must divide it.
+const OrderIDStep = 1000
+
 type ShardKey string
 type ShardIndex int
+type Bucket int
 
-type ShardFn func(ShardKey) ShardIndex
+type BucketFn func(ShardKey) Bucket
 
+// ShardManager routes keys to virtual buckets and buckets to shards. Bucket of key never changes,
+// order ID keeps bucket of user, so orders are found by ID after their bucket is moved to another shard.
 type ShardManager struct {
-	shardFn          ShardFn
-	shards           []*pgxpool.Pool
-	shardBucketCount int
+	bucketFn BucketFn
+	shards   []*pgxpool.Pool
+	readers  []*replica.Pool
+	breakers []*circuitBreaker
+
+	mu      sync.RWMutex
+	buckets []ShardIndex
 }
 
-// NewShardManager creates a new ShardManager instance.
-func NewShardManager(shardFn ShardFn, shards []*pgxpool.Pool, shardBucketCount int) *ShardManager {
+// NewShardManager creates a new ShardManager instance, buckets holds owner shard of each bucket.
+// Reads are served by primaries of shards until replicas are set.
+func NewShardManager(bucketFn BucketFn, shards []*pgxpool.Pool, buckets []ShardIndex) *ShardManager {
+	readers := make([]*replica.Pool, len(shards))
+	breakers := make([]*circuitBreaker, len(shards))
+	for i, pool := range shards {
+		readers[i] = replica.NewPool(shardName(ShardIndex(i)), pool, nil, 0)
+		breakers[i] = newCircuitBreaker()
+	}
+
 	return &ShardManager{
-		shardFn:          shardFn,
-		shards:           shards,
-		shardBucketCount: shardBucketCount,
+		bucketFn: bucketFn,
+		shards:   shards,
+		readers:  readers,
+		breakers: breakers,
+		buckets:  append([]ShardIndex(nil), buckets...),
+	}
+}
+
+// SetReplicas sets replica of each shard, nil replica keeps reads of shard on primary.
+// It must be called before ShardManager is used.
+func (sm *ShardManager) SetReplicas(replicas []*pgxpool.Pool, maxLag time.Duration) error {
+	if len(replicas) > len(sm.shards) {
+		return fmt.Errorf("%d replicas are given for %d shards", len(replicas), len(sm.shards))
+	}
+	for i, pool := range replicas {
+		sm.readers[i] = replica.NewPool(shardName(ShardIndex(i)), sm.shards[i], pool, maxLag)
 	}
+	return nil
+}
+
+// DefaultBuckets returns buckets spread over shards round-robin. For order IDs created before
+// bucket map bucket equals shard index, so they stay on their shards.
+func DefaultBuckets(bucketCount, shardCount int) []ShardIndex {
+	buckets := make([]ShardIndex, bucketCount)
+	for i := range buckets {
+		buckets[i] = ShardIndex(i % shardCount)
+	}
+	return buckets
+}
+
+// ValidateBucketCount checks bucket count can be encoded in order ID.
+func ValidateBucketCount(bucketCount int) error {
+	if bucketCount < 1 || OrderIDStep%bucketCount != 0 {
+		return fmt.Errorf("shard bucket count %d must divide %d", bucketCount, OrderIDStep)
+	}
+	return nil
+}
+
+// GetBucket returns the bucket for a given key.
+func (sm *ShardManager) GetBucket(key ShardKey) Bucket {
+	return sm.bucketFn(key)
+}
+
+// GetBucketFromID returns the bucket of order ID.
+func (sm *ShardManager) GetBucketFromID(id int64) Bucket {
+	return Bucket(id % int64(sm.BucketCount()))
 }
 
 // GetShardIndex returns the shard index for a given key.
 func (sm *ShardManager) GetShardIndex(key ShardKey) ShardIndex {
-	return sm.shardFn(key)
+	return sm.GetBucketShard(sm.GetBucket(key))
 }
 
-// GetShardIndexFromID
+// GetShardIndexFromID returns the shard index of order ID.
 func (sm *ShardManager) GetShardIndexFromID(id int64) ShardIndex {
-	return ShardIndex(id % int64(sm.shardBucketCount))
+	return sm.GetBucketShard(sm.GetBucketFromID(id))
+}
+
+// GetBucketShard returns owner shard of bucket.
+func (sm *ShardManager) GetBucketShard(bucket Bucket) ShardIndex {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.buckets[bucket]
+}
+
+// SetBucketShard changes owner shard of bucket.
+func (sm *ShardManager) SetBucketShard(bucket Bucket, shard ShardIndex) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.buckets[bucket] = shard
+}
+
+// SetBuckets replaces owner shards of all buckets, bucket count can not be changed.
+func (sm *ShardManager) SetBuckets(buckets []ShardIndex) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if len(buckets) != len(sm.buckets) {
+		return fmt.Errorf("bucket count can not be changed from %d to %d", len(sm.buckets), len(buckets))
+	}
+	copy(sm.buckets, buckets)
+	return nil
+}
+
+// BucketCount returns count of buckets.
+func (sm *ShardManager) BucketCount() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return len(sm.buckets)
 }
 
 // GetShard returns the connection pool for the given shard index.
 func (sm *ShardManager) GetShard(index ShardIndex) (*pgxpool.Pool, error) {
-	if int(index) < len(sm.shards) {
+	if index >= 0 && int(index) < len(sm.shards) {
 		return sm.shards[index], nil
 	}
 	return nil, fmt.Errorf("%w: given index=%d, len=%d", internal_errors.ErrShardIndexOutOfRange, index, len(sm.shards))
 }
 
+// GetReader returns pool for read-only queries of the given shard index.
+func (sm *ShardManager) GetReader(index ShardIndex) (*replica.Pool, error) {
+	if index >= 0 && int(index) < len(sm.readers) {
+		return sm.readers[index], nil
+	}
+	return nil, fmt.Errorf("%w: given index=%d, len=%d", internal_errors.ErrShardIndexOutOfRange, index, len(sm.readers))
+}
+
+// GetReaders returns pools for read-only queries of all shards.
+func (sm *ShardManager) GetReaders() []*replica.Pool {
+	return sm.readers
+}
+
 // GetShard returns all connection pool.
 func (sm *ShardManager) GetShards() []*pgxpool.Pool {
 	return sm.shards
 }
 
-// HashShardFn is a hash function for sharding.
-func HashShardFn(shardCount int) ShardFn {
-	return func(key ShardKey) ShardIndex {
-		h := murmur3.New32()
-		h.Write([]byte(key))
-		return ShardIndex(uint32(h.Sum32()) % uint32(shardCount))
-	}
-}
-
-// GetMurmur3ShardFn returns a ShardFn based on the Murmur3 hash function.
-func GetMurmur3ShardFn(shardCount int) ShardFn {
-	return func(key ShardKey) ShardIndex {
+// GetMurmur3BucketFn returns a BucketFn based on the Murmur3 hash function.
+func GetMurmur3BucketFn(bucketCount int) BucketFn {
+	return func(key ShardKey) Bucket {
 		hasher := murmur3.New32()
 		hasher.Write([]byte(key))
-		return ShardIndex(hasher.Sum32() % uint32(shardCount))
+		return Bucket(hasher.Sum32() % uint32(bucketCount))
 	}
 }
 
@@ -75,4 +175,12 @@ func (sm *ShardManager) CloseShards() {
 	for _, pool := range sm.shards {
 		pool.Close()
 	}
+	for _, reader := range sm.readers {
+		reader.Close()
+	}
+}
+
+// shardName returns name of shard in metrics.
+func shardName(index ShardIndex) string {
+	return fmt.Sprintf("shard-%d", index)
 }
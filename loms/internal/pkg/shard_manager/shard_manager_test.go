@@ -0,0 +1,250 @@
+package shard_manager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/shard_manager"
+	"route256/loms/internal/pkg/shard_manager/mock"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// Test bucket of order ID is bucket of its user
+func TestShardManager_GetBucketFromID(t *testing.T) {
+	const bucketCount = 10
+
+	sm := shard_manager.NewShardManager(shard_manager.GetMurmur3BucketFn(bucketCount), make([]*pgxpool.Pool, 2), shard_manager.DefaultBuckets(bucketCount, 2))
+
+	for _, user := range []string{"1", "42", "1001", "999999"} {
+		bucket := sm.GetBucket(shard_manager.ShardKey(user))
+		orderID := int64(5*shard_manager.OrderIDStep) + int64(bucket)
+
+		require.Equal(t, bucket, sm.GetBucketFromID(orderID))
+		require.Equal(t, sm.GetShardIndex(shard_manager.ShardKey(user)), sm.GetShardIndexFromID(orderID))
+	}
+}
+
+// Test orders created before bucket map stay on their shards
+func TestShardManager_LegacyOrderID(t *testing.T) {
+	sm := shard_manager.NewShardManager(shard_manager.GetMurmur3BucketFn(1000), make([]*pgxpool.Pool, 2), shard_manager.DefaultBuckets(1000, 2))
+
+	require.Equal(t, shard_manager.ShardIndex(0), sm.GetShardIndexFromID(3000))
+	require.Equal(t, shard_manager.ShardIndex(1), sm.GetShardIndexFromID(3001))
+}
+
+// Test bucket map update
+func TestShardManager_SetBuckets(t *testing.T) {
+	sm := shard_manager.NewShardManager(shard_manager.GetMurmur3BucketFn(4), make([]*pgxpool.Pool, 2), shard_manager.DefaultBuckets(4, 2))
+
+	sm.SetBucketShard(2, 1)
+	require.Equal(t, shard_manager.ShardIndex(1), sm.GetBucketShard(2))
+
+	require.NoError(t, sm.SetBuckets([]shard_manager.ShardIndex{1, 1, 0, 0}))
+	require.Equal(t, shard_manager.ShardIndex(0), sm.GetBucketShard(2))
+
+	require.Error(t, sm.SetBuckets([]shard_manager.ShardIndex{0, 1}))
+}
+
+// Test bucket count validation
+func TestValidateBucketCount_Table(t *testing.T) {
+	tests := []struct {
+		name        string
+		bucketCount int
+		wantErr     bool
+	}{
+		{name: "one bucket", bucketCount: 1},
+		{name: "divides order ID step", bucketCount: 100},
+		{name: "equals order ID step", bucketCount: 1000},
+		{name: "zero", bucketCount: 0, wantErr: true},
+		{name: "does not divide order ID step", bucketCount: 300, wantErr: true},
+		{name: "greater than order ID step", bucketCount: 2000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := shard_manager.ValidateBucketCount(tt.bucketCount)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// Test planning moves to new shard
+func TestPlanMoves_Table(t *testing.T) {
+	tests := []struct {
+		name       string
+		buckets    []shard_manager.ShardIndex
+		shardCount int
+		target     shard_manager.ShardIndex
+		expected   []shard_manager.Bucket
+	}{
+		{
+			name:       "new shard takes even part",
+			buckets:    shard_manager.DefaultBuckets(6, 2),
+			shardCount: 3,
+			target:     2,
+			expected:   []shard_manager.Bucket{4, 5},
+		},
+		{
+			name:       "buckets taken from largest shard",
+			buckets:    []shard_manager.ShardIndex{0, 0, 0, 0, 1, 1},
+			shardCount: 3,
+			target:     2,
+			expected:   []shard_manager.Bucket{2, 3},
+		},
+		{
+			name:       "balanced shard",
+			buckets:    shard_manager.DefaultBuckets(6, 3),
+			shardCount: 3,
+			target:     2,
+			expected:   nil,
+		},
+		{
+			name:       "target out of range",
+			buckets:    shard_manager.DefaultBuckets(6, 2),
+			shardCount: 2,
+			target:     2,
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, shard_manager.PlanMoves(tt.buckets, tt.shardCount, tt.target))
+		})
+	}
+}
+
+// Test function for MoveBucket method of Rebalancer
+func TestRebalancer_MoveBucket_Table(t *testing.T) {
+	errStore := errors.New("store error")
+
+	tests := []struct {
+		name          string
+		bucket        shard_manager.Bucket
+		target        shard_manager.ShardIndex
+		setupMocks    func(moverMock *mock.IBucketMoverMock, storeMock *mock.IBucketStoreMock)
+		expectedMoved int64
+		expectedShard shard_manager.ShardIndex
+		expectedErr   error
+	}{
+		{
+			name:   "successful move",
+			bucket: 1,
+			target: 0,
+			setupMocks: func(moverMock *mock.IBucketMoverMock, storeMock *mock.IBucketStoreMock) {
+				moverMock.MoveBucketMock.Expect(minimock.AnyContext, 1, 1, 0).Return(3, nil)
+				storeMock.SetShardMock.Expect(minimock.AnyContext, 1, 0).Return(nil)
+			},
+			expectedMoved: 3,
+			expectedShard: 0,
+		},
+		{
+			name:   "busy bucket retried",
+			bucket: 1,
+			target: 0,
+			setupMocks: func(moverMock *mock.IBucketMoverMock, storeMock *mock.IBucketStoreMock) {
+				calls := 0
+				moverMock.MoveBucketMock.Set(func(ctx context.Context, bucket shard_manager.Bucket, source, target shard_manager.ShardIndex) (int64, error) {
+					calls++
+					if calls == 1 {
+						return 0, internal_errors.ErrBucketBusy
+					}
+					return 2, nil
+				})
+				storeMock.SetShardMock.Expect(minimock.AnyContext, 1, 0).Return(nil)
+			},
+			expectedMoved: 2,
+			expectedShard: 0,
+		},
+		{
+			name:          "bucket already on target",
+			bucket:        1,
+			target:        1,
+			setupMocks:    func(moverMock *mock.IBucketMoverMock, storeMock *mock.IBucketStoreMock) {},
+			expectedShard: 1,
+		},
+		{
+			name:   "owner not stored",
+			bucket: 1,
+			target: 0,
+			setupMocks: func(moverMock *mock.IBucketMoverMock, storeMock *mock.IBucketStoreMock) {
+				moverMock.MoveBucketMock.Return(3, nil)
+				storeMock.SetShardMock.Return(errStore)
+			},
+			expectedShard: 1,
+			expectedErr:   errStore,
+		},
+		{
+			name:          "bucket out of range",
+			bucket:        4,
+			target:        0,
+			setupMocks:    func(moverMock *mock.IBucketMoverMock, storeMock *mock.IBucketStoreMock) {},
+			expectedShard: 1,
+			expectedErr:   internal_errors.ErrBadRequest,
+		},
+		{
+			name:          "shard out of range",
+			bucket:        1,
+			target:        2,
+			setupMocks:    func(moverMock *mock.IBucketMoverMock, storeMock *mock.IBucketStoreMock) {},
+			expectedShard: 1,
+			expectedErr:   internal_errors.ErrShardIndexOutOfRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := minimock.NewController(t)
+			moverMock := mock.NewIBucketMoverMock(ctrl)
+			storeMock := mock.NewIBucketStoreMock(ctrl)
+			tt.setupMocks(moverMock, storeMock)
+
+			sm := shard_manager.NewShardManager(shard_manager.GetMurmur3BucketFn(4), make([]*pgxpool.Pool, 2), shard_manager.DefaultBuckets(4, 2))
+			rebalancer := shard_manager.NewRebalancer(sm, moverMock, storeMock, time.Millisecond)
+
+			moved, err := rebalancer.MoveBucket(context.Background(), tt.bucket, tt.target)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedMoved, moved)
+			}
+			if tt.bucket < 4 {
+				require.Equal(t, tt.expectedShard, sm.GetBucketShard(tt.bucket))
+			}
+		})
+	}
+}
+
+// Test circuit of shard is opened by failures and closed by success
+func TestShardManager_CircuitBreaker(t *testing.T) {
+	sm := shard_manager.NewShardManager(shard_manager.GetMurmur3BucketFn(4), make([]*pgxpool.Pool, 2), shard_manager.DefaultBuckets(4, 2))
+	errShard := errors.New("shard error")
+
+	for i := 0; i < 2; i++ {
+		sm.ReportResult(0, errShard)
+		require.True(t, sm.Available(0))
+	}
+
+	sm.ReportResult(0, errShard)
+	require.False(t, sm.Available(0))
+	require.ErrorIs(t, sm.CheckAvailable(0), internal_errors.ErrShardUnavailable)
+	require.Equal(t, shard_manager.CircuitOpen, sm.CircuitState(0))
+	require.True(t, sm.Available(1))
+
+	sm.ReportResult(0, nil)
+	require.True(t, sm.Available(0))
+	require.Equal(t, shard_manager.CircuitClosed, sm.CircuitState(0))
+
+	require.False(t, sm.Available(2))
+}
@@ -0,0 +1,269 @@
+package stock_import
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+)
+
+// RowError describes invalid row of imported file.
+type RowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Errors contains all invalid rows of imported file.
+type Errors []RowError
+
+// Error returns errors of all rows, one row per line.
+func (e Errors) Error() string {
+	lines := make([]string, len(e))
+	for i, rowErr := range e {
+		lines[i] = fmt.Sprintf("line %d: %s", rowErr.Line, rowErr.Message)
+	}
+	return "invalid stock rows:\n" + strings.Join(lines, "\n")
+}
+
+// Unwrap makes row errors match bad request.
+func (e Errors) Unwrap() error {
+	return internal_errors.ErrBadRequest
+}
+
+// stockRow represents single row of imported file, missing values are nil.
+type stockRow struct {
+	SKU        *int64 `json:"sku"`
+	TotalCount *int64 `json:"total_count"`
+	Reserved   *int64 `json:"reserved"`
+}
+
+// FormatFromPath detects file format by extension.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("unsupported stock file format %q: %w", filepath.Ext(path), internal_errors.ErrBadRequest)
+	}
+}
+
+// ParseFile reads stock rows from file, format is detected by extension.
+func ParseFile(path string) ([]models.StockImportRow, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stock file: %w", err)
+	}
+	defer file.Close()
+
+	return Parse(file, format)
+}
+
+// Parse reads stock rows in format, all invalid rows are returned as Errors.
+func Parse(r io.Reader, format Format) ([]models.StockImportRow, error) {
+	switch format {
+	case FormatJSON:
+		return parseJSON(r)
+	case FormatCSV:
+		return parseCSV(r)
+	default:
+		return nil, fmt.Errorf("unsupported stock file format %q: %w", format, internal_errors.ErrBadRequest)
+	}
+}
+
+// parseJSON reads array of stock objects.
+func parseJSON(r io.Reader) ([]models.StockImportRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stock file: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if token, err := dec.Token(); err != nil || token != json.Delim('[') {
+		return nil, Errors{{Line: 1, Message: "stock file must contain JSON array"}}
+	}
+
+	var rows []models.StockImportRow
+	var rowErrs Errors
+	v := newValidator()
+	for dec.More() {
+		line := lineAt(data, dec.InputOffset())
+
+		var row stockRow
+		if err := dec.Decode(&row); err != nil {
+			// Decoder can not continue after syntax error
+			rowErrs = append(rowErrs, RowError{Line: line, Message: err.Error()})
+			return nil, rowErrs
+		}
+
+		if stock, ok := v.validate(line, row); ok {
+			rows = append(rows, models.StockImportRow{Line: line, Stock: stock})
+		}
+	}
+	rowErrs = append(rowErrs, v.errs...)
+
+	if len(rowErrs) > 0 {
+		return nil, rowErrs
+	}
+
+	return rows, nil
+}
+
+// parseCSV reads rows with header containing sku, total_count and optional reserved columns.
+func parseCSV(r io.Reader) ([]models.StockImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, Errors{{Line: 1, Message: "stock file must contain CSV header"}}
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"sku", "total_count"} {
+		if _, ok := columns[required]; !ok {
+			return nil, Errors{{Line: 1, Message: fmt.Sprintf("header must contain %q column", required)}}
+		}
+	}
+
+	var rows []models.StockImportRow
+	v := newValidator()
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				line = parseErr.Line
+			}
+			v.errs = append(v.errs, RowError{Line: line, Message: err.Error()})
+			continue
+		}
+
+		row, err := csvRow(record, columns)
+		if err != nil {
+			v.errs = append(v.errs, RowError{Line: line, Message: err.Error()})
+			continue
+		}
+
+		if stock, ok := v.validate(line, row); ok {
+			rows = append(rows, models.StockImportRow{Line: line, Stock: stock})
+		}
+	}
+
+	if len(v.errs) > 0 {
+		return nil, v.errs
+	}
+
+	return rows, nil
+}
+
+// csvRow converts CSV record to row, empty values are treated as missing.
+func csvRow(record []string, columns map[string]int) (stockRow, error) {
+	var row stockRow
+	fields := []struct {
+		name  string
+		value **int64
+	}{
+		{"sku", &row.SKU},
+		{"total_count", &row.TotalCount},
+		{"reserved", &row.Reserved},
+	}
+
+	for _, field := range fields {
+		i, ok := columns[field.name]
+		if !ok || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+			continue
+		}
+
+		value, err := strconv.ParseInt(strings.TrimSpace(record[i]), 10, 64)
+		if err != nil {
+			return stockRow{}, fmt.Errorf("%s must be integer", field.name)
+		}
+		*field.value = &value
+	}
+
+	return row, nil
+}
+
+// validator checks rows and collects errors.
+type validator struct {
+	seen map[models.SKU]int
+	errs Errors
+}
+
+// newValidator creates validator.
+func newValidator() *validator {
+	return &validator{seen: make(map[models.SKU]int)}
+}
+
+// validate checks row and converts it to stock.
+func (v *validator) validate(line int, row stockRow) (models.Stock, bool) {
+	fail := func(format string, args ...interface{}) (models.Stock, bool) {
+		v.errs = append(v.errs, RowError{Line: line, Message: fmt.Sprintf(format, args...)})
+		return models.Stock{}, false
+	}
+
+	switch {
+	case row.SKU == nil:
+		return fail("sku is required")
+	// stocks.sku is INTEGER
+	case *row.SKU < 1 || *row.SKU > math.MaxInt32:
+		return fail("sku must be in range [1, %d]", math.MaxInt32)
+	case row.TotalCount == nil:
+		return fail("total_count is required")
+	case *row.TotalCount < 0:
+		return fail("total_count must not be negative")
+	case row.Reserved != nil && *row.Reserved != 0:
+		return fail("reserved must be 0, stock is reserved only by orders")
+	}
+
+	sku := models.SKU(*row.SKU)
+	if prev, ok := v.seen[sku]; ok {
+		return fail("duplicate sku %d, first defined on line %d", sku, prev)
+	}
+	v.seen[sku] = line
+
+	return models.Stock{
+		SKU:        sku,
+		TotalCount: uint64(*row.TotalCount),
+	}, true
+}
+
+// lineAt returns line number of first non-space byte starting from offset.
+func lineAt(data []byte, offset int64) int {
+	for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n,", rune(data[offset])) {
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
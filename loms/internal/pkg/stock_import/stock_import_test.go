@@ -0,0 +1,136 @@
+package stock_import_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/stock_import"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for Parse function.
+func TestParse_Table(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		format       stock_import.Format
+		expectedRows []models.StockImportRow
+		expectedErrs stock_import.Errors
+	}{
+		{
+			name: "valid json",
+			content: `[
+  {"sku": 1001, "total_count": 100, "reserved": 0},
+  {
+    "sku": 1002,
+    "total_count": 50
+  }
+]`,
+			format: stock_import.FormatJSON,
+			expectedRows: []models.StockImportRow{
+				{Line: 2, Stock: models.Stock{SKU: 1001, TotalCount: 100}},
+				{Line: 3, Stock: models.Stock{SKU: 1002, TotalCount: 50}},
+			},
+		},
+		{
+			name:    "valid csv",
+			content: "sku,total_count,reserved\n1001,100,0\n1002, 50,\n",
+			format:  stock_import.FormatCSV,
+			expectedRows: []models.StockImportRow{
+				{Line: 2, Stock: models.Stock{SKU: 1001, TotalCount: 100}},
+				{Line: 3, Stock: models.Stock{SKU: 1002, TotalCount: 50}},
+			},
+		},
+		{
+			name: "invalid json rows",
+			content: `[
+  {"sku": 0, "total_count": 100},
+  {"sku": 1001},
+  {"sku": 1002, "total_count": 5, "reserved": 6},
+  {"sku": 1003, "total_count": 5},
+  {"sku": 1003, "total_count": 7}
+]`,
+			format: stock_import.FormatJSON,
+			expectedErrs: stock_import.Errors{
+				{Line: 2, Message: "sku must be in range [1, 2147483647]"},
+				{Line: 3, Message: "total_count is required"},
+				{Line: 4, Message: "reserved must be 0, stock is reserved only by orders"},
+				{Line: 6, Message: "duplicate sku 1003, first defined on line 5"},
+			},
+		},
+		{
+			name:    "invalid csv rows",
+			content: "sku,total_count\n1001,-1\nabc,5\n1002,5\n",
+			format:  stock_import.FormatCSV,
+			expectedErrs: stock_import.Errors{
+				{Line: 2, Message: "total_count must not be negative"},
+				{Line: 3, Message: "sku must be integer"},
+			},
+		},
+		{
+			name:    "max sku",
+			content: "sku,total_count\n2147483647,1\n",
+			format:  stock_import.FormatCSV,
+			expectedRows: []models.StockImportRow{
+				{Line: 2, Stock: models.Stock{SKU: 2147483647, TotalCount: 1}},
+			},
+		},
+		{
+			name:    "sku out of range of database",
+			content: "sku,total_count\n2147483648,1\n4294967295,1\n",
+			format:  stock_import.FormatCSV,
+			expectedErrs: stock_import.Errors{
+				{Line: 2, Message: "sku must be in range [1, 2147483647]"},
+				{Line: 3, Message: "sku must be in range [1, 2147483647]"},
+			},
+		},
+		{
+			name:    "csv without required column",
+			content: "sku,reserved\n1001,1\n",
+			format:  stock_import.FormatCSV,
+			expectedErrs: stock_import.Errors{
+				{Line: 1, Message: `header must contain "total_count" column`},
+			},
+		},
+		{
+			name:    "json is not array",
+			content: `{"sku": 1001, "total_count": 1}`,
+			format:  stock_import.FormatJSON,
+			expectedErrs: stock_import.Errors{
+				{Line: 1, Message: "stock file must contain JSON array"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, err := stock_import.Parse(strings.NewReader(tt.content), tt.format)
+			if tt.expectedErrs != nil {
+				var rowErrs stock_import.Errors
+				require.True(t, errors.As(err, &rowErrs))
+				require.Equal(t, tt.expectedErrs, rowErrs)
+				require.True(t, errors.Is(err, internal_errors.ErrBadRequest))
+				require.Nil(t, rows)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedRows, rows)
+			}
+		})
+	}
+}
+
+// Test function for ParseFile function with stock data of service.
+func TestParseFile(t *testing.T) {
+	rows, err := stock_import.ParseFile("../../../data/stock-data.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+
+	_, err = stock_import.ParseFile("stocks.xml")
+	require.True(t, errors.Is(err, internal_errors.ErrBadRequest))
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"route256/loms/internal/pkg/shard_manager"
+	"route256/loms/internal/repository/sqlc"
+	"time"
+
+	"route256/loms/internal/pkg/metrics"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+)
+
+// BucketRepository stores owner shard of each bucket in main database.
+type BucketRepository struct {
+	queries sqlc.Querier
+}
+
+// NewBucketRepository creates new instance of BucketRepository.
+func NewBucketRepository(pool *pgxpool.Pool) *BucketRepository {
+	return &BucketRepository{
+		queries: sqlc.New(pool),
+	}
+}
+
+// Init stores default owners of buckets missing in database, owners of existing buckets are kept.
+func (r *BucketRepository) Init(ctx context.Context, bucketCount, shardCount int) error {
+	// Tracer
+	ctx, span := otel.Tracer("BucketRepository").Start(ctx, "Init")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("InitShardBuckets", startTime)
+
+	_, err := r.queries.InitShardBuckets(ctx, &sqlc.InitShardBucketsParams{
+		ShardCount:  int32(shardCount),
+		BucketCount: int32(bucketCount),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to init shard buckets: %w", err)
+	}
+	return nil
+}
+
+// List returns owner shard of each bucket ordered by bucket.
+func (r *BucketRepository) List(ctx context.Context) ([]shard_manager.ShardIndex, error) {
+	// Tracer
+	ctx, span := otel.Tracer("BucketRepository").Start(ctx, "List")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("ListShardBuckets", startTime)
+
+	rows, err := r.queries.ListShardBuckets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shard buckets: %w", err)
+	}
+
+	buckets := make([]shard_manager.ShardIndex, len(rows))
+	for i, row := range rows {
+		if int(row.Bucket) != i {
+			return nil, fmt.Errorf("shard bucket %d is missing", i)
+		}
+		buckets[i] = shard_manager.ShardIndex(row.Shard)
+	}
+
+	return buckets, nil
+}
+
+// SetShard changes owner shard of bucket.
+func (r *BucketRepository) SetShard(ctx context.Context, bucket shard_manager.Bucket, shard shard_manager.ShardIndex) error {
+	// Tracer
+	ctx, span := otel.Tracer("BucketRepository").Start(ctx, "SetShard")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("SetShardBucketShard", startTime)
+
+	err := r.queries.SetShardBucketShard(ctx, &sqlc.SetShardBucketShardParams{
+		Bucket: int32(bucket),
+		Shard:  int32(shard),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set shard of bucket %d: %w", bucket, err)
+	}
+	return nil
+}
+
+// setMetrics set metrics of operation.
+func setMetrics(operation string, startTime time.Time) {
+	duration := time.Since(startTime)
+	metrics.IncDBQueryCounter(operation)
+	metrics.ObserveDBQueryDuration(operation, duration)
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/shard_manager"
+	"route256/loms/internal/repository/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+// bucketData holds all rows of orders in bucket.
+type bucketData struct {
+	orders          []*sqlc.Order
+	items           []*sqlc.ListBucketOrderItemsRow
+	history         []*sqlc.ListBucketOrderStatusHistoryRow
+	idempotencyKeys []*sqlc.IdempotencyKey
+	sequences       []*sqlc.OrderEventSequence
+	lastOrderID     int64
+}
+
+// MoveBucket copies orders of bucket from source shard to target shard and redirects requests of bucket
+// on source to target, returns count of moved orders. Writes of bucket wait while bucket is copied,
+// other buckets and reads are served as usual. Bucket with unsent events in outbox of source is not moved
+// and ErrBucketBusy is returned, so events of order are not sent out of order from two shards.
+// Interrupted move is finished by calling MoveBucket again.
+func (r *OrderRepository) MoveBucket(ctx context.Context, bucket shard_manager.Bucket, source, target shard_manager.ShardIndex) (int64, error) {
+	// Tracer
+	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "MoveBucket")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("MoveBucket", startTime)
+
+	if source == target {
+		return 0, fmt.Errorf("bucket %d is already on shard %d: %w", bucket, target, internal_errors.ErrBadRequest)
+	}
+
+	sourcePool, err := r.shardManager.GetShard(source)
+	if err != nil {
+		return 0, err
+	}
+	targetPool, err := r.shardManager.GetShard(target)
+	if err != nil {
+		return 0, err
+	}
+
+	// Start transaction on source shard
+	sourceTx, err := sourcePool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer sourceTx.Rollback(ctx)
+
+	src := sqlc.New(sourceTx)
+
+	// Writes of bucket wait until bucket is moved
+	if err := src.LockShardBucket(ctx, int32(bucket)); err != nil {
+		return 0, fmt.Errorf("failed to lock bucket: %w", err)
+	}
+
+	// Bucket was moved by interrupted call, only orders on target are left hidden
+	err = checkBucketMoved(ctx, src, bucket)
+	var moved errBucketMoved
+	if errors.As(err, &moved) {
+		if moved.shard != target {
+			return 0, fmt.Errorf("bucket %d is already moved to shard %d: %w", bucket, moved.shard, internal_errors.ErrPreconditionFailed)
+		}
+		return 0, showBucket(ctx, sqlc.New(targetPool), bucket)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	bucketCount := int64(r.shardManager.BucketCount())
+
+	// Next event of order must be sent after previous events from source
+	pending, err := src.CountBucketPendingOutboxEvents(ctx, &sqlc.CountBucketPendingOutboxEventsParams{
+		BucketCount: bucketCount,
+		Bucket:      int64(bucket),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending outbox events: %w", err)
+	}
+	if pending > 0 {
+		return 0, fmt.Errorf("bucket %d has %d pending events: %w", bucket, pending, internal_errors.ErrBucketBusy)
+	}
+
+	data, err := readBucket(ctx, src, bucketCount, bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	// Copy orders to target, they are hidden until bucket is removed from source
+	if err := copyBucket(ctx, targetPool, bucketCount, bucket, source, data); err != nil {
+		return 0, err
+	}
+
+	// Redirect requests of bucket to target and remove its orders from source
+	err = src.SetShardBucketState(ctx, &sqlc.SetShardBucketStateParams{
+		Bucket: int32(bucket),
+		State:  bucketStateMoved,
+		Shard:  int32(target),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to set bucket state: %w", err)
+	}
+	if _, err := src.DeleteBucketOrders(ctx, &sqlc.DeleteBucketOrdersParams{BucketCount: bucketCount, Bucket: int64(bucket)}); err != nil {
+		return 0, fmt.Errorf("failed to delete bucket orders: %w", err)
+	}
+	if err := src.DeleteBucketOrderEventSequences(ctx, &sqlc.DeleteBucketOrderEventSequencesParams{BucketCount: bucketCount, Bucket: int64(bucket)}); err != nil {
+		return 0, fmt.Errorf("failed to delete bucket event sequences: %w", err)
+	}
+
+	// Commit transaction
+	if err := sourceTx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := showBucket(ctx, sqlc.New(targetPool), bucket); err != nil {
+		return 0, err
+	}
+
+	return int64(len(data.orders)), nil
+}
+
+// readBucket reads all rows of orders in bucket.
+func readBucket(ctx context.Context, q *sqlc.Queries, bucketCount int64, bucket shard_manager.Bucket) (*bucketData, error) {
+	var (
+		data bucketData
+		err  error
+	)
+
+	data.orders, err = q.ListBucketOrders(ctx, &sqlc.ListBucketOrdersParams{BucketCount: bucketCount, Bucket: int64(bucket)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket orders: %w", err)
+	}
+	data.items, err = q.ListBucketOrderItems(ctx, &sqlc.ListBucketOrderItemsParams{BucketCount: bucketCount, Bucket: int64(bucket)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket order items: %w", err)
+	}
+	data.history, err = q.ListBucketOrderStatusHistory(ctx, &sqlc.ListBucketOrderStatusHistoryParams{BucketCount: bucketCount, Bucket: int64(bucket)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket order status history: %w", err)
+	}
+	data.idempotencyKeys, err = q.ListBucketIdempotencyKeys(ctx, &sqlc.ListBucketIdempotencyKeysParams{BucketCount: bucketCount, Bucket: int64(bucket)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket idempotency keys: %w", err)
+	}
+	data.sequences, err = q.ListBucketOrderEventSequences(ctx, &sqlc.ListBucketOrderEventSequencesParams{BucketCount: bucketCount, Bucket: int64(bucket)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket event sequences: %w", err)
+	}
+
+	// Order IDs of bucket are taken from sequence of shard
+	data.lastOrderID, err = q.GetOrderIDSequenceValue(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order ID sequence: %w", err)
+	}
+
+	return &data, nil
+}
+
+// copyBucket replaces orders of bucket on target shard with data in one transaction,
+// bucket stays hidden on target until showBucket is called.
+func copyBucket(ctx context.Context, pool interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}, bucketCount int64, bucket shard_manager.Bucket, source shard_manager.ShardIndex, data *bucketData) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(tx)
+
+	err = q.SetShardBucketState(ctx, &sqlc.SetShardBucketStateParams{
+		Bucket: int32(bucket),
+		State:  bucketStateImporting,
+		Shard:  int32(source),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set bucket state: %w", err)
+	}
+
+	// Orders left by interrupted move are replaced
+	if _, err := q.DeleteBucketOrders(ctx, &sqlc.DeleteBucketOrdersParams{BucketCount: bucketCount, Bucket: int64(bucket)}); err != nil {
+		return fmt.Errorf("failed to delete bucket orders: %w", err)
+	}
+
+	// New orders of bucket on target must not reuse IDs of source
+	if err := q.AdvanceOrderIDSequence(ctx, data.lastOrderID); err != nil {
+		return fmt.Errorf("failed to advance order ID sequence: %w", err)
+	}
+
+	for _, order := range data.orders {
+		err := q.CopyBucketOrder(ctx, &sqlc.CopyBucketOrderParams{
+			ID:        order.ID,
+			UserID:    order.UserID,
+			StatusID:  order.StatusID,
+			CreatedAt: order.CreatedAt,
+			UpdatedAt: order.UpdatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy order %d: %w", order.ID, err)
+		}
+	}
+	for _, item := range data.items {
+		err := q.CopyBucketOrderItem(ctx, &sqlc.CopyBucketOrderItemParams{
+			OrderID: item.OrderID,
+			Sku:     item.Sku,
+			Count:   item.Count,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy order item: %w", err)
+		}
+	}
+	for _, change := range data.history {
+		err := q.CopyBucketOrderStatusHistory(ctx, &sqlc.CopyBucketOrderStatusHistoryParams{
+			OrderID:   change.OrderID,
+			StatusID:  change.StatusID,
+			CreatedAt: change.CreatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy order status history: %w", err)
+		}
+	}
+	for _, key := range data.idempotencyKeys {
+		err := q.CopyBucketIdempotencyKey(ctx, &sqlc.CopyBucketIdempotencyKeyParams{
+			UserID:      key.UserID,
+			Key:         key.Key,
+			RequestHash: key.RequestHash,
+			OrderID:     key.OrderID,
+			CreatedAt:   key.CreatedAt,
+			ExpiresAt:   key.ExpiresAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy idempotency key: %w", err)
+		}
+	}
+	for _, sequence := range data.sequences {
+		err := q.CopyBucketOrderEventSequence(ctx, &sqlc.CopyBucketOrderEventSequenceParams{
+			OrderID:      sequence.OrderID,
+			LastSequence: sequence.LastSequence,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy event sequence of order %d: %w", sequence.OrderID, err)
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// showBucket makes orders of moved bucket visible on target shard.
+func showBucket(ctx context.Context, q *sqlc.Queries, bucket shard_manager.Bucket) error {
+	if err := q.DeleteShardBucketState(ctx, int32(bucket)); err != nil {
+		return fmt.Errorf("failed to show bucket %d: %w", bucket, err)
+	}
+	return nil
+}
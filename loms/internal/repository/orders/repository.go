@@ -2,32 +2,63 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"route256/loms/internal/models"
 	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/replica"
 	"route256/loms/internal/pkg/shard_manager"
+	repo_outbox "route256/loms/internal/repository/outbox"
 	"route256/loms/internal/repository/sqlc"
-	"sort"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
 
 	"route256/loms/internal/pkg/metrics"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel"
 )
 
-const allOrdersChannelBufferSize = 100
+const shardOrdersChannelBufferSize = 10
+
+// bucketMovedRetries limits redirects of request to new owner of bucket moved during request.
+const bucketMovedRetries = 3
+
+// States of bucket on shard during move.
+const (
+	bucketStateImporting = "importing"
+	bucketStateMoved     = "moved"
+)
 
 type IShardManager interface {
-	GetShardIndex(key shard_manager.ShardKey) shard_manager.ShardIndex
-	GetShardIndexFromID(id int64) shard_manager.ShardIndex
+	GetBucket(key shard_manager.ShardKey) shard_manager.Bucket
+	GetBucketFromID(id int64) shard_manager.Bucket
+	GetBucketShard(bucket shard_manager.Bucket) shard_manager.ShardIndex
+	SetBucketShard(bucket shard_manager.Bucket, shard shard_manager.ShardIndex)
+	BucketCount() int
 	GetShard(shard_manager.ShardIndex) (*pgxpool.Pool, error)
 	GetShards() []*pgxpool.Pool
+	GetReader(shard_manager.ShardIndex) (*replica.Pool, error)
+	GetReaders() []*replica.Pool
+	CheckAvailable(shard_manager.ShardIndex) error
+	ReportResult(shard_manager.ShardIndex, error)
 	CloseShards()
 }
 
+// errBucketMoved is returned by shard which does not own bucket anymore.
+type errBucketMoved struct {
+	shard shard_manager.ShardIndex
+}
+
+// Error returns new owner shard of bucket.
+func (e errBucketMoved) Error() string {
+	return fmt.Sprintf("bucket is moved to shard %d", e.shard)
+}
+
 // OrderRepository.
 type OrderRepository struct {
 	shardManager IShardManager
@@ -40,8 +71,8 @@ func NewOrderRepository(shardManager IShardManager) *OrderRepository {
 	}
 }
 
-// Create adds a new order to repository and returns unique orderID.
-func (r *OrderRepository) Create(ctx context.Context, order models.Order) (models.OID, error) {
+// Create adds a new order to repository with event of eventType in outbox of shard and returns unique orderID.
+func (r *OrderRepository) Create(ctx context.Context, order models.Order, eventType string) (models.OID, error) {
 	// Tracer
 	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "Create")
 	defer span.End()
@@ -54,49 +85,203 @@ func (r *OrderRepository) Create(ctx context.Context, order models.Order) (model
 		return 0, err
 	}
 
-	// Determine shard
-	shardIndex := r.shardManager.GetShardIndex(shard_manager.ShardKey(strconv.FormatInt(order.UserID, 10)))
-	pool, err := r.shardManager.GetShard(shardIndex)
+	// Orders of user are created in bucket of user
+	bucket := r.shardManager.GetBucket(shard_manager.ShardKey(strconv.FormatInt(order.UserID, 10)))
+
+	var orderID models.OID
+	err := r.withBucketShard(ctx, bucket, func(pool *pgxpool.Pool) error {
+		// Start transaction on the shard
+		tx, err := pool.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		q := sqlc.New(tx)
+
+		if err := lockBucket(ctx, q, bucket); err != nil {
+			return err
+		}
+
+		// Create order
+		orderID, err = createOrder(ctx, q, bucket, order)
+		if err != nil {
+			return err
+		}
+
+		// Write event in outbox of shard
+		event := models.OrderEvent{
+			OrderID: orderID,
+			UserID:  order.UserID,
+			Items:   order.Items,
+			Status:  order.Status,
+		}
+		if err := createOrderEvent(ctx, q, eventType, event); err != nil {
+			return err
+		}
+
+		// Commit transaction
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	// Start transaction on the shard
-	tx, err := pool.Begin(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	return orderID, nil
+}
+
+// CreateWithIdempotencyKey adds a new order, its idempotency key and event of eventType in one transaction on user shard.
+func (r *OrderRepository) CreateWithIdempotencyKey(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string) (models.OID, error) {
+	// Tracer
+	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "CreateWithIdempotencyKey")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("CreateWithIdempotencyKey", startTime)
+
+	// Validate input data
+	if err := validateOrder(order); err != nil {
+		return 0, err
+	}
+	if key.Key == "" {
+		return 0, fmt.Errorf("idempotency key must not be empty: %w", internal_errors.ErrBadRequest)
 	}
-	defer tx.Rollback(ctx)
 
-	q := sqlc.New(tx)
+	// Orders of user are created in bucket of user
+	bucket := r.shardManager.GetBucket(shard_manager.ShardKey(strconv.FormatInt(order.UserID, 10)))
 
-	// Create order
-	orderID, err := q.CreateOrder(ctx, &sqlc.CreateOrderParams{
-		Column1: shardIndex,
-		UserID:  order.UserID,
-		Name:    string(order.Status),
+	var orderID models.OID
+	err := r.withBucketShard(ctx, bucket, func(pool *pgxpool.Pool) error {
+		// Start transaction on the shard
+		tx, err := pool.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		q := sqlc.New(tx)
+
+		if err := lockBucket(ctx, q, bucket); err != nil {
+			return err
+		}
+
+		// Create order
+		orderID, err = createOrder(ctx, q, bucket, order)
+		if err != nil {
+			return err
+		}
+
+		// Write event in outbox of shard
+		event := models.OrderEvent{
+			OrderID: orderID,
+			UserID:  order.UserID,
+			Items:   order.Items,
+			Status:  order.Status,
+		}
+		if err := createOrderEvent(ctx, q, eventType, event); err != nil {
+			return err
+		}
+
+		// Store idempotency key, expired key with the same value is replaced
+		inserted, err := q.InsertIdempotencyKey(ctx, &sqlc.InsertIdempotencyKeyParams{
+			UserID:      order.UserID,
+			Key:         key.Key,
+			RequestHash: key.RequestHash,
+			OrderID:     orderID,
+			ExpiresAt:   pgtype.Timestamptz{Time: key.ExpiresAt, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create idempotency key: %w", err)
+		}
+		if inserted == 0 {
+			return internal_errors.ErrIdempotencyKeyExists
+		}
+
+		// Commit transaction
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
 	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to create order: %w", err)
+		return 0, err
 	}
 
-	for _, item := range order.Items {
-		_, err := q.CreateOrderItem(ctx, &sqlc.CreateOrderItemParams{
-			OrderID: &orderID,
-			Sku:     int32(item.SKU),
-			Count:   int16(item.Count),
+	return orderID, nil
+}
+
+// GetIdempotencyKey returns not expired idempotency key of user.
+func (r *OrderRepository) GetIdempotencyKey(ctx context.Context, userID models.UID, key string) (models.IdempotencyKey, error) {
+	// Tracer
+	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "GetIdempotencyKey")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("GetIdempotencyKey", startTime)
+
+	// Validate input data
+	if userID < 1 {
+		return models.IdempotencyKey{}, fmt.Errorf("userID must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Keys of user are stored in bucket of user
+	bucket := r.shardManager.GetBucket(shard_manager.ShardKey(strconv.FormatInt(userID, 10)))
+
+	var row *sqlc.GetIdempotencyKeyRow
+	err := r.withBucketShard(ctx, bucket, func(pool *pgxpool.Pool) error {
+		q := sqlc.New(pool)
+
+		// Get key
+		var err error
+		row, err = q.GetIdempotencyKey(ctx, &sqlc.GetIdempotencyKeyParams{
+			UserID: userID,
+			Key:    key,
 		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			if err := checkBucketMoved(ctx, q, bucket); err != nil {
+				return err
+			}
+			return internal_errors.ErrNotFound
+		}
 		if err != nil {
-			return 0, fmt.Errorf("failed to create order item: %w", err)
+			return fmt.Errorf("failed to get idempotency key: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return models.IdempotencyKey{}, err
 	}
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	return models.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: row.RequestHash,
+		OrderID:     row.OrderID,
+	}, nil
+}
+
+// DeleteExpiredIdempotencyKeys removes expired idempotency keys from all shards.
+func (r *OrderRepository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	// Tracer
+	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "DeleteExpiredIdempotencyKeys")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("DeleteExpiredIdempotencyKeys", startTime)
+
+	var deleted int64
+	for i, pool := range r.shardManager.GetShards() {
+		count, err := sqlc.New(pool).DeleteExpiredIdempotencyKeys(ctx)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete expired idempotency keys on shard %d: %w", i, err)
+		}
+		deleted += count
 	}
 
-	return models.OID(orderID), nil
+	return deleted, nil
 }
 
 // GetByID return order by orderID.
@@ -113,45 +298,70 @@ func (r *OrderRepository) GetByID(ctx context.Context, orderID models.OID) (mode
 		return models.Order{}, fmt.Errorf("orderID must be greater than zero: %w", internal_errors.ErrBadRequest)
 	}
 
-	// Determine shard
-	shardIndex := r.shardManager.GetShardIndexFromID(orderID)
-	pool, err := r.shardManager.GetShard(shardIndex)
-	if err != nil {
-		return models.Order{}, err
-	}
-
-	q := sqlc.New(pool)
+	bucket := r.shardManager.GetBucketFromID(orderID)
+
+	var (
+		order   *sqlc.GetOrderByIDRow
+		items   []*sqlc.Item
+		history []*sqlc.GetOrderStatusHistoryRow
+	)
+	err := r.withBucketReader(ctx, bucket, func(pool *pgxpool.Pool) error {
+		q := sqlc.New(pool)
+
+		// Get order
+		var err error
+		order, err = q.GetOrderByID(ctx, orderID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			if err := checkBucketMoved(ctx, q, bucket); err != nil {
+				return err
+			}
+			return internal_errors.ErrNotFound
+		}
+		if err != nil {
+			return internal_errors.ErrNotFound
+		}
 
-	// Get order
-	order, err := q.GetOrderByID(ctx, orderID)
-	if err != nil {
-		return models.Order{}, internal_errors.ErrNotFound
-	}
+		// Get items
+		items, err = q.GetOrderItems(ctx, &orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
 
-	// Get items
-	items, err := q.GetOrderItems(ctx, &orderID)
+		// Get status history
+		history, err = q.GetOrderStatusHistory(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order status history: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return models.Order{}, fmt.Errorf("failed to get order items: %w", err)
+		return models.Order{}, err
 	}
 
 	// Convert
-	var modelItems []models.Item
-	for _, item := range items {
-		modelItems = append(modelItems, models.Item{
-			SKU:   models.SKU(item.Sku),
-			Count: uint16(item.Count),
+	modelItems := toModelItems(items)
+
+	var modelHistory []models.OrderStatusChange
+	for _, change := range history {
+		modelHistory = append(modelHistory, models.OrderStatusChange{
+			Status: models.OrderStatus(change.Status),
+			Time:   change.CreatedAt.Time,
 		})
 	}
 
 	return models.Order{
-		Status: models.OrderStatus(order.Status),
-		UserID: order.UserID,
-		Items:  modelItems,
+		OrderID:   order.ID,
+		Status:    models.OrderStatus(order.Status),
+		UserID:    order.UserID,
+		Items:     modelItems,
+		CreatedAt: order.CreatedAt.Time,
+		UpdatedAt: order.UpdatedAt.Time,
+		History:   modelHistory,
 	}, nil
 }
 
-// SetStatus updates the status of an existing order.
-func (r *OrderRepository) SetStatus(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
+// SetStatus updates the status of an existing order and writes event of eventType in outbox of shard.
+func (r *OrderRepository) SetStatus(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) error {
 	// Tracer
 	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "SetStatus")
 	defer span.End()
@@ -159,86 +369,416 @@ func (r *OrderRepository) SetStatus(ctx context.Context, orderID models.OID, sta
 	startTime := time.Now()
 	defer setMetrics("SetStatus", startTime)
 
-	// Validate input data
-	if orderID < 1 {
-		return fmt.Errorf("orderID must be greater than zero: %w", internal_errors.ErrBadRequest)
-	}
+	return r.setStatus(ctx, orderID, "", status, eventType)
+}
 
-	if !isValidOrderStatus(status) {
-		return fmt.Errorf("invalid order status: %w", internal_errors.ErrPreconditionFailed)
+// CompareAndSetStatus updates the status of an order only if its current status is expected,
+// event of eventType is written in outbox of shard together with status.
+func (r *OrderRepository) CompareAndSetStatus(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+	// Tracer
+	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "CompareAndSetStatus")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("CompareAndSetStatus", startTime)
+
+	if !isValidOrderStatus(expected) {
+		return fmt.Errorf("invalid expected order status: %w", internal_errors.ErrPreconditionFailed)
 	}
 
-	// Determine shard
-	shardIndex := r.shardManager.GetShardIndexFromID(orderID)
-	pool, err := r.shardManager.GetShard(shardIndex)
+	return r.setStatus(ctx, orderID, expected, status, eventType)
+}
+
+// GetOrders returns up to limit orders matching filter, sorted by orderID desc.
+func (r *OrderRepository) GetOrders(ctx context.Context, filter models.OrderFilter, limit uint32) ([]models.Order, error) {
+	// Tracer
+	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "GetOrders")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("GetOrders", startTime)
+
+	page, err := r.getOrders(ctx, filter, limit, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return page.Orders, nil
+}
 
-	// Check transaction
-	q := sqlc.New(pool)
+// GetExpiredOrders returns up to limit orders awaiting payment longer than paymentTimeout from all shards,
+// oldest orders first.
+func (r *OrderRepository) GetExpiredOrders(ctx context.Context, paymentTimeout time.Duration, limit uint32) ([]models.Order, error) {
+	// Tracer
+	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "GetExpiredOrders")
+	defer span.End()
 
-	// Update order status
-	err = q.SetOrderStatus(ctx, &sqlc.SetOrderStatusParams{
-		ID:   orderID,
-		Name: string(status),
+	startTime := time.Now()
+	defer setMetrics("GetExpiredOrders", startTime)
+
+	// Validate input data
+	if limit < 1 {
+		return nil, fmt.Errorf("limit must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Each shard returns its oldest orders, batch is taken from oldest of them
+	params := &sqlc.GetExpiredOrdersParams{
+		PaymentTimeout: pgtype.Interval{Microseconds: paymentTimeout.Microseconds(), Valid: true},
+		BucketCount:    int64(r.shardManager.BucketCount()),
+		BatchLimit:     int32(limit),
+	}
+	var orders []models.Order
+	for i, pool := range r.shardManager.GetShards() {
+		rows, err := sqlc.New(pool).GetExpiredOrders(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get expired orders on shard %d: %w", i, err)
+		}
+		for _, row := range rows {
+			orders = append(orders, models.Order{
+				OrderID:   row.ID,
+				Status:    models.OrderStatusAwaitingPayment,
+				CreatedAt: row.CreatedAt.Time,
+			})
+		}
+	}
+
+	slices.SortFunc(orders, func(a, b models.Order) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
 	})
-	if err != nil {
-		return fmt.Errorf("failed to set order status: %w", err)
+	if len(orders) > int(limit) {
+		orders = orders[:limit]
 	}
 
-	return nil
+	return orders, nil
 }
 
-// GetOrders returns all orders.
-func (r *OrderRepository) GetOrders(ctx context.Context) ([]models.Order, error) {
+// GetOrdersPartial returns up to limit orders matching filter from available shards, sorted by orderID desc.
+// Shards which are unavailable or fail are skipped and listed in page.
+func (r *OrderRepository) GetOrdersPartial(ctx context.Context, filter models.OrderFilter, limit uint32) (models.OrdersPage, error) {
 	// Tracer
-	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "GetOrders")
+	ctx, span := otel.Tracer("OrderRepository").Start(ctx, "GetOrdersPartial")
 	defer span.End()
 
 	startTime := time.Now()
-	defer setMetrics("GetOrders", startTime)
+	defer setMetrics("GetOrdersPartial", startTime)
+
+	return r.getOrders(ctx, filter, limit, true)
+}
 
-	shards := r.shardManager.GetShards()
-	allOrders := make(chan models.Order, allOrdersChannelBufferSize)
-	errCh := make(chan error, len(shards))
+// getOrders reads page of orders from all shards, failed shards are skipped if partial is set.
+func (r *OrderRepository) getOrders(ctx context.Context, filter models.OrderFilter, limit uint32, partial bool) (models.OrdersPage, error) {
+	// Validate input data
+	if limit < 1 {
+		return models.OrdersPage{}, fmt.Errorf("limit must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Shard readers are stopped as soon as page is full
+	shardCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Orders of buckets being moved are hidden on both shards
+	params := toOrdersPageParams(filter, limit)
+	params.BucketCount = int64(r.shardManager.BucketCount())
+	readers := r.shardManager.GetReaders()
+	streams := make([]chan models.Order, len(readers))
+	errCh := make(chan error, len(readers))
+	unavailable := make([]bool, len(readers))
 	var wg sync.WaitGroup
 
 	// Run
-	for _, pool := range shards {
+	for i, reader := range readers {
+		shard := shard_manager.ShardIndex(i)
+		streams[i] = make(chan models.Order, shardOrdersChannelBufferSize)
+
+		// Shard with open circuit is not requested
+		if err := r.shardManager.CheckAvailable(shard); err != nil {
+			close(streams[i])
+			unavailable[i] = true
+			errCh <- err
+			continue
+		}
+
 		wg.Add(1)
-		go func(pool *pgxpool.Pool) {
+		go func(reader *replica.Pool, out chan<- models.Order) {
 			defer wg.Done()
-			if err := r.processShard(ctx, pool, allOrders); err != nil {
-				errCh <- err
+			defer close(out)
+			err := r.processShard(shardCtx, reader, params, out)
+			if shardCtx.Err() != nil {
+				return
 			}
-		}(pool)
+			r.shardManager.ReportResult(shard, err)
+			if err != nil {
+				unavailable[shard] = true
+				errCh <- fmt.Errorf("shard %d: %w", shard, err)
+			}
+		}(reader, streams[i])
 	}
 
-	// Close channels
-	go func() {
-		wg.Wait()
-		close(allOrders)
-		close(errCh)
-	}()
+	// Merge shard streams until page is full
+	orders := mergeOrderStreams(streams, int(limit))
 
-	// Collecting all orders
-	var orders []models.Order
-	for order := range allOrders {
-		orders = append(orders, order)
-	}
+	// Stop shard readers
+	cancel()
+	wg.Wait()
+	close(errCh)
 
 	// Collecting errors
-	if err := collectErrors(errCh); err != nil {
-		return nil, err
+	if err := collectErrors(errCh); err != nil && !partial {
+		return models.OrdersPage{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return models.OrdersPage{}, err
+	}
+
+	page := models.OrdersPage{Orders: orders}
+	for i, skipped := range unavailable {
+		if skipped {
+			page.UnavailableShards = append(page.UnavailableShards, i)
+		}
+	}
+
+	return page, nil
+}
+
+// setStatus updates order status, records transition in history and writes event in outbox,
+// empty expected status is not checked.
+func (r *OrderRepository) setStatus(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+	// Validate input data
+	if orderID < 1 {
+		return fmt.Errorf("orderID must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	if !isValidOrderStatus(status) {
+		return fmt.Errorf("invalid order status: %w", internal_errors.ErrPreconditionFailed)
 	}
 
-	// Sort orders by ID desc
-	sort.Slice(orders, func(i, j int) bool {
-		return orders[i].OrderID > orders[j].OrderID
+	bucket := r.shardManager.GetBucketFromID(orderID)
+
+	return r.withBucketShard(ctx, bucket, func(pool *pgxpool.Pool) error {
+		// Start transaction on the shard
+		tx, err := pool.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		q := sqlc.New(tx)
+
+		if err := lockBucket(ctx, q, bucket); err != nil {
+			return err
+		}
+
+		// Lock order, so event has status it was changed from
+		current, err := q.GetOrderByIDForUpdate(ctx, orderID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return internal_errors.ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if expected != "" && models.OrderStatus(current.Status) != expected {
+			return fmt.Errorf("order is not in %s status: %w", expected, internal_errors.ErrInvalidOrderStatus)
+		}
+
+		// Update order status
+		if expected == "" {
+			err = q.SetOrderStatus(ctx, &sqlc.SetOrderStatusParams{
+				ID:   orderID,
+				Name: string(status),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to set order status: %w", err)
+			}
+		} else {
+			updated, err := q.CompareAndSetOrderStatus(ctx, &sqlc.CompareAndSetOrderStatusParams{
+				Status:         string(status),
+				ID:             orderID,
+				ExpectedStatus: string(expected),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to set order status: %w", err)
+			}
+			if updated == 0 {
+				return fmt.Errorf("order is not in %s status: %w", expected, internal_errors.ErrInvalidOrderStatus)
+			}
+		}
+
+		// Record transition in history
+		err = q.InsertOrderStatusHistory(ctx, &sqlc.InsertOrderStatusHistoryParams{
+			OrderID: orderID,
+			Name:    string(status),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create order status history: %w", err)
+		}
+
+		// Write event in outbox of shard
+		items, err := q.GetOrderItems(ctx, &orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+		event := models.OrderEvent{
+			OrderID:   orderID,
+			UserID:    current.UserID,
+			Items:     toModelItems(items),
+			OldStatus: models.OrderStatus(current.Status),
+			Status:    status,
+		}
+		if err := createOrderEvent(ctx, q, eventType, event); err != nil {
+			return err
+		}
+
+		// Commit transaction
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
 	})
+}
 
-	return orders, nil
+// createOrder inserts order, its items and initial status history within transaction,
+// order ID ends with bucket, so order is found by ID on owner shard of bucket.
+func createOrder(ctx context.Context, q *sqlc.Queries, bucket shard_manager.Bucket, order models.Order) (models.OID, error) {
+	// Create order
+	orderID, err := q.CreateOrder(ctx, &sqlc.CreateOrderParams{
+		Column1: int(bucket),
+		UserID:  order.UserID,
+		Name:    string(order.Status),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	for _, item := range order.Items {
+		_, err := q.CreateOrderItem(ctx, &sqlc.CreateOrderItemParams{
+			OrderID: &orderID,
+			Sku:     int32(item.SKU),
+			Count:   int16(item.Count),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to create order item: %w", err)
+		}
+	}
+
+	// Record initial status in history
+	err = q.InsertOrderStatusHistory(ctx, &sqlc.InsertOrderStatusHistoryParams{
+		OrderID: orderID,
+		Name:    string(order.Status),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create order status history: %w", err)
+	}
+
+	return models.OID(orderID), nil
+}
+
+// createOrderEvent writes event of order status to outbox of shard within transaction.
+func createOrderEvent(ctx context.Context, q *sqlc.Queries, eventType string, event models.OrderEvent) error {
+	event.Time = time.Now()
+	_, err := repo_outbox.InsertOrderEvent(ctx, q, eventType, event)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox event: %w", err)
+	}
+	return nil
+}
+
+// withBucketShard runs fn on owner shard of bucket, fn is run again on new owner if bucket was moved.
+func (r *OrderRepository) withBucketShard(ctx context.Context, bucket shard_manager.Bucket, fn func(pool *pgxpool.Pool) error) error {
+	return r.withBucket(ctx, bucket, func(shard shard_manager.ShardIndex) error {
+		pool, err := r.shardManager.GetShard(shard)
+		if err != nil {
+			return err
+		}
+		return fn(pool)
+	})
+}
+
+// withBucketReader runs read-only fn on replica of owner shard of bucket with fallback to primary,
+// fn is run again on new owner if bucket was moved.
+func (r *OrderRepository) withBucketReader(ctx context.Context, bucket shard_manager.Bucket, fn func(pool *pgxpool.Pool) error) error {
+	return r.withBucket(ctx, bucket, func(shard shard_manager.ShardIndex) error {
+		reader, err := r.shardManager.GetReader(shard)
+		if err != nil {
+			return err
+		}
+		return reader.Read(ctx, fn)
+	})
+}
+
+// withBucket runs fn with owner shard of bucket, fn is run again with new owner if bucket was moved.
+// Result of fn is reported to circuit of shard unless request was canceled by caller.
+func (r *OrderRepository) withBucket(ctx context.Context, bucket shard_manager.Bucket, fn func(shard shard_manager.ShardIndex) error) error {
+	for attempt := 0; ; attempt++ {
+		shard := r.shardManager.GetBucketShard(bucket)
+		if err := r.shardManager.CheckAvailable(shard); err != nil {
+			return err
+		}
+
+		err := fn(shard)
+		var moved errBucketMoved
+		if !errors.As(err, &moved) {
+			if ctx.Err() == nil {
+				r.shardManager.ReportResult(shard, shardFailure(err))
+			}
+			return err
+		}
+		if attempt == bucketMovedRetries {
+			return err
+		}
+		r.shardManager.SetBucketShard(bucket, moved.shard)
+	}
+}
+
+// shardFailure returns err if it is failure of shard, errors about data of request mean shard has answered.
+func shardFailure(err error) error {
+	for _, answered := range []error{
+		internal_errors.ErrBadRequest,
+		internal_errors.ErrNotFound,
+		internal_errors.ErrPreconditionFailed,
+		internal_errors.ErrInvalidOrderStatus,
+		internal_errors.ErrIdempotencyKeyExists,
+		internal_errors.ErrIdempotencyKeyReused,
+	} {
+		if errors.Is(err, answered) {
+			return nil
+		}
+	}
+	return err
+}
+
+// lockBucket locks bucket for transaction on shard, so bucket is not moved until commit,
+// and checks shard still owns bucket.
+func lockBucket(ctx context.Context, q *sqlc.Queries, bucket shard_manager.Bucket) error {
+	if err := q.LockShardBucketShared(ctx, int32(bucket)); err != nil {
+		return fmt.Errorf("failed to lock bucket: %w", err)
+	}
+	return checkBucketMoved(ctx, q, bucket)
+}
+
+// checkBucketMoved returns errBucketMoved if bucket was moved from shard.
+func checkBucketMoved(ctx context.Context, q *sqlc.Queries, bucket shard_manager.Bucket) error {
+	state, err := q.GetShardBucketState(ctx, int32(bucket))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get bucket state: %w", err)
+	}
+	if state.State == bucketStateMoved {
+		return errBucketMoved{shard: shard_manager.ShardIndex(state.Shard)}
+	}
+	return nil
+}
+
+// toModelItems converts items of order from database.
+func toModelItems(items []*sqlc.Item) []models.Item {
+	var modelItems []models.Item
+	for _, item := range items {
+		modelItems = append(modelItems, models.Item{
+			SKU:   models.SKU(item.Sku),
+			Count: uint16(item.Count),
+		})
+	}
+	return modelItems
 }
 
 // validateOrder validate the order.
@@ -281,11 +821,19 @@ func setMetrics(operation string, startTime time.Time) {
 	metrics.ObserveDBQueryDuration(operation, duration)
 }
 
-// processShard processes one shard.
-func (r *OrderRepository) processShard(ctx context.Context, pool *pgxpool.Pool, allOrders chan<- models.Order) error {
-	q := sqlc.New(pool)
-
-	orders, err := q.GetAllOrders(ctx)
+// processShard reads one page of orders from shard and streams them sorted by orderID desc.
+func (r *OrderRepository) processShard(ctx context.Context, reader *replica.Pool, params *sqlc.GetOrdersPageParams, out chan<- models.Order) error {
+	// Items are read from pool which returned page, page is sent only once
+	var (
+		q      *sqlc.Queries
+		orders []*sqlc.GetOrdersPageRow
+	)
+	err := reader.Read(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		q = sqlc.New(pool)
+		orders, err = q.GetOrdersPage(ctx, params)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get orders from shard: %w", err)
 	}
@@ -295,18 +843,88 @@ func (r *OrderRepository) processShard(ctx context.Context, pool *pgxpool.Pool,
 		if err != nil {
 			return err
 		}
-		allOrders <- modelOrder
+
+		select {
+		case out <- modelOrder:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 
 	return nil
 }
 
+// toOrdersPageParams converts filter to query params, empty filter fields are not applied.
+func toOrdersPageParams(filter models.OrderFilter, limit uint32) *sqlc.GetOrdersPageParams {
+	params := &sqlc.GetOrdersPageParams{
+		PageLimit: int32(limit),
+	}
+
+	if filter.AfterID > 0 {
+		params.AfterID = &filter.AfterID
+	}
+	if filter.UserID > 0 {
+		params.UserID = &filter.UserID
+	}
+	if len(filter.Statuses) > 0 {
+		params.Statuses = make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			params.Statuses[i] = string(status)
+		}
+	}
+	if !filter.CreatedFrom.IsZero() {
+		params.CreatedFrom = pgtype.Timestamptz{Time: filter.CreatedFrom, Valid: true}
+	}
+	if !filter.CreatedTo.IsZero() {
+		params.CreatedTo = pgtype.Timestamptz{Time: filter.CreatedTo, Valid: true}
+	}
+
+	return params
+}
+
+// mergeOrderStreams merges shard streams sorted by orderID desc and stops once limit orders are taken.
+func mergeOrderStreams(streams []chan models.Order, limit int) []models.Order {
+	heads := make([]*models.Order, len(streams))
+	for i, stream := range streams {
+		heads[i] = nextOrder(stream)
+	}
+
+	orders := make([]models.Order, 0, limit)
+	for len(orders) < limit {
+		next := -1
+		for i, head := range heads {
+			if head != nil && (next == -1 || head.OrderID > heads[next].OrderID) {
+				next = i
+			}
+		}
+		if next == -1 {
+			break
+		}
+
+		orders = append(orders, *heads[next])
+		heads[next] = nextOrder(streams[next])
+	}
+
+	return orders
+}
+
+// nextOrder reads next order from stream, returns nil if stream is closed.
+func nextOrder(stream <-chan models.Order) *models.Order {
+	order, ok := <-stream
+	if !ok {
+		return nil
+	}
+	return &order
+}
+
 // buildModelOrder build order model.
-func (r *OrderRepository) buildModelOrder(ctx context.Context, q *sqlc.Queries, order *sqlc.GetAllOrdersRow) (models.Order, error) {
+func (r *OrderRepository) buildModelOrder(ctx context.Context, q *sqlc.Queries, order *sqlc.GetOrdersPageRow) (models.Order, error) {
 	modelOrder := models.Order{
-		OrderID: order.ID,
-		Status:  models.OrderStatus(order.Status),
-		UserID:  order.UserID,
+		OrderID:   order.ID,
+		Status:    models.OrderStatus(order.Status),
+		UserID:    order.UserID,
+		CreatedAt: order.CreatedAt.Time,
+		UpdatedAt: order.UpdatedAt.Time,
 	}
 
 	items, err := q.GetOrderItems(ctx, &order.ID)
@@ -314,12 +932,7 @@ func (r *OrderRepository) buildModelOrder(ctx context.Context, q *sqlc.Queries,
 		return models.Order{}, fmt.Errorf("failed to get items for order %d: %w", order.ID, err)
 	}
 
-	for _, item := range items {
-		modelOrder.Items = append(modelOrder.Items, models.Item{
-			SKU:   models.SKU(item.Sku),
-			Count: uint16(item.Count),
-		})
-	}
+	modelOrder.Items = toModelItems(items)
 
 	return modelOrder, nil
 }
@@ -3,27 +3,84 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/shard_manager"
 	"route256/loms/internal/repository/sqlc"
+	"route256/utils/tracer"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// outboxChannel is notified by trigger on insert into outbox.
+const outboxChannel = "outbox"
+
+type IShardManager interface {
+	GetShardIndexFromID(id int64) shard_manager.ShardIndex
+	GetShard(shard_manager.ShardIndex) (*pgxpool.Pool, error)
+	GetShards() []*pgxpool.Pool
+}
+
+// OutboxRepository works with outbox of main database and outboxes of order shards.
 type OutboxRepository struct {
-	queries sqlc.Querier
-	pool    *pgxpool.Pool
+	queries      sqlc.Querier
+	pool         *pgxpool.Pool
+	shardManager IShardManager
 }
 
-// NewOutboxRepository creates new instance of OutboxRepository.
-func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+// NewOutboxRepository creates new instance of OutboxRepository, shardManager may be nil
+// when only outbox of main database is used.
+func NewOutboxRepository(pool *pgxpool.Pool, shardManager IShardManager) *OutboxRepository {
 	return &OutboxRepository{
-		queries: sqlc.New(pool),
-		pool:    pool,
+		queries:      sqlc.New(pool),
+		pool:         pool,
+		shardManager: shardManager,
+	}
+}
+
+// Shards returns all databases holding outbox, main database goes first.
+func (r *OutboxRepository) Shards() []models.OutboxShard {
+	shards := []models.OutboxShard{models.OutboxShardMain}
+	if r.shardManager == nil {
+		return shards
+	}
+	for i := range r.shardManager.GetShards() {
+		shards = append(shards, models.OutboxShard(i))
 	}
+	return shards
 }
 
-// CreateEvent inserts new outbox event into database.
+// WithShardTx performs a function inside a transaction on database of outbox shard.
+func (r *OutboxRepository) WithShardTx(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) (err error) {
+	pool, err := r.getPool(shard)
+	if err != nil {
+		return err
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		} else if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return fn(ctx, tx)
+}
+
+// CreateEvent inserts new outbox event into database with trace of request in ctx.
 func (r *OutboxRepository) CreateEvent(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -33,35 +90,353 @@ func (r *OutboxRepository) CreateEvent(ctx context.Context, tx pgx.Tx, eventType
 	q := r.getQuerier(tx)
 
 	_, err = q.InsertOutboxEvent(ctx, &sqlc.InsertOutboxEventParams{
-		EventType: eventType,
-		Payload:   string(payloadBytes),
+		EventType:   eventType,
+		Payload:     string(payloadBytes),
+		TraceParent: tracer.TraceParent(ctx),
 	})
 
 	return err
 }
 
-// FetchNextMsg retrieves next unprocessed outbox event from database.
-func (r *OutboxRepository) FetchNextMsg(ctx context.Context, tx pgx.Tx) (*models.OutboxEvent, error) {
+// InsertOrderEvent inserts event of order with next sequence number of order using q,
+// so event is written in transaction of order changes. Event without ID gets new unique ID,
+// trace of request in ctx is stored with event to be continued by consumers.
+func InsertOrderEvent(ctx context.Context, q sqlc.Querier, eventType string, event models.OrderEvent) (int64, error) {
+	if event.EventID == "" {
+		event.EventID = uuid.NewString()
+	}
+
+	payloadBytes, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	// Sequence is taken and written to payload in the same statement, so failed insert leaves no gap
+	sequence, err := q.InsertOrderOutboxEvent(ctx, &sqlc.InsertOrderOutboxEventParams{
+		OrderID:     event.OrderID,
+		EventType:   eventType,
+		Payload:     payloadBytes,
+		TraceParent: tracer.TraceParent(ctx),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if sequence == nil {
+		return 0, nil
+	}
+
+	return *sequence, nil
+}
+
+// FetchBatch locks up to limit unprocessed outbox events, rows locked by other relays are skipped.
+// Event of order is not returned until previous events of the same order are processed.
+func (r *OutboxRepository) FetchBatch(ctx context.Context, tx pgx.Tx, limit uint32) ([]models.OutboxEvent, error) {
 	q := r.getQuerier(tx)
 
-	row, err := q.FetchNextOutboxEvent(ctx)
+	rows, err := q.FetchOutboxEvents(ctx, int32(limit))
 	if err != nil {
 		return nil, err
 	}
 
-	event := &models.OutboxEvent{
-		ID:        int64(row.ID),
-		EventType: row.EventType,
-		Payload:   row.Payload,
+	events := make([]models.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		event := models.OutboxEvent{
+			ID:          int64(row.ID),
+			EventType:   row.EventType,
+			Payload:     row.Payload,
+			State:       models.OutboxEventStatePending,
+			Attempts:    row.Attempts,
+			TraceParent: row.TraceParent,
+		}
+		if row.OrderID != nil {
+			event.OrderID = *row.OrderID
+		}
+		if row.Sequence != nil {
+			event.Sequence = *row.Sequence
+		}
+		events = append(events, event)
 	}
 
-	return event, nil
+	return events, nil
+}
+
+// MarkAsSent marks outbox events as processed in database.
+func (r *OutboxRepository) MarkAsSent(ctx context.Context, tx pgx.Tx, eventIDs []int64) error {
+	q := r.getQuerier(tx)
+	return q.MarkOutboxEventsAsProcessed(ctx, toOutboxIDs(eventIDs))
 }
 
-// MarkAsSent marks outbox event as processed in database.
-func (r *OutboxRepository) MarkAsSent(ctx context.Context, tx pgx.Tx, eventID int64) error {
+// MarkAsFailed records failed delivery attempt of outbox event, event is moved to state
+// when it should not be sent anymore or is retried after nextAttemptAt.
+func (r *OutboxRepository) MarkAsFailed(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) error {
 	q := r.getQuerier(tx)
-	return q.MarkOutboxEventAsProcessed(ctx, int32(eventID))
+	return q.RecordOutboxEventFailure(ctx, &sqlc.RecordOutboxEventFailureParams{
+		ID:            int32(eventID),
+		LastError:     sendErr.Error(),
+		NextAttemptAt: pgtype.Timestamptz{Time: nextAttemptAt, Valid: true},
+		State:         string(state),
+	})
+}
+
+// DeleteProcessed deletes events of outbox shard processed before processedBefore and returns count of deleted events.
+func (r *OutboxRepository) DeleteProcessed(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) (int64, error) {
+	pool, err := r.getPool(shard)
+	if err != nil {
+		return 0, err
+	}
+
+	return sqlc.New(pool).DeleteProcessedOutboxEvents(ctx, pgtype.Timestamptz{Time: processedBefore, Valid: true})
+}
+
+// List returns up to limit events of outbox shard matching filter ordered by ID.
+func (r *OutboxRepository) List(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) ([]models.OutboxEvent, error) {
+	pool, err := r.getPool(shard)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &sqlc.ListOutboxEventsParams{
+		Ids:         toOutboxIDs(filter.IDs),
+		States:      toOutboxStates(filter.States),
+		CreatedFrom: toTimestamptz(filter.CreatedFrom),
+		CreatedTo:   toTimestamptz(filter.CreatedTo),
+		PageLimit:   int32(limit),
+	}
+	if filter.AfterID > 0 {
+		afterID := int32(filter.AfterID)
+		params.AfterID = &afterID
+	}
+
+	rows, err := sqlc.New(pool).ListOutboxEvents(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]models.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		event := models.OutboxEvent{
+			ID:            int64(row.ID),
+			Shard:         shard,
+			EventType:     row.EventType,
+			Payload:       row.Payload,
+			State:         models.OutboxEventState(row.State),
+			Attempts:      row.Attempts,
+			LastError:     row.LastError,
+			NextAttemptAt: row.NextAttemptAt.Time,
+			CreatedAt:     row.CreatedAt.Time,
+			ProcessedAt:   row.ProcessedAt.Time,
+		}
+		if row.OrderID != nil {
+			event.OrderID = *row.OrderID
+		}
+		if row.Sequence != nil {
+			event.Sequence = *row.Sequence
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// Reset queues events of outbox shard in fromStates matching filter for sending again and returns count of queued events.
+func (r *OutboxRepository) Reset(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) (int64, error) {
+	pool, err := r.getPool(shard)
+	if err != nil {
+		return 0, err
+	}
+
+	return sqlc.New(pool).ResetOutboxEvents(ctx, &sqlc.ResetOutboxEventsParams{
+		FromStates:  toOutboxStates(fromStates),
+		Ids:         toOutboxIDs(filter.IDs),
+		CreatedFrom: toTimestamptz(filter.CreatedFrom),
+		CreatedTo:   toTimestamptz(filter.CreatedTo),
+	})
+}
+
+// MoveMainOrderEvents moves up to limit sequences of order events left in main database by previous
+// version of service to shards of orders together with undelivered events of these orders,
+// returns count of moved sequences, zero means nothing is left in main database.
+// Moved event is not copied twice if it is already on shard, so failed move can be repeated.
+func (r *OutboxRepository) MoveMainOrderEvents(ctx context.Context, limit uint32) (int64, error) {
+	if r.shardManager == nil {
+		return 0, fmt.Errorf("%w: shards are not configured", internal_errors.ErrShardIndexOutOfRange)
+	}
+
+	var moved int64
+	err := r.WithShardTx(ctx, models.OutboxShardMain, func(ctx context.Context, tx pgx.Tx) error {
+		q := sqlc.New(tx)
+
+		sequences, err := q.ListOrderEventSequences(ctx, int32(limit))
+		if err != nil {
+			return fmt.Errorf("failed to list order event sequences: %w", err)
+		}
+		if len(sequences) == 0 {
+			return nil
+		}
+
+		orderIDs := make([]int64, 0, len(sequences))
+		for _, sequence := range sequences {
+			orderIDs = append(orderIDs, sequence.OrderID)
+		}
+
+		events, err := q.ListUndeliveredOrderOutboxEvents(ctx, orderIDs)
+		if err != nil {
+			return fmt.Errorf("failed to list undelivered order events: %w", err)
+		}
+
+		// Sequences and events are grouped by shard of order
+		shardSequences := make(map[shard_manager.ShardIndex][]*sqlc.OrderEventSequence)
+		for _, sequence := range sequences {
+			shard := r.shardManager.GetShardIndexFromID(sequence.OrderID)
+			shardSequences[shard] = append(shardSequences[shard], sequence)
+		}
+		shardEvents := make(map[shard_manager.ShardIndex][]*sqlc.ListUndeliveredOrderOutboxEventsRow)
+		for _, event := range events {
+			if event.OrderID == nil || event.Sequence == nil {
+				continue
+			}
+			shard := r.shardManager.GetShardIndexFromID(*event.OrderID)
+			shardEvents[shard] = append(shardEvents[shard], event)
+		}
+
+		for shard, sequences := range shardSequences {
+			err := r.WithShardTx(ctx, models.OutboxShard(shard), func(ctx context.Context, shardTx pgx.Tx) error {
+				return copyOrderEvents(ctx, sqlc.New(shardTx), sequences, shardEvents[shard])
+			})
+			if err != nil {
+				return fmt.Errorf("failed to copy order events to shard %d: %w", shard, err)
+			}
+		}
+
+		// Rows are deleted from main database only after all shards committed copies
+		if err := q.DeleteUndeliveredOrderOutboxEvents(ctx, orderIDs); err != nil {
+			return fmt.Errorf("failed to delete undelivered order events: %w", err)
+		}
+		if err := q.DeleteOrderEventSequences(ctx, orderIDs); err != nil {
+			return fmt.Errorf("failed to delete order event sequences: %w", err)
+		}
+
+		moved = int64(len(sequences))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
+
+// copyOrderEvents copies sequences and undelivered events of orders to shard with q,
+// sequence on shard is not decreased if shard already has events of order.
+func copyOrderEvents(ctx context.Context, q sqlc.Querier, sequences []*sqlc.OrderEventSequence, events []*sqlc.ListUndeliveredOrderOutboxEventsRow) error {
+	for _, sequence := range sequences {
+		err := q.CopyBucketOrderEventSequence(ctx, &sqlc.CopyBucketOrderEventSequenceParams{
+			OrderID:      sequence.OrderID,
+			LastSequence: sequence.LastSequence,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		err := q.CopyOrderOutboxEvent(ctx, &sqlc.CopyOrderOutboxEventParams{
+			EventType:     event.EventType,
+			Payload:       event.Payload,
+			OrderID:       *event.OrderID,
+			Sequence:      *event.Sequence,
+			State:         event.State,
+			Attempts:      event.Attempts,
+			LastError:     event.LastError,
+			NextAttemptAt: event.NextAttemptAt,
+			TraceParent:   event.TraceParent,
+			CreatedAt:     event.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListenNewEvents sends to wakeup on each notification about new events in outbox shard until ctx is done.
+func (r *OutboxRepository) ListenNewEvents(ctx context.Context, shard models.OutboxShard, wakeup chan<- struct{}) error {
+	pool, err := r.getPool(shard)
+	if err != nil {
+		return err
+	}
+
+	pooledConn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	// Connection with LISTEN is not returned to pool
+	conn := pooledConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+outboxChannel); err != nil {
+		return fmt.Errorf("failed to listen outbox channel: %w", err)
+	}
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+
+		// Relay is already woken up if wakeup is full
+		select {
+		case wakeup <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// toOutboxIDs converts event IDs to query parameter, empty list means no filter.
+func toOutboxIDs(eventIDs []int64) []int32 {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	ids := make([]int32, len(eventIDs))
+	for i, id := range eventIDs {
+		ids[i] = int32(id)
+	}
+	return ids
+}
+
+// toOutboxStates converts states to query parameter, empty list means no filter.
+func toOutboxStates(states []models.OutboxEventState) []string {
+	if len(states) == 0 {
+		return nil
+	}
+	res := make([]string, len(states))
+	for i, state := range states {
+		res[i] = string(state)
+	}
+	return res
+}
+
+// toTimestamptz converts time to query parameter, zero time means no filter.
+func toTimestamptz(t time.Time) pgtype.Timestamptz {
+	if t.IsZero() {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+// getPool returns connection pool of outbox shard.
+func (r *OutboxRepository) getPool(shard models.OutboxShard) (*pgxpool.Pool, error) {
+	if shard == models.OutboxShardMain {
+		return r.pool, nil
+	}
+	if r.shardManager == nil || shard < 0 {
+		return nil, fmt.Errorf("%w: given index=%d", internal_errors.ErrShardIndexOutOfRange, shard)
+	}
+	return r.shardManager.GetShard(shard_manager.ShardIndex(shard))
 }
 
 // getQuerier returns sqlc.Querier based on provided transaction.
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/metrics"
+	"time"
+
+	"route256/loms/internal/repository/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+)
+
+// SagaRepository stores sagas in the same database as stocks and outbox,
+// so saga state changes are committed atomically with stock changes.
+type SagaRepository struct {
+	queries sqlc.Querier
+	pool    *pgxpool.Pool
+}
+
+// NewSagaRepository creates a new instance of SagaRepository.
+func NewSagaRepository(pool *pgxpool.Pool) *SagaRepository {
+	return &SagaRepository{
+		queries: sqlc.New(pool),
+		pool:    pool,
+	}
+}
+
+// Create inserts started saga, ErrSagaInProgress is returned if order already has started saga.
+func (r *SagaRepository) Create(ctx context.Context, tx pgx.Tx, saga models.Saga) (int64, error) {
+	// Tracer
+	ctx, span := otel.Tracer("SagaRepository").Start(ctx, "Create")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("Create", startTime)
+
+	items, err := json.Marshal(saga.Items)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal saga items: %w", err)
+	}
+
+	q := r.getQuerier(tx)
+
+	sagaID, err := q.CreateSaga(ctx, &sqlc.CreateSagaParams{
+		OrderID:    saga.OrderID,
+		Type:       string(saga.Type),
+		FromStatus: string(saga.FromStatus),
+		ToStatus:   string(saga.ToStatus),
+		EventType:  saga.EventType,
+		Items:      items,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("order %d: %w", saga.OrderID, internal_errors.ErrSagaInProgress)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to create saga: %w", err)
+	}
+
+	return sagaID, nil
+}
+
+// SetState finishes started saga, ErrSagaFinished is returned if saga was finished before.
+func (r *SagaRepository) SetState(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+	// Tracer
+	ctx, span := otel.Tracer("SagaRepository").Start(ctx, "SetState")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("SetState", startTime)
+
+	q := r.getQuerier(tx)
+
+	rows, err := q.SetSagaState(ctx, &sqlc.SetSagaStateParams{
+		ID:    sagaID,
+		State: string(state),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set saga state: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("saga %d: %w", sagaID, internal_errors.ErrSagaFinished)
+	}
+
+	return nil
+}
+
+// RecordAttempt increments attempts of saga and stores error of last attempt.
+func (r *SagaRepository) RecordAttempt(ctx context.Context, sagaID int64, lastErr error) error {
+	// Tracer
+	ctx, span := otel.Tracer("SagaRepository").Start(ctx, "RecordAttempt")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("RecordAttempt", startTime)
+
+	var lastError string
+	if lastErr != nil {
+		lastError = lastErr.Error()
+	}
+
+	err := r.queries.RecordSagaAttempt(ctx, &sqlc.RecordSagaAttemptParams{
+		ID:        sagaID,
+		LastError: lastError,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record saga attempt: %w", err)
+	}
+
+	return nil
+}
+
+// GetStarted returns started sagas not updated since updatedBefore.
+func (r *SagaRepository) GetStarted(ctx context.Context, updatedBefore time.Time, limit uint32) ([]models.Saga, error) {
+	// Tracer
+	ctx, span := otel.Tracer("SagaRepository").Start(ctx, "GetStarted")
+	defer span.End()
+
+	startTime := time.Now()
+	defer setMetrics("GetStarted", startTime)
+
+	rows, err := r.queries.GetStartedSagas(ctx, &sqlc.GetStartedSagasParams{
+		UpdatedAt: pgtype.Timestamptz{Time: updatedBefore, Valid: true},
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get started sagas: %w", err)
+	}
+
+	sagas := make([]models.Saga, 0, len(rows))
+	for _, row := range rows {
+		var items []models.Item
+		if err := json.Unmarshal(row.Items, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal items of saga %d: %w", row.ID, err)
+		}
+
+		sagas = append(sagas, models.Saga{
+			ID:         row.ID,
+			OrderID:    row.OrderID,
+			Type:       models.SagaType(row.Type),
+			FromStatus: models.OrderStatus(row.FromStatus),
+			ToStatus:   models.OrderStatus(row.ToStatus),
+			EventType:  row.EventType,
+			Items:      items,
+			State:      models.SagaStateStarted,
+			Attempts:   row.Attempts,
+		})
+	}
+
+	return sagas, nil
+}
+
+// setMetrics set metrics of operation.
+func setMetrics(operation string, startTime time.Time) {
+	duration := time.Since(startTime)
+	metrics.IncDBQueryCounter(operation)
+	metrics.ObserveDBQueryDuration(operation, duration)
+}
+
+// getQuerier returns sqlc.Querier based on provided transaction.
+func (r *SagaRepository) getQuerier(tx pgx.Tx) sqlc.Querier {
+	if tx != nil {
+		return sqlc.New(tx)
+	}
+	return r.queries
+}
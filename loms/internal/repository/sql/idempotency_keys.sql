@@ -0,0 +1,18 @@
+-- name: GetIdempotencyKey :one
+SELECT request_hash, order_id
+FROM idempotency_keys
+WHERE user_id = $1 AND key = $2 AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: InsertIdempotencyKey :execrows
+INSERT INTO idempotency_keys (user_id, key, request_hash, order_id, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    order_id = EXCLUDED.order_id,
+    created_at = CURRENT_TIMESTAMP,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= CURRENT_TIMESTAMP;
\ No newline at end of file
@@ -4,16 +4,40 @@ VALUES (nextval('order_id_manual_seq') + $1, $2, (SELECT id FROM statuses st WHE
 RETURNING id;
 
 -- name: GetOrderByID :one
-SELECT o.id, o.user_id, s.name AS status, o.created_at
+SELECT o.id, o.user_id, s.name AS status, o.created_at, o.updated_at
 FROM orders o
 JOIN statuses s ON o.status_id = s.id
 WHERE o.id = $1;
 
+-- name: GetOrderByIDForUpdate :one
+SELECT o.id, o.user_id, s.name AS status
+FROM orders o
+JOIN statuses s ON o.status_id = s.id
+WHERE o.id = $1
+FOR UPDATE OF o;
+
 -- name: SetOrderStatus :exec
 UPDATE orders
-SET status_id = (SELECT id FROM statuses st WHERE st.name = $2)
+SET status_id = (SELECT id FROM statuses st WHERE st.name = $2), updated_at = CURRENT_TIMESTAMP
 WHERE orders.id = $1;
 
+-- name: CompareAndSetOrderStatus :execrows
+UPDATE orders
+SET status_id = (SELECT id FROM statuses st WHERE st.name = sqlc.arg('status')), updated_at = CURRENT_TIMESTAMP
+WHERE orders.id = sqlc.arg('id')
+  AND orders.status_id = (SELECT id FROM statuses st WHERE st.name = sqlc.arg('expected_status'));
+
+-- name: InsertOrderStatusHistory :exec
+INSERT INTO order_status_history (order_id, status_id)
+VALUES ($1, (SELECT id FROM statuses st WHERE st.name = $2));
+
+-- name: GetOrderStatusHistory :many
+SELECT s.name AS status, h.created_at
+FROM order_status_history h
+JOIN statuses s ON h.status_id = s.id
+WHERE h.order_id = $1
+ORDER BY h.created_at, h.id;
+
 -- name: CreateOrderItem :one
 INSERT INTO items (order_id, sku, count)
 VALUES ($1, $2, $3)
@@ -24,8 +48,33 @@ SELECT id, order_id, sku, count
 FROM items
 WHERE order_id = $1;
 
--- name: GetAllOrders :many
-SELECT o.id, o.user_id, s.name AS status, o.created_at
+-- name: GetOrdersPage :many
+SELECT o.id, o.user_id, s.name AS status, o.created_at, o.updated_at
+FROM orders o
+JOIN statuses s ON o.status_id = s.id
+WHERE (sqlc.narg('after_id')::BIGINT IS NULL OR o.id < sqlc.narg('after_id')::BIGINT)
+  AND (sqlc.narg('user_id')::BIGINT IS NULL OR o.user_id = sqlc.narg('user_id')::BIGINT)
+  AND (sqlc.narg('statuses')::TEXT[] IS NULL OR s.name = ANY(sqlc.narg('statuses')::TEXT[]))
+  AND (sqlc.narg('created_from')::TIMESTAMPTZ IS NULL OR o.created_at >= sqlc.narg('created_from')::TIMESTAMPTZ)
+  AND (sqlc.narg('created_to')::TIMESTAMPTZ IS NULL OR o.created_at < sqlc.narg('created_to')::TIMESTAMPTZ)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM shard_bucket_states sbs
+    WHERE sbs.bucket = o.id % sqlc.arg('bucket_count')::BIGINT
+  )
+ORDER BY o.id DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: GetExpiredOrders :many
+SELECT o.id, o.created_at
 FROM orders o
 JOIN statuses s ON o.status_id = s.id
-ORDER BY o.id DESC;
\ No newline at end of file
+WHERE s.name = 'awaiting payment'
+  AND o.created_at < CURRENT_TIMESTAMP - sqlc.arg('payment_timeout')::INTERVAL
+  AND NOT EXISTS (
+    SELECT 1
+    FROM shard_bucket_states sbs
+    WHERE sbs.bucket = o.id % sqlc.arg('bucket_count')::BIGINT
+  )
+ORDER BY o.created_at ASC
+LIMIT sqlc.arg('batch_limit');
\ No newline at end of file
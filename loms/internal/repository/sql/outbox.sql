@@ -1,17 +1,102 @@
 -- name: InsertOutboxEvent :one
-INSERT INTO outbox (event_type, payload)
-VALUES ($1, $2)
-RETURNING id, event_type, payload, created_at, processed;
+INSERT INTO outbox (event_type, payload, trace_parent)
+VALUES ($1, $2, $3)
+RETURNING id;
 
--- name: FetchNextOutboxEvent :one
-SELECT id, event_type, payload
+-- name: InsertOrderOutboxEvent :one
+WITH seq AS (
+    INSERT INTO order_event_sequences (order_id, last_sequence)
+    VALUES (sqlc.arg(order_id), 1)
+    ON CONFLICT (order_id) DO UPDATE
+    SET last_sequence = order_event_sequences.last_sequence + 1
+    RETURNING last_sequence
+)
+INSERT INTO outbox (event_type, payload, order_id, sequence, trace_parent)
+SELECT sqlc.arg(event_type), jsonb_set(sqlc.arg(payload)::jsonb, '{sequence}', to_jsonb(seq.last_sequence))::text, sqlc.arg(order_id), seq.last_sequence, sqlc.arg(trace_parent)
+FROM seq
+RETURNING sequence;
+
+-- name: FetchOutboxEvents :many
+SELECT id, event_type, payload, order_id, sequence, attempts, trace_parent
 FROM outbox
-WHERE processed = FALSE
-ORDER BY created_at
-LIMIT 1
+WHERE state = 'pending'
+  AND next_attempt_at <= CURRENT_TIMESTAMP
+  AND NOT EXISTS (
+    SELECT 1
+    FROM outbox prev
+    WHERE prev.order_id = outbox.order_id
+      AND prev.state = 'pending'
+      AND prev.sequence < outbox.sequence
+  )
+ORDER BY created_at, id
+LIMIT $1
 FOR UPDATE SKIP LOCKED;
 
--- name: MarkOutboxEventAsProcessed :exec
+-- name: MarkOutboxEventsAsProcessed :exec
+UPDATE outbox
+SET state = 'processed', processed_at = CURRENT_TIMESTAMP, last_error = ''
+WHERE id = ANY($1::int[]);
+
+-- name: RecordOutboxEventFailure :exec
+UPDATE outbox
+SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3, state = $4
+WHERE id = $1;
+
+-- name: DeleteProcessedOutboxEvents :execrows
+DELETE FROM outbox
+WHERE state = 'processed' AND processed_at < $1;
+
+-- name: ListOutboxEvents :many
+SELECT id, event_type, payload, order_id, sequence, state, attempts, last_error, next_attempt_at, processed_at, created_at
+FROM outbox
+WHERE (sqlc.narg('after_id')::INT IS NULL OR id > sqlc.narg('after_id')::INT)
+  AND (sqlc.narg('ids')::INT[] IS NULL OR id = ANY(sqlc.narg('ids')::INT[]))
+  AND (sqlc.narg('states')::TEXT[] IS NULL OR state = ANY(sqlc.narg('states')::TEXT[]))
+  AND (sqlc.narg('created_from')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('created_from')::TIMESTAMPTZ)
+  AND (sqlc.narg('created_to')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('created_to')::TIMESTAMPTZ)
+ORDER BY id
+LIMIT sqlc.arg('page_limit');
+
+-- name: ResetOutboxEvents :execrows
 UPDATE outbox
-SET processed = TRUE
-WHERE id = $1;
\ No newline at end of file
+SET state = 'pending', attempts = 0, last_error = '', next_attempt_at = CURRENT_TIMESTAMP, processed_at = NULL
+WHERE state = ANY(sqlc.arg('from_states')::TEXT[])
+  AND (sqlc.narg('ids')::INT[] IS NULL OR id = ANY(sqlc.narg('ids')::INT[]))
+  AND (sqlc.narg('created_from')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('created_from')::TIMESTAMPTZ)
+  AND (sqlc.narg('created_to')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('created_to')::TIMESTAMPTZ);
+
+-- name: ListOrderEventSequences :many
+SELECT order_id, last_sequence
+FROM order_event_sequences
+ORDER BY order_id
+LIMIT $1
+FOR UPDATE;
+
+-- name: ListUndeliveredOrderOutboxEvents :many
+SELECT event_type, payload, order_id, sequence, state, attempts, last_error, next_attempt_at, trace_parent, created_at
+FROM outbox
+WHERE order_id = ANY(sqlc.arg(order_ids)::BIGINT[])
+  AND state <> 'processed'
+ORDER BY id
+FOR UPDATE;
+
+-- name: CopyOrderOutboxEvent :exec
+INSERT INTO outbox (event_type, payload, order_id, sequence, state, attempts, last_error, next_attempt_at, trace_parent, created_at)
+SELECT sqlc.arg(event_type)::VARCHAR, sqlc.arg(payload)::TEXT, sqlc.arg(order_id)::BIGINT, sqlc.arg(sequence)::BIGINT,
+       sqlc.arg(state)::VARCHAR, sqlc.arg(attempts)::INT, sqlc.arg(last_error)::TEXT, sqlc.arg(next_attempt_at)::TIMESTAMPTZ,
+       sqlc.arg(trace_parent)::TEXT, sqlc.arg(created_at)::TIMESTAMPTZ
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM outbox
+    WHERE order_id = sqlc.arg(order_id)::BIGINT
+      AND sequence = sqlc.arg(sequence)::BIGINT
+);
+
+-- name: DeleteUndeliveredOrderOutboxEvents :exec
+DELETE FROM outbox
+WHERE order_id = ANY(sqlc.arg(order_ids)::BIGINT[])
+  AND state <> 'processed';
+
+-- name: DeleteOrderEventSequences :exec
+DELETE FROM order_event_sequences
+WHERE order_id = ANY(sqlc.arg(order_ids)::BIGINT[]);
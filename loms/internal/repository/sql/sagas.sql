@@ -0,0 +1,22 @@
+-- name: CreateSaga :one
+INSERT INTO sagas (order_id, type, from_status, to_status, event_type, items)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (order_id) WHERE state = 'started' DO NOTHING
+RETURNING id;
+
+-- name: SetSagaState :execrows
+UPDATE sagas
+SET state = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND state = 'started';
+
+-- name: RecordSagaAttempt :exec
+UPDATE sagas
+SET attempts = attempts + 1, last_error = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: GetStartedSagas :many
+SELECT id, order_id, type, from_status, to_status, event_type, items, attempts
+FROM sagas
+WHERE state = 'started' AND updated_at < $1
+ORDER BY id
+LIMIT $2;
@@ -0,0 +1,108 @@
+-- name: ListShardBuckets :many
+SELECT bucket, shard
+FROM shard_buckets
+ORDER BY bucket;
+
+-- name: InitShardBuckets :execrows
+INSERT INTO shard_buckets (bucket, shard)
+SELECT b, b % sqlc.arg(shard_count)::INT
+FROM generate_series(0, sqlc.arg(bucket_count)::INT - 1) AS b
+ON CONFLICT (bucket) DO NOTHING;
+
+-- name: SetShardBucketShard :exec
+UPDATE shard_buckets
+SET shard = $2, updated_at = CURRENT_TIMESTAMP
+WHERE bucket = $1;
+
+-- name: GetShardBucketState :one
+SELECT state, shard
+FROM shard_bucket_states
+WHERE bucket = $1;
+
+-- name: SetShardBucketState :exec
+INSERT INTO shard_bucket_states (bucket, state, shard)
+VALUES ($1, $2, $3)
+ON CONFLICT (bucket) DO UPDATE
+SET state = EXCLUDED.state, shard = EXCLUDED.shard, updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteShardBucketState :exec
+DELETE FROM shard_bucket_states
+WHERE bucket = $1;
+
+-- name: LockShardBucket :exec
+SELECT pg_advisory_xact_lock(256, sqlc.arg(bucket)::INT);
+
+-- name: LockShardBucketShared :exec
+SELECT pg_advisory_xact_lock_shared(256, sqlc.arg(bucket)::INT);
+
+-- name: ListBucketOrders :many
+SELECT id, user_id, status_id, created_at, updated_at
+FROM orders
+WHERE id % sqlc.arg(bucket_count)::BIGINT = sqlc.arg(bucket)::BIGINT
+ORDER BY id;
+
+-- name: ListBucketOrderItems :many
+SELECT order_id, sku, count
+FROM items
+WHERE order_id % sqlc.arg(bucket_count)::BIGINT = sqlc.arg(bucket)::BIGINT
+ORDER BY id;
+
+-- name: ListBucketOrderStatusHistory :many
+SELECT order_id, status_id, created_at
+FROM order_status_history
+WHERE order_id % sqlc.arg(bucket_count)::BIGINT = sqlc.arg(bucket)::BIGINT
+ORDER BY id;
+
+-- name: ListBucketIdempotencyKeys :many
+SELECT user_id, key, request_hash, order_id, created_at, expires_at
+FROM idempotency_keys
+WHERE order_id % sqlc.arg(bucket_count)::BIGINT = sqlc.arg(bucket)::BIGINT;
+
+-- name: ListBucketOrderEventSequences :many
+SELECT order_id, last_sequence
+FROM order_event_sequences
+WHERE order_id % sqlc.arg(bucket_count)::BIGINT = sqlc.arg(bucket)::BIGINT;
+
+-- name: CountBucketPendingOutboxEvents :one
+SELECT COUNT(*)
+FROM outbox
+WHERE state = 'pending'
+  AND order_id % sqlc.arg(bucket_count)::BIGINT = sqlc.arg(bucket)::BIGINT;
+
+-- name: CopyBucketOrder :exec
+INSERT INTO orders (id, user_id, status_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: CopyBucketOrderItem :exec
+INSERT INTO items (order_id, sku, count)
+VALUES ($1, $2, $3);
+
+-- name: CopyBucketOrderStatusHistory :exec
+INSERT INTO order_status_history (order_id, status_id, created_at)
+VALUES ($1, $2, $3);
+
+-- name: CopyBucketIdempotencyKey :exec
+INSERT INTO idempotency_keys (user_id, key, request_hash, order_id, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: CopyBucketOrderEventSequence :exec
+INSERT INTO order_event_sequences (order_id, last_sequence)
+VALUES ($1, $2)
+ON CONFLICT (order_id) DO UPDATE
+SET last_sequence = GREATEST(order_event_sequences.last_sequence, EXCLUDED.last_sequence);
+
+-- name: DeleteBucketOrders :execrows
+DELETE FROM orders
+WHERE id % sqlc.arg(bucket_count)::BIGINT = sqlc.arg(bucket)::BIGINT;
+
+-- name: DeleteBucketOrderEventSequences :exec
+DELETE FROM order_event_sequences
+WHERE order_id % sqlc.arg(bucket_count)::BIGINT = sqlc.arg(bucket)::BIGINT;
+
+-- name: GetOrderIDSequenceValue :one
+SELECT last_value
+FROM order_id_manual_seq;
+
+-- name: AdvanceOrderIDSequence :exec
+SELECT setval('order_id_manual_seq', GREATEST(last_value, sqlc.arg(value)::BIGINT))
+FROM order_id_manual_seq;
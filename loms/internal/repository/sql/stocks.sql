@@ -3,15 +3,38 @@ SELECT sku, total_count, reserved
 FROM stocks
 WHERE sku = $1;
 
+-- name: GetStocksBySKUs :many
+SELECT sku, total_count, reserved
+FROM stocks
+WHERE sku = ANY(sqlc.arg(skus)::int[])
+ORDER BY sku;
+
 -- name: GetAvailableStockBySKU :one
 SELECT total_count - reserved AS available
 FROM stocks
 WHERE sku = $1;
 
--- name: ReserveItems :exec
+-- name: ReserveItems :many
+WITH items AS (
+    SELECT unnest(sqlc.arg(skus)::int[]) AS sku, unnest(sqlc.arg(counts)::bigint[]) AS count
+), locked AS MATERIALIZED (
+    SELECT sku, total_count - reserved AS available
+    FROM stocks
+    WHERE sku = ANY(sqlc.arg(skus)::int[])
+    ORDER BY sku
+    FOR UPDATE
+)
 UPDATE stocks
-SET reserved = reserved + $2
-WHERE sku = $1;
+SET reserved = stocks.reserved + items.count
+FROM items
+WHERE stocks.sku = items.sku
+  AND (
+    SELECT COUNT(*)
+    FROM locked
+    JOIN items ON items.sku = locked.sku
+    WHERE locked.available >= items.count
+  ) = cardinality(sqlc.arg(skus)::int[])
+RETURNING stocks.sku, stocks.total_count, stocks.reserved;
 
 -- name: RemoveReservedItems :exec
 UPDATE stocks
@@ -21,4 +44,44 @@ WHERE sku = $1;
 -- name: CancelReservedItems :exec
 UPDATE stocks
 SET reserved = reserved - $2
-WHERE sku = $1;
\ No newline at end of file
+WHERE sku = $1;
+
+-- name: ReplenishStock :one
+INSERT INTO stocks (sku, total_count, reserved)
+VALUES ($1, $2, 0)
+ON CONFLICT (sku) DO UPDATE
+SET total_count = stocks.total_count + EXCLUDED.total_count
+RETURNING sku, total_count, reserved;
+
+-- name: SetStockTotalCount :one
+INSERT INTO stocks (sku, total_count, reserved)
+VALUES ($1, $2, 0)
+ON CONFLICT (sku) DO UPDATE
+SET total_count = EXCLUDED.total_count
+WHERE stocks.reserved <= EXCLUDED.total_count
+RETURNING sku, total_count, reserved;
+
+-- name: AdjustStockTotalCount :one
+UPDATE stocks
+SET total_count = total_count + $2
+WHERE sku = $1 AND total_count + $2 >= reserved
+RETURNING sku, total_count, reserved;
+-- name: GetStocksBySKUsForUpdate :many
+SELECT sku, total_count, reserved
+FROM stocks
+WHERE sku = ANY(sqlc.arg(skus)::int[])
+ORDER BY sku
+FOR UPDATE;
+
+-- name: ImportStock :one
+INSERT INTO stocks (sku, total_count, reserved)
+VALUES ($1, $2, 0)
+ON CONFLICT (sku) DO UPDATE
+SET total_count = EXCLUDED.total_count
+WHERE stocks.reserved <= EXCLUDED.total_count
+RETURNING sku, total_count, reserved;
+
+-- name: RestoreRemovedItems :exec
+UPDATE stocks
+SET reserved = reserved + $2, total_count = total_count + $2
+WHERE sku = $1;
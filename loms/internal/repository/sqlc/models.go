@@ -8,6 +8,15 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type IdempotencyKey struct {
+	UserID      int64
+	Key         string
+	RequestHash string
+	OrderID     int64
+	CreatedAt   pgtype.Timestamptz
+	ExpiresAt   pgtype.Timestamptz
+}
+
 type Item struct {
 	ID      int64
 	OrderID *int64
@@ -15,12 +24,17 @@ type Item struct {
 	Count   int16
 }
 
-type Outbox struct {
-	ID        int32
-	EventType string
-	Payload   string
+type Order struct {
+	ID        int64
+	UserID    int64
+	StatusID  int32
 	CreatedAt pgtype.Timestamptz
-	Processed *bool
+	UpdatedAt pgtype.Timestamptz
+}
+
+type OrderEventSequence struct {
+	OrderID      int64
+	LastSequence int64
 }
 
 type Stock struct {
@@ -11,6 +11,27 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const compareAndSetOrderStatus = `-- name: CompareAndSetOrderStatus :execrows
+UPDATE orders
+SET status_id = (SELECT id FROM statuses st WHERE st.name = $1), updated_at = CURRENT_TIMESTAMP
+WHERE orders.id = $2
+  AND orders.status_id = (SELECT id FROM statuses st WHERE st.name = $3)
+`
+
+type CompareAndSetOrderStatusParams struct {
+	Status         string
+	ID             int64
+	ExpectedStatus string
+}
+
+func (q *Queries) CompareAndSetOrderStatus(ctx context.Context, arg *CompareAndSetOrderStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, compareAndSetOrderStatus, arg.Status, arg.ID, arg.ExpectedStatus)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createOrder = `-- name: CreateOrder :one
 INSERT INTO orders (id, user_id, status_id)
 VALUES (nextval('order_id_manual_seq') + $1, $2, (SELECT id FROM statuses st WHERE st.name = $3))
@@ -49,34 +70,75 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg *CreateOrderItemParam
 	return id, err
 }
 
-const getAllOrders = `-- name: GetAllOrders :many
-SELECT o.id, o.user_id, s.name AS status, o.created_at
+const getOrderByID = `-- name: GetOrderByID :one
+SELECT o.id, o.user_id, s.name AS status, o.created_at, o.updated_at
 FROM orders o
 JOIN statuses s ON o.status_id = s.id
-ORDER BY o.id DESC
+WHERE o.id = $1
 `
 
-type GetAllOrdersRow struct {
+type GetOrderByIDRow struct {
 	ID        int64
 	UserID    int64
 	Status    string
 	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) GetOrderByID(ctx context.Context, id int64) (*GetOrderByIDRow, error) {
+	row := q.db.QueryRow(ctx, getOrderByID, id)
+	var i GetOrderByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const getOrderByIDForUpdate = `-- name: GetOrderByIDForUpdate :one
+SELECT o.id, o.user_id, s.name AS status
+FROM orders o
+JOIN statuses s ON o.status_id = s.id
+WHERE o.id = $1
+FOR UPDATE OF o
+`
+
+type GetOrderByIDForUpdateRow struct {
+	ID     int64
+	UserID int64
+	Status string
+}
+
+func (q *Queries) GetOrderByIDForUpdate(ctx context.Context, id int64) (*GetOrderByIDForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getOrderByIDForUpdate, id)
+	var i GetOrderByIDForUpdateRow
+	err := row.Scan(&i.ID, &i.UserID, &i.Status)
+	return &i, err
 }
 
-func (q *Queries) GetAllOrders(ctx context.Context) ([]*GetAllOrdersRow, error) {
-	rows, err := q.db.Query(ctx, getAllOrders)
+const getOrderItems = `-- name: GetOrderItems :many
+SELECT id, order_id, sku, count
+FROM items
+WHERE order_id = $1
+`
+
+func (q *Queries) GetOrderItems(ctx context.Context, orderID *int64) ([]*Item, error) {
+	rows, err := q.db.Query(ctx, getOrderItems, orderID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []*GetAllOrdersRow
+	var items []*Item
 	for rows.Next() {
-		var i GetAllOrdersRow
+		var i Item
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.Status,
-			&i.CreatedAt,
+			&i.OrderID,
+			&i.Sku,
+			&i.Count,
 		); err != nil {
 			return nil, err
 		}
@@ -88,53 +150,145 @@ func (q *Queries) GetAllOrders(ctx context.Context) ([]*GetAllOrdersRow, error)
 	return items, nil
 }
 
-const getOrderByID = `-- name: GetOrderByID :one
-SELECT o.id, o.user_id, s.name AS status, o.created_at
+const getOrderStatusHistory = `-- name: GetOrderStatusHistory :many
+SELECT s.name AS status, h.created_at
+FROM order_status_history h
+JOIN statuses s ON h.status_id = s.id
+WHERE h.order_id = $1
+ORDER BY h.created_at, h.id
+`
+
+type GetOrderStatusHistoryRow struct {
+	Status    string
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) GetOrderStatusHistory(ctx context.Context, orderID int64) ([]*GetOrderStatusHistoryRow, error) {
+	rows, err := q.db.Query(ctx, getOrderStatusHistory, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*GetOrderStatusHistoryRow
+	for rows.Next() {
+		var i GetOrderStatusHistoryRow
+		if err := rows.Scan(&i.Status, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrdersPage = `-- name: GetOrdersPage :many
+SELECT o.id, o.user_id, s.name AS status, o.created_at, o.updated_at
 FROM orders o
 JOIN statuses s ON o.status_id = s.id
-WHERE o.id = $1
+WHERE ($1::BIGINT IS NULL OR o.id < $1::BIGINT)
+  AND ($2::BIGINT IS NULL OR o.user_id = $2::BIGINT)
+  AND ($3::TEXT[] IS NULL OR s.name = ANY($3::TEXT[]))
+  AND ($4::TIMESTAMPTZ IS NULL OR o.created_at >= $4::TIMESTAMPTZ)
+  AND ($5::TIMESTAMPTZ IS NULL OR o.created_at < $5::TIMESTAMPTZ)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM shard_bucket_states sbs
+    WHERE sbs.bucket = o.id % $6::BIGINT
+  )
+ORDER BY o.id DESC
+LIMIT $7
 `
 
-type GetOrderByIDRow struct {
+type GetOrdersPageParams struct {
+	AfterID     *int64
+	UserID      *int64
+	Statuses    []string
+	CreatedFrom pgtype.Timestamptz
+	CreatedTo   pgtype.Timestamptz
+	BucketCount int64
+	PageLimit   int32
+}
+
+type GetOrdersPageRow struct {
 	ID        int64
 	UserID    int64
 	Status    string
 	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
 }
 
-func (q *Queries) GetOrderByID(ctx context.Context, id int64) (*GetOrderByIDRow, error) {
-	row := q.db.QueryRow(ctx, getOrderByID, id)
-	var i GetOrderByIDRow
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Status,
-		&i.CreatedAt,
+func (q *Queries) GetOrdersPage(ctx context.Context, arg *GetOrdersPageParams) ([]*GetOrdersPageRow, error) {
+	rows, err := q.db.Query(ctx, getOrdersPage,
+		arg.AfterID,
+		arg.UserID,
+		arg.Statuses,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.BucketCount,
+		arg.PageLimit,
 	)
-	return &i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*GetOrdersPageRow
+	for rows.Next() {
+		var i GetOrdersPageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getOrderItems = `-- name: GetOrderItems :many
-SELECT id, order_id, sku, count
-FROM items
-WHERE order_id = $1
+const getExpiredOrders = `-- name: GetExpiredOrders :many
+SELECT o.id, o.created_at
+FROM orders o
+JOIN statuses s ON o.status_id = s.id
+WHERE s.name = 'awaiting payment'
+  AND o.created_at < CURRENT_TIMESTAMP - $1::INTERVAL
+  AND NOT EXISTS (
+    SELECT 1
+    FROM shard_bucket_states sbs
+    WHERE sbs.bucket = o.id % $2::BIGINT
+  )
+ORDER BY o.created_at ASC
+LIMIT $3
 `
 
-func (q *Queries) GetOrderItems(ctx context.Context, orderID *int64) ([]*Item, error) {
-	rows, err := q.db.Query(ctx, getOrderItems, orderID)
+type GetExpiredOrdersParams struct {
+	PaymentTimeout pgtype.Interval
+	BucketCount    int64
+	BatchLimit     int32
+}
+
+type GetExpiredOrdersRow struct {
+	ID        int64
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) GetExpiredOrders(ctx context.Context, arg *GetExpiredOrdersParams) ([]*GetExpiredOrdersRow, error) {
+	rows, err := q.db.Query(ctx, getExpiredOrders, arg.PaymentTimeout, arg.BucketCount, arg.BatchLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []*Item
+	var items []*GetExpiredOrdersRow
 	for rows.Next() {
-		var i Item
-		if err := rows.Scan(
-			&i.ID,
-			&i.OrderID,
-			&i.Sku,
-			&i.Count,
-		); err != nil {
+		var i GetExpiredOrdersRow
+		if err := rows.Scan(&i.ID, &i.CreatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -145,9 +299,24 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID *int64) ([]*Item, e
 	return items, nil
 }
 
+const insertOrderStatusHistory = `-- name: InsertOrderStatusHistory :exec
+INSERT INTO order_status_history (order_id, status_id)
+VALUES ($1, (SELECT id FROM statuses st WHERE st.name = $2))
+`
+
+type InsertOrderStatusHistoryParams struct {
+	OrderID int64
+	Name    string
+}
+
+func (q *Queries) InsertOrderStatusHistory(ctx context.Context, arg *InsertOrderStatusHistoryParams) error {
+	_, err := q.db.Exec(ctx, insertOrderStatusHistory, arg.OrderID, arg.Name)
+	return err
+}
+
 const setOrderStatus = `-- name: SetOrderStatus :exec
 UPDATE orders
-SET status_id = (SELECT id FROM statuses st WHERE st.name = $2)
+SET status_id = (SELECT id FROM statuses st WHERE st.name = $2), updated_at = CURRENT_TIMESTAMP
 WHERE orders.id = $1
 `
 
@@ -7,61 +7,407 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const copyOrderOutboxEvent = `-- name: CopyOrderOutboxEvent :exec
+INSERT INTO outbox (event_type, payload, order_id, sequence, state, attempts, last_error, next_attempt_at, trace_parent, created_at)
+SELECT $1::VARCHAR, $2::TEXT, $3::BIGINT, $4::BIGINT,
+       $5::VARCHAR, $6::INT, $7::TEXT, $8::TIMESTAMPTZ,
+       $9::TEXT, $10::TIMESTAMPTZ
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM outbox
+    WHERE order_id = $3::BIGINT
+      AND sequence = $4::BIGINT
 )
+`
+
+type CopyOrderOutboxEventParams struct {
+	EventType     string
+	Payload       string
+	OrderID       int64
+	Sequence      int64
+	State         string
+	Attempts      int32
+	LastError     string
+	NextAttemptAt pgtype.Timestamptz
+	TraceParent   string
+	CreatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) CopyOrderOutboxEvent(ctx context.Context, arg *CopyOrderOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, copyOrderOutboxEvent,
+		arg.EventType,
+		arg.Payload,
+		arg.OrderID,
+		arg.Sequence,
+		arg.State,
+		arg.Attempts,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.TraceParent,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteOrderEventSequences = `-- name: DeleteOrderEventSequences :exec
+DELETE FROM order_event_sequences
+WHERE order_id = ANY($1::BIGINT[])
+`
+
+func (q *Queries) DeleteOrderEventSequences(ctx context.Context, orderIds []int64) error {
+	_, err := q.db.Exec(ctx, deleteOrderEventSequences, orderIds)
+	return err
+}
+
+const deleteProcessedOutboxEvents = `-- name: DeleteProcessedOutboxEvents :execrows
+DELETE FROM outbox
+WHERE state = 'processed' AND processed_at < $1
+`
+
+func (q *Queries) DeleteProcessedOutboxEvents(ctx context.Context, processedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProcessedOutboxEvents, processedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUndeliveredOrderOutboxEvents = `-- name: DeleteUndeliveredOrderOutboxEvents :exec
+DELETE FROM outbox
+WHERE order_id = ANY($1::BIGINT[])
+  AND state <> 'processed'
+`
 
-const fetchNextOutboxEvent = `-- name: FetchNextOutboxEvent :one
-SELECT id, event_type, payload
+func (q *Queries) DeleteUndeliveredOrderOutboxEvents(ctx context.Context, orderIds []int64) error {
+	_, err := q.db.Exec(ctx, deleteUndeliveredOrderOutboxEvents, orderIds)
+	return err
+}
+
+const fetchOutboxEvents = `-- name: FetchOutboxEvents :many
+SELECT id, event_type, payload, order_id, sequence, attempts, trace_parent
 FROM outbox
-WHERE processed = FALSE
-ORDER BY created_at
-LIMIT 1
+WHERE state = 'pending'
+  AND next_attempt_at <= CURRENT_TIMESTAMP
+  AND NOT EXISTS (
+    SELECT 1
+    FROM outbox prev
+    WHERE prev.order_id = outbox.order_id
+      AND prev.state = 'pending'
+      AND prev.sequence < outbox.sequence
+  )
+ORDER BY created_at, id
+LIMIT $1
 FOR UPDATE SKIP LOCKED
 `
 
-type FetchNextOutboxEventRow struct {
-	ID        int32
-	EventType string
-	Payload   string
+type FetchOutboxEventsRow struct {
+	ID          int32
+	EventType   string
+	Payload     string
+	OrderID     *int64
+	Sequence    *int64
+	Attempts    int32
+	TraceParent string
+}
+
+func (q *Queries) FetchOutboxEvents(ctx context.Context, limit int32) ([]*FetchOutboxEventsRow, error) {
+	rows, err := q.db.Query(ctx, fetchOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*FetchOutboxEventsRow
+	for rows.Next() {
+		var i FetchOutboxEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.Payload,
+			&i.OrderID,
+			&i.Sequence,
+			&i.Attempts,
+			&i.TraceParent,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertOrderOutboxEvent = `-- name: InsertOrderOutboxEvent :one
+WITH seq AS (
+    INSERT INTO order_event_sequences (order_id, last_sequence)
+    VALUES ($1, 1)
+    ON CONFLICT (order_id) DO UPDATE
+    SET last_sequence = order_event_sequences.last_sequence + 1
+    RETURNING last_sequence
+)
+INSERT INTO outbox (event_type, payload, order_id, sequence, trace_parent)
+SELECT $2, jsonb_set($3::jsonb, '{sequence}', to_jsonb(seq.last_sequence))::text, $1, seq.last_sequence, $4
+FROM seq
+RETURNING sequence
+`
+
+type InsertOrderOutboxEventParams struct {
+	OrderID     int64
+	EventType   string
+	Payload     []byte
+	TraceParent string
 }
 
-func (q *Queries) FetchNextOutboxEvent(ctx context.Context) (*FetchNextOutboxEventRow, error) {
-	row := q.db.QueryRow(ctx, fetchNextOutboxEvent)
-	var i FetchNextOutboxEventRow
-	err := row.Scan(&i.ID, &i.EventType, &i.Payload)
-	return &i, err
+func (q *Queries) InsertOrderOutboxEvent(ctx context.Context, arg *InsertOrderOutboxEventParams) (*int64, error) {
+	row := q.db.QueryRow(ctx, insertOrderOutboxEvent,
+		arg.OrderID,
+		arg.EventType,
+		arg.Payload,
+		arg.TraceParent,
+	)
+	var sequence *int64
+	err := row.Scan(&sequence)
+	return sequence, err
 }
 
 const insertOutboxEvent = `-- name: InsertOutboxEvent :one
-INSERT INTO outbox (event_type, payload)
-VALUES ($1, $2)
-RETURNING id, event_type, payload, created_at, processed
+INSERT INTO outbox (event_type, payload, trace_parent)
+VALUES ($1, $2, $3)
+RETURNING id
 `
 
 type InsertOutboxEventParams struct {
-	EventType string
-	Payload   string
-}
-
-func (q *Queries) InsertOutboxEvent(ctx context.Context, arg *InsertOutboxEventParams) (*Outbox, error) {
-	row := q.db.QueryRow(ctx, insertOutboxEvent, arg.EventType, arg.Payload)
-	var i Outbox
-	err := row.Scan(
-		&i.ID,
-		&i.EventType,
-		&i.Payload,
-		&i.CreatedAt,
-		&i.Processed,
+	EventType   string
+	Payload     string
+	TraceParent string
+}
+
+func (q *Queries) InsertOutboxEvent(ctx context.Context, arg *InsertOutboxEventParams) (int32, error) {
+	row := q.db.QueryRow(ctx, insertOutboxEvent, arg.EventType, arg.Payload, arg.TraceParent)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const listOrderEventSequences = `-- name: ListOrderEventSequences :many
+SELECT order_id, last_sequence
+FROM order_event_sequences
+ORDER BY order_id
+LIMIT $1
+FOR UPDATE
+`
+
+func (q *Queries) ListOrderEventSequences(ctx context.Context, limit int32) ([]*OrderEventSequence, error) {
+	rows, err := q.db.Query(ctx, listOrderEventSequences, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*OrderEventSequence
+	for rows.Next() {
+		var i OrderEventSequence
+		if err := rows.Scan(&i.OrderID, &i.LastSequence); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOutboxEvents = `-- name: ListOutboxEvents :many
+SELECT id, event_type, payload, order_id, sequence, state, attempts, last_error, next_attempt_at, processed_at, created_at
+FROM outbox
+WHERE ($1::INT IS NULL OR id > $1::INT)
+  AND ($2::INT[] IS NULL OR id = ANY($2::INT[]))
+  AND ($3::TEXT[] IS NULL OR state = ANY($3::TEXT[]))
+  AND ($4::TIMESTAMPTZ IS NULL OR created_at >= $4::TIMESTAMPTZ)
+  AND ($5::TIMESTAMPTZ IS NULL OR created_at < $5::TIMESTAMPTZ)
+ORDER BY id
+LIMIT $6
+`
+
+type ListOutboxEventsParams struct {
+	AfterID     *int32
+	Ids         []int32
+	States      []string
+	CreatedFrom pgtype.Timestamptz
+	CreatedTo   pgtype.Timestamptz
+	PageLimit   int32
+}
+
+type ListOutboxEventsRow struct {
+	ID            int32
+	EventType     string
+	Payload       string
+	OrderID       *int64
+	Sequence      *int64
+	State         string
+	Attempts      int32
+	LastError     string
+	NextAttemptAt pgtype.Timestamptz
+	ProcessedAt   pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) ListOutboxEvents(ctx context.Context, arg *ListOutboxEventsParams) ([]*ListOutboxEventsRow, error) {
+	rows, err := q.db.Query(ctx, listOutboxEvents,
+		arg.AfterID,
+		arg.Ids,
+		arg.States,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.PageLimit,
 	)
-	return &i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListOutboxEventsRow
+	for rows.Next() {
+		var i ListOutboxEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.Payload,
+			&i.OrderID,
+			&i.Sequence,
+			&i.State,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.ProcessedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUndeliveredOrderOutboxEvents = `-- name: ListUndeliveredOrderOutboxEvents :many
+SELECT event_type, payload, order_id, sequence, state, attempts, last_error, next_attempt_at, trace_parent, created_at
+FROM outbox
+WHERE order_id = ANY($1::BIGINT[])
+  AND state <> 'processed'
+ORDER BY id
+FOR UPDATE
+`
+
+type ListUndeliveredOrderOutboxEventsRow struct {
+	EventType     string
+	Payload       string
+	OrderID       *int64
+	Sequence      *int64
+	State         string
+	Attempts      int32
+	LastError     string
+	NextAttemptAt pgtype.Timestamptz
+	TraceParent   string
+	CreatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) ListUndeliveredOrderOutboxEvents(ctx context.Context, orderIds []int64) ([]*ListUndeliveredOrderOutboxEventsRow, error) {
+	rows, err := q.db.Query(ctx, listUndeliveredOrderOutboxEvents, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListUndeliveredOrderOutboxEventsRow
+	for rows.Next() {
+		var i ListUndeliveredOrderOutboxEventsRow
+		if err := rows.Scan(
+			&i.EventType,
+			&i.Payload,
+			&i.OrderID,
+			&i.Sequence,
+			&i.State,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.TraceParent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const markOutboxEventAsProcessed = `-- name: MarkOutboxEventAsProcessed :exec
+const markOutboxEventsAsProcessed = `-- name: MarkOutboxEventsAsProcessed :exec
 UPDATE outbox
-SET processed = TRUE
+SET state = 'processed', processed_at = CURRENT_TIMESTAMP, last_error = ''
+WHERE id = ANY($1::int[])
+`
+
+func (q *Queries) MarkOutboxEventsAsProcessed(ctx context.Context, ids []int32) error {
+	_, err := q.db.Exec(ctx, markOutboxEventsAsProcessed, ids)
+	return err
+}
+
+const recordOutboxEventFailure = `-- name: RecordOutboxEventFailure :exec
+UPDATE outbox
+SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3, state = $4
 WHERE id = $1
 `
 
-func (q *Queries) MarkOutboxEventAsProcessed(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, markOutboxEventAsProcessed, id)
+type RecordOutboxEventFailureParams struct {
+	ID            int32
+	LastError     string
+	NextAttemptAt pgtype.Timestamptz
+	State         string
+}
+
+func (q *Queries) RecordOutboxEventFailure(ctx context.Context, arg *RecordOutboxEventFailureParams) error {
+	_, err := q.db.Exec(ctx, recordOutboxEventFailure,
+		arg.ID,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.State,
+	)
 	return err
 }
+
+const resetOutboxEvents = `-- name: ResetOutboxEvents :execrows
+UPDATE outbox
+SET state = 'pending', attempts = 0, last_error = '', next_attempt_at = CURRENT_TIMESTAMP, processed_at = NULL
+WHERE state = ANY($1::TEXT[])
+  AND ($2::INT[] IS NULL OR id = ANY($2::INT[]))
+  AND ($3::TIMESTAMPTZ IS NULL OR created_at >= $3::TIMESTAMPTZ)
+  AND ($4::TIMESTAMPTZ IS NULL OR created_at < $4::TIMESTAMPTZ)
+`
+
+type ResetOutboxEventsParams struct {
+	FromStates  []string
+	Ids         []int32
+	CreatedFrom pgtype.Timestamptz
+	CreatedTo   pgtype.Timestamptz
+}
+
+func (q *Queries) ResetOutboxEvents(ctx context.Context, arg *ResetOutboxEventsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resetOutboxEvents,
+		arg.FromStates,
+		arg.Ids,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -6,23 +6,77 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AdjustStockTotalCount(ctx context.Context, arg *AdjustStockTotalCountParams) (*Stock, error)
+	AdvanceOrderIDSequence(ctx context.Context, value int64) error
 	CancelReservedItems(ctx context.Context, arg *CancelReservedItemsParams) error
+	CompareAndSetOrderStatus(ctx context.Context, arg *CompareAndSetOrderStatusParams) (int64, error)
+	CopyBucketIdempotencyKey(ctx context.Context, arg *CopyBucketIdempotencyKeyParams) error
+	CopyBucketOrder(ctx context.Context, arg *CopyBucketOrderParams) error
+	CopyBucketOrderEventSequence(ctx context.Context, arg *CopyBucketOrderEventSequenceParams) error
+	CopyBucketOrderItem(ctx context.Context, arg *CopyBucketOrderItemParams) error
+	CopyBucketOrderStatusHistory(ctx context.Context, arg *CopyBucketOrderStatusHistoryParams) error
+	CopyOrderOutboxEvent(ctx context.Context, arg *CopyOrderOutboxEventParams) error
+	CountBucketPendingOutboxEvents(ctx context.Context, arg *CountBucketPendingOutboxEventsParams) (int64, error)
 	CreateOrder(ctx context.Context, arg *CreateOrderParams) (int64, error)
 	CreateOrderItem(ctx context.Context, arg *CreateOrderItemParams) (int64, error)
-	FetchNextOutboxEvent(ctx context.Context) (*FetchNextOutboxEventRow, error)
-	GetAllOrders(ctx context.Context) ([]*GetAllOrdersRow, error)
+	CreateSaga(ctx context.Context, arg *CreateSagaParams) (int64, error)
+	DeleteBucketOrderEventSequences(ctx context.Context, arg *DeleteBucketOrderEventSequencesParams) error
+	DeleteBucketOrders(ctx context.Context, arg *DeleteBucketOrdersParams) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	DeleteOrderEventSequences(ctx context.Context, orderIds []int64) error
+	DeleteProcessedOutboxEvents(ctx context.Context, processedAt pgtype.Timestamptz) (int64, error)
+	DeleteShardBucketState(ctx context.Context, bucket int32) error
+	DeleteUndeliveredOrderOutboxEvents(ctx context.Context, orderIds []int64) error
+	FetchOutboxEvents(ctx context.Context, limit int32) ([]*FetchOutboxEventsRow, error)
 	GetAvailableStockBySKU(ctx context.Context, sku int32) (int32, error)
+	GetExpiredOrders(ctx context.Context, arg *GetExpiredOrdersParams) ([]*GetExpiredOrdersRow, error)
+	GetIdempotencyKey(ctx context.Context, arg *GetIdempotencyKeyParams) (*GetIdempotencyKeyRow, error)
 	GetOrderByID(ctx context.Context, id int64) (*GetOrderByIDRow, error)
+	GetOrderByIDForUpdate(ctx context.Context, id int64) (*GetOrderByIDForUpdateRow, error)
+	GetOrderIDSequenceValue(ctx context.Context) (int64, error)
 	GetOrderItems(ctx context.Context, orderID *int64) ([]*Item, error)
+	GetOrderStatusHistory(ctx context.Context, orderID int64) ([]*GetOrderStatusHistoryRow, error)
+	GetOrdersPage(ctx context.Context, arg *GetOrdersPageParams) ([]*GetOrdersPageRow, error)
+	GetShardBucketState(ctx context.Context, bucket int32) (*GetShardBucketStateRow, error)
+	GetStartedSagas(ctx context.Context, arg *GetStartedSagasParams) ([]*GetStartedSagasRow, error)
 	GetStockBySKU(ctx context.Context, sku int32) (*Stock, error)
-	InsertOutboxEvent(ctx context.Context, arg *InsertOutboxEventParams) (*Outbox, error)
-	MarkOutboxEventAsProcessed(ctx context.Context, id int32) error
+	GetStocksBySKUs(ctx context.Context, skus []int32) ([]*Stock, error)
+	GetStocksBySKUsForUpdate(ctx context.Context, skus []int32) ([]*Stock, error)
+	ImportStock(ctx context.Context, arg *ImportStockParams) (*Stock, error)
+	InitShardBuckets(ctx context.Context, arg *InitShardBucketsParams) (int64, error)
+	InsertIdempotencyKey(ctx context.Context, arg *InsertIdempotencyKeyParams) (int64, error)
+	InsertOrderOutboxEvent(ctx context.Context, arg *InsertOrderOutboxEventParams) (*int64, error)
+	InsertOrderStatusHistory(ctx context.Context, arg *InsertOrderStatusHistoryParams) error
+	InsertOutboxEvent(ctx context.Context, arg *InsertOutboxEventParams) (int32, error)
+	ListBucketIdempotencyKeys(ctx context.Context, arg *ListBucketIdempotencyKeysParams) ([]*IdempotencyKey, error)
+	ListBucketOrderEventSequences(ctx context.Context, arg *ListBucketOrderEventSequencesParams) ([]*OrderEventSequence, error)
+	ListBucketOrderItems(ctx context.Context, arg *ListBucketOrderItemsParams) ([]*ListBucketOrderItemsRow, error)
+	ListBucketOrderStatusHistory(ctx context.Context, arg *ListBucketOrderStatusHistoryParams) ([]*ListBucketOrderStatusHistoryRow, error)
+	ListBucketOrders(ctx context.Context, arg *ListBucketOrdersParams) ([]*Order, error)
+	ListOrderEventSequences(ctx context.Context, limit int32) ([]*OrderEventSequence, error)
+	ListOutboxEvents(ctx context.Context, arg *ListOutboxEventsParams) ([]*ListOutboxEventsRow, error)
+	ListShardBuckets(ctx context.Context) ([]*ListShardBucketsRow, error)
+	ListUndeliveredOrderOutboxEvents(ctx context.Context, orderIds []int64) ([]*ListUndeliveredOrderOutboxEventsRow, error)
+	LockShardBucket(ctx context.Context, bucket int32) error
+	LockShardBucketShared(ctx context.Context, bucket int32) error
+	MarkOutboxEventsAsProcessed(ctx context.Context, ids []int32) error
+	RecordOutboxEventFailure(ctx context.Context, arg *RecordOutboxEventFailureParams) error
+	RecordSagaAttempt(ctx context.Context, arg *RecordSagaAttemptParams) error
 	RemoveReservedItems(ctx context.Context, arg *RemoveReservedItemsParams) error
-	ReserveItems(ctx context.Context, arg *ReserveItemsParams) error
+	ReplenishStock(ctx context.Context, arg *ReplenishStockParams) (*Stock, error)
+	ReserveItems(ctx context.Context, arg *ReserveItemsParams) ([]*Stock, error)
+	ResetOutboxEvents(ctx context.Context, arg *ResetOutboxEventsParams) (int64, error)
+	RestoreRemovedItems(ctx context.Context, arg *RestoreRemovedItemsParams) error
 	SetOrderStatus(ctx context.Context, arg *SetOrderStatusParams) error
+	SetSagaState(ctx context.Context, arg *SetSagaStateParams) (int64, error)
+	SetShardBucketShard(ctx context.Context, arg *SetShardBucketShardParams) error
+	SetShardBucketState(ctx context.Context, arg *SetShardBucketStateParams) error
+	SetStockTotalCount(ctx context.Context, arg *SetStockTotalCountParams) (*Stock, error)
 }
 
 var _ Querier = (*Queries)(nil)
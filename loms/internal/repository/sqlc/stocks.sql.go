@@ -9,6 +9,25 @@ import (
 	"context"
 )
 
+const adjustStockTotalCount = `-- name: AdjustStockTotalCount :one
+UPDATE stocks
+SET total_count = total_count + $2
+WHERE sku = $1 AND total_count + $2 >= reserved
+RETURNING sku, total_count, reserved
+`
+
+type AdjustStockTotalCountParams struct {
+	Sku        int32
+	TotalCount int64
+}
+
+func (q *Queries) AdjustStockTotalCount(ctx context.Context, arg *AdjustStockTotalCountParams) (*Stock, error) {
+	row := q.db.QueryRow(ctx, adjustStockTotalCount, arg.Sku, arg.TotalCount)
+	var i Stock
+	err := row.Scan(&i.Sku, &i.TotalCount, &i.Reserved)
+	return &i, err
+}
+
 const cancelReservedItems = `-- name: CancelReservedItems :exec
 UPDATE stocks
 SET reserved = reserved - $2
@@ -51,6 +70,82 @@ func (q *Queries) GetStockBySKU(ctx context.Context, sku int32) (*Stock, error)
 	return &i, err
 }
 
+const getStocksBySKUs = `-- name: GetStocksBySKUs :many
+SELECT sku, total_count, reserved
+FROM stocks
+WHERE sku = ANY($1::int[])
+ORDER BY sku
+`
+
+func (q *Queries) GetStocksBySKUs(ctx context.Context, skus []int32) ([]*Stock, error) {
+	rows, err := q.db.Query(ctx, getStocksBySKUs, skus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Stock
+	for rows.Next() {
+		var i Stock
+		if err := rows.Scan(&i.Sku, &i.TotalCount, &i.Reserved); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStocksBySKUsForUpdate = `-- name: GetStocksBySKUsForUpdate :many
+SELECT sku, total_count, reserved
+FROM stocks
+WHERE sku = ANY($1::int[])
+ORDER BY sku
+FOR UPDATE
+`
+
+func (q *Queries) GetStocksBySKUsForUpdate(ctx context.Context, skus []int32) ([]*Stock, error) {
+	rows, err := q.db.Query(ctx, getStocksBySKUsForUpdate, skus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Stock
+	for rows.Next() {
+		var i Stock
+		if err := rows.Scan(&i.Sku, &i.TotalCount, &i.Reserved); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const importStock = `-- name: ImportStock :one
+INSERT INTO stocks (sku, total_count, reserved)
+VALUES ($1, $2, 0)
+ON CONFLICT (sku) DO UPDATE
+SET total_count = EXCLUDED.total_count
+WHERE stocks.reserved <= EXCLUDED.total_count
+RETURNING sku, total_count, reserved
+`
+
+type ImportStockParams struct {
+	Sku        int32
+	TotalCount int64
+}
+
+func (q *Queries) ImportStock(ctx context.Context, arg *ImportStockParams) (*Stock, error) {
+	row := q.db.QueryRow(ctx, importStock, arg.Sku, arg.TotalCount)
+	var i Stock
+	err := row.Scan(&i.Sku, &i.TotalCount, &i.Reserved)
+	return &i, err
+}
+
 const removeReservedItems = `-- name: RemoveReservedItems :exec
 UPDATE stocks
 SET reserved = reserved - $2, total_count = total_count - $2
@@ -67,18 +162,107 @@ func (q *Queries) RemoveReservedItems(ctx context.Context, arg *RemoveReservedIt
 	return err
 }
 
-const reserveItems = `-- name: ReserveItems :exec
+const replenishStock = `-- name: ReplenishStock :one
+INSERT INTO stocks (sku, total_count, reserved)
+VALUES ($1, $2, 0)
+ON CONFLICT (sku) DO UPDATE
+SET total_count = stocks.total_count + EXCLUDED.total_count
+RETURNING sku, total_count, reserved
+`
+
+type ReplenishStockParams struct {
+	Sku        int32
+	TotalCount int64
+}
+
+func (q *Queries) ReplenishStock(ctx context.Context, arg *ReplenishStockParams) (*Stock, error) {
+	row := q.db.QueryRow(ctx, replenishStock, arg.Sku, arg.TotalCount)
+	var i Stock
+	err := row.Scan(&i.Sku, &i.TotalCount, &i.Reserved)
+	return &i, err
+}
+
+const reserveItems = `-- name: ReserveItems :many
+WITH items AS (
+    SELECT unnest($1::int[]) AS sku, unnest($2::bigint[]) AS count
+), locked AS MATERIALIZED (
+    SELECT sku, total_count - reserved AS available
+    FROM stocks
+    WHERE sku = ANY($1::int[])
+    ORDER BY sku
+    FOR UPDATE
+)
 UPDATE stocks
-SET reserved = reserved + $2
-WHERE sku = $1
+SET reserved = stocks.reserved + items.count
+FROM items
+WHERE stocks.sku = items.sku
+  AND (
+    SELECT COUNT(*)
+    FROM locked
+    JOIN items ON items.sku = locked.sku
+    WHERE locked.available >= items.count
+  ) = cardinality($1::int[])
+RETURNING stocks.sku, stocks.total_count, stocks.reserved
 `
 
 type ReserveItemsParams struct {
+	Skus   []int32
+	Counts []int64
+}
+
+func (q *Queries) ReserveItems(ctx context.Context, arg *ReserveItemsParams) ([]*Stock, error) {
+	rows, err := q.db.Query(ctx, reserveItems, arg.Skus, arg.Counts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Stock
+	for rows.Next() {
+		var i Stock
+		if err := rows.Scan(&i.Sku, &i.TotalCount, &i.Reserved); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreRemovedItems = `-- name: RestoreRemovedItems :exec
+UPDATE stocks
+SET reserved = reserved + $2, total_count = total_count + $2
+WHERE sku = $1
+`
+
+type RestoreRemovedItemsParams struct {
 	Sku      int32
 	Reserved int64
 }
 
-func (q *Queries) ReserveItems(ctx context.Context, arg *ReserveItemsParams) error {
-	_, err := q.db.Exec(ctx, reserveItems, arg.Sku, arg.Reserved)
+func (q *Queries) RestoreRemovedItems(ctx context.Context, arg *RestoreRemovedItemsParams) error {
+	_, err := q.db.Exec(ctx, restoreRemovedItems, arg.Sku, arg.Reserved)
 	return err
 }
+
+const setStockTotalCount = `-- name: SetStockTotalCount :one
+INSERT INTO stocks (sku, total_count, reserved)
+VALUES ($1, $2, 0)
+ON CONFLICT (sku) DO UPDATE
+SET total_count = EXCLUDED.total_count
+WHERE stocks.reserved <= EXCLUDED.total_count
+RETURNING sku, total_count, reserved
+`
+
+type SetStockTotalCountParams struct {
+	Sku        int32
+	TotalCount int64
+}
+
+func (q *Queries) SetStockTotalCount(ctx context.Context, arg *SetStockTotalCountParams) (*Stock, error) {
+	row := q.db.QueryRow(ctx, setStockTotalCount, arg.Sku, arg.TotalCount)
+	var i Stock
+	err := row.Scan(&i.Sku, &i.TotalCount, &i.Reserved)
+	return &i, err
+}
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"route256/loms/internal/models"
 	internal_errors "route256/loms/internal/pkg/errors"
 	"route256/loms/internal/pkg/metrics"
+	"route256/loms/internal/pkg/replica"
+	"slices"
 	"time"
 
 	"route256/loms/internal/repository/sqlc"
@@ -19,6 +22,7 @@ import (
 type StockRepository struct {
 	queries sqlc.Querier
 	pool    *pgxpool.Pool
+	reader  *replica.Pool
 }
 
 // NewStockRepository creates a new instance of StockRepository.
@@ -26,9 +30,15 @@ func NewStockRepository(pool *pgxpool.Pool) *StockRepository {
 	return &StockRepository{
 		queries: sqlc.New(pool),
 		pool:    pool,
+		reader:  replica.NewPool("main", pool, nil, 0),
 	}
 }
 
+// SetReader sets pool for read-only queries of stocks, primary of reader must be pool of repository.
+func (r *StockRepository) SetReader(reader *replica.Pool) {
+	r.reader = reader
+}
+
 // GetAvailableStockBySKU returns the available stock for specified SKU.
 func (r *StockRepository) GetAvailableStockBySKU(ctx context.Context, SKU models.SKU) (uint64, error) {
 	// Tracer
@@ -49,7 +59,12 @@ func (r *StockRepository) GetAvailableStockBySKU(ctx context.Context, SKU models
 	}
 
 	// Get stock by SKU
-	available, err := r.queries.GetAvailableStockBySKU(ctx, int32(SKU))
+	var available int32
+	err := r.reader.Read(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		available, err = sqlc.New(pool).GetAvailableStockBySKU(ctx, int32(SKU))
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get available stock: %w", err)
 	}
@@ -57,6 +72,40 @@ func (r *StockRepository) GetAvailableStockBySKU(ctx context.Context, SKU models
 	return uint64(available), nil
 }
 
+// GetStocksBySKUs returns existing stocks for SKUs with single query, unknown SKUs are skipped.
+func (r *StockRepository) GetStocksBySKUs(ctx context.Context, SKUs []models.SKU) ([]models.Stock, error) {
+	// Tracer
+	ctx, span := otel.Tracer("StockRepository").Start(ctx, "GetStocksBySKUs")
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		operation := "GetStocksBySKUs"
+		metrics.IncDBQueryCounter(operation)
+		metrics.ObserveDBQueryDuration(operation, duration)
+	}()
+
+	// Validate input data
+	skus, err := r.toSKUs(SKUs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get stocks
+	var rows []*sqlc.Stock
+	err = r.reader.Read(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		rows, err = sqlc.New(pool).GetStocksBySKUs(ctx, skus)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocks: %w", err)
+	}
+
+	return toModelStocks(rows), nil
+}
+
 // ReserveItems reserves the specified count of products in the provided array of items.
 func (r *StockRepository) ReserveItems(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 	// Tracer
@@ -79,27 +128,19 @@ func (r *StockRepository) ReserveItems(ctx context.Context, tx pgx.Tx, items []m
 	// Check transaction
 	q := r.getQuerier(tx)
 
-	// Reserve
-	for _, item := range items {
-		// Get available stock
-		available, err := q.GetAvailableStockBySKU(ctx, int32(item.SKU))
-		if err != nil {
-			return fmt.Errorf("failed to get available stock for SKU %d: %w", item.SKU, err)
-		}
-
-		// Check available stock
-		if available < int32(item.Count) {
-			return fmt.Errorf("not enough stock for SKU %d: %w", item.SKU, internal_errors.ErrPreconditionFailed)
-		}
+	// Reserve all items at once, rows are locked in order of SKU so concurrent orders do not deadlock
+	skus, counts := mergeItems(items)
+	reserved, err := q.ReserveItems(ctx, &sqlc.ReserveItemsParams{
+		Skus:   skus,
+		Counts: counts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reserve items: %w", err)
+	}
 
-		// Reserve product
-		err = q.ReserveItems(ctx, &sqlc.ReserveItemsParams{
-			Sku:      int32(item.SKU),
-			Reserved: int64(item.Count),
-		})
-		if err != nil {
-			return fmt.Errorf("failed to reserve items for SKU %d: %w", item.SKU, err)
-		}
+	// Nothing is reserved if any SKU is unknown or has not enough stock
+	if len(reserved) != len(skus) {
+		return fmt.Errorf("not enough stock for SKUs %v: %w", skus, internal_errors.ErrPreconditionFailed)
 	}
 
 	return nil
@@ -153,6 +194,42 @@ func (r *StockRepository) RemoveReservedItems(ctx context.Context, tx pgx.Tx, it
 	return nil
 }
 
+// RestoreRemovedItems returns items removed on payment back to stock as reserved.
+func (r *StockRepository) RestoreRemovedItems(ctx context.Context, tx pgx.Tx, items []models.Item) error {
+	// Tracer
+	ctx, span := otel.Tracer("StockRepository").Start(ctx, "RestoreRemovedItems")
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		operation := "RestoreRemovedItems"
+		metrics.IncDBQueryCounter(operation)
+		metrics.ObserveDBQueryDuration(operation, duration)
+	}()
+
+	// Validate input data
+	if err := r.validateItems(items); err != nil {
+		return err
+	}
+
+	// Check transaction
+	q := r.getQuerier(tx)
+
+	// Restore removed items
+	for _, item := range items {
+		err := q.RestoreRemovedItems(ctx, &sqlc.RestoreRemovedItemsParams{
+			Sku:      int32(item.SKU),
+			Reserved: int64(item.Count),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore removed items for SKU %d: %w", item.SKU, err)
+		}
+	}
+
+	return nil
+}
+
 // CancelReservedItems cancels reservation and makes the stock available again.
 func (r *StockRepository) CancelReservedItems(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 	// Tracer
@@ -201,6 +278,250 @@ func (r *StockRepository) CancelReservedItems(ctx context.Context, tx pgx.Tx, it
 	return nil
 }
 
+// ReplenishStock adds count to total count of stock, stock is created if SKU is unknown.
+func (r *StockRepository) ReplenishStock(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64) (models.Stock, error) {
+	// Tracer
+	ctx, span := otel.Tracer("StockRepository").Start(ctx, "ReplenishStock")
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		operation := "ReplenishStock"
+		metrics.IncDBQueryCounter(operation)
+		metrics.ObserveDBQueryDuration(operation, duration)
+	}()
+
+	// Validate input data
+	if err := r.validateSKU(SKU); err != nil {
+		return models.Stock{}, err
+	}
+	if count < 1 {
+		return models.Stock{}, fmt.Errorf("count must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Check transaction
+	q := r.getQuerier(tx)
+
+	// Replenish
+	stock, err := q.ReplenishStock(ctx, &sqlc.ReplenishStockParams{
+		Sku:        int32(SKU),
+		TotalCount: int64(count),
+	})
+	if err != nil {
+		return models.Stock{}, fmt.Errorf("failed to replenish stock for SKU %d: %w", SKU, err)
+	}
+
+	return toModelStock(stock), nil
+}
+
+// SetStockTotalCount sets total count of stock, stock is created if SKU is unknown.
+func (r *StockRepository) SetStockTotalCount(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64) (models.Stock, error) {
+	// Tracer
+	ctx, span := otel.Tracer("StockRepository").Start(ctx, "SetStockTotalCount")
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		operation := "SetStockTotalCount"
+		metrics.IncDBQueryCounter(operation)
+		metrics.ObserveDBQueryDuration(operation, duration)
+	}()
+
+	// Validate input data
+	if err := r.validateSKU(SKU); err != nil {
+		return models.Stock{}, err
+	}
+
+	// Check transaction
+	q := r.getQuerier(tx)
+
+	// Set total count, existing stock is not updated if reserved count exceeds new total
+	stock, err := q.SetStockTotalCount(ctx, &sqlc.SetStockTotalCountParams{
+		Sku:        int32(SKU),
+		TotalCount: int64(totalCount),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Stock{}, fmt.Errorf("total count is less than reserved for SKU %d: %w", SKU, internal_errors.ErrPreconditionFailed)
+	}
+	if err != nil {
+		return models.Stock{}, fmt.Errorf("failed to set stock for SKU %d: %w", SKU, err)
+	}
+
+	return toModelStock(stock), nil
+}
+
+// AdjustStockTotalCount changes total count of existing stock by delta.
+func (r *StockRepository) AdjustStockTotalCount(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64) (models.Stock, error) {
+	// Tracer
+	ctx, span := otel.Tracer("StockRepository").Start(ctx, "AdjustStockTotalCount")
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		operation := "AdjustStockTotalCount"
+		metrics.IncDBQueryCounter(operation)
+		metrics.ObserveDBQueryDuration(operation, duration)
+	}()
+
+	// Validate input data
+	if err := r.validateSKU(SKU); err != nil {
+		return models.Stock{}, err
+	}
+	if delta == 0 {
+		return models.Stock{}, fmt.Errorf("delta must not be zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Check transaction
+	q := r.getQuerier(tx)
+
+	// Check stock exists
+	if _, err := q.GetStockBySKU(ctx, int32(SKU)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Stock{}, fmt.Errorf("stock for SKU %d: %w", SKU, internal_errors.ErrNotFound)
+		}
+		return models.Stock{}, fmt.Errorf("failed to get stock for SKU %d: %w", SKU, err)
+	}
+
+	// Adjust, stock is not updated if total count becomes less than reserved
+	stock, err := q.AdjustStockTotalCount(ctx, &sqlc.AdjustStockTotalCountParams{
+		Sku:        int32(SKU),
+		TotalCount: delta,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Stock{}, fmt.Errorf("total count becomes less than reserved for SKU %d: %w", SKU, internal_errors.ErrPreconditionFailed)
+	}
+	if err != nil {
+		return models.Stock{}, fmt.Errorf("failed to adjust stock for SKU %d: %w", SKU, err)
+	}
+
+	return toModelStock(stock), nil
+}
+
+// GetStocksForUpdate returns existing stocks for SKUs and locks them until the end of transaction.
+func (r *StockRepository) GetStocksForUpdate(ctx context.Context, tx pgx.Tx, SKUs []models.SKU) ([]models.Stock, error) {
+	// Tracer
+	ctx, span := otel.Tracer("StockRepository").Start(ctx, "GetStocksForUpdate")
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		operation := "GetStocksForUpdate"
+		metrics.IncDBQueryCounter(operation)
+		metrics.ObserveDBQueryDuration(operation, duration)
+	}()
+
+	// Validate input data
+	skus, err := r.toSKUs(SKUs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check transaction
+	q := r.getQuerier(tx)
+
+	// Get stocks
+	rows, err := q.GetStocksBySKUsForUpdate(ctx, skus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocks: %w", err)
+	}
+
+	return toModelStocks(rows), nil
+}
+
+// ImportStock sets total count of stock, reserved count of existing stock is preserved.
+func (r *StockRepository) ImportStock(ctx context.Context, tx pgx.Tx, stock models.Stock) (models.Stock, error) {
+	// Tracer
+	ctx, span := otel.Tracer("StockRepository").Start(ctx, "ImportStock")
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		operation := "ImportStock"
+		metrics.IncDBQueryCounter(operation)
+		metrics.ObserveDBQueryDuration(operation, duration)
+	}()
+
+	// Validate input data
+	if err := r.validateSKU(stock.SKU); err != nil {
+		return models.Stock{}, err
+	}
+	if stock.Reserved != 0 {
+		return models.Stock{}, fmt.Errorf("imported stock for SKU %d must not be reserved: %w", stock.SKU, internal_errors.ErrBadRequest)
+	}
+
+	// Check transaction
+	q := r.getQuerier(tx)
+
+	// Import, new stock is not reserved and reserved of existing stock is preserved
+	imported, err := q.ImportStock(ctx, &sqlc.ImportStockParams{
+		Sku:        int32(stock.SKU),
+		TotalCount: int64(stock.TotalCount),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Stock{}, fmt.Errorf("total count is less than reserved for SKU %d: %w", stock.SKU, internal_errors.ErrPreconditionFailed)
+	}
+	if err != nil {
+		return models.Stock{}, fmt.Errorf("failed to import stock for SKU %d: %w", stock.SKU, err)
+	}
+
+	return toModelStock(imported), nil
+}
+
+// toModelStock converts stock row to model.
+func toModelStock(stock *sqlc.Stock) models.Stock {
+	return models.Stock{
+		SKU:        models.SKU(stock.Sku),
+		TotalCount: uint64(stock.TotalCount),
+		Reserved:   uint64(stock.Reserved),
+	}
+}
+
+// toModelStocks converts stock rows to models.
+func toModelStocks(rows []*sqlc.Stock) []models.Stock {
+	stocks := make([]models.Stock, len(rows))
+	for i, row := range rows {
+		stocks[i] = toModelStock(row)
+	}
+	return stocks
+}
+
+// mergeItems sums counts of duplicate SKUs and returns SKUs sorted with their counts.
+func mergeItems(items []models.Item) ([]int32, []int64) {
+	merged := make(map[models.SKU]int64, len(items))
+	for _, item := range items {
+		merged[item.SKU] += int64(item.Count)
+	}
+
+	skus := make([]int32, 0, len(merged))
+	for SKU := range merged {
+		skus = append(skus, int32(SKU))
+	}
+	slices.Sort(skus)
+
+	counts := make([]int64, len(skus))
+	for i, SKU := range skus {
+		counts[i] = merged[models.SKU(SKU)]
+	}
+	return skus, counts
+}
+
+// toSKUs validates SKUs and converts them to query argument.
+func (r *StockRepository) toSKUs(SKUs []models.SKU) ([]int32, error) {
+	skus := make([]int32, len(SKUs))
+	for i, SKU := range SKUs {
+		if err := r.validateSKU(SKU); err != nil {
+			return nil, err
+		}
+		skus[i] = int32(SKU)
+	}
+	return skus, nil
+}
+
 // validateSKU function for validate SKU.
 func (r *StockRepository) validateSKU(SKU models.SKU) error {
 	if SKU < 1 {
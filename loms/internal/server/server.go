@@ -177,6 +177,7 @@ func (s *GrpcServer) createGrpcServer() *grpc.Server {
 			grpc_ctxtags.UnaryServerInterceptor(),
 			mw.Logger,
 			mw.Validate,
+			mw.ReadYourWrites,
 			grpcrecovery.UnaryServerInterceptor(),
 			mw.UnaryServerMetricsInterceptor(),
 			grpc_zap.UnaryServerInterceptor(logger.GetZapLogger()),
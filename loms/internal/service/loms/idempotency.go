@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// CleanupIdempotencyKeys removes expired idempotency keys and returns count of removed keys.
+func (s *LomsService) CleanupIdempotencyKeys(ctx context.Context) (int64, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "CleanupIdempotencyKeys")
+	defer span.End()
+
+	deleted, err := s.orderRepository.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// newIdempotencyKey builds idempotency key of request, returns nil if request has no key.
+func (s *LomsService) newIdempotencyKey(req *models.OrderCreateRequest) *models.IdempotencyKey {
+	if req.IdempotencyKey == "" {
+		return nil
+	}
+
+	return &models.IdempotencyKey{
+		UserID:      req.User,
+		Key:         req.IdempotencyKey,
+		RequestHash: hashOrderCreateRequest(req),
+		ExpiresAt:   time.Now().Add(s.idempotencyKeyRetention),
+	}
+}
+
+// findIdempotentOrder returns orderID created earlier with the same idempotency key.
+func (s *LomsService) findIdempotentOrder(ctx context.Context, key *models.IdempotencyKey) (models.OID, error) {
+	stored, err := s.orderRepository.GetIdempotencyKey(ctx, key.UserID, key.Key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if stored.RequestHash != key.RequestHash {
+		return 0, fmt.Errorf("key %q: %w", key.Key, internal_errors.ErrIdempotencyKeyReused)
+	}
+
+	return stored.OrderID, nil
+}
+
+// hashOrderCreateRequest returns hash of request payload, items order does not matter.
+func hashOrderCreateRequest(req *models.OrderCreateRequest) string {
+	items := make([]models.Item, len(req.Items))
+	copy(items, req.Items)
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].SKU != items[j].SKU {
+			return items[i].SKU < items[j].SKU
+		}
+		return items[i].Count < items[j].Count
+	})
+
+	h := sha256.New()
+	h.Write([]byte(strconv.FormatInt(req.User, 10)))
+	for _, item := range items {
+		h.Write([]byte(":" + strconv.FormatUint(uint64(item.SKU), 10) + "x" + strconv.FormatUint(uint64(item.Count), 10)))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
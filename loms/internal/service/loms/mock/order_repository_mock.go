@@ -9,6 +9,7 @@ import (
 	"route256/loms/internal/models"
 	"sync"
 	mm_atomic "sync/atomic"
+	"time"
 	mm_time "time"
 
 	"github.com/gojuno/minimock/v3"
@@ -19,13 +20,34 @@ type IOrderRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcCreate          func(ctx context.Context, order models.Order) (o1 models.OID, err error)
+	funcCompareAndSetStatus          func(ctx context.Context, orderID models.OID, expected models.OrderStatus, status models.OrderStatus, eventType string) (err error)
+	funcCompareAndSetStatusOrigin    string
+	inspectFuncCompareAndSetStatus   func(ctx context.Context, orderID models.OID, expected models.OrderStatus, status models.OrderStatus, eventType string)
+	afterCompareAndSetStatusCounter  uint64
+	beforeCompareAndSetStatusCounter uint64
+	CompareAndSetStatusMock          mIOrderRepositoryMockCompareAndSetStatus
+
+	funcCreate          func(ctx context.Context, order models.Order, eventType string) (o1 models.OID, err error)
 	funcCreateOrigin    string
-	inspectFuncCreate   func(ctx context.Context, order models.Order)
+	inspectFuncCreate   func(ctx context.Context, order models.Order, eventType string)
 	afterCreateCounter  uint64
 	beforeCreateCounter uint64
 	CreateMock          mIOrderRepositoryMockCreate
 
+	funcCreateWithIdempotencyKey          func(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string) (o1 models.OID, err error)
+	funcCreateWithIdempotencyKeyOrigin    string
+	inspectFuncCreateWithIdempotencyKey   func(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string)
+	afterCreateWithIdempotencyKeyCounter  uint64
+	beforeCreateWithIdempotencyKeyCounter uint64
+	CreateWithIdempotencyKeyMock          mIOrderRepositoryMockCreateWithIdempotencyKey
+
+	funcDeleteExpiredIdempotencyKeys          func(ctx context.Context) (i1 int64, err error)
+	funcDeleteExpiredIdempotencyKeysOrigin    string
+	inspectFuncDeleteExpiredIdempotencyKeys   func(ctx context.Context)
+	afterDeleteExpiredIdempotencyKeysCounter  uint64
+	beforeDeleteExpiredIdempotencyKeysCounter uint64
+	DeleteExpiredIdempotencyKeysMock          mIOrderRepositoryMockDeleteExpiredIdempotencyKeys
+
 	funcGetByID          func(ctx context.Context, orderID models.OID) (o1 models.Order, err error)
 	funcGetByIDOrigin    string
 	inspectFuncGetByID   func(ctx context.Context, orderID models.OID)
@@ -33,16 +55,37 @@ type IOrderRepositoryMock struct {
 	beforeGetByIDCounter uint64
 	GetByIDMock          mIOrderRepositoryMockGetByID
 
-	funcGetOrders          func(ctx context.Context) (oa1 []models.Order, err error)
+	funcGetExpiredOrders          func(ctx context.Context, paymentTimeout time.Duration, limit uint32) (oa1 []models.Order, err error)
+	funcGetExpiredOrdersOrigin    string
+	inspectFuncGetExpiredOrders   func(ctx context.Context, paymentTimeout time.Duration, limit uint32)
+	afterGetExpiredOrdersCounter  uint64
+	beforeGetExpiredOrdersCounter uint64
+	GetExpiredOrdersMock          mIOrderRepositoryMockGetExpiredOrders
+
+	funcGetIdempotencyKey          func(ctx context.Context, userID models.UID, key string) (i1 models.IdempotencyKey, err error)
+	funcGetIdempotencyKeyOrigin    string
+	inspectFuncGetIdempotencyKey   func(ctx context.Context, userID models.UID, key string)
+	afterGetIdempotencyKeyCounter  uint64
+	beforeGetIdempotencyKeyCounter uint64
+	GetIdempotencyKeyMock          mIOrderRepositoryMockGetIdempotencyKey
+
+	funcGetOrders          func(ctx context.Context, filter models.OrderFilter, limit uint32) (oa1 []models.Order, err error)
 	funcGetOrdersOrigin    string
-	inspectFuncGetOrders   func(ctx context.Context)
+	inspectFuncGetOrders   func(ctx context.Context, filter models.OrderFilter, limit uint32)
 	afterGetOrdersCounter  uint64
 	beforeGetOrdersCounter uint64
 	GetOrdersMock          mIOrderRepositoryMockGetOrders
 
-	funcSetStatus          func(ctx context.Context, orderID models.OID, status models.OrderStatus) (err error)
+	funcGetOrdersPartial          func(ctx context.Context, filter models.OrderFilter, limit uint32) (o1 models.OrdersPage, err error)
+	funcGetOrdersPartialOrigin    string
+	inspectFuncGetOrdersPartial   func(ctx context.Context, filter models.OrderFilter, limit uint32)
+	afterGetOrdersPartialCounter  uint64
+	beforeGetOrdersPartialCounter uint64
+	GetOrdersPartialMock          mIOrderRepositoryMockGetOrdersPartial
+
+	funcSetStatus          func(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) (err error)
 	funcSetStatusOrigin    string
-	inspectFuncSetStatus   func(ctx context.Context, orderID models.OID, status models.OrderStatus)
+	inspectFuncSetStatus   func(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string)
 	afterSetStatusCounter  uint64
 	beforeSetStatusCounter uint64
 	SetStatusMock          mIOrderRepositoryMockSetStatus
@@ -56,15 +99,33 @@ func NewIOrderRepositoryMock(t minimock.Tester) *IOrderRepositoryMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.CompareAndSetStatusMock = mIOrderRepositoryMockCompareAndSetStatus{mock: m}
+	m.CompareAndSetStatusMock.callArgs = []*IOrderRepositoryMockCompareAndSetStatusParams{}
+
 	m.CreateMock = mIOrderRepositoryMockCreate{mock: m}
 	m.CreateMock.callArgs = []*IOrderRepositoryMockCreateParams{}
 
+	m.CreateWithIdempotencyKeyMock = mIOrderRepositoryMockCreateWithIdempotencyKey{mock: m}
+	m.CreateWithIdempotencyKeyMock.callArgs = []*IOrderRepositoryMockCreateWithIdempotencyKeyParams{}
+
+	m.DeleteExpiredIdempotencyKeysMock = mIOrderRepositoryMockDeleteExpiredIdempotencyKeys{mock: m}
+	m.DeleteExpiredIdempotencyKeysMock.callArgs = []*IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams{}
+
 	m.GetByIDMock = mIOrderRepositoryMockGetByID{mock: m}
 	m.GetByIDMock.callArgs = []*IOrderRepositoryMockGetByIDParams{}
 
+	m.GetExpiredOrdersMock = mIOrderRepositoryMockGetExpiredOrders{mock: m}
+	m.GetExpiredOrdersMock.callArgs = []*IOrderRepositoryMockGetExpiredOrdersParams{}
+
+	m.GetIdempotencyKeyMock = mIOrderRepositoryMockGetIdempotencyKey{mock: m}
+	m.GetIdempotencyKeyMock.callArgs = []*IOrderRepositoryMockGetIdempotencyKeyParams{}
+
 	m.GetOrdersMock = mIOrderRepositoryMockGetOrders{mock: m}
 	m.GetOrdersMock.callArgs = []*IOrderRepositoryMockGetOrdersParams{}
 
+	m.GetOrdersPartialMock = mIOrderRepositoryMockGetOrdersPartial{mock: m}
+	m.GetOrdersPartialMock.callArgs = []*IOrderRepositoryMockGetOrdersPartialParams{}
+
 	m.SetStatusMock = mIOrderRepositoryMockSetStatus{mock: m}
 	m.SetStatusMock.callArgs = []*IOrderRepositoryMockSetStatusParams{}
 
@@ -73,6 +134,441 @@ func NewIOrderRepositoryMock(t minimock.Tester) *IOrderRepositoryMock {
 	return m
 }
 
+type mIOrderRepositoryMockCompareAndSetStatus struct {
+	optional           bool
+	mock               *IOrderRepositoryMock
+	defaultExpectation *IOrderRepositoryMockCompareAndSetStatusExpectation
+	expectations       []*IOrderRepositoryMockCompareAndSetStatusExpectation
+
+	callArgs []*IOrderRepositoryMockCompareAndSetStatusParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOrderRepositoryMockCompareAndSetStatusExpectation specifies expectation struct of the IOrderRepository.CompareAndSetStatus
+type IOrderRepositoryMockCompareAndSetStatusExpectation struct {
+	mock               *IOrderRepositoryMock
+	params             *IOrderRepositoryMockCompareAndSetStatusParams
+	paramPtrs          *IOrderRepositoryMockCompareAndSetStatusParamPtrs
+	expectationOrigins IOrderRepositoryMockCompareAndSetStatusExpectationOrigins
+	results            *IOrderRepositoryMockCompareAndSetStatusResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOrderRepositoryMockCompareAndSetStatusParams contains parameters of the IOrderRepository.CompareAndSetStatus
+type IOrderRepositoryMockCompareAndSetStatusParams struct {
+	ctx       context.Context
+	orderID   models.OID
+	expected  models.OrderStatus
+	status    models.OrderStatus
+	eventType string
+}
+
+// IOrderRepositoryMockCompareAndSetStatusParamPtrs contains pointers to parameters of the IOrderRepository.CompareAndSetStatus
+type IOrderRepositoryMockCompareAndSetStatusParamPtrs struct {
+	ctx       *context.Context
+	orderID   *models.OID
+	expected  *models.OrderStatus
+	status    *models.OrderStatus
+	eventType *string
+}
+
+// IOrderRepositoryMockCompareAndSetStatusResults contains results of the IOrderRepository.CompareAndSetStatus
+type IOrderRepositoryMockCompareAndSetStatusResults struct {
+	err error
+}
+
+// IOrderRepositoryMockCompareAndSetStatusOrigins contains origins of expectations of the IOrderRepository.CompareAndSetStatus
+type IOrderRepositoryMockCompareAndSetStatusExpectationOrigins struct {
+	origin          string
+	originCtx       string
+	originOrderID   string
+	originExpected  string
+	originStatus    string
+	originEventType string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) Optional() *mIOrderRepositoryMockCompareAndSetStatus {
+	mmCompareAndSetStatus.optional = true
+	return mmCompareAndSetStatus
+}
+
+// Expect sets up expected params for IOrderRepository.CompareAndSetStatus
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) Expect(ctx context.Context, orderID models.OID, expected models.OrderStatus, status models.OrderStatus, eventType string) *mIOrderRepositoryMockCompareAndSetStatus {
+	if mmCompareAndSetStatus.mock.funcCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Set")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation == nil {
+		mmCompareAndSetStatus.defaultExpectation = &IOrderRepositoryMockCompareAndSetStatusExpectation{}
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.paramPtrs != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by ExpectParams functions")
+	}
+
+	mmCompareAndSetStatus.defaultExpectation.params = &IOrderRepositoryMockCompareAndSetStatusParams{ctx, orderID, expected, status, eventType}
+	mmCompareAndSetStatus.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmCompareAndSetStatus.expectations {
+		if minimock.Equal(e.params, mmCompareAndSetStatus.defaultExpectation.params) {
+			mmCompareAndSetStatus.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCompareAndSetStatus.defaultExpectation.params)
+		}
+	}
+
+	return mmCompareAndSetStatus
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOrderRepository.CompareAndSetStatus
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) ExpectCtxParam1(ctx context.Context) *mIOrderRepositoryMockCompareAndSetStatus {
+	if mmCompareAndSetStatus.mock.funcCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Set")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation == nil {
+		mmCompareAndSetStatus.defaultExpectation = &IOrderRepositoryMockCompareAndSetStatusExpectation{}
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.params != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Expect")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.paramPtrs == nil {
+		mmCompareAndSetStatus.defaultExpectation.paramPtrs = &IOrderRepositoryMockCompareAndSetStatusParamPtrs{}
+	}
+	mmCompareAndSetStatus.defaultExpectation.paramPtrs.ctx = &ctx
+	mmCompareAndSetStatus.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmCompareAndSetStatus
+}
+
+// ExpectOrderIDParam2 sets up expected param orderID for IOrderRepository.CompareAndSetStatus
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) ExpectOrderIDParam2(orderID models.OID) *mIOrderRepositoryMockCompareAndSetStatus {
+	if mmCompareAndSetStatus.mock.funcCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Set")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation == nil {
+		mmCompareAndSetStatus.defaultExpectation = &IOrderRepositoryMockCompareAndSetStatusExpectation{}
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.params != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Expect")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.paramPtrs == nil {
+		mmCompareAndSetStatus.defaultExpectation.paramPtrs = &IOrderRepositoryMockCompareAndSetStatusParamPtrs{}
+	}
+	mmCompareAndSetStatus.defaultExpectation.paramPtrs.orderID = &orderID
+	mmCompareAndSetStatus.defaultExpectation.expectationOrigins.originOrderID = minimock.CallerInfo(1)
+
+	return mmCompareAndSetStatus
+}
+
+// ExpectExpectedParam3 sets up expected param expected for IOrderRepository.CompareAndSetStatus
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) ExpectExpectedParam3(expected models.OrderStatus) *mIOrderRepositoryMockCompareAndSetStatus {
+	if mmCompareAndSetStatus.mock.funcCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Set")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation == nil {
+		mmCompareAndSetStatus.defaultExpectation = &IOrderRepositoryMockCompareAndSetStatusExpectation{}
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.params != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Expect")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.paramPtrs == nil {
+		mmCompareAndSetStatus.defaultExpectation.paramPtrs = &IOrderRepositoryMockCompareAndSetStatusParamPtrs{}
+	}
+	mmCompareAndSetStatus.defaultExpectation.paramPtrs.expected = &expected
+	mmCompareAndSetStatus.defaultExpectation.expectationOrigins.originExpected = minimock.CallerInfo(1)
+
+	return mmCompareAndSetStatus
+}
+
+// ExpectStatusParam4 sets up expected param status for IOrderRepository.CompareAndSetStatus
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) ExpectStatusParam4(status models.OrderStatus) *mIOrderRepositoryMockCompareAndSetStatus {
+	if mmCompareAndSetStatus.mock.funcCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Set")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation == nil {
+		mmCompareAndSetStatus.defaultExpectation = &IOrderRepositoryMockCompareAndSetStatusExpectation{}
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.params != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Expect")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.paramPtrs == nil {
+		mmCompareAndSetStatus.defaultExpectation.paramPtrs = &IOrderRepositoryMockCompareAndSetStatusParamPtrs{}
+	}
+	mmCompareAndSetStatus.defaultExpectation.paramPtrs.status = &status
+	mmCompareAndSetStatus.defaultExpectation.expectationOrigins.originStatus = minimock.CallerInfo(1)
+
+	return mmCompareAndSetStatus
+}
+
+// ExpectEventTypeParam5 sets up expected param eventType for IOrderRepository.CompareAndSetStatus
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) ExpectEventTypeParam5(eventType string) *mIOrderRepositoryMockCompareAndSetStatus {
+	if mmCompareAndSetStatus.mock.funcCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Set")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation == nil {
+		mmCompareAndSetStatus.defaultExpectation = &IOrderRepositoryMockCompareAndSetStatusExpectation{}
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.params != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Expect")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation.paramPtrs == nil {
+		mmCompareAndSetStatus.defaultExpectation.paramPtrs = &IOrderRepositoryMockCompareAndSetStatusParamPtrs{}
+	}
+	mmCompareAndSetStatus.defaultExpectation.paramPtrs.eventType = &eventType
+	mmCompareAndSetStatus.defaultExpectation.expectationOrigins.originEventType = minimock.CallerInfo(1)
+
+	return mmCompareAndSetStatus
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOrderRepository.CompareAndSetStatus
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) Inspect(f func(ctx context.Context, orderID models.OID, expected models.OrderStatus, status models.OrderStatus, eventType string)) *mIOrderRepositoryMockCompareAndSetStatus {
+	if mmCompareAndSetStatus.mock.inspectFuncCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.CompareAndSetStatus")
+	}
+
+	mmCompareAndSetStatus.mock.inspectFuncCompareAndSetStatus = f
+
+	return mmCompareAndSetStatus
+}
+
+// Return sets up results that will be returned by IOrderRepository.CompareAndSetStatus
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) Return(err error) *IOrderRepositoryMock {
+	if mmCompareAndSetStatus.mock.funcCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Set")
+	}
+
+	if mmCompareAndSetStatus.defaultExpectation == nil {
+		mmCompareAndSetStatus.defaultExpectation = &IOrderRepositoryMockCompareAndSetStatusExpectation{mock: mmCompareAndSetStatus.mock}
+	}
+	mmCompareAndSetStatus.defaultExpectation.results = &IOrderRepositoryMockCompareAndSetStatusResults{err}
+	mmCompareAndSetStatus.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmCompareAndSetStatus.mock
+}
+
+// Set uses given function f to mock the IOrderRepository.CompareAndSetStatus method
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) Set(f func(ctx context.Context, orderID models.OID, expected models.OrderStatus, status models.OrderStatus, eventType string) (err error)) *IOrderRepositoryMock {
+	if mmCompareAndSetStatus.defaultExpectation != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.CompareAndSetStatus method")
+	}
+
+	if len(mmCompareAndSetStatus.expectations) > 0 {
+		mmCompareAndSetStatus.mock.t.Fatalf("Some expectations are already set for the IOrderRepository.CompareAndSetStatus method")
+	}
+
+	mmCompareAndSetStatus.mock.funcCompareAndSetStatus = f
+	mmCompareAndSetStatus.mock.funcCompareAndSetStatusOrigin = minimock.CallerInfo(1)
+	return mmCompareAndSetStatus.mock
+}
+
+// When sets expectation for the IOrderRepository.CompareAndSetStatus which will trigger the result defined by the following
+// Then helper
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) When(ctx context.Context, orderID models.OID, expected models.OrderStatus, status models.OrderStatus, eventType string) *IOrderRepositoryMockCompareAndSetStatusExpectation {
+	if mmCompareAndSetStatus.mock.funcCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.mock.t.Fatalf("IOrderRepositoryMock.CompareAndSetStatus mock is already set by Set")
+	}
+
+	expectation := &IOrderRepositoryMockCompareAndSetStatusExpectation{
+		mock:               mmCompareAndSetStatus.mock,
+		params:             &IOrderRepositoryMockCompareAndSetStatusParams{ctx, orderID, expected, status, eventType},
+		expectationOrigins: IOrderRepositoryMockCompareAndSetStatusExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmCompareAndSetStatus.expectations = append(mmCompareAndSetStatus.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOrderRepository.CompareAndSetStatus return parameters for the expectation previously defined by the When method
+func (e *IOrderRepositoryMockCompareAndSetStatusExpectation) Then(err error) *IOrderRepositoryMock {
+	e.results = &IOrderRepositoryMockCompareAndSetStatusResults{err}
+	return e.mock
+}
+
+// Times sets number of times IOrderRepository.CompareAndSetStatus should be invoked
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) Times(n uint64) *mIOrderRepositoryMockCompareAndSetStatus {
+	if n == 0 {
+		mmCompareAndSetStatus.mock.t.Fatalf("Times of IOrderRepositoryMock.CompareAndSetStatus mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCompareAndSetStatus.expectedInvocations, n)
+	mmCompareAndSetStatus.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmCompareAndSetStatus
+}
+
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) invocationsDone() bool {
+	if len(mmCompareAndSetStatus.expectations) == 0 && mmCompareAndSetStatus.defaultExpectation == nil && mmCompareAndSetStatus.mock.funcCompareAndSetStatus == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCompareAndSetStatus.mock.afterCompareAndSetStatusCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCompareAndSetStatus.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// CompareAndSetStatus implements mm_service.IOrderRepository
+func (mmCompareAndSetStatus *IOrderRepositoryMock) CompareAndSetStatus(ctx context.Context, orderID models.OID, expected models.OrderStatus, status models.OrderStatus, eventType string) (err error) {
+	mm_atomic.AddUint64(&mmCompareAndSetStatus.beforeCompareAndSetStatusCounter, 1)
+	defer mm_atomic.AddUint64(&mmCompareAndSetStatus.afterCompareAndSetStatusCounter, 1)
+
+	mmCompareAndSetStatus.t.Helper()
+
+	if mmCompareAndSetStatus.inspectFuncCompareAndSetStatus != nil {
+		mmCompareAndSetStatus.inspectFuncCompareAndSetStatus(ctx, orderID, expected, status, eventType)
+	}
+
+	mm_params := IOrderRepositoryMockCompareAndSetStatusParams{ctx, orderID, expected, status, eventType}
+
+	// Record call args
+	mmCompareAndSetStatus.CompareAndSetStatusMock.mutex.Lock()
+	mmCompareAndSetStatus.CompareAndSetStatusMock.callArgs = append(mmCompareAndSetStatus.CompareAndSetStatusMock.callArgs, &mm_params)
+	mmCompareAndSetStatus.CompareAndSetStatusMock.mutex.Unlock()
+
+	for _, e := range mmCompareAndSetStatus.CompareAndSetStatusMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.Counter, 1)
+		mm_want := mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.params
+		mm_want_ptrs := mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.paramPtrs
+
+		mm_got := IOrderRepositoryMockCompareAndSetStatusParams{ctx, orderID, expected, status, eventType}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCompareAndSetStatus.t.Errorf("IOrderRepositoryMock.CompareAndSetStatus got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.orderID != nil && !minimock.Equal(*mm_want_ptrs.orderID, mm_got.orderID) {
+				mmCompareAndSetStatus.t.Errorf("IOrderRepositoryMock.CompareAndSetStatus got unexpected parameter orderID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.expectationOrigins.originOrderID, *mm_want_ptrs.orderID, mm_got.orderID, minimock.Diff(*mm_want_ptrs.orderID, mm_got.orderID))
+			}
+
+			if mm_want_ptrs.expected != nil && !minimock.Equal(*mm_want_ptrs.expected, mm_got.expected) {
+				mmCompareAndSetStatus.t.Errorf("IOrderRepositoryMock.CompareAndSetStatus got unexpected parameter expected, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.expectationOrigins.originExpected, *mm_want_ptrs.expected, mm_got.expected, minimock.Diff(*mm_want_ptrs.expected, mm_got.expected))
+			}
+
+			if mm_want_ptrs.status != nil && !minimock.Equal(*mm_want_ptrs.status, mm_got.status) {
+				mmCompareAndSetStatus.t.Errorf("IOrderRepositoryMock.CompareAndSetStatus got unexpected parameter status, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.expectationOrigins.originStatus, *mm_want_ptrs.status, mm_got.status, minimock.Diff(*mm_want_ptrs.status, mm_got.status))
+			}
+
+			if mm_want_ptrs.eventType != nil && !minimock.Equal(*mm_want_ptrs.eventType, mm_got.eventType) {
+				mmCompareAndSetStatus.t.Errorf("IOrderRepositoryMock.CompareAndSetStatus got unexpected parameter eventType, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.expectationOrigins.originEventType, *mm_want_ptrs.eventType, mm_got.eventType, minimock.Diff(*mm_want_ptrs.eventType, mm_got.eventType))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCompareAndSetStatus.t.Errorf("IOrderRepositoryMock.CompareAndSetStatus got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCompareAndSetStatus.CompareAndSetStatusMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCompareAndSetStatus.t.Fatal("No results are set for the IOrderRepositoryMock.CompareAndSetStatus")
+		}
+		return (*mm_results).err
+	}
+	if mmCompareAndSetStatus.funcCompareAndSetStatus != nil {
+		return mmCompareAndSetStatus.funcCompareAndSetStatus(ctx, orderID, expected, status, eventType)
+	}
+	mmCompareAndSetStatus.t.Fatalf("Unexpected call to IOrderRepositoryMock.CompareAndSetStatus. %v %v %v %v %v", ctx, orderID, expected, status, eventType)
+	return
+}
+
+// CompareAndSetStatusAfterCounter returns a count of finished IOrderRepositoryMock.CompareAndSetStatus invocations
+func (mmCompareAndSetStatus *IOrderRepositoryMock) CompareAndSetStatusAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCompareAndSetStatus.afterCompareAndSetStatusCounter)
+}
+
+// CompareAndSetStatusBeforeCounter returns a count of IOrderRepositoryMock.CompareAndSetStatus invocations
+func (mmCompareAndSetStatus *IOrderRepositoryMock) CompareAndSetStatusBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCompareAndSetStatus.beforeCompareAndSetStatusCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOrderRepositoryMock.CompareAndSetStatus.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCompareAndSetStatus *mIOrderRepositoryMockCompareAndSetStatus) Calls() []*IOrderRepositoryMockCompareAndSetStatusParams {
+	mmCompareAndSetStatus.mutex.RLock()
+
+	argCopy := make([]*IOrderRepositoryMockCompareAndSetStatusParams, len(mmCompareAndSetStatus.callArgs))
+	copy(argCopy, mmCompareAndSetStatus.callArgs)
+
+	mmCompareAndSetStatus.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCompareAndSetStatusDone returns true if the count of the CompareAndSetStatus invocations corresponds
+// the number of defined expectations
+func (m *IOrderRepositoryMock) MinimockCompareAndSetStatusDone() bool {
+	if m.CompareAndSetStatusMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.CompareAndSetStatusMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CompareAndSetStatusMock.invocationsDone()
+}
+
+// MinimockCompareAndSetStatusInspect logs each unmet expectation
+func (m *IOrderRepositoryMock) MinimockCompareAndSetStatusInspect() {
+	for _, e := range m.CompareAndSetStatusMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.CompareAndSetStatus at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterCompareAndSetStatusCounter := mm_atomic.LoadUint64(&m.afterCompareAndSetStatusCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CompareAndSetStatusMock.defaultExpectation != nil && afterCompareAndSetStatusCounter < 1 {
+		if m.CompareAndSetStatusMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.CompareAndSetStatus at\n%s", m.CompareAndSetStatusMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.CompareAndSetStatus at\n%s with params: %#v", m.CompareAndSetStatusMock.defaultExpectation.expectationOrigins.origin, *m.CompareAndSetStatusMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCompareAndSetStatus != nil && afterCompareAndSetStatusCounter < 1 {
+		m.t.Errorf("Expected call to IOrderRepositoryMock.CompareAndSetStatus at\n%s", m.funcCompareAndSetStatusOrigin)
+	}
+
+	if !m.CompareAndSetStatusMock.invocationsDone() && afterCompareAndSetStatusCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOrderRepositoryMock.CompareAndSetStatus at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.CompareAndSetStatusMock.expectedInvocations), m.CompareAndSetStatusMock.expectedInvocationsOrigin, afterCompareAndSetStatusCounter)
+	}
+}
+
 type mIOrderRepositoryMockCreate struct {
 	optional           bool
 	mock               *IOrderRepositoryMock
@@ -99,14 +595,16 @@ type IOrderRepositoryMockCreateExpectation struct {
 
 // IOrderRepositoryMockCreateParams contains parameters of the IOrderRepository.Create
 type IOrderRepositoryMockCreateParams struct {
-	ctx   context.Context
-	order models.Order
+	ctx       context.Context
+	order     models.Order
+	eventType string
 }
 
 // IOrderRepositoryMockCreateParamPtrs contains pointers to parameters of the IOrderRepository.Create
 type IOrderRepositoryMockCreateParamPtrs struct {
-	ctx   *context.Context
-	order *models.Order
+	ctx       *context.Context
+	order     *models.Order
+	eventType *string
 }
 
 // IOrderRepositoryMockCreateResults contains results of the IOrderRepository.Create
@@ -117,9 +615,10 @@ type IOrderRepositoryMockCreateResults struct {
 
 // IOrderRepositoryMockCreateOrigins contains origins of expectations of the IOrderRepository.Create
 type IOrderRepositoryMockCreateExpectationOrigins struct {
-	origin      string
-	originCtx   string
-	originOrder string
+	origin          string
+	originCtx       string
+	originOrder     string
+	originEventType string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -133,7 +632,7 @@ func (mmCreate *mIOrderRepositoryMockCreate) Optional() *mIOrderRepositoryMockCr
 }
 
 // Expect sets up expected params for IOrderRepository.Create
-func (mmCreate *mIOrderRepositoryMockCreate) Expect(ctx context.Context, order models.Order) *mIOrderRepositoryMockCreate {
+func (mmCreate *mIOrderRepositoryMockCreate) Expect(ctx context.Context, order models.Order, eventType string) *mIOrderRepositoryMockCreate {
 	if mmCreate.mock.funcCreate != nil {
 		mmCreate.mock.t.Fatalf("IOrderRepositoryMock.Create mock is already set by Set")
 	}
@@ -146,7 +645,7 @@ func (mmCreate *mIOrderRepositoryMockCreate) Expect(ctx context.Context, order m
 		mmCreate.mock.t.Fatalf("IOrderRepositoryMock.Create mock is already set by ExpectParams functions")
 	}
 
-	mmCreate.defaultExpectation.params = &IOrderRepositoryMockCreateParams{ctx, order}
+	mmCreate.defaultExpectation.params = &IOrderRepositoryMockCreateParams{ctx, order, eventType}
 	mmCreate.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmCreate.expectations {
 		if minimock.Equal(e.params, mmCreate.defaultExpectation.params) {
@@ -203,8 +702,31 @@ func (mmCreate *mIOrderRepositoryMockCreate) ExpectOrderParam2(order models.Orde
 	return mmCreate
 }
 
+// ExpectEventTypeParam3 sets up expected param eventType for IOrderRepository.Create
+func (mmCreate *mIOrderRepositoryMockCreate) ExpectEventTypeParam3(eventType string) *mIOrderRepositoryMockCreate {
+	if mmCreate.mock.funcCreate != nil {
+		mmCreate.mock.t.Fatalf("IOrderRepositoryMock.Create mock is already set by Set")
+	}
+
+	if mmCreate.defaultExpectation == nil {
+		mmCreate.defaultExpectation = &IOrderRepositoryMockCreateExpectation{}
+	}
+
+	if mmCreate.defaultExpectation.params != nil {
+		mmCreate.mock.t.Fatalf("IOrderRepositoryMock.Create mock is already set by Expect")
+	}
+
+	if mmCreate.defaultExpectation.paramPtrs == nil {
+		mmCreate.defaultExpectation.paramPtrs = &IOrderRepositoryMockCreateParamPtrs{}
+	}
+	mmCreate.defaultExpectation.paramPtrs.eventType = &eventType
+	mmCreate.defaultExpectation.expectationOrigins.originEventType = minimock.CallerInfo(1)
+
+	return mmCreate
+}
+
 // Inspect accepts an inspector function that has same arguments as the IOrderRepository.Create
-func (mmCreate *mIOrderRepositoryMockCreate) Inspect(f func(ctx context.Context, order models.Order)) *mIOrderRepositoryMockCreate {
+func (mmCreate *mIOrderRepositoryMockCreate) Inspect(f func(ctx context.Context, order models.Order, eventType string)) *mIOrderRepositoryMockCreate {
 	if mmCreate.mock.inspectFuncCreate != nil {
 		mmCreate.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.Create")
 	}
@@ -229,7 +751,7 @@ func (mmCreate *mIOrderRepositoryMockCreate) Return(o1 models.OID, err error) *I
 }
 
 // Set uses given function f to mock the IOrderRepository.Create method
-func (mmCreate *mIOrderRepositoryMockCreate) Set(f func(ctx context.Context, order models.Order) (o1 models.OID, err error)) *IOrderRepositoryMock {
+func (mmCreate *mIOrderRepositoryMockCreate) Set(f func(ctx context.Context, order models.Order, eventType string) (o1 models.OID, err error)) *IOrderRepositoryMock {
 	if mmCreate.defaultExpectation != nil {
 		mmCreate.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.Create method")
 	}
@@ -245,14 +767,14 @@ func (mmCreate *mIOrderRepositoryMockCreate) Set(f func(ctx context.Context, ord
 
 // When sets expectation for the IOrderRepository.Create which will trigger the result defined by the following
 // Then helper
-func (mmCreate *mIOrderRepositoryMockCreate) When(ctx context.Context, order models.Order) *IOrderRepositoryMockCreateExpectation {
+func (mmCreate *mIOrderRepositoryMockCreate) When(ctx context.Context, order models.Order, eventType string) *IOrderRepositoryMockCreateExpectation {
 	if mmCreate.mock.funcCreate != nil {
 		mmCreate.mock.t.Fatalf("IOrderRepositoryMock.Create mock is already set by Set")
 	}
 
 	expectation := &IOrderRepositoryMockCreateExpectation{
 		mock:               mmCreate.mock,
-		params:             &IOrderRepositoryMockCreateParams{ctx, order},
+		params:             &IOrderRepositoryMockCreateParams{ctx, order, eventType},
 		expectationOrigins: IOrderRepositoryMockCreateExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmCreate.expectations = append(mmCreate.expectations, expectation)
@@ -287,17 +809,17 @@ func (mmCreate *mIOrderRepositoryMockCreate) invocationsDone() bool {
 }
 
 // Create implements mm_service.IOrderRepository
-func (mmCreate *IOrderRepositoryMock) Create(ctx context.Context, order models.Order) (o1 models.OID, err error) {
+func (mmCreate *IOrderRepositoryMock) Create(ctx context.Context, order models.Order, eventType string) (o1 models.OID, err error) {
 	mm_atomic.AddUint64(&mmCreate.beforeCreateCounter, 1)
 	defer mm_atomic.AddUint64(&mmCreate.afterCreateCounter, 1)
 
 	mmCreate.t.Helper()
 
 	if mmCreate.inspectFuncCreate != nil {
-		mmCreate.inspectFuncCreate(ctx, order)
+		mmCreate.inspectFuncCreate(ctx, order, eventType)
 	}
 
-	mm_params := IOrderRepositoryMockCreateParams{ctx, order}
+	mm_params := IOrderRepositoryMockCreateParams{ctx, order, eventType}
 
 	// Record call args
 	mmCreate.CreateMock.mutex.Lock()
@@ -316,7 +838,7 @@ func (mmCreate *IOrderRepositoryMock) Create(ctx context.Context, order models.O
 		mm_want := mmCreate.CreateMock.defaultExpectation.params
 		mm_want_ptrs := mmCreate.CreateMock.defaultExpectation.paramPtrs
 
-		mm_got := IOrderRepositoryMockCreateParams{ctx, order}
+		mm_got := IOrderRepositoryMockCreateParams{ctx, order, eventType}
 
 		if mm_want_ptrs != nil {
 
@@ -330,6 +852,11 @@ func (mmCreate *IOrderRepositoryMock) Create(ctx context.Context, order models.O
 					mmCreate.CreateMock.defaultExpectation.expectationOrigins.originOrder, *mm_want_ptrs.order, mm_got.order, minimock.Diff(*mm_want_ptrs.order, mm_got.order))
 			}
 
+			if mm_want_ptrs.eventType != nil && !minimock.Equal(*mm_want_ptrs.eventType, mm_got.eventType) {
+				mmCreate.t.Errorf("IOrderRepositoryMock.Create got unexpected parameter eventType, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreate.CreateMock.defaultExpectation.expectationOrigins.originEventType, *mm_want_ptrs.eventType, mm_got.eventType, minimock.Diff(*mm_want_ptrs.eventType, mm_got.eventType))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmCreate.t.Errorf("IOrderRepositoryMock.Create got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 				mmCreate.CreateMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
@@ -342,9 +869,9 @@ func (mmCreate *IOrderRepositoryMock) Create(ctx context.Context, order models.O
 		return (*mm_results).o1, (*mm_results).err
 	}
 	if mmCreate.funcCreate != nil {
-		return mmCreate.funcCreate(ctx, order)
+		return mmCreate.funcCreate(ctx, order, eventType)
 	}
-	mmCreate.t.Fatalf("Unexpected call to IOrderRepositoryMock.Create. %v %v", ctx, order)
+	mmCreate.t.Fatalf("Unexpected call to IOrderRepositoryMock.Create. %v %v %v", ctx, order, eventType)
 	return
 }
 
@@ -416,53 +943,1490 @@ func (m *IOrderRepositoryMock) MinimockCreateInspect() {
 	}
 }
 
-type mIOrderRepositoryMockGetByID struct {
+type mIOrderRepositoryMockCreateWithIdempotencyKey struct {
 	optional           bool
 	mock               *IOrderRepositoryMock
-	defaultExpectation *IOrderRepositoryMockGetByIDExpectation
-	expectations       []*IOrderRepositoryMockGetByIDExpectation
+	defaultExpectation *IOrderRepositoryMockCreateWithIdempotencyKeyExpectation
+	expectations       []*IOrderRepositoryMockCreateWithIdempotencyKeyExpectation
 
-	callArgs []*IOrderRepositoryMockGetByIDParams
+	callArgs []*IOrderRepositoryMockCreateWithIdempotencyKeyParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// IOrderRepositoryMockGetByIDExpectation specifies expectation struct of the IOrderRepository.GetByID
-type IOrderRepositoryMockGetByIDExpectation struct {
+// IOrderRepositoryMockCreateWithIdempotencyKeyExpectation specifies expectation struct of the IOrderRepository.CreateWithIdempotencyKey
+type IOrderRepositoryMockCreateWithIdempotencyKeyExpectation struct {
 	mock               *IOrderRepositoryMock
-	params             *IOrderRepositoryMockGetByIDParams
-	paramPtrs          *IOrderRepositoryMockGetByIDParamPtrs
-	expectationOrigins IOrderRepositoryMockGetByIDExpectationOrigins
-	results            *IOrderRepositoryMockGetByIDResults
+	params             *IOrderRepositoryMockCreateWithIdempotencyKeyParams
+	paramPtrs          *IOrderRepositoryMockCreateWithIdempotencyKeyParamPtrs
+	expectationOrigins IOrderRepositoryMockCreateWithIdempotencyKeyExpectationOrigins
+	results            *IOrderRepositoryMockCreateWithIdempotencyKeyResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// IOrderRepositoryMockGetByIDParams contains parameters of the IOrderRepository.GetByID
-type IOrderRepositoryMockGetByIDParams struct {
-	ctx     context.Context
-	orderID models.OID
+// IOrderRepositoryMockCreateWithIdempotencyKeyParams contains parameters of the IOrderRepository.CreateWithIdempotencyKey
+type IOrderRepositoryMockCreateWithIdempotencyKeyParams struct {
+	ctx       context.Context
+	order     models.Order
+	key       models.IdempotencyKey
+	eventType string
 }
 
-// IOrderRepositoryMockGetByIDParamPtrs contains pointers to parameters of the IOrderRepository.GetByID
-type IOrderRepositoryMockGetByIDParamPtrs struct {
-	ctx     *context.Context
-	orderID *models.OID
+// IOrderRepositoryMockCreateWithIdempotencyKeyParamPtrs contains pointers to parameters of the IOrderRepository.CreateWithIdempotencyKey
+type IOrderRepositoryMockCreateWithIdempotencyKeyParamPtrs struct {
+	ctx       *context.Context
+	order     *models.Order
+	key       *models.IdempotencyKey
+	eventType *string
 }
 
-// IOrderRepositoryMockGetByIDResults contains results of the IOrderRepository.GetByID
+// IOrderRepositoryMockCreateWithIdempotencyKeyResults contains results of the IOrderRepository.CreateWithIdempotencyKey
+type IOrderRepositoryMockCreateWithIdempotencyKeyResults struct {
+	o1  models.OID
+	err error
+}
+
+// IOrderRepositoryMockCreateWithIdempotencyKeyOrigins contains origins of expectations of the IOrderRepository.CreateWithIdempotencyKey
+type IOrderRepositoryMockCreateWithIdempotencyKeyExpectationOrigins struct {
+	origin          string
+	originCtx       string
+	originOrder     string
+	originKey       string
+	originEventType string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) Optional() *mIOrderRepositoryMockCreateWithIdempotencyKey {
+	mmCreateWithIdempotencyKey.optional = true
+	return mmCreateWithIdempotencyKey
+}
+
+// Expect sets up expected params for IOrderRepository.CreateWithIdempotencyKey
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) Expect(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string) *mIOrderRepositoryMockCreateWithIdempotencyKey {
+	if mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Set")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation = &IOrderRepositoryMockCreateWithIdempotencyKeyExpectation{}
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by ExpectParams functions")
+	}
+
+	mmCreateWithIdempotencyKey.defaultExpectation.params = &IOrderRepositoryMockCreateWithIdempotencyKeyParams{ctx, order, key, eventType}
+	mmCreateWithIdempotencyKey.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmCreateWithIdempotencyKey.expectations {
+		if minimock.Equal(e.params, mmCreateWithIdempotencyKey.defaultExpectation.params) {
+			mmCreateWithIdempotencyKey.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateWithIdempotencyKey.defaultExpectation.params)
+		}
+	}
+
+	return mmCreateWithIdempotencyKey
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOrderRepository.CreateWithIdempotencyKey
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) ExpectCtxParam1(ctx context.Context) *mIOrderRepositoryMockCreateWithIdempotencyKey {
+	if mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Set")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation = &IOrderRepositoryMockCreateWithIdempotencyKeyExpectation{}
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.params != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Expect")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs = &IOrderRepositoryMockCreateWithIdempotencyKeyParamPtrs{}
+	}
+	mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs.ctx = &ctx
+	mmCreateWithIdempotencyKey.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmCreateWithIdempotencyKey
+}
+
+// ExpectOrderParam2 sets up expected param order for IOrderRepository.CreateWithIdempotencyKey
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) ExpectOrderParam2(order models.Order) *mIOrderRepositoryMockCreateWithIdempotencyKey {
+	if mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Set")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation = &IOrderRepositoryMockCreateWithIdempotencyKeyExpectation{}
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.params != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Expect")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs = &IOrderRepositoryMockCreateWithIdempotencyKeyParamPtrs{}
+	}
+	mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs.order = &order
+	mmCreateWithIdempotencyKey.defaultExpectation.expectationOrigins.originOrder = minimock.CallerInfo(1)
+
+	return mmCreateWithIdempotencyKey
+}
+
+// ExpectKeyParam3 sets up expected param key for IOrderRepository.CreateWithIdempotencyKey
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) ExpectKeyParam3(key models.IdempotencyKey) *mIOrderRepositoryMockCreateWithIdempotencyKey {
+	if mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Set")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation = &IOrderRepositoryMockCreateWithIdempotencyKeyExpectation{}
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.params != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Expect")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs = &IOrderRepositoryMockCreateWithIdempotencyKeyParamPtrs{}
+	}
+	mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs.key = &key
+	mmCreateWithIdempotencyKey.defaultExpectation.expectationOrigins.originKey = minimock.CallerInfo(1)
+
+	return mmCreateWithIdempotencyKey
+}
+
+// ExpectEventTypeParam4 sets up expected param eventType for IOrderRepository.CreateWithIdempotencyKey
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) ExpectEventTypeParam4(eventType string) *mIOrderRepositoryMockCreateWithIdempotencyKey {
+	if mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Set")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation = &IOrderRepositoryMockCreateWithIdempotencyKeyExpectation{}
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.params != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Expect")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs = &IOrderRepositoryMockCreateWithIdempotencyKeyParamPtrs{}
+	}
+	mmCreateWithIdempotencyKey.defaultExpectation.paramPtrs.eventType = &eventType
+	mmCreateWithIdempotencyKey.defaultExpectation.expectationOrigins.originEventType = minimock.CallerInfo(1)
+
+	return mmCreateWithIdempotencyKey
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOrderRepository.CreateWithIdempotencyKey
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) Inspect(f func(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string)) *mIOrderRepositoryMockCreateWithIdempotencyKey {
+	if mmCreateWithIdempotencyKey.mock.inspectFuncCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.CreateWithIdempotencyKey")
+	}
+
+	mmCreateWithIdempotencyKey.mock.inspectFuncCreateWithIdempotencyKey = f
+
+	return mmCreateWithIdempotencyKey
+}
+
+// Return sets up results that will be returned by IOrderRepository.CreateWithIdempotencyKey
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) Return(o1 models.OID, err error) *IOrderRepositoryMock {
+	if mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Set")
+	}
+
+	if mmCreateWithIdempotencyKey.defaultExpectation == nil {
+		mmCreateWithIdempotencyKey.defaultExpectation = &IOrderRepositoryMockCreateWithIdempotencyKeyExpectation{mock: mmCreateWithIdempotencyKey.mock}
+	}
+	mmCreateWithIdempotencyKey.defaultExpectation.results = &IOrderRepositoryMockCreateWithIdempotencyKeyResults{o1, err}
+	mmCreateWithIdempotencyKey.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmCreateWithIdempotencyKey.mock
+}
+
+// Set uses given function f to mock the IOrderRepository.CreateWithIdempotencyKey method
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) Set(f func(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string) (o1 models.OID, err error)) *IOrderRepositoryMock {
+	if mmCreateWithIdempotencyKey.defaultExpectation != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.CreateWithIdempotencyKey method")
+	}
+
+	if len(mmCreateWithIdempotencyKey.expectations) > 0 {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("Some expectations are already set for the IOrderRepository.CreateWithIdempotencyKey method")
+	}
+
+	mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey = f
+	mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKeyOrigin = minimock.CallerInfo(1)
+	return mmCreateWithIdempotencyKey.mock
+}
+
+// When sets expectation for the IOrderRepository.CreateWithIdempotencyKey which will trigger the result defined by the following
+// Then helper
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) When(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string) *IOrderRepositoryMockCreateWithIdempotencyKeyExpectation {
+	if mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.CreateWithIdempotencyKey mock is already set by Set")
+	}
+
+	expectation := &IOrderRepositoryMockCreateWithIdempotencyKeyExpectation{
+		mock:               mmCreateWithIdempotencyKey.mock,
+		params:             &IOrderRepositoryMockCreateWithIdempotencyKeyParams{ctx, order, key, eventType},
+		expectationOrigins: IOrderRepositoryMockCreateWithIdempotencyKeyExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmCreateWithIdempotencyKey.expectations = append(mmCreateWithIdempotencyKey.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOrderRepository.CreateWithIdempotencyKey return parameters for the expectation previously defined by the When method
+func (e *IOrderRepositoryMockCreateWithIdempotencyKeyExpectation) Then(o1 models.OID, err error) *IOrderRepositoryMock {
+	e.results = &IOrderRepositoryMockCreateWithIdempotencyKeyResults{o1, err}
+	return e.mock
+}
+
+// Times sets number of times IOrderRepository.CreateWithIdempotencyKey should be invoked
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) Times(n uint64) *mIOrderRepositoryMockCreateWithIdempotencyKey {
+	if n == 0 {
+		mmCreateWithIdempotencyKey.mock.t.Fatalf("Times of IOrderRepositoryMock.CreateWithIdempotencyKey mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCreateWithIdempotencyKey.expectedInvocations, n)
+	mmCreateWithIdempotencyKey.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmCreateWithIdempotencyKey
+}
+
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) invocationsDone() bool {
+	if len(mmCreateWithIdempotencyKey.expectations) == 0 && mmCreateWithIdempotencyKey.defaultExpectation == nil && mmCreateWithIdempotencyKey.mock.funcCreateWithIdempotencyKey == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCreateWithIdempotencyKey.mock.afterCreateWithIdempotencyKeyCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCreateWithIdempotencyKey.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// CreateWithIdempotencyKey implements mm_service.IOrderRepository
+func (mmCreateWithIdempotencyKey *IOrderRepositoryMock) CreateWithIdempotencyKey(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string) (o1 models.OID, err error) {
+	mm_atomic.AddUint64(&mmCreateWithIdempotencyKey.beforeCreateWithIdempotencyKeyCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateWithIdempotencyKey.afterCreateWithIdempotencyKeyCounter, 1)
+
+	mmCreateWithIdempotencyKey.t.Helper()
+
+	if mmCreateWithIdempotencyKey.inspectFuncCreateWithIdempotencyKey != nil {
+		mmCreateWithIdempotencyKey.inspectFuncCreateWithIdempotencyKey(ctx, order, key, eventType)
+	}
+
+	mm_params := IOrderRepositoryMockCreateWithIdempotencyKeyParams{ctx, order, key, eventType}
+
+	// Record call args
+	mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.mutex.Lock()
+	mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.callArgs = append(mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.callArgs, &mm_params)
+	mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.mutex.Unlock()
+
+	for _, e := range mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.o1, e.results.err
+		}
+	}
+
+	if mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.params
+		mm_want_ptrs := mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.paramPtrs
+
+		mm_got := IOrderRepositoryMockCreateWithIdempotencyKeyParams{ctx, order, key, eventType}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCreateWithIdempotencyKey.t.Errorf("IOrderRepositoryMock.CreateWithIdempotencyKey got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.order != nil && !minimock.Equal(*mm_want_ptrs.order, mm_got.order) {
+				mmCreateWithIdempotencyKey.t.Errorf("IOrderRepositoryMock.CreateWithIdempotencyKey got unexpected parameter order, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.expectationOrigins.originOrder, *mm_want_ptrs.order, mm_got.order, minimock.Diff(*mm_want_ptrs.order, mm_got.order))
+			}
+
+			if mm_want_ptrs.key != nil && !minimock.Equal(*mm_want_ptrs.key, mm_got.key) {
+				mmCreateWithIdempotencyKey.t.Errorf("IOrderRepositoryMock.CreateWithIdempotencyKey got unexpected parameter key, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.expectationOrigins.originKey, *mm_want_ptrs.key, mm_got.key, minimock.Diff(*mm_want_ptrs.key, mm_got.key))
+			}
+
+			if mm_want_ptrs.eventType != nil && !minimock.Equal(*mm_want_ptrs.eventType, mm_got.eventType) {
+				mmCreateWithIdempotencyKey.t.Errorf("IOrderRepositoryMock.CreateWithIdempotencyKey got unexpected parameter eventType, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.expectationOrigins.originEventType, *mm_want_ptrs.eventType, mm_got.eventType, minimock.Diff(*mm_want_ptrs.eventType, mm_got.eventType))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCreateWithIdempotencyKey.t.Errorf("IOrderRepositoryMock.CreateWithIdempotencyKey got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCreateWithIdempotencyKey.CreateWithIdempotencyKeyMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCreateWithIdempotencyKey.t.Fatal("No results are set for the IOrderRepositoryMock.CreateWithIdempotencyKey")
+		}
+		return (*mm_results).o1, (*mm_results).err
+	}
+	if mmCreateWithIdempotencyKey.funcCreateWithIdempotencyKey != nil {
+		return mmCreateWithIdempotencyKey.funcCreateWithIdempotencyKey(ctx, order, key, eventType)
+	}
+	mmCreateWithIdempotencyKey.t.Fatalf("Unexpected call to IOrderRepositoryMock.CreateWithIdempotencyKey. %v %v %v %v", ctx, order, key, eventType)
+	return
+}
+
+// CreateWithIdempotencyKeyAfterCounter returns a count of finished IOrderRepositoryMock.CreateWithIdempotencyKey invocations
+func (mmCreateWithIdempotencyKey *IOrderRepositoryMock) CreateWithIdempotencyKeyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateWithIdempotencyKey.afterCreateWithIdempotencyKeyCounter)
+}
+
+// CreateWithIdempotencyKeyBeforeCounter returns a count of IOrderRepositoryMock.CreateWithIdempotencyKey invocations
+func (mmCreateWithIdempotencyKey *IOrderRepositoryMock) CreateWithIdempotencyKeyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateWithIdempotencyKey.beforeCreateWithIdempotencyKeyCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOrderRepositoryMock.CreateWithIdempotencyKey.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCreateWithIdempotencyKey *mIOrderRepositoryMockCreateWithIdempotencyKey) Calls() []*IOrderRepositoryMockCreateWithIdempotencyKeyParams {
+	mmCreateWithIdempotencyKey.mutex.RLock()
+
+	argCopy := make([]*IOrderRepositoryMockCreateWithIdempotencyKeyParams, len(mmCreateWithIdempotencyKey.callArgs))
+	copy(argCopy, mmCreateWithIdempotencyKey.callArgs)
+
+	mmCreateWithIdempotencyKey.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCreateWithIdempotencyKeyDone returns true if the count of the CreateWithIdempotencyKey invocations corresponds
+// the number of defined expectations
+func (m *IOrderRepositoryMock) MinimockCreateWithIdempotencyKeyDone() bool {
+	if m.CreateWithIdempotencyKeyMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.CreateWithIdempotencyKeyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CreateWithIdempotencyKeyMock.invocationsDone()
+}
+
+// MinimockCreateWithIdempotencyKeyInspect logs each unmet expectation
+func (m *IOrderRepositoryMock) MinimockCreateWithIdempotencyKeyInspect() {
+	for _, e := range m.CreateWithIdempotencyKeyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.CreateWithIdempotencyKey at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterCreateWithIdempotencyKeyCounter := mm_atomic.LoadUint64(&m.afterCreateWithIdempotencyKeyCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CreateWithIdempotencyKeyMock.defaultExpectation != nil && afterCreateWithIdempotencyKeyCounter < 1 {
+		if m.CreateWithIdempotencyKeyMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.CreateWithIdempotencyKey at\n%s", m.CreateWithIdempotencyKeyMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.CreateWithIdempotencyKey at\n%s with params: %#v", m.CreateWithIdempotencyKeyMock.defaultExpectation.expectationOrigins.origin, *m.CreateWithIdempotencyKeyMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCreateWithIdempotencyKey != nil && afterCreateWithIdempotencyKeyCounter < 1 {
+		m.t.Errorf("Expected call to IOrderRepositoryMock.CreateWithIdempotencyKey at\n%s", m.funcCreateWithIdempotencyKeyOrigin)
+	}
+
+	if !m.CreateWithIdempotencyKeyMock.invocationsDone() && afterCreateWithIdempotencyKeyCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOrderRepositoryMock.CreateWithIdempotencyKey at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.CreateWithIdempotencyKeyMock.expectedInvocations), m.CreateWithIdempotencyKeyMock.expectedInvocationsOrigin, afterCreateWithIdempotencyKeyCounter)
+	}
+}
+
+type mIOrderRepositoryMockDeleteExpiredIdempotencyKeys struct {
+	optional           bool
+	mock               *IOrderRepositoryMock
+	defaultExpectation *IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation
+	expectations       []*IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation
+
+	callArgs []*IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation specifies expectation struct of the IOrderRepository.DeleteExpiredIdempotencyKeys
+type IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation struct {
+	mock               *IOrderRepositoryMock
+	params             *IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams
+	paramPtrs          *IOrderRepositoryMockDeleteExpiredIdempotencyKeysParamPtrs
+	expectationOrigins IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectationOrigins
+	results            *IOrderRepositoryMockDeleteExpiredIdempotencyKeysResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams contains parameters of the IOrderRepository.DeleteExpiredIdempotencyKeys
+type IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams struct {
+	ctx context.Context
+}
+
+// IOrderRepositoryMockDeleteExpiredIdempotencyKeysParamPtrs contains pointers to parameters of the IOrderRepository.DeleteExpiredIdempotencyKeys
+type IOrderRepositoryMockDeleteExpiredIdempotencyKeysParamPtrs struct {
+	ctx *context.Context
+}
+
+// IOrderRepositoryMockDeleteExpiredIdempotencyKeysResults contains results of the IOrderRepository.DeleteExpiredIdempotencyKeys
+type IOrderRepositoryMockDeleteExpiredIdempotencyKeysResults struct {
+	i1  int64
+	err error
+}
+
+// IOrderRepositoryMockDeleteExpiredIdempotencyKeysOrigins contains origins of expectations of the IOrderRepository.DeleteExpiredIdempotencyKeys
+type IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectationOrigins struct {
+	origin    string
+	originCtx string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) Optional() *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys {
+	mmDeleteExpiredIdempotencyKeys.optional = true
+	return mmDeleteExpiredIdempotencyKeys
+}
+
+// Expect sets up expected params for IOrderRepository.DeleteExpiredIdempotencyKeys
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) Expect(ctx context.Context) *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys {
+	if mmDeleteExpiredIdempotencyKeys.mock.funcDeleteExpiredIdempotencyKeys != nil {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("IOrderRepositoryMock.DeleteExpiredIdempotencyKeys mock is already set by Set")
+	}
+
+	if mmDeleteExpiredIdempotencyKeys.defaultExpectation == nil {
+		mmDeleteExpiredIdempotencyKeys.defaultExpectation = &IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation{}
+	}
+
+	if mmDeleteExpiredIdempotencyKeys.defaultExpectation.paramPtrs != nil {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("IOrderRepositoryMock.DeleteExpiredIdempotencyKeys mock is already set by ExpectParams functions")
+	}
+
+	mmDeleteExpiredIdempotencyKeys.defaultExpectation.params = &IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams{ctx}
+	mmDeleteExpiredIdempotencyKeys.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmDeleteExpiredIdempotencyKeys.expectations {
+		if minimock.Equal(e.params, mmDeleteExpiredIdempotencyKeys.defaultExpectation.params) {
+			mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteExpiredIdempotencyKeys.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteExpiredIdempotencyKeys
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOrderRepository.DeleteExpiredIdempotencyKeys
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) ExpectCtxParam1(ctx context.Context) *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys {
+	if mmDeleteExpiredIdempotencyKeys.mock.funcDeleteExpiredIdempotencyKeys != nil {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("IOrderRepositoryMock.DeleteExpiredIdempotencyKeys mock is already set by Set")
+	}
+
+	if mmDeleteExpiredIdempotencyKeys.defaultExpectation == nil {
+		mmDeleteExpiredIdempotencyKeys.defaultExpectation = &IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation{}
+	}
+
+	if mmDeleteExpiredIdempotencyKeys.defaultExpectation.params != nil {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("IOrderRepositoryMock.DeleteExpiredIdempotencyKeys mock is already set by Expect")
+	}
+
+	if mmDeleteExpiredIdempotencyKeys.defaultExpectation.paramPtrs == nil {
+		mmDeleteExpiredIdempotencyKeys.defaultExpectation.paramPtrs = &IOrderRepositoryMockDeleteExpiredIdempotencyKeysParamPtrs{}
+	}
+	mmDeleteExpiredIdempotencyKeys.defaultExpectation.paramPtrs.ctx = &ctx
+	mmDeleteExpiredIdempotencyKeys.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmDeleteExpiredIdempotencyKeys
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOrderRepository.DeleteExpiredIdempotencyKeys
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) Inspect(f func(ctx context.Context)) *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys {
+	if mmDeleteExpiredIdempotencyKeys.mock.inspectFuncDeleteExpiredIdempotencyKeys != nil {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.DeleteExpiredIdempotencyKeys")
+	}
+
+	mmDeleteExpiredIdempotencyKeys.mock.inspectFuncDeleteExpiredIdempotencyKeys = f
+
+	return mmDeleteExpiredIdempotencyKeys
+}
+
+// Return sets up results that will be returned by IOrderRepository.DeleteExpiredIdempotencyKeys
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) Return(i1 int64, err error) *IOrderRepositoryMock {
+	if mmDeleteExpiredIdempotencyKeys.mock.funcDeleteExpiredIdempotencyKeys != nil {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("IOrderRepositoryMock.DeleteExpiredIdempotencyKeys mock is already set by Set")
+	}
+
+	if mmDeleteExpiredIdempotencyKeys.defaultExpectation == nil {
+		mmDeleteExpiredIdempotencyKeys.defaultExpectation = &IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation{mock: mmDeleteExpiredIdempotencyKeys.mock}
+	}
+	mmDeleteExpiredIdempotencyKeys.defaultExpectation.results = &IOrderRepositoryMockDeleteExpiredIdempotencyKeysResults{i1, err}
+	mmDeleteExpiredIdempotencyKeys.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmDeleteExpiredIdempotencyKeys.mock
+}
+
+// Set uses given function f to mock the IOrderRepository.DeleteExpiredIdempotencyKeys method
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) Set(f func(ctx context.Context) (i1 int64, err error)) *IOrderRepositoryMock {
+	if mmDeleteExpiredIdempotencyKeys.defaultExpectation != nil {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.DeleteExpiredIdempotencyKeys method")
+	}
+
+	if len(mmDeleteExpiredIdempotencyKeys.expectations) > 0 {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("Some expectations are already set for the IOrderRepository.DeleteExpiredIdempotencyKeys method")
+	}
+
+	mmDeleteExpiredIdempotencyKeys.mock.funcDeleteExpiredIdempotencyKeys = f
+	mmDeleteExpiredIdempotencyKeys.mock.funcDeleteExpiredIdempotencyKeysOrigin = minimock.CallerInfo(1)
+	return mmDeleteExpiredIdempotencyKeys.mock
+}
+
+// When sets expectation for the IOrderRepository.DeleteExpiredIdempotencyKeys which will trigger the result defined by the following
+// Then helper
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) When(ctx context.Context) *IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation {
+	if mmDeleteExpiredIdempotencyKeys.mock.funcDeleteExpiredIdempotencyKeys != nil {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("IOrderRepositoryMock.DeleteExpiredIdempotencyKeys mock is already set by Set")
+	}
+
+	expectation := &IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation{
+		mock:               mmDeleteExpiredIdempotencyKeys.mock,
+		params:             &IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams{ctx},
+		expectationOrigins: IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmDeleteExpiredIdempotencyKeys.expectations = append(mmDeleteExpiredIdempotencyKeys.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOrderRepository.DeleteExpiredIdempotencyKeys return parameters for the expectation previously defined by the When method
+func (e *IOrderRepositoryMockDeleteExpiredIdempotencyKeysExpectation) Then(i1 int64, err error) *IOrderRepositoryMock {
+	e.results = &IOrderRepositoryMockDeleteExpiredIdempotencyKeysResults{i1, err}
+	return e.mock
+}
+
+// Times sets number of times IOrderRepository.DeleteExpiredIdempotencyKeys should be invoked
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) Times(n uint64) *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys {
+	if n == 0 {
+		mmDeleteExpiredIdempotencyKeys.mock.t.Fatalf("Times of IOrderRepositoryMock.DeleteExpiredIdempotencyKeys mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeleteExpiredIdempotencyKeys.expectedInvocations, n)
+	mmDeleteExpiredIdempotencyKeys.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmDeleteExpiredIdempotencyKeys
+}
+
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) invocationsDone() bool {
+	if len(mmDeleteExpiredIdempotencyKeys.expectations) == 0 && mmDeleteExpiredIdempotencyKeys.defaultExpectation == nil && mmDeleteExpiredIdempotencyKeys.mock.funcDeleteExpiredIdempotencyKeys == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeleteExpiredIdempotencyKeys.mock.afterDeleteExpiredIdempotencyKeysCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeleteExpiredIdempotencyKeys.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeleteExpiredIdempotencyKeys implements mm_service.IOrderRepository
+func (mmDeleteExpiredIdempotencyKeys *IOrderRepositoryMock) DeleteExpiredIdempotencyKeys(ctx context.Context) (i1 int64, err error) {
+	mm_atomic.AddUint64(&mmDeleteExpiredIdempotencyKeys.beforeDeleteExpiredIdempotencyKeysCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteExpiredIdempotencyKeys.afterDeleteExpiredIdempotencyKeysCounter, 1)
+
+	mmDeleteExpiredIdempotencyKeys.t.Helper()
+
+	if mmDeleteExpiredIdempotencyKeys.inspectFuncDeleteExpiredIdempotencyKeys != nil {
+		mmDeleteExpiredIdempotencyKeys.inspectFuncDeleteExpiredIdempotencyKeys(ctx)
+	}
+
+	mm_params := IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams{ctx}
+
+	// Record call args
+	mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.mutex.Lock()
+	mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.callArgs = append(mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.callArgs, &mm_params)
+	mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.mutex.Unlock()
+
+	for _, e := range mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.i1, e.results.err
+		}
+	}
+
+	if mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.defaultExpectation.params
+		mm_want_ptrs := mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.defaultExpectation.paramPtrs
+
+		mm_got := IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams{ctx}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeleteExpiredIdempotencyKeys.t.Errorf("IOrderRepositoryMock.DeleteExpiredIdempotencyKeys got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteExpiredIdempotencyKeys.t.Errorf("IOrderRepositoryMock.DeleteExpiredIdempotencyKeys got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteExpiredIdempotencyKeys.DeleteExpiredIdempotencyKeysMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteExpiredIdempotencyKeys.t.Fatal("No results are set for the IOrderRepositoryMock.DeleteExpiredIdempotencyKeys")
+		}
+		return (*mm_results).i1, (*mm_results).err
+	}
+	if mmDeleteExpiredIdempotencyKeys.funcDeleteExpiredIdempotencyKeys != nil {
+		return mmDeleteExpiredIdempotencyKeys.funcDeleteExpiredIdempotencyKeys(ctx)
+	}
+	mmDeleteExpiredIdempotencyKeys.t.Fatalf("Unexpected call to IOrderRepositoryMock.DeleteExpiredIdempotencyKeys. %v", ctx)
+	return
+}
+
+// DeleteExpiredIdempotencyKeysAfterCounter returns a count of finished IOrderRepositoryMock.DeleteExpiredIdempotencyKeys invocations
+func (mmDeleteExpiredIdempotencyKeys *IOrderRepositoryMock) DeleteExpiredIdempotencyKeysAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteExpiredIdempotencyKeys.afterDeleteExpiredIdempotencyKeysCounter)
+}
+
+// DeleteExpiredIdempotencyKeysBeforeCounter returns a count of IOrderRepositoryMock.DeleteExpiredIdempotencyKeys invocations
+func (mmDeleteExpiredIdempotencyKeys *IOrderRepositoryMock) DeleteExpiredIdempotencyKeysBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteExpiredIdempotencyKeys.beforeDeleteExpiredIdempotencyKeysCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOrderRepositoryMock.DeleteExpiredIdempotencyKeys.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteExpiredIdempotencyKeys *mIOrderRepositoryMockDeleteExpiredIdempotencyKeys) Calls() []*IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams {
+	mmDeleteExpiredIdempotencyKeys.mutex.RLock()
+
+	argCopy := make([]*IOrderRepositoryMockDeleteExpiredIdempotencyKeysParams, len(mmDeleteExpiredIdempotencyKeys.callArgs))
+	copy(argCopy, mmDeleteExpiredIdempotencyKeys.callArgs)
+
+	mmDeleteExpiredIdempotencyKeys.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteExpiredIdempotencyKeysDone returns true if the count of the DeleteExpiredIdempotencyKeys invocations corresponds
+// the number of defined expectations
+func (m *IOrderRepositoryMock) MinimockDeleteExpiredIdempotencyKeysDone() bool {
+	if m.DeleteExpiredIdempotencyKeysMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.DeleteExpiredIdempotencyKeysMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeleteExpiredIdempotencyKeysMock.invocationsDone()
+}
+
+// MinimockDeleteExpiredIdempotencyKeysInspect logs each unmet expectation
+func (m *IOrderRepositoryMock) MinimockDeleteExpiredIdempotencyKeysInspect() {
+	for _, e := range m.DeleteExpiredIdempotencyKeysMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.DeleteExpiredIdempotencyKeys at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterDeleteExpiredIdempotencyKeysCounter := mm_atomic.LoadUint64(&m.afterDeleteExpiredIdempotencyKeysCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteExpiredIdempotencyKeysMock.defaultExpectation != nil && afterDeleteExpiredIdempotencyKeysCounter < 1 {
+		if m.DeleteExpiredIdempotencyKeysMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.DeleteExpiredIdempotencyKeys at\n%s", m.DeleteExpiredIdempotencyKeysMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.DeleteExpiredIdempotencyKeys at\n%s with params: %#v", m.DeleteExpiredIdempotencyKeysMock.defaultExpectation.expectationOrigins.origin, *m.DeleteExpiredIdempotencyKeysMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteExpiredIdempotencyKeys != nil && afterDeleteExpiredIdempotencyKeysCounter < 1 {
+		m.t.Errorf("Expected call to IOrderRepositoryMock.DeleteExpiredIdempotencyKeys at\n%s", m.funcDeleteExpiredIdempotencyKeysOrigin)
+	}
+
+	if !m.DeleteExpiredIdempotencyKeysMock.invocationsDone() && afterDeleteExpiredIdempotencyKeysCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOrderRepositoryMock.DeleteExpiredIdempotencyKeys at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.DeleteExpiredIdempotencyKeysMock.expectedInvocations), m.DeleteExpiredIdempotencyKeysMock.expectedInvocationsOrigin, afterDeleteExpiredIdempotencyKeysCounter)
+	}
+}
+
+type mIOrderRepositoryMockGetByID struct {
+	optional           bool
+	mock               *IOrderRepositoryMock
+	defaultExpectation *IOrderRepositoryMockGetByIDExpectation
+	expectations       []*IOrderRepositoryMockGetByIDExpectation
+
+	callArgs []*IOrderRepositoryMockGetByIDParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOrderRepositoryMockGetByIDExpectation specifies expectation struct of the IOrderRepository.GetByID
+type IOrderRepositoryMockGetByIDExpectation struct {
+	mock               *IOrderRepositoryMock
+	params             *IOrderRepositoryMockGetByIDParams
+	paramPtrs          *IOrderRepositoryMockGetByIDParamPtrs
+	expectationOrigins IOrderRepositoryMockGetByIDExpectationOrigins
+	results            *IOrderRepositoryMockGetByIDResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOrderRepositoryMockGetByIDParams contains parameters of the IOrderRepository.GetByID
+type IOrderRepositoryMockGetByIDParams struct {
+	ctx     context.Context
+	orderID models.OID
+}
+
+// IOrderRepositoryMockGetByIDParamPtrs contains pointers to parameters of the IOrderRepository.GetByID
+type IOrderRepositoryMockGetByIDParamPtrs struct {
+	ctx     *context.Context
+	orderID *models.OID
+}
+
+// IOrderRepositoryMockGetByIDResults contains results of the IOrderRepository.GetByID
 type IOrderRepositoryMockGetByIDResults struct {
 	o1  models.Order
 	err error
 }
 
-// IOrderRepositoryMockGetByIDOrigins contains origins of expectations of the IOrderRepository.GetByID
-type IOrderRepositoryMockGetByIDExpectationOrigins struct {
-	origin        string
-	originCtx     string
-	originOrderID string
+// IOrderRepositoryMockGetByIDOrigins contains origins of expectations of the IOrderRepository.GetByID
+type IOrderRepositoryMockGetByIDExpectationOrigins struct {
+	origin        string
+	originCtx     string
+	originOrderID string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetByID *mIOrderRepositoryMockGetByID) Optional() *mIOrderRepositoryMockGetByID {
+	mmGetByID.optional = true
+	return mmGetByID
+}
+
+// Expect sets up expected params for IOrderRepository.GetByID
+func (mmGetByID *mIOrderRepositoryMockGetByID) Expect(ctx context.Context, orderID models.OID) *mIOrderRepositoryMockGetByID {
+	if mmGetByID.mock.funcGetByID != nil {
+		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+	}
+
+	if mmGetByID.defaultExpectation == nil {
+		mmGetByID.defaultExpectation = &IOrderRepositoryMockGetByIDExpectation{}
+	}
+
+	if mmGetByID.defaultExpectation.paramPtrs != nil {
+		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by ExpectParams functions")
+	}
+
+	mmGetByID.defaultExpectation.params = &IOrderRepositoryMockGetByIDParams{ctx, orderID}
+	mmGetByID.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetByID.expectations {
+		if minimock.Equal(e.params, mmGetByID.defaultExpectation.params) {
+			mmGetByID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetByID.defaultExpectation.params)
+		}
+	}
+
+	return mmGetByID
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOrderRepository.GetByID
+func (mmGetByID *mIOrderRepositoryMockGetByID) ExpectCtxParam1(ctx context.Context) *mIOrderRepositoryMockGetByID {
+	if mmGetByID.mock.funcGetByID != nil {
+		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+	}
+
+	if mmGetByID.defaultExpectation == nil {
+		mmGetByID.defaultExpectation = &IOrderRepositoryMockGetByIDExpectation{}
+	}
+
+	if mmGetByID.defaultExpectation.params != nil {
+		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Expect")
+	}
+
+	if mmGetByID.defaultExpectation.paramPtrs == nil {
+		mmGetByID.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetByIDParamPtrs{}
+	}
+	mmGetByID.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetByID.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetByID
+}
+
+// ExpectOrderIDParam2 sets up expected param orderID for IOrderRepository.GetByID
+func (mmGetByID *mIOrderRepositoryMockGetByID) ExpectOrderIDParam2(orderID models.OID) *mIOrderRepositoryMockGetByID {
+	if mmGetByID.mock.funcGetByID != nil {
+		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+	}
+
+	if mmGetByID.defaultExpectation == nil {
+		mmGetByID.defaultExpectation = &IOrderRepositoryMockGetByIDExpectation{}
+	}
+
+	if mmGetByID.defaultExpectation.params != nil {
+		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Expect")
+	}
+
+	if mmGetByID.defaultExpectation.paramPtrs == nil {
+		mmGetByID.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetByIDParamPtrs{}
+	}
+	mmGetByID.defaultExpectation.paramPtrs.orderID = &orderID
+	mmGetByID.defaultExpectation.expectationOrigins.originOrderID = minimock.CallerInfo(1)
+
+	return mmGetByID
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOrderRepository.GetByID
+func (mmGetByID *mIOrderRepositoryMockGetByID) Inspect(f func(ctx context.Context, orderID models.OID)) *mIOrderRepositoryMockGetByID {
+	if mmGetByID.mock.inspectFuncGetByID != nil {
+		mmGetByID.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.GetByID")
+	}
+
+	mmGetByID.mock.inspectFuncGetByID = f
+
+	return mmGetByID
+}
+
+// Return sets up results that will be returned by IOrderRepository.GetByID
+func (mmGetByID *mIOrderRepositoryMockGetByID) Return(o1 models.Order, err error) *IOrderRepositoryMock {
+	if mmGetByID.mock.funcGetByID != nil {
+		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+	}
+
+	if mmGetByID.defaultExpectation == nil {
+		mmGetByID.defaultExpectation = &IOrderRepositoryMockGetByIDExpectation{mock: mmGetByID.mock}
+	}
+	mmGetByID.defaultExpectation.results = &IOrderRepositoryMockGetByIDResults{o1, err}
+	mmGetByID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetByID.mock
+}
+
+// Set uses given function f to mock the IOrderRepository.GetByID method
+func (mmGetByID *mIOrderRepositoryMockGetByID) Set(f func(ctx context.Context, orderID models.OID) (o1 models.Order, err error)) *IOrderRepositoryMock {
+	if mmGetByID.defaultExpectation != nil {
+		mmGetByID.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.GetByID method")
+	}
+
+	if len(mmGetByID.expectations) > 0 {
+		mmGetByID.mock.t.Fatalf("Some expectations are already set for the IOrderRepository.GetByID method")
+	}
+
+	mmGetByID.mock.funcGetByID = f
+	mmGetByID.mock.funcGetByIDOrigin = minimock.CallerInfo(1)
+	return mmGetByID.mock
+}
+
+// When sets expectation for the IOrderRepository.GetByID which will trigger the result defined by the following
+// Then helper
+func (mmGetByID *mIOrderRepositoryMockGetByID) When(ctx context.Context, orderID models.OID) *IOrderRepositoryMockGetByIDExpectation {
+	if mmGetByID.mock.funcGetByID != nil {
+		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+	}
+
+	expectation := &IOrderRepositoryMockGetByIDExpectation{
+		mock:               mmGetByID.mock,
+		params:             &IOrderRepositoryMockGetByIDParams{ctx, orderID},
+		expectationOrigins: IOrderRepositoryMockGetByIDExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetByID.expectations = append(mmGetByID.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOrderRepository.GetByID return parameters for the expectation previously defined by the When method
+func (e *IOrderRepositoryMockGetByIDExpectation) Then(o1 models.Order, err error) *IOrderRepositoryMock {
+	e.results = &IOrderRepositoryMockGetByIDResults{o1, err}
+	return e.mock
+}
+
+// Times sets number of times IOrderRepository.GetByID should be invoked
+func (mmGetByID *mIOrderRepositoryMockGetByID) Times(n uint64) *mIOrderRepositoryMockGetByID {
+	if n == 0 {
+		mmGetByID.mock.t.Fatalf("Times of IOrderRepositoryMock.GetByID mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetByID.expectedInvocations, n)
+	mmGetByID.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetByID
+}
+
+func (mmGetByID *mIOrderRepositoryMockGetByID) invocationsDone() bool {
+	if len(mmGetByID.expectations) == 0 && mmGetByID.defaultExpectation == nil && mmGetByID.mock.funcGetByID == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetByID.mock.afterGetByIDCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetByID.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetByID implements mm_service.IOrderRepository
+func (mmGetByID *IOrderRepositoryMock) GetByID(ctx context.Context, orderID models.OID) (o1 models.Order, err error) {
+	mm_atomic.AddUint64(&mmGetByID.beforeGetByIDCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetByID.afterGetByIDCounter, 1)
+
+	mmGetByID.t.Helper()
+
+	if mmGetByID.inspectFuncGetByID != nil {
+		mmGetByID.inspectFuncGetByID(ctx, orderID)
+	}
+
+	mm_params := IOrderRepositoryMockGetByIDParams{ctx, orderID}
+
+	// Record call args
+	mmGetByID.GetByIDMock.mutex.Lock()
+	mmGetByID.GetByIDMock.callArgs = append(mmGetByID.GetByIDMock.callArgs, &mm_params)
+	mmGetByID.GetByIDMock.mutex.Unlock()
+
+	for _, e := range mmGetByID.GetByIDMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.o1, e.results.err
+		}
+	}
+
+	if mmGetByID.GetByIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetByID.GetByIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetByID.GetByIDMock.defaultExpectation.params
+		mm_want_ptrs := mmGetByID.GetByIDMock.defaultExpectation.paramPtrs
+
+		mm_got := IOrderRepositoryMockGetByIDParams{ctx, orderID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetByID.t.Errorf("IOrderRepositoryMock.GetByID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetByID.GetByIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.orderID != nil && !minimock.Equal(*mm_want_ptrs.orderID, mm_got.orderID) {
+				mmGetByID.t.Errorf("IOrderRepositoryMock.GetByID got unexpected parameter orderID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetByID.GetByIDMock.defaultExpectation.expectationOrigins.originOrderID, *mm_want_ptrs.orderID, mm_got.orderID, minimock.Diff(*mm_want_ptrs.orderID, mm_got.orderID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetByID.t.Errorf("IOrderRepositoryMock.GetByID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetByID.GetByIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetByID.GetByIDMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetByID.t.Fatal("No results are set for the IOrderRepositoryMock.GetByID")
+		}
+		return (*mm_results).o1, (*mm_results).err
+	}
+	if mmGetByID.funcGetByID != nil {
+		return mmGetByID.funcGetByID(ctx, orderID)
+	}
+	mmGetByID.t.Fatalf("Unexpected call to IOrderRepositoryMock.GetByID. %v %v", ctx, orderID)
+	return
+}
+
+// GetByIDAfterCounter returns a count of finished IOrderRepositoryMock.GetByID invocations
+func (mmGetByID *IOrderRepositoryMock) GetByIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetByID.afterGetByIDCounter)
+}
+
+// GetByIDBeforeCounter returns a count of IOrderRepositoryMock.GetByID invocations
+func (mmGetByID *IOrderRepositoryMock) GetByIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetByID.beforeGetByIDCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOrderRepositoryMock.GetByID.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetByID *mIOrderRepositoryMockGetByID) Calls() []*IOrderRepositoryMockGetByIDParams {
+	mmGetByID.mutex.RLock()
+
+	argCopy := make([]*IOrderRepositoryMockGetByIDParams, len(mmGetByID.callArgs))
+	copy(argCopy, mmGetByID.callArgs)
+
+	mmGetByID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetByIDDone returns true if the count of the GetByID invocations corresponds
+// the number of defined expectations
+func (m *IOrderRepositoryMock) MinimockGetByIDDone() bool {
+	if m.GetByIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetByIDMock.invocationsDone()
+}
+
+// MinimockGetByIDInspect logs each unmet expectation
+func (m *IOrderRepositoryMock) MinimockGetByIDInspect() {
+	for _, e := range m.GetByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetByID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetByIDCounter := mm_atomic.LoadUint64(&m.afterGetByIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetByIDMock.defaultExpectation != nil && afterGetByIDCounter < 1 {
+		if m.GetByIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetByID at\n%s", m.GetByIDMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetByID at\n%s with params: %#v", m.GetByIDMock.defaultExpectation.expectationOrigins.origin, *m.GetByIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetByID != nil && afterGetByIDCounter < 1 {
+		m.t.Errorf("Expected call to IOrderRepositoryMock.GetByID at\n%s", m.funcGetByIDOrigin)
+	}
+
+	if !m.GetByIDMock.invocationsDone() && afterGetByIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOrderRepositoryMock.GetByID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetByIDMock.expectedInvocations), m.GetByIDMock.expectedInvocationsOrigin, afterGetByIDCounter)
+	}
+}
+
+type mIOrderRepositoryMockGetExpiredOrders struct {
+	optional           bool
+	mock               *IOrderRepositoryMock
+	defaultExpectation *IOrderRepositoryMockGetExpiredOrdersExpectation
+	expectations       []*IOrderRepositoryMockGetExpiredOrdersExpectation
+
+	callArgs []*IOrderRepositoryMockGetExpiredOrdersParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOrderRepositoryMockGetExpiredOrdersExpectation specifies expectation struct of the IOrderRepository.GetExpiredOrders
+type IOrderRepositoryMockGetExpiredOrdersExpectation struct {
+	mock               *IOrderRepositoryMock
+	params             *IOrderRepositoryMockGetExpiredOrdersParams
+	paramPtrs          *IOrderRepositoryMockGetExpiredOrdersParamPtrs
+	expectationOrigins IOrderRepositoryMockGetExpiredOrdersExpectationOrigins
+	results            *IOrderRepositoryMockGetExpiredOrdersResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOrderRepositoryMockGetExpiredOrdersParams contains parameters of the IOrderRepository.GetExpiredOrders
+type IOrderRepositoryMockGetExpiredOrdersParams struct {
+	ctx            context.Context
+	paymentTimeout time.Duration
+	limit          uint32
+}
+
+// IOrderRepositoryMockGetExpiredOrdersParamPtrs contains pointers to parameters of the IOrderRepository.GetExpiredOrders
+type IOrderRepositoryMockGetExpiredOrdersParamPtrs struct {
+	ctx            *context.Context
+	paymentTimeout *time.Duration
+	limit          *uint32
+}
+
+// IOrderRepositoryMockGetExpiredOrdersResults contains results of the IOrderRepository.GetExpiredOrders
+type IOrderRepositoryMockGetExpiredOrdersResults struct {
+	oa1 []models.Order
+	err error
+}
+
+// IOrderRepositoryMockGetExpiredOrdersOrigins contains origins of expectations of the IOrderRepository.GetExpiredOrders
+type IOrderRepositoryMockGetExpiredOrdersExpectationOrigins struct {
+	origin               string
+	originCtx            string
+	originPaymentTimeout string
+	originLimit          string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) Optional() *mIOrderRepositoryMockGetExpiredOrders {
+	mmGetExpiredOrders.optional = true
+	return mmGetExpiredOrders
+}
+
+// Expect sets up expected params for IOrderRepository.GetExpiredOrders
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) Expect(ctx context.Context, paymentTimeout time.Duration, limit uint32) *mIOrderRepositoryMockGetExpiredOrders {
+	if mmGetExpiredOrders.mock.funcGetExpiredOrders != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Set")
+	}
+
+	if mmGetExpiredOrders.defaultExpectation == nil {
+		mmGetExpiredOrders.defaultExpectation = &IOrderRepositoryMockGetExpiredOrdersExpectation{}
+	}
+
+	if mmGetExpiredOrders.defaultExpectation.paramPtrs != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by ExpectParams functions")
+	}
+
+	mmGetExpiredOrders.defaultExpectation.params = &IOrderRepositoryMockGetExpiredOrdersParams{ctx, paymentTimeout, limit}
+	mmGetExpiredOrders.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetExpiredOrders.expectations {
+		if minimock.Equal(e.params, mmGetExpiredOrders.defaultExpectation.params) {
+			mmGetExpiredOrders.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetExpiredOrders.defaultExpectation.params)
+		}
+	}
+
+	return mmGetExpiredOrders
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOrderRepository.GetExpiredOrders
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) ExpectCtxParam1(ctx context.Context) *mIOrderRepositoryMockGetExpiredOrders {
+	if mmGetExpiredOrders.mock.funcGetExpiredOrders != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Set")
+	}
+
+	if mmGetExpiredOrders.defaultExpectation == nil {
+		mmGetExpiredOrders.defaultExpectation = &IOrderRepositoryMockGetExpiredOrdersExpectation{}
+	}
+
+	if mmGetExpiredOrders.defaultExpectation.params != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Expect")
+	}
+
+	if mmGetExpiredOrders.defaultExpectation.paramPtrs == nil {
+		mmGetExpiredOrders.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetExpiredOrdersParamPtrs{}
+	}
+	mmGetExpiredOrders.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetExpiredOrders.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetExpiredOrders
+}
+
+// ExpectPaymentTimeoutParam2 sets up expected param paymentTimeout for IOrderRepository.GetExpiredOrders
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) ExpectPaymentTimeoutParam2(paymentTimeout time.Duration) *mIOrderRepositoryMockGetExpiredOrders {
+	if mmGetExpiredOrders.mock.funcGetExpiredOrders != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Set")
+	}
+
+	if mmGetExpiredOrders.defaultExpectation == nil {
+		mmGetExpiredOrders.defaultExpectation = &IOrderRepositoryMockGetExpiredOrdersExpectation{}
+	}
+
+	if mmGetExpiredOrders.defaultExpectation.params != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Expect")
+	}
+
+	if mmGetExpiredOrders.defaultExpectation.paramPtrs == nil {
+		mmGetExpiredOrders.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetExpiredOrdersParamPtrs{}
+	}
+	mmGetExpiredOrders.defaultExpectation.paramPtrs.paymentTimeout = &paymentTimeout
+	mmGetExpiredOrders.defaultExpectation.expectationOrigins.originPaymentTimeout = minimock.CallerInfo(1)
+
+	return mmGetExpiredOrders
+}
+
+// ExpectLimitParam3 sets up expected param limit for IOrderRepository.GetExpiredOrders
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) ExpectLimitParam3(limit uint32) *mIOrderRepositoryMockGetExpiredOrders {
+	if mmGetExpiredOrders.mock.funcGetExpiredOrders != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Set")
+	}
+
+	if mmGetExpiredOrders.defaultExpectation == nil {
+		mmGetExpiredOrders.defaultExpectation = &IOrderRepositoryMockGetExpiredOrdersExpectation{}
+	}
+
+	if mmGetExpiredOrders.defaultExpectation.params != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Expect")
+	}
+
+	if mmGetExpiredOrders.defaultExpectation.paramPtrs == nil {
+		mmGetExpiredOrders.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetExpiredOrdersParamPtrs{}
+	}
+	mmGetExpiredOrders.defaultExpectation.paramPtrs.limit = &limit
+	mmGetExpiredOrders.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmGetExpiredOrders
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOrderRepository.GetExpiredOrders
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) Inspect(f func(ctx context.Context, paymentTimeout time.Duration, limit uint32)) *mIOrderRepositoryMockGetExpiredOrders {
+	if mmGetExpiredOrders.mock.inspectFuncGetExpiredOrders != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.GetExpiredOrders")
+	}
+
+	mmGetExpiredOrders.mock.inspectFuncGetExpiredOrders = f
+
+	return mmGetExpiredOrders
+}
+
+// Return sets up results that will be returned by IOrderRepository.GetExpiredOrders
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) Return(oa1 []models.Order, err error) *IOrderRepositoryMock {
+	if mmGetExpiredOrders.mock.funcGetExpiredOrders != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Set")
+	}
+
+	if mmGetExpiredOrders.defaultExpectation == nil {
+		mmGetExpiredOrders.defaultExpectation = &IOrderRepositoryMockGetExpiredOrdersExpectation{mock: mmGetExpiredOrders.mock}
+	}
+	mmGetExpiredOrders.defaultExpectation.results = &IOrderRepositoryMockGetExpiredOrdersResults{oa1, err}
+	mmGetExpiredOrders.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetExpiredOrders.mock
+}
+
+// Set uses given function f to mock the IOrderRepository.GetExpiredOrders method
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) Set(f func(ctx context.Context, paymentTimeout time.Duration, limit uint32) (oa1 []models.Order, err error)) *IOrderRepositoryMock {
+	if mmGetExpiredOrders.defaultExpectation != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.GetExpiredOrders method")
+	}
+
+	if len(mmGetExpiredOrders.expectations) > 0 {
+		mmGetExpiredOrders.mock.t.Fatalf("Some expectations are already set for the IOrderRepository.GetExpiredOrders method")
+	}
+
+	mmGetExpiredOrders.mock.funcGetExpiredOrders = f
+	mmGetExpiredOrders.mock.funcGetExpiredOrdersOrigin = minimock.CallerInfo(1)
+	return mmGetExpiredOrders.mock
+}
+
+// When sets expectation for the IOrderRepository.GetExpiredOrders which will trigger the result defined by the following
+// Then helper
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) When(ctx context.Context, paymentTimeout time.Duration, limit uint32) *IOrderRepositoryMockGetExpiredOrdersExpectation {
+	if mmGetExpiredOrders.mock.funcGetExpiredOrders != nil {
+		mmGetExpiredOrders.mock.t.Fatalf("IOrderRepositoryMock.GetExpiredOrders mock is already set by Set")
+	}
+
+	expectation := &IOrderRepositoryMockGetExpiredOrdersExpectation{
+		mock:               mmGetExpiredOrders.mock,
+		params:             &IOrderRepositoryMockGetExpiredOrdersParams{ctx, paymentTimeout, limit},
+		expectationOrigins: IOrderRepositoryMockGetExpiredOrdersExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetExpiredOrders.expectations = append(mmGetExpiredOrders.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOrderRepository.GetExpiredOrders return parameters for the expectation previously defined by the When method
+func (e *IOrderRepositoryMockGetExpiredOrdersExpectation) Then(oa1 []models.Order, err error) *IOrderRepositoryMock {
+	e.results = &IOrderRepositoryMockGetExpiredOrdersResults{oa1, err}
+	return e.mock
+}
+
+// Times sets number of times IOrderRepository.GetExpiredOrders should be invoked
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) Times(n uint64) *mIOrderRepositoryMockGetExpiredOrders {
+	if n == 0 {
+		mmGetExpiredOrders.mock.t.Fatalf("Times of IOrderRepositoryMock.GetExpiredOrders mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetExpiredOrders.expectedInvocations, n)
+	mmGetExpiredOrders.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetExpiredOrders
+}
+
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) invocationsDone() bool {
+	if len(mmGetExpiredOrders.expectations) == 0 && mmGetExpiredOrders.defaultExpectation == nil && mmGetExpiredOrders.mock.funcGetExpiredOrders == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetExpiredOrders.mock.afterGetExpiredOrdersCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetExpiredOrders.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetExpiredOrders implements mm_service.IOrderRepository
+func (mmGetExpiredOrders *IOrderRepositoryMock) GetExpiredOrders(ctx context.Context, paymentTimeout time.Duration, limit uint32) (oa1 []models.Order, err error) {
+	mm_atomic.AddUint64(&mmGetExpiredOrders.beforeGetExpiredOrdersCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetExpiredOrders.afterGetExpiredOrdersCounter, 1)
+
+	mmGetExpiredOrders.t.Helper()
+
+	if mmGetExpiredOrders.inspectFuncGetExpiredOrders != nil {
+		mmGetExpiredOrders.inspectFuncGetExpiredOrders(ctx, paymentTimeout, limit)
+	}
+
+	mm_params := IOrderRepositoryMockGetExpiredOrdersParams{ctx, paymentTimeout, limit}
+
+	// Record call args
+	mmGetExpiredOrders.GetExpiredOrdersMock.mutex.Lock()
+	mmGetExpiredOrders.GetExpiredOrdersMock.callArgs = append(mmGetExpiredOrders.GetExpiredOrdersMock.callArgs, &mm_params)
+	mmGetExpiredOrders.GetExpiredOrdersMock.mutex.Unlock()
+
+	for _, e := range mmGetExpiredOrders.GetExpiredOrdersMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.oa1, e.results.err
+		}
+	}
+
+	if mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation.params
+		mm_want_ptrs := mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation.paramPtrs
+
+		mm_got := IOrderRepositoryMockGetExpiredOrdersParams{ctx, paymentTimeout, limit}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetExpiredOrders.t.Errorf("IOrderRepositoryMock.GetExpiredOrders got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.paymentTimeout != nil && !minimock.Equal(*mm_want_ptrs.paymentTimeout, mm_got.paymentTimeout) {
+				mmGetExpiredOrders.t.Errorf("IOrderRepositoryMock.GetExpiredOrders got unexpected parameter paymentTimeout, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation.expectationOrigins.originPaymentTimeout, *mm_want_ptrs.paymentTimeout, mm_got.paymentTimeout, minimock.Diff(*mm_want_ptrs.paymentTimeout, mm_got.paymentTimeout))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetExpiredOrders.t.Errorf("IOrderRepositoryMock.GetExpiredOrders got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetExpiredOrders.t.Errorf("IOrderRepositoryMock.GetExpiredOrders got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetExpiredOrders.GetExpiredOrdersMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetExpiredOrders.t.Fatal("No results are set for the IOrderRepositoryMock.GetExpiredOrders")
+		}
+		return (*mm_results).oa1, (*mm_results).err
+	}
+	if mmGetExpiredOrders.funcGetExpiredOrders != nil {
+		return mmGetExpiredOrders.funcGetExpiredOrders(ctx, paymentTimeout, limit)
+	}
+	mmGetExpiredOrders.t.Fatalf("Unexpected call to IOrderRepositoryMock.GetExpiredOrders. %v %v %v", ctx, paymentTimeout, limit)
+	return
+}
+
+// GetExpiredOrdersAfterCounter returns a count of finished IOrderRepositoryMock.GetExpiredOrders invocations
+func (mmGetExpiredOrders *IOrderRepositoryMock) GetExpiredOrdersAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetExpiredOrders.afterGetExpiredOrdersCounter)
+}
+
+// GetExpiredOrdersBeforeCounter returns a count of IOrderRepositoryMock.GetExpiredOrders invocations
+func (mmGetExpiredOrders *IOrderRepositoryMock) GetExpiredOrdersBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetExpiredOrders.beforeGetExpiredOrdersCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOrderRepositoryMock.GetExpiredOrders.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetExpiredOrders *mIOrderRepositoryMockGetExpiredOrders) Calls() []*IOrderRepositoryMockGetExpiredOrdersParams {
+	mmGetExpiredOrders.mutex.RLock()
+
+	argCopy := make([]*IOrderRepositoryMockGetExpiredOrdersParams, len(mmGetExpiredOrders.callArgs))
+	copy(argCopy, mmGetExpiredOrders.callArgs)
+
+	mmGetExpiredOrders.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetExpiredOrdersDone returns true if the count of the GetExpiredOrders invocations corresponds
+// the number of defined expectations
+func (m *IOrderRepositoryMock) MinimockGetExpiredOrdersDone() bool {
+	if m.GetExpiredOrdersMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetExpiredOrdersMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetExpiredOrdersMock.invocationsDone()
+}
+
+// MinimockGetExpiredOrdersInspect logs each unmet expectation
+func (m *IOrderRepositoryMock) MinimockGetExpiredOrdersInspect() {
+	for _, e := range m.GetExpiredOrdersMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetExpiredOrders at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetExpiredOrdersCounter := mm_atomic.LoadUint64(&m.afterGetExpiredOrdersCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetExpiredOrdersMock.defaultExpectation != nil && afterGetExpiredOrdersCounter < 1 {
+		if m.GetExpiredOrdersMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetExpiredOrders at\n%s", m.GetExpiredOrdersMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetExpiredOrders at\n%s with params: %#v", m.GetExpiredOrdersMock.defaultExpectation.expectationOrigins.origin, *m.GetExpiredOrdersMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetExpiredOrders != nil && afterGetExpiredOrdersCounter < 1 {
+		m.t.Errorf("Expected call to IOrderRepositoryMock.GetExpiredOrders at\n%s", m.funcGetExpiredOrdersOrigin)
+	}
+
+	if !m.GetExpiredOrdersMock.invocationsDone() && afterGetExpiredOrdersCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOrderRepositoryMock.GetExpiredOrders at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetExpiredOrdersMock.expectedInvocations), m.GetExpiredOrdersMock.expectedInvocationsOrigin, afterGetExpiredOrdersCounter)
+	}
+}
+
+type mIOrderRepositoryMockGetIdempotencyKey struct {
+	optional           bool
+	mock               *IOrderRepositoryMock
+	defaultExpectation *IOrderRepositoryMockGetIdempotencyKeyExpectation
+	expectations       []*IOrderRepositoryMockGetIdempotencyKeyExpectation
+
+	callArgs []*IOrderRepositoryMockGetIdempotencyKeyParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOrderRepositoryMockGetIdempotencyKeyExpectation specifies expectation struct of the IOrderRepository.GetIdempotencyKey
+type IOrderRepositoryMockGetIdempotencyKeyExpectation struct {
+	mock               *IOrderRepositoryMock
+	params             *IOrderRepositoryMockGetIdempotencyKeyParams
+	paramPtrs          *IOrderRepositoryMockGetIdempotencyKeyParamPtrs
+	expectationOrigins IOrderRepositoryMockGetIdempotencyKeyExpectationOrigins
+	results            *IOrderRepositoryMockGetIdempotencyKeyResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOrderRepositoryMockGetIdempotencyKeyParams contains parameters of the IOrderRepository.GetIdempotencyKey
+type IOrderRepositoryMockGetIdempotencyKeyParams struct {
+	ctx    context.Context
+	userID models.UID
+	key    string
+}
+
+// IOrderRepositoryMockGetIdempotencyKeyParamPtrs contains pointers to parameters of the IOrderRepository.GetIdempotencyKey
+type IOrderRepositoryMockGetIdempotencyKeyParamPtrs struct {
+	ctx    *context.Context
+	userID *models.UID
+	key    *string
+}
+
+// IOrderRepositoryMockGetIdempotencyKeyResults contains results of the IOrderRepository.GetIdempotencyKey
+type IOrderRepositoryMockGetIdempotencyKeyResults struct {
+	i1  models.IdempotencyKey
+	err error
+}
+
+// IOrderRepositoryMockGetIdempotencyKeyOrigins contains origins of expectations of the IOrderRepository.GetIdempotencyKey
+type IOrderRepositoryMockGetIdempotencyKeyExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originUserID string
+	originKey    string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -470,292 +2434,320 @@ type IOrderRepositoryMockGetByIDExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmGetByID *mIOrderRepositoryMockGetByID) Optional() *mIOrderRepositoryMockGetByID {
-	mmGetByID.optional = true
-	return mmGetByID
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) Optional() *mIOrderRepositoryMockGetIdempotencyKey {
+	mmGetIdempotencyKey.optional = true
+	return mmGetIdempotencyKey
 }
 
-// Expect sets up expected params for IOrderRepository.GetByID
-func (mmGetByID *mIOrderRepositoryMockGetByID) Expect(ctx context.Context, orderID models.OID) *mIOrderRepositoryMockGetByID {
-	if mmGetByID.mock.funcGetByID != nil {
-		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+// Expect sets up expected params for IOrderRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) Expect(ctx context.Context, userID models.UID, key string) *mIOrderRepositoryMockGetIdempotencyKey {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Set")
 	}
 
-	if mmGetByID.defaultExpectation == nil {
-		mmGetByID.defaultExpectation = &IOrderRepositoryMockGetByIDExpectation{}
+	if mmGetIdempotencyKey.defaultExpectation == nil {
+		mmGetIdempotencyKey.defaultExpectation = &IOrderRepositoryMockGetIdempotencyKeyExpectation{}
 	}
 
-	if mmGetByID.defaultExpectation.paramPtrs != nil {
-		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by ExpectParams functions")
+	if mmGetIdempotencyKey.defaultExpectation.paramPtrs != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by ExpectParams functions")
 	}
 
-	mmGetByID.defaultExpectation.params = &IOrderRepositoryMockGetByIDParams{ctx, orderID}
-	mmGetByID.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmGetByID.expectations {
-		if minimock.Equal(e.params, mmGetByID.defaultExpectation.params) {
-			mmGetByID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetByID.defaultExpectation.params)
+	mmGetIdempotencyKey.defaultExpectation.params = &IOrderRepositoryMockGetIdempotencyKeyParams{ctx, userID, key}
+	mmGetIdempotencyKey.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetIdempotencyKey.expectations {
+		if minimock.Equal(e.params, mmGetIdempotencyKey.defaultExpectation.params) {
+			mmGetIdempotencyKey.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetIdempotencyKey.defaultExpectation.params)
 		}
 	}
 
-	return mmGetByID
+	return mmGetIdempotencyKey
 }
 
-// ExpectCtxParam1 sets up expected param ctx for IOrderRepository.GetByID
-func (mmGetByID *mIOrderRepositoryMockGetByID) ExpectCtxParam1(ctx context.Context) *mIOrderRepositoryMockGetByID {
-	if mmGetByID.mock.funcGetByID != nil {
-		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for IOrderRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) ExpectCtxParam1(ctx context.Context) *mIOrderRepositoryMockGetIdempotencyKey {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Set")
 	}
 
-	if mmGetByID.defaultExpectation == nil {
-		mmGetByID.defaultExpectation = &IOrderRepositoryMockGetByIDExpectation{}
+	if mmGetIdempotencyKey.defaultExpectation == nil {
+		mmGetIdempotencyKey.defaultExpectation = &IOrderRepositoryMockGetIdempotencyKeyExpectation{}
 	}
 
-	if mmGetByID.defaultExpectation.params != nil {
-		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Expect")
+	if mmGetIdempotencyKey.defaultExpectation.params != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Expect")
 	}
 
-	if mmGetByID.defaultExpectation.paramPtrs == nil {
-		mmGetByID.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetByIDParamPtrs{}
+	if mmGetIdempotencyKey.defaultExpectation.paramPtrs == nil {
+		mmGetIdempotencyKey.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetIdempotencyKeyParamPtrs{}
 	}
-	mmGetByID.defaultExpectation.paramPtrs.ctx = &ctx
-	mmGetByID.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+	mmGetIdempotencyKey.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetIdempotencyKey.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmGetByID
+	return mmGetIdempotencyKey
 }
 
-// ExpectOrderIDParam2 sets up expected param orderID for IOrderRepository.GetByID
-func (mmGetByID *mIOrderRepositoryMockGetByID) ExpectOrderIDParam2(orderID models.OID) *mIOrderRepositoryMockGetByID {
-	if mmGetByID.mock.funcGetByID != nil {
-		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+// ExpectUserIDParam2 sets up expected param userID for IOrderRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) ExpectUserIDParam2(userID models.UID) *mIOrderRepositoryMockGetIdempotencyKey {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Set")
 	}
 
-	if mmGetByID.defaultExpectation == nil {
-		mmGetByID.defaultExpectation = &IOrderRepositoryMockGetByIDExpectation{}
+	if mmGetIdempotencyKey.defaultExpectation == nil {
+		mmGetIdempotencyKey.defaultExpectation = &IOrderRepositoryMockGetIdempotencyKeyExpectation{}
 	}
 
-	if mmGetByID.defaultExpectation.params != nil {
-		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Expect")
+	if mmGetIdempotencyKey.defaultExpectation.params != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Expect")
 	}
 
-	if mmGetByID.defaultExpectation.paramPtrs == nil {
-		mmGetByID.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetByIDParamPtrs{}
+	if mmGetIdempotencyKey.defaultExpectation.paramPtrs == nil {
+		mmGetIdempotencyKey.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetIdempotencyKeyParamPtrs{}
 	}
-	mmGetByID.defaultExpectation.paramPtrs.orderID = &orderID
-	mmGetByID.defaultExpectation.expectationOrigins.originOrderID = minimock.CallerInfo(1)
+	mmGetIdempotencyKey.defaultExpectation.paramPtrs.userID = &userID
+	mmGetIdempotencyKey.defaultExpectation.expectationOrigins.originUserID = minimock.CallerInfo(1)
 
-	return mmGetByID
+	return mmGetIdempotencyKey
 }
 
-// Inspect accepts an inspector function that has same arguments as the IOrderRepository.GetByID
-func (mmGetByID *mIOrderRepositoryMockGetByID) Inspect(f func(ctx context.Context, orderID models.OID)) *mIOrderRepositoryMockGetByID {
-	if mmGetByID.mock.inspectFuncGetByID != nil {
-		mmGetByID.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.GetByID")
+// ExpectKeyParam3 sets up expected param key for IOrderRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) ExpectKeyParam3(key string) *mIOrderRepositoryMockGetIdempotencyKey {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Set")
 	}
 
-	mmGetByID.mock.inspectFuncGetByID = f
+	if mmGetIdempotencyKey.defaultExpectation == nil {
+		mmGetIdempotencyKey.defaultExpectation = &IOrderRepositoryMockGetIdempotencyKeyExpectation{}
+	}
 
-	return mmGetByID
+	if mmGetIdempotencyKey.defaultExpectation.params != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Expect")
+	}
+
+	if mmGetIdempotencyKey.defaultExpectation.paramPtrs == nil {
+		mmGetIdempotencyKey.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetIdempotencyKeyParamPtrs{}
+	}
+	mmGetIdempotencyKey.defaultExpectation.paramPtrs.key = &key
+	mmGetIdempotencyKey.defaultExpectation.expectationOrigins.originKey = minimock.CallerInfo(1)
+
+	return mmGetIdempotencyKey
 }
 
-// Return sets up results that will be returned by IOrderRepository.GetByID
-func (mmGetByID *mIOrderRepositoryMockGetByID) Return(o1 models.Order, err error) *IOrderRepositoryMock {
-	if mmGetByID.mock.funcGetByID != nil {
-		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+// Inspect accepts an inspector function that has same arguments as the IOrderRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) Inspect(f func(ctx context.Context, userID models.UID, key string)) *mIOrderRepositoryMockGetIdempotencyKey {
+	if mmGetIdempotencyKey.mock.inspectFuncGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.GetIdempotencyKey")
 	}
 
-	if mmGetByID.defaultExpectation == nil {
-		mmGetByID.defaultExpectation = &IOrderRepositoryMockGetByIDExpectation{mock: mmGetByID.mock}
+	mmGetIdempotencyKey.mock.inspectFuncGetIdempotencyKey = f
+
+	return mmGetIdempotencyKey
+}
+
+// Return sets up results that will be returned by IOrderRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) Return(i1 models.IdempotencyKey, err error) *IOrderRepositoryMock {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Set")
 	}
-	mmGetByID.defaultExpectation.results = &IOrderRepositoryMockGetByIDResults{o1, err}
-	mmGetByID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmGetByID.mock
+
+	if mmGetIdempotencyKey.defaultExpectation == nil {
+		mmGetIdempotencyKey.defaultExpectation = &IOrderRepositoryMockGetIdempotencyKeyExpectation{mock: mmGetIdempotencyKey.mock}
+	}
+	mmGetIdempotencyKey.defaultExpectation.results = &IOrderRepositoryMockGetIdempotencyKeyResults{i1, err}
+	mmGetIdempotencyKey.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetIdempotencyKey.mock
 }
 
-// Set uses given function f to mock the IOrderRepository.GetByID method
-func (mmGetByID *mIOrderRepositoryMockGetByID) Set(f func(ctx context.Context, orderID models.OID) (o1 models.Order, err error)) *IOrderRepositoryMock {
-	if mmGetByID.defaultExpectation != nil {
-		mmGetByID.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.GetByID method")
+// Set uses given function f to mock the IOrderRepository.GetIdempotencyKey method
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) Set(f func(ctx context.Context, userID models.UID, key string) (i1 models.IdempotencyKey, err error)) *IOrderRepositoryMock {
+	if mmGetIdempotencyKey.defaultExpectation != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.GetIdempotencyKey method")
 	}
 
-	if len(mmGetByID.expectations) > 0 {
-		mmGetByID.mock.t.Fatalf("Some expectations are already set for the IOrderRepository.GetByID method")
+	if len(mmGetIdempotencyKey.expectations) > 0 {
+		mmGetIdempotencyKey.mock.t.Fatalf("Some expectations are already set for the IOrderRepository.GetIdempotencyKey method")
 	}
 
-	mmGetByID.mock.funcGetByID = f
-	mmGetByID.mock.funcGetByIDOrigin = minimock.CallerInfo(1)
-	return mmGetByID.mock
+	mmGetIdempotencyKey.mock.funcGetIdempotencyKey = f
+	mmGetIdempotencyKey.mock.funcGetIdempotencyKeyOrigin = minimock.CallerInfo(1)
+	return mmGetIdempotencyKey.mock
 }
 
-// When sets expectation for the IOrderRepository.GetByID which will trigger the result defined by the following
+// When sets expectation for the IOrderRepository.GetIdempotencyKey which will trigger the result defined by the following
 // Then helper
-func (mmGetByID *mIOrderRepositoryMockGetByID) When(ctx context.Context, orderID models.OID) *IOrderRepositoryMockGetByIDExpectation {
-	if mmGetByID.mock.funcGetByID != nil {
-		mmGetByID.mock.t.Fatalf("IOrderRepositoryMock.GetByID mock is already set by Set")
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) When(ctx context.Context, userID models.UID, key string) *IOrderRepositoryMockGetIdempotencyKeyExpectation {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IOrderRepositoryMock.GetIdempotencyKey mock is already set by Set")
 	}
 
-	expectation := &IOrderRepositoryMockGetByIDExpectation{
-		mock:               mmGetByID.mock,
-		params:             &IOrderRepositoryMockGetByIDParams{ctx, orderID},
-		expectationOrigins: IOrderRepositoryMockGetByIDExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &IOrderRepositoryMockGetIdempotencyKeyExpectation{
+		mock:               mmGetIdempotencyKey.mock,
+		params:             &IOrderRepositoryMockGetIdempotencyKeyParams{ctx, userID, key},
+		expectationOrigins: IOrderRepositoryMockGetIdempotencyKeyExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmGetByID.expectations = append(mmGetByID.expectations, expectation)
+	mmGetIdempotencyKey.expectations = append(mmGetIdempotencyKey.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IOrderRepository.GetByID return parameters for the expectation previously defined by the When method
-func (e *IOrderRepositoryMockGetByIDExpectation) Then(o1 models.Order, err error) *IOrderRepositoryMock {
-	e.results = &IOrderRepositoryMockGetByIDResults{o1, err}
+// Then sets up IOrderRepository.GetIdempotencyKey return parameters for the expectation previously defined by the When method
+func (e *IOrderRepositoryMockGetIdempotencyKeyExpectation) Then(i1 models.IdempotencyKey, err error) *IOrderRepositoryMock {
+	e.results = &IOrderRepositoryMockGetIdempotencyKeyResults{i1, err}
 	return e.mock
 }
 
-// Times sets number of times IOrderRepository.GetByID should be invoked
-func (mmGetByID *mIOrderRepositoryMockGetByID) Times(n uint64) *mIOrderRepositoryMockGetByID {
+// Times sets number of times IOrderRepository.GetIdempotencyKey should be invoked
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) Times(n uint64) *mIOrderRepositoryMockGetIdempotencyKey {
 	if n == 0 {
-		mmGetByID.mock.t.Fatalf("Times of IOrderRepositoryMock.GetByID mock can not be zero")
+		mmGetIdempotencyKey.mock.t.Fatalf("Times of IOrderRepositoryMock.GetIdempotencyKey mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmGetByID.expectedInvocations, n)
-	mmGetByID.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmGetByID
+	mm_atomic.StoreUint64(&mmGetIdempotencyKey.expectedInvocations, n)
+	mmGetIdempotencyKey.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetIdempotencyKey
 }
 
-func (mmGetByID *mIOrderRepositoryMockGetByID) invocationsDone() bool {
-	if len(mmGetByID.expectations) == 0 && mmGetByID.defaultExpectation == nil && mmGetByID.mock.funcGetByID == nil {
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) invocationsDone() bool {
+	if len(mmGetIdempotencyKey.expectations) == 0 && mmGetIdempotencyKey.defaultExpectation == nil && mmGetIdempotencyKey.mock.funcGetIdempotencyKey == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmGetByID.mock.afterGetByIDCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmGetByID.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmGetIdempotencyKey.mock.afterGetIdempotencyKeyCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetIdempotencyKey.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// GetByID implements mm_service.IOrderRepository
-func (mmGetByID *IOrderRepositoryMock) GetByID(ctx context.Context, orderID models.OID) (o1 models.Order, err error) {
-	mm_atomic.AddUint64(&mmGetByID.beforeGetByIDCounter, 1)
-	defer mm_atomic.AddUint64(&mmGetByID.afterGetByIDCounter, 1)
+// GetIdempotencyKey implements mm_service.IOrderRepository
+func (mmGetIdempotencyKey *IOrderRepositoryMock) GetIdempotencyKey(ctx context.Context, userID models.UID, key string) (i1 models.IdempotencyKey, err error) {
+	mm_atomic.AddUint64(&mmGetIdempotencyKey.beforeGetIdempotencyKeyCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetIdempotencyKey.afterGetIdempotencyKeyCounter, 1)
 
-	mmGetByID.t.Helper()
+	mmGetIdempotencyKey.t.Helper()
 
-	if mmGetByID.inspectFuncGetByID != nil {
-		mmGetByID.inspectFuncGetByID(ctx, orderID)
+	if mmGetIdempotencyKey.inspectFuncGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.inspectFuncGetIdempotencyKey(ctx, userID, key)
 	}
 
-	mm_params := IOrderRepositoryMockGetByIDParams{ctx, orderID}
+	mm_params := IOrderRepositoryMockGetIdempotencyKeyParams{ctx, userID, key}
 
 	// Record call args
-	mmGetByID.GetByIDMock.mutex.Lock()
-	mmGetByID.GetByIDMock.callArgs = append(mmGetByID.GetByIDMock.callArgs, &mm_params)
-	mmGetByID.GetByIDMock.mutex.Unlock()
+	mmGetIdempotencyKey.GetIdempotencyKeyMock.mutex.Lock()
+	mmGetIdempotencyKey.GetIdempotencyKeyMock.callArgs = append(mmGetIdempotencyKey.GetIdempotencyKeyMock.callArgs, &mm_params)
+	mmGetIdempotencyKey.GetIdempotencyKeyMock.mutex.Unlock()
 
-	for _, e := range mmGetByID.GetByIDMock.expectations {
+	for _, e := range mmGetIdempotencyKey.GetIdempotencyKeyMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.o1, e.results.err
+			return e.results.i1, e.results.err
 		}
 	}
 
-	if mmGetByID.GetByIDMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmGetByID.GetByIDMock.defaultExpectation.Counter, 1)
-		mm_want := mmGetByID.GetByIDMock.defaultExpectation.params
-		mm_want_ptrs := mmGetByID.GetByIDMock.defaultExpectation.paramPtrs
+	if mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.params
+		mm_want_ptrs := mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.paramPtrs
 
-		mm_got := IOrderRepositoryMockGetByIDParams{ctx, orderID}
+		mm_got := IOrderRepositoryMockGetIdempotencyKeyParams{ctx, userID, key}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmGetByID.t.Errorf("IOrderRepositoryMock.GetByID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmGetByID.GetByIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmGetIdempotencyKey.t.Errorf("IOrderRepositoryMock.GetIdempotencyKey got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.orderID != nil && !minimock.Equal(*mm_want_ptrs.orderID, mm_got.orderID) {
-				mmGetByID.t.Errorf("IOrderRepositoryMock.GetByID got unexpected parameter orderID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmGetByID.GetByIDMock.defaultExpectation.expectationOrigins.originOrderID, *mm_want_ptrs.orderID, mm_got.orderID, minimock.Diff(*mm_want_ptrs.orderID, mm_got.orderID))
+			if mm_want_ptrs.userID != nil && !minimock.Equal(*mm_want_ptrs.userID, mm_got.userID) {
+				mmGetIdempotencyKey.t.Errorf("IOrderRepositoryMock.GetIdempotencyKey got unexpected parameter userID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.expectationOrigins.originUserID, *mm_want_ptrs.userID, mm_got.userID, minimock.Diff(*mm_want_ptrs.userID, mm_got.userID))
+			}
+
+			if mm_want_ptrs.key != nil && !minimock.Equal(*mm_want_ptrs.key, mm_got.key) {
+				mmGetIdempotencyKey.t.Errorf("IOrderRepositoryMock.GetIdempotencyKey got unexpected parameter key, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.expectationOrigins.originKey, *mm_want_ptrs.key, mm_got.key, minimock.Diff(*mm_want_ptrs.key, mm_got.key))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmGetByID.t.Errorf("IOrderRepositoryMock.GetByID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmGetByID.GetByIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmGetIdempotencyKey.t.Errorf("IOrderRepositoryMock.GetIdempotencyKey got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmGetByID.GetByIDMock.defaultExpectation.results
+		mm_results := mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.results
 		if mm_results == nil {
-			mmGetByID.t.Fatal("No results are set for the IOrderRepositoryMock.GetByID")
+			mmGetIdempotencyKey.t.Fatal("No results are set for the IOrderRepositoryMock.GetIdempotencyKey")
 		}
-		return (*mm_results).o1, (*mm_results).err
+		return (*mm_results).i1, (*mm_results).err
 	}
-	if mmGetByID.funcGetByID != nil {
-		return mmGetByID.funcGetByID(ctx, orderID)
+	if mmGetIdempotencyKey.funcGetIdempotencyKey != nil {
+		return mmGetIdempotencyKey.funcGetIdempotencyKey(ctx, userID, key)
 	}
-	mmGetByID.t.Fatalf("Unexpected call to IOrderRepositoryMock.GetByID. %v %v", ctx, orderID)
+	mmGetIdempotencyKey.t.Fatalf("Unexpected call to IOrderRepositoryMock.GetIdempotencyKey. %v %v %v", ctx, userID, key)
 	return
 }
 
-// GetByIDAfterCounter returns a count of finished IOrderRepositoryMock.GetByID invocations
-func (mmGetByID *IOrderRepositoryMock) GetByIDAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetByID.afterGetByIDCounter)
+// GetIdempotencyKeyAfterCounter returns a count of finished IOrderRepositoryMock.GetIdempotencyKey invocations
+func (mmGetIdempotencyKey *IOrderRepositoryMock) GetIdempotencyKeyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetIdempotencyKey.afterGetIdempotencyKeyCounter)
 }
 
-// GetByIDBeforeCounter returns a count of IOrderRepositoryMock.GetByID invocations
-func (mmGetByID *IOrderRepositoryMock) GetByIDBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetByID.beforeGetByIDCounter)
+// GetIdempotencyKeyBeforeCounter returns a count of IOrderRepositoryMock.GetIdempotencyKey invocations
+func (mmGetIdempotencyKey *IOrderRepositoryMock) GetIdempotencyKeyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetIdempotencyKey.beforeGetIdempotencyKeyCounter)
 }
 
-// Calls returns a list of arguments used in each call to IOrderRepositoryMock.GetByID.
+// Calls returns a list of arguments used in each call to IOrderRepositoryMock.GetIdempotencyKey.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmGetByID *mIOrderRepositoryMockGetByID) Calls() []*IOrderRepositoryMockGetByIDParams {
-	mmGetByID.mutex.RLock()
+func (mmGetIdempotencyKey *mIOrderRepositoryMockGetIdempotencyKey) Calls() []*IOrderRepositoryMockGetIdempotencyKeyParams {
+	mmGetIdempotencyKey.mutex.RLock()
 
-	argCopy := make([]*IOrderRepositoryMockGetByIDParams, len(mmGetByID.callArgs))
-	copy(argCopy, mmGetByID.callArgs)
+	argCopy := make([]*IOrderRepositoryMockGetIdempotencyKeyParams, len(mmGetIdempotencyKey.callArgs))
+	copy(argCopy, mmGetIdempotencyKey.callArgs)
 
-	mmGetByID.mutex.RUnlock()
+	mmGetIdempotencyKey.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockGetByIDDone returns true if the count of the GetByID invocations corresponds
+// MinimockGetIdempotencyKeyDone returns true if the count of the GetIdempotencyKey invocations corresponds
 // the number of defined expectations
-func (m *IOrderRepositoryMock) MinimockGetByIDDone() bool {
-	if m.GetByIDMock.optional {
+func (m *IOrderRepositoryMock) MinimockGetIdempotencyKeyDone() bool {
+	if m.GetIdempotencyKeyMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.GetByIDMock.expectations {
+	for _, e := range m.GetIdempotencyKeyMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.GetByIDMock.invocationsDone()
+	return m.GetIdempotencyKeyMock.invocationsDone()
 }
 
-// MinimockGetByIDInspect logs each unmet expectation
-func (m *IOrderRepositoryMock) MinimockGetByIDInspect() {
-	for _, e := range m.GetByIDMock.expectations {
+// MinimockGetIdempotencyKeyInspect logs each unmet expectation
+func (m *IOrderRepositoryMock) MinimockGetIdempotencyKeyInspect() {
+	for _, e := range m.GetIdempotencyKeyMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IOrderRepositoryMock.GetByID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetIdempotencyKey at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterGetByIDCounter := mm_atomic.LoadUint64(&m.afterGetByIDCounter)
+	afterGetIdempotencyKeyCounter := mm_atomic.LoadUint64(&m.afterGetIdempotencyKeyCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetByIDMock.defaultExpectation != nil && afterGetByIDCounter < 1 {
-		if m.GetByIDMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to IOrderRepositoryMock.GetByID at\n%s", m.GetByIDMock.defaultExpectation.returnOrigin)
+	if m.GetIdempotencyKeyMock.defaultExpectation != nil && afterGetIdempotencyKeyCounter < 1 {
+		if m.GetIdempotencyKeyMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetIdempotencyKey at\n%s", m.GetIdempotencyKeyMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to IOrderRepositoryMock.GetByID at\n%s with params: %#v", m.GetByIDMock.defaultExpectation.expectationOrigins.origin, *m.GetByIDMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetIdempotencyKey at\n%s with params: %#v", m.GetIdempotencyKeyMock.defaultExpectation.expectationOrigins.origin, *m.GetIdempotencyKeyMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetByID != nil && afterGetByIDCounter < 1 {
-		m.t.Errorf("Expected call to IOrderRepositoryMock.GetByID at\n%s", m.funcGetByIDOrigin)
+	if m.funcGetIdempotencyKey != nil && afterGetIdempotencyKeyCounter < 1 {
+		m.t.Errorf("Expected call to IOrderRepositoryMock.GetIdempotencyKey at\n%s", m.funcGetIdempotencyKeyOrigin)
 	}
 
-	if !m.GetByIDMock.invocationsDone() && afterGetByIDCounter > 0 {
-		m.t.Errorf("Expected %d calls to IOrderRepositoryMock.GetByID at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.GetByIDMock.expectedInvocations), m.GetByIDMock.expectedInvocationsOrigin, afterGetByIDCounter)
+	if !m.GetIdempotencyKeyMock.invocationsDone() && afterGetIdempotencyKeyCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOrderRepositoryMock.GetIdempotencyKey at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetIdempotencyKeyMock.expectedInvocations), m.GetIdempotencyKeyMock.expectedInvocationsOrigin, afterGetIdempotencyKeyCounter)
 	}
 }
 
@@ -785,12 +2777,16 @@ type IOrderRepositoryMockGetOrdersExpectation struct {
 
 // IOrderRepositoryMockGetOrdersParams contains parameters of the IOrderRepository.GetOrders
 type IOrderRepositoryMockGetOrdersParams struct {
-	ctx context.Context
+	ctx    context.Context
+	filter models.OrderFilter
+	limit  uint32
 }
 
 // IOrderRepositoryMockGetOrdersParamPtrs contains pointers to parameters of the IOrderRepository.GetOrders
 type IOrderRepositoryMockGetOrdersParamPtrs struct {
-	ctx *context.Context
+	ctx    *context.Context
+	filter *models.OrderFilter
+	limit  *uint32
 }
 
 // IOrderRepositoryMockGetOrdersResults contains results of the IOrderRepository.GetOrders
@@ -801,8 +2797,10 @@ type IOrderRepositoryMockGetOrdersResults struct {
 
 // IOrderRepositoryMockGetOrdersOrigins contains origins of expectations of the IOrderRepository.GetOrders
 type IOrderRepositoryMockGetOrdersExpectationOrigins struct {
-	origin    string
-	originCtx string
+	origin       string
+	originCtx    string
+	originFilter string
+	originLimit  string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -816,7 +2814,7 @@ func (mmGetOrders *mIOrderRepositoryMockGetOrders) Optional() *mIOrderRepository
 }
 
 // Expect sets up expected params for IOrderRepository.GetOrders
-func (mmGetOrders *mIOrderRepositoryMockGetOrders) Expect(ctx context.Context) *mIOrderRepositoryMockGetOrders {
+func (mmGetOrders *mIOrderRepositoryMockGetOrders) Expect(ctx context.Context, filter models.OrderFilter, limit uint32) *mIOrderRepositoryMockGetOrders {
 	if mmGetOrders.mock.funcGetOrders != nil {
 		mmGetOrders.mock.t.Fatalf("IOrderRepositoryMock.GetOrders mock is already set by Set")
 	}
@@ -829,7 +2827,7 @@ func (mmGetOrders *mIOrderRepositoryMockGetOrders) Expect(ctx context.Context) *
 		mmGetOrders.mock.t.Fatalf("IOrderRepositoryMock.GetOrders mock is already set by ExpectParams functions")
 	}
 
-	mmGetOrders.defaultExpectation.params = &IOrderRepositoryMockGetOrdersParams{ctx}
+	mmGetOrders.defaultExpectation.params = &IOrderRepositoryMockGetOrdersParams{ctx, filter, limit}
 	mmGetOrders.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmGetOrders.expectations {
 		if minimock.Equal(e.params, mmGetOrders.defaultExpectation.params) {
@@ -863,8 +2861,54 @@ func (mmGetOrders *mIOrderRepositoryMockGetOrders) ExpectCtxParam1(ctx context.C
 	return mmGetOrders
 }
 
+// ExpectFilterParam2 sets up expected param filter for IOrderRepository.GetOrders
+func (mmGetOrders *mIOrderRepositoryMockGetOrders) ExpectFilterParam2(filter models.OrderFilter) *mIOrderRepositoryMockGetOrders {
+	if mmGetOrders.mock.funcGetOrders != nil {
+		mmGetOrders.mock.t.Fatalf("IOrderRepositoryMock.GetOrders mock is already set by Set")
+	}
+
+	if mmGetOrders.defaultExpectation == nil {
+		mmGetOrders.defaultExpectation = &IOrderRepositoryMockGetOrdersExpectation{}
+	}
+
+	if mmGetOrders.defaultExpectation.params != nil {
+		mmGetOrders.mock.t.Fatalf("IOrderRepositoryMock.GetOrders mock is already set by Expect")
+	}
+
+	if mmGetOrders.defaultExpectation.paramPtrs == nil {
+		mmGetOrders.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetOrdersParamPtrs{}
+	}
+	mmGetOrders.defaultExpectation.paramPtrs.filter = &filter
+	mmGetOrders.defaultExpectation.expectationOrigins.originFilter = minimock.CallerInfo(1)
+
+	return mmGetOrders
+}
+
+// ExpectLimitParam3 sets up expected param limit for IOrderRepository.GetOrders
+func (mmGetOrders *mIOrderRepositoryMockGetOrders) ExpectLimitParam3(limit uint32) *mIOrderRepositoryMockGetOrders {
+	if mmGetOrders.mock.funcGetOrders != nil {
+		mmGetOrders.mock.t.Fatalf("IOrderRepositoryMock.GetOrders mock is already set by Set")
+	}
+
+	if mmGetOrders.defaultExpectation == nil {
+		mmGetOrders.defaultExpectation = &IOrderRepositoryMockGetOrdersExpectation{}
+	}
+
+	if mmGetOrders.defaultExpectation.params != nil {
+		mmGetOrders.mock.t.Fatalf("IOrderRepositoryMock.GetOrders mock is already set by Expect")
+	}
+
+	if mmGetOrders.defaultExpectation.paramPtrs == nil {
+		mmGetOrders.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetOrdersParamPtrs{}
+	}
+	mmGetOrders.defaultExpectation.paramPtrs.limit = &limit
+	mmGetOrders.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmGetOrders
+}
+
 // Inspect accepts an inspector function that has same arguments as the IOrderRepository.GetOrders
-func (mmGetOrders *mIOrderRepositoryMockGetOrders) Inspect(f func(ctx context.Context)) *mIOrderRepositoryMockGetOrders {
+func (mmGetOrders *mIOrderRepositoryMockGetOrders) Inspect(f func(ctx context.Context, filter models.OrderFilter, limit uint32)) *mIOrderRepositoryMockGetOrders {
 	if mmGetOrders.mock.inspectFuncGetOrders != nil {
 		mmGetOrders.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.GetOrders")
 	}
@@ -889,7 +2933,7 @@ func (mmGetOrders *mIOrderRepositoryMockGetOrders) Return(oa1 []models.Order, er
 }
 
 // Set uses given function f to mock the IOrderRepository.GetOrders method
-func (mmGetOrders *mIOrderRepositoryMockGetOrders) Set(f func(ctx context.Context) (oa1 []models.Order, err error)) *IOrderRepositoryMock {
+func (mmGetOrders *mIOrderRepositoryMockGetOrders) Set(f func(ctx context.Context, filter models.OrderFilter, limit uint32) (oa1 []models.Order, err error)) *IOrderRepositoryMock {
 	if mmGetOrders.defaultExpectation != nil {
 		mmGetOrders.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.GetOrders method")
 	}
@@ -905,14 +2949,14 @@ func (mmGetOrders *mIOrderRepositoryMockGetOrders) Set(f func(ctx context.Contex
 
 // When sets expectation for the IOrderRepository.GetOrders which will trigger the result defined by the following
 // Then helper
-func (mmGetOrders *mIOrderRepositoryMockGetOrders) When(ctx context.Context) *IOrderRepositoryMockGetOrdersExpectation {
+func (mmGetOrders *mIOrderRepositoryMockGetOrders) When(ctx context.Context, filter models.OrderFilter, limit uint32) *IOrderRepositoryMockGetOrdersExpectation {
 	if mmGetOrders.mock.funcGetOrders != nil {
 		mmGetOrders.mock.t.Fatalf("IOrderRepositoryMock.GetOrders mock is already set by Set")
 	}
 
 	expectation := &IOrderRepositoryMockGetOrdersExpectation{
 		mock:               mmGetOrders.mock,
-		params:             &IOrderRepositoryMockGetOrdersParams{ctx},
+		params:             &IOrderRepositoryMockGetOrdersParams{ctx, filter, limit},
 		expectationOrigins: IOrderRepositoryMockGetOrdersExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmGetOrders.expectations = append(mmGetOrders.expectations, expectation)
@@ -947,17 +2991,17 @@ func (mmGetOrders *mIOrderRepositoryMockGetOrders) invocationsDone() bool {
 }
 
 // GetOrders implements mm_service.IOrderRepository
-func (mmGetOrders *IOrderRepositoryMock) GetOrders(ctx context.Context) (oa1 []models.Order, err error) {
+func (mmGetOrders *IOrderRepositoryMock) GetOrders(ctx context.Context, filter models.OrderFilter, limit uint32) (oa1 []models.Order, err error) {
 	mm_atomic.AddUint64(&mmGetOrders.beforeGetOrdersCounter, 1)
 	defer mm_atomic.AddUint64(&mmGetOrders.afterGetOrdersCounter, 1)
 
 	mmGetOrders.t.Helper()
 
 	if mmGetOrders.inspectFuncGetOrders != nil {
-		mmGetOrders.inspectFuncGetOrders(ctx)
+		mmGetOrders.inspectFuncGetOrders(ctx, filter, limit)
 	}
 
-	mm_params := IOrderRepositoryMockGetOrdersParams{ctx}
+	mm_params := IOrderRepositoryMockGetOrdersParams{ctx, filter, limit}
 
 	// Record call args
 	mmGetOrders.GetOrdersMock.mutex.Lock()
@@ -976,7 +3020,7 @@ func (mmGetOrders *IOrderRepositoryMock) GetOrders(ctx context.Context) (oa1 []m
 		mm_want := mmGetOrders.GetOrdersMock.defaultExpectation.params
 		mm_want_ptrs := mmGetOrders.GetOrdersMock.defaultExpectation.paramPtrs
 
-		mm_got := IOrderRepositoryMockGetOrdersParams{ctx}
+		mm_got := IOrderRepositoryMockGetOrdersParams{ctx, filter, limit}
 
 		if mm_want_ptrs != nil {
 
@@ -985,6 +3029,16 @@ func (mmGetOrders *IOrderRepositoryMock) GetOrders(ctx context.Context) (oa1 []m
 					mmGetOrders.GetOrdersMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
+			if mm_want_ptrs.filter != nil && !minimock.Equal(*mm_want_ptrs.filter, mm_got.filter) {
+				mmGetOrders.t.Errorf("IOrderRepositoryMock.GetOrders got unexpected parameter filter, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetOrders.GetOrdersMock.defaultExpectation.expectationOrigins.originFilter, *mm_want_ptrs.filter, mm_got.filter, minimock.Diff(*mm_want_ptrs.filter, mm_got.filter))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetOrders.t.Errorf("IOrderRepositoryMock.GetOrders got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetOrders.GetOrdersMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmGetOrders.t.Errorf("IOrderRepositoryMock.GetOrders got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 				mmGetOrders.GetOrdersMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
@@ -997,9 +3051,9 @@ func (mmGetOrders *IOrderRepositoryMock) GetOrders(ctx context.Context) (oa1 []m
 		return (*mm_results).oa1, (*mm_results).err
 	}
 	if mmGetOrders.funcGetOrders != nil {
-		return mmGetOrders.funcGetOrders(ctx)
+		return mmGetOrders.funcGetOrders(ctx, filter, limit)
 	}
-	mmGetOrders.t.Fatalf("Unexpected call to IOrderRepositoryMock.GetOrders. %v", ctx)
+	mmGetOrders.t.Fatalf("Unexpected call to IOrderRepositoryMock.GetOrders. %v %v %v", ctx, filter, limit)
 	return
 }
 
@@ -1071,6 +3125,380 @@ func (m *IOrderRepositoryMock) MinimockGetOrdersInspect() {
 	}
 }
 
+type mIOrderRepositoryMockGetOrdersPartial struct {
+	optional           bool
+	mock               *IOrderRepositoryMock
+	defaultExpectation *IOrderRepositoryMockGetOrdersPartialExpectation
+	expectations       []*IOrderRepositoryMockGetOrdersPartialExpectation
+
+	callArgs []*IOrderRepositoryMockGetOrdersPartialParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOrderRepositoryMockGetOrdersPartialExpectation specifies expectation struct of the IOrderRepository.GetOrdersPartial
+type IOrderRepositoryMockGetOrdersPartialExpectation struct {
+	mock               *IOrderRepositoryMock
+	params             *IOrderRepositoryMockGetOrdersPartialParams
+	paramPtrs          *IOrderRepositoryMockGetOrdersPartialParamPtrs
+	expectationOrigins IOrderRepositoryMockGetOrdersPartialExpectationOrigins
+	results            *IOrderRepositoryMockGetOrdersPartialResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOrderRepositoryMockGetOrdersPartialParams contains parameters of the IOrderRepository.GetOrdersPartial
+type IOrderRepositoryMockGetOrdersPartialParams struct {
+	ctx    context.Context
+	filter models.OrderFilter
+	limit  uint32
+}
+
+// IOrderRepositoryMockGetOrdersPartialParamPtrs contains pointers to parameters of the IOrderRepository.GetOrdersPartial
+type IOrderRepositoryMockGetOrdersPartialParamPtrs struct {
+	ctx    *context.Context
+	filter *models.OrderFilter
+	limit  *uint32
+}
+
+// IOrderRepositoryMockGetOrdersPartialResults contains results of the IOrderRepository.GetOrdersPartial
+type IOrderRepositoryMockGetOrdersPartialResults struct {
+	o1  models.OrdersPage
+	err error
+}
+
+// IOrderRepositoryMockGetOrdersPartialOrigins contains origins of expectations of the IOrderRepository.GetOrdersPartial
+type IOrderRepositoryMockGetOrdersPartialExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originFilter string
+	originLimit  string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) Optional() *mIOrderRepositoryMockGetOrdersPartial {
+	mmGetOrdersPartial.optional = true
+	return mmGetOrdersPartial
+}
+
+// Expect sets up expected params for IOrderRepository.GetOrdersPartial
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) Expect(ctx context.Context, filter models.OrderFilter, limit uint32) *mIOrderRepositoryMockGetOrdersPartial {
+	if mmGetOrdersPartial.mock.funcGetOrdersPartial != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Set")
+	}
+
+	if mmGetOrdersPartial.defaultExpectation == nil {
+		mmGetOrdersPartial.defaultExpectation = &IOrderRepositoryMockGetOrdersPartialExpectation{}
+	}
+
+	if mmGetOrdersPartial.defaultExpectation.paramPtrs != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by ExpectParams functions")
+	}
+
+	mmGetOrdersPartial.defaultExpectation.params = &IOrderRepositoryMockGetOrdersPartialParams{ctx, filter, limit}
+	mmGetOrdersPartial.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetOrdersPartial.expectations {
+		if minimock.Equal(e.params, mmGetOrdersPartial.defaultExpectation.params) {
+			mmGetOrdersPartial.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetOrdersPartial.defaultExpectation.params)
+		}
+	}
+
+	return mmGetOrdersPartial
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOrderRepository.GetOrdersPartial
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) ExpectCtxParam1(ctx context.Context) *mIOrderRepositoryMockGetOrdersPartial {
+	if mmGetOrdersPartial.mock.funcGetOrdersPartial != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Set")
+	}
+
+	if mmGetOrdersPartial.defaultExpectation == nil {
+		mmGetOrdersPartial.defaultExpectation = &IOrderRepositoryMockGetOrdersPartialExpectation{}
+	}
+
+	if mmGetOrdersPartial.defaultExpectation.params != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Expect")
+	}
+
+	if mmGetOrdersPartial.defaultExpectation.paramPtrs == nil {
+		mmGetOrdersPartial.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetOrdersPartialParamPtrs{}
+	}
+	mmGetOrdersPartial.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetOrdersPartial.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetOrdersPartial
+}
+
+// ExpectFilterParam2 sets up expected param filter for IOrderRepository.GetOrdersPartial
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) ExpectFilterParam2(filter models.OrderFilter) *mIOrderRepositoryMockGetOrdersPartial {
+	if mmGetOrdersPartial.mock.funcGetOrdersPartial != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Set")
+	}
+
+	if mmGetOrdersPartial.defaultExpectation == nil {
+		mmGetOrdersPartial.defaultExpectation = &IOrderRepositoryMockGetOrdersPartialExpectation{}
+	}
+
+	if mmGetOrdersPartial.defaultExpectation.params != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Expect")
+	}
+
+	if mmGetOrdersPartial.defaultExpectation.paramPtrs == nil {
+		mmGetOrdersPartial.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetOrdersPartialParamPtrs{}
+	}
+	mmGetOrdersPartial.defaultExpectation.paramPtrs.filter = &filter
+	mmGetOrdersPartial.defaultExpectation.expectationOrigins.originFilter = minimock.CallerInfo(1)
+
+	return mmGetOrdersPartial
+}
+
+// ExpectLimitParam3 sets up expected param limit for IOrderRepository.GetOrdersPartial
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) ExpectLimitParam3(limit uint32) *mIOrderRepositoryMockGetOrdersPartial {
+	if mmGetOrdersPartial.mock.funcGetOrdersPartial != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Set")
+	}
+
+	if mmGetOrdersPartial.defaultExpectation == nil {
+		mmGetOrdersPartial.defaultExpectation = &IOrderRepositoryMockGetOrdersPartialExpectation{}
+	}
+
+	if mmGetOrdersPartial.defaultExpectation.params != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Expect")
+	}
+
+	if mmGetOrdersPartial.defaultExpectation.paramPtrs == nil {
+		mmGetOrdersPartial.defaultExpectation.paramPtrs = &IOrderRepositoryMockGetOrdersPartialParamPtrs{}
+	}
+	mmGetOrdersPartial.defaultExpectation.paramPtrs.limit = &limit
+	mmGetOrdersPartial.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmGetOrdersPartial
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOrderRepository.GetOrdersPartial
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) Inspect(f func(ctx context.Context, filter models.OrderFilter, limit uint32)) *mIOrderRepositoryMockGetOrdersPartial {
+	if mmGetOrdersPartial.mock.inspectFuncGetOrdersPartial != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.GetOrdersPartial")
+	}
+
+	mmGetOrdersPartial.mock.inspectFuncGetOrdersPartial = f
+
+	return mmGetOrdersPartial
+}
+
+// Return sets up results that will be returned by IOrderRepository.GetOrdersPartial
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) Return(o1 models.OrdersPage, err error) *IOrderRepositoryMock {
+	if mmGetOrdersPartial.mock.funcGetOrdersPartial != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Set")
+	}
+
+	if mmGetOrdersPartial.defaultExpectation == nil {
+		mmGetOrdersPartial.defaultExpectation = &IOrderRepositoryMockGetOrdersPartialExpectation{mock: mmGetOrdersPartial.mock}
+	}
+	mmGetOrdersPartial.defaultExpectation.results = &IOrderRepositoryMockGetOrdersPartialResults{o1, err}
+	mmGetOrdersPartial.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetOrdersPartial.mock
+}
+
+// Set uses given function f to mock the IOrderRepository.GetOrdersPartial method
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) Set(f func(ctx context.Context, filter models.OrderFilter, limit uint32) (o1 models.OrdersPage, err error)) *IOrderRepositoryMock {
+	if mmGetOrdersPartial.defaultExpectation != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.GetOrdersPartial method")
+	}
+
+	if len(mmGetOrdersPartial.expectations) > 0 {
+		mmGetOrdersPartial.mock.t.Fatalf("Some expectations are already set for the IOrderRepository.GetOrdersPartial method")
+	}
+
+	mmGetOrdersPartial.mock.funcGetOrdersPartial = f
+	mmGetOrdersPartial.mock.funcGetOrdersPartialOrigin = minimock.CallerInfo(1)
+	return mmGetOrdersPartial.mock
+}
+
+// When sets expectation for the IOrderRepository.GetOrdersPartial which will trigger the result defined by the following
+// Then helper
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) When(ctx context.Context, filter models.OrderFilter, limit uint32) *IOrderRepositoryMockGetOrdersPartialExpectation {
+	if mmGetOrdersPartial.mock.funcGetOrdersPartial != nil {
+		mmGetOrdersPartial.mock.t.Fatalf("IOrderRepositoryMock.GetOrdersPartial mock is already set by Set")
+	}
+
+	expectation := &IOrderRepositoryMockGetOrdersPartialExpectation{
+		mock:               mmGetOrdersPartial.mock,
+		params:             &IOrderRepositoryMockGetOrdersPartialParams{ctx, filter, limit},
+		expectationOrigins: IOrderRepositoryMockGetOrdersPartialExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetOrdersPartial.expectations = append(mmGetOrdersPartial.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOrderRepository.GetOrdersPartial return parameters for the expectation previously defined by the When method
+func (e *IOrderRepositoryMockGetOrdersPartialExpectation) Then(o1 models.OrdersPage, err error) *IOrderRepositoryMock {
+	e.results = &IOrderRepositoryMockGetOrdersPartialResults{o1, err}
+	return e.mock
+}
+
+// Times sets number of times IOrderRepository.GetOrdersPartial should be invoked
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) Times(n uint64) *mIOrderRepositoryMockGetOrdersPartial {
+	if n == 0 {
+		mmGetOrdersPartial.mock.t.Fatalf("Times of IOrderRepositoryMock.GetOrdersPartial mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetOrdersPartial.expectedInvocations, n)
+	mmGetOrdersPartial.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetOrdersPartial
+}
+
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) invocationsDone() bool {
+	if len(mmGetOrdersPartial.expectations) == 0 && mmGetOrdersPartial.defaultExpectation == nil && mmGetOrdersPartial.mock.funcGetOrdersPartial == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetOrdersPartial.mock.afterGetOrdersPartialCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetOrdersPartial.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetOrdersPartial implements mm_service.IOrderRepository
+func (mmGetOrdersPartial *IOrderRepositoryMock) GetOrdersPartial(ctx context.Context, filter models.OrderFilter, limit uint32) (o1 models.OrdersPage, err error) {
+	mm_atomic.AddUint64(&mmGetOrdersPartial.beforeGetOrdersPartialCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetOrdersPartial.afterGetOrdersPartialCounter, 1)
+
+	mmGetOrdersPartial.t.Helper()
+
+	if mmGetOrdersPartial.inspectFuncGetOrdersPartial != nil {
+		mmGetOrdersPartial.inspectFuncGetOrdersPartial(ctx, filter, limit)
+	}
+
+	mm_params := IOrderRepositoryMockGetOrdersPartialParams{ctx, filter, limit}
+
+	// Record call args
+	mmGetOrdersPartial.GetOrdersPartialMock.mutex.Lock()
+	mmGetOrdersPartial.GetOrdersPartialMock.callArgs = append(mmGetOrdersPartial.GetOrdersPartialMock.callArgs, &mm_params)
+	mmGetOrdersPartial.GetOrdersPartialMock.mutex.Unlock()
+
+	for _, e := range mmGetOrdersPartial.GetOrdersPartialMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.o1, e.results.err
+		}
+	}
+
+	if mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation.params
+		mm_want_ptrs := mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation.paramPtrs
+
+		mm_got := IOrderRepositoryMockGetOrdersPartialParams{ctx, filter, limit}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetOrdersPartial.t.Errorf("IOrderRepositoryMock.GetOrdersPartial got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.filter != nil && !minimock.Equal(*mm_want_ptrs.filter, mm_got.filter) {
+				mmGetOrdersPartial.t.Errorf("IOrderRepositoryMock.GetOrdersPartial got unexpected parameter filter, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation.expectationOrigins.originFilter, *mm_want_ptrs.filter, mm_got.filter, minimock.Diff(*mm_want_ptrs.filter, mm_got.filter))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetOrdersPartial.t.Errorf("IOrderRepositoryMock.GetOrdersPartial got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetOrdersPartial.t.Errorf("IOrderRepositoryMock.GetOrdersPartial got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetOrdersPartial.GetOrdersPartialMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetOrdersPartial.t.Fatal("No results are set for the IOrderRepositoryMock.GetOrdersPartial")
+		}
+		return (*mm_results).o1, (*mm_results).err
+	}
+	if mmGetOrdersPartial.funcGetOrdersPartial != nil {
+		return mmGetOrdersPartial.funcGetOrdersPartial(ctx, filter, limit)
+	}
+	mmGetOrdersPartial.t.Fatalf("Unexpected call to IOrderRepositoryMock.GetOrdersPartial. %v %v %v", ctx, filter, limit)
+	return
+}
+
+// GetOrdersPartialAfterCounter returns a count of finished IOrderRepositoryMock.GetOrdersPartial invocations
+func (mmGetOrdersPartial *IOrderRepositoryMock) GetOrdersPartialAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetOrdersPartial.afterGetOrdersPartialCounter)
+}
+
+// GetOrdersPartialBeforeCounter returns a count of IOrderRepositoryMock.GetOrdersPartial invocations
+func (mmGetOrdersPartial *IOrderRepositoryMock) GetOrdersPartialBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetOrdersPartial.beforeGetOrdersPartialCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOrderRepositoryMock.GetOrdersPartial.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetOrdersPartial *mIOrderRepositoryMockGetOrdersPartial) Calls() []*IOrderRepositoryMockGetOrdersPartialParams {
+	mmGetOrdersPartial.mutex.RLock()
+
+	argCopy := make([]*IOrderRepositoryMockGetOrdersPartialParams, len(mmGetOrdersPartial.callArgs))
+	copy(argCopy, mmGetOrdersPartial.callArgs)
+
+	mmGetOrdersPartial.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetOrdersPartialDone returns true if the count of the GetOrdersPartial invocations corresponds
+// the number of defined expectations
+func (m *IOrderRepositoryMock) MinimockGetOrdersPartialDone() bool {
+	if m.GetOrdersPartialMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetOrdersPartialMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetOrdersPartialMock.invocationsDone()
+}
+
+// MinimockGetOrdersPartialInspect logs each unmet expectation
+func (m *IOrderRepositoryMock) MinimockGetOrdersPartialInspect() {
+	for _, e := range m.GetOrdersPartialMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetOrdersPartial at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetOrdersPartialCounter := mm_atomic.LoadUint64(&m.afterGetOrdersPartialCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetOrdersPartialMock.defaultExpectation != nil && afterGetOrdersPartialCounter < 1 {
+		if m.GetOrdersPartialMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetOrdersPartial at\n%s", m.GetOrdersPartialMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOrderRepositoryMock.GetOrdersPartial at\n%s with params: %#v", m.GetOrdersPartialMock.defaultExpectation.expectationOrigins.origin, *m.GetOrdersPartialMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetOrdersPartial != nil && afterGetOrdersPartialCounter < 1 {
+		m.t.Errorf("Expected call to IOrderRepositoryMock.GetOrdersPartial at\n%s", m.funcGetOrdersPartialOrigin)
+	}
+
+	if !m.GetOrdersPartialMock.invocationsDone() && afterGetOrdersPartialCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOrderRepositoryMock.GetOrdersPartial at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetOrdersPartialMock.expectedInvocations), m.GetOrdersPartialMock.expectedInvocationsOrigin, afterGetOrdersPartialCounter)
+	}
+}
+
 type mIOrderRepositoryMockSetStatus struct {
 	optional           bool
 	mock               *IOrderRepositoryMock
@@ -1097,16 +3525,18 @@ type IOrderRepositoryMockSetStatusExpectation struct {
 
 // IOrderRepositoryMockSetStatusParams contains parameters of the IOrderRepository.SetStatus
 type IOrderRepositoryMockSetStatusParams struct {
-	ctx     context.Context
-	orderID models.OID
-	status  models.OrderStatus
+	ctx       context.Context
+	orderID   models.OID
+	status    models.OrderStatus
+	eventType string
 }
 
 // IOrderRepositoryMockSetStatusParamPtrs contains pointers to parameters of the IOrderRepository.SetStatus
 type IOrderRepositoryMockSetStatusParamPtrs struct {
-	ctx     *context.Context
-	orderID *models.OID
-	status  *models.OrderStatus
+	ctx       *context.Context
+	orderID   *models.OID
+	status    *models.OrderStatus
+	eventType *string
 }
 
 // IOrderRepositoryMockSetStatusResults contains results of the IOrderRepository.SetStatus
@@ -1116,10 +3546,11 @@ type IOrderRepositoryMockSetStatusResults struct {
 
 // IOrderRepositoryMockSetStatusOrigins contains origins of expectations of the IOrderRepository.SetStatus
 type IOrderRepositoryMockSetStatusExpectationOrigins struct {
-	origin        string
-	originCtx     string
-	originOrderID string
-	originStatus  string
+	origin          string
+	originCtx       string
+	originOrderID   string
+	originStatus    string
+	originEventType string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -1133,7 +3564,7 @@ func (mmSetStatus *mIOrderRepositoryMockSetStatus) Optional() *mIOrderRepository
 }
 
 // Expect sets up expected params for IOrderRepository.SetStatus
-func (mmSetStatus *mIOrderRepositoryMockSetStatus) Expect(ctx context.Context, orderID models.OID, status models.OrderStatus) *mIOrderRepositoryMockSetStatus {
+func (mmSetStatus *mIOrderRepositoryMockSetStatus) Expect(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) *mIOrderRepositoryMockSetStatus {
 	if mmSetStatus.mock.funcSetStatus != nil {
 		mmSetStatus.mock.t.Fatalf("IOrderRepositoryMock.SetStatus mock is already set by Set")
 	}
@@ -1146,7 +3577,7 @@ func (mmSetStatus *mIOrderRepositoryMockSetStatus) Expect(ctx context.Context, o
 		mmSetStatus.mock.t.Fatalf("IOrderRepositoryMock.SetStatus mock is already set by ExpectParams functions")
 	}
 
-	mmSetStatus.defaultExpectation.params = &IOrderRepositoryMockSetStatusParams{ctx, orderID, status}
+	mmSetStatus.defaultExpectation.params = &IOrderRepositoryMockSetStatusParams{ctx, orderID, status, eventType}
 	mmSetStatus.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmSetStatus.expectations {
 		if minimock.Equal(e.params, mmSetStatus.defaultExpectation.params) {
@@ -1226,8 +3657,31 @@ func (mmSetStatus *mIOrderRepositoryMockSetStatus) ExpectStatusParam3(status mod
 	return mmSetStatus
 }
 
+// ExpectEventTypeParam4 sets up expected param eventType for IOrderRepository.SetStatus
+func (mmSetStatus *mIOrderRepositoryMockSetStatus) ExpectEventTypeParam4(eventType string) *mIOrderRepositoryMockSetStatus {
+	if mmSetStatus.mock.funcSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("IOrderRepositoryMock.SetStatus mock is already set by Set")
+	}
+
+	if mmSetStatus.defaultExpectation == nil {
+		mmSetStatus.defaultExpectation = &IOrderRepositoryMockSetStatusExpectation{}
+	}
+
+	if mmSetStatus.defaultExpectation.params != nil {
+		mmSetStatus.mock.t.Fatalf("IOrderRepositoryMock.SetStatus mock is already set by Expect")
+	}
+
+	if mmSetStatus.defaultExpectation.paramPtrs == nil {
+		mmSetStatus.defaultExpectation.paramPtrs = &IOrderRepositoryMockSetStatusParamPtrs{}
+	}
+	mmSetStatus.defaultExpectation.paramPtrs.eventType = &eventType
+	mmSetStatus.defaultExpectation.expectationOrigins.originEventType = minimock.CallerInfo(1)
+
+	return mmSetStatus
+}
+
 // Inspect accepts an inspector function that has same arguments as the IOrderRepository.SetStatus
-func (mmSetStatus *mIOrderRepositoryMockSetStatus) Inspect(f func(ctx context.Context, orderID models.OID, status models.OrderStatus)) *mIOrderRepositoryMockSetStatus {
+func (mmSetStatus *mIOrderRepositoryMockSetStatus) Inspect(f func(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string)) *mIOrderRepositoryMockSetStatus {
 	if mmSetStatus.mock.inspectFuncSetStatus != nil {
 		mmSetStatus.mock.t.Fatalf("Inspect function is already set for IOrderRepositoryMock.SetStatus")
 	}
@@ -1252,7 +3706,7 @@ func (mmSetStatus *mIOrderRepositoryMockSetStatus) Return(err error) *IOrderRepo
 }
 
 // Set uses given function f to mock the IOrderRepository.SetStatus method
-func (mmSetStatus *mIOrderRepositoryMockSetStatus) Set(f func(ctx context.Context, orderID models.OID, status models.OrderStatus) (err error)) *IOrderRepositoryMock {
+func (mmSetStatus *mIOrderRepositoryMockSetStatus) Set(f func(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) (err error)) *IOrderRepositoryMock {
 	if mmSetStatus.defaultExpectation != nil {
 		mmSetStatus.mock.t.Fatalf("Default expectation is already set for the IOrderRepository.SetStatus method")
 	}
@@ -1268,14 +3722,14 @@ func (mmSetStatus *mIOrderRepositoryMockSetStatus) Set(f func(ctx context.Contex
 
 // When sets expectation for the IOrderRepository.SetStatus which will trigger the result defined by the following
 // Then helper
-func (mmSetStatus *mIOrderRepositoryMockSetStatus) When(ctx context.Context, orderID models.OID, status models.OrderStatus) *IOrderRepositoryMockSetStatusExpectation {
+func (mmSetStatus *mIOrderRepositoryMockSetStatus) When(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) *IOrderRepositoryMockSetStatusExpectation {
 	if mmSetStatus.mock.funcSetStatus != nil {
 		mmSetStatus.mock.t.Fatalf("IOrderRepositoryMock.SetStatus mock is already set by Set")
 	}
 
 	expectation := &IOrderRepositoryMockSetStatusExpectation{
 		mock:               mmSetStatus.mock,
-		params:             &IOrderRepositoryMockSetStatusParams{ctx, orderID, status},
+		params:             &IOrderRepositoryMockSetStatusParams{ctx, orderID, status, eventType},
 		expectationOrigins: IOrderRepositoryMockSetStatusExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmSetStatus.expectations = append(mmSetStatus.expectations, expectation)
@@ -1310,17 +3764,17 @@ func (mmSetStatus *mIOrderRepositoryMockSetStatus) invocationsDone() bool {
 }
 
 // SetStatus implements mm_service.IOrderRepository
-func (mmSetStatus *IOrderRepositoryMock) SetStatus(ctx context.Context, orderID models.OID, status models.OrderStatus) (err error) {
+func (mmSetStatus *IOrderRepositoryMock) SetStatus(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) (err error) {
 	mm_atomic.AddUint64(&mmSetStatus.beforeSetStatusCounter, 1)
 	defer mm_atomic.AddUint64(&mmSetStatus.afterSetStatusCounter, 1)
 
 	mmSetStatus.t.Helper()
 
 	if mmSetStatus.inspectFuncSetStatus != nil {
-		mmSetStatus.inspectFuncSetStatus(ctx, orderID, status)
+		mmSetStatus.inspectFuncSetStatus(ctx, orderID, status, eventType)
 	}
 
-	mm_params := IOrderRepositoryMockSetStatusParams{ctx, orderID, status}
+	mm_params := IOrderRepositoryMockSetStatusParams{ctx, orderID, status, eventType}
 
 	// Record call args
 	mmSetStatus.SetStatusMock.mutex.Lock()
@@ -1339,7 +3793,7 @@ func (mmSetStatus *IOrderRepositoryMock) SetStatus(ctx context.Context, orderID
 		mm_want := mmSetStatus.SetStatusMock.defaultExpectation.params
 		mm_want_ptrs := mmSetStatus.SetStatusMock.defaultExpectation.paramPtrs
 
-		mm_got := IOrderRepositoryMockSetStatusParams{ctx, orderID, status}
+		mm_got := IOrderRepositoryMockSetStatusParams{ctx, orderID, status, eventType}
 
 		if mm_want_ptrs != nil {
 
@@ -1358,6 +3812,11 @@ func (mmSetStatus *IOrderRepositoryMock) SetStatus(ctx context.Context, orderID
 					mmSetStatus.SetStatusMock.defaultExpectation.expectationOrigins.originStatus, *mm_want_ptrs.status, mm_got.status, minimock.Diff(*mm_want_ptrs.status, mm_got.status))
 			}
 
+			if mm_want_ptrs.eventType != nil && !minimock.Equal(*mm_want_ptrs.eventType, mm_got.eventType) {
+				mmSetStatus.t.Errorf("IOrderRepositoryMock.SetStatus got unexpected parameter eventType, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetStatus.SetStatusMock.defaultExpectation.expectationOrigins.originEventType, *mm_want_ptrs.eventType, mm_got.eventType, minimock.Diff(*mm_want_ptrs.eventType, mm_got.eventType))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmSetStatus.t.Errorf("IOrderRepositoryMock.SetStatus got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 				mmSetStatus.SetStatusMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
@@ -1370,9 +3829,9 @@ func (mmSetStatus *IOrderRepositoryMock) SetStatus(ctx context.Context, orderID
 		return (*mm_results).err
 	}
 	if mmSetStatus.funcSetStatus != nil {
-		return mmSetStatus.funcSetStatus(ctx, orderID, status)
+		return mmSetStatus.funcSetStatus(ctx, orderID, status, eventType)
 	}
-	mmSetStatus.t.Fatalf("Unexpected call to IOrderRepositoryMock.SetStatus. %v %v %v", ctx, orderID, status)
+	mmSetStatus.t.Fatalf("Unexpected call to IOrderRepositoryMock.SetStatus. %v %v %v %v", ctx, orderID, status, eventType)
 	return
 }
 
@@ -1448,12 +3907,24 @@ func (m *IOrderRepositoryMock) MinimockSetStatusInspect() {
 func (m *IOrderRepositoryMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
+			m.MinimockCompareAndSetStatusInspect()
+
 			m.MinimockCreateInspect()
 
+			m.MinimockCreateWithIdempotencyKeyInspect()
+
+			m.MinimockDeleteExpiredIdempotencyKeysInspect()
+
 			m.MinimockGetByIDInspect()
 
+			m.MinimockGetExpiredOrdersInspect()
+
+			m.MinimockGetIdempotencyKeyInspect()
+
 			m.MinimockGetOrdersInspect()
 
+			m.MinimockGetOrdersPartialInspect()
+
 			m.MinimockSetStatusInspect()
 		}
 	})
@@ -1478,8 +3949,14 @@ func (m *IOrderRepositoryMock) MinimockWait(timeout mm_time.Duration) {
 func (m *IOrderRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
+		m.MinimockCompareAndSetStatusDone() &&
 		m.MinimockCreateDone() &&
+		m.MinimockCreateWithIdempotencyKeyDone() &&
+		m.MinimockDeleteExpiredIdempotencyKeysDone() &&
 		m.MinimockGetByIDDone() &&
+		m.MinimockGetExpiredOrdersDone() &&
+		m.MinimockGetIdempotencyKeyDone() &&
 		m.MinimockGetOrdersDone() &&
+		m.MinimockGetOrdersPartialDone() &&
 		m.MinimockSetStatusDone()
 }
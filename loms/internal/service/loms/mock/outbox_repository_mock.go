@@ -9,6 +9,7 @@ import (
 	"route256/loms/internal/models"
 	"sync"
 	mm_atomic "sync/atomic"
+	"time"
 	mm_time "time"
 
 	"github.com/gojuno/minimock/v3"
@@ -27,19 +28,61 @@ type IOutboxRepositoryMock struct {
 	beforeCreateEventCounter uint64
 	CreateEventMock          mIOutboxRepositoryMockCreateEvent
 
-	funcFetchNextMsg          func(ctx context.Context, tx pgx.Tx) (op1 *models.OutboxEvent, err error)
-	funcFetchNextMsgOrigin    string
-	inspectFuncFetchNextMsg   func(ctx context.Context, tx pgx.Tx)
-	afterFetchNextMsgCounter  uint64
-	beforeFetchNextMsgCounter uint64
-	FetchNextMsgMock          mIOutboxRepositoryMockFetchNextMsg
-
-	funcMarkAsSent          func(ctx context.Context, tx pgx.Tx, eventID int64) (err error)
+	funcDeleteProcessed          func(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) (i1 int64, err error)
+	funcDeleteProcessedOrigin    string
+	inspectFuncDeleteProcessed   func(ctx context.Context, shard models.OutboxShard, processedBefore time.Time)
+	afterDeleteProcessedCounter  uint64
+	beforeDeleteProcessedCounter uint64
+	DeleteProcessedMock          mIOutboxRepositoryMockDeleteProcessed
+
+	funcFetchBatch          func(ctx context.Context, tx pgx.Tx, limit uint32) (oa1 []models.OutboxEvent, err error)
+	funcFetchBatchOrigin    string
+	inspectFuncFetchBatch   func(ctx context.Context, tx pgx.Tx, limit uint32)
+	afterFetchBatchCounter  uint64
+	beforeFetchBatchCounter uint64
+	FetchBatchMock          mIOutboxRepositoryMockFetchBatch
+
+	funcList          func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) (oa1 []models.OutboxEvent, err error)
+	funcListOrigin    string
+	inspectFuncList   func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32)
+	afterListCounter  uint64
+	beforeListCounter uint64
+	ListMock          mIOutboxRepositoryMockList
+
+	funcMarkAsFailed          func(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) (err error)
+	funcMarkAsFailedOrigin    string
+	inspectFuncMarkAsFailed   func(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState)
+	afterMarkAsFailedCounter  uint64
+	beforeMarkAsFailedCounter uint64
+	MarkAsFailedMock          mIOutboxRepositoryMockMarkAsFailed
+
+	funcMarkAsSent          func(ctx context.Context, tx pgx.Tx, eventIDs []int64) (err error)
 	funcMarkAsSentOrigin    string
-	inspectFuncMarkAsSent   func(ctx context.Context, tx pgx.Tx, eventID int64)
+	inspectFuncMarkAsSent   func(ctx context.Context, tx pgx.Tx, eventIDs []int64)
 	afterMarkAsSentCounter  uint64
 	beforeMarkAsSentCounter uint64
 	MarkAsSentMock          mIOutboxRepositoryMockMarkAsSent
+
+	funcReset          func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) (i1 int64, err error)
+	funcResetOrigin    string
+	inspectFuncReset   func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState)
+	afterResetCounter  uint64
+	beforeResetCounter uint64
+	ResetMock          mIOutboxRepositoryMockReset
+
+	funcShards          func() (oa1 []models.OutboxShard)
+	funcShardsOrigin    string
+	inspectFuncShards   func()
+	afterShardsCounter  uint64
+	beforeShardsCounter uint64
+	ShardsMock          mIOutboxRepositoryMockShards
+
+	funcWithShardTx          func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) (err error)
+	funcWithShardTxOrigin    string
+	inspectFuncWithShardTx   func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error)
+	afterWithShardTxCounter  uint64
+	beforeWithShardTxCounter uint64
+	WithShardTxMock          mIOutboxRepositoryMockWithShardTx
 }
 
 // NewIOutboxRepositoryMock returns a mock for mm_service.IOutboxRepository
@@ -53,12 +96,29 @@ func NewIOutboxRepositoryMock(t minimock.Tester) *IOutboxRepositoryMock {
 	m.CreateEventMock = mIOutboxRepositoryMockCreateEvent{mock: m}
 	m.CreateEventMock.callArgs = []*IOutboxRepositoryMockCreateEventParams{}
 
-	m.FetchNextMsgMock = mIOutboxRepositoryMockFetchNextMsg{mock: m}
-	m.FetchNextMsgMock.callArgs = []*IOutboxRepositoryMockFetchNextMsgParams{}
+	m.DeleteProcessedMock = mIOutboxRepositoryMockDeleteProcessed{mock: m}
+	m.DeleteProcessedMock.callArgs = []*IOutboxRepositoryMockDeleteProcessedParams{}
+
+	m.FetchBatchMock = mIOutboxRepositoryMockFetchBatch{mock: m}
+	m.FetchBatchMock.callArgs = []*IOutboxRepositoryMockFetchBatchParams{}
+
+	m.ListMock = mIOutboxRepositoryMockList{mock: m}
+	m.ListMock.callArgs = []*IOutboxRepositoryMockListParams{}
+
+	m.MarkAsFailedMock = mIOutboxRepositoryMockMarkAsFailed{mock: m}
+	m.MarkAsFailedMock.callArgs = []*IOutboxRepositoryMockMarkAsFailedParams{}
 
 	m.MarkAsSentMock = mIOutboxRepositoryMockMarkAsSent{mock: m}
 	m.MarkAsSentMock.callArgs = []*IOutboxRepositoryMockMarkAsSentParams{}
 
+	m.ResetMock = mIOutboxRepositoryMockReset{mock: m}
+	m.ResetMock.callArgs = []*IOutboxRepositoryMockResetParams{}
+
+	m.ShardsMock = mIOutboxRepositoryMockShards{mock: m}
+
+	m.WithShardTxMock = mIOutboxRepositoryMockWithShardTx{mock: m}
+	m.WithShardTxMock.callArgs = []*IOutboxRepositoryMockWithShardTxParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
@@ -468,53 +528,56 @@ func (m *IOutboxRepositoryMock) MinimockCreateEventInspect() {
 	}
 }
 
-type mIOutboxRepositoryMockFetchNextMsg struct {
+type mIOutboxRepositoryMockDeleteProcessed struct {
 	optional           bool
 	mock               *IOutboxRepositoryMock
-	defaultExpectation *IOutboxRepositoryMockFetchNextMsgExpectation
-	expectations       []*IOutboxRepositoryMockFetchNextMsgExpectation
+	defaultExpectation *IOutboxRepositoryMockDeleteProcessedExpectation
+	expectations       []*IOutboxRepositoryMockDeleteProcessedExpectation
 
-	callArgs []*IOutboxRepositoryMockFetchNextMsgParams
+	callArgs []*IOutboxRepositoryMockDeleteProcessedParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// IOutboxRepositoryMockFetchNextMsgExpectation specifies expectation struct of the IOutboxRepository.FetchNextMsg
-type IOutboxRepositoryMockFetchNextMsgExpectation struct {
+// IOutboxRepositoryMockDeleteProcessedExpectation specifies expectation struct of the IOutboxRepository.DeleteProcessed
+type IOutboxRepositoryMockDeleteProcessedExpectation struct {
 	mock               *IOutboxRepositoryMock
-	params             *IOutboxRepositoryMockFetchNextMsgParams
-	paramPtrs          *IOutboxRepositoryMockFetchNextMsgParamPtrs
-	expectationOrigins IOutboxRepositoryMockFetchNextMsgExpectationOrigins
-	results            *IOutboxRepositoryMockFetchNextMsgResults
+	params             *IOutboxRepositoryMockDeleteProcessedParams
+	paramPtrs          *IOutboxRepositoryMockDeleteProcessedParamPtrs
+	expectationOrigins IOutboxRepositoryMockDeleteProcessedExpectationOrigins
+	results            *IOutboxRepositoryMockDeleteProcessedResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// IOutboxRepositoryMockFetchNextMsgParams contains parameters of the IOutboxRepository.FetchNextMsg
-type IOutboxRepositoryMockFetchNextMsgParams struct {
-	ctx context.Context
-	tx  pgx.Tx
+// IOutboxRepositoryMockDeleteProcessedParams contains parameters of the IOutboxRepository.DeleteProcessed
+type IOutboxRepositoryMockDeleteProcessedParams struct {
+	ctx             context.Context
+	shard           models.OutboxShard
+	processedBefore time.Time
 }
 
-// IOutboxRepositoryMockFetchNextMsgParamPtrs contains pointers to parameters of the IOutboxRepository.FetchNextMsg
-type IOutboxRepositoryMockFetchNextMsgParamPtrs struct {
-	ctx *context.Context
-	tx  *pgx.Tx
+// IOutboxRepositoryMockDeleteProcessedParamPtrs contains pointers to parameters of the IOutboxRepository.DeleteProcessed
+type IOutboxRepositoryMockDeleteProcessedParamPtrs struct {
+	ctx             *context.Context
+	shard           *models.OutboxShard
+	processedBefore *time.Time
 }
 
-// IOutboxRepositoryMockFetchNextMsgResults contains results of the IOutboxRepository.FetchNextMsg
-type IOutboxRepositoryMockFetchNextMsgResults struct {
-	op1 *models.OutboxEvent
+// IOutboxRepositoryMockDeleteProcessedResults contains results of the IOutboxRepository.DeleteProcessed
+type IOutboxRepositoryMockDeleteProcessedResults struct {
+	i1  int64
 	err error
 }
 
-// IOutboxRepositoryMockFetchNextMsgOrigins contains origins of expectations of the IOutboxRepository.FetchNextMsg
-type IOutboxRepositoryMockFetchNextMsgExpectationOrigins struct {
-	origin    string
-	originCtx string
-	originTx  string
+// IOutboxRepositoryMockDeleteProcessedOrigins contains origins of expectations of the IOutboxRepository.DeleteProcessed
+type IOutboxRepositoryMockDeleteProcessedExpectationOrigins struct {
+	origin                string
+	originCtx             string
+	originShard           string
+	originProcessedBefore string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -522,344 +585,373 @@ type IOutboxRepositoryMockFetchNextMsgExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) Optional() *mIOutboxRepositoryMockFetchNextMsg {
-	mmFetchNextMsg.optional = true
-	return mmFetchNextMsg
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) Optional() *mIOutboxRepositoryMockDeleteProcessed {
+	mmDeleteProcessed.optional = true
+	return mmDeleteProcessed
 }
 
-// Expect sets up expected params for IOutboxRepository.FetchNextMsg
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) Expect(ctx context.Context, tx pgx.Tx) *mIOutboxRepositoryMockFetchNextMsg {
-	if mmFetchNextMsg.mock.funcFetchNextMsg != nil {
-		mmFetchNextMsg.mock.t.Fatalf("IOutboxRepositoryMock.FetchNextMsg mock is already set by Set")
+// Expect sets up expected params for IOutboxRepository.DeleteProcessed
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) Expect(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) *mIOutboxRepositoryMockDeleteProcessed {
+	if mmDeleteProcessed.mock.funcDeleteProcessed != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Set")
 	}
 
-	if mmFetchNextMsg.defaultExpectation == nil {
-		mmFetchNextMsg.defaultExpectation = &IOutboxRepositoryMockFetchNextMsgExpectation{}
+	if mmDeleteProcessed.defaultExpectation == nil {
+		mmDeleteProcessed.defaultExpectation = &IOutboxRepositoryMockDeleteProcessedExpectation{}
 	}
 
-	if mmFetchNextMsg.defaultExpectation.paramPtrs != nil {
-		mmFetchNextMsg.mock.t.Fatalf("IOutboxRepositoryMock.FetchNextMsg mock is already set by ExpectParams functions")
+	if mmDeleteProcessed.defaultExpectation.paramPtrs != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by ExpectParams functions")
 	}
 
-	mmFetchNextMsg.defaultExpectation.params = &IOutboxRepositoryMockFetchNextMsgParams{ctx, tx}
-	mmFetchNextMsg.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmFetchNextMsg.expectations {
-		if minimock.Equal(e.params, mmFetchNextMsg.defaultExpectation.params) {
-			mmFetchNextMsg.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmFetchNextMsg.defaultExpectation.params)
+	mmDeleteProcessed.defaultExpectation.params = &IOutboxRepositoryMockDeleteProcessedParams{ctx, shard, processedBefore}
+	mmDeleteProcessed.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmDeleteProcessed.expectations {
+		if minimock.Equal(e.params, mmDeleteProcessed.defaultExpectation.params) {
+			mmDeleteProcessed.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteProcessed.defaultExpectation.params)
 		}
 	}
 
-	return mmFetchNextMsg
+	return mmDeleteProcessed
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.DeleteProcessed
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockDeleteProcessed {
+	if mmDeleteProcessed.mock.funcDeleteProcessed != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Set")
+	}
+
+	if mmDeleteProcessed.defaultExpectation == nil {
+		mmDeleteProcessed.defaultExpectation = &IOutboxRepositoryMockDeleteProcessedExpectation{}
+	}
+
+	if mmDeleteProcessed.defaultExpectation.params != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Expect")
+	}
+
+	if mmDeleteProcessed.defaultExpectation.paramPtrs == nil {
+		mmDeleteProcessed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockDeleteProcessedParamPtrs{}
+	}
+	mmDeleteProcessed.defaultExpectation.paramPtrs.ctx = &ctx
+	mmDeleteProcessed.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmDeleteProcessed
 }
 
-// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.FetchNextMsg
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockFetchNextMsg {
-	if mmFetchNextMsg.mock.funcFetchNextMsg != nil {
-		mmFetchNextMsg.mock.t.Fatalf("IOutboxRepositoryMock.FetchNextMsg mock is already set by Set")
+// ExpectShardParam2 sets up expected param shard for IOutboxRepository.DeleteProcessed
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) ExpectShardParam2(shard models.OutboxShard) *mIOutboxRepositoryMockDeleteProcessed {
+	if mmDeleteProcessed.mock.funcDeleteProcessed != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Set")
 	}
 
-	if mmFetchNextMsg.defaultExpectation == nil {
-		mmFetchNextMsg.defaultExpectation = &IOutboxRepositoryMockFetchNextMsgExpectation{}
+	if mmDeleteProcessed.defaultExpectation == nil {
+		mmDeleteProcessed.defaultExpectation = &IOutboxRepositoryMockDeleteProcessedExpectation{}
 	}
 
-	if mmFetchNextMsg.defaultExpectation.params != nil {
-		mmFetchNextMsg.mock.t.Fatalf("IOutboxRepositoryMock.FetchNextMsg mock is already set by Expect")
+	if mmDeleteProcessed.defaultExpectation.params != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Expect")
 	}
 
-	if mmFetchNextMsg.defaultExpectation.paramPtrs == nil {
-		mmFetchNextMsg.defaultExpectation.paramPtrs = &IOutboxRepositoryMockFetchNextMsgParamPtrs{}
+	if mmDeleteProcessed.defaultExpectation.paramPtrs == nil {
+		mmDeleteProcessed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockDeleteProcessedParamPtrs{}
 	}
-	mmFetchNextMsg.defaultExpectation.paramPtrs.ctx = &ctx
-	mmFetchNextMsg.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+	mmDeleteProcessed.defaultExpectation.paramPtrs.shard = &shard
+	mmDeleteProcessed.defaultExpectation.expectationOrigins.originShard = minimock.CallerInfo(1)
 
-	return mmFetchNextMsg
+	return mmDeleteProcessed
 }
 
-// ExpectTxParam2 sets up expected param tx for IOutboxRepository.FetchNextMsg
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) ExpectTxParam2(tx pgx.Tx) *mIOutboxRepositoryMockFetchNextMsg {
-	if mmFetchNextMsg.mock.funcFetchNextMsg != nil {
-		mmFetchNextMsg.mock.t.Fatalf("IOutboxRepositoryMock.FetchNextMsg mock is already set by Set")
+// ExpectProcessedBeforeParam3 sets up expected param processedBefore for IOutboxRepository.DeleteProcessed
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) ExpectProcessedBeforeParam3(processedBefore time.Time) *mIOutboxRepositoryMockDeleteProcessed {
+	if mmDeleteProcessed.mock.funcDeleteProcessed != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Set")
 	}
 
-	if mmFetchNextMsg.defaultExpectation == nil {
-		mmFetchNextMsg.defaultExpectation = &IOutboxRepositoryMockFetchNextMsgExpectation{}
+	if mmDeleteProcessed.defaultExpectation == nil {
+		mmDeleteProcessed.defaultExpectation = &IOutboxRepositoryMockDeleteProcessedExpectation{}
 	}
 
-	if mmFetchNextMsg.defaultExpectation.params != nil {
-		mmFetchNextMsg.mock.t.Fatalf("IOutboxRepositoryMock.FetchNextMsg mock is already set by Expect")
+	if mmDeleteProcessed.defaultExpectation.params != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Expect")
 	}
 
-	if mmFetchNextMsg.defaultExpectation.paramPtrs == nil {
-		mmFetchNextMsg.defaultExpectation.paramPtrs = &IOutboxRepositoryMockFetchNextMsgParamPtrs{}
+	if mmDeleteProcessed.defaultExpectation.paramPtrs == nil {
+		mmDeleteProcessed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockDeleteProcessedParamPtrs{}
 	}
-	mmFetchNextMsg.defaultExpectation.paramPtrs.tx = &tx
-	mmFetchNextMsg.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+	mmDeleteProcessed.defaultExpectation.paramPtrs.processedBefore = &processedBefore
+	mmDeleteProcessed.defaultExpectation.expectationOrigins.originProcessedBefore = minimock.CallerInfo(1)
 
-	return mmFetchNextMsg
+	return mmDeleteProcessed
 }
 
-// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.FetchNextMsg
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) Inspect(f func(ctx context.Context, tx pgx.Tx)) *mIOutboxRepositoryMockFetchNextMsg {
-	if mmFetchNextMsg.mock.inspectFuncFetchNextMsg != nil {
-		mmFetchNextMsg.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.FetchNextMsg")
+// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.DeleteProcessed
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) Inspect(f func(ctx context.Context, shard models.OutboxShard, processedBefore time.Time)) *mIOutboxRepositoryMockDeleteProcessed {
+	if mmDeleteProcessed.mock.inspectFuncDeleteProcessed != nil {
+		mmDeleteProcessed.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.DeleteProcessed")
 	}
 
-	mmFetchNextMsg.mock.inspectFuncFetchNextMsg = f
+	mmDeleteProcessed.mock.inspectFuncDeleteProcessed = f
 
-	return mmFetchNextMsg
+	return mmDeleteProcessed
 }
 
-// Return sets up results that will be returned by IOutboxRepository.FetchNextMsg
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) Return(op1 *models.OutboxEvent, err error) *IOutboxRepositoryMock {
-	if mmFetchNextMsg.mock.funcFetchNextMsg != nil {
-		mmFetchNextMsg.mock.t.Fatalf("IOutboxRepositoryMock.FetchNextMsg mock is already set by Set")
+// Return sets up results that will be returned by IOutboxRepository.DeleteProcessed
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) Return(i1 int64, err error) *IOutboxRepositoryMock {
+	if mmDeleteProcessed.mock.funcDeleteProcessed != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Set")
 	}
 
-	if mmFetchNextMsg.defaultExpectation == nil {
-		mmFetchNextMsg.defaultExpectation = &IOutboxRepositoryMockFetchNextMsgExpectation{mock: mmFetchNextMsg.mock}
+	if mmDeleteProcessed.defaultExpectation == nil {
+		mmDeleteProcessed.defaultExpectation = &IOutboxRepositoryMockDeleteProcessedExpectation{mock: mmDeleteProcessed.mock}
 	}
-	mmFetchNextMsg.defaultExpectation.results = &IOutboxRepositoryMockFetchNextMsgResults{op1, err}
-	mmFetchNextMsg.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmFetchNextMsg.mock
+	mmDeleteProcessed.defaultExpectation.results = &IOutboxRepositoryMockDeleteProcessedResults{i1, err}
+	mmDeleteProcessed.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmDeleteProcessed.mock
 }
 
-// Set uses given function f to mock the IOutboxRepository.FetchNextMsg method
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) Set(f func(ctx context.Context, tx pgx.Tx) (op1 *models.OutboxEvent, err error)) *IOutboxRepositoryMock {
-	if mmFetchNextMsg.defaultExpectation != nil {
-		mmFetchNextMsg.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.FetchNextMsg method")
+// Set uses given function f to mock the IOutboxRepository.DeleteProcessed method
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) Set(f func(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) (i1 int64, err error)) *IOutboxRepositoryMock {
+	if mmDeleteProcessed.defaultExpectation != nil {
+		mmDeleteProcessed.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.DeleteProcessed method")
 	}
 
-	if len(mmFetchNextMsg.expectations) > 0 {
-		mmFetchNextMsg.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.FetchNextMsg method")
+	if len(mmDeleteProcessed.expectations) > 0 {
+		mmDeleteProcessed.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.DeleteProcessed method")
 	}
 
-	mmFetchNextMsg.mock.funcFetchNextMsg = f
-	mmFetchNextMsg.mock.funcFetchNextMsgOrigin = minimock.CallerInfo(1)
-	return mmFetchNextMsg.mock
+	mmDeleteProcessed.mock.funcDeleteProcessed = f
+	mmDeleteProcessed.mock.funcDeleteProcessedOrigin = minimock.CallerInfo(1)
+	return mmDeleteProcessed.mock
 }
 
-// When sets expectation for the IOutboxRepository.FetchNextMsg which will trigger the result defined by the following
+// When sets expectation for the IOutboxRepository.DeleteProcessed which will trigger the result defined by the following
 // Then helper
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) When(ctx context.Context, tx pgx.Tx) *IOutboxRepositoryMockFetchNextMsgExpectation {
-	if mmFetchNextMsg.mock.funcFetchNextMsg != nil {
-		mmFetchNextMsg.mock.t.Fatalf("IOutboxRepositoryMock.FetchNextMsg mock is already set by Set")
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) When(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) *IOutboxRepositoryMockDeleteProcessedExpectation {
+	if mmDeleteProcessed.mock.funcDeleteProcessed != nil {
+		mmDeleteProcessed.mock.t.Fatalf("IOutboxRepositoryMock.DeleteProcessed mock is already set by Set")
 	}
 
-	expectation := &IOutboxRepositoryMockFetchNextMsgExpectation{
-		mock:               mmFetchNextMsg.mock,
-		params:             &IOutboxRepositoryMockFetchNextMsgParams{ctx, tx},
-		expectationOrigins: IOutboxRepositoryMockFetchNextMsgExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &IOutboxRepositoryMockDeleteProcessedExpectation{
+		mock:               mmDeleteProcessed.mock,
+		params:             &IOutboxRepositoryMockDeleteProcessedParams{ctx, shard, processedBefore},
+		expectationOrigins: IOutboxRepositoryMockDeleteProcessedExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmFetchNextMsg.expectations = append(mmFetchNextMsg.expectations, expectation)
+	mmDeleteProcessed.expectations = append(mmDeleteProcessed.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IOutboxRepository.FetchNextMsg return parameters for the expectation previously defined by the When method
-func (e *IOutboxRepositoryMockFetchNextMsgExpectation) Then(op1 *models.OutboxEvent, err error) *IOutboxRepositoryMock {
-	e.results = &IOutboxRepositoryMockFetchNextMsgResults{op1, err}
+// Then sets up IOutboxRepository.DeleteProcessed return parameters for the expectation previously defined by the When method
+func (e *IOutboxRepositoryMockDeleteProcessedExpectation) Then(i1 int64, err error) *IOutboxRepositoryMock {
+	e.results = &IOutboxRepositoryMockDeleteProcessedResults{i1, err}
 	return e.mock
 }
 
-// Times sets number of times IOutboxRepository.FetchNextMsg should be invoked
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) Times(n uint64) *mIOutboxRepositoryMockFetchNextMsg {
+// Times sets number of times IOutboxRepository.DeleteProcessed should be invoked
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) Times(n uint64) *mIOutboxRepositoryMockDeleteProcessed {
 	if n == 0 {
-		mmFetchNextMsg.mock.t.Fatalf("Times of IOutboxRepositoryMock.FetchNextMsg mock can not be zero")
+		mmDeleteProcessed.mock.t.Fatalf("Times of IOutboxRepositoryMock.DeleteProcessed mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmFetchNextMsg.expectedInvocations, n)
-	mmFetchNextMsg.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmFetchNextMsg
+	mm_atomic.StoreUint64(&mmDeleteProcessed.expectedInvocations, n)
+	mmDeleteProcessed.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmDeleteProcessed
 }
 
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) invocationsDone() bool {
-	if len(mmFetchNextMsg.expectations) == 0 && mmFetchNextMsg.defaultExpectation == nil && mmFetchNextMsg.mock.funcFetchNextMsg == nil {
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) invocationsDone() bool {
+	if len(mmDeleteProcessed.expectations) == 0 && mmDeleteProcessed.defaultExpectation == nil && mmDeleteProcessed.mock.funcDeleteProcessed == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmFetchNextMsg.mock.afterFetchNextMsgCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmFetchNextMsg.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmDeleteProcessed.mock.afterDeleteProcessedCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeleteProcessed.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// FetchNextMsg implements mm_service.IOutboxRepository
-func (mmFetchNextMsg *IOutboxRepositoryMock) FetchNextMsg(ctx context.Context, tx pgx.Tx) (op1 *models.OutboxEvent, err error) {
-	mm_atomic.AddUint64(&mmFetchNextMsg.beforeFetchNextMsgCounter, 1)
-	defer mm_atomic.AddUint64(&mmFetchNextMsg.afterFetchNextMsgCounter, 1)
+// DeleteProcessed implements mm_service.IOutboxRepository
+func (mmDeleteProcessed *IOutboxRepositoryMock) DeleteProcessed(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) (i1 int64, err error) {
+	mm_atomic.AddUint64(&mmDeleteProcessed.beforeDeleteProcessedCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteProcessed.afterDeleteProcessedCounter, 1)
 
-	mmFetchNextMsg.t.Helper()
+	mmDeleteProcessed.t.Helper()
 
-	if mmFetchNextMsg.inspectFuncFetchNextMsg != nil {
-		mmFetchNextMsg.inspectFuncFetchNextMsg(ctx, tx)
+	if mmDeleteProcessed.inspectFuncDeleteProcessed != nil {
+		mmDeleteProcessed.inspectFuncDeleteProcessed(ctx, shard, processedBefore)
 	}
 
-	mm_params := IOutboxRepositoryMockFetchNextMsgParams{ctx, tx}
+	mm_params := IOutboxRepositoryMockDeleteProcessedParams{ctx, shard, processedBefore}
 
 	// Record call args
-	mmFetchNextMsg.FetchNextMsgMock.mutex.Lock()
-	mmFetchNextMsg.FetchNextMsgMock.callArgs = append(mmFetchNextMsg.FetchNextMsgMock.callArgs, &mm_params)
-	mmFetchNextMsg.FetchNextMsgMock.mutex.Unlock()
+	mmDeleteProcessed.DeleteProcessedMock.mutex.Lock()
+	mmDeleteProcessed.DeleteProcessedMock.callArgs = append(mmDeleteProcessed.DeleteProcessedMock.callArgs, &mm_params)
+	mmDeleteProcessed.DeleteProcessedMock.mutex.Unlock()
 
-	for _, e := range mmFetchNextMsg.FetchNextMsgMock.expectations {
+	for _, e := range mmDeleteProcessed.DeleteProcessedMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.op1, e.results.err
+			return e.results.i1, e.results.err
 		}
 	}
 
-	if mmFetchNextMsg.FetchNextMsgMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmFetchNextMsg.FetchNextMsgMock.defaultExpectation.Counter, 1)
-		mm_want := mmFetchNextMsg.FetchNextMsgMock.defaultExpectation.params
-		mm_want_ptrs := mmFetchNextMsg.FetchNextMsgMock.defaultExpectation.paramPtrs
+	if mmDeleteProcessed.DeleteProcessedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteProcessed.DeleteProcessedMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteProcessed.DeleteProcessedMock.defaultExpectation.params
+		mm_want_ptrs := mmDeleteProcessed.DeleteProcessedMock.defaultExpectation.paramPtrs
 
-		mm_got := IOutboxRepositoryMockFetchNextMsgParams{ctx, tx}
+		mm_got := IOutboxRepositoryMockDeleteProcessedParams{ctx, shard, processedBefore}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmFetchNextMsg.t.Errorf("IOutboxRepositoryMock.FetchNextMsg got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmFetchNextMsg.FetchNextMsgMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmDeleteProcessed.t.Errorf("IOutboxRepositoryMock.DeleteProcessed got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteProcessed.DeleteProcessedMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
-				mmFetchNextMsg.t.Errorf("IOutboxRepositoryMock.FetchNextMsg got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmFetchNextMsg.FetchNextMsgMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			if mm_want_ptrs.shard != nil && !minimock.Equal(*mm_want_ptrs.shard, mm_got.shard) {
+				mmDeleteProcessed.t.Errorf("IOutboxRepositoryMock.DeleteProcessed got unexpected parameter shard, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteProcessed.DeleteProcessedMock.defaultExpectation.expectationOrigins.originShard, *mm_want_ptrs.shard, mm_got.shard, minimock.Diff(*mm_want_ptrs.shard, mm_got.shard))
+			}
+
+			if mm_want_ptrs.processedBefore != nil && !minimock.Equal(*mm_want_ptrs.processedBefore, mm_got.processedBefore) {
+				mmDeleteProcessed.t.Errorf("IOutboxRepositoryMock.DeleteProcessed got unexpected parameter processedBefore, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteProcessed.DeleteProcessedMock.defaultExpectation.expectationOrigins.originProcessedBefore, *mm_want_ptrs.processedBefore, mm_got.processedBefore, minimock.Diff(*mm_want_ptrs.processedBefore, mm_got.processedBefore))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmFetchNextMsg.t.Errorf("IOutboxRepositoryMock.FetchNextMsg got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmFetchNextMsg.FetchNextMsgMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmDeleteProcessed.t.Errorf("IOutboxRepositoryMock.DeleteProcessed got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmDeleteProcessed.DeleteProcessedMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmFetchNextMsg.FetchNextMsgMock.defaultExpectation.results
+		mm_results := mmDeleteProcessed.DeleteProcessedMock.defaultExpectation.results
 		if mm_results == nil {
-			mmFetchNextMsg.t.Fatal("No results are set for the IOutboxRepositoryMock.FetchNextMsg")
+			mmDeleteProcessed.t.Fatal("No results are set for the IOutboxRepositoryMock.DeleteProcessed")
 		}
-		return (*mm_results).op1, (*mm_results).err
+		return (*mm_results).i1, (*mm_results).err
 	}
-	if mmFetchNextMsg.funcFetchNextMsg != nil {
-		return mmFetchNextMsg.funcFetchNextMsg(ctx, tx)
+	if mmDeleteProcessed.funcDeleteProcessed != nil {
+		return mmDeleteProcessed.funcDeleteProcessed(ctx, shard, processedBefore)
 	}
-	mmFetchNextMsg.t.Fatalf("Unexpected call to IOutboxRepositoryMock.FetchNextMsg. %v %v", ctx, tx)
+	mmDeleteProcessed.t.Fatalf("Unexpected call to IOutboxRepositoryMock.DeleteProcessed. %v %v %v", ctx, shard, processedBefore)
 	return
 }
 
-// FetchNextMsgAfterCounter returns a count of finished IOutboxRepositoryMock.FetchNextMsg invocations
-func (mmFetchNextMsg *IOutboxRepositoryMock) FetchNextMsgAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmFetchNextMsg.afterFetchNextMsgCounter)
+// DeleteProcessedAfterCounter returns a count of finished IOutboxRepositoryMock.DeleteProcessed invocations
+func (mmDeleteProcessed *IOutboxRepositoryMock) DeleteProcessedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteProcessed.afterDeleteProcessedCounter)
 }
 
-// FetchNextMsgBeforeCounter returns a count of IOutboxRepositoryMock.FetchNextMsg invocations
-func (mmFetchNextMsg *IOutboxRepositoryMock) FetchNextMsgBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmFetchNextMsg.beforeFetchNextMsgCounter)
+// DeleteProcessedBeforeCounter returns a count of IOutboxRepositoryMock.DeleteProcessed invocations
+func (mmDeleteProcessed *IOutboxRepositoryMock) DeleteProcessedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteProcessed.beforeDeleteProcessedCounter)
 }
 
-// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.FetchNextMsg.
+// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.DeleteProcessed.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmFetchNextMsg *mIOutboxRepositoryMockFetchNextMsg) Calls() []*IOutboxRepositoryMockFetchNextMsgParams {
-	mmFetchNextMsg.mutex.RLock()
+func (mmDeleteProcessed *mIOutboxRepositoryMockDeleteProcessed) Calls() []*IOutboxRepositoryMockDeleteProcessedParams {
+	mmDeleteProcessed.mutex.RLock()
 
-	argCopy := make([]*IOutboxRepositoryMockFetchNextMsgParams, len(mmFetchNextMsg.callArgs))
-	copy(argCopy, mmFetchNextMsg.callArgs)
+	argCopy := make([]*IOutboxRepositoryMockDeleteProcessedParams, len(mmDeleteProcessed.callArgs))
+	copy(argCopy, mmDeleteProcessed.callArgs)
 
-	mmFetchNextMsg.mutex.RUnlock()
+	mmDeleteProcessed.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockFetchNextMsgDone returns true if the count of the FetchNextMsg invocations corresponds
+// MinimockDeleteProcessedDone returns true if the count of the DeleteProcessed invocations corresponds
 // the number of defined expectations
-func (m *IOutboxRepositoryMock) MinimockFetchNextMsgDone() bool {
-	if m.FetchNextMsgMock.optional {
+func (m *IOutboxRepositoryMock) MinimockDeleteProcessedDone() bool {
+	if m.DeleteProcessedMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.FetchNextMsgMock.expectations {
+	for _, e := range m.DeleteProcessedMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.FetchNextMsgMock.invocationsDone()
+	return m.DeleteProcessedMock.invocationsDone()
 }
 
-// MinimockFetchNextMsgInspect logs each unmet expectation
-func (m *IOutboxRepositoryMock) MinimockFetchNextMsgInspect() {
-	for _, e := range m.FetchNextMsgMock.expectations {
+// MinimockDeleteProcessedInspect logs each unmet expectation
+func (m *IOutboxRepositoryMock) MinimockDeleteProcessedInspect() {
+	for _, e := range m.DeleteProcessedMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IOutboxRepositoryMock.FetchNextMsg at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.DeleteProcessed at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterFetchNextMsgCounter := mm_atomic.LoadUint64(&m.afterFetchNextMsgCounter)
+	afterDeleteProcessedCounter := mm_atomic.LoadUint64(&m.afterDeleteProcessedCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.FetchNextMsgMock.defaultExpectation != nil && afterFetchNextMsgCounter < 1 {
-		if m.FetchNextMsgMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to IOutboxRepositoryMock.FetchNextMsg at\n%s", m.FetchNextMsgMock.defaultExpectation.returnOrigin)
+	if m.DeleteProcessedMock.defaultExpectation != nil && afterDeleteProcessedCounter < 1 {
+		if m.DeleteProcessedMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.DeleteProcessed at\n%s", m.DeleteProcessedMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to IOutboxRepositoryMock.FetchNextMsg at\n%s with params: %#v", m.FetchNextMsgMock.defaultExpectation.expectationOrigins.origin, *m.FetchNextMsgMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.DeleteProcessed at\n%s with params: %#v", m.DeleteProcessedMock.defaultExpectation.expectationOrigins.origin, *m.DeleteProcessedMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcFetchNextMsg != nil && afterFetchNextMsgCounter < 1 {
-		m.t.Errorf("Expected call to IOutboxRepositoryMock.FetchNextMsg at\n%s", m.funcFetchNextMsgOrigin)
+	if m.funcDeleteProcessed != nil && afterDeleteProcessedCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.DeleteProcessed at\n%s", m.funcDeleteProcessedOrigin)
 	}
 
-	if !m.FetchNextMsgMock.invocationsDone() && afterFetchNextMsgCounter > 0 {
-		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.FetchNextMsg at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.FetchNextMsgMock.expectedInvocations), m.FetchNextMsgMock.expectedInvocationsOrigin, afterFetchNextMsgCounter)
+	if !m.DeleteProcessedMock.invocationsDone() && afterDeleteProcessedCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.DeleteProcessed at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.DeleteProcessedMock.expectedInvocations), m.DeleteProcessedMock.expectedInvocationsOrigin, afterDeleteProcessedCounter)
 	}
 }
 
-type mIOutboxRepositoryMockMarkAsSent struct {
+type mIOutboxRepositoryMockFetchBatch struct {
 	optional           bool
 	mock               *IOutboxRepositoryMock
-	defaultExpectation *IOutboxRepositoryMockMarkAsSentExpectation
-	expectations       []*IOutboxRepositoryMockMarkAsSentExpectation
+	defaultExpectation *IOutboxRepositoryMockFetchBatchExpectation
+	expectations       []*IOutboxRepositoryMockFetchBatchExpectation
 
-	callArgs []*IOutboxRepositoryMockMarkAsSentParams
+	callArgs []*IOutboxRepositoryMockFetchBatchParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// IOutboxRepositoryMockMarkAsSentExpectation specifies expectation struct of the IOutboxRepository.MarkAsSent
-type IOutboxRepositoryMockMarkAsSentExpectation struct {
+// IOutboxRepositoryMockFetchBatchExpectation specifies expectation struct of the IOutboxRepository.FetchBatch
+type IOutboxRepositoryMockFetchBatchExpectation struct {
 	mock               *IOutboxRepositoryMock
-	params             *IOutboxRepositoryMockMarkAsSentParams
-	paramPtrs          *IOutboxRepositoryMockMarkAsSentParamPtrs
-	expectationOrigins IOutboxRepositoryMockMarkAsSentExpectationOrigins
-	results            *IOutboxRepositoryMockMarkAsSentResults
+	params             *IOutboxRepositoryMockFetchBatchParams
+	paramPtrs          *IOutboxRepositoryMockFetchBatchParamPtrs
+	expectationOrigins IOutboxRepositoryMockFetchBatchExpectationOrigins
+	results            *IOutboxRepositoryMockFetchBatchResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// IOutboxRepositoryMockMarkAsSentParams contains parameters of the IOutboxRepository.MarkAsSent
-type IOutboxRepositoryMockMarkAsSentParams struct {
-	ctx     context.Context
-	tx      pgx.Tx
-	eventID int64
+// IOutboxRepositoryMockFetchBatchParams contains parameters of the IOutboxRepository.FetchBatch
+type IOutboxRepositoryMockFetchBatchParams struct {
+	ctx   context.Context
+	tx    pgx.Tx
+	limit uint32
 }
 
-// IOutboxRepositoryMockMarkAsSentParamPtrs contains pointers to parameters of the IOutboxRepository.MarkAsSent
-type IOutboxRepositoryMockMarkAsSentParamPtrs struct {
-	ctx     *context.Context
-	tx      *pgx.Tx
-	eventID *int64
+// IOutboxRepositoryMockFetchBatchParamPtrs contains pointers to parameters of the IOutboxRepository.FetchBatch
+type IOutboxRepositoryMockFetchBatchParamPtrs struct {
+	ctx   *context.Context
+	tx    *pgx.Tx
+	limit *uint32
 }
 
-// IOutboxRepositoryMockMarkAsSentResults contains results of the IOutboxRepository.MarkAsSent
-type IOutboxRepositoryMockMarkAsSentResults struct {
+// IOutboxRepositoryMockFetchBatchResults contains results of the IOutboxRepository.FetchBatch
+type IOutboxRepositoryMockFetchBatchResults struct {
+	oa1 []models.OutboxEvent
 	err error
 }
 
-// IOutboxRepositoryMockMarkAsSentOrigins contains origins of expectations of the IOutboxRepository.MarkAsSent
-type IOutboxRepositoryMockMarkAsSentExpectationOrigins struct {
-	origin        string
-	originCtx     string
-	originTx      string
-	originEventID string
+// IOutboxRepositoryMockFetchBatchOrigins contains origins of expectations of the IOutboxRepository.FetchBatch
+type IOutboxRepositoryMockFetchBatchExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originTx    string
+	originLimit string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -867,332 +959,2552 @@ type IOutboxRepositoryMockMarkAsSentExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Optional() *mIOutboxRepositoryMockMarkAsSent {
-	mmMarkAsSent.optional = true
-	return mmMarkAsSent
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) Optional() *mIOutboxRepositoryMockFetchBatch {
+	mmFetchBatch.optional = true
+	return mmFetchBatch
 }
 
-// Expect sets up expected params for IOutboxRepository.MarkAsSent
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Expect(ctx context.Context, tx pgx.Tx, eventID int64) *mIOutboxRepositoryMockMarkAsSent {
-	if mmMarkAsSent.mock.funcMarkAsSent != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+// Expect sets up expected params for IOutboxRepository.FetchBatch
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) Expect(ctx context.Context, tx pgx.Tx, limit uint32) *mIOutboxRepositoryMockFetchBatch {
+	if mmFetchBatch.mock.funcFetchBatch != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Set")
 	}
 
-	if mmMarkAsSent.defaultExpectation == nil {
-		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{}
+	if mmFetchBatch.defaultExpectation == nil {
+		mmFetchBatch.defaultExpectation = &IOutboxRepositoryMockFetchBatchExpectation{}
 	}
 
-	if mmMarkAsSent.defaultExpectation.paramPtrs != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by ExpectParams functions")
+	if mmFetchBatch.defaultExpectation.paramPtrs != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by ExpectParams functions")
 	}
 
-	mmMarkAsSent.defaultExpectation.params = &IOutboxRepositoryMockMarkAsSentParams{ctx, tx, eventID}
-	mmMarkAsSent.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmMarkAsSent.expectations {
-		if minimock.Equal(e.params, mmMarkAsSent.defaultExpectation.params) {
-			mmMarkAsSent.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmMarkAsSent.defaultExpectation.params)
+	mmFetchBatch.defaultExpectation.params = &IOutboxRepositoryMockFetchBatchParams{ctx, tx, limit}
+	mmFetchBatch.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmFetchBatch.expectations {
+		if minimock.Equal(e.params, mmFetchBatch.defaultExpectation.params) {
+			mmFetchBatch.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmFetchBatch.defaultExpectation.params)
 		}
 	}
 
-	return mmMarkAsSent
+	return mmFetchBatch
 }
 
-// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.MarkAsSent
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockMarkAsSent {
-	if mmMarkAsSent.mock.funcMarkAsSent != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.FetchBatch
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockFetchBatch {
+	if mmFetchBatch.mock.funcFetchBatch != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Set")
 	}
 
-	if mmMarkAsSent.defaultExpectation == nil {
-		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{}
+	if mmFetchBatch.defaultExpectation == nil {
+		mmFetchBatch.defaultExpectation = &IOutboxRepositoryMockFetchBatchExpectation{}
 	}
 
-	if mmMarkAsSent.defaultExpectation.params != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Expect")
+	if mmFetchBatch.defaultExpectation.params != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Expect")
 	}
 
-	if mmMarkAsSent.defaultExpectation.paramPtrs == nil {
-		mmMarkAsSent.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsSentParamPtrs{}
+	if mmFetchBatch.defaultExpectation.paramPtrs == nil {
+		mmFetchBatch.defaultExpectation.paramPtrs = &IOutboxRepositoryMockFetchBatchParamPtrs{}
 	}
-	mmMarkAsSent.defaultExpectation.paramPtrs.ctx = &ctx
-	mmMarkAsSent.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+	mmFetchBatch.defaultExpectation.paramPtrs.ctx = &ctx
+	mmFetchBatch.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmMarkAsSent
+	return mmFetchBatch
 }
 
-// ExpectTxParam2 sets up expected param tx for IOutboxRepository.MarkAsSent
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) ExpectTxParam2(tx pgx.Tx) *mIOutboxRepositoryMockMarkAsSent {
-	if mmMarkAsSent.mock.funcMarkAsSent != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+// ExpectTxParam2 sets up expected param tx for IOutboxRepository.FetchBatch
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) ExpectTxParam2(tx pgx.Tx) *mIOutboxRepositoryMockFetchBatch {
+	if mmFetchBatch.mock.funcFetchBatch != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Set")
 	}
 
-	if mmMarkAsSent.defaultExpectation == nil {
-		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{}
+	if mmFetchBatch.defaultExpectation == nil {
+		mmFetchBatch.defaultExpectation = &IOutboxRepositoryMockFetchBatchExpectation{}
 	}
 
-	if mmMarkAsSent.defaultExpectation.params != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Expect")
+	if mmFetchBatch.defaultExpectation.params != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Expect")
 	}
 
-	if mmMarkAsSent.defaultExpectation.paramPtrs == nil {
-		mmMarkAsSent.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsSentParamPtrs{}
+	if mmFetchBatch.defaultExpectation.paramPtrs == nil {
+		mmFetchBatch.defaultExpectation.paramPtrs = &IOutboxRepositoryMockFetchBatchParamPtrs{}
 	}
-	mmMarkAsSent.defaultExpectation.paramPtrs.tx = &tx
-	mmMarkAsSent.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+	mmFetchBatch.defaultExpectation.paramPtrs.tx = &tx
+	mmFetchBatch.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
 
-	return mmMarkAsSent
+	return mmFetchBatch
 }
 
-// ExpectEventIDParam3 sets up expected param eventID for IOutboxRepository.MarkAsSent
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) ExpectEventIDParam3(eventID int64) *mIOutboxRepositoryMockMarkAsSent {
-	if mmMarkAsSent.mock.funcMarkAsSent != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+// ExpectLimitParam3 sets up expected param limit for IOutboxRepository.FetchBatch
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) ExpectLimitParam3(limit uint32) *mIOutboxRepositoryMockFetchBatch {
+	if mmFetchBatch.mock.funcFetchBatch != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Set")
 	}
 
-	if mmMarkAsSent.defaultExpectation == nil {
-		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{}
+	if mmFetchBatch.defaultExpectation == nil {
+		mmFetchBatch.defaultExpectation = &IOutboxRepositoryMockFetchBatchExpectation{}
 	}
 
-	if mmMarkAsSent.defaultExpectation.params != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Expect")
+	if mmFetchBatch.defaultExpectation.params != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Expect")
 	}
 
-	if mmMarkAsSent.defaultExpectation.paramPtrs == nil {
-		mmMarkAsSent.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsSentParamPtrs{}
+	if mmFetchBatch.defaultExpectation.paramPtrs == nil {
+		mmFetchBatch.defaultExpectation.paramPtrs = &IOutboxRepositoryMockFetchBatchParamPtrs{}
 	}
-	mmMarkAsSent.defaultExpectation.paramPtrs.eventID = &eventID
-	mmMarkAsSent.defaultExpectation.expectationOrigins.originEventID = minimock.CallerInfo(1)
+	mmFetchBatch.defaultExpectation.paramPtrs.limit = &limit
+	mmFetchBatch.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
 
-	return mmMarkAsSent
+	return mmFetchBatch
 }
 
-// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.MarkAsSent
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Inspect(f func(ctx context.Context, tx pgx.Tx, eventID int64)) *mIOutboxRepositoryMockMarkAsSent {
-	if mmMarkAsSent.mock.inspectFuncMarkAsSent != nil {
-		mmMarkAsSent.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.MarkAsSent")
+// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.FetchBatch
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) Inspect(f func(ctx context.Context, tx pgx.Tx, limit uint32)) *mIOutboxRepositoryMockFetchBatch {
+	if mmFetchBatch.mock.inspectFuncFetchBatch != nil {
+		mmFetchBatch.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.FetchBatch")
 	}
 
-	mmMarkAsSent.mock.inspectFuncMarkAsSent = f
+	mmFetchBatch.mock.inspectFuncFetchBatch = f
 
-	return mmMarkAsSent
+	return mmFetchBatch
 }
 
-// Return sets up results that will be returned by IOutboxRepository.MarkAsSent
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Return(err error) *IOutboxRepositoryMock {
-	if mmMarkAsSent.mock.funcMarkAsSent != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+// Return sets up results that will be returned by IOutboxRepository.FetchBatch
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) Return(oa1 []models.OutboxEvent, err error) *IOutboxRepositoryMock {
+	if mmFetchBatch.mock.funcFetchBatch != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Set")
 	}
 
-	if mmMarkAsSent.defaultExpectation == nil {
-		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{mock: mmMarkAsSent.mock}
+	if mmFetchBatch.defaultExpectation == nil {
+		mmFetchBatch.defaultExpectation = &IOutboxRepositoryMockFetchBatchExpectation{mock: mmFetchBatch.mock}
 	}
-	mmMarkAsSent.defaultExpectation.results = &IOutboxRepositoryMockMarkAsSentResults{err}
-	mmMarkAsSent.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmMarkAsSent.mock
+	mmFetchBatch.defaultExpectation.results = &IOutboxRepositoryMockFetchBatchResults{oa1, err}
+	mmFetchBatch.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmFetchBatch.mock
 }
 
-// Set uses given function f to mock the IOutboxRepository.MarkAsSent method
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Set(f func(ctx context.Context, tx pgx.Tx, eventID int64) (err error)) *IOutboxRepositoryMock {
-	if mmMarkAsSent.defaultExpectation != nil {
-		mmMarkAsSent.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.MarkAsSent method")
+// Set uses given function f to mock the IOutboxRepository.FetchBatch method
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) Set(f func(ctx context.Context, tx pgx.Tx, limit uint32) (oa1 []models.OutboxEvent, err error)) *IOutboxRepositoryMock {
+	if mmFetchBatch.defaultExpectation != nil {
+		mmFetchBatch.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.FetchBatch method")
 	}
 
-	if len(mmMarkAsSent.expectations) > 0 {
-		mmMarkAsSent.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.MarkAsSent method")
+	if len(mmFetchBatch.expectations) > 0 {
+		mmFetchBatch.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.FetchBatch method")
 	}
 
-	mmMarkAsSent.mock.funcMarkAsSent = f
-	mmMarkAsSent.mock.funcMarkAsSentOrigin = minimock.CallerInfo(1)
-	return mmMarkAsSent.mock
+	mmFetchBatch.mock.funcFetchBatch = f
+	mmFetchBatch.mock.funcFetchBatchOrigin = minimock.CallerInfo(1)
+	return mmFetchBatch.mock
 }
 
-// When sets expectation for the IOutboxRepository.MarkAsSent which will trigger the result defined by the following
+// When sets expectation for the IOutboxRepository.FetchBatch which will trigger the result defined by the following
 // Then helper
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) When(ctx context.Context, tx pgx.Tx, eventID int64) *IOutboxRepositoryMockMarkAsSentExpectation {
-	if mmMarkAsSent.mock.funcMarkAsSent != nil {
-		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) When(ctx context.Context, tx pgx.Tx, limit uint32) *IOutboxRepositoryMockFetchBatchExpectation {
+	if mmFetchBatch.mock.funcFetchBatch != nil {
+		mmFetchBatch.mock.t.Fatalf("IOutboxRepositoryMock.FetchBatch mock is already set by Set")
 	}
 
-	expectation := &IOutboxRepositoryMockMarkAsSentExpectation{
-		mock:               mmMarkAsSent.mock,
-		params:             &IOutboxRepositoryMockMarkAsSentParams{ctx, tx, eventID},
-		expectationOrigins: IOutboxRepositoryMockMarkAsSentExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &IOutboxRepositoryMockFetchBatchExpectation{
+		mock:               mmFetchBatch.mock,
+		params:             &IOutboxRepositoryMockFetchBatchParams{ctx, tx, limit},
+		expectationOrigins: IOutboxRepositoryMockFetchBatchExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmMarkAsSent.expectations = append(mmMarkAsSent.expectations, expectation)
+	mmFetchBatch.expectations = append(mmFetchBatch.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IOutboxRepository.MarkAsSent return parameters for the expectation previously defined by the When method
-func (e *IOutboxRepositoryMockMarkAsSentExpectation) Then(err error) *IOutboxRepositoryMock {
-	e.results = &IOutboxRepositoryMockMarkAsSentResults{err}
+// Then sets up IOutboxRepository.FetchBatch return parameters for the expectation previously defined by the When method
+func (e *IOutboxRepositoryMockFetchBatchExpectation) Then(oa1 []models.OutboxEvent, err error) *IOutboxRepositoryMock {
+	e.results = &IOutboxRepositoryMockFetchBatchResults{oa1, err}
 	return e.mock
 }
 
-// Times sets number of times IOutboxRepository.MarkAsSent should be invoked
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Times(n uint64) *mIOutboxRepositoryMockMarkAsSent {
+// Times sets number of times IOutboxRepository.FetchBatch should be invoked
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) Times(n uint64) *mIOutboxRepositoryMockFetchBatch {
 	if n == 0 {
-		mmMarkAsSent.mock.t.Fatalf("Times of IOutboxRepositoryMock.MarkAsSent mock can not be zero")
+		mmFetchBatch.mock.t.Fatalf("Times of IOutboxRepositoryMock.FetchBatch mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmMarkAsSent.expectedInvocations, n)
-	mmMarkAsSent.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmMarkAsSent
+	mm_atomic.StoreUint64(&mmFetchBatch.expectedInvocations, n)
+	mmFetchBatch.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmFetchBatch
 }
 
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) invocationsDone() bool {
-	if len(mmMarkAsSent.expectations) == 0 && mmMarkAsSent.defaultExpectation == nil && mmMarkAsSent.mock.funcMarkAsSent == nil {
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) invocationsDone() bool {
+	if len(mmFetchBatch.expectations) == 0 && mmFetchBatch.defaultExpectation == nil && mmFetchBatch.mock.funcFetchBatch == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmMarkAsSent.mock.afterMarkAsSentCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmMarkAsSent.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmFetchBatch.mock.afterFetchBatchCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmFetchBatch.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// MarkAsSent implements mm_service.IOutboxRepository
-func (mmMarkAsSent *IOutboxRepositoryMock) MarkAsSent(ctx context.Context, tx pgx.Tx, eventID int64) (err error) {
-	mm_atomic.AddUint64(&mmMarkAsSent.beforeMarkAsSentCounter, 1)
-	defer mm_atomic.AddUint64(&mmMarkAsSent.afterMarkAsSentCounter, 1)
+// FetchBatch implements mm_service.IOutboxRepository
+func (mmFetchBatch *IOutboxRepositoryMock) FetchBatch(ctx context.Context, tx pgx.Tx, limit uint32) (oa1 []models.OutboxEvent, err error) {
+	mm_atomic.AddUint64(&mmFetchBatch.beforeFetchBatchCounter, 1)
+	defer mm_atomic.AddUint64(&mmFetchBatch.afterFetchBatchCounter, 1)
 
-	mmMarkAsSent.t.Helper()
+	mmFetchBatch.t.Helper()
 
-	if mmMarkAsSent.inspectFuncMarkAsSent != nil {
-		mmMarkAsSent.inspectFuncMarkAsSent(ctx, tx, eventID)
+	if mmFetchBatch.inspectFuncFetchBatch != nil {
+		mmFetchBatch.inspectFuncFetchBatch(ctx, tx, limit)
 	}
 
-	mm_params := IOutboxRepositoryMockMarkAsSentParams{ctx, tx, eventID}
+	mm_params := IOutboxRepositoryMockFetchBatchParams{ctx, tx, limit}
 
 	// Record call args
-	mmMarkAsSent.MarkAsSentMock.mutex.Lock()
-	mmMarkAsSent.MarkAsSentMock.callArgs = append(mmMarkAsSent.MarkAsSentMock.callArgs, &mm_params)
-	mmMarkAsSent.MarkAsSentMock.mutex.Unlock()
+	mmFetchBatch.FetchBatchMock.mutex.Lock()
+	mmFetchBatch.FetchBatchMock.callArgs = append(mmFetchBatch.FetchBatchMock.callArgs, &mm_params)
+	mmFetchBatch.FetchBatchMock.mutex.Unlock()
 
-	for _, e := range mmMarkAsSent.MarkAsSentMock.expectations {
+	for _, e := range mmFetchBatch.FetchBatchMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.oa1, e.results.err
 		}
 	}
 
-	if mmMarkAsSent.MarkAsSentMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmMarkAsSent.MarkAsSentMock.defaultExpectation.Counter, 1)
-		mm_want := mmMarkAsSent.MarkAsSentMock.defaultExpectation.params
-		mm_want_ptrs := mmMarkAsSent.MarkAsSentMock.defaultExpectation.paramPtrs
+	if mmFetchBatch.FetchBatchMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmFetchBatch.FetchBatchMock.defaultExpectation.Counter, 1)
+		mm_want := mmFetchBatch.FetchBatchMock.defaultExpectation.params
+		mm_want_ptrs := mmFetchBatch.FetchBatchMock.defaultExpectation.paramPtrs
 
-		mm_got := IOutboxRepositoryMockMarkAsSentParams{ctx, tx, eventID}
+		mm_got := IOutboxRepositoryMockFetchBatchParams{ctx, tx, limit}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmMarkAsSent.t.Errorf("IOutboxRepositoryMock.MarkAsSent got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmMarkAsSent.MarkAsSentMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmFetchBatch.t.Errorf("IOutboxRepositoryMock.FetchBatch got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmFetchBatch.FetchBatchMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
 			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
-				mmMarkAsSent.t.Errorf("IOutboxRepositoryMock.MarkAsSent got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmMarkAsSent.MarkAsSentMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+				mmFetchBatch.t.Errorf("IOutboxRepositoryMock.FetchBatch got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmFetchBatch.FetchBatchMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
 			}
 
-			if mm_want_ptrs.eventID != nil && !minimock.Equal(*mm_want_ptrs.eventID, mm_got.eventID) {
-				mmMarkAsSent.t.Errorf("IOutboxRepositoryMock.MarkAsSent got unexpected parameter eventID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmMarkAsSent.MarkAsSentMock.defaultExpectation.expectationOrigins.originEventID, *mm_want_ptrs.eventID, mm_got.eventID, minimock.Diff(*mm_want_ptrs.eventID, mm_got.eventID))
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmFetchBatch.t.Errorf("IOutboxRepositoryMock.FetchBatch got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmFetchBatch.FetchBatchMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmMarkAsSent.t.Errorf("IOutboxRepositoryMock.MarkAsSent got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmMarkAsSent.MarkAsSentMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmFetchBatch.t.Errorf("IOutboxRepositoryMock.FetchBatch got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmFetchBatch.FetchBatchMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmMarkAsSent.MarkAsSentMock.defaultExpectation.results
+		mm_results := mmFetchBatch.FetchBatchMock.defaultExpectation.results
 		if mm_results == nil {
-			mmMarkAsSent.t.Fatal("No results are set for the IOutboxRepositoryMock.MarkAsSent")
+			mmFetchBatch.t.Fatal("No results are set for the IOutboxRepositoryMock.FetchBatch")
 		}
-		return (*mm_results).err
+		return (*mm_results).oa1, (*mm_results).err
 	}
-	if mmMarkAsSent.funcMarkAsSent != nil {
-		return mmMarkAsSent.funcMarkAsSent(ctx, tx, eventID)
+	if mmFetchBatch.funcFetchBatch != nil {
+		return mmFetchBatch.funcFetchBatch(ctx, tx, limit)
 	}
-	mmMarkAsSent.t.Fatalf("Unexpected call to IOutboxRepositoryMock.MarkAsSent. %v %v %v", ctx, tx, eventID)
+	mmFetchBatch.t.Fatalf("Unexpected call to IOutboxRepositoryMock.FetchBatch. %v %v %v", ctx, tx, limit)
 	return
 }
 
-// MarkAsSentAfterCounter returns a count of finished IOutboxRepositoryMock.MarkAsSent invocations
-func (mmMarkAsSent *IOutboxRepositoryMock) MarkAsSentAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmMarkAsSent.afterMarkAsSentCounter)
+// FetchBatchAfterCounter returns a count of finished IOutboxRepositoryMock.FetchBatch invocations
+func (mmFetchBatch *IOutboxRepositoryMock) FetchBatchAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmFetchBatch.afterFetchBatchCounter)
 }
 
-// MarkAsSentBeforeCounter returns a count of IOutboxRepositoryMock.MarkAsSent invocations
-func (mmMarkAsSent *IOutboxRepositoryMock) MarkAsSentBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmMarkAsSent.beforeMarkAsSentCounter)
+// FetchBatchBeforeCounter returns a count of IOutboxRepositoryMock.FetchBatch invocations
+func (mmFetchBatch *IOutboxRepositoryMock) FetchBatchBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmFetchBatch.beforeFetchBatchCounter)
 }
 
-// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.MarkAsSent.
+// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.FetchBatch.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Calls() []*IOutboxRepositoryMockMarkAsSentParams {
-	mmMarkAsSent.mutex.RLock()
+func (mmFetchBatch *mIOutboxRepositoryMockFetchBatch) Calls() []*IOutboxRepositoryMockFetchBatchParams {
+	mmFetchBatch.mutex.RLock()
 
-	argCopy := make([]*IOutboxRepositoryMockMarkAsSentParams, len(mmMarkAsSent.callArgs))
-	copy(argCopy, mmMarkAsSent.callArgs)
+	argCopy := make([]*IOutboxRepositoryMockFetchBatchParams, len(mmFetchBatch.callArgs))
+	copy(argCopy, mmFetchBatch.callArgs)
 
-	mmMarkAsSent.mutex.RUnlock()
+	mmFetchBatch.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockMarkAsSentDone returns true if the count of the MarkAsSent invocations corresponds
+// MinimockFetchBatchDone returns true if the count of the FetchBatch invocations corresponds
 // the number of defined expectations
-func (m *IOutboxRepositoryMock) MinimockMarkAsSentDone() bool {
-	if m.MarkAsSentMock.optional {
+func (m *IOutboxRepositoryMock) MinimockFetchBatchDone() bool {
+	if m.FetchBatchMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.MarkAsSentMock.expectations {
+	for _, e := range m.FetchBatchMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.MarkAsSentMock.invocationsDone()
+	return m.FetchBatchMock.invocationsDone()
 }
 
-// MinimockMarkAsSentInspect logs each unmet expectation
-func (m *IOutboxRepositoryMock) MinimockMarkAsSentInspect() {
-	for _, e := range m.MarkAsSentMock.expectations {
+// MinimockFetchBatchInspect logs each unmet expectation
+func (m *IOutboxRepositoryMock) MinimockFetchBatchInspect() {
+	for _, e := range m.FetchBatchMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsSent at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.FetchBatch at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterMarkAsSentCounter := mm_atomic.LoadUint64(&m.afterMarkAsSentCounter)
+	afterFetchBatchCounter := mm_atomic.LoadUint64(&m.afterFetchBatchCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.MarkAsSentMock.defaultExpectation != nil && afterMarkAsSentCounter < 1 {
-		if m.MarkAsSentMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsSent at\n%s", m.MarkAsSentMock.defaultExpectation.returnOrigin)
+	if m.FetchBatchMock.defaultExpectation != nil && afterFetchBatchCounter < 1 {
+		if m.FetchBatchMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.FetchBatch at\n%s", m.FetchBatchMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsSent at\n%s with params: %#v", m.MarkAsSentMock.defaultExpectation.expectationOrigins.origin, *m.MarkAsSentMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.FetchBatch at\n%s with params: %#v", m.FetchBatchMock.defaultExpectation.expectationOrigins.origin, *m.FetchBatchMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcMarkAsSent != nil && afterMarkAsSentCounter < 1 {
-		m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsSent at\n%s", m.funcMarkAsSentOrigin)
+	if m.funcFetchBatch != nil && afterFetchBatchCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.FetchBatch at\n%s", m.funcFetchBatchOrigin)
 	}
 
-	if !m.MarkAsSentMock.invocationsDone() && afterMarkAsSentCounter > 0 {
-		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.MarkAsSent at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.MarkAsSentMock.expectedInvocations), m.MarkAsSentMock.expectedInvocationsOrigin, afterMarkAsSentCounter)
+	if !m.FetchBatchMock.invocationsDone() && afterFetchBatchCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.FetchBatch at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.FetchBatchMock.expectedInvocations), m.FetchBatchMock.expectedInvocationsOrigin, afterFetchBatchCounter)
 	}
 }
 
-// MinimockFinish checks that all mocked methods have been called the expected number of times
-func (m *IOutboxRepositoryMock) MinimockFinish() {
-	m.finishOnce.Do(func() {
-		if !m.minimockDone() {
-			m.MinimockCreateEventInspect()
+type mIOutboxRepositoryMockList struct {
+	optional           bool
+	mock               *IOutboxRepositoryMock
+	defaultExpectation *IOutboxRepositoryMockListExpectation
+	expectations       []*IOutboxRepositoryMockListExpectation
 
-			m.MinimockFetchNextMsgInspect()
+	callArgs []*IOutboxRepositoryMockListParams
+	mutex    sync.RWMutex
 
-			m.MinimockMarkAsSentInspect()
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOutboxRepositoryMockListExpectation specifies expectation struct of the IOutboxRepository.List
+type IOutboxRepositoryMockListExpectation struct {
+	mock               *IOutboxRepositoryMock
+	params             *IOutboxRepositoryMockListParams
+	paramPtrs          *IOutboxRepositoryMockListParamPtrs
+	expectationOrigins IOutboxRepositoryMockListExpectationOrigins
+	results            *IOutboxRepositoryMockListResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOutboxRepositoryMockListParams contains parameters of the IOutboxRepository.List
+type IOutboxRepositoryMockListParams struct {
+	ctx    context.Context
+	shard  models.OutboxShard
+	filter models.OutboxEventFilter
+	limit  uint32
+}
+
+// IOutboxRepositoryMockListParamPtrs contains pointers to parameters of the IOutboxRepository.List
+type IOutboxRepositoryMockListParamPtrs struct {
+	ctx    *context.Context
+	shard  *models.OutboxShard
+	filter *models.OutboxEventFilter
+	limit  *uint32
+}
+
+// IOutboxRepositoryMockListResults contains results of the IOutboxRepository.List
+type IOutboxRepositoryMockListResults struct {
+	oa1 []models.OutboxEvent
+	err error
+}
+
+// IOutboxRepositoryMockListOrigins contains origins of expectations of the IOutboxRepository.List
+type IOutboxRepositoryMockListExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originShard  string
+	originFilter string
+	originLimit  string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmList *mIOutboxRepositoryMockList) Optional() *mIOutboxRepositoryMockList {
+	mmList.optional = true
+	return mmList
+}
+
+// Expect sets up expected params for IOutboxRepository.List
+func (mmList *mIOutboxRepositoryMockList) Expect(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) *mIOutboxRepositoryMockList {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &IOutboxRepositoryMockListExpectation{}
+	}
+
+	if mmList.defaultExpectation.paramPtrs != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by ExpectParams functions")
+	}
+
+	mmList.defaultExpectation.params = &IOutboxRepositoryMockListParams{ctx, shard, filter, limit}
+	mmList.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmList.expectations {
+		if minimock.Equal(e.params, mmList.defaultExpectation.params) {
+			mmList.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmList.defaultExpectation.params)
+		}
+	}
+
+	return mmList
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.List
+func (mmList *mIOutboxRepositoryMockList) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockList {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &IOutboxRepositoryMockListExpectation{}
+	}
+
+	if mmList.defaultExpectation.params != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Expect")
+	}
+
+	if mmList.defaultExpectation.paramPtrs == nil {
+		mmList.defaultExpectation.paramPtrs = &IOutboxRepositoryMockListParamPtrs{}
+	}
+	mmList.defaultExpectation.paramPtrs.ctx = &ctx
+	mmList.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmList
+}
+
+// ExpectShardParam2 sets up expected param shard for IOutboxRepository.List
+func (mmList *mIOutboxRepositoryMockList) ExpectShardParam2(shard models.OutboxShard) *mIOutboxRepositoryMockList {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &IOutboxRepositoryMockListExpectation{}
+	}
+
+	if mmList.defaultExpectation.params != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Expect")
+	}
+
+	if mmList.defaultExpectation.paramPtrs == nil {
+		mmList.defaultExpectation.paramPtrs = &IOutboxRepositoryMockListParamPtrs{}
+	}
+	mmList.defaultExpectation.paramPtrs.shard = &shard
+	mmList.defaultExpectation.expectationOrigins.originShard = minimock.CallerInfo(1)
+
+	return mmList
+}
+
+// ExpectFilterParam3 sets up expected param filter for IOutboxRepository.List
+func (mmList *mIOutboxRepositoryMockList) ExpectFilterParam3(filter models.OutboxEventFilter) *mIOutboxRepositoryMockList {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &IOutboxRepositoryMockListExpectation{}
+	}
+
+	if mmList.defaultExpectation.params != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Expect")
+	}
+
+	if mmList.defaultExpectation.paramPtrs == nil {
+		mmList.defaultExpectation.paramPtrs = &IOutboxRepositoryMockListParamPtrs{}
+	}
+	mmList.defaultExpectation.paramPtrs.filter = &filter
+	mmList.defaultExpectation.expectationOrigins.originFilter = minimock.CallerInfo(1)
+
+	return mmList
+}
+
+// ExpectLimitParam4 sets up expected param limit for IOutboxRepository.List
+func (mmList *mIOutboxRepositoryMockList) ExpectLimitParam4(limit uint32) *mIOutboxRepositoryMockList {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &IOutboxRepositoryMockListExpectation{}
+	}
+
+	if mmList.defaultExpectation.params != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Expect")
+	}
+
+	if mmList.defaultExpectation.paramPtrs == nil {
+		mmList.defaultExpectation.paramPtrs = &IOutboxRepositoryMockListParamPtrs{}
+	}
+	mmList.defaultExpectation.paramPtrs.limit = &limit
+	mmList.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmList
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.List
+func (mmList *mIOutboxRepositoryMockList) Inspect(f func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32)) *mIOutboxRepositoryMockList {
+	if mmList.mock.inspectFuncList != nil {
+		mmList.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.List")
+	}
+
+	mmList.mock.inspectFuncList = f
+
+	return mmList
+}
+
+// Return sets up results that will be returned by IOutboxRepository.List
+func (mmList *mIOutboxRepositoryMockList) Return(oa1 []models.OutboxEvent, err error) *IOutboxRepositoryMock {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &IOutboxRepositoryMockListExpectation{mock: mmList.mock}
+	}
+	mmList.defaultExpectation.results = &IOutboxRepositoryMockListResults{oa1, err}
+	mmList.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmList.mock
+}
+
+// Set uses given function f to mock the IOutboxRepository.List method
+func (mmList *mIOutboxRepositoryMockList) Set(f func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) (oa1 []models.OutboxEvent, err error)) *IOutboxRepositoryMock {
+	if mmList.defaultExpectation != nil {
+		mmList.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.List method")
+	}
+
+	if len(mmList.expectations) > 0 {
+		mmList.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.List method")
+	}
+
+	mmList.mock.funcList = f
+	mmList.mock.funcListOrigin = minimock.CallerInfo(1)
+	return mmList.mock
+}
+
+// When sets expectation for the IOutboxRepository.List which will trigger the result defined by the following
+// Then helper
+func (mmList *mIOutboxRepositoryMockList) When(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) *IOutboxRepositoryMockListExpectation {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("IOutboxRepositoryMock.List mock is already set by Set")
+	}
+
+	expectation := &IOutboxRepositoryMockListExpectation{
+		mock:               mmList.mock,
+		params:             &IOutboxRepositoryMockListParams{ctx, shard, filter, limit},
+		expectationOrigins: IOutboxRepositoryMockListExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmList.expectations = append(mmList.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOutboxRepository.List return parameters for the expectation previously defined by the When method
+func (e *IOutboxRepositoryMockListExpectation) Then(oa1 []models.OutboxEvent, err error) *IOutboxRepositoryMock {
+	e.results = &IOutboxRepositoryMockListResults{oa1, err}
+	return e.mock
+}
+
+// Times sets number of times IOutboxRepository.List should be invoked
+func (mmList *mIOutboxRepositoryMockList) Times(n uint64) *mIOutboxRepositoryMockList {
+	if n == 0 {
+		mmList.mock.t.Fatalf("Times of IOutboxRepositoryMock.List mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmList.expectedInvocations, n)
+	mmList.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmList
+}
+
+func (mmList *mIOutboxRepositoryMockList) invocationsDone() bool {
+	if len(mmList.expectations) == 0 && mmList.defaultExpectation == nil && mmList.mock.funcList == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmList.mock.afterListCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmList.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// List implements mm_service.IOutboxRepository
+func (mmList *IOutboxRepositoryMock) List(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) (oa1 []models.OutboxEvent, err error) {
+	mm_atomic.AddUint64(&mmList.beforeListCounter, 1)
+	defer mm_atomic.AddUint64(&mmList.afterListCounter, 1)
+
+	mmList.t.Helper()
+
+	if mmList.inspectFuncList != nil {
+		mmList.inspectFuncList(ctx, shard, filter, limit)
+	}
+
+	mm_params := IOutboxRepositoryMockListParams{ctx, shard, filter, limit}
+
+	// Record call args
+	mmList.ListMock.mutex.Lock()
+	mmList.ListMock.callArgs = append(mmList.ListMock.callArgs, &mm_params)
+	mmList.ListMock.mutex.Unlock()
+
+	for _, e := range mmList.ListMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.oa1, e.results.err
+		}
+	}
+
+	if mmList.ListMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmList.ListMock.defaultExpectation.Counter, 1)
+		mm_want := mmList.ListMock.defaultExpectation.params
+		mm_want_ptrs := mmList.ListMock.defaultExpectation.paramPtrs
+
+		mm_got := IOutboxRepositoryMockListParams{ctx, shard, filter, limit}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmList.t.Errorf("IOutboxRepositoryMock.List got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmList.ListMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.shard != nil && !minimock.Equal(*mm_want_ptrs.shard, mm_got.shard) {
+				mmList.t.Errorf("IOutboxRepositoryMock.List got unexpected parameter shard, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmList.ListMock.defaultExpectation.expectationOrigins.originShard, *mm_want_ptrs.shard, mm_got.shard, minimock.Diff(*mm_want_ptrs.shard, mm_got.shard))
+			}
+
+			if mm_want_ptrs.filter != nil && !minimock.Equal(*mm_want_ptrs.filter, mm_got.filter) {
+				mmList.t.Errorf("IOutboxRepositoryMock.List got unexpected parameter filter, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmList.ListMock.defaultExpectation.expectationOrigins.originFilter, *mm_want_ptrs.filter, mm_got.filter, minimock.Diff(*mm_want_ptrs.filter, mm_got.filter))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmList.t.Errorf("IOutboxRepositoryMock.List got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmList.ListMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmList.t.Errorf("IOutboxRepositoryMock.List got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmList.ListMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmList.ListMock.defaultExpectation.results
+		if mm_results == nil {
+			mmList.t.Fatal("No results are set for the IOutboxRepositoryMock.List")
+		}
+		return (*mm_results).oa1, (*mm_results).err
+	}
+	if mmList.funcList != nil {
+		return mmList.funcList(ctx, shard, filter, limit)
+	}
+	mmList.t.Fatalf("Unexpected call to IOutboxRepositoryMock.List. %v %v %v %v", ctx, shard, filter, limit)
+	return
+}
+
+// ListAfterCounter returns a count of finished IOutboxRepositoryMock.List invocations
+func (mmList *IOutboxRepositoryMock) ListAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmList.afterListCounter)
+}
+
+// ListBeforeCounter returns a count of IOutboxRepositoryMock.List invocations
+func (mmList *IOutboxRepositoryMock) ListBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmList.beforeListCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.List.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmList *mIOutboxRepositoryMockList) Calls() []*IOutboxRepositoryMockListParams {
+	mmList.mutex.RLock()
+
+	argCopy := make([]*IOutboxRepositoryMockListParams, len(mmList.callArgs))
+	copy(argCopy, mmList.callArgs)
+
+	mmList.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListDone returns true if the count of the List invocations corresponds
+// the number of defined expectations
+func (m *IOutboxRepositoryMock) MinimockListDone() bool {
+	if m.ListMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListMock.invocationsDone()
+}
+
+// MinimockListInspect logs each unmet expectation
+func (m *IOutboxRepositoryMock) MinimockListInspect() {
+	for _, e := range m.ListMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.List at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListCounter := mm_atomic.LoadUint64(&m.afterListCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListMock.defaultExpectation != nil && afterListCounter < 1 {
+		if m.ListMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.List at\n%s", m.ListMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.List at\n%s with params: %#v", m.ListMock.defaultExpectation.expectationOrigins.origin, *m.ListMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcList != nil && afterListCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.List at\n%s", m.funcListOrigin)
+	}
+
+	if !m.ListMock.invocationsDone() && afterListCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.List at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListMock.expectedInvocations), m.ListMock.expectedInvocationsOrigin, afterListCounter)
+	}
+}
+
+type mIOutboxRepositoryMockMarkAsFailed struct {
+	optional           bool
+	mock               *IOutboxRepositoryMock
+	defaultExpectation *IOutboxRepositoryMockMarkAsFailedExpectation
+	expectations       []*IOutboxRepositoryMockMarkAsFailedExpectation
+
+	callArgs []*IOutboxRepositoryMockMarkAsFailedParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOutboxRepositoryMockMarkAsFailedExpectation specifies expectation struct of the IOutboxRepository.MarkAsFailed
+type IOutboxRepositoryMockMarkAsFailedExpectation struct {
+	mock               *IOutboxRepositoryMock
+	params             *IOutboxRepositoryMockMarkAsFailedParams
+	paramPtrs          *IOutboxRepositoryMockMarkAsFailedParamPtrs
+	expectationOrigins IOutboxRepositoryMockMarkAsFailedExpectationOrigins
+	results            *IOutboxRepositoryMockMarkAsFailedResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOutboxRepositoryMockMarkAsFailedParams contains parameters of the IOutboxRepository.MarkAsFailed
+type IOutboxRepositoryMockMarkAsFailedParams struct {
+	ctx           context.Context
+	tx            pgx.Tx
+	eventID       int64
+	sendErr       error
+	nextAttemptAt time.Time
+	state         models.OutboxEventState
+}
+
+// IOutboxRepositoryMockMarkAsFailedParamPtrs contains pointers to parameters of the IOutboxRepository.MarkAsFailed
+type IOutboxRepositoryMockMarkAsFailedParamPtrs struct {
+	ctx           *context.Context
+	tx            *pgx.Tx
+	eventID       *int64
+	sendErr       *error
+	nextAttemptAt *time.Time
+	state         *models.OutboxEventState
+}
+
+// IOutboxRepositoryMockMarkAsFailedResults contains results of the IOutboxRepository.MarkAsFailed
+type IOutboxRepositoryMockMarkAsFailedResults struct {
+	err error
+}
+
+// IOutboxRepositoryMockMarkAsFailedOrigins contains origins of expectations of the IOutboxRepository.MarkAsFailed
+type IOutboxRepositoryMockMarkAsFailedExpectationOrigins struct {
+	origin              string
+	originCtx           string
+	originTx            string
+	originEventID       string
+	originSendErr       string
+	originNextAttemptAt string
+	originState         string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) Optional() *mIOutboxRepositoryMockMarkAsFailed {
+	mmMarkAsFailed.optional = true
+	return mmMarkAsFailed
+}
+
+// Expect sets up expected params for IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) Expect(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) *mIOutboxRepositoryMockMarkAsFailed {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	if mmMarkAsFailed.defaultExpectation == nil {
+		mmMarkAsFailed.defaultExpectation = &IOutboxRepositoryMockMarkAsFailedExpectation{}
+	}
+
+	if mmMarkAsFailed.defaultExpectation.paramPtrs != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by ExpectParams functions")
+	}
+
+	mmMarkAsFailed.defaultExpectation.params = &IOutboxRepositoryMockMarkAsFailedParams{ctx, tx, eventID, sendErr, nextAttemptAt, state}
+	mmMarkAsFailed.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmMarkAsFailed.expectations {
+		if minimock.Equal(e.params, mmMarkAsFailed.defaultExpectation.params) {
+			mmMarkAsFailed.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmMarkAsFailed.defaultExpectation.params)
+		}
+	}
+
+	return mmMarkAsFailed
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockMarkAsFailed {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	if mmMarkAsFailed.defaultExpectation == nil {
+		mmMarkAsFailed.defaultExpectation = &IOutboxRepositoryMockMarkAsFailedExpectation{}
+	}
+
+	if mmMarkAsFailed.defaultExpectation.params != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Expect")
+	}
+
+	if mmMarkAsFailed.defaultExpectation.paramPtrs == nil {
+		mmMarkAsFailed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsFailedParamPtrs{}
+	}
+	mmMarkAsFailed.defaultExpectation.paramPtrs.ctx = &ctx
+	mmMarkAsFailed.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmMarkAsFailed
+}
+
+// ExpectTxParam2 sets up expected param tx for IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) ExpectTxParam2(tx pgx.Tx) *mIOutboxRepositoryMockMarkAsFailed {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	if mmMarkAsFailed.defaultExpectation == nil {
+		mmMarkAsFailed.defaultExpectation = &IOutboxRepositoryMockMarkAsFailedExpectation{}
+	}
+
+	if mmMarkAsFailed.defaultExpectation.params != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Expect")
+	}
+
+	if mmMarkAsFailed.defaultExpectation.paramPtrs == nil {
+		mmMarkAsFailed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsFailedParamPtrs{}
+	}
+	mmMarkAsFailed.defaultExpectation.paramPtrs.tx = &tx
+	mmMarkAsFailed.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmMarkAsFailed
+}
+
+// ExpectEventIDParam3 sets up expected param eventID for IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) ExpectEventIDParam3(eventID int64) *mIOutboxRepositoryMockMarkAsFailed {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	if mmMarkAsFailed.defaultExpectation == nil {
+		mmMarkAsFailed.defaultExpectation = &IOutboxRepositoryMockMarkAsFailedExpectation{}
+	}
+
+	if mmMarkAsFailed.defaultExpectation.params != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Expect")
+	}
+
+	if mmMarkAsFailed.defaultExpectation.paramPtrs == nil {
+		mmMarkAsFailed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsFailedParamPtrs{}
+	}
+	mmMarkAsFailed.defaultExpectation.paramPtrs.eventID = &eventID
+	mmMarkAsFailed.defaultExpectation.expectationOrigins.originEventID = minimock.CallerInfo(1)
+
+	return mmMarkAsFailed
+}
+
+// ExpectSendErrParam4 sets up expected param sendErr for IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) ExpectSendErrParam4(sendErr error) *mIOutboxRepositoryMockMarkAsFailed {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	if mmMarkAsFailed.defaultExpectation == nil {
+		mmMarkAsFailed.defaultExpectation = &IOutboxRepositoryMockMarkAsFailedExpectation{}
+	}
+
+	if mmMarkAsFailed.defaultExpectation.params != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Expect")
+	}
+
+	if mmMarkAsFailed.defaultExpectation.paramPtrs == nil {
+		mmMarkAsFailed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsFailedParamPtrs{}
+	}
+	mmMarkAsFailed.defaultExpectation.paramPtrs.sendErr = &sendErr
+	mmMarkAsFailed.defaultExpectation.expectationOrigins.originSendErr = minimock.CallerInfo(1)
+
+	return mmMarkAsFailed
+}
+
+// ExpectNextAttemptAtParam5 sets up expected param nextAttemptAt for IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) ExpectNextAttemptAtParam5(nextAttemptAt time.Time) *mIOutboxRepositoryMockMarkAsFailed {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	if mmMarkAsFailed.defaultExpectation == nil {
+		mmMarkAsFailed.defaultExpectation = &IOutboxRepositoryMockMarkAsFailedExpectation{}
+	}
+
+	if mmMarkAsFailed.defaultExpectation.params != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Expect")
+	}
+
+	if mmMarkAsFailed.defaultExpectation.paramPtrs == nil {
+		mmMarkAsFailed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsFailedParamPtrs{}
+	}
+	mmMarkAsFailed.defaultExpectation.paramPtrs.nextAttemptAt = &nextAttemptAt
+	mmMarkAsFailed.defaultExpectation.expectationOrigins.originNextAttemptAt = minimock.CallerInfo(1)
+
+	return mmMarkAsFailed
+}
+
+// ExpectStateParam6 sets up expected param state for IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) ExpectStateParam6(state models.OutboxEventState) *mIOutboxRepositoryMockMarkAsFailed {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	if mmMarkAsFailed.defaultExpectation == nil {
+		mmMarkAsFailed.defaultExpectation = &IOutboxRepositoryMockMarkAsFailedExpectation{}
+	}
+
+	if mmMarkAsFailed.defaultExpectation.params != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Expect")
+	}
+
+	if mmMarkAsFailed.defaultExpectation.paramPtrs == nil {
+		mmMarkAsFailed.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsFailedParamPtrs{}
+	}
+	mmMarkAsFailed.defaultExpectation.paramPtrs.state = &state
+	mmMarkAsFailed.defaultExpectation.expectationOrigins.originState = minimock.CallerInfo(1)
+
+	return mmMarkAsFailed
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) Inspect(f func(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState)) *mIOutboxRepositoryMockMarkAsFailed {
+	if mmMarkAsFailed.mock.inspectFuncMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.MarkAsFailed")
+	}
+
+	mmMarkAsFailed.mock.inspectFuncMarkAsFailed = f
+
+	return mmMarkAsFailed
+}
+
+// Return sets up results that will be returned by IOutboxRepository.MarkAsFailed
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) Return(err error) *IOutboxRepositoryMock {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	if mmMarkAsFailed.defaultExpectation == nil {
+		mmMarkAsFailed.defaultExpectation = &IOutboxRepositoryMockMarkAsFailedExpectation{mock: mmMarkAsFailed.mock}
+	}
+	mmMarkAsFailed.defaultExpectation.results = &IOutboxRepositoryMockMarkAsFailedResults{err}
+	mmMarkAsFailed.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmMarkAsFailed.mock
+}
+
+// Set uses given function f to mock the IOutboxRepository.MarkAsFailed method
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) Set(f func(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) (err error)) *IOutboxRepositoryMock {
+	if mmMarkAsFailed.defaultExpectation != nil {
+		mmMarkAsFailed.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.MarkAsFailed method")
+	}
+
+	if len(mmMarkAsFailed.expectations) > 0 {
+		mmMarkAsFailed.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.MarkAsFailed method")
+	}
+
+	mmMarkAsFailed.mock.funcMarkAsFailed = f
+	mmMarkAsFailed.mock.funcMarkAsFailedOrigin = minimock.CallerInfo(1)
+	return mmMarkAsFailed.mock
+}
+
+// When sets expectation for the IOutboxRepository.MarkAsFailed which will trigger the result defined by the following
+// Then helper
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) When(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) *IOutboxRepositoryMockMarkAsFailedExpectation {
+	if mmMarkAsFailed.mock.funcMarkAsFailed != nil {
+		mmMarkAsFailed.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsFailed mock is already set by Set")
+	}
+
+	expectation := &IOutboxRepositoryMockMarkAsFailedExpectation{
+		mock:               mmMarkAsFailed.mock,
+		params:             &IOutboxRepositoryMockMarkAsFailedParams{ctx, tx, eventID, sendErr, nextAttemptAt, state},
+		expectationOrigins: IOutboxRepositoryMockMarkAsFailedExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmMarkAsFailed.expectations = append(mmMarkAsFailed.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOutboxRepository.MarkAsFailed return parameters for the expectation previously defined by the When method
+func (e *IOutboxRepositoryMockMarkAsFailedExpectation) Then(err error) *IOutboxRepositoryMock {
+	e.results = &IOutboxRepositoryMockMarkAsFailedResults{err}
+	return e.mock
+}
+
+// Times sets number of times IOutboxRepository.MarkAsFailed should be invoked
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) Times(n uint64) *mIOutboxRepositoryMockMarkAsFailed {
+	if n == 0 {
+		mmMarkAsFailed.mock.t.Fatalf("Times of IOutboxRepositoryMock.MarkAsFailed mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmMarkAsFailed.expectedInvocations, n)
+	mmMarkAsFailed.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmMarkAsFailed
+}
+
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) invocationsDone() bool {
+	if len(mmMarkAsFailed.expectations) == 0 && mmMarkAsFailed.defaultExpectation == nil && mmMarkAsFailed.mock.funcMarkAsFailed == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmMarkAsFailed.mock.afterMarkAsFailedCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmMarkAsFailed.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// MarkAsFailed implements mm_service.IOutboxRepository
+func (mmMarkAsFailed *IOutboxRepositoryMock) MarkAsFailed(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) (err error) {
+	mm_atomic.AddUint64(&mmMarkAsFailed.beforeMarkAsFailedCounter, 1)
+	defer mm_atomic.AddUint64(&mmMarkAsFailed.afterMarkAsFailedCounter, 1)
+
+	mmMarkAsFailed.t.Helper()
+
+	if mmMarkAsFailed.inspectFuncMarkAsFailed != nil {
+		mmMarkAsFailed.inspectFuncMarkAsFailed(ctx, tx, eventID, sendErr, nextAttemptAt, state)
+	}
+
+	mm_params := IOutboxRepositoryMockMarkAsFailedParams{ctx, tx, eventID, sendErr, nextAttemptAt, state}
+
+	// Record call args
+	mmMarkAsFailed.MarkAsFailedMock.mutex.Lock()
+	mmMarkAsFailed.MarkAsFailedMock.callArgs = append(mmMarkAsFailed.MarkAsFailedMock.callArgs, &mm_params)
+	mmMarkAsFailed.MarkAsFailedMock.mutex.Unlock()
+
+	for _, e := range mmMarkAsFailed.MarkAsFailedMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmMarkAsFailed.MarkAsFailedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.Counter, 1)
+		mm_want := mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.params
+		mm_want_ptrs := mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.paramPtrs
+
+		mm_got := IOutboxRepositoryMockMarkAsFailedParams{ctx, tx, eventID, sendErr, nextAttemptAt, state}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmMarkAsFailed.t.Errorf("IOutboxRepositoryMock.MarkAsFailed got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmMarkAsFailed.t.Errorf("IOutboxRepositoryMock.MarkAsFailed got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.eventID != nil && !minimock.Equal(*mm_want_ptrs.eventID, mm_got.eventID) {
+				mmMarkAsFailed.t.Errorf("IOutboxRepositoryMock.MarkAsFailed got unexpected parameter eventID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.expectationOrigins.originEventID, *mm_want_ptrs.eventID, mm_got.eventID, minimock.Diff(*mm_want_ptrs.eventID, mm_got.eventID))
+			}
+
+			if mm_want_ptrs.sendErr != nil && !minimock.Equal(*mm_want_ptrs.sendErr, mm_got.sendErr) {
+				mmMarkAsFailed.t.Errorf("IOutboxRepositoryMock.MarkAsFailed got unexpected parameter sendErr, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.expectationOrigins.originSendErr, *mm_want_ptrs.sendErr, mm_got.sendErr, minimock.Diff(*mm_want_ptrs.sendErr, mm_got.sendErr))
+			}
+
+			if mm_want_ptrs.nextAttemptAt != nil && !minimock.Equal(*mm_want_ptrs.nextAttemptAt, mm_got.nextAttemptAt) {
+				mmMarkAsFailed.t.Errorf("IOutboxRepositoryMock.MarkAsFailed got unexpected parameter nextAttemptAt, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.expectationOrigins.originNextAttemptAt, *mm_want_ptrs.nextAttemptAt, mm_got.nextAttemptAt, minimock.Diff(*mm_want_ptrs.nextAttemptAt, mm_got.nextAttemptAt))
+			}
+
+			if mm_want_ptrs.state != nil && !minimock.Equal(*mm_want_ptrs.state, mm_got.state) {
+				mmMarkAsFailed.t.Errorf("IOutboxRepositoryMock.MarkAsFailed got unexpected parameter state, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.expectationOrigins.originState, *mm_want_ptrs.state, mm_got.state, minimock.Diff(*mm_want_ptrs.state, mm_got.state))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmMarkAsFailed.t.Errorf("IOutboxRepositoryMock.MarkAsFailed got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmMarkAsFailed.MarkAsFailedMock.defaultExpectation.results
+		if mm_results == nil {
+			mmMarkAsFailed.t.Fatal("No results are set for the IOutboxRepositoryMock.MarkAsFailed")
+		}
+		return (*mm_results).err
+	}
+	if mmMarkAsFailed.funcMarkAsFailed != nil {
+		return mmMarkAsFailed.funcMarkAsFailed(ctx, tx, eventID, sendErr, nextAttemptAt, state)
+	}
+	mmMarkAsFailed.t.Fatalf("Unexpected call to IOutboxRepositoryMock.MarkAsFailed. %v %v %v %v %v %v", ctx, tx, eventID, sendErr, nextAttemptAt, state)
+	return
+}
+
+// MarkAsFailedAfterCounter returns a count of finished IOutboxRepositoryMock.MarkAsFailed invocations
+func (mmMarkAsFailed *IOutboxRepositoryMock) MarkAsFailedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmMarkAsFailed.afterMarkAsFailedCounter)
+}
+
+// MarkAsFailedBeforeCounter returns a count of IOutboxRepositoryMock.MarkAsFailed invocations
+func (mmMarkAsFailed *IOutboxRepositoryMock) MarkAsFailedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmMarkAsFailed.beforeMarkAsFailedCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.MarkAsFailed.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmMarkAsFailed *mIOutboxRepositoryMockMarkAsFailed) Calls() []*IOutboxRepositoryMockMarkAsFailedParams {
+	mmMarkAsFailed.mutex.RLock()
+
+	argCopy := make([]*IOutboxRepositoryMockMarkAsFailedParams, len(mmMarkAsFailed.callArgs))
+	copy(argCopy, mmMarkAsFailed.callArgs)
+
+	mmMarkAsFailed.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockMarkAsFailedDone returns true if the count of the MarkAsFailed invocations corresponds
+// the number of defined expectations
+func (m *IOutboxRepositoryMock) MinimockMarkAsFailedDone() bool {
+	if m.MarkAsFailedMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.MarkAsFailedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.MarkAsFailedMock.invocationsDone()
+}
+
+// MinimockMarkAsFailedInspect logs each unmet expectation
+func (m *IOutboxRepositoryMock) MinimockMarkAsFailedInspect() {
+	for _, e := range m.MarkAsFailedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsFailed at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterMarkAsFailedCounter := mm_atomic.LoadUint64(&m.afterMarkAsFailedCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.MarkAsFailedMock.defaultExpectation != nil && afterMarkAsFailedCounter < 1 {
+		if m.MarkAsFailedMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsFailed at\n%s", m.MarkAsFailedMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsFailed at\n%s with params: %#v", m.MarkAsFailedMock.defaultExpectation.expectationOrigins.origin, *m.MarkAsFailedMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcMarkAsFailed != nil && afterMarkAsFailedCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsFailed at\n%s", m.funcMarkAsFailedOrigin)
+	}
+
+	if !m.MarkAsFailedMock.invocationsDone() && afterMarkAsFailedCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.MarkAsFailed at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.MarkAsFailedMock.expectedInvocations), m.MarkAsFailedMock.expectedInvocationsOrigin, afterMarkAsFailedCounter)
+	}
+}
+
+type mIOutboxRepositoryMockMarkAsSent struct {
+	optional           bool
+	mock               *IOutboxRepositoryMock
+	defaultExpectation *IOutboxRepositoryMockMarkAsSentExpectation
+	expectations       []*IOutboxRepositoryMockMarkAsSentExpectation
+
+	callArgs []*IOutboxRepositoryMockMarkAsSentParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOutboxRepositoryMockMarkAsSentExpectation specifies expectation struct of the IOutboxRepository.MarkAsSent
+type IOutboxRepositoryMockMarkAsSentExpectation struct {
+	mock               *IOutboxRepositoryMock
+	params             *IOutboxRepositoryMockMarkAsSentParams
+	paramPtrs          *IOutboxRepositoryMockMarkAsSentParamPtrs
+	expectationOrigins IOutboxRepositoryMockMarkAsSentExpectationOrigins
+	results            *IOutboxRepositoryMockMarkAsSentResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOutboxRepositoryMockMarkAsSentParams contains parameters of the IOutboxRepository.MarkAsSent
+type IOutboxRepositoryMockMarkAsSentParams struct {
+	ctx      context.Context
+	tx       pgx.Tx
+	eventIDs []int64
+}
+
+// IOutboxRepositoryMockMarkAsSentParamPtrs contains pointers to parameters of the IOutboxRepository.MarkAsSent
+type IOutboxRepositoryMockMarkAsSentParamPtrs struct {
+	ctx      *context.Context
+	tx       *pgx.Tx
+	eventIDs *[]int64
+}
+
+// IOutboxRepositoryMockMarkAsSentResults contains results of the IOutboxRepository.MarkAsSent
+type IOutboxRepositoryMockMarkAsSentResults struct {
+	err error
+}
+
+// IOutboxRepositoryMockMarkAsSentOrigins contains origins of expectations of the IOutboxRepository.MarkAsSent
+type IOutboxRepositoryMockMarkAsSentExpectationOrigins struct {
+	origin         string
+	originCtx      string
+	originTx       string
+	originEventIDs string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Optional() *mIOutboxRepositoryMockMarkAsSent {
+	mmMarkAsSent.optional = true
+	return mmMarkAsSent
+}
+
+// Expect sets up expected params for IOutboxRepository.MarkAsSent
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Expect(ctx context.Context, tx pgx.Tx, eventIDs []int64) *mIOutboxRepositoryMockMarkAsSent {
+	if mmMarkAsSent.mock.funcMarkAsSent != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+	}
+
+	if mmMarkAsSent.defaultExpectation == nil {
+		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{}
+	}
+
+	if mmMarkAsSent.defaultExpectation.paramPtrs != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by ExpectParams functions")
+	}
+
+	mmMarkAsSent.defaultExpectation.params = &IOutboxRepositoryMockMarkAsSentParams{ctx, tx, eventIDs}
+	mmMarkAsSent.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmMarkAsSent.expectations {
+		if minimock.Equal(e.params, mmMarkAsSent.defaultExpectation.params) {
+			mmMarkAsSent.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmMarkAsSent.defaultExpectation.params)
+		}
+	}
+
+	return mmMarkAsSent
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.MarkAsSent
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockMarkAsSent {
+	if mmMarkAsSent.mock.funcMarkAsSent != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+	}
+
+	if mmMarkAsSent.defaultExpectation == nil {
+		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{}
+	}
+
+	if mmMarkAsSent.defaultExpectation.params != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Expect")
+	}
+
+	if mmMarkAsSent.defaultExpectation.paramPtrs == nil {
+		mmMarkAsSent.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsSentParamPtrs{}
+	}
+	mmMarkAsSent.defaultExpectation.paramPtrs.ctx = &ctx
+	mmMarkAsSent.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmMarkAsSent
+}
+
+// ExpectTxParam2 sets up expected param tx for IOutboxRepository.MarkAsSent
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) ExpectTxParam2(tx pgx.Tx) *mIOutboxRepositoryMockMarkAsSent {
+	if mmMarkAsSent.mock.funcMarkAsSent != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+	}
+
+	if mmMarkAsSent.defaultExpectation == nil {
+		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{}
+	}
+
+	if mmMarkAsSent.defaultExpectation.params != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Expect")
+	}
+
+	if mmMarkAsSent.defaultExpectation.paramPtrs == nil {
+		mmMarkAsSent.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsSentParamPtrs{}
+	}
+	mmMarkAsSent.defaultExpectation.paramPtrs.tx = &tx
+	mmMarkAsSent.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmMarkAsSent
+}
+
+// ExpectEventIDsParam3 sets up expected param eventIDs for IOutboxRepository.MarkAsSent
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) ExpectEventIDsParam3(eventIDs []int64) *mIOutboxRepositoryMockMarkAsSent {
+	if mmMarkAsSent.mock.funcMarkAsSent != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+	}
+
+	if mmMarkAsSent.defaultExpectation == nil {
+		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{}
+	}
+
+	if mmMarkAsSent.defaultExpectation.params != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Expect")
+	}
+
+	if mmMarkAsSent.defaultExpectation.paramPtrs == nil {
+		mmMarkAsSent.defaultExpectation.paramPtrs = &IOutboxRepositoryMockMarkAsSentParamPtrs{}
+	}
+	mmMarkAsSent.defaultExpectation.paramPtrs.eventIDs = &eventIDs
+	mmMarkAsSent.defaultExpectation.expectationOrigins.originEventIDs = minimock.CallerInfo(1)
+
+	return mmMarkAsSent
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.MarkAsSent
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Inspect(f func(ctx context.Context, tx pgx.Tx, eventIDs []int64)) *mIOutboxRepositoryMockMarkAsSent {
+	if mmMarkAsSent.mock.inspectFuncMarkAsSent != nil {
+		mmMarkAsSent.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.MarkAsSent")
+	}
+
+	mmMarkAsSent.mock.inspectFuncMarkAsSent = f
+
+	return mmMarkAsSent
+}
+
+// Return sets up results that will be returned by IOutboxRepository.MarkAsSent
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Return(err error) *IOutboxRepositoryMock {
+	if mmMarkAsSent.mock.funcMarkAsSent != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+	}
+
+	if mmMarkAsSent.defaultExpectation == nil {
+		mmMarkAsSent.defaultExpectation = &IOutboxRepositoryMockMarkAsSentExpectation{mock: mmMarkAsSent.mock}
+	}
+	mmMarkAsSent.defaultExpectation.results = &IOutboxRepositoryMockMarkAsSentResults{err}
+	mmMarkAsSent.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmMarkAsSent.mock
+}
+
+// Set uses given function f to mock the IOutboxRepository.MarkAsSent method
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Set(f func(ctx context.Context, tx pgx.Tx, eventIDs []int64) (err error)) *IOutboxRepositoryMock {
+	if mmMarkAsSent.defaultExpectation != nil {
+		mmMarkAsSent.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.MarkAsSent method")
+	}
+
+	if len(mmMarkAsSent.expectations) > 0 {
+		mmMarkAsSent.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.MarkAsSent method")
+	}
+
+	mmMarkAsSent.mock.funcMarkAsSent = f
+	mmMarkAsSent.mock.funcMarkAsSentOrigin = minimock.CallerInfo(1)
+	return mmMarkAsSent.mock
+}
+
+// When sets expectation for the IOutboxRepository.MarkAsSent which will trigger the result defined by the following
+// Then helper
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) When(ctx context.Context, tx pgx.Tx, eventIDs []int64) *IOutboxRepositoryMockMarkAsSentExpectation {
+	if mmMarkAsSent.mock.funcMarkAsSent != nil {
+		mmMarkAsSent.mock.t.Fatalf("IOutboxRepositoryMock.MarkAsSent mock is already set by Set")
+	}
+
+	expectation := &IOutboxRepositoryMockMarkAsSentExpectation{
+		mock:               mmMarkAsSent.mock,
+		params:             &IOutboxRepositoryMockMarkAsSentParams{ctx, tx, eventIDs},
+		expectationOrigins: IOutboxRepositoryMockMarkAsSentExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmMarkAsSent.expectations = append(mmMarkAsSent.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOutboxRepository.MarkAsSent return parameters for the expectation previously defined by the When method
+func (e *IOutboxRepositoryMockMarkAsSentExpectation) Then(err error) *IOutboxRepositoryMock {
+	e.results = &IOutboxRepositoryMockMarkAsSentResults{err}
+	return e.mock
+}
+
+// Times sets number of times IOutboxRepository.MarkAsSent should be invoked
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Times(n uint64) *mIOutboxRepositoryMockMarkAsSent {
+	if n == 0 {
+		mmMarkAsSent.mock.t.Fatalf("Times of IOutboxRepositoryMock.MarkAsSent mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmMarkAsSent.expectedInvocations, n)
+	mmMarkAsSent.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmMarkAsSent
+}
+
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) invocationsDone() bool {
+	if len(mmMarkAsSent.expectations) == 0 && mmMarkAsSent.defaultExpectation == nil && mmMarkAsSent.mock.funcMarkAsSent == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmMarkAsSent.mock.afterMarkAsSentCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmMarkAsSent.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// MarkAsSent implements mm_service.IOutboxRepository
+func (mmMarkAsSent *IOutboxRepositoryMock) MarkAsSent(ctx context.Context, tx pgx.Tx, eventIDs []int64) (err error) {
+	mm_atomic.AddUint64(&mmMarkAsSent.beforeMarkAsSentCounter, 1)
+	defer mm_atomic.AddUint64(&mmMarkAsSent.afterMarkAsSentCounter, 1)
+
+	mmMarkAsSent.t.Helper()
+
+	if mmMarkAsSent.inspectFuncMarkAsSent != nil {
+		mmMarkAsSent.inspectFuncMarkAsSent(ctx, tx, eventIDs)
+	}
+
+	mm_params := IOutboxRepositoryMockMarkAsSentParams{ctx, tx, eventIDs}
+
+	// Record call args
+	mmMarkAsSent.MarkAsSentMock.mutex.Lock()
+	mmMarkAsSent.MarkAsSentMock.callArgs = append(mmMarkAsSent.MarkAsSentMock.callArgs, &mm_params)
+	mmMarkAsSent.MarkAsSentMock.mutex.Unlock()
+
+	for _, e := range mmMarkAsSent.MarkAsSentMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmMarkAsSent.MarkAsSentMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmMarkAsSent.MarkAsSentMock.defaultExpectation.Counter, 1)
+		mm_want := mmMarkAsSent.MarkAsSentMock.defaultExpectation.params
+		mm_want_ptrs := mmMarkAsSent.MarkAsSentMock.defaultExpectation.paramPtrs
+
+		mm_got := IOutboxRepositoryMockMarkAsSentParams{ctx, tx, eventIDs}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmMarkAsSent.t.Errorf("IOutboxRepositoryMock.MarkAsSent got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsSent.MarkAsSentMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmMarkAsSent.t.Errorf("IOutboxRepositoryMock.MarkAsSent got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsSent.MarkAsSentMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.eventIDs != nil && !minimock.Equal(*mm_want_ptrs.eventIDs, mm_got.eventIDs) {
+				mmMarkAsSent.t.Errorf("IOutboxRepositoryMock.MarkAsSent got unexpected parameter eventIDs, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmMarkAsSent.MarkAsSentMock.defaultExpectation.expectationOrigins.originEventIDs, *mm_want_ptrs.eventIDs, mm_got.eventIDs, minimock.Diff(*mm_want_ptrs.eventIDs, mm_got.eventIDs))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmMarkAsSent.t.Errorf("IOutboxRepositoryMock.MarkAsSent got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmMarkAsSent.MarkAsSentMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmMarkAsSent.MarkAsSentMock.defaultExpectation.results
+		if mm_results == nil {
+			mmMarkAsSent.t.Fatal("No results are set for the IOutboxRepositoryMock.MarkAsSent")
+		}
+		return (*mm_results).err
+	}
+	if mmMarkAsSent.funcMarkAsSent != nil {
+		return mmMarkAsSent.funcMarkAsSent(ctx, tx, eventIDs)
+	}
+	mmMarkAsSent.t.Fatalf("Unexpected call to IOutboxRepositoryMock.MarkAsSent. %v %v %v", ctx, tx, eventIDs)
+	return
+}
+
+// MarkAsSentAfterCounter returns a count of finished IOutboxRepositoryMock.MarkAsSent invocations
+func (mmMarkAsSent *IOutboxRepositoryMock) MarkAsSentAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmMarkAsSent.afterMarkAsSentCounter)
+}
+
+// MarkAsSentBeforeCounter returns a count of IOutboxRepositoryMock.MarkAsSent invocations
+func (mmMarkAsSent *IOutboxRepositoryMock) MarkAsSentBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmMarkAsSent.beforeMarkAsSentCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.MarkAsSent.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmMarkAsSent *mIOutboxRepositoryMockMarkAsSent) Calls() []*IOutboxRepositoryMockMarkAsSentParams {
+	mmMarkAsSent.mutex.RLock()
+
+	argCopy := make([]*IOutboxRepositoryMockMarkAsSentParams, len(mmMarkAsSent.callArgs))
+	copy(argCopy, mmMarkAsSent.callArgs)
+
+	mmMarkAsSent.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockMarkAsSentDone returns true if the count of the MarkAsSent invocations corresponds
+// the number of defined expectations
+func (m *IOutboxRepositoryMock) MinimockMarkAsSentDone() bool {
+	if m.MarkAsSentMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.MarkAsSentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.MarkAsSentMock.invocationsDone()
+}
+
+// MinimockMarkAsSentInspect logs each unmet expectation
+func (m *IOutboxRepositoryMock) MinimockMarkAsSentInspect() {
+	for _, e := range m.MarkAsSentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsSent at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterMarkAsSentCounter := mm_atomic.LoadUint64(&m.afterMarkAsSentCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.MarkAsSentMock.defaultExpectation != nil && afterMarkAsSentCounter < 1 {
+		if m.MarkAsSentMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsSent at\n%s", m.MarkAsSentMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsSent at\n%s with params: %#v", m.MarkAsSentMock.defaultExpectation.expectationOrigins.origin, *m.MarkAsSentMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcMarkAsSent != nil && afterMarkAsSentCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.MarkAsSent at\n%s", m.funcMarkAsSentOrigin)
+	}
+
+	if !m.MarkAsSentMock.invocationsDone() && afterMarkAsSentCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.MarkAsSent at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.MarkAsSentMock.expectedInvocations), m.MarkAsSentMock.expectedInvocationsOrigin, afterMarkAsSentCounter)
+	}
+}
+
+type mIOutboxRepositoryMockReset struct {
+	optional           bool
+	mock               *IOutboxRepositoryMock
+	defaultExpectation *IOutboxRepositoryMockResetExpectation
+	expectations       []*IOutboxRepositoryMockResetExpectation
+
+	callArgs []*IOutboxRepositoryMockResetParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOutboxRepositoryMockResetExpectation specifies expectation struct of the IOutboxRepository.Reset
+type IOutboxRepositoryMockResetExpectation struct {
+	mock               *IOutboxRepositoryMock
+	params             *IOutboxRepositoryMockResetParams
+	paramPtrs          *IOutboxRepositoryMockResetParamPtrs
+	expectationOrigins IOutboxRepositoryMockResetExpectationOrigins
+	results            *IOutboxRepositoryMockResetResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOutboxRepositoryMockResetParams contains parameters of the IOutboxRepository.Reset
+type IOutboxRepositoryMockResetParams struct {
+	ctx        context.Context
+	shard      models.OutboxShard
+	filter     models.OutboxEventFilter
+	fromStates []models.OutboxEventState
+}
+
+// IOutboxRepositoryMockResetParamPtrs contains pointers to parameters of the IOutboxRepository.Reset
+type IOutboxRepositoryMockResetParamPtrs struct {
+	ctx        *context.Context
+	shard      *models.OutboxShard
+	filter     *models.OutboxEventFilter
+	fromStates *[]models.OutboxEventState
+}
+
+// IOutboxRepositoryMockResetResults contains results of the IOutboxRepository.Reset
+type IOutboxRepositoryMockResetResults struct {
+	i1  int64
+	err error
+}
+
+// IOutboxRepositoryMockResetOrigins contains origins of expectations of the IOutboxRepository.Reset
+type IOutboxRepositoryMockResetExpectationOrigins struct {
+	origin           string
+	originCtx        string
+	originShard      string
+	originFilter     string
+	originFromStates string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmReset *mIOutboxRepositoryMockReset) Optional() *mIOutboxRepositoryMockReset {
+	mmReset.optional = true
+	return mmReset
+}
+
+// Expect sets up expected params for IOutboxRepository.Reset
+func (mmReset *mIOutboxRepositoryMockReset) Expect(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) *mIOutboxRepositoryMockReset {
+	if mmReset.mock.funcReset != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Set")
+	}
+
+	if mmReset.defaultExpectation == nil {
+		mmReset.defaultExpectation = &IOutboxRepositoryMockResetExpectation{}
+	}
+
+	if mmReset.defaultExpectation.paramPtrs != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by ExpectParams functions")
+	}
+
+	mmReset.defaultExpectation.params = &IOutboxRepositoryMockResetParams{ctx, shard, filter, fromStates}
+	mmReset.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmReset.expectations {
+		if minimock.Equal(e.params, mmReset.defaultExpectation.params) {
+			mmReset.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReset.defaultExpectation.params)
+		}
+	}
+
+	return mmReset
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.Reset
+func (mmReset *mIOutboxRepositoryMockReset) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockReset {
+	if mmReset.mock.funcReset != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Set")
+	}
+
+	if mmReset.defaultExpectation == nil {
+		mmReset.defaultExpectation = &IOutboxRepositoryMockResetExpectation{}
+	}
+
+	if mmReset.defaultExpectation.params != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Expect")
+	}
+
+	if mmReset.defaultExpectation.paramPtrs == nil {
+		mmReset.defaultExpectation.paramPtrs = &IOutboxRepositoryMockResetParamPtrs{}
+	}
+	mmReset.defaultExpectation.paramPtrs.ctx = &ctx
+	mmReset.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmReset
+}
+
+// ExpectShardParam2 sets up expected param shard for IOutboxRepository.Reset
+func (mmReset *mIOutboxRepositoryMockReset) ExpectShardParam2(shard models.OutboxShard) *mIOutboxRepositoryMockReset {
+	if mmReset.mock.funcReset != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Set")
+	}
+
+	if mmReset.defaultExpectation == nil {
+		mmReset.defaultExpectation = &IOutboxRepositoryMockResetExpectation{}
+	}
+
+	if mmReset.defaultExpectation.params != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Expect")
+	}
+
+	if mmReset.defaultExpectation.paramPtrs == nil {
+		mmReset.defaultExpectation.paramPtrs = &IOutboxRepositoryMockResetParamPtrs{}
+	}
+	mmReset.defaultExpectation.paramPtrs.shard = &shard
+	mmReset.defaultExpectation.expectationOrigins.originShard = minimock.CallerInfo(1)
+
+	return mmReset
+}
+
+// ExpectFilterParam3 sets up expected param filter for IOutboxRepository.Reset
+func (mmReset *mIOutboxRepositoryMockReset) ExpectFilterParam3(filter models.OutboxEventFilter) *mIOutboxRepositoryMockReset {
+	if mmReset.mock.funcReset != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Set")
+	}
+
+	if mmReset.defaultExpectation == nil {
+		mmReset.defaultExpectation = &IOutboxRepositoryMockResetExpectation{}
+	}
+
+	if mmReset.defaultExpectation.params != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Expect")
+	}
+
+	if mmReset.defaultExpectation.paramPtrs == nil {
+		mmReset.defaultExpectation.paramPtrs = &IOutboxRepositoryMockResetParamPtrs{}
+	}
+	mmReset.defaultExpectation.paramPtrs.filter = &filter
+	mmReset.defaultExpectation.expectationOrigins.originFilter = minimock.CallerInfo(1)
+
+	return mmReset
+}
+
+// ExpectFromStatesParam4 sets up expected param fromStates for IOutboxRepository.Reset
+func (mmReset *mIOutboxRepositoryMockReset) ExpectFromStatesParam4(fromStates []models.OutboxEventState) *mIOutboxRepositoryMockReset {
+	if mmReset.mock.funcReset != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Set")
+	}
+
+	if mmReset.defaultExpectation == nil {
+		mmReset.defaultExpectation = &IOutboxRepositoryMockResetExpectation{}
+	}
+
+	if mmReset.defaultExpectation.params != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Expect")
+	}
+
+	if mmReset.defaultExpectation.paramPtrs == nil {
+		mmReset.defaultExpectation.paramPtrs = &IOutboxRepositoryMockResetParamPtrs{}
+	}
+	mmReset.defaultExpectation.paramPtrs.fromStates = &fromStates
+	mmReset.defaultExpectation.expectationOrigins.originFromStates = minimock.CallerInfo(1)
+
+	return mmReset
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.Reset
+func (mmReset *mIOutboxRepositoryMockReset) Inspect(f func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState)) *mIOutboxRepositoryMockReset {
+	if mmReset.mock.inspectFuncReset != nil {
+		mmReset.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.Reset")
+	}
+
+	mmReset.mock.inspectFuncReset = f
+
+	return mmReset
+}
+
+// Return sets up results that will be returned by IOutboxRepository.Reset
+func (mmReset *mIOutboxRepositoryMockReset) Return(i1 int64, err error) *IOutboxRepositoryMock {
+	if mmReset.mock.funcReset != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Set")
+	}
+
+	if mmReset.defaultExpectation == nil {
+		mmReset.defaultExpectation = &IOutboxRepositoryMockResetExpectation{mock: mmReset.mock}
+	}
+	mmReset.defaultExpectation.results = &IOutboxRepositoryMockResetResults{i1, err}
+	mmReset.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmReset.mock
+}
+
+// Set uses given function f to mock the IOutboxRepository.Reset method
+func (mmReset *mIOutboxRepositoryMockReset) Set(f func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) (i1 int64, err error)) *IOutboxRepositoryMock {
+	if mmReset.defaultExpectation != nil {
+		mmReset.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.Reset method")
+	}
+
+	if len(mmReset.expectations) > 0 {
+		mmReset.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.Reset method")
+	}
+
+	mmReset.mock.funcReset = f
+	mmReset.mock.funcResetOrigin = minimock.CallerInfo(1)
+	return mmReset.mock
+}
+
+// When sets expectation for the IOutboxRepository.Reset which will trigger the result defined by the following
+// Then helper
+func (mmReset *mIOutboxRepositoryMockReset) When(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) *IOutboxRepositoryMockResetExpectation {
+	if mmReset.mock.funcReset != nil {
+		mmReset.mock.t.Fatalf("IOutboxRepositoryMock.Reset mock is already set by Set")
+	}
+
+	expectation := &IOutboxRepositoryMockResetExpectation{
+		mock:               mmReset.mock,
+		params:             &IOutboxRepositoryMockResetParams{ctx, shard, filter, fromStates},
+		expectationOrigins: IOutboxRepositoryMockResetExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmReset.expectations = append(mmReset.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOutboxRepository.Reset return parameters for the expectation previously defined by the When method
+func (e *IOutboxRepositoryMockResetExpectation) Then(i1 int64, err error) *IOutboxRepositoryMock {
+	e.results = &IOutboxRepositoryMockResetResults{i1, err}
+	return e.mock
+}
+
+// Times sets number of times IOutboxRepository.Reset should be invoked
+func (mmReset *mIOutboxRepositoryMockReset) Times(n uint64) *mIOutboxRepositoryMockReset {
+	if n == 0 {
+		mmReset.mock.t.Fatalf("Times of IOutboxRepositoryMock.Reset mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmReset.expectedInvocations, n)
+	mmReset.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmReset
+}
+
+func (mmReset *mIOutboxRepositoryMockReset) invocationsDone() bool {
+	if len(mmReset.expectations) == 0 && mmReset.defaultExpectation == nil && mmReset.mock.funcReset == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmReset.mock.afterResetCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmReset.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Reset implements mm_service.IOutboxRepository
+func (mmReset *IOutboxRepositoryMock) Reset(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) (i1 int64, err error) {
+	mm_atomic.AddUint64(&mmReset.beforeResetCounter, 1)
+	defer mm_atomic.AddUint64(&mmReset.afterResetCounter, 1)
+
+	mmReset.t.Helper()
+
+	if mmReset.inspectFuncReset != nil {
+		mmReset.inspectFuncReset(ctx, shard, filter, fromStates)
+	}
+
+	mm_params := IOutboxRepositoryMockResetParams{ctx, shard, filter, fromStates}
+
+	// Record call args
+	mmReset.ResetMock.mutex.Lock()
+	mmReset.ResetMock.callArgs = append(mmReset.ResetMock.callArgs, &mm_params)
+	mmReset.ResetMock.mutex.Unlock()
+
+	for _, e := range mmReset.ResetMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.i1, e.results.err
+		}
+	}
+
+	if mmReset.ResetMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmReset.ResetMock.defaultExpectation.Counter, 1)
+		mm_want := mmReset.ResetMock.defaultExpectation.params
+		mm_want_ptrs := mmReset.ResetMock.defaultExpectation.paramPtrs
+
+		mm_got := IOutboxRepositoryMockResetParams{ctx, shard, filter, fromStates}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmReset.t.Errorf("IOutboxRepositoryMock.Reset got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReset.ResetMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.shard != nil && !minimock.Equal(*mm_want_ptrs.shard, mm_got.shard) {
+				mmReset.t.Errorf("IOutboxRepositoryMock.Reset got unexpected parameter shard, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReset.ResetMock.defaultExpectation.expectationOrigins.originShard, *mm_want_ptrs.shard, mm_got.shard, minimock.Diff(*mm_want_ptrs.shard, mm_got.shard))
+			}
+
+			if mm_want_ptrs.filter != nil && !minimock.Equal(*mm_want_ptrs.filter, mm_got.filter) {
+				mmReset.t.Errorf("IOutboxRepositoryMock.Reset got unexpected parameter filter, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReset.ResetMock.defaultExpectation.expectationOrigins.originFilter, *mm_want_ptrs.filter, mm_got.filter, minimock.Diff(*mm_want_ptrs.filter, mm_got.filter))
+			}
+
+			if mm_want_ptrs.fromStates != nil && !minimock.Equal(*mm_want_ptrs.fromStates, mm_got.fromStates) {
+				mmReset.t.Errorf("IOutboxRepositoryMock.Reset got unexpected parameter fromStates, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReset.ResetMock.defaultExpectation.expectationOrigins.originFromStates, *mm_want_ptrs.fromStates, mm_got.fromStates, minimock.Diff(*mm_want_ptrs.fromStates, mm_got.fromStates))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmReset.t.Errorf("IOutboxRepositoryMock.Reset got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmReset.ResetMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmReset.ResetMock.defaultExpectation.results
+		if mm_results == nil {
+			mmReset.t.Fatal("No results are set for the IOutboxRepositoryMock.Reset")
+		}
+		return (*mm_results).i1, (*mm_results).err
+	}
+	if mmReset.funcReset != nil {
+		return mmReset.funcReset(ctx, shard, filter, fromStates)
+	}
+	mmReset.t.Fatalf("Unexpected call to IOutboxRepositoryMock.Reset. %v %v %v %v", ctx, shard, filter, fromStates)
+	return
+}
+
+// ResetAfterCounter returns a count of finished IOutboxRepositoryMock.Reset invocations
+func (mmReset *IOutboxRepositoryMock) ResetAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReset.afterResetCounter)
+}
+
+// ResetBeforeCounter returns a count of IOutboxRepositoryMock.Reset invocations
+func (mmReset *IOutboxRepositoryMock) ResetBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReset.beforeResetCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.Reset.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmReset *mIOutboxRepositoryMockReset) Calls() []*IOutboxRepositoryMockResetParams {
+	mmReset.mutex.RLock()
+
+	argCopy := make([]*IOutboxRepositoryMockResetParams, len(mmReset.callArgs))
+	copy(argCopy, mmReset.callArgs)
+
+	mmReset.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockResetDone returns true if the count of the Reset invocations corresponds
+// the number of defined expectations
+func (m *IOutboxRepositoryMock) MinimockResetDone() bool {
+	if m.ResetMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ResetMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ResetMock.invocationsDone()
+}
+
+// MinimockResetInspect logs each unmet expectation
+func (m *IOutboxRepositoryMock) MinimockResetInspect() {
+	for _, e := range m.ResetMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.Reset at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterResetCounter := mm_atomic.LoadUint64(&m.afterResetCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ResetMock.defaultExpectation != nil && afterResetCounter < 1 {
+		if m.ResetMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.Reset at\n%s", m.ResetMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.Reset at\n%s with params: %#v", m.ResetMock.defaultExpectation.expectationOrigins.origin, *m.ResetMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReset != nil && afterResetCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.Reset at\n%s", m.funcResetOrigin)
+	}
+
+	if !m.ResetMock.invocationsDone() && afterResetCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.Reset at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ResetMock.expectedInvocations), m.ResetMock.expectedInvocationsOrigin, afterResetCounter)
+	}
+}
+
+type mIOutboxRepositoryMockShards struct {
+	optional           bool
+	mock               *IOutboxRepositoryMock
+	defaultExpectation *IOutboxRepositoryMockShardsExpectation
+	expectations       []*IOutboxRepositoryMockShardsExpectation
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOutboxRepositoryMockShardsExpectation specifies expectation struct of the IOutboxRepository.Shards
+type IOutboxRepositoryMockShardsExpectation struct {
+	mock *IOutboxRepositoryMock
+
+	results      *IOutboxRepositoryMockShardsResults
+	returnOrigin string
+	Counter      uint64
+}
+
+// IOutboxRepositoryMockShardsResults contains results of the IOutboxRepository.Shards
+type IOutboxRepositoryMockShardsResults struct {
+	oa1 []models.OutboxShard
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmShards *mIOutboxRepositoryMockShards) Optional() *mIOutboxRepositoryMockShards {
+	mmShards.optional = true
+	return mmShards
+}
+
+// Expect sets up expected params for IOutboxRepository.Shards
+func (mmShards *mIOutboxRepositoryMockShards) Expect() *mIOutboxRepositoryMockShards {
+	if mmShards.mock.funcShards != nil {
+		mmShards.mock.t.Fatalf("IOutboxRepositoryMock.Shards mock is already set by Set")
+	}
+
+	if mmShards.defaultExpectation == nil {
+		mmShards.defaultExpectation = &IOutboxRepositoryMockShardsExpectation{}
+	}
+
+	return mmShards
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.Shards
+func (mmShards *mIOutboxRepositoryMockShards) Inspect(f func()) *mIOutboxRepositoryMockShards {
+	if mmShards.mock.inspectFuncShards != nil {
+		mmShards.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.Shards")
+	}
+
+	mmShards.mock.inspectFuncShards = f
+
+	return mmShards
+}
+
+// Return sets up results that will be returned by IOutboxRepository.Shards
+func (mmShards *mIOutboxRepositoryMockShards) Return(oa1 []models.OutboxShard) *IOutboxRepositoryMock {
+	if mmShards.mock.funcShards != nil {
+		mmShards.mock.t.Fatalf("IOutboxRepositoryMock.Shards mock is already set by Set")
+	}
+
+	if mmShards.defaultExpectation == nil {
+		mmShards.defaultExpectation = &IOutboxRepositoryMockShardsExpectation{mock: mmShards.mock}
+	}
+	mmShards.defaultExpectation.results = &IOutboxRepositoryMockShardsResults{oa1}
+	mmShards.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmShards.mock
+}
+
+// Set uses given function f to mock the IOutboxRepository.Shards method
+func (mmShards *mIOutboxRepositoryMockShards) Set(f func() (oa1 []models.OutboxShard)) *IOutboxRepositoryMock {
+	if mmShards.defaultExpectation != nil {
+		mmShards.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.Shards method")
+	}
+
+	if len(mmShards.expectations) > 0 {
+		mmShards.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.Shards method")
+	}
+
+	mmShards.mock.funcShards = f
+	mmShards.mock.funcShardsOrigin = minimock.CallerInfo(1)
+	return mmShards.mock
+}
+
+// Times sets number of times IOutboxRepository.Shards should be invoked
+func (mmShards *mIOutboxRepositoryMockShards) Times(n uint64) *mIOutboxRepositoryMockShards {
+	if n == 0 {
+		mmShards.mock.t.Fatalf("Times of IOutboxRepositoryMock.Shards mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmShards.expectedInvocations, n)
+	mmShards.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmShards
+}
+
+func (mmShards *mIOutboxRepositoryMockShards) invocationsDone() bool {
+	if len(mmShards.expectations) == 0 && mmShards.defaultExpectation == nil && mmShards.mock.funcShards == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmShards.mock.afterShardsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmShards.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Shards implements mm_service.IOutboxRepository
+func (mmShards *IOutboxRepositoryMock) Shards() (oa1 []models.OutboxShard) {
+	mm_atomic.AddUint64(&mmShards.beforeShardsCounter, 1)
+	defer mm_atomic.AddUint64(&mmShards.afterShardsCounter, 1)
+
+	mmShards.t.Helper()
+
+	if mmShards.inspectFuncShards != nil {
+		mmShards.inspectFuncShards()
+	}
+
+	if mmShards.ShardsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmShards.ShardsMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmShards.ShardsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmShards.t.Fatal("No results are set for the IOutboxRepositoryMock.Shards")
+		}
+		return (*mm_results).oa1
+	}
+	if mmShards.funcShards != nil {
+		return mmShards.funcShards()
+	}
+	mmShards.t.Fatalf("Unexpected call to IOutboxRepositoryMock.Shards.")
+	return
+}
+
+// ShardsAfterCounter returns a count of finished IOutboxRepositoryMock.Shards invocations
+func (mmShards *IOutboxRepositoryMock) ShardsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmShards.afterShardsCounter)
+}
+
+// ShardsBeforeCounter returns a count of IOutboxRepositoryMock.Shards invocations
+func (mmShards *IOutboxRepositoryMock) ShardsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmShards.beforeShardsCounter)
+}
+
+// MinimockShardsDone returns true if the count of the Shards invocations corresponds
+// the number of defined expectations
+func (m *IOutboxRepositoryMock) MinimockShardsDone() bool {
+	if m.ShardsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ShardsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ShardsMock.invocationsDone()
+}
+
+// MinimockShardsInspect logs each unmet expectation
+func (m *IOutboxRepositoryMock) MinimockShardsInspect() {
+	for _, e := range m.ShardsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to IOutboxRepositoryMock.Shards")
+		}
+	}
+
+	afterShardsCounter := mm_atomic.LoadUint64(&m.afterShardsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ShardsMock.defaultExpectation != nil && afterShardsCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.Shards at\n%s", m.ShardsMock.defaultExpectation.returnOrigin)
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcShards != nil && afterShardsCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.Shards at\n%s", m.funcShardsOrigin)
+	}
+
+	if !m.ShardsMock.invocationsDone() && afterShardsCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.Shards at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ShardsMock.expectedInvocations), m.ShardsMock.expectedInvocationsOrigin, afterShardsCounter)
+	}
+}
+
+type mIOutboxRepositoryMockWithShardTx struct {
+	optional           bool
+	mock               *IOutboxRepositoryMock
+	defaultExpectation *IOutboxRepositoryMockWithShardTxExpectation
+	expectations       []*IOutboxRepositoryMockWithShardTxExpectation
+
+	callArgs []*IOutboxRepositoryMockWithShardTxParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IOutboxRepositoryMockWithShardTxExpectation specifies expectation struct of the IOutboxRepository.WithShardTx
+type IOutboxRepositoryMockWithShardTxExpectation struct {
+	mock               *IOutboxRepositoryMock
+	params             *IOutboxRepositoryMockWithShardTxParams
+	paramPtrs          *IOutboxRepositoryMockWithShardTxParamPtrs
+	expectationOrigins IOutboxRepositoryMockWithShardTxExpectationOrigins
+	results            *IOutboxRepositoryMockWithShardTxResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IOutboxRepositoryMockWithShardTxParams contains parameters of the IOutboxRepository.WithShardTx
+type IOutboxRepositoryMockWithShardTxParams struct {
+	ctx   context.Context
+	shard models.OutboxShard
+	fn    func(ctx context.Context, tx pgx.Tx) error
+}
+
+// IOutboxRepositoryMockWithShardTxParamPtrs contains pointers to parameters of the IOutboxRepository.WithShardTx
+type IOutboxRepositoryMockWithShardTxParamPtrs struct {
+	ctx   *context.Context
+	shard *models.OutboxShard
+	fn    *func(ctx context.Context, tx pgx.Tx) error
+}
+
+// IOutboxRepositoryMockWithShardTxResults contains results of the IOutboxRepository.WithShardTx
+type IOutboxRepositoryMockWithShardTxResults struct {
+	err error
+}
+
+// IOutboxRepositoryMockWithShardTxOrigins contains origins of expectations of the IOutboxRepository.WithShardTx
+type IOutboxRepositoryMockWithShardTxExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originShard string
+	originFn    string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) Optional() *mIOutboxRepositoryMockWithShardTx {
+	mmWithShardTx.optional = true
+	return mmWithShardTx
+}
+
+// Expect sets up expected params for IOutboxRepository.WithShardTx
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) Expect(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) *mIOutboxRepositoryMockWithShardTx {
+	if mmWithShardTx.mock.funcWithShardTx != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Set")
+	}
+
+	if mmWithShardTx.defaultExpectation == nil {
+		mmWithShardTx.defaultExpectation = &IOutboxRepositoryMockWithShardTxExpectation{}
+	}
+
+	if mmWithShardTx.defaultExpectation.paramPtrs != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by ExpectParams functions")
+	}
+
+	mmWithShardTx.defaultExpectation.params = &IOutboxRepositoryMockWithShardTxParams{ctx, shard, fn}
+	mmWithShardTx.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmWithShardTx.expectations {
+		if minimock.Equal(e.params, mmWithShardTx.defaultExpectation.params) {
+			mmWithShardTx.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmWithShardTx.defaultExpectation.params)
+		}
+	}
+
+	return mmWithShardTx
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IOutboxRepository.WithShardTx
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) ExpectCtxParam1(ctx context.Context) *mIOutboxRepositoryMockWithShardTx {
+	if mmWithShardTx.mock.funcWithShardTx != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Set")
+	}
+
+	if mmWithShardTx.defaultExpectation == nil {
+		mmWithShardTx.defaultExpectation = &IOutboxRepositoryMockWithShardTxExpectation{}
+	}
+
+	if mmWithShardTx.defaultExpectation.params != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Expect")
+	}
+
+	if mmWithShardTx.defaultExpectation.paramPtrs == nil {
+		mmWithShardTx.defaultExpectation.paramPtrs = &IOutboxRepositoryMockWithShardTxParamPtrs{}
+	}
+	mmWithShardTx.defaultExpectation.paramPtrs.ctx = &ctx
+	mmWithShardTx.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmWithShardTx
+}
+
+// ExpectShardParam2 sets up expected param shard for IOutboxRepository.WithShardTx
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) ExpectShardParam2(shard models.OutboxShard) *mIOutboxRepositoryMockWithShardTx {
+	if mmWithShardTx.mock.funcWithShardTx != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Set")
+	}
+
+	if mmWithShardTx.defaultExpectation == nil {
+		mmWithShardTx.defaultExpectation = &IOutboxRepositoryMockWithShardTxExpectation{}
+	}
+
+	if mmWithShardTx.defaultExpectation.params != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Expect")
+	}
+
+	if mmWithShardTx.defaultExpectation.paramPtrs == nil {
+		mmWithShardTx.defaultExpectation.paramPtrs = &IOutboxRepositoryMockWithShardTxParamPtrs{}
+	}
+	mmWithShardTx.defaultExpectation.paramPtrs.shard = &shard
+	mmWithShardTx.defaultExpectation.expectationOrigins.originShard = minimock.CallerInfo(1)
+
+	return mmWithShardTx
+}
+
+// ExpectFnParam3 sets up expected param fn for IOutboxRepository.WithShardTx
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) ExpectFnParam3(fn func(ctx context.Context, tx pgx.Tx) error) *mIOutboxRepositoryMockWithShardTx {
+	if mmWithShardTx.mock.funcWithShardTx != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Set")
+	}
+
+	if mmWithShardTx.defaultExpectation == nil {
+		mmWithShardTx.defaultExpectation = &IOutboxRepositoryMockWithShardTxExpectation{}
+	}
+
+	if mmWithShardTx.defaultExpectation.params != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Expect")
+	}
+
+	if mmWithShardTx.defaultExpectation.paramPtrs == nil {
+		mmWithShardTx.defaultExpectation.paramPtrs = &IOutboxRepositoryMockWithShardTxParamPtrs{}
+	}
+	mmWithShardTx.defaultExpectation.paramPtrs.fn = &fn
+	mmWithShardTx.defaultExpectation.expectationOrigins.originFn = minimock.CallerInfo(1)
+
+	return mmWithShardTx
+}
+
+// Inspect accepts an inspector function that has same arguments as the IOutboxRepository.WithShardTx
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) Inspect(f func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error)) *mIOutboxRepositoryMockWithShardTx {
+	if mmWithShardTx.mock.inspectFuncWithShardTx != nil {
+		mmWithShardTx.mock.t.Fatalf("Inspect function is already set for IOutboxRepositoryMock.WithShardTx")
+	}
+
+	mmWithShardTx.mock.inspectFuncWithShardTx = f
+
+	return mmWithShardTx
+}
+
+// Return sets up results that will be returned by IOutboxRepository.WithShardTx
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) Return(err error) *IOutboxRepositoryMock {
+	if mmWithShardTx.mock.funcWithShardTx != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Set")
+	}
+
+	if mmWithShardTx.defaultExpectation == nil {
+		mmWithShardTx.defaultExpectation = &IOutboxRepositoryMockWithShardTxExpectation{mock: mmWithShardTx.mock}
+	}
+	mmWithShardTx.defaultExpectation.results = &IOutboxRepositoryMockWithShardTxResults{err}
+	mmWithShardTx.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmWithShardTx.mock
+}
+
+// Set uses given function f to mock the IOutboxRepository.WithShardTx method
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) Set(f func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) (err error)) *IOutboxRepositoryMock {
+	if mmWithShardTx.defaultExpectation != nil {
+		mmWithShardTx.mock.t.Fatalf("Default expectation is already set for the IOutboxRepository.WithShardTx method")
+	}
+
+	if len(mmWithShardTx.expectations) > 0 {
+		mmWithShardTx.mock.t.Fatalf("Some expectations are already set for the IOutboxRepository.WithShardTx method")
+	}
+
+	mmWithShardTx.mock.funcWithShardTx = f
+	mmWithShardTx.mock.funcWithShardTxOrigin = minimock.CallerInfo(1)
+	return mmWithShardTx.mock
+}
+
+// When sets expectation for the IOutboxRepository.WithShardTx which will trigger the result defined by the following
+// Then helper
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) When(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) *IOutboxRepositoryMockWithShardTxExpectation {
+	if mmWithShardTx.mock.funcWithShardTx != nil {
+		mmWithShardTx.mock.t.Fatalf("IOutboxRepositoryMock.WithShardTx mock is already set by Set")
+	}
+
+	expectation := &IOutboxRepositoryMockWithShardTxExpectation{
+		mock:               mmWithShardTx.mock,
+		params:             &IOutboxRepositoryMockWithShardTxParams{ctx, shard, fn},
+		expectationOrigins: IOutboxRepositoryMockWithShardTxExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmWithShardTx.expectations = append(mmWithShardTx.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IOutboxRepository.WithShardTx return parameters for the expectation previously defined by the When method
+func (e *IOutboxRepositoryMockWithShardTxExpectation) Then(err error) *IOutboxRepositoryMock {
+	e.results = &IOutboxRepositoryMockWithShardTxResults{err}
+	return e.mock
+}
+
+// Times sets number of times IOutboxRepository.WithShardTx should be invoked
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) Times(n uint64) *mIOutboxRepositoryMockWithShardTx {
+	if n == 0 {
+		mmWithShardTx.mock.t.Fatalf("Times of IOutboxRepositoryMock.WithShardTx mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmWithShardTx.expectedInvocations, n)
+	mmWithShardTx.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmWithShardTx
+}
+
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) invocationsDone() bool {
+	if len(mmWithShardTx.expectations) == 0 && mmWithShardTx.defaultExpectation == nil && mmWithShardTx.mock.funcWithShardTx == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmWithShardTx.mock.afterWithShardTxCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmWithShardTx.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// WithShardTx implements mm_service.IOutboxRepository
+func (mmWithShardTx *IOutboxRepositoryMock) WithShardTx(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) (err error) {
+	mm_atomic.AddUint64(&mmWithShardTx.beforeWithShardTxCounter, 1)
+	defer mm_atomic.AddUint64(&mmWithShardTx.afterWithShardTxCounter, 1)
+
+	mmWithShardTx.t.Helper()
+
+	if mmWithShardTx.inspectFuncWithShardTx != nil {
+		mmWithShardTx.inspectFuncWithShardTx(ctx, shard, fn)
+	}
+
+	mm_params := IOutboxRepositoryMockWithShardTxParams{ctx, shard, fn}
+
+	// Record call args
+	mmWithShardTx.WithShardTxMock.mutex.Lock()
+	mmWithShardTx.WithShardTxMock.callArgs = append(mmWithShardTx.WithShardTxMock.callArgs, &mm_params)
+	mmWithShardTx.WithShardTxMock.mutex.Unlock()
+
+	for _, e := range mmWithShardTx.WithShardTxMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmWithShardTx.WithShardTxMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmWithShardTx.WithShardTxMock.defaultExpectation.Counter, 1)
+		mm_want := mmWithShardTx.WithShardTxMock.defaultExpectation.params
+		mm_want_ptrs := mmWithShardTx.WithShardTxMock.defaultExpectation.paramPtrs
+
+		mm_got := IOutboxRepositoryMockWithShardTxParams{ctx, shard, fn}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmWithShardTx.t.Errorf("IOutboxRepositoryMock.WithShardTx got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmWithShardTx.WithShardTxMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.shard != nil && !minimock.Equal(*mm_want_ptrs.shard, mm_got.shard) {
+				mmWithShardTx.t.Errorf("IOutboxRepositoryMock.WithShardTx got unexpected parameter shard, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmWithShardTx.WithShardTxMock.defaultExpectation.expectationOrigins.originShard, *mm_want_ptrs.shard, mm_got.shard, minimock.Diff(*mm_want_ptrs.shard, mm_got.shard))
+			}
+
+			if mm_want_ptrs.fn != nil && !minimock.Equal(*mm_want_ptrs.fn, mm_got.fn) {
+				mmWithShardTx.t.Errorf("IOutboxRepositoryMock.WithShardTx got unexpected parameter fn, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmWithShardTx.WithShardTxMock.defaultExpectation.expectationOrigins.originFn, *mm_want_ptrs.fn, mm_got.fn, minimock.Diff(*mm_want_ptrs.fn, mm_got.fn))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmWithShardTx.t.Errorf("IOutboxRepositoryMock.WithShardTx got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmWithShardTx.WithShardTxMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmWithShardTx.WithShardTxMock.defaultExpectation.results
+		if mm_results == nil {
+			mmWithShardTx.t.Fatal("No results are set for the IOutboxRepositoryMock.WithShardTx")
+		}
+		return (*mm_results).err
+	}
+	if mmWithShardTx.funcWithShardTx != nil {
+		return mmWithShardTx.funcWithShardTx(ctx, shard, fn)
+	}
+	mmWithShardTx.t.Fatalf("Unexpected call to IOutboxRepositoryMock.WithShardTx. %v %v %v", ctx, shard, fn)
+	return
+}
+
+// WithShardTxAfterCounter returns a count of finished IOutboxRepositoryMock.WithShardTx invocations
+func (mmWithShardTx *IOutboxRepositoryMock) WithShardTxAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmWithShardTx.afterWithShardTxCounter)
+}
+
+// WithShardTxBeforeCounter returns a count of IOutboxRepositoryMock.WithShardTx invocations
+func (mmWithShardTx *IOutboxRepositoryMock) WithShardTxBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmWithShardTx.beforeWithShardTxCounter)
+}
+
+// Calls returns a list of arguments used in each call to IOutboxRepositoryMock.WithShardTx.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmWithShardTx *mIOutboxRepositoryMockWithShardTx) Calls() []*IOutboxRepositoryMockWithShardTxParams {
+	mmWithShardTx.mutex.RLock()
+
+	argCopy := make([]*IOutboxRepositoryMockWithShardTxParams, len(mmWithShardTx.callArgs))
+	copy(argCopy, mmWithShardTx.callArgs)
+
+	mmWithShardTx.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockWithShardTxDone returns true if the count of the WithShardTx invocations corresponds
+// the number of defined expectations
+func (m *IOutboxRepositoryMock) MinimockWithShardTxDone() bool {
+	if m.WithShardTxMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.WithShardTxMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.WithShardTxMock.invocationsDone()
+}
+
+// MinimockWithShardTxInspect logs each unmet expectation
+func (m *IOutboxRepositoryMock) MinimockWithShardTxInspect() {
+	for _, e := range m.WithShardTxMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.WithShardTx at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterWithShardTxCounter := mm_atomic.LoadUint64(&m.afterWithShardTxCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.WithShardTxMock.defaultExpectation != nil && afterWithShardTxCounter < 1 {
+		if m.WithShardTxMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.WithShardTx at\n%s", m.WithShardTxMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IOutboxRepositoryMock.WithShardTx at\n%s with params: %#v", m.WithShardTxMock.defaultExpectation.expectationOrigins.origin, *m.WithShardTxMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcWithShardTx != nil && afterWithShardTxCounter < 1 {
+		m.t.Errorf("Expected call to IOutboxRepositoryMock.WithShardTx at\n%s", m.funcWithShardTxOrigin)
+	}
+
+	if !m.WithShardTxMock.invocationsDone() && afterWithShardTxCounter > 0 {
+		m.t.Errorf("Expected %d calls to IOutboxRepositoryMock.WithShardTx at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.WithShardTxMock.expectedInvocations), m.WithShardTxMock.expectedInvocationsOrigin, afterWithShardTxCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *IOutboxRepositoryMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockCreateEventInspect()
+
+			m.MinimockDeleteProcessedInspect()
+
+			m.MinimockFetchBatchInspect()
+
+			m.MinimockListInspect()
+
+			m.MinimockMarkAsFailedInspect()
+
+			m.MinimockMarkAsSentInspect()
+
+			m.MinimockResetInspect()
+
+			m.MinimockShardsInspect()
+
+			m.MinimockWithShardTxInspect()
 		}
 	})
 }
@@ -1217,6 +3529,12 @@ func (m *IOutboxRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockCreateEventDone() &&
-		m.MinimockFetchNextMsgDone() &&
-		m.MinimockMarkAsSentDone()
+		m.MinimockDeleteProcessedDone() &&
+		m.MinimockFetchBatchDone() &&
+		m.MinimockListDone() &&
+		m.MinimockMarkAsFailedDone() &&
+		m.MinimockMarkAsSentDone() &&
+		m.MinimockResetDone() &&
+		m.MinimockShardsDone() &&
+		m.MinimockWithShardTxDone()
 }
@@ -19,12 +19,12 @@ type IProducerMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcSendOutboxEvent          func(ctx context.Context, event *models.OutboxEvent) (err error)
-	funcSendOutboxEventOrigin    string
-	inspectFuncSendOutboxEvent   func(ctx context.Context, event *models.OutboxEvent)
-	afterSendOutboxEventCounter  uint64
-	beforeSendOutboxEventCounter uint64
-	SendOutboxEventMock          mIProducerMockSendOutboxEvent
+	funcSendOutboxEvents          func(ctx context.Context, events []models.OutboxEvent) (m1 map[int64]error, err error)
+	funcSendOutboxEventsOrigin    string
+	inspectFuncSendOutboxEvents   func(ctx context.Context, events []models.OutboxEvent)
+	afterSendOutboxEventsCounter  uint64
+	beforeSendOutboxEventsCounter uint64
+	SendOutboxEventsMock          mIProducerMockSendOutboxEvents
 }
 
 // NewIProducerMock returns a mock for mm_service.IProducer
@@ -35,60 +35,61 @@ func NewIProducerMock(t minimock.Tester) *IProducerMock {
 		controller.RegisterMocker(m)
 	}
 
-	m.SendOutboxEventMock = mIProducerMockSendOutboxEvent{mock: m}
-	m.SendOutboxEventMock.callArgs = []*IProducerMockSendOutboxEventParams{}
+	m.SendOutboxEventsMock = mIProducerMockSendOutboxEvents{mock: m}
+	m.SendOutboxEventsMock.callArgs = []*IProducerMockSendOutboxEventsParams{}
 
 	t.Cleanup(m.MinimockFinish)
 
 	return m
 }
 
-type mIProducerMockSendOutboxEvent struct {
+type mIProducerMockSendOutboxEvents struct {
 	optional           bool
 	mock               *IProducerMock
-	defaultExpectation *IProducerMockSendOutboxEventExpectation
-	expectations       []*IProducerMockSendOutboxEventExpectation
+	defaultExpectation *IProducerMockSendOutboxEventsExpectation
+	expectations       []*IProducerMockSendOutboxEventsExpectation
 
-	callArgs []*IProducerMockSendOutboxEventParams
+	callArgs []*IProducerMockSendOutboxEventsParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// IProducerMockSendOutboxEventExpectation specifies expectation struct of the IProducer.SendOutboxEvent
-type IProducerMockSendOutboxEventExpectation struct {
+// IProducerMockSendOutboxEventsExpectation specifies expectation struct of the IProducer.SendOutboxEvents
+type IProducerMockSendOutboxEventsExpectation struct {
 	mock               *IProducerMock
-	params             *IProducerMockSendOutboxEventParams
-	paramPtrs          *IProducerMockSendOutboxEventParamPtrs
-	expectationOrigins IProducerMockSendOutboxEventExpectationOrigins
-	results            *IProducerMockSendOutboxEventResults
+	params             *IProducerMockSendOutboxEventsParams
+	paramPtrs          *IProducerMockSendOutboxEventsParamPtrs
+	expectationOrigins IProducerMockSendOutboxEventsExpectationOrigins
+	results            *IProducerMockSendOutboxEventsResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// IProducerMockSendOutboxEventParams contains parameters of the IProducer.SendOutboxEvent
-type IProducerMockSendOutboxEventParams struct {
-	ctx   context.Context
-	event *models.OutboxEvent
+// IProducerMockSendOutboxEventsParams contains parameters of the IProducer.SendOutboxEvents
+type IProducerMockSendOutboxEventsParams struct {
+	ctx    context.Context
+	events []models.OutboxEvent
 }
 
-// IProducerMockSendOutboxEventParamPtrs contains pointers to parameters of the IProducer.SendOutboxEvent
-type IProducerMockSendOutboxEventParamPtrs struct {
-	ctx   *context.Context
-	event **models.OutboxEvent
+// IProducerMockSendOutboxEventsParamPtrs contains pointers to parameters of the IProducer.SendOutboxEvents
+type IProducerMockSendOutboxEventsParamPtrs struct {
+	ctx    *context.Context
+	events *[]models.OutboxEvent
 }
 
-// IProducerMockSendOutboxEventResults contains results of the IProducer.SendOutboxEvent
-type IProducerMockSendOutboxEventResults struct {
+// IProducerMockSendOutboxEventsResults contains results of the IProducer.SendOutboxEvents
+type IProducerMockSendOutboxEventsResults struct {
+	m1  map[int64]error
 	err error
 }
 
-// IProducerMockSendOutboxEventOrigins contains origins of expectations of the IProducer.SendOutboxEvent
-type IProducerMockSendOutboxEventExpectationOrigins struct {
-	origin      string
-	originCtx   string
-	originEvent string
+// IProducerMockSendOutboxEventsOrigins contains origins of expectations of the IProducer.SendOutboxEvents
+type IProducerMockSendOutboxEventsExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originEvents string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -96,292 +97,292 @@ type IProducerMockSendOutboxEventExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) Optional() *mIProducerMockSendOutboxEvent {
-	mmSendOutboxEvent.optional = true
-	return mmSendOutboxEvent
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) Optional() *mIProducerMockSendOutboxEvents {
+	mmSendOutboxEvents.optional = true
+	return mmSendOutboxEvents
 }
 
-// Expect sets up expected params for IProducer.SendOutboxEvent
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) Expect(ctx context.Context, event *models.OutboxEvent) *mIProducerMockSendOutboxEvent {
-	if mmSendOutboxEvent.mock.funcSendOutboxEvent != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("IProducerMock.SendOutboxEvent mock is already set by Set")
+// Expect sets up expected params for IProducer.SendOutboxEvents
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) Expect(ctx context.Context, events []models.OutboxEvent) *mIProducerMockSendOutboxEvents {
+	if mmSendOutboxEvents.mock.funcSendOutboxEvents != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("IProducerMock.SendOutboxEvents mock is already set by Set")
 	}
 
-	if mmSendOutboxEvent.defaultExpectation == nil {
-		mmSendOutboxEvent.defaultExpectation = &IProducerMockSendOutboxEventExpectation{}
+	if mmSendOutboxEvents.defaultExpectation == nil {
+		mmSendOutboxEvents.defaultExpectation = &IProducerMockSendOutboxEventsExpectation{}
 	}
 
-	if mmSendOutboxEvent.defaultExpectation.paramPtrs != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("IProducerMock.SendOutboxEvent mock is already set by ExpectParams functions")
+	if mmSendOutboxEvents.defaultExpectation.paramPtrs != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("IProducerMock.SendOutboxEvents mock is already set by ExpectParams functions")
 	}
 
-	mmSendOutboxEvent.defaultExpectation.params = &IProducerMockSendOutboxEventParams{ctx, event}
-	mmSendOutboxEvent.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmSendOutboxEvent.expectations {
-		if minimock.Equal(e.params, mmSendOutboxEvent.defaultExpectation.params) {
-			mmSendOutboxEvent.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSendOutboxEvent.defaultExpectation.params)
+	mmSendOutboxEvents.defaultExpectation.params = &IProducerMockSendOutboxEventsParams{ctx, events}
+	mmSendOutboxEvents.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmSendOutboxEvents.expectations {
+		if minimock.Equal(e.params, mmSendOutboxEvents.defaultExpectation.params) {
+			mmSendOutboxEvents.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSendOutboxEvents.defaultExpectation.params)
 		}
 	}
 
-	return mmSendOutboxEvent
+	return mmSendOutboxEvents
 }
 
-// ExpectCtxParam1 sets up expected param ctx for IProducer.SendOutboxEvent
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) ExpectCtxParam1(ctx context.Context) *mIProducerMockSendOutboxEvent {
-	if mmSendOutboxEvent.mock.funcSendOutboxEvent != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("IProducerMock.SendOutboxEvent mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for IProducer.SendOutboxEvents
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) ExpectCtxParam1(ctx context.Context) *mIProducerMockSendOutboxEvents {
+	if mmSendOutboxEvents.mock.funcSendOutboxEvents != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("IProducerMock.SendOutboxEvents mock is already set by Set")
 	}
 
-	if mmSendOutboxEvent.defaultExpectation == nil {
-		mmSendOutboxEvent.defaultExpectation = &IProducerMockSendOutboxEventExpectation{}
+	if mmSendOutboxEvents.defaultExpectation == nil {
+		mmSendOutboxEvents.defaultExpectation = &IProducerMockSendOutboxEventsExpectation{}
 	}
 
-	if mmSendOutboxEvent.defaultExpectation.params != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("IProducerMock.SendOutboxEvent mock is already set by Expect")
+	if mmSendOutboxEvents.defaultExpectation.params != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("IProducerMock.SendOutboxEvents mock is already set by Expect")
 	}
 
-	if mmSendOutboxEvent.defaultExpectation.paramPtrs == nil {
-		mmSendOutboxEvent.defaultExpectation.paramPtrs = &IProducerMockSendOutboxEventParamPtrs{}
+	if mmSendOutboxEvents.defaultExpectation.paramPtrs == nil {
+		mmSendOutboxEvents.defaultExpectation.paramPtrs = &IProducerMockSendOutboxEventsParamPtrs{}
 	}
-	mmSendOutboxEvent.defaultExpectation.paramPtrs.ctx = &ctx
-	mmSendOutboxEvent.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+	mmSendOutboxEvents.defaultExpectation.paramPtrs.ctx = &ctx
+	mmSendOutboxEvents.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmSendOutboxEvent
+	return mmSendOutboxEvents
 }
 
-// ExpectEventParam2 sets up expected param event for IProducer.SendOutboxEvent
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) ExpectEventParam2(event *models.OutboxEvent) *mIProducerMockSendOutboxEvent {
-	if mmSendOutboxEvent.mock.funcSendOutboxEvent != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("IProducerMock.SendOutboxEvent mock is already set by Set")
+// ExpectEventsParam2 sets up expected param events for IProducer.SendOutboxEvents
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) ExpectEventsParam2(events []models.OutboxEvent) *mIProducerMockSendOutboxEvents {
+	if mmSendOutboxEvents.mock.funcSendOutboxEvents != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("IProducerMock.SendOutboxEvents mock is already set by Set")
 	}
 
-	if mmSendOutboxEvent.defaultExpectation == nil {
-		mmSendOutboxEvent.defaultExpectation = &IProducerMockSendOutboxEventExpectation{}
+	if mmSendOutboxEvents.defaultExpectation == nil {
+		mmSendOutboxEvents.defaultExpectation = &IProducerMockSendOutboxEventsExpectation{}
 	}
 
-	if mmSendOutboxEvent.defaultExpectation.params != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("IProducerMock.SendOutboxEvent mock is already set by Expect")
+	if mmSendOutboxEvents.defaultExpectation.params != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("IProducerMock.SendOutboxEvents mock is already set by Expect")
 	}
 
-	if mmSendOutboxEvent.defaultExpectation.paramPtrs == nil {
-		mmSendOutboxEvent.defaultExpectation.paramPtrs = &IProducerMockSendOutboxEventParamPtrs{}
+	if mmSendOutboxEvents.defaultExpectation.paramPtrs == nil {
+		mmSendOutboxEvents.defaultExpectation.paramPtrs = &IProducerMockSendOutboxEventsParamPtrs{}
 	}
-	mmSendOutboxEvent.defaultExpectation.paramPtrs.event = &event
-	mmSendOutboxEvent.defaultExpectation.expectationOrigins.originEvent = minimock.CallerInfo(1)
+	mmSendOutboxEvents.defaultExpectation.paramPtrs.events = &events
+	mmSendOutboxEvents.defaultExpectation.expectationOrigins.originEvents = minimock.CallerInfo(1)
 
-	return mmSendOutboxEvent
+	return mmSendOutboxEvents
 }
 
-// Inspect accepts an inspector function that has same arguments as the IProducer.SendOutboxEvent
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) Inspect(f func(ctx context.Context, event *models.OutboxEvent)) *mIProducerMockSendOutboxEvent {
-	if mmSendOutboxEvent.mock.inspectFuncSendOutboxEvent != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("Inspect function is already set for IProducerMock.SendOutboxEvent")
+// Inspect accepts an inspector function that has same arguments as the IProducer.SendOutboxEvents
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) Inspect(f func(ctx context.Context, events []models.OutboxEvent)) *mIProducerMockSendOutboxEvents {
+	if mmSendOutboxEvents.mock.inspectFuncSendOutboxEvents != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("Inspect function is already set for IProducerMock.SendOutboxEvents")
 	}
 
-	mmSendOutboxEvent.mock.inspectFuncSendOutboxEvent = f
+	mmSendOutboxEvents.mock.inspectFuncSendOutboxEvents = f
 
-	return mmSendOutboxEvent
+	return mmSendOutboxEvents
 }
 
-// Return sets up results that will be returned by IProducer.SendOutboxEvent
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) Return(err error) *IProducerMock {
-	if mmSendOutboxEvent.mock.funcSendOutboxEvent != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("IProducerMock.SendOutboxEvent mock is already set by Set")
+// Return sets up results that will be returned by IProducer.SendOutboxEvents
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) Return(m1 map[int64]error, err error) *IProducerMock {
+	if mmSendOutboxEvents.mock.funcSendOutboxEvents != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("IProducerMock.SendOutboxEvents mock is already set by Set")
 	}
 
-	if mmSendOutboxEvent.defaultExpectation == nil {
-		mmSendOutboxEvent.defaultExpectation = &IProducerMockSendOutboxEventExpectation{mock: mmSendOutboxEvent.mock}
+	if mmSendOutboxEvents.defaultExpectation == nil {
+		mmSendOutboxEvents.defaultExpectation = &IProducerMockSendOutboxEventsExpectation{mock: mmSendOutboxEvents.mock}
 	}
-	mmSendOutboxEvent.defaultExpectation.results = &IProducerMockSendOutboxEventResults{err}
-	mmSendOutboxEvent.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmSendOutboxEvent.mock
+	mmSendOutboxEvents.defaultExpectation.results = &IProducerMockSendOutboxEventsResults{m1, err}
+	mmSendOutboxEvents.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmSendOutboxEvents.mock
 }
 
-// Set uses given function f to mock the IProducer.SendOutboxEvent method
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) Set(f func(ctx context.Context, event *models.OutboxEvent) (err error)) *IProducerMock {
-	if mmSendOutboxEvent.defaultExpectation != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("Default expectation is already set for the IProducer.SendOutboxEvent method")
+// Set uses given function f to mock the IProducer.SendOutboxEvents method
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) Set(f func(ctx context.Context, events []models.OutboxEvent) (m1 map[int64]error, err error)) *IProducerMock {
+	if mmSendOutboxEvents.defaultExpectation != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("Default expectation is already set for the IProducer.SendOutboxEvents method")
 	}
 
-	if len(mmSendOutboxEvent.expectations) > 0 {
-		mmSendOutboxEvent.mock.t.Fatalf("Some expectations are already set for the IProducer.SendOutboxEvent method")
+	if len(mmSendOutboxEvents.expectations) > 0 {
+		mmSendOutboxEvents.mock.t.Fatalf("Some expectations are already set for the IProducer.SendOutboxEvents method")
 	}
 
-	mmSendOutboxEvent.mock.funcSendOutboxEvent = f
-	mmSendOutboxEvent.mock.funcSendOutboxEventOrigin = minimock.CallerInfo(1)
-	return mmSendOutboxEvent.mock
+	mmSendOutboxEvents.mock.funcSendOutboxEvents = f
+	mmSendOutboxEvents.mock.funcSendOutboxEventsOrigin = minimock.CallerInfo(1)
+	return mmSendOutboxEvents.mock
 }
 
-// When sets expectation for the IProducer.SendOutboxEvent which will trigger the result defined by the following
+// When sets expectation for the IProducer.SendOutboxEvents which will trigger the result defined by the following
 // Then helper
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) When(ctx context.Context, event *models.OutboxEvent) *IProducerMockSendOutboxEventExpectation {
-	if mmSendOutboxEvent.mock.funcSendOutboxEvent != nil {
-		mmSendOutboxEvent.mock.t.Fatalf("IProducerMock.SendOutboxEvent mock is already set by Set")
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) When(ctx context.Context, events []models.OutboxEvent) *IProducerMockSendOutboxEventsExpectation {
+	if mmSendOutboxEvents.mock.funcSendOutboxEvents != nil {
+		mmSendOutboxEvents.mock.t.Fatalf("IProducerMock.SendOutboxEvents mock is already set by Set")
 	}
 
-	expectation := &IProducerMockSendOutboxEventExpectation{
-		mock:               mmSendOutboxEvent.mock,
-		params:             &IProducerMockSendOutboxEventParams{ctx, event},
-		expectationOrigins: IProducerMockSendOutboxEventExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &IProducerMockSendOutboxEventsExpectation{
+		mock:               mmSendOutboxEvents.mock,
+		params:             &IProducerMockSendOutboxEventsParams{ctx, events},
+		expectationOrigins: IProducerMockSendOutboxEventsExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmSendOutboxEvent.expectations = append(mmSendOutboxEvent.expectations, expectation)
+	mmSendOutboxEvents.expectations = append(mmSendOutboxEvents.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IProducer.SendOutboxEvent return parameters for the expectation previously defined by the When method
-func (e *IProducerMockSendOutboxEventExpectation) Then(err error) *IProducerMock {
-	e.results = &IProducerMockSendOutboxEventResults{err}
+// Then sets up IProducer.SendOutboxEvents return parameters for the expectation previously defined by the When method
+func (e *IProducerMockSendOutboxEventsExpectation) Then(m1 map[int64]error, err error) *IProducerMock {
+	e.results = &IProducerMockSendOutboxEventsResults{m1, err}
 	return e.mock
 }
 
-// Times sets number of times IProducer.SendOutboxEvent should be invoked
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) Times(n uint64) *mIProducerMockSendOutboxEvent {
+// Times sets number of times IProducer.SendOutboxEvents should be invoked
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) Times(n uint64) *mIProducerMockSendOutboxEvents {
 	if n == 0 {
-		mmSendOutboxEvent.mock.t.Fatalf("Times of IProducerMock.SendOutboxEvent mock can not be zero")
+		mmSendOutboxEvents.mock.t.Fatalf("Times of IProducerMock.SendOutboxEvents mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmSendOutboxEvent.expectedInvocations, n)
-	mmSendOutboxEvent.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmSendOutboxEvent
+	mm_atomic.StoreUint64(&mmSendOutboxEvents.expectedInvocations, n)
+	mmSendOutboxEvents.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmSendOutboxEvents
 }
 
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) invocationsDone() bool {
-	if len(mmSendOutboxEvent.expectations) == 0 && mmSendOutboxEvent.defaultExpectation == nil && mmSendOutboxEvent.mock.funcSendOutboxEvent == nil {
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) invocationsDone() bool {
+	if len(mmSendOutboxEvents.expectations) == 0 && mmSendOutboxEvents.defaultExpectation == nil && mmSendOutboxEvents.mock.funcSendOutboxEvents == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmSendOutboxEvent.mock.afterSendOutboxEventCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmSendOutboxEvent.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmSendOutboxEvents.mock.afterSendOutboxEventsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSendOutboxEvents.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// SendOutboxEvent implements mm_service.IProducer
-func (mmSendOutboxEvent *IProducerMock) SendOutboxEvent(ctx context.Context, event *models.OutboxEvent) (err error) {
-	mm_atomic.AddUint64(&mmSendOutboxEvent.beforeSendOutboxEventCounter, 1)
-	defer mm_atomic.AddUint64(&mmSendOutboxEvent.afterSendOutboxEventCounter, 1)
+// SendOutboxEvents implements mm_service.IProducer
+func (mmSendOutboxEvents *IProducerMock) SendOutboxEvents(ctx context.Context, events []models.OutboxEvent) (m1 map[int64]error, err error) {
+	mm_atomic.AddUint64(&mmSendOutboxEvents.beforeSendOutboxEventsCounter, 1)
+	defer mm_atomic.AddUint64(&mmSendOutboxEvents.afterSendOutboxEventsCounter, 1)
 
-	mmSendOutboxEvent.t.Helper()
+	mmSendOutboxEvents.t.Helper()
 
-	if mmSendOutboxEvent.inspectFuncSendOutboxEvent != nil {
-		mmSendOutboxEvent.inspectFuncSendOutboxEvent(ctx, event)
+	if mmSendOutboxEvents.inspectFuncSendOutboxEvents != nil {
+		mmSendOutboxEvents.inspectFuncSendOutboxEvents(ctx, events)
 	}
 
-	mm_params := IProducerMockSendOutboxEventParams{ctx, event}
+	mm_params := IProducerMockSendOutboxEventsParams{ctx, events}
 
 	// Record call args
-	mmSendOutboxEvent.SendOutboxEventMock.mutex.Lock()
-	mmSendOutboxEvent.SendOutboxEventMock.callArgs = append(mmSendOutboxEvent.SendOutboxEventMock.callArgs, &mm_params)
-	mmSendOutboxEvent.SendOutboxEventMock.mutex.Unlock()
+	mmSendOutboxEvents.SendOutboxEventsMock.mutex.Lock()
+	mmSendOutboxEvents.SendOutboxEventsMock.callArgs = append(mmSendOutboxEvents.SendOutboxEventsMock.callArgs, &mm_params)
+	mmSendOutboxEvents.SendOutboxEventsMock.mutex.Unlock()
 
-	for _, e := range mmSendOutboxEvent.SendOutboxEventMock.expectations {
+	for _, e := range mmSendOutboxEvents.SendOutboxEventsMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.m1, e.results.err
 		}
 	}
 
-	if mmSendOutboxEvent.SendOutboxEventMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmSendOutboxEvent.SendOutboxEventMock.defaultExpectation.Counter, 1)
-		mm_want := mmSendOutboxEvent.SendOutboxEventMock.defaultExpectation.params
-		mm_want_ptrs := mmSendOutboxEvent.SendOutboxEventMock.defaultExpectation.paramPtrs
+	if mmSendOutboxEvents.SendOutboxEventsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSendOutboxEvents.SendOutboxEventsMock.defaultExpectation.Counter, 1)
+		mm_want := mmSendOutboxEvents.SendOutboxEventsMock.defaultExpectation.params
+		mm_want_ptrs := mmSendOutboxEvents.SendOutboxEventsMock.defaultExpectation.paramPtrs
 
-		mm_got := IProducerMockSendOutboxEventParams{ctx, event}
+		mm_got := IProducerMockSendOutboxEventsParams{ctx, events}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmSendOutboxEvent.t.Errorf("IProducerMock.SendOutboxEvent got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmSendOutboxEvent.SendOutboxEventMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmSendOutboxEvents.t.Errorf("IProducerMock.SendOutboxEvents got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSendOutboxEvents.SendOutboxEventsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.event != nil && !minimock.Equal(*mm_want_ptrs.event, mm_got.event) {
-				mmSendOutboxEvent.t.Errorf("IProducerMock.SendOutboxEvent got unexpected parameter event, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmSendOutboxEvent.SendOutboxEventMock.defaultExpectation.expectationOrigins.originEvent, *mm_want_ptrs.event, mm_got.event, minimock.Diff(*mm_want_ptrs.event, mm_got.event))
+			if mm_want_ptrs.events != nil && !minimock.Equal(*mm_want_ptrs.events, mm_got.events) {
+				mmSendOutboxEvents.t.Errorf("IProducerMock.SendOutboxEvents got unexpected parameter events, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSendOutboxEvents.SendOutboxEventsMock.defaultExpectation.expectationOrigins.originEvents, *mm_want_ptrs.events, mm_got.events, minimock.Diff(*mm_want_ptrs.events, mm_got.events))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmSendOutboxEvent.t.Errorf("IProducerMock.SendOutboxEvent got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmSendOutboxEvent.SendOutboxEventMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmSendOutboxEvents.t.Errorf("IProducerMock.SendOutboxEvents got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmSendOutboxEvents.SendOutboxEventsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmSendOutboxEvent.SendOutboxEventMock.defaultExpectation.results
+		mm_results := mmSendOutboxEvents.SendOutboxEventsMock.defaultExpectation.results
 		if mm_results == nil {
-			mmSendOutboxEvent.t.Fatal("No results are set for the IProducerMock.SendOutboxEvent")
+			mmSendOutboxEvents.t.Fatal("No results are set for the IProducerMock.SendOutboxEvents")
 		}
-		return (*mm_results).err
+		return (*mm_results).m1, (*mm_results).err
 	}
-	if mmSendOutboxEvent.funcSendOutboxEvent != nil {
-		return mmSendOutboxEvent.funcSendOutboxEvent(ctx, event)
+	if mmSendOutboxEvents.funcSendOutboxEvents != nil {
+		return mmSendOutboxEvents.funcSendOutboxEvents(ctx, events)
 	}
-	mmSendOutboxEvent.t.Fatalf("Unexpected call to IProducerMock.SendOutboxEvent. %v %v", ctx, event)
+	mmSendOutboxEvents.t.Fatalf("Unexpected call to IProducerMock.SendOutboxEvents. %v %v", ctx, events)
 	return
 }
 
-// SendOutboxEventAfterCounter returns a count of finished IProducerMock.SendOutboxEvent invocations
-func (mmSendOutboxEvent *IProducerMock) SendOutboxEventAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmSendOutboxEvent.afterSendOutboxEventCounter)
+// SendOutboxEventsAfterCounter returns a count of finished IProducerMock.SendOutboxEvents invocations
+func (mmSendOutboxEvents *IProducerMock) SendOutboxEventsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSendOutboxEvents.afterSendOutboxEventsCounter)
 }
 
-// SendOutboxEventBeforeCounter returns a count of IProducerMock.SendOutboxEvent invocations
-func (mmSendOutboxEvent *IProducerMock) SendOutboxEventBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmSendOutboxEvent.beforeSendOutboxEventCounter)
+// SendOutboxEventsBeforeCounter returns a count of IProducerMock.SendOutboxEvents invocations
+func (mmSendOutboxEvents *IProducerMock) SendOutboxEventsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSendOutboxEvents.beforeSendOutboxEventsCounter)
 }
 
-// Calls returns a list of arguments used in each call to IProducerMock.SendOutboxEvent.
+// Calls returns a list of arguments used in each call to IProducerMock.SendOutboxEvents.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmSendOutboxEvent *mIProducerMockSendOutboxEvent) Calls() []*IProducerMockSendOutboxEventParams {
-	mmSendOutboxEvent.mutex.RLock()
+func (mmSendOutboxEvents *mIProducerMockSendOutboxEvents) Calls() []*IProducerMockSendOutboxEventsParams {
+	mmSendOutboxEvents.mutex.RLock()
 
-	argCopy := make([]*IProducerMockSendOutboxEventParams, len(mmSendOutboxEvent.callArgs))
-	copy(argCopy, mmSendOutboxEvent.callArgs)
+	argCopy := make([]*IProducerMockSendOutboxEventsParams, len(mmSendOutboxEvents.callArgs))
+	copy(argCopy, mmSendOutboxEvents.callArgs)
 
-	mmSendOutboxEvent.mutex.RUnlock()
+	mmSendOutboxEvents.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockSendOutboxEventDone returns true if the count of the SendOutboxEvent invocations corresponds
+// MinimockSendOutboxEventsDone returns true if the count of the SendOutboxEvents invocations corresponds
 // the number of defined expectations
-func (m *IProducerMock) MinimockSendOutboxEventDone() bool {
-	if m.SendOutboxEventMock.optional {
+func (m *IProducerMock) MinimockSendOutboxEventsDone() bool {
+	if m.SendOutboxEventsMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.SendOutboxEventMock.expectations {
+	for _, e := range m.SendOutboxEventsMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.SendOutboxEventMock.invocationsDone()
+	return m.SendOutboxEventsMock.invocationsDone()
 }
 
-// MinimockSendOutboxEventInspect logs each unmet expectation
-func (m *IProducerMock) MinimockSendOutboxEventInspect() {
-	for _, e := range m.SendOutboxEventMock.expectations {
+// MinimockSendOutboxEventsInspect logs each unmet expectation
+func (m *IProducerMock) MinimockSendOutboxEventsInspect() {
+	for _, e := range m.SendOutboxEventsMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IProducerMock.SendOutboxEvent at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to IProducerMock.SendOutboxEvents at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterSendOutboxEventCounter := mm_atomic.LoadUint64(&m.afterSendOutboxEventCounter)
+	afterSendOutboxEventsCounter := mm_atomic.LoadUint64(&m.afterSendOutboxEventsCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.SendOutboxEventMock.defaultExpectation != nil && afterSendOutboxEventCounter < 1 {
-		if m.SendOutboxEventMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to IProducerMock.SendOutboxEvent at\n%s", m.SendOutboxEventMock.defaultExpectation.returnOrigin)
+	if m.SendOutboxEventsMock.defaultExpectation != nil && afterSendOutboxEventsCounter < 1 {
+		if m.SendOutboxEventsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IProducerMock.SendOutboxEvents at\n%s", m.SendOutboxEventsMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to IProducerMock.SendOutboxEvent at\n%s with params: %#v", m.SendOutboxEventMock.defaultExpectation.expectationOrigins.origin, *m.SendOutboxEventMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IProducerMock.SendOutboxEvents at\n%s with params: %#v", m.SendOutboxEventsMock.defaultExpectation.expectationOrigins.origin, *m.SendOutboxEventsMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcSendOutboxEvent != nil && afterSendOutboxEventCounter < 1 {
-		m.t.Errorf("Expected call to IProducerMock.SendOutboxEvent at\n%s", m.funcSendOutboxEventOrigin)
+	if m.funcSendOutboxEvents != nil && afterSendOutboxEventsCounter < 1 {
+		m.t.Errorf("Expected call to IProducerMock.SendOutboxEvents at\n%s", m.funcSendOutboxEventsOrigin)
 	}
 
-	if !m.SendOutboxEventMock.invocationsDone() && afterSendOutboxEventCounter > 0 {
-		m.t.Errorf("Expected %d calls to IProducerMock.SendOutboxEvent at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.SendOutboxEventMock.expectedInvocations), m.SendOutboxEventMock.expectedInvocationsOrigin, afterSendOutboxEventCounter)
+	if !m.SendOutboxEventsMock.invocationsDone() && afterSendOutboxEventsCounter > 0 {
+		m.t.Errorf("Expected %d calls to IProducerMock.SendOutboxEvents at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.SendOutboxEventsMock.expectedInvocations), m.SendOutboxEventsMock.expectedInvocationsOrigin, afterSendOutboxEventsCounter)
 	}
 }
 
@@ -389,7 +390,7 @@ func (m *IProducerMock) MinimockSendOutboxEventInspect() {
 func (m *IProducerMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
-			m.MinimockSendOutboxEventInspect()
+			m.MinimockSendOutboxEventsInspect()
 		}
 	})
 }
@@ -413,5 +414,5 @@ func (m *IProducerMock) MinimockWait(timeout mm_time.Duration) {
 func (m *IProducerMock) minimockDone() bool {
 	done := true
 	return done &&
-		m.MinimockSendOutboxEventDone()
+		m.MinimockSendOutboxEventsDone()
 }
@@ -0,0 +1,1641 @@
+// Code generated by http://github.com/gojuno/minimock (v3.4.0). DO NOT EDIT.
+
+package mock
+
+//go:generate minimock -i route256/loms/internal/service/loms.ISagaRepository -o saga_repository_mock.go -n ISagaRepositoryMock -p mock
+
+import (
+	"context"
+	"route256/loms/internal/models"
+	"sync"
+	mm_atomic "sync/atomic"
+	"time"
+	mm_time "time"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/jackc/pgx/v5"
+)
+
+// ISagaRepositoryMock implements mm_service.ISagaRepository
+type ISagaRepositoryMock struct {
+	t          minimock.Tester
+	finishOnce sync.Once
+
+	funcCreate          func(ctx context.Context, tx pgx.Tx, saga models.Saga) (i1 int64, err error)
+	funcCreateOrigin    string
+	inspectFuncCreate   func(ctx context.Context, tx pgx.Tx, saga models.Saga)
+	afterCreateCounter  uint64
+	beforeCreateCounter uint64
+	CreateMock          mISagaRepositoryMockCreate
+
+	funcGetStarted          func(ctx context.Context, updatedBefore time.Time, limit uint32) (sa1 []models.Saga, err error)
+	funcGetStartedOrigin    string
+	inspectFuncGetStarted   func(ctx context.Context, updatedBefore time.Time, limit uint32)
+	afterGetStartedCounter  uint64
+	beforeGetStartedCounter uint64
+	GetStartedMock          mISagaRepositoryMockGetStarted
+
+	funcRecordAttempt          func(ctx context.Context, sagaID int64, lastErr error) (err error)
+	funcRecordAttemptOrigin    string
+	inspectFuncRecordAttempt   func(ctx context.Context, sagaID int64, lastErr error)
+	afterRecordAttemptCounter  uint64
+	beforeRecordAttemptCounter uint64
+	RecordAttemptMock          mISagaRepositoryMockRecordAttempt
+
+	funcSetState          func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) (err error)
+	funcSetStateOrigin    string
+	inspectFuncSetState   func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState)
+	afterSetStateCounter  uint64
+	beforeSetStateCounter uint64
+	SetStateMock          mISagaRepositoryMockSetState
+}
+
+// NewISagaRepositoryMock returns a mock for mm_service.ISagaRepository
+func NewISagaRepositoryMock(t minimock.Tester) *ISagaRepositoryMock {
+	m := &ISagaRepositoryMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.CreateMock = mISagaRepositoryMockCreate{mock: m}
+	m.CreateMock.callArgs = []*ISagaRepositoryMockCreateParams{}
+
+	m.GetStartedMock = mISagaRepositoryMockGetStarted{mock: m}
+	m.GetStartedMock.callArgs = []*ISagaRepositoryMockGetStartedParams{}
+
+	m.RecordAttemptMock = mISagaRepositoryMockRecordAttempt{mock: m}
+	m.RecordAttemptMock.callArgs = []*ISagaRepositoryMockRecordAttemptParams{}
+
+	m.SetStateMock = mISagaRepositoryMockSetState{mock: m}
+	m.SetStateMock.callArgs = []*ISagaRepositoryMockSetStateParams{}
+
+	t.Cleanup(m.MinimockFinish)
+
+	return m
+}
+
+type mISagaRepositoryMockCreate struct {
+	optional           bool
+	mock               *ISagaRepositoryMock
+	defaultExpectation *ISagaRepositoryMockCreateExpectation
+	expectations       []*ISagaRepositoryMockCreateExpectation
+
+	callArgs []*ISagaRepositoryMockCreateParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ISagaRepositoryMockCreateExpectation specifies expectation struct of the ISagaRepository.Create
+type ISagaRepositoryMockCreateExpectation struct {
+	mock               *ISagaRepositoryMock
+	params             *ISagaRepositoryMockCreateParams
+	paramPtrs          *ISagaRepositoryMockCreateParamPtrs
+	expectationOrigins ISagaRepositoryMockCreateExpectationOrigins
+	results            *ISagaRepositoryMockCreateResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ISagaRepositoryMockCreateParams contains parameters of the ISagaRepository.Create
+type ISagaRepositoryMockCreateParams struct {
+	ctx  context.Context
+	tx   pgx.Tx
+	saga models.Saga
+}
+
+// ISagaRepositoryMockCreateParamPtrs contains pointers to parameters of the ISagaRepository.Create
+type ISagaRepositoryMockCreateParamPtrs struct {
+	ctx  *context.Context
+	tx   *pgx.Tx
+	saga *models.Saga
+}
+
+// ISagaRepositoryMockCreateResults contains results of the ISagaRepository.Create
+type ISagaRepositoryMockCreateResults struct {
+	i1  int64
+	err error
+}
+
+// ISagaRepositoryMockCreateOrigins contains origins of expectations of the ISagaRepository.Create
+type ISagaRepositoryMockCreateExpectationOrigins struct {
+	origin     string
+	originCtx  string
+	originTx   string
+	originSaga string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmCreate *mISagaRepositoryMockCreate) Optional() *mISagaRepositoryMockCreate {
+	mmCreate.optional = true
+	return mmCreate
+}
+
+// Expect sets up expected params for ISagaRepository.Create
+func (mmCreate *mISagaRepositoryMockCreate) Expect(ctx context.Context, tx pgx.Tx, saga models.Saga) *mISagaRepositoryMockCreate {
+	if mmCreate.mock.funcCreate != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Set")
+	}
+
+	if mmCreate.defaultExpectation == nil {
+		mmCreate.defaultExpectation = &ISagaRepositoryMockCreateExpectation{}
+	}
+
+	if mmCreate.defaultExpectation.paramPtrs != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by ExpectParams functions")
+	}
+
+	mmCreate.defaultExpectation.params = &ISagaRepositoryMockCreateParams{ctx, tx, saga}
+	mmCreate.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmCreate.expectations {
+		if minimock.Equal(e.params, mmCreate.defaultExpectation.params) {
+			mmCreate.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreate.defaultExpectation.params)
+		}
+	}
+
+	return mmCreate
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ISagaRepository.Create
+func (mmCreate *mISagaRepositoryMockCreate) ExpectCtxParam1(ctx context.Context) *mISagaRepositoryMockCreate {
+	if mmCreate.mock.funcCreate != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Set")
+	}
+
+	if mmCreate.defaultExpectation == nil {
+		mmCreate.defaultExpectation = &ISagaRepositoryMockCreateExpectation{}
+	}
+
+	if mmCreate.defaultExpectation.params != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Expect")
+	}
+
+	if mmCreate.defaultExpectation.paramPtrs == nil {
+		mmCreate.defaultExpectation.paramPtrs = &ISagaRepositoryMockCreateParamPtrs{}
+	}
+	mmCreate.defaultExpectation.paramPtrs.ctx = &ctx
+	mmCreate.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmCreate
+}
+
+// ExpectTxParam2 sets up expected param tx for ISagaRepository.Create
+func (mmCreate *mISagaRepositoryMockCreate) ExpectTxParam2(tx pgx.Tx) *mISagaRepositoryMockCreate {
+	if mmCreate.mock.funcCreate != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Set")
+	}
+
+	if mmCreate.defaultExpectation == nil {
+		mmCreate.defaultExpectation = &ISagaRepositoryMockCreateExpectation{}
+	}
+
+	if mmCreate.defaultExpectation.params != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Expect")
+	}
+
+	if mmCreate.defaultExpectation.paramPtrs == nil {
+		mmCreate.defaultExpectation.paramPtrs = &ISagaRepositoryMockCreateParamPtrs{}
+	}
+	mmCreate.defaultExpectation.paramPtrs.tx = &tx
+	mmCreate.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmCreate
+}
+
+// ExpectSagaParam3 sets up expected param saga for ISagaRepository.Create
+func (mmCreate *mISagaRepositoryMockCreate) ExpectSagaParam3(saga models.Saga) *mISagaRepositoryMockCreate {
+	if mmCreate.mock.funcCreate != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Set")
+	}
+
+	if mmCreate.defaultExpectation == nil {
+		mmCreate.defaultExpectation = &ISagaRepositoryMockCreateExpectation{}
+	}
+
+	if mmCreate.defaultExpectation.params != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Expect")
+	}
+
+	if mmCreate.defaultExpectation.paramPtrs == nil {
+		mmCreate.defaultExpectation.paramPtrs = &ISagaRepositoryMockCreateParamPtrs{}
+	}
+	mmCreate.defaultExpectation.paramPtrs.saga = &saga
+	mmCreate.defaultExpectation.expectationOrigins.originSaga = minimock.CallerInfo(1)
+
+	return mmCreate
+}
+
+// Inspect accepts an inspector function that has same arguments as the ISagaRepository.Create
+func (mmCreate *mISagaRepositoryMockCreate) Inspect(f func(ctx context.Context, tx pgx.Tx, saga models.Saga)) *mISagaRepositoryMockCreate {
+	if mmCreate.mock.inspectFuncCreate != nil {
+		mmCreate.mock.t.Fatalf("Inspect function is already set for ISagaRepositoryMock.Create")
+	}
+
+	mmCreate.mock.inspectFuncCreate = f
+
+	return mmCreate
+}
+
+// Return sets up results that will be returned by ISagaRepository.Create
+func (mmCreate *mISagaRepositoryMockCreate) Return(i1 int64, err error) *ISagaRepositoryMock {
+	if mmCreate.mock.funcCreate != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Set")
+	}
+
+	if mmCreate.defaultExpectation == nil {
+		mmCreate.defaultExpectation = &ISagaRepositoryMockCreateExpectation{mock: mmCreate.mock}
+	}
+	mmCreate.defaultExpectation.results = &ISagaRepositoryMockCreateResults{i1, err}
+	mmCreate.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmCreate.mock
+}
+
+// Set uses given function f to mock the ISagaRepository.Create method
+func (mmCreate *mISagaRepositoryMockCreate) Set(f func(ctx context.Context, tx pgx.Tx, saga models.Saga) (i1 int64, err error)) *ISagaRepositoryMock {
+	if mmCreate.defaultExpectation != nil {
+		mmCreate.mock.t.Fatalf("Default expectation is already set for the ISagaRepository.Create method")
+	}
+
+	if len(mmCreate.expectations) > 0 {
+		mmCreate.mock.t.Fatalf("Some expectations are already set for the ISagaRepository.Create method")
+	}
+
+	mmCreate.mock.funcCreate = f
+	mmCreate.mock.funcCreateOrigin = minimock.CallerInfo(1)
+	return mmCreate.mock
+}
+
+// When sets expectation for the ISagaRepository.Create which will trigger the result defined by the following
+// Then helper
+func (mmCreate *mISagaRepositoryMockCreate) When(ctx context.Context, tx pgx.Tx, saga models.Saga) *ISagaRepositoryMockCreateExpectation {
+	if mmCreate.mock.funcCreate != nil {
+		mmCreate.mock.t.Fatalf("ISagaRepositoryMock.Create mock is already set by Set")
+	}
+
+	expectation := &ISagaRepositoryMockCreateExpectation{
+		mock:               mmCreate.mock,
+		params:             &ISagaRepositoryMockCreateParams{ctx, tx, saga},
+		expectationOrigins: ISagaRepositoryMockCreateExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmCreate.expectations = append(mmCreate.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ISagaRepository.Create return parameters for the expectation previously defined by the When method
+func (e *ISagaRepositoryMockCreateExpectation) Then(i1 int64, err error) *ISagaRepositoryMock {
+	e.results = &ISagaRepositoryMockCreateResults{i1, err}
+	return e.mock
+}
+
+// Times sets number of times ISagaRepository.Create should be invoked
+func (mmCreate *mISagaRepositoryMockCreate) Times(n uint64) *mISagaRepositoryMockCreate {
+	if n == 0 {
+		mmCreate.mock.t.Fatalf("Times of ISagaRepositoryMock.Create mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCreate.expectedInvocations, n)
+	mmCreate.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmCreate
+}
+
+func (mmCreate *mISagaRepositoryMockCreate) invocationsDone() bool {
+	if len(mmCreate.expectations) == 0 && mmCreate.defaultExpectation == nil && mmCreate.mock.funcCreate == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCreate.mock.afterCreateCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCreate.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Create implements mm_service.ISagaRepository
+func (mmCreate *ISagaRepositoryMock) Create(ctx context.Context, tx pgx.Tx, saga models.Saga) (i1 int64, err error) {
+	mm_atomic.AddUint64(&mmCreate.beforeCreateCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreate.afterCreateCounter, 1)
+
+	mmCreate.t.Helper()
+
+	if mmCreate.inspectFuncCreate != nil {
+		mmCreate.inspectFuncCreate(ctx, tx, saga)
+	}
+
+	mm_params := ISagaRepositoryMockCreateParams{ctx, tx, saga}
+
+	// Record call args
+	mmCreate.CreateMock.mutex.Lock()
+	mmCreate.CreateMock.callArgs = append(mmCreate.CreateMock.callArgs, &mm_params)
+	mmCreate.CreateMock.mutex.Unlock()
+
+	for _, e := range mmCreate.CreateMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.i1, e.results.err
+		}
+	}
+
+	if mmCreate.CreateMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreate.CreateMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreate.CreateMock.defaultExpectation.params
+		mm_want_ptrs := mmCreate.CreateMock.defaultExpectation.paramPtrs
+
+		mm_got := ISagaRepositoryMockCreateParams{ctx, tx, saga}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCreate.t.Errorf("ISagaRepositoryMock.Create got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreate.CreateMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmCreate.t.Errorf("ISagaRepositoryMock.Create got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreate.CreateMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.saga != nil && !minimock.Equal(*mm_want_ptrs.saga, mm_got.saga) {
+				mmCreate.t.Errorf("ISagaRepositoryMock.Create got unexpected parameter saga, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreate.CreateMock.defaultExpectation.expectationOrigins.originSaga, *mm_want_ptrs.saga, mm_got.saga, minimock.Diff(*mm_want_ptrs.saga, mm_got.saga))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCreate.t.Errorf("ISagaRepositoryMock.Create got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmCreate.CreateMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCreate.CreateMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCreate.t.Fatal("No results are set for the ISagaRepositoryMock.Create")
+		}
+		return (*mm_results).i1, (*mm_results).err
+	}
+	if mmCreate.funcCreate != nil {
+		return mmCreate.funcCreate(ctx, tx, saga)
+	}
+	mmCreate.t.Fatalf("Unexpected call to ISagaRepositoryMock.Create. %v %v %v", ctx, tx, saga)
+	return
+}
+
+// CreateAfterCounter returns a count of finished ISagaRepositoryMock.Create invocations
+func (mmCreate *ISagaRepositoryMock) CreateAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreate.afterCreateCounter)
+}
+
+// CreateBeforeCounter returns a count of ISagaRepositoryMock.Create invocations
+func (mmCreate *ISagaRepositoryMock) CreateBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreate.beforeCreateCounter)
+}
+
+// Calls returns a list of arguments used in each call to ISagaRepositoryMock.Create.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCreate *mISagaRepositoryMockCreate) Calls() []*ISagaRepositoryMockCreateParams {
+	mmCreate.mutex.RLock()
+
+	argCopy := make([]*ISagaRepositoryMockCreateParams, len(mmCreate.callArgs))
+	copy(argCopy, mmCreate.callArgs)
+
+	mmCreate.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCreateDone returns true if the count of the Create invocations corresponds
+// the number of defined expectations
+func (m *ISagaRepositoryMock) MinimockCreateDone() bool {
+	if m.CreateMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.CreateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CreateMock.invocationsDone()
+}
+
+// MinimockCreateInspect logs each unmet expectation
+func (m *ISagaRepositoryMock) MinimockCreateInspect() {
+	for _, e := range m.CreateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.Create at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterCreateCounter := mm_atomic.LoadUint64(&m.afterCreateCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CreateMock.defaultExpectation != nil && afterCreateCounter < 1 {
+		if m.CreateMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.Create at\n%s", m.CreateMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.Create at\n%s with params: %#v", m.CreateMock.defaultExpectation.expectationOrigins.origin, *m.CreateMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCreate != nil && afterCreateCounter < 1 {
+		m.t.Errorf("Expected call to ISagaRepositoryMock.Create at\n%s", m.funcCreateOrigin)
+	}
+
+	if !m.CreateMock.invocationsDone() && afterCreateCounter > 0 {
+		m.t.Errorf("Expected %d calls to ISagaRepositoryMock.Create at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.CreateMock.expectedInvocations), m.CreateMock.expectedInvocationsOrigin, afterCreateCounter)
+	}
+}
+
+type mISagaRepositoryMockGetStarted struct {
+	optional           bool
+	mock               *ISagaRepositoryMock
+	defaultExpectation *ISagaRepositoryMockGetStartedExpectation
+	expectations       []*ISagaRepositoryMockGetStartedExpectation
+
+	callArgs []*ISagaRepositoryMockGetStartedParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ISagaRepositoryMockGetStartedExpectation specifies expectation struct of the ISagaRepository.GetStarted
+type ISagaRepositoryMockGetStartedExpectation struct {
+	mock               *ISagaRepositoryMock
+	params             *ISagaRepositoryMockGetStartedParams
+	paramPtrs          *ISagaRepositoryMockGetStartedParamPtrs
+	expectationOrigins ISagaRepositoryMockGetStartedExpectationOrigins
+	results            *ISagaRepositoryMockGetStartedResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ISagaRepositoryMockGetStartedParams contains parameters of the ISagaRepository.GetStarted
+type ISagaRepositoryMockGetStartedParams struct {
+	ctx           context.Context
+	updatedBefore time.Time
+	limit         uint32
+}
+
+// ISagaRepositoryMockGetStartedParamPtrs contains pointers to parameters of the ISagaRepository.GetStarted
+type ISagaRepositoryMockGetStartedParamPtrs struct {
+	ctx           *context.Context
+	updatedBefore *time.Time
+	limit         *uint32
+}
+
+// ISagaRepositoryMockGetStartedResults contains results of the ISagaRepository.GetStarted
+type ISagaRepositoryMockGetStartedResults struct {
+	sa1 []models.Saga
+	err error
+}
+
+// ISagaRepositoryMockGetStartedOrigins contains origins of expectations of the ISagaRepository.GetStarted
+type ISagaRepositoryMockGetStartedExpectationOrigins struct {
+	origin              string
+	originCtx           string
+	originUpdatedBefore string
+	originLimit         string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetStarted *mISagaRepositoryMockGetStarted) Optional() *mISagaRepositoryMockGetStarted {
+	mmGetStarted.optional = true
+	return mmGetStarted
+}
+
+// Expect sets up expected params for ISagaRepository.GetStarted
+func (mmGetStarted *mISagaRepositoryMockGetStarted) Expect(ctx context.Context, updatedBefore time.Time, limit uint32) *mISagaRepositoryMockGetStarted {
+	if mmGetStarted.mock.funcGetStarted != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Set")
+	}
+
+	if mmGetStarted.defaultExpectation == nil {
+		mmGetStarted.defaultExpectation = &ISagaRepositoryMockGetStartedExpectation{}
+	}
+
+	if mmGetStarted.defaultExpectation.paramPtrs != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by ExpectParams functions")
+	}
+
+	mmGetStarted.defaultExpectation.params = &ISagaRepositoryMockGetStartedParams{ctx, updatedBefore, limit}
+	mmGetStarted.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetStarted.expectations {
+		if minimock.Equal(e.params, mmGetStarted.defaultExpectation.params) {
+			mmGetStarted.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStarted.defaultExpectation.params)
+		}
+	}
+
+	return mmGetStarted
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ISagaRepository.GetStarted
+func (mmGetStarted *mISagaRepositoryMockGetStarted) ExpectCtxParam1(ctx context.Context) *mISagaRepositoryMockGetStarted {
+	if mmGetStarted.mock.funcGetStarted != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Set")
+	}
+
+	if mmGetStarted.defaultExpectation == nil {
+		mmGetStarted.defaultExpectation = &ISagaRepositoryMockGetStartedExpectation{}
+	}
+
+	if mmGetStarted.defaultExpectation.params != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Expect")
+	}
+
+	if mmGetStarted.defaultExpectation.paramPtrs == nil {
+		mmGetStarted.defaultExpectation.paramPtrs = &ISagaRepositoryMockGetStartedParamPtrs{}
+	}
+	mmGetStarted.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetStarted.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetStarted
+}
+
+// ExpectUpdatedBeforeParam2 sets up expected param updatedBefore for ISagaRepository.GetStarted
+func (mmGetStarted *mISagaRepositoryMockGetStarted) ExpectUpdatedBeforeParam2(updatedBefore time.Time) *mISagaRepositoryMockGetStarted {
+	if mmGetStarted.mock.funcGetStarted != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Set")
+	}
+
+	if mmGetStarted.defaultExpectation == nil {
+		mmGetStarted.defaultExpectation = &ISagaRepositoryMockGetStartedExpectation{}
+	}
+
+	if mmGetStarted.defaultExpectation.params != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Expect")
+	}
+
+	if mmGetStarted.defaultExpectation.paramPtrs == nil {
+		mmGetStarted.defaultExpectation.paramPtrs = &ISagaRepositoryMockGetStartedParamPtrs{}
+	}
+	mmGetStarted.defaultExpectation.paramPtrs.updatedBefore = &updatedBefore
+	mmGetStarted.defaultExpectation.expectationOrigins.originUpdatedBefore = minimock.CallerInfo(1)
+
+	return mmGetStarted
+}
+
+// ExpectLimitParam3 sets up expected param limit for ISagaRepository.GetStarted
+func (mmGetStarted *mISagaRepositoryMockGetStarted) ExpectLimitParam3(limit uint32) *mISagaRepositoryMockGetStarted {
+	if mmGetStarted.mock.funcGetStarted != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Set")
+	}
+
+	if mmGetStarted.defaultExpectation == nil {
+		mmGetStarted.defaultExpectation = &ISagaRepositoryMockGetStartedExpectation{}
+	}
+
+	if mmGetStarted.defaultExpectation.params != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Expect")
+	}
+
+	if mmGetStarted.defaultExpectation.paramPtrs == nil {
+		mmGetStarted.defaultExpectation.paramPtrs = &ISagaRepositoryMockGetStartedParamPtrs{}
+	}
+	mmGetStarted.defaultExpectation.paramPtrs.limit = &limit
+	mmGetStarted.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmGetStarted
+}
+
+// Inspect accepts an inspector function that has same arguments as the ISagaRepository.GetStarted
+func (mmGetStarted *mISagaRepositoryMockGetStarted) Inspect(f func(ctx context.Context, updatedBefore time.Time, limit uint32)) *mISagaRepositoryMockGetStarted {
+	if mmGetStarted.mock.inspectFuncGetStarted != nil {
+		mmGetStarted.mock.t.Fatalf("Inspect function is already set for ISagaRepositoryMock.GetStarted")
+	}
+
+	mmGetStarted.mock.inspectFuncGetStarted = f
+
+	return mmGetStarted
+}
+
+// Return sets up results that will be returned by ISagaRepository.GetStarted
+func (mmGetStarted *mISagaRepositoryMockGetStarted) Return(sa1 []models.Saga, err error) *ISagaRepositoryMock {
+	if mmGetStarted.mock.funcGetStarted != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Set")
+	}
+
+	if mmGetStarted.defaultExpectation == nil {
+		mmGetStarted.defaultExpectation = &ISagaRepositoryMockGetStartedExpectation{mock: mmGetStarted.mock}
+	}
+	mmGetStarted.defaultExpectation.results = &ISagaRepositoryMockGetStartedResults{sa1, err}
+	mmGetStarted.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetStarted.mock
+}
+
+// Set uses given function f to mock the ISagaRepository.GetStarted method
+func (mmGetStarted *mISagaRepositoryMockGetStarted) Set(f func(ctx context.Context, updatedBefore time.Time, limit uint32) (sa1 []models.Saga, err error)) *ISagaRepositoryMock {
+	if mmGetStarted.defaultExpectation != nil {
+		mmGetStarted.mock.t.Fatalf("Default expectation is already set for the ISagaRepository.GetStarted method")
+	}
+
+	if len(mmGetStarted.expectations) > 0 {
+		mmGetStarted.mock.t.Fatalf("Some expectations are already set for the ISagaRepository.GetStarted method")
+	}
+
+	mmGetStarted.mock.funcGetStarted = f
+	mmGetStarted.mock.funcGetStartedOrigin = minimock.CallerInfo(1)
+	return mmGetStarted.mock
+}
+
+// When sets expectation for the ISagaRepository.GetStarted which will trigger the result defined by the following
+// Then helper
+func (mmGetStarted *mISagaRepositoryMockGetStarted) When(ctx context.Context, updatedBefore time.Time, limit uint32) *ISagaRepositoryMockGetStartedExpectation {
+	if mmGetStarted.mock.funcGetStarted != nil {
+		mmGetStarted.mock.t.Fatalf("ISagaRepositoryMock.GetStarted mock is already set by Set")
+	}
+
+	expectation := &ISagaRepositoryMockGetStartedExpectation{
+		mock:               mmGetStarted.mock,
+		params:             &ISagaRepositoryMockGetStartedParams{ctx, updatedBefore, limit},
+		expectationOrigins: ISagaRepositoryMockGetStartedExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetStarted.expectations = append(mmGetStarted.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ISagaRepository.GetStarted return parameters for the expectation previously defined by the When method
+func (e *ISagaRepositoryMockGetStartedExpectation) Then(sa1 []models.Saga, err error) *ISagaRepositoryMock {
+	e.results = &ISagaRepositoryMockGetStartedResults{sa1, err}
+	return e.mock
+}
+
+// Times sets number of times ISagaRepository.GetStarted should be invoked
+func (mmGetStarted *mISagaRepositoryMockGetStarted) Times(n uint64) *mISagaRepositoryMockGetStarted {
+	if n == 0 {
+		mmGetStarted.mock.t.Fatalf("Times of ISagaRepositoryMock.GetStarted mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetStarted.expectedInvocations, n)
+	mmGetStarted.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetStarted
+}
+
+func (mmGetStarted *mISagaRepositoryMockGetStarted) invocationsDone() bool {
+	if len(mmGetStarted.expectations) == 0 && mmGetStarted.defaultExpectation == nil && mmGetStarted.mock.funcGetStarted == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetStarted.mock.afterGetStartedCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetStarted.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetStarted implements mm_service.ISagaRepository
+func (mmGetStarted *ISagaRepositoryMock) GetStarted(ctx context.Context, updatedBefore time.Time, limit uint32) (sa1 []models.Saga, err error) {
+	mm_atomic.AddUint64(&mmGetStarted.beforeGetStartedCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetStarted.afterGetStartedCounter, 1)
+
+	mmGetStarted.t.Helper()
+
+	if mmGetStarted.inspectFuncGetStarted != nil {
+		mmGetStarted.inspectFuncGetStarted(ctx, updatedBefore, limit)
+	}
+
+	mm_params := ISagaRepositoryMockGetStartedParams{ctx, updatedBefore, limit}
+
+	// Record call args
+	mmGetStarted.GetStartedMock.mutex.Lock()
+	mmGetStarted.GetStartedMock.callArgs = append(mmGetStarted.GetStartedMock.callArgs, &mm_params)
+	mmGetStarted.GetStartedMock.mutex.Unlock()
+
+	for _, e := range mmGetStarted.GetStartedMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sa1, e.results.err
+		}
+	}
+
+	if mmGetStarted.GetStartedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetStarted.GetStartedMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetStarted.GetStartedMock.defaultExpectation.params
+		mm_want_ptrs := mmGetStarted.GetStartedMock.defaultExpectation.paramPtrs
+
+		mm_got := ISagaRepositoryMockGetStartedParams{ctx, updatedBefore, limit}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetStarted.t.Errorf("ISagaRepositoryMock.GetStarted got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStarted.GetStartedMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.updatedBefore != nil && !minimock.Equal(*mm_want_ptrs.updatedBefore, mm_got.updatedBefore) {
+				mmGetStarted.t.Errorf("ISagaRepositoryMock.GetStarted got unexpected parameter updatedBefore, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStarted.GetStartedMock.defaultExpectation.expectationOrigins.originUpdatedBefore, *mm_want_ptrs.updatedBefore, mm_got.updatedBefore, minimock.Diff(*mm_want_ptrs.updatedBefore, mm_got.updatedBefore))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetStarted.t.Errorf("ISagaRepositoryMock.GetStarted got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStarted.GetStartedMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetStarted.t.Errorf("ISagaRepositoryMock.GetStarted got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetStarted.GetStartedMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetStarted.GetStartedMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetStarted.t.Fatal("No results are set for the ISagaRepositoryMock.GetStarted")
+		}
+		return (*mm_results).sa1, (*mm_results).err
+	}
+	if mmGetStarted.funcGetStarted != nil {
+		return mmGetStarted.funcGetStarted(ctx, updatedBefore, limit)
+	}
+	mmGetStarted.t.Fatalf("Unexpected call to ISagaRepositoryMock.GetStarted. %v %v %v", ctx, updatedBefore, limit)
+	return
+}
+
+// GetStartedAfterCounter returns a count of finished ISagaRepositoryMock.GetStarted invocations
+func (mmGetStarted *ISagaRepositoryMock) GetStartedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStarted.afterGetStartedCounter)
+}
+
+// GetStartedBeforeCounter returns a count of ISagaRepositoryMock.GetStarted invocations
+func (mmGetStarted *ISagaRepositoryMock) GetStartedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStarted.beforeGetStartedCounter)
+}
+
+// Calls returns a list of arguments used in each call to ISagaRepositoryMock.GetStarted.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetStarted *mISagaRepositoryMockGetStarted) Calls() []*ISagaRepositoryMockGetStartedParams {
+	mmGetStarted.mutex.RLock()
+
+	argCopy := make([]*ISagaRepositoryMockGetStartedParams, len(mmGetStarted.callArgs))
+	copy(argCopy, mmGetStarted.callArgs)
+
+	mmGetStarted.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetStartedDone returns true if the count of the GetStarted invocations corresponds
+// the number of defined expectations
+func (m *ISagaRepositoryMock) MinimockGetStartedDone() bool {
+	if m.GetStartedMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetStartedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetStartedMock.invocationsDone()
+}
+
+// MinimockGetStartedInspect logs each unmet expectation
+func (m *ISagaRepositoryMock) MinimockGetStartedInspect() {
+	for _, e := range m.GetStartedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.GetStarted at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetStartedCounter := mm_atomic.LoadUint64(&m.afterGetStartedCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetStartedMock.defaultExpectation != nil && afterGetStartedCounter < 1 {
+		if m.GetStartedMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.GetStarted at\n%s", m.GetStartedMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.GetStarted at\n%s with params: %#v", m.GetStartedMock.defaultExpectation.expectationOrigins.origin, *m.GetStartedMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetStarted != nil && afterGetStartedCounter < 1 {
+		m.t.Errorf("Expected call to ISagaRepositoryMock.GetStarted at\n%s", m.funcGetStartedOrigin)
+	}
+
+	if !m.GetStartedMock.invocationsDone() && afterGetStartedCounter > 0 {
+		m.t.Errorf("Expected %d calls to ISagaRepositoryMock.GetStarted at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetStartedMock.expectedInvocations), m.GetStartedMock.expectedInvocationsOrigin, afterGetStartedCounter)
+	}
+}
+
+type mISagaRepositoryMockRecordAttempt struct {
+	optional           bool
+	mock               *ISagaRepositoryMock
+	defaultExpectation *ISagaRepositoryMockRecordAttemptExpectation
+	expectations       []*ISagaRepositoryMockRecordAttemptExpectation
+
+	callArgs []*ISagaRepositoryMockRecordAttemptParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ISagaRepositoryMockRecordAttemptExpectation specifies expectation struct of the ISagaRepository.RecordAttempt
+type ISagaRepositoryMockRecordAttemptExpectation struct {
+	mock               *ISagaRepositoryMock
+	params             *ISagaRepositoryMockRecordAttemptParams
+	paramPtrs          *ISagaRepositoryMockRecordAttemptParamPtrs
+	expectationOrigins ISagaRepositoryMockRecordAttemptExpectationOrigins
+	results            *ISagaRepositoryMockRecordAttemptResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ISagaRepositoryMockRecordAttemptParams contains parameters of the ISagaRepository.RecordAttempt
+type ISagaRepositoryMockRecordAttemptParams struct {
+	ctx     context.Context
+	sagaID  int64
+	lastErr error
+}
+
+// ISagaRepositoryMockRecordAttemptParamPtrs contains pointers to parameters of the ISagaRepository.RecordAttempt
+type ISagaRepositoryMockRecordAttemptParamPtrs struct {
+	ctx     *context.Context
+	sagaID  *int64
+	lastErr *error
+}
+
+// ISagaRepositoryMockRecordAttemptResults contains results of the ISagaRepository.RecordAttempt
+type ISagaRepositoryMockRecordAttemptResults struct {
+	err error
+}
+
+// ISagaRepositoryMockRecordAttemptOrigins contains origins of expectations of the ISagaRepository.RecordAttempt
+type ISagaRepositoryMockRecordAttemptExpectationOrigins struct {
+	origin        string
+	originCtx     string
+	originSagaID  string
+	originLastErr string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) Optional() *mISagaRepositoryMockRecordAttempt {
+	mmRecordAttempt.optional = true
+	return mmRecordAttempt
+}
+
+// Expect sets up expected params for ISagaRepository.RecordAttempt
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) Expect(ctx context.Context, sagaID int64, lastErr error) *mISagaRepositoryMockRecordAttempt {
+	if mmRecordAttempt.mock.funcRecordAttempt != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Set")
+	}
+
+	if mmRecordAttempt.defaultExpectation == nil {
+		mmRecordAttempt.defaultExpectation = &ISagaRepositoryMockRecordAttemptExpectation{}
+	}
+
+	if mmRecordAttempt.defaultExpectation.paramPtrs != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by ExpectParams functions")
+	}
+
+	mmRecordAttempt.defaultExpectation.params = &ISagaRepositoryMockRecordAttemptParams{ctx, sagaID, lastErr}
+	mmRecordAttempt.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmRecordAttempt.expectations {
+		if minimock.Equal(e.params, mmRecordAttempt.defaultExpectation.params) {
+			mmRecordAttempt.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmRecordAttempt.defaultExpectation.params)
+		}
+	}
+
+	return mmRecordAttempt
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ISagaRepository.RecordAttempt
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) ExpectCtxParam1(ctx context.Context) *mISagaRepositoryMockRecordAttempt {
+	if mmRecordAttempt.mock.funcRecordAttempt != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Set")
+	}
+
+	if mmRecordAttempt.defaultExpectation == nil {
+		mmRecordAttempt.defaultExpectation = &ISagaRepositoryMockRecordAttemptExpectation{}
+	}
+
+	if mmRecordAttempt.defaultExpectation.params != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Expect")
+	}
+
+	if mmRecordAttempt.defaultExpectation.paramPtrs == nil {
+		mmRecordAttempt.defaultExpectation.paramPtrs = &ISagaRepositoryMockRecordAttemptParamPtrs{}
+	}
+	mmRecordAttempt.defaultExpectation.paramPtrs.ctx = &ctx
+	mmRecordAttempt.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmRecordAttempt
+}
+
+// ExpectSagaIDParam2 sets up expected param sagaID for ISagaRepository.RecordAttempt
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) ExpectSagaIDParam2(sagaID int64) *mISagaRepositoryMockRecordAttempt {
+	if mmRecordAttempt.mock.funcRecordAttempt != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Set")
+	}
+
+	if mmRecordAttempt.defaultExpectation == nil {
+		mmRecordAttempt.defaultExpectation = &ISagaRepositoryMockRecordAttemptExpectation{}
+	}
+
+	if mmRecordAttempt.defaultExpectation.params != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Expect")
+	}
+
+	if mmRecordAttempt.defaultExpectation.paramPtrs == nil {
+		mmRecordAttempt.defaultExpectation.paramPtrs = &ISagaRepositoryMockRecordAttemptParamPtrs{}
+	}
+	mmRecordAttempt.defaultExpectation.paramPtrs.sagaID = &sagaID
+	mmRecordAttempt.defaultExpectation.expectationOrigins.originSagaID = minimock.CallerInfo(1)
+
+	return mmRecordAttempt
+}
+
+// ExpectLastErrParam3 sets up expected param lastErr for ISagaRepository.RecordAttempt
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) ExpectLastErrParam3(lastErr error) *mISagaRepositoryMockRecordAttempt {
+	if mmRecordAttempt.mock.funcRecordAttempt != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Set")
+	}
+
+	if mmRecordAttempt.defaultExpectation == nil {
+		mmRecordAttempt.defaultExpectation = &ISagaRepositoryMockRecordAttemptExpectation{}
+	}
+
+	if mmRecordAttempt.defaultExpectation.params != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Expect")
+	}
+
+	if mmRecordAttempt.defaultExpectation.paramPtrs == nil {
+		mmRecordAttempt.defaultExpectation.paramPtrs = &ISagaRepositoryMockRecordAttemptParamPtrs{}
+	}
+	mmRecordAttempt.defaultExpectation.paramPtrs.lastErr = &lastErr
+	mmRecordAttempt.defaultExpectation.expectationOrigins.originLastErr = minimock.CallerInfo(1)
+
+	return mmRecordAttempt
+}
+
+// Inspect accepts an inspector function that has same arguments as the ISagaRepository.RecordAttempt
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) Inspect(f func(ctx context.Context, sagaID int64, lastErr error)) *mISagaRepositoryMockRecordAttempt {
+	if mmRecordAttempt.mock.inspectFuncRecordAttempt != nil {
+		mmRecordAttempt.mock.t.Fatalf("Inspect function is already set for ISagaRepositoryMock.RecordAttempt")
+	}
+
+	mmRecordAttempt.mock.inspectFuncRecordAttempt = f
+
+	return mmRecordAttempt
+}
+
+// Return sets up results that will be returned by ISagaRepository.RecordAttempt
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) Return(err error) *ISagaRepositoryMock {
+	if mmRecordAttempt.mock.funcRecordAttempt != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Set")
+	}
+
+	if mmRecordAttempt.defaultExpectation == nil {
+		mmRecordAttempt.defaultExpectation = &ISagaRepositoryMockRecordAttemptExpectation{mock: mmRecordAttempt.mock}
+	}
+	mmRecordAttempt.defaultExpectation.results = &ISagaRepositoryMockRecordAttemptResults{err}
+	mmRecordAttempt.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmRecordAttempt.mock
+}
+
+// Set uses given function f to mock the ISagaRepository.RecordAttempt method
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) Set(f func(ctx context.Context, sagaID int64, lastErr error) (err error)) *ISagaRepositoryMock {
+	if mmRecordAttempt.defaultExpectation != nil {
+		mmRecordAttempt.mock.t.Fatalf("Default expectation is already set for the ISagaRepository.RecordAttempt method")
+	}
+
+	if len(mmRecordAttempt.expectations) > 0 {
+		mmRecordAttempt.mock.t.Fatalf("Some expectations are already set for the ISagaRepository.RecordAttempt method")
+	}
+
+	mmRecordAttempt.mock.funcRecordAttempt = f
+	mmRecordAttempt.mock.funcRecordAttemptOrigin = minimock.CallerInfo(1)
+	return mmRecordAttempt.mock
+}
+
+// When sets expectation for the ISagaRepository.RecordAttempt which will trigger the result defined by the following
+// Then helper
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) When(ctx context.Context, sagaID int64, lastErr error) *ISagaRepositoryMockRecordAttemptExpectation {
+	if mmRecordAttempt.mock.funcRecordAttempt != nil {
+		mmRecordAttempt.mock.t.Fatalf("ISagaRepositoryMock.RecordAttempt mock is already set by Set")
+	}
+
+	expectation := &ISagaRepositoryMockRecordAttemptExpectation{
+		mock:               mmRecordAttempt.mock,
+		params:             &ISagaRepositoryMockRecordAttemptParams{ctx, sagaID, lastErr},
+		expectationOrigins: ISagaRepositoryMockRecordAttemptExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmRecordAttempt.expectations = append(mmRecordAttempt.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ISagaRepository.RecordAttempt return parameters for the expectation previously defined by the When method
+func (e *ISagaRepositoryMockRecordAttemptExpectation) Then(err error) *ISagaRepositoryMock {
+	e.results = &ISagaRepositoryMockRecordAttemptResults{err}
+	return e.mock
+}
+
+// Times sets number of times ISagaRepository.RecordAttempt should be invoked
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) Times(n uint64) *mISagaRepositoryMockRecordAttempt {
+	if n == 0 {
+		mmRecordAttempt.mock.t.Fatalf("Times of ISagaRepositoryMock.RecordAttempt mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmRecordAttempt.expectedInvocations, n)
+	mmRecordAttempt.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmRecordAttempt
+}
+
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) invocationsDone() bool {
+	if len(mmRecordAttempt.expectations) == 0 && mmRecordAttempt.defaultExpectation == nil && mmRecordAttempt.mock.funcRecordAttempt == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmRecordAttempt.mock.afterRecordAttemptCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmRecordAttempt.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// RecordAttempt implements mm_service.ISagaRepository
+func (mmRecordAttempt *ISagaRepositoryMock) RecordAttempt(ctx context.Context, sagaID int64, lastErr error) (err error) {
+	mm_atomic.AddUint64(&mmRecordAttempt.beforeRecordAttemptCounter, 1)
+	defer mm_atomic.AddUint64(&mmRecordAttempt.afterRecordAttemptCounter, 1)
+
+	mmRecordAttempt.t.Helper()
+
+	if mmRecordAttempt.inspectFuncRecordAttempt != nil {
+		mmRecordAttempt.inspectFuncRecordAttempt(ctx, sagaID, lastErr)
+	}
+
+	mm_params := ISagaRepositoryMockRecordAttemptParams{ctx, sagaID, lastErr}
+
+	// Record call args
+	mmRecordAttempt.RecordAttemptMock.mutex.Lock()
+	mmRecordAttempt.RecordAttemptMock.callArgs = append(mmRecordAttempt.RecordAttemptMock.callArgs, &mm_params)
+	mmRecordAttempt.RecordAttemptMock.mutex.Unlock()
+
+	for _, e := range mmRecordAttempt.RecordAttemptMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmRecordAttempt.RecordAttemptMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmRecordAttempt.RecordAttemptMock.defaultExpectation.Counter, 1)
+		mm_want := mmRecordAttempt.RecordAttemptMock.defaultExpectation.params
+		mm_want_ptrs := mmRecordAttempt.RecordAttemptMock.defaultExpectation.paramPtrs
+
+		mm_got := ISagaRepositoryMockRecordAttemptParams{ctx, sagaID, lastErr}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmRecordAttempt.t.Errorf("ISagaRepositoryMock.RecordAttempt got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRecordAttempt.RecordAttemptMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.sagaID != nil && !minimock.Equal(*mm_want_ptrs.sagaID, mm_got.sagaID) {
+				mmRecordAttempt.t.Errorf("ISagaRepositoryMock.RecordAttempt got unexpected parameter sagaID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRecordAttempt.RecordAttemptMock.defaultExpectation.expectationOrigins.originSagaID, *mm_want_ptrs.sagaID, mm_got.sagaID, minimock.Diff(*mm_want_ptrs.sagaID, mm_got.sagaID))
+			}
+
+			if mm_want_ptrs.lastErr != nil && !minimock.Equal(*mm_want_ptrs.lastErr, mm_got.lastErr) {
+				mmRecordAttempt.t.Errorf("ISagaRepositoryMock.RecordAttempt got unexpected parameter lastErr, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRecordAttempt.RecordAttemptMock.defaultExpectation.expectationOrigins.originLastErr, *mm_want_ptrs.lastErr, mm_got.lastErr, minimock.Diff(*mm_want_ptrs.lastErr, mm_got.lastErr))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmRecordAttempt.t.Errorf("ISagaRepositoryMock.RecordAttempt got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmRecordAttempt.RecordAttemptMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmRecordAttempt.RecordAttemptMock.defaultExpectation.results
+		if mm_results == nil {
+			mmRecordAttempt.t.Fatal("No results are set for the ISagaRepositoryMock.RecordAttempt")
+		}
+		return (*mm_results).err
+	}
+	if mmRecordAttempt.funcRecordAttempt != nil {
+		return mmRecordAttempt.funcRecordAttempt(ctx, sagaID, lastErr)
+	}
+	mmRecordAttempt.t.Fatalf("Unexpected call to ISagaRepositoryMock.RecordAttempt. %v %v %v", ctx, sagaID, lastErr)
+	return
+}
+
+// RecordAttemptAfterCounter returns a count of finished ISagaRepositoryMock.RecordAttempt invocations
+func (mmRecordAttempt *ISagaRepositoryMock) RecordAttemptAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRecordAttempt.afterRecordAttemptCounter)
+}
+
+// RecordAttemptBeforeCounter returns a count of ISagaRepositoryMock.RecordAttempt invocations
+func (mmRecordAttempt *ISagaRepositoryMock) RecordAttemptBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRecordAttempt.beforeRecordAttemptCounter)
+}
+
+// Calls returns a list of arguments used in each call to ISagaRepositoryMock.RecordAttempt.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmRecordAttempt *mISagaRepositoryMockRecordAttempt) Calls() []*ISagaRepositoryMockRecordAttemptParams {
+	mmRecordAttempt.mutex.RLock()
+
+	argCopy := make([]*ISagaRepositoryMockRecordAttemptParams, len(mmRecordAttempt.callArgs))
+	copy(argCopy, mmRecordAttempt.callArgs)
+
+	mmRecordAttempt.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockRecordAttemptDone returns true if the count of the RecordAttempt invocations corresponds
+// the number of defined expectations
+func (m *ISagaRepositoryMock) MinimockRecordAttemptDone() bool {
+	if m.RecordAttemptMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.RecordAttemptMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.RecordAttemptMock.invocationsDone()
+}
+
+// MinimockRecordAttemptInspect logs each unmet expectation
+func (m *ISagaRepositoryMock) MinimockRecordAttemptInspect() {
+	for _, e := range m.RecordAttemptMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.RecordAttempt at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterRecordAttemptCounter := mm_atomic.LoadUint64(&m.afterRecordAttemptCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.RecordAttemptMock.defaultExpectation != nil && afterRecordAttemptCounter < 1 {
+		if m.RecordAttemptMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.RecordAttempt at\n%s", m.RecordAttemptMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.RecordAttempt at\n%s with params: %#v", m.RecordAttemptMock.defaultExpectation.expectationOrigins.origin, *m.RecordAttemptMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcRecordAttempt != nil && afterRecordAttemptCounter < 1 {
+		m.t.Errorf("Expected call to ISagaRepositoryMock.RecordAttempt at\n%s", m.funcRecordAttemptOrigin)
+	}
+
+	if !m.RecordAttemptMock.invocationsDone() && afterRecordAttemptCounter > 0 {
+		m.t.Errorf("Expected %d calls to ISagaRepositoryMock.RecordAttempt at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.RecordAttemptMock.expectedInvocations), m.RecordAttemptMock.expectedInvocationsOrigin, afterRecordAttemptCounter)
+	}
+}
+
+type mISagaRepositoryMockSetState struct {
+	optional           bool
+	mock               *ISagaRepositoryMock
+	defaultExpectation *ISagaRepositoryMockSetStateExpectation
+	expectations       []*ISagaRepositoryMockSetStateExpectation
+
+	callArgs []*ISagaRepositoryMockSetStateParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ISagaRepositoryMockSetStateExpectation specifies expectation struct of the ISagaRepository.SetState
+type ISagaRepositoryMockSetStateExpectation struct {
+	mock               *ISagaRepositoryMock
+	params             *ISagaRepositoryMockSetStateParams
+	paramPtrs          *ISagaRepositoryMockSetStateParamPtrs
+	expectationOrigins ISagaRepositoryMockSetStateExpectationOrigins
+	results            *ISagaRepositoryMockSetStateResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ISagaRepositoryMockSetStateParams contains parameters of the ISagaRepository.SetState
+type ISagaRepositoryMockSetStateParams struct {
+	ctx    context.Context
+	tx     pgx.Tx
+	sagaID int64
+	state  models.SagaState
+}
+
+// ISagaRepositoryMockSetStateParamPtrs contains pointers to parameters of the ISagaRepository.SetState
+type ISagaRepositoryMockSetStateParamPtrs struct {
+	ctx    *context.Context
+	tx     *pgx.Tx
+	sagaID *int64
+	state  *models.SagaState
+}
+
+// ISagaRepositoryMockSetStateResults contains results of the ISagaRepository.SetState
+type ISagaRepositoryMockSetStateResults struct {
+	err error
+}
+
+// ISagaRepositoryMockSetStateOrigins contains origins of expectations of the ISagaRepository.SetState
+type ISagaRepositoryMockSetStateExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originTx     string
+	originSagaID string
+	originState  string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSetState *mISagaRepositoryMockSetState) Optional() *mISagaRepositoryMockSetState {
+	mmSetState.optional = true
+	return mmSetState
+}
+
+// Expect sets up expected params for ISagaRepository.SetState
+func (mmSetState *mISagaRepositoryMockSetState) Expect(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) *mISagaRepositoryMockSetState {
+	if mmSetState.mock.funcSetState != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Set")
+	}
+
+	if mmSetState.defaultExpectation == nil {
+		mmSetState.defaultExpectation = &ISagaRepositoryMockSetStateExpectation{}
+	}
+
+	if mmSetState.defaultExpectation.paramPtrs != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by ExpectParams functions")
+	}
+
+	mmSetState.defaultExpectation.params = &ISagaRepositoryMockSetStateParams{ctx, tx, sagaID, state}
+	mmSetState.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmSetState.expectations {
+		if minimock.Equal(e.params, mmSetState.defaultExpectation.params) {
+			mmSetState.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetState.defaultExpectation.params)
+		}
+	}
+
+	return mmSetState
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ISagaRepository.SetState
+func (mmSetState *mISagaRepositoryMockSetState) ExpectCtxParam1(ctx context.Context) *mISagaRepositoryMockSetState {
+	if mmSetState.mock.funcSetState != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Set")
+	}
+
+	if mmSetState.defaultExpectation == nil {
+		mmSetState.defaultExpectation = &ISagaRepositoryMockSetStateExpectation{}
+	}
+
+	if mmSetState.defaultExpectation.params != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Expect")
+	}
+
+	if mmSetState.defaultExpectation.paramPtrs == nil {
+		mmSetState.defaultExpectation.paramPtrs = &ISagaRepositoryMockSetStateParamPtrs{}
+	}
+	mmSetState.defaultExpectation.paramPtrs.ctx = &ctx
+	mmSetState.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmSetState
+}
+
+// ExpectTxParam2 sets up expected param tx for ISagaRepository.SetState
+func (mmSetState *mISagaRepositoryMockSetState) ExpectTxParam2(tx pgx.Tx) *mISagaRepositoryMockSetState {
+	if mmSetState.mock.funcSetState != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Set")
+	}
+
+	if mmSetState.defaultExpectation == nil {
+		mmSetState.defaultExpectation = &ISagaRepositoryMockSetStateExpectation{}
+	}
+
+	if mmSetState.defaultExpectation.params != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Expect")
+	}
+
+	if mmSetState.defaultExpectation.paramPtrs == nil {
+		mmSetState.defaultExpectation.paramPtrs = &ISagaRepositoryMockSetStateParamPtrs{}
+	}
+	mmSetState.defaultExpectation.paramPtrs.tx = &tx
+	mmSetState.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmSetState
+}
+
+// ExpectSagaIDParam3 sets up expected param sagaID for ISagaRepository.SetState
+func (mmSetState *mISagaRepositoryMockSetState) ExpectSagaIDParam3(sagaID int64) *mISagaRepositoryMockSetState {
+	if mmSetState.mock.funcSetState != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Set")
+	}
+
+	if mmSetState.defaultExpectation == nil {
+		mmSetState.defaultExpectation = &ISagaRepositoryMockSetStateExpectation{}
+	}
+
+	if mmSetState.defaultExpectation.params != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Expect")
+	}
+
+	if mmSetState.defaultExpectation.paramPtrs == nil {
+		mmSetState.defaultExpectation.paramPtrs = &ISagaRepositoryMockSetStateParamPtrs{}
+	}
+	mmSetState.defaultExpectation.paramPtrs.sagaID = &sagaID
+	mmSetState.defaultExpectation.expectationOrigins.originSagaID = minimock.CallerInfo(1)
+
+	return mmSetState
+}
+
+// ExpectStateParam4 sets up expected param state for ISagaRepository.SetState
+func (mmSetState *mISagaRepositoryMockSetState) ExpectStateParam4(state models.SagaState) *mISagaRepositoryMockSetState {
+	if mmSetState.mock.funcSetState != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Set")
+	}
+
+	if mmSetState.defaultExpectation == nil {
+		mmSetState.defaultExpectation = &ISagaRepositoryMockSetStateExpectation{}
+	}
+
+	if mmSetState.defaultExpectation.params != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Expect")
+	}
+
+	if mmSetState.defaultExpectation.paramPtrs == nil {
+		mmSetState.defaultExpectation.paramPtrs = &ISagaRepositoryMockSetStateParamPtrs{}
+	}
+	mmSetState.defaultExpectation.paramPtrs.state = &state
+	mmSetState.defaultExpectation.expectationOrigins.originState = minimock.CallerInfo(1)
+
+	return mmSetState
+}
+
+// Inspect accepts an inspector function that has same arguments as the ISagaRepository.SetState
+func (mmSetState *mISagaRepositoryMockSetState) Inspect(f func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState)) *mISagaRepositoryMockSetState {
+	if mmSetState.mock.inspectFuncSetState != nil {
+		mmSetState.mock.t.Fatalf("Inspect function is already set for ISagaRepositoryMock.SetState")
+	}
+
+	mmSetState.mock.inspectFuncSetState = f
+
+	return mmSetState
+}
+
+// Return sets up results that will be returned by ISagaRepository.SetState
+func (mmSetState *mISagaRepositoryMockSetState) Return(err error) *ISagaRepositoryMock {
+	if mmSetState.mock.funcSetState != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Set")
+	}
+
+	if mmSetState.defaultExpectation == nil {
+		mmSetState.defaultExpectation = &ISagaRepositoryMockSetStateExpectation{mock: mmSetState.mock}
+	}
+	mmSetState.defaultExpectation.results = &ISagaRepositoryMockSetStateResults{err}
+	mmSetState.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmSetState.mock
+}
+
+// Set uses given function f to mock the ISagaRepository.SetState method
+func (mmSetState *mISagaRepositoryMockSetState) Set(f func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) (err error)) *ISagaRepositoryMock {
+	if mmSetState.defaultExpectation != nil {
+		mmSetState.mock.t.Fatalf("Default expectation is already set for the ISagaRepository.SetState method")
+	}
+
+	if len(mmSetState.expectations) > 0 {
+		mmSetState.mock.t.Fatalf("Some expectations are already set for the ISagaRepository.SetState method")
+	}
+
+	mmSetState.mock.funcSetState = f
+	mmSetState.mock.funcSetStateOrigin = minimock.CallerInfo(1)
+	return mmSetState.mock
+}
+
+// When sets expectation for the ISagaRepository.SetState which will trigger the result defined by the following
+// Then helper
+func (mmSetState *mISagaRepositoryMockSetState) When(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) *ISagaRepositoryMockSetStateExpectation {
+	if mmSetState.mock.funcSetState != nil {
+		mmSetState.mock.t.Fatalf("ISagaRepositoryMock.SetState mock is already set by Set")
+	}
+
+	expectation := &ISagaRepositoryMockSetStateExpectation{
+		mock:               mmSetState.mock,
+		params:             &ISagaRepositoryMockSetStateParams{ctx, tx, sagaID, state},
+		expectationOrigins: ISagaRepositoryMockSetStateExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmSetState.expectations = append(mmSetState.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ISagaRepository.SetState return parameters for the expectation previously defined by the When method
+func (e *ISagaRepositoryMockSetStateExpectation) Then(err error) *ISagaRepositoryMock {
+	e.results = &ISagaRepositoryMockSetStateResults{err}
+	return e.mock
+}
+
+// Times sets number of times ISagaRepository.SetState should be invoked
+func (mmSetState *mISagaRepositoryMockSetState) Times(n uint64) *mISagaRepositoryMockSetState {
+	if n == 0 {
+		mmSetState.mock.t.Fatalf("Times of ISagaRepositoryMock.SetState mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSetState.expectedInvocations, n)
+	mmSetState.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmSetState
+}
+
+func (mmSetState *mISagaRepositoryMockSetState) invocationsDone() bool {
+	if len(mmSetState.expectations) == 0 && mmSetState.defaultExpectation == nil && mmSetState.mock.funcSetState == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSetState.mock.afterSetStateCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSetState.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SetState implements mm_service.ISagaRepository
+func (mmSetState *ISagaRepositoryMock) SetState(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) (err error) {
+	mm_atomic.AddUint64(&mmSetState.beforeSetStateCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetState.afterSetStateCounter, 1)
+
+	mmSetState.t.Helper()
+
+	if mmSetState.inspectFuncSetState != nil {
+		mmSetState.inspectFuncSetState(ctx, tx, sagaID, state)
+	}
+
+	mm_params := ISagaRepositoryMockSetStateParams{ctx, tx, sagaID, state}
+
+	// Record call args
+	mmSetState.SetStateMock.mutex.Lock()
+	mmSetState.SetStateMock.callArgs = append(mmSetState.SetStateMock.callArgs, &mm_params)
+	mmSetState.SetStateMock.mutex.Unlock()
+
+	for _, e := range mmSetState.SetStateMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmSetState.SetStateMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetState.SetStateMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetState.SetStateMock.defaultExpectation.params
+		mm_want_ptrs := mmSetState.SetStateMock.defaultExpectation.paramPtrs
+
+		mm_got := ISagaRepositoryMockSetStateParams{ctx, tx, sagaID, state}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmSetState.t.Errorf("ISagaRepositoryMock.SetState got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetState.SetStateMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmSetState.t.Errorf("ISagaRepositoryMock.SetState got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetState.SetStateMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.sagaID != nil && !minimock.Equal(*mm_want_ptrs.sagaID, mm_got.sagaID) {
+				mmSetState.t.Errorf("ISagaRepositoryMock.SetState got unexpected parameter sagaID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetState.SetStateMock.defaultExpectation.expectationOrigins.originSagaID, *mm_want_ptrs.sagaID, mm_got.sagaID, minimock.Diff(*mm_want_ptrs.sagaID, mm_got.sagaID))
+			}
+
+			if mm_want_ptrs.state != nil && !minimock.Equal(*mm_want_ptrs.state, mm_got.state) {
+				mmSetState.t.Errorf("ISagaRepositoryMock.SetState got unexpected parameter state, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetState.SetStateMock.defaultExpectation.expectationOrigins.originState, *mm_want_ptrs.state, mm_got.state, minimock.Diff(*mm_want_ptrs.state, mm_got.state))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetState.t.Errorf("ISagaRepositoryMock.SetState got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmSetState.SetStateMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSetState.SetStateMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSetState.t.Fatal("No results are set for the ISagaRepositoryMock.SetState")
+		}
+		return (*mm_results).err
+	}
+	if mmSetState.funcSetState != nil {
+		return mmSetState.funcSetState(ctx, tx, sagaID, state)
+	}
+	mmSetState.t.Fatalf("Unexpected call to ISagaRepositoryMock.SetState. %v %v %v %v", ctx, tx, sagaID, state)
+	return
+}
+
+// SetStateAfterCounter returns a count of finished ISagaRepositoryMock.SetState invocations
+func (mmSetState *ISagaRepositoryMock) SetStateAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetState.afterSetStateCounter)
+}
+
+// SetStateBeforeCounter returns a count of ISagaRepositoryMock.SetState invocations
+func (mmSetState *ISagaRepositoryMock) SetStateBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetState.beforeSetStateCounter)
+}
+
+// Calls returns a list of arguments used in each call to ISagaRepositoryMock.SetState.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetState *mISagaRepositoryMockSetState) Calls() []*ISagaRepositoryMockSetStateParams {
+	mmSetState.mutex.RLock()
+
+	argCopy := make([]*ISagaRepositoryMockSetStateParams, len(mmSetState.callArgs))
+	copy(argCopy, mmSetState.callArgs)
+
+	mmSetState.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetStateDone returns true if the count of the SetState invocations corresponds
+// the number of defined expectations
+func (m *ISagaRepositoryMock) MinimockSetStateDone() bool {
+	if m.SetStateMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.SetStateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.SetStateMock.invocationsDone()
+}
+
+// MinimockSetStateInspect logs each unmet expectation
+func (m *ISagaRepositoryMock) MinimockSetStateInspect() {
+	for _, e := range m.SetStateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.SetState at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterSetStateCounter := mm_atomic.LoadUint64(&m.afterSetStateCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetStateMock.defaultExpectation != nil && afterSetStateCounter < 1 {
+		if m.SetStateMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.SetState at\n%s", m.SetStateMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ISagaRepositoryMock.SetState at\n%s with params: %#v", m.SetStateMock.defaultExpectation.expectationOrigins.origin, *m.SetStateMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetState != nil && afterSetStateCounter < 1 {
+		m.t.Errorf("Expected call to ISagaRepositoryMock.SetState at\n%s", m.funcSetStateOrigin)
+	}
+
+	if !m.SetStateMock.invocationsDone() && afterSetStateCounter > 0 {
+		m.t.Errorf("Expected %d calls to ISagaRepositoryMock.SetState at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.SetStateMock.expectedInvocations), m.SetStateMock.expectedInvocationsOrigin, afterSetStateCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *ISagaRepositoryMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockCreateInspect()
+
+			m.MinimockGetStartedInspect()
+
+			m.MinimockRecordAttemptInspect()
+
+			m.MinimockSetStateInspect()
+		}
+	})
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *ISagaRepositoryMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *ISagaRepositoryMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockCreateDone() &&
+		m.MinimockGetStartedDone() &&
+		m.MinimockRecordAttemptDone() &&
+		m.MinimockSetStateDone()
+}
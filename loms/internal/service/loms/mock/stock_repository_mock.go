@@ -20,6 +20,13 @@ type IStockRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
+	funcAdjustStockTotalCount          func(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64) (s1 models.Stock, err error)
+	funcAdjustStockTotalCountOrigin    string
+	inspectFuncAdjustStockTotalCount   func(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64)
+	afterAdjustStockTotalCountCounter  uint64
+	beforeAdjustStockTotalCountCounter uint64
+	AdjustStockTotalCountMock          mIStockRepositoryMockAdjustStockTotalCount
+
 	funcCancelReservedItems          func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)
 	funcCancelReservedItemsOrigin    string
 	inspectFuncCancelReservedItems   func(ctx context.Context, tx pgx.Tx, items []models.Item)
@@ -34,6 +41,27 @@ type IStockRepositoryMock struct {
 	beforeGetAvailableStockBySKUCounter uint64
 	GetAvailableStockBySKUMock          mIStockRepositoryMockGetAvailableStockBySKU
 
+	funcGetStocksBySKUs          func(ctx context.Context, SKUs []models.SKU) (sa1 []models.Stock, err error)
+	funcGetStocksBySKUsOrigin    string
+	inspectFuncGetStocksBySKUs   func(ctx context.Context, SKUs []models.SKU)
+	afterGetStocksBySKUsCounter  uint64
+	beforeGetStocksBySKUsCounter uint64
+	GetStocksBySKUsMock          mIStockRepositoryMockGetStocksBySKUs
+
+	funcGetStocksForUpdate          func(ctx context.Context, tx pgx.Tx, SKUs []models.SKU) (sa1 []models.Stock, err error)
+	funcGetStocksForUpdateOrigin    string
+	inspectFuncGetStocksForUpdate   func(ctx context.Context, tx pgx.Tx, SKUs []models.SKU)
+	afterGetStocksForUpdateCounter  uint64
+	beforeGetStocksForUpdateCounter uint64
+	GetStocksForUpdateMock          mIStockRepositoryMockGetStocksForUpdate
+
+	funcImportStock          func(ctx context.Context, tx pgx.Tx, stock models.Stock) (s1 models.Stock, err error)
+	funcImportStockOrigin    string
+	inspectFuncImportStock   func(ctx context.Context, tx pgx.Tx, stock models.Stock)
+	afterImportStockCounter  uint64
+	beforeImportStockCounter uint64
+	ImportStockMock          mIStockRepositoryMockImportStock
+
 	funcRemoveReservedItems          func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)
 	funcRemoveReservedItemsOrigin    string
 	inspectFuncRemoveReservedItems   func(ctx context.Context, tx pgx.Tx, items []models.Item)
@@ -41,12 +69,33 @@ type IStockRepositoryMock struct {
 	beforeRemoveReservedItemsCounter uint64
 	RemoveReservedItemsMock          mIStockRepositoryMockRemoveReservedItems
 
+	funcReplenishStock          func(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64) (s1 models.Stock, err error)
+	funcReplenishStockOrigin    string
+	inspectFuncReplenishStock   func(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64)
+	afterReplenishStockCounter  uint64
+	beforeReplenishStockCounter uint64
+	ReplenishStockMock          mIStockRepositoryMockReplenishStock
+
 	funcReserveItems          func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)
 	funcReserveItemsOrigin    string
 	inspectFuncReserveItems   func(ctx context.Context, tx pgx.Tx, items []models.Item)
 	afterReserveItemsCounter  uint64
 	beforeReserveItemsCounter uint64
 	ReserveItemsMock          mIStockRepositoryMockReserveItems
+
+	funcRestoreRemovedItems          func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)
+	funcRestoreRemovedItemsOrigin    string
+	inspectFuncRestoreRemovedItems   func(ctx context.Context, tx pgx.Tx, items []models.Item)
+	afterRestoreRemovedItemsCounter  uint64
+	beforeRestoreRemovedItemsCounter uint64
+	RestoreRemovedItemsMock          mIStockRepositoryMockRestoreRemovedItems
+
+	funcSetStockTotalCount          func(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64) (s1 models.Stock, err error)
+	funcSetStockTotalCountOrigin    string
+	inspectFuncSetStockTotalCount   func(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64)
+	afterSetStockTotalCountCounter  uint64
+	beforeSetStockTotalCountCounter uint64
+	SetStockTotalCountMock          mIStockRepositoryMockSetStockTotalCount
 }
 
 // NewIStockRepositoryMock returns a mock for mm_service.IStockRepository
@@ -57,23 +106,449 @@ func NewIStockRepositoryMock(t minimock.Tester) *IStockRepositoryMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.AdjustStockTotalCountMock = mIStockRepositoryMockAdjustStockTotalCount{mock: m}
+	m.AdjustStockTotalCountMock.callArgs = []*IStockRepositoryMockAdjustStockTotalCountParams{}
+
 	m.CancelReservedItemsMock = mIStockRepositoryMockCancelReservedItems{mock: m}
 	m.CancelReservedItemsMock.callArgs = []*IStockRepositoryMockCancelReservedItemsParams{}
 
 	m.GetAvailableStockBySKUMock = mIStockRepositoryMockGetAvailableStockBySKU{mock: m}
 	m.GetAvailableStockBySKUMock.callArgs = []*IStockRepositoryMockGetAvailableStockBySKUParams{}
 
+	m.GetStocksBySKUsMock = mIStockRepositoryMockGetStocksBySKUs{mock: m}
+	m.GetStocksBySKUsMock.callArgs = []*IStockRepositoryMockGetStocksBySKUsParams{}
+
+	m.GetStocksForUpdateMock = mIStockRepositoryMockGetStocksForUpdate{mock: m}
+	m.GetStocksForUpdateMock.callArgs = []*IStockRepositoryMockGetStocksForUpdateParams{}
+
+	m.ImportStockMock = mIStockRepositoryMockImportStock{mock: m}
+	m.ImportStockMock.callArgs = []*IStockRepositoryMockImportStockParams{}
+
 	m.RemoveReservedItemsMock = mIStockRepositoryMockRemoveReservedItems{mock: m}
 	m.RemoveReservedItemsMock.callArgs = []*IStockRepositoryMockRemoveReservedItemsParams{}
 
+	m.ReplenishStockMock = mIStockRepositoryMockReplenishStock{mock: m}
+	m.ReplenishStockMock.callArgs = []*IStockRepositoryMockReplenishStockParams{}
+
 	m.ReserveItemsMock = mIStockRepositoryMockReserveItems{mock: m}
 	m.ReserveItemsMock.callArgs = []*IStockRepositoryMockReserveItemsParams{}
 
+	m.RestoreRemovedItemsMock = mIStockRepositoryMockRestoreRemovedItems{mock: m}
+	m.RestoreRemovedItemsMock.callArgs = []*IStockRepositoryMockRestoreRemovedItemsParams{}
+
+	m.SetStockTotalCountMock = mIStockRepositoryMockSetStockTotalCount{mock: m}
+	m.SetStockTotalCountMock.callArgs = []*IStockRepositoryMockSetStockTotalCountParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
 }
 
+type mIStockRepositoryMockAdjustStockTotalCount struct {
+	optional           bool
+	mock               *IStockRepositoryMock
+	defaultExpectation *IStockRepositoryMockAdjustStockTotalCountExpectation
+	expectations       []*IStockRepositoryMockAdjustStockTotalCountExpectation
+
+	callArgs []*IStockRepositoryMockAdjustStockTotalCountParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IStockRepositoryMockAdjustStockTotalCountExpectation specifies expectation struct of the IStockRepository.AdjustStockTotalCount
+type IStockRepositoryMockAdjustStockTotalCountExpectation struct {
+	mock               *IStockRepositoryMock
+	params             *IStockRepositoryMockAdjustStockTotalCountParams
+	paramPtrs          *IStockRepositoryMockAdjustStockTotalCountParamPtrs
+	expectationOrigins IStockRepositoryMockAdjustStockTotalCountExpectationOrigins
+	results            *IStockRepositoryMockAdjustStockTotalCountResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IStockRepositoryMockAdjustStockTotalCountParams contains parameters of the IStockRepository.AdjustStockTotalCount
+type IStockRepositoryMockAdjustStockTotalCountParams struct {
+	ctx   context.Context
+	tx    pgx.Tx
+	SKU   models.SKU
+	delta int64
+}
+
+// IStockRepositoryMockAdjustStockTotalCountParamPtrs contains pointers to parameters of the IStockRepository.AdjustStockTotalCount
+type IStockRepositoryMockAdjustStockTotalCountParamPtrs struct {
+	ctx   *context.Context
+	tx    *pgx.Tx
+	SKU   *models.SKU
+	delta *int64
+}
+
+// IStockRepositoryMockAdjustStockTotalCountResults contains results of the IStockRepository.AdjustStockTotalCount
+type IStockRepositoryMockAdjustStockTotalCountResults struct {
+	s1  models.Stock
+	err error
+}
+
+// IStockRepositoryMockAdjustStockTotalCountOrigins contains origins of expectations of the IStockRepository.AdjustStockTotalCount
+type IStockRepositoryMockAdjustStockTotalCountExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originTx    string
+	originSKU   string
+	originDelta string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) Optional() *mIStockRepositoryMockAdjustStockTotalCount {
+	mmAdjustStockTotalCount.optional = true
+	return mmAdjustStockTotalCount
+}
+
+// Expect sets up expected params for IStockRepository.AdjustStockTotalCount
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) Expect(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64) *mIStockRepositoryMockAdjustStockTotalCount {
+	if mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Set")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation == nil {
+		mmAdjustStockTotalCount.defaultExpectation = &IStockRepositoryMockAdjustStockTotalCountExpectation{}
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.paramPtrs != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by ExpectParams functions")
+	}
+
+	mmAdjustStockTotalCount.defaultExpectation.params = &IStockRepositoryMockAdjustStockTotalCountParams{ctx, tx, SKU, delta}
+	mmAdjustStockTotalCount.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmAdjustStockTotalCount.expectations {
+		if minimock.Equal(e.params, mmAdjustStockTotalCount.defaultExpectation.params) {
+			mmAdjustStockTotalCount.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAdjustStockTotalCount.defaultExpectation.params)
+		}
+	}
+
+	return mmAdjustStockTotalCount
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.AdjustStockTotalCount
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockAdjustStockTotalCount {
+	if mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Set")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation == nil {
+		mmAdjustStockTotalCount.defaultExpectation = &IStockRepositoryMockAdjustStockTotalCountExpectation{}
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.params != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Expect")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.paramPtrs == nil {
+		mmAdjustStockTotalCount.defaultExpectation.paramPtrs = &IStockRepositoryMockAdjustStockTotalCountParamPtrs{}
+	}
+	mmAdjustStockTotalCount.defaultExpectation.paramPtrs.ctx = &ctx
+	mmAdjustStockTotalCount.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmAdjustStockTotalCount
+}
+
+// ExpectTxParam2 sets up expected param tx for IStockRepository.AdjustStockTotalCount
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockAdjustStockTotalCount {
+	if mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Set")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation == nil {
+		mmAdjustStockTotalCount.defaultExpectation = &IStockRepositoryMockAdjustStockTotalCountExpectation{}
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.params != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Expect")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.paramPtrs == nil {
+		mmAdjustStockTotalCount.defaultExpectation.paramPtrs = &IStockRepositoryMockAdjustStockTotalCountParamPtrs{}
+	}
+	mmAdjustStockTotalCount.defaultExpectation.paramPtrs.tx = &tx
+	mmAdjustStockTotalCount.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmAdjustStockTotalCount
+}
+
+// ExpectSKUParam3 sets up expected param SKU for IStockRepository.AdjustStockTotalCount
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) ExpectSKUParam3(SKU models.SKU) *mIStockRepositoryMockAdjustStockTotalCount {
+	if mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Set")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation == nil {
+		mmAdjustStockTotalCount.defaultExpectation = &IStockRepositoryMockAdjustStockTotalCountExpectation{}
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.params != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Expect")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.paramPtrs == nil {
+		mmAdjustStockTotalCount.defaultExpectation.paramPtrs = &IStockRepositoryMockAdjustStockTotalCountParamPtrs{}
+	}
+	mmAdjustStockTotalCount.defaultExpectation.paramPtrs.SKU = &SKU
+	mmAdjustStockTotalCount.defaultExpectation.expectationOrigins.originSKU = minimock.CallerInfo(1)
+
+	return mmAdjustStockTotalCount
+}
+
+// ExpectDeltaParam4 sets up expected param delta for IStockRepository.AdjustStockTotalCount
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) ExpectDeltaParam4(delta int64) *mIStockRepositoryMockAdjustStockTotalCount {
+	if mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Set")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation == nil {
+		mmAdjustStockTotalCount.defaultExpectation = &IStockRepositoryMockAdjustStockTotalCountExpectation{}
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.params != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Expect")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation.paramPtrs == nil {
+		mmAdjustStockTotalCount.defaultExpectation.paramPtrs = &IStockRepositoryMockAdjustStockTotalCountParamPtrs{}
+	}
+	mmAdjustStockTotalCount.defaultExpectation.paramPtrs.delta = &delta
+	mmAdjustStockTotalCount.defaultExpectation.expectationOrigins.originDelta = minimock.CallerInfo(1)
+
+	return mmAdjustStockTotalCount
+}
+
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.AdjustStockTotalCount
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) Inspect(f func(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64)) *mIStockRepositoryMockAdjustStockTotalCount {
+	if mmAdjustStockTotalCount.mock.inspectFuncAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.AdjustStockTotalCount")
+	}
+
+	mmAdjustStockTotalCount.mock.inspectFuncAdjustStockTotalCount = f
+
+	return mmAdjustStockTotalCount
+}
+
+// Return sets up results that will be returned by IStockRepository.AdjustStockTotalCount
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) Return(s1 models.Stock, err error) *IStockRepositoryMock {
+	if mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Set")
+	}
+
+	if mmAdjustStockTotalCount.defaultExpectation == nil {
+		mmAdjustStockTotalCount.defaultExpectation = &IStockRepositoryMockAdjustStockTotalCountExpectation{mock: mmAdjustStockTotalCount.mock}
+	}
+	mmAdjustStockTotalCount.defaultExpectation.results = &IStockRepositoryMockAdjustStockTotalCountResults{s1, err}
+	mmAdjustStockTotalCount.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmAdjustStockTotalCount.mock
+}
+
+// Set uses given function f to mock the IStockRepository.AdjustStockTotalCount method
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) Set(f func(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64) (s1 models.Stock, err error)) *IStockRepositoryMock {
+	if mmAdjustStockTotalCount.defaultExpectation != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("Default expectation is already set for the IStockRepository.AdjustStockTotalCount method")
+	}
+
+	if len(mmAdjustStockTotalCount.expectations) > 0 {
+		mmAdjustStockTotalCount.mock.t.Fatalf("Some expectations are already set for the IStockRepository.AdjustStockTotalCount method")
+	}
+
+	mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount = f
+	mmAdjustStockTotalCount.mock.funcAdjustStockTotalCountOrigin = minimock.CallerInfo(1)
+	return mmAdjustStockTotalCount.mock
+}
+
+// When sets expectation for the IStockRepository.AdjustStockTotalCount which will trigger the result defined by the following
+// Then helper
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) When(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64) *IStockRepositoryMockAdjustStockTotalCountExpectation {
+	if mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.AdjustStockTotalCount mock is already set by Set")
+	}
+
+	expectation := &IStockRepositoryMockAdjustStockTotalCountExpectation{
+		mock:               mmAdjustStockTotalCount.mock,
+		params:             &IStockRepositoryMockAdjustStockTotalCountParams{ctx, tx, SKU, delta},
+		expectationOrigins: IStockRepositoryMockAdjustStockTotalCountExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmAdjustStockTotalCount.expectations = append(mmAdjustStockTotalCount.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IStockRepository.AdjustStockTotalCount return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockAdjustStockTotalCountExpectation) Then(s1 models.Stock, err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockAdjustStockTotalCountResults{s1, err}
+	return e.mock
+}
+
+// Times sets number of times IStockRepository.AdjustStockTotalCount should be invoked
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) Times(n uint64) *mIStockRepositoryMockAdjustStockTotalCount {
+	if n == 0 {
+		mmAdjustStockTotalCount.mock.t.Fatalf("Times of IStockRepositoryMock.AdjustStockTotalCount mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmAdjustStockTotalCount.expectedInvocations, n)
+	mmAdjustStockTotalCount.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmAdjustStockTotalCount
+}
+
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) invocationsDone() bool {
+	if len(mmAdjustStockTotalCount.expectations) == 0 && mmAdjustStockTotalCount.defaultExpectation == nil && mmAdjustStockTotalCount.mock.funcAdjustStockTotalCount == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmAdjustStockTotalCount.mock.afterAdjustStockTotalCountCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmAdjustStockTotalCount.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// AdjustStockTotalCount implements mm_service.IStockRepository
+func (mmAdjustStockTotalCount *IStockRepositoryMock) AdjustStockTotalCount(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64) (s1 models.Stock, err error) {
+	mm_atomic.AddUint64(&mmAdjustStockTotalCount.beforeAdjustStockTotalCountCounter, 1)
+	defer mm_atomic.AddUint64(&mmAdjustStockTotalCount.afterAdjustStockTotalCountCounter, 1)
+
+	mmAdjustStockTotalCount.t.Helper()
+
+	if mmAdjustStockTotalCount.inspectFuncAdjustStockTotalCount != nil {
+		mmAdjustStockTotalCount.inspectFuncAdjustStockTotalCount(ctx, tx, SKU, delta)
+	}
+
+	mm_params := IStockRepositoryMockAdjustStockTotalCountParams{ctx, tx, SKU, delta}
+
+	// Record call args
+	mmAdjustStockTotalCount.AdjustStockTotalCountMock.mutex.Lock()
+	mmAdjustStockTotalCount.AdjustStockTotalCountMock.callArgs = append(mmAdjustStockTotalCount.AdjustStockTotalCountMock.callArgs, &mm_params)
+	mmAdjustStockTotalCount.AdjustStockTotalCountMock.mutex.Unlock()
+
+	for _, e := range mmAdjustStockTotalCount.AdjustStockTotalCountMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.s1, e.results.err
+		}
+	}
+
+	if mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.Counter, 1)
+		mm_want := mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.params
+		mm_want_ptrs := mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.paramPtrs
+
+		mm_got := IStockRepositoryMockAdjustStockTotalCountParams{ctx, tx, SKU, delta}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmAdjustStockTotalCount.t.Errorf("IStockRepositoryMock.AdjustStockTotalCount got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmAdjustStockTotalCount.t.Errorf("IStockRepositoryMock.AdjustStockTotalCount got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.SKU != nil && !minimock.Equal(*mm_want_ptrs.SKU, mm_got.SKU) {
+				mmAdjustStockTotalCount.t.Errorf("IStockRepositoryMock.AdjustStockTotalCount got unexpected parameter SKU, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.expectationOrigins.originSKU, *mm_want_ptrs.SKU, mm_got.SKU, minimock.Diff(*mm_want_ptrs.SKU, mm_got.SKU))
+			}
+
+			if mm_want_ptrs.delta != nil && !minimock.Equal(*mm_want_ptrs.delta, mm_got.delta) {
+				mmAdjustStockTotalCount.t.Errorf("IStockRepositoryMock.AdjustStockTotalCount got unexpected parameter delta, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.expectationOrigins.originDelta, *mm_want_ptrs.delta, mm_got.delta, minimock.Diff(*mm_want_ptrs.delta, mm_got.delta))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmAdjustStockTotalCount.t.Errorf("IStockRepositoryMock.AdjustStockTotalCount got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmAdjustStockTotalCount.AdjustStockTotalCountMock.defaultExpectation.results
+		if mm_results == nil {
+			mmAdjustStockTotalCount.t.Fatal("No results are set for the IStockRepositoryMock.AdjustStockTotalCount")
+		}
+		return (*mm_results).s1, (*mm_results).err
+	}
+	if mmAdjustStockTotalCount.funcAdjustStockTotalCount != nil {
+		return mmAdjustStockTotalCount.funcAdjustStockTotalCount(ctx, tx, SKU, delta)
+	}
+	mmAdjustStockTotalCount.t.Fatalf("Unexpected call to IStockRepositoryMock.AdjustStockTotalCount. %v %v %v %v", ctx, tx, SKU, delta)
+	return
+}
+
+// AdjustStockTotalCountAfterCounter returns a count of finished IStockRepositoryMock.AdjustStockTotalCount invocations
+func (mmAdjustStockTotalCount *IStockRepositoryMock) AdjustStockTotalCountAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAdjustStockTotalCount.afterAdjustStockTotalCountCounter)
+}
+
+// AdjustStockTotalCountBeforeCounter returns a count of IStockRepositoryMock.AdjustStockTotalCount invocations
+func (mmAdjustStockTotalCount *IStockRepositoryMock) AdjustStockTotalCountBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAdjustStockTotalCount.beforeAdjustStockTotalCountCounter)
+}
+
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.AdjustStockTotalCount.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmAdjustStockTotalCount *mIStockRepositoryMockAdjustStockTotalCount) Calls() []*IStockRepositoryMockAdjustStockTotalCountParams {
+	mmAdjustStockTotalCount.mutex.RLock()
+
+	argCopy := make([]*IStockRepositoryMockAdjustStockTotalCountParams, len(mmAdjustStockTotalCount.callArgs))
+	copy(argCopy, mmAdjustStockTotalCount.callArgs)
+
+	mmAdjustStockTotalCount.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockAdjustStockTotalCountDone returns true if the count of the AdjustStockTotalCount invocations corresponds
+// the number of defined expectations
+func (m *IStockRepositoryMock) MinimockAdjustStockTotalCountDone() bool {
+	if m.AdjustStockTotalCountMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.AdjustStockTotalCountMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.AdjustStockTotalCountMock.invocationsDone()
+}
+
+// MinimockAdjustStockTotalCountInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockAdjustStockTotalCountInspect() {
+	for _, e := range m.AdjustStockTotalCountMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IStockRepositoryMock.AdjustStockTotalCount at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterAdjustStockTotalCountCounter := mm_atomic.LoadUint64(&m.afterAdjustStockTotalCountCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AdjustStockTotalCountMock.defaultExpectation != nil && afterAdjustStockTotalCountCounter < 1 {
+		if m.AdjustStockTotalCountMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.AdjustStockTotalCount at\n%s", m.AdjustStockTotalCountMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IStockRepositoryMock.AdjustStockTotalCount at\n%s with params: %#v", m.AdjustStockTotalCountMock.defaultExpectation.expectationOrigins.origin, *m.AdjustStockTotalCountMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAdjustStockTotalCount != nil && afterAdjustStockTotalCountCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.AdjustStockTotalCount at\n%s", m.funcAdjustStockTotalCountOrigin)
+	}
+
+	if !m.AdjustStockTotalCountMock.invocationsDone() && afterAdjustStockTotalCountCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.AdjustStockTotalCount at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.AdjustStockTotalCountMock.expectedInvocations), m.AdjustStockTotalCountMock.expectedInvocationsOrigin, afterAdjustStockTotalCountCounter)
+	}
+}
+
 type mIStockRepositoryMockCancelReservedItems struct {
 	optional           bool
 	mock               *IStockRepositoryMock
@@ -790,55 +1265,53 @@ func (m *IStockRepositoryMock) MinimockGetAvailableStockBySKUInspect() {
 	}
 }
 
-type mIStockRepositoryMockRemoveReservedItems struct {
+type mIStockRepositoryMockGetStocksBySKUs struct {
 	optional           bool
 	mock               *IStockRepositoryMock
-	defaultExpectation *IStockRepositoryMockRemoveReservedItemsExpectation
-	expectations       []*IStockRepositoryMockRemoveReservedItemsExpectation
+	defaultExpectation *IStockRepositoryMockGetStocksBySKUsExpectation
+	expectations       []*IStockRepositoryMockGetStocksBySKUsExpectation
 
-	callArgs []*IStockRepositoryMockRemoveReservedItemsParams
+	callArgs []*IStockRepositoryMockGetStocksBySKUsParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// IStockRepositoryMockRemoveReservedItemsExpectation specifies expectation struct of the IStockRepository.RemoveReservedItems
-type IStockRepositoryMockRemoveReservedItemsExpectation struct {
+// IStockRepositoryMockGetStocksBySKUsExpectation specifies expectation struct of the IStockRepository.GetStocksBySKUs
+type IStockRepositoryMockGetStocksBySKUsExpectation struct {
 	mock               *IStockRepositoryMock
-	params             *IStockRepositoryMockRemoveReservedItemsParams
-	paramPtrs          *IStockRepositoryMockRemoveReservedItemsParamPtrs
-	expectationOrigins IStockRepositoryMockRemoveReservedItemsExpectationOrigins
-	results            *IStockRepositoryMockRemoveReservedItemsResults
+	params             *IStockRepositoryMockGetStocksBySKUsParams
+	paramPtrs          *IStockRepositoryMockGetStocksBySKUsParamPtrs
+	expectationOrigins IStockRepositoryMockGetStocksBySKUsExpectationOrigins
+	results            *IStockRepositoryMockGetStocksBySKUsResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// IStockRepositoryMockRemoveReservedItemsParams contains parameters of the IStockRepository.RemoveReservedItems
-type IStockRepositoryMockRemoveReservedItemsParams struct {
-	ctx   context.Context
-	tx    pgx.Tx
-	items []models.Item
+// IStockRepositoryMockGetStocksBySKUsParams contains parameters of the IStockRepository.GetStocksBySKUs
+type IStockRepositoryMockGetStocksBySKUsParams struct {
+	ctx  context.Context
+	SKUs []models.SKU
 }
 
-// IStockRepositoryMockRemoveReservedItemsParamPtrs contains pointers to parameters of the IStockRepository.RemoveReservedItems
-type IStockRepositoryMockRemoveReservedItemsParamPtrs struct {
-	ctx   *context.Context
-	tx    *pgx.Tx
-	items *[]models.Item
+// IStockRepositoryMockGetStocksBySKUsParamPtrs contains pointers to parameters of the IStockRepository.GetStocksBySKUs
+type IStockRepositoryMockGetStocksBySKUsParamPtrs struct {
+	ctx  *context.Context
+	SKUs *[]models.SKU
 }
 
-// IStockRepositoryMockRemoveReservedItemsResults contains results of the IStockRepository.RemoveReservedItems
-type IStockRepositoryMockRemoveReservedItemsResults struct {
+// IStockRepositoryMockGetStocksBySKUsResults contains results of the IStockRepository.GetStocksBySKUs
+type IStockRepositoryMockGetStocksBySKUsResults struct {
+	sa1 []models.Stock
 	err error
 }
 
-// IStockRepositoryMockRemoveReservedItemsOrigins contains origins of expectations of the IStockRepository.RemoveReservedItems
-type IStockRepositoryMockRemoveReservedItemsExpectationOrigins struct {
-	origin      string
-	originCtx   string
-	originTx    string
-	originItems string
+// IStockRepositoryMockGetStocksBySKUsOrigins contains origins of expectations of the IStockRepository.GetStocksBySKUs
+type IStockRepositoryMockGetStocksBySKUsExpectationOrigins struct {
+	origin     string
+	originCtx  string
+	originSKUs string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -846,372 +1319,2620 @@ type IStockRepositoryMockRemoveReservedItemsExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Optional() *mIStockRepositoryMockRemoveReservedItems {
-	mmRemoveReservedItems.optional = true
-	return mmRemoveReservedItems
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) Optional() *mIStockRepositoryMockGetStocksBySKUs {
+	mmGetStocksBySKUs.optional = true
+	return mmGetStocksBySKUs
 }
 
-// Expect sets up expected params for IStockRepository.RemoveReservedItems
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Expect(ctx context.Context, tx pgx.Tx, items []models.Item) *mIStockRepositoryMockRemoveReservedItems {
-	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+// Expect sets up expected params for IStockRepository.GetStocksBySKUs
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) Expect(ctx context.Context, SKUs []models.SKU) *mIStockRepositoryMockGetStocksBySKUs {
+	if mmGetStocksBySKUs.mock.funcGetStocksBySKUs != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("IStockRepositoryMock.GetStocksBySKUs mock is already set by Set")
 	}
 
-	if mmRemoveReservedItems.defaultExpectation == nil {
-		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{}
+	if mmGetStocksBySKUs.defaultExpectation == nil {
+		mmGetStocksBySKUs.defaultExpectation = &IStockRepositoryMockGetStocksBySKUsExpectation{}
 	}
 
-	if mmRemoveReservedItems.defaultExpectation.paramPtrs != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by ExpectParams functions")
+	if mmGetStocksBySKUs.defaultExpectation.paramPtrs != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("IStockRepositoryMock.GetStocksBySKUs mock is already set by ExpectParams functions")
 	}
 
-	mmRemoveReservedItems.defaultExpectation.params = &IStockRepositoryMockRemoveReservedItemsParams{ctx, tx, items}
-	mmRemoveReservedItems.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmRemoveReservedItems.expectations {
-		if minimock.Equal(e.params, mmRemoveReservedItems.defaultExpectation.params) {
-			mmRemoveReservedItems.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmRemoveReservedItems.defaultExpectation.params)
+	mmGetStocksBySKUs.defaultExpectation.params = &IStockRepositoryMockGetStocksBySKUsParams{ctx, SKUs}
+	mmGetStocksBySKUs.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetStocksBySKUs.expectations {
+		if minimock.Equal(e.params, mmGetStocksBySKUs.defaultExpectation.params) {
+			mmGetStocksBySKUs.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStocksBySKUs.defaultExpectation.params)
 		}
 	}
 
-	return mmRemoveReservedItems
+	return mmGetStocksBySKUs
 }
 
-// ExpectCtxParam1 sets up expected param ctx for IStockRepository.RemoveReservedItems
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.GetStocksBySKUs
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockGetStocksBySKUs {
+	if mmGetStocksBySKUs.mock.funcGetStocksBySKUs != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("IStockRepositoryMock.GetStocksBySKUs mock is already set by Set")
+	}
+
+	if mmGetStocksBySKUs.defaultExpectation == nil {
+		mmGetStocksBySKUs.defaultExpectation = &IStockRepositoryMockGetStocksBySKUsExpectation{}
+	}
+
+	if mmGetStocksBySKUs.defaultExpectation.params != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("IStockRepositoryMock.GetStocksBySKUs mock is already set by Expect")
+	}
+
+	if mmGetStocksBySKUs.defaultExpectation.paramPtrs == nil {
+		mmGetStocksBySKUs.defaultExpectation.paramPtrs = &IStockRepositoryMockGetStocksBySKUsParamPtrs{}
+	}
+	mmGetStocksBySKUs.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetStocksBySKUs.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetStocksBySKUs
+}
+
+// ExpectSKUsParam2 sets up expected param SKUs for IStockRepository.GetStocksBySKUs
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) ExpectSKUsParam2(SKUs []models.SKU) *mIStockRepositoryMockGetStocksBySKUs {
+	if mmGetStocksBySKUs.mock.funcGetStocksBySKUs != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("IStockRepositoryMock.GetStocksBySKUs mock is already set by Set")
+	}
+
+	if mmGetStocksBySKUs.defaultExpectation == nil {
+		mmGetStocksBySKUs.defaultExpectation = &IStockRepositoryMockGetStocksBySKUsExpectation{}
+	}
+
+	if mmGetStocksBySKUs.defaultExpectation.params != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("IStockRepositoryMock.GetStocksBySKUs mock is already set by Expect")
+	}
+
+	if mmGetStocksBySKUs.defaultExpectation.paramPtrs == nil {
+		mmGetStocksBySKUs.defaultExpectation.paramPtrs = &IStockRepositoryMockGetStocksBySKUsParamPtrs{}
+	}
+	mmGetStocksBySKUs.defaultExpectation.paramPtrs.SKUs = &SKUs
+	mmGetStocksBySKUs.defaultExpectation.expectationOrigins.originSKUs = minimock.CallerInfo(1)
+
+	return mmGetStocksBySKUs
+}
+
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.GetStocksBySKUs
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) Inspect(f func(ctx context.Context, SKUs []models.SKU)) *mIStockRepositoryMockGetStocksBySKUs {
+	if mmGetStocksBySKUs.mock.inspectFuncGetStocksBySKUs != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.GetStocksBySKUs")
+	}
+
+	mmGetStocksBySKUs.mock.inspectFuncGetStocksBySKUs = f
+
+	return mmGetStocksBySKUs
+}
+
+// Return sets up results that will be returned by IStockRepository.GetStocksBySKUs
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) Return(sa1 []models.Stock, err error) *IStockRepositoryMock {
+	if mmGetStocksBySKUs.mock.funcGetStocksBySKUs != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("IStockRepositoryMock.GetStocksBySKUs mock is already set by Set")
+	}
+
+	if mmGetStocksBySKUs.defaultExpectation == nil {
+		mmGetStocksBySKUs.defaultExpectation = &IStockRepositoryMockGetStocksBySKUsExpectation{mock: mmGetStocksBySKUs.mock}
+	}
+	mmGetStocksBySKUs.defaultExpectation.results = &IStockRepositoryMockGetStocksBySKUsResults{sa1, err}
+	mmGetStocksBySKUs.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetStocksBySKUs.mock
+}
+
+// Set uses given function f to mock the IStockRepository.GetStocksBySKUs method
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) Set(f func(ctx context.Context, SKUs []models.SKU) (sa1 []models.Stock, err error)) *IStockRepositoryMock {
+	if mmGetStocksBySKUs.defaultExpectation != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("Default expectation is already set for the IStockRepository.GetStocksBySKUs method")
+	}
+
+	if len(mmGetStocksBySKUs.expectations) > 0 {
+		mmGetStocksBySKUs.mock.t.Fatalf("Some expectations are already set for the IStockRepository.GetStocksBySKUs method")
+	}
+
+	mmGetStocksBySKUs.mock.funcGetStocksBySKUs = f
+	mmGetStocksBySKUs.mock.funcGetStocksBySKUsOrigin = minimock.CallerInfo(1)
+	return mmGetStocksBySKUs.mock
+}
+
+// When sets expectation for the IStockRepository.GetStocksBySKUs which will trigger the result defined by the following
+// Then helper
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) When(ctx context.Context, SKUs []models.SKU) *IStockRepositoryMockGetStocksBySKUsExpectation {
+	if mmGetStocksBySKUs.mock.funcGetStocksBySKUs != nil {
+		mmGetStocksBySKUs.mock.t.Fatalf("IStockRepositoryMock.GetStocksBySKUs mock is already set by Set")
+	}
+
+	expectation := &IStockRepositoryMockGetStocksBySKUsExpectation{
+		mock:               mmGetStocksBySKUs.mock,
+		params:             &IStockRepositoryMockGetStocksBySKUsParams{ctx, SKUs},
+		expectationOrigins: IStockRepositoryMockGetStocksBySKUsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetStocksBySKUs.expectations = append(mmGetStocksBySKUs.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IStockRepository.GetStocksBySKUs return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockGetStocksBySKUsExpectation) Then(sa1 []models.Stock, err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockGetStocksBySKUsResults{sa1, err}
+	return e.mock
+}
+
+// Times sets number of times IStockRepository.GetStocksBySKUs should be invoked
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) Times(n uint64) *mIStockRepositoryMockGetStocksBySKUs {
+	if n == 0 {
+		mmGetStocksBySKUs.mock.t.Fatalf("Times of IStockRepositoryMock.GetStocksBySKUs mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetStocksBySKUs.expectedInvocations, n)
+	mmGetStocksBySKUs.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetStocksBySKUs
+}
+
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) invocationsDone() bool {
+	if len(mmGetStocksBySKUs.expectations) == 0 && mmGetStocksBySKUs.defaultExpectation == nil && mmGetStocksBySKUs.mock.funcGetStocksBySKUs == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetStocksBySKUs.mock.afterGetStocksBySKUsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetStocksBySKUs.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetStocksBySKUs implements mm_service.IStockRepository
+func (mmGetStocksBySKUs *IStockRepositoryMock) GetStocksBySKUs(ctx context.Context, SKUs []models.SKU) (sa1 []models.Stock, err error) {
+	mm_atomic.AddUint64(&mmGetStocksBySKUs.beforeGetStocksBySKUsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetStocksBySKUs.afterGetStocksBySKUsCounter, 1)
+
+	mmGetStocksBySKUs.t.Helper()
+
+	if mmGetStocksBySKUs.inspectFuncGetStocksBySKUs != nil {
+		mmGetStocksBySKUs.inspectFuncGetStocksBySKUs(ctx, SKUs)
+	}
+
+	mm_params := IStockRepositoryMockGetStocksBySKUsParams{ctx, SKUs}
+
+	// Record call args
+	mmGetStocksBySKUs.GetStocksBySKUsMock.mutex.Lock()
+	mmGetStocksBySKUs.GetStocksBySKUsMock.callArgs = append(mmGetStocksBySKUs.GetStocksBySKUsMock.callArgs, &mm_params)
+	mmGetStocksBySKUs.GetStocksBySKUsMock.mutex.Unlock()
+
+	for _, e := range mmGetStocksBySKUs.GetStocksBySKUsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sa1, e.results.err
+		}
+	}
+
+	if mmGetStocksBySKUs.GetStocksBySKUsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetStocksBySKUs.GetStocksBySKUsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetStocksBySKUs.GetStocksBySKUsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetStocksBySKUs.GetStocksBySKUsMock.defaultExpectation.paramPtrs
+
+		mm_got := IStockRepositoryMockGetStocksBySKUsParams{ctx, SKUs}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetStocksBySKUs.t.Errorf("IStockRepositoryMock.GetStocksBySKUs got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStocksBySKUs.GetStocksBySKUsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.SKUs != nil && !minimock.Equal(*mm_want_ptrs.SKUs, mm_got.SKUs) {
+				mmGetStocksBySKUs.t.Errorf("IStockRepositoryMock.GetStocksBySKUs got unexpected parameter SKUs, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStocksBySKUs.GetStocksBySKUsMock.defaultExpectation.expectationOrigins.originSKUs, *mm_want_ptrs.SKUs, mm_got.SKUs, minimock.Diff(*mm_want_ptrs.SKUs, mm_got.SKUs))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetStocksBySKUs.t.Errorf("IStockRepositoryMock.GetStocksBySKUs got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetStocksBySKUs.GetStocksBySKUsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetStocksBySKUs.GetStocksBySKUsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetStocksBySKUs.t.Fatal("No results are set for the IStockRepositoryMock.GetStocksBySKUs")
+		}
+		return (*mm_results).sa1, (*mm_results).err
+	}
+	if mmGetStocksBySKUs.funcGetStocksBySKUs != nil {
+		return mmGetStocksBySKUs.funcGetStocksBySKUs(ctx, SKUs)
+	}
+	mmGetStocksBySKUs.t.Fatalf("Unexpected call to IStockRepositoryMock.GetStocksBySKUs. %v %v", ctx, SKUs)
+	return
+}
+
+// GetStocksBySKUsAfterCounter returns a count of finished IStockRepositoryMock.GetStocksBySKUs invocations
+func (mmGetStocksBySKUs *IStockRepositoryMock) GetStocksBySKUsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksBySKUs.afterGetStocksBySKUsCounter)
+}
+
+// GetStocksBySKUsBeforeCounter returns a count of IStockRepositoryMock.GetStocksBySKUs invocations
+func (mmGetStocksBySKUs *IStockRepositoryMock) GetStocksBySKUsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksBySKUs.beforeGetStocksBySKUsCounter)
+}
+
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.GetStocksBySKUs.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetStocksBySKUs *mIStockRepositoryMockGetStocksBySKUs) Calls() []*IStockRepositoryMockGetStocksBySKUsParams {
+	mmGetStocksBySKUs.mutex.RLock()
+
+	argCopy := make([]*IStockRepositoryMockGetStocksBySKUsParams, len(mmGetStocksBySKUs.callArgs))
+	copy(argCopy, mmGetStocksBySKUs.callArgs)
+
+	mmGetStocksBySKUs.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetStocksBySKUsDone returns true if the count of the GetStocksBySKUs invocations corresponds
+// the number of defined expectations
+func (m *IStockRepositoryMock) MinimockGetStocksBySKUsDone() bool {
+	if m.GetStocksBySKUsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetStocksBySKUsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetStocksBySKUsMock.invocationsDone()
+}
+
+// MinimockGetStocksBySKUsInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockGetStocksBySKUsInspect() {
+	for _, e := range m.GetStocksBySKUsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IStockRepositoryMock.GetStocksBySKUs at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetStocksBySKUsCounter := mm_atomic.LoadUint64(&m.afterGetStocksBySKUsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetStocksBySKUsMock.defaultExpectation != nil && afterGetStocksBySKUsCounter < 1 {
+		if m.GetStocksBySKUsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.GetStocksBySKUs at\n%s", m.GetStocksBySKUsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IStockRepositoryMock.GetStocksBySKUs at\n%s with params: %#v", m.GetStocksBySKUsMock.defaultExpectation.expectationOrigins.origin, *m.GetStocksBySKUsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetStocksBySKUs != nil && afterGetStocksBySKUsCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.GetStocksBySKUs at\n%s", m.funcGetStocksBySKUsOrigin)
+	}
+
+	if !m.GetStocksBySKUsMock.invocationsDone() && afterGetStocksBySKUsCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.GetStocksBySKUs at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetStocksBySKUsMock.expectedInvocations), m.GetStocksBySKUsMock.expectedInvocationsOrigin, afterGetStocksBySKUsCounter)
+	}
+}
+
+type mIStockRepositoryMockGetStocksForUpdate struct {
+	optional           bool
+	mock               *IStockRepositoryMock
+	defaultExpectation *IStockRepositoryMockGetStocksForUpdateExpectation
+	expectations       []*IStockRepositoryMockGetStocksForUpdateExpectation
+
+	callArgs []*IStockRepositoryMockGetStocksForUpdateParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IStockRepositoryMockGetStocksForUpdateExpectation specifies expectation struct of the IStockRepository.GetStocksForUpdate
+type IStockRepositoryMockGetStocksForUpdateExpectation struct {
+	mock               *IStockRepositoryMock
+	params             *IStockRepositoryMockGetStocksForUpdateParams
+	paramPtrs          *IStockRepositoryMockGetStocksForUpdateParamPtrs
+	expectationOrigins IStockRepositoryMockGetStocksForUpdateExpectationOrigins
+	results            *IStockRepositoryMockGetStocksForUpdateResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IStockRepositoryMockGetStocksForUpdateParams contains parameters of the IStockRepository.GetStocksForUpdate
+type IStockRepositoryMockGetStocksForUpdateParams struct {
+	ctx  context.Context
+	tx   pgx.Tx
+	SKUs []models.SKU
+}
+
+// IStockRepositoryMockGetStocksForUpdateParamPtrs contains pointers to parameters of the IStockRepository.GetStocksForUpdate
+type IStockRepositoryMockGetStocksForUpdateParamPtrs struct {
+	ctx  *context.Context
+	tx   *pgx.Tx
+	SKUs *[]models.SKU
+}
+
+// IStockRepositoryMockGetStocksForUpdateResults contains results of the IStockRepository.GetStocksForUpdate
+type IStockRepositoryMockGetStocksForUpdateResults struct {
+	sa1 []models.Stock
+	err error
+}
+
+// IStockRepositoryMockGetStocksForUpdateOrigins contains origins of expectations of the IStockRepository.GetStocksForUpdate
+type IStockRepositoryMockGetStocksForUpdateExpectationOrigins struct {
+	origin     string
+	originCtx  string
+	originTx   string
+	originSKUs string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) Optional() *mIStockRepositoryMockGetStocksForUpdate {
+	mmGetStocksForUpdate.optional = true
+	return mmGetStocksForUpdate
+}
+
+// Expect sets up expected params for IStockRepository.GetStocksForUpdate
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) Expect(ctx context.Context, tx pgx.Tx, SKUs []models.SKU) *mIStockRepositoryMockGetStocksForUpdate {
+	if mmGetStocksForUpdate.mock.funcGetStocksForUpdate != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Set")
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation == nil {
+		mmGetStocksForUpdate.defaultExpectation = &IStockRepositoryMockGetStocksForUpdateExpectation{}
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation.paramPtrs != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by ExpectParams functions")
+	}
+
+	mmGetStocksForUpdate.defaultExpectation.params = &IStockRepositoryMockGetStocksForUpdateParams{ctx, tx, SKUs}
+	mmGetStocksForUpdate.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetStocksForUpdate.expectations {
+		if minimock.Equal(e.params, mmGetStocksForUpdate.defaultExpectation.params) {
+			mmGetStocksForUpdate.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStocksForUpdate.defaultExpectation.params)
+		}
+	}
+
+	return mmGetStocksForUpdate
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.GetStocksForUpdate
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockGetStocksForUpdate {
+	if mmGetStocksForUpdate.mock.funcGetStocksForUpdate != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Set")
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation == nil {
+		mmGetStocksForUpdate.defaultExpectation = &IStockRepositoryMockGetStocksForUpdateExpectation{}
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation.params != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Expect")
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation.paramPtrs == nil {
+		mmGetStocksForUpdate.defaultExpectation.paramPtrs = &IStockRepositoryMockGetStocksForUpdateParamPtrs{}
+	}
+	mmGetStocksForUpdate.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetStocksForUpdate.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetStocksForUpdate
+}
+
+// ExpectTxParam2 sets up expected param tx for IStockRepository.GetStocksForUpdate
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockGetStocksForUpdate {
+	if mmGetStocksForUpdate.mock.funcGetStocksForUpdate != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Set")
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation == nil {
+		mmGetStocksForUpdate.defaultExpectation = &IStockRepositoryMockGetStocksForUpdateExpectation{}
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation.params != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Expect")
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation.paramPtrs == nil {
+		mmGetStocksForUpdate.defaultExpectation.paramPtrs = &IStockRepositoryMockGetStocksForUpdateParamPtrs{}
+	}
+	mmGetStocksForUpdate.defaultExpectation.paramPtrs.tx = &tx
+	mmGetStocksForUpdate.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmGetStocksForUpdate
+}
+
+// ExpectSKUsParam3 sets up expected param SKUs for IStockRepository.GetStocksForUpdate
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) ExpectSKUsParam3(SKUs []models.SKU) *mIStockRepositoryMockGetStocksForUpdate {
+	if mmGetStocksForUpdate.mock.funcGetStocksForUpdate != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Set")
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation == nil {
+		mmGetStocksForUpdate.defaultExpectation = &IStockRepositoryMockGetStocksForUpdateExpectation{}
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation.params != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Expect")
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation.paramPtrs == nil {
+		mmGetStocksForUpdate.defaultExpectation.paramPtrs = &IStockRepositoryMockGetStocksForUpdateParamPtrs{}
+	}
+	mmGetStocksForUpdate.defaultExpectation.paramPtrs.SKUs = &SKUs
+	mmGetStocksForUpdate.defaultExpectation.expectationOrigins.originSKUs = minimock.CallerInfo(1)
+
+	return mmGetStocksForUpdate
+}
+
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.GetStocksForUpdate
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) Inspect(f func(ctx context.Context, tx pgx.Tx, SKUs []models.SKU)) *mIStockRepositoryMockGetStocksForUpdate {
+	if mmGetStocksForUpdate.mock.inspectFuncGetStocksForUpdate != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.GetStocksForUpdate")
+	}
+
+	mmGetStocksForUpdate.mock.inspectFuncGetStocksForUpdate = f
+
+	return mmGetStocksForUpdate
+}
+
+// Return sets up results that will be returned by IStockRepository.GetStocksForUpdate
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) Return(sa1 []models.Stock, err error) *IStockRepositoryMock {
+	if mmGetStocksForUpdate.mock.funcGetStocksForUpdate != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Set")
+	}
+
+	if mmGetStocksForUpdate.defaultExpectation == nil {
+		mmGetStocksForUpdate.defaultExpectation = &IStockRepositoryMockGetStocksForUpdateExpectation{mock: mmGetStocksForUpdate.mock}
+	}
+	mmGetStocksForUpdate.defaultExpectation.results = &IStockRepositoryMockGetStocksForUpdateResults{sa1, err}
+	mmGetStocksForUpdate.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetStocksForUpdate.mock
+}
+
+// Set uses given function f to mock the IStockRepository.GetStocksForUpdate method
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) Set(f func(ctx context.Context, tx pgx.Tx, SKUs []models.SKU) (sa1 []models.Stock, err error)) *IStockRepositoryMock {
+	if mmGetStocksForUpdate.defaultExpectation != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("Default expectation is already set for the IStockRepository.GetStocksForUpdate method")
+	}
+
+	if len(mmGetStocksForUpdate.expectations) > 0 {
+		mmGetStocksForUpdate.mock.t.Fatalf("Some expectations are already set for the IStockRepository.GetStocksForUpdate method")
+	}
+
+	mmGetStocksForUpdate.mock.funcGetStocksForUpdate = f
+	mmGetStocksForUpdate.mock.funcGetStocksForUpdateOrigin = minimock.CallerInfo(1)
+	return mmGetStocksForUpdate.mock
+}
+
+// When sets expectation for the IStockRepository.GetStocksForUpdate which will trigger the result defined by the following
+// Then helper
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) When(ctx context.Context, tx pgx.Tx, SKUs []models.SKU) *IStockRepositoryMockGetStocksForUpdateExpectation {
+	if mmGetStocksForUpdate.mock.funcGetStocksForUpdate != nil {
+		mmGetStocksForUpdate.mock.t.Fatalf("IStockRepositoryMock.GetStocksForUpdate mock is already set by Set")
+	}
+
+	expectation := &IStockRepositoryMockGetStocksForUpdateExpectation{
+		mock:               mmGetStocksForUpdate.mock,
+		params:             &IStockRepositoryMockGetStocksForUpdateParams{ctx, tx, SKUs},
+		expectationOrigins: IStockRepositoryMockGetStocksForUpdateExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetStocksForUpdate.expectations = append(mmGetStocksForUpdate.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IStockRepository.GetStocksForUpdate return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockGetStocksForUpdateExpectation) Then(sa1 []models.Stock, err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockGetStocksForUpdateResults{sa1, err}
+	return e.mock
+}
+
+// Times sets number of times IStockRepository.GetStocksForUpdate should be invoked
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) Times(n uint64) *mIStockRepositoryMockGetStocksForUpdate {
+	if n == 0 {
+		mmGetStocksForUpdate.mock.t.Fatalf("Times of IStockRepositoryMock.GetStocksForUpdate mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetStocksForUpdate.expectedInvocations, n)
+	mmGetStocksForUpdate.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetStocksForUpdate
+}
+
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) invocationsDone() bool {
+	if len(mmGetStocksForUpdate.expectations) == 0 && mmGetStocksForUpdate.defaultExpectation == nil && mmGetStocksForUpdate.mock.funcGetStocksForUpdate == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetStocksForUpdate.mock.afterGetStocksForUpdateCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetStocksForUpdate.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetStocksForUpdate implements mm_service.IStockRepository
+func (mmGetStocksForUpdate *IStockRepositoryMock) GetStocksForUpdate(ctx context.Context, tx pgx.Tx, SKUs []models.SKU) (sa1 []models.Stock, err error) {
+	mm_atomic.AddUint64(&mmGetStocksForUpdate.beforeGetStocksForUpdateCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetStocksForUpdate.afterGetStocksForUpdateCounter, 1)
+
+	mmGetStocksForUpdate.t.Helper()
+
+	if mmGetStocksForUpdate.inspectFuncGetStocksForUpdate != nil {
+		mmGetStocksForUpdate.inspectFuncGetStocksForUpdate(ctx, tx, SKUs)
+	}
+
+	mm_params := IStockRepositoryMockGetStocksForUpdateParams{ctx, tx, SKUs}
+
+	// Record call args
+	mmGetStocksForUpdate.GetStocksForUpdateMock.mutex.Lock()
+	mmGetStocksForUpdate.GetStocksForUpdateMock.callArgs = append(mmGetStocksForUpdate.GetStocksForUpdateMock.callArgs, &mm_params)
+	mmGetStocksForUpdate.GetStocksForUpdateMock.mutex.Unlock()
+
+	for _, e := range mmGetStocksForUpdate.GetStocksForUpdateMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sa1, e.results.err
+		}
+	}
+
+	if mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation.params
+		mm_want_ptrs := mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation.paramPtrs
+
+		mm_got := IStockRepositoryMockGetStocksForUpdateParams{ctx, tx, SKUs}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetStocksForUpdate.t.Errorf("IStockRepositoryMock.GetStocksForUpdate got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmGetStocksForUpdate.t.Errorf("IStockRepositoryMock.GetStocksForUpdate got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.SKUs != nil && !minimock.Equal(*mm_want_ptrs.SKUs, mm_got.SKUs) {
+				mmGetStocksForUpdate.t.Errorf("IStockRepositoryMock.GetStocksForUpdate got unexpected parameter SKUs, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation.expectationOrigins.originSKUs, *mm_want_ptrs.SKUs, mm_got.SKUs, minimock.Diff(*mm_want_ptrs.SKUs, mm_got.SKUs))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetStocksForUpdate.t.Errorf("IStockRepositoryMock.GetStocksForUpdate got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetStocksForUpdate.GetStocksForUpdateMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetStocksForUpdate.t.Fatal("No results are set for the IStockRepositoryMock.GetStocksForUpdate")
+		}
+		return (*mm_results).sa1, (*mm_results).err
+	}
+	if mmGetStocksForUpdate.funcGetStocksForUpdate != nil {
+		return mmGetStocksForUpdate.funcGetStocksForUpdate(ctx, tx, SKUs)
+	}
+	mmGetStocksForUpdate.t.Fatalf("Unexpected call to IStockRepositoryMock.GetStocksForUpdate. %v %v %v", ctx, tx, SKUs)
+	return
+}
+
+// GetStocksForUpdateAfterCounter returns a count of finished IStockRepositoryMock.GetStocksForUpdate invocations
+func (mmGetStocksForUpdate *IStockRepositoryMock) GetStocksForUpdateAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksForUpdate.afterGetStocksForUpdateCounter)
+}
+
+// GetStocksForUpdateBeforeCounter returns a count of IStockRepositoryMock.GetStocksForUpdate invocations
+func (mmGetStocksForUpdate *IStockRepositoryMock) GetStocksForUpdateBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksForUpdate.beforeGetStocksForUpdateCounter)
+}
+
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.GetStocksForUpdate.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetStocksForUpdate *mIStockRepositoryMockGetStocksForUpdate) Calls() []*IStockRepositoryMockGetStocksForUpdateParams {
+	mmGetStocksForUpdate.mutex.RLock()
+
+	argCopy := make([]*IStockRepositoryMockGetStocksForUpdateParams, len(mmGetStocksForUpdate.callArgs))
+	copy(argCopy, mmGetStocksForUpdate.callArgs)
+
+	mmGetStocksForUpdate.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetStocksForUpdateDone returns true if the count of the GetStocksForUpdate invocations corresponds
+// the number of defined expectations
+func (m *IStockRepositoryMock) MinimockGetStocksForUpdateDone() bool {
+	if m.GetStocksForUpdateMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetStocksForUpdateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetStocksForUpdateMock.invocationsDone()
+}
+
+// MinimockGetStocksForUpdateInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockGetStocksForUpdateInspect() {
+	for _, e := range m.GetStocksForUpdateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IStockRepositoryMock.GetStocksForUpdate at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetStocksForUpdateCounter := mm_atomic.LoadUint64(&m.afterGetStocksForUpdateCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetStocksForUpdateMock.defaultExpectation != nil && afterGetStocksForUpdateCounter < 1 {
+		if m.GetStocksForUpdateMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.GetStocksForUpdate at\n%s", m.GetStocksForUpdateMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IStockRepositoryMock.GetStocksForUpdate at\n%s with params: %#v", m.GetStocksForUpdateMock.defaultExpectation.expectationOrigins.origin, *m.GetStocksForUpdateMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetStocksForUpdate != nil && afterGetStocksForUpdateCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.GetStocksForUpdate at\n%s", m.funcGetStocksForUpdateOrigin)
+	}
+
+	if !m.GetStocksForUpdateMock.invocationsDone() && afterGetStocksForUpdateCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.GetStocksForUpdate at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetStocksForUpdateMock.expectedInvocations), m.GetStocksForUpdateMock.expectedInvocationsOrigin, afterGetStocksForUpdateCounter)
+	}
+}
+
+type mIStockRepositoryMockImportStock struct {
+	optional           bool
+	mock               *IStockRepositoryMock
+	defaultExpectation *IStockRepositoryMockImportStockExpectation
+	expectations       []*IStockRepositoryMockImportStockExpectation
+
+	callArgs []*IStockRepositoryMockImportStockParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IStockRepositoryMockImportStockExpectation specifies expectation struct of the IStockRepository.ImportStock
+type IStockRepositoryMockImportStockExpectation struct {
+	mock               *IStockRepositoryMock
+	params             *IStockRepositoryMockImportStockParams
+	paramPtrs          *IStockRepositoryMockImportStockParamPtrs
+	expectationOrigins IStockRepositoryMockImportStockExpectationOrigins
+	results            *IStockRepositoryMockImportStockResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IStockRepositoryMockImportStockParams contains parameters of the IStockRepository.ImportStock
+type IStockRepositoryMockImportStockParams struct {
+	ctx   context.Context
+	tx    pgx.Tx
+	stock models.Stock
+}
+
+// IStockRepositoryMockImportStockParamPtrs contains pointers to parameters of the IStockRepository.ImportStock
+type IStockRepositoryMockImportStockParamPtrs struct {
+	ctx   *context.Context
+	tx    *pgx.Tx
+	stock *models.Stock
+}
+
+// IStockRepositoryMockImportStockResults contains results of the IStockRepository.ImportStock
+type IStockRepositoryMockImportStockResults struct {
+	s1  models.Stock
+	err error
+}
+
+// IStockRepositoryMockImportStockOrigins contains origins of expectations of the IStockRepository.ImportStock
+type IStockRepositoryMockImportStockExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originTx    string
+	originStock string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmImportStock *mIStockRepositoryMockImportStock) Optional() *mIStockRepositoryMockImportStock {
+	mmImportStock.optional = true
+	return mmImportStock
+}
+
+// Expect sets up expected params for IStockRepository.ImportStock
+func (mmImportStock *mIStockRepositoryMockImportStock) Expect(ctx context.Context, tx pgx.Tx, stock models.Stock) *mIStockRepositoryMockImportStock {
+	if mmImportStock.mock.funcImportStock != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Set")
+	}
+
+	if mmImportStock.defaultExpectation == nil {
+		mmImportStock.defaultExpectation = &IStockRepositoryMockImportStockExpectation{}
+	}
+
+	if mmImportStock.defaultExpectation.paramPtrs != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by ExpectParams functions")
+	}
+
+	mmImportStock.defaultExpectation.params = &IStockRepositoryMockImportStockParams{ctx, tx, stock}
+	mmImportStock.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmImportStock.expectations {
+		if minimock.Equal(e.params, mmImportStock.defaultExpectation.params) {
+			mmImportStock.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmImportStock.defaultExpectation.params)
+		}
+	}
+
+	return mmImportStock
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.ImportStock
+func (mmImportStock *mIStockRepositoryMockImportStock) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockImportStock {
+	if mmImportStock.mock.funcImportStock != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Set")
+	}
+
+	if mmImportStock.defaultExpectation == nil {
+		mmImportStock.defaultExpectation = &IStockRepositoryMockImportStockExpectation{}
+	}
+
+	if mmImportStock.defaultExpectation.params != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Expect")
+	}
+
+	if mmImportStock.defaultExpectation.paramPtrs == nil {
+		mmImportStock.defaultExpectation.paramPtrs = &IStockRepositoryMockImportStockParamPtrs{}
+	}
+	mmImportStock.defaultExpectation.paramPtrs.ctx = &ctx
+	mmImportStock.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmImportStock
+}
+
+// ExpectTxParam2 sets up expected param tx for IStockRepository.ImportStock
+func (mmImportStock *mIStockRepositoryMockImportStock) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockImportStock {
+	if mmImportStock.mock.funcImportStock != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Set")
+	}
+
+	if mmImportStock.defaultExpectation == nil {
+		mmImportStock.defaultExpectation = &IStockRepositoryMockImportStockExpectation{}
+	}
+
+	if mmImportStock.defaultExpectation.params != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Expect")
+	}
+
+	if mmImportStock.defaultExpectation.paramPtrs == nil {
+		mmImportStock.defaultExpectation.paramPtrs = &IStockRepositoryMockImportStockParamPtrs{}
+	}
+	mmImportStock.defaultExpectation.paramPtrs.tx = &tx
+	mmImportStock.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmImportStock
+}
+
+// ExpectStockParam3 sets up expected param stock for IStockRepository.ImportStock
+func (mmImportStock *mIStockRepositoryMockImportStock) ExpectStockParam3(stock models.Stock) *mIStockRepositoryMockImportStock {
+	if mmImportStock.mock.funcImportStock != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Set")
+	}
+
+	if mmImportStock.defaultExpectation == nil {
+		mmImportStock.defaultExpectation = &IStockRepositoryMockImportStockExpectation{}
+	}
+
+	if mmImportStock.defaultExpectation.params != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Expect")
+	}
+
+	if mmImportStock.defaultExpectation.paramPtrs == nil {
+		mmImportStock.defaultExpectation.paramPtrs = &IStockRepositoryMockImportStockParamPtrs{}
+	}
+	mmImportStock.defaultExpectation.paramPtrs.stock = &stock
+	mmImportStock.defaultExpectation.expectationOrigins.originStock = minimock.CallerInfo(1)
+
+	return mmImportStock
+}
+
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.ImportStock
+func (mmImportStock *mIStockRepositoryMockImportStock) Inspect(f func(ctx context.Context, tx pgx.Tx, stock models.Stock)) *mIStockRepositoryMockImportStock {
+	if mmImportStock.mock.inspectFuncImportStock != nil {
+		mmImportStock.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.ImportStock")
+	}
+
+	mmImportStock.mock.inspectFuncImportStock = f
+
+	return mmImportStock
+}
+
+// Return sets up results that will be returned by IStockRepository.ImportStock
+func (mmImportStock *mIStockRepositoryMockImportStock) Return(s1 models.Stock, err error) *IStockRepositoryMock {
+	if mmImportStock.mock.funcImportStock != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Set")
+	}
+
+	if mmImportStock.defaultExpectation == nil {
+		mmImportStock.defaultExpectation = &IStockRepositoryMockImportStockExpectation{mock: mmImportStock.mock}
+	}
+	mmImportStock.defaultExpectation.results = &IStockRepositoryMockImportStockResults{s1, err}
+	mmImportStock.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmImportStock.mock
+}
+
+// Set uses given function f to mock the IStockRepository.ImportStock method
+func (mmImportStock *mIStockRepositoryMockImportStock) Set(f func(ctx context.Context, tx pgx.Tx, stock models.Stock) (s1 models.Stock, err error)) *IStockRepositoryMock {
+	if mmImportStock.defaultExpectation != nil {
+		mmImportStock.mock.t.Fatalf("Default expectation is already set for the IStockRepository.ImportStock method")
+	}
+
+	if len(mmImportStock.expectations) > 0 {
+		mmImportStock.mock.t.Fatalf("Some expectations are already set for the IStockRepository.ImportStock method")
+	}
+
+	mmImportStock.mock.funcImportStock = f
+	mmImportStock.mock.funcImportStockOrigin = minimock.CallerInfo(1)
+	return mmImportStock.mock
+}
+
+// When sets expectation for the IStockRepository.ImportStock which will trigger the result defined by the following
+// Then helper
+func (mmImportStock *mIStockRepositoryMockImportStock) When(ctx context.Context, tx pgx.Tx, stock models.Stock) *IStockRepositoryMockImportStockExpectation {
+	if mmImportStock.mock.funcImportStock != nil {
+		mmImportStock.mock.t.Fatalf("IStockRepositoryMock.ImportStock mock is already set by Set")
+	}
+
+	expectation := &IStockRepositoryMockImportStockExpectation{
+		mock:               mmImportStock.mock,
+		params:             &IStockRepositoryMockImportStockParams{ctx, tx, stock},
+		expectationOrigins: IStockRepositoryMockImportStockExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmImportStock.expectations = append(mmImportStock.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IStockRepository.ImportStock return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockImportStockExpectation) Then(s1 models.Stock, err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockImportStockResults{s1, err}
+	return e.mock
+}
+
+// Times sets number of times IStockRepository.ImportStock should be invoked
+func (mmImportStock *mIStockRepositoryMockImportStock) Times(n uint64) *mIStockRepositoryMockImportStock {
+	if n == 0 {
+		mmImportStock.mock.t.Fatalf("Times of IStockRepositoryMock.ImportStock mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmImportStock.expectedInvocations, n)
+	mmImportStock.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmImportStock
+}
+
+func (mmImportStock *mIStockRepositoryMockImportStock) invocationsDone() bool {
+	if len(mmImportStock.expectations) == 0 && mmImportStock.defaultExpectation == nil && mmImportStock.mock.funcImportStock == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmImportStock.mock.afterImportStockCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmImportStock.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ImportStock implements mm_service.IStockRepository
+func (mmImportStock *IStockRepositoryMock) ImportStock(ctx context.Context, tx pgx.Tx, stock models.Stock) (s1 models.Stock, err error) {
+	mm_atomic.AddUint64(&mmImportStock.beforeImportStockCounter, 1)
+	defer mm_atomic.AddUint64(&mmImportStock.afterImportStockCounter, 1)
+
+	mmImportStock.t.Helper()
+
+	if mmImportStock.inspectFuncImportStock != nil {
+		mmImportStock.inspectFuncImportStock(ctx, tx, stock)
+	}
+
+	mm_params := IStockRepositoryMockImportStockParams{ctx, tx, stock}
+
+	// Record call args
+	mmImportStock.ImportStockMock.mutex.Lock()
+	mmImportStock.ImportStockMock.callArgs = append(mmImportStock.ImportStockMock.callArgs, &mm_params)
+	mmImportStock.ImportStockMock.mutex.Unlock()
+
+	for _, e := range mmImportStock.ImportStockMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.s1, e.results.err
+		}
+	}
+
+	if mmImportStock.ImportStockMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmImportStock.ImportStockMock.defaultExpectation.Counter, 1)
+		mm_want := mmImportStock.ImportStockMock.defaultExpectation.params
+		mm_want_ptrs := mmImportStock.ImportStockMock.defaultExpectation.paramPtrs
+
+		mm_got := IStockRepositoryMockImportStockParams{ctx, tx, stock}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmImportStock.t.Errorf("IStockRepositoryMock.ImportStock got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmImportStock.ImportStockMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmImportStock.t.Errorf("IStockRepositoryMock.ImportStock got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmImportStock.ImportStockMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.stock != nil && !minimock.Equal(*mm_want_ptrs.stock, mm_got.stock) {
+				mmImportStock.t.Errorf("IStockRepositoryMock.ImportStock got unexpected parameter stock, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmImportStock.ImportStockMock.defaultExpectation.expectationOrigins.originStock, *mm_want_ptrs.stock, mm_got.stock, minimock.Diff(*mm_want_ptrs.stock, mm_got.stock))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmImportStock.t.Errorf("IStockRepositoryMock.ImportStock got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmImportStock.ImportStockMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmImportStock.ImportStockMock.defaultExpectation.results
+		if mm_results == nil {
+			mmImportStock.t.Fatal("No results are set for the IStockRepositoryMock.ImportStock")
+		}
+		return (*mm_results).s1, (*mm_results).err
+	}
+	if mmImportStock.funcImportStock != nil {
+		return mmImportStock.funcImportStock(ctx, tx, stock)
+	}
+	mmImportStock.t.Fatalf("Unexpected call to IStockRepositoryMock.ImportStock. %v %v %v", ctx, tx, stock)
+	return
+}
+
+// ImportStockAfterCounter returns a count of finished IStockRepositoryMock.ImportStock invocations
+func (mmImportStock *IStockRepositoryMock) ImportStockAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmImportStock.afterImportStockCounter)
+}
+
+// ImportStockBeforeCounter returns a count of IStockRepositoryMock.ImportStock invocations
+func (mmImportStock *IStockRepositoryMock) ImportStockBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmImportStock.beforeImportStockCounter)
+}
+
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.ImportStock.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmImportStock *mIStockRepositoryMockImportStock) Calls() []*IStockRepositoryMockImportStockParams {
+	mmImportStock.mutex.RLock()
+
+	argCopy := make([]*IStockRepositoryMockImportStockParams, len(mmImportStock.callArgs))
+	copy(argCopy, mmImportStock.callArgs)
+
+	mmImportStock.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockImportStockDone returns true if the count of the ImportStock invocations corresponds
+// the number of defined expectations
+func (m *IStockRepositoryMock) MinimockImportStockDone() bool {
+	if m.ImportStockMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ImportStockMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ImportStockMock.invocationsDone()
+}
+
+// MinimockImportStockInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockImportStockInspect() {
+	for _, e := range m.ImportStockMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ImportStock at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterImportStockCounter := mm_atomic.LoadUint64(&m.afterImportStockCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ImportStockMock.defaultExpectation != nil && afterImportStockCounter < 1 {
+		if m.ImportStockMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ImportStock at\n%s", m.ImportStockMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ImportStock at\n%s with params: %#v", m.ImportStockMock.defaultExpectation.expectationOrigins.origin, *m.ImportStockMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcImportStock != nil && afterImportStockCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.ImportStock at\n%s", m.funcImportStockOrigin)
+	}
+
+	if !m.ImportStockMock.invocationsDone() && afterImportStockCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.ImportStock at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ImportStockMock.expectedInvocations), m.ImportStockMock.expectedInvocationsOrigin, afterImportStockCounter)
+	}
+}
+
+type mIStockRepositoryMockRemoveReservedItems struct {
+	optional           bool
+	mock               *IStockRepositoryMock
+	defaultExpectation *IStockRepositoryMockRemoveReservedItemsExpectation
+	expectations       []*IStockRepositoryMockRemoveReservedItemsExpectation
+
+	callArgs []*IStockRepositoryMockRemoveReservedItemsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IStockRepositoryMockRemoveReservedItemsExpectation specifies expectation struct of the IStockRepository.RemoveReservedItems
+type IStockRepositoryMockRemoveReservedItemsExpectation struct {
+	mock               *IStockRepositoryMock
+	params             *IStockRepositoryMockRemoveReservedItemsParams
+	paramPtrs          *IStockRepositoryMockRemoveReservedItemsParamPtrs
+	expectationOrigins IStockRepositoryMockRemoveReservedItemsExpectationOrigins
+	results            *IStockRepositoryMockRemoveReservedItemsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IStockRepositoryMockRemoveReservedItemsParams contains parameters of the IStockRepository.RemoveReservedItems
+type IStockRepositoryMockRemoveReservedItemsParams struct {
+	ctx   context.Context
+	tx    pgx.Tx
+	items []models.Item
+}
+
+// IStockRepositoryMockRemoveReservedItemsParamPtrs contains pointers to parameters of the IStockRepository.RemoveReservedItems
+type IStockRepositoryMockRemoveReservedItemsParamPtrs struct {
+	ctx   *context.Context
+	tx    *pgx.Tx
+	items *[]models.Item
+}
+
+// IStockRepositoryMockRemoveReservedItemsResults contains results of the IStockRepository.RemoveReservedItems
+type IStockRepositoryMockRemoveReservedItemsResults struct {
+	err error
+}
+
+// IStockRepositoryMockRemoveReservedItemsOrigins contains origins of expectations of the IStockRepository.RemoveReservedItems
+type IStockRepositoryMockRemoveReservedItemsExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originTx    string
+	originItems string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Optional() *mIStockRepositoryMockRemoveReservedItems {
+	mmRemoveReservedItems.optional = true
+	return mmRemoveReservedItems
+}
+
+// Expect sets up expected params for IStockRepository.RemoveReservedItems
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Expect(ctx context.Context, tx pgx.Tx, items []models.Item) *mIStockRepositoryMockRemoveReservedItems {
+	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+	}
+
+	if mmRemoveReservedItems.defaultExpectation == nil {
+		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{}
+	}
+
+	if mmRemoveReservedItems.defaultExpectation.paramPtrs != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by ExpectParams functions")
+	}
+
+	mmRemoveReservedItems.defaultExpectation.params = &IStockRepositoryMockRemoveReservedItemsParams{ctx, tx, items}
+	mmRemoveReservedItems.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmRemoveReservedItems.expectations {
+		if minimock.Equal(e.params, mmRemoveReservedItems.defaultExpectation.params) {
+			mmRemoveReservedItems.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmRemoveReservedItems.defaultExpectation.params)
+		}
+	}
+
+	return mmRemoveReservedItems
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.RemoveReservedItems
 func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockRemoveReservedItems {
 	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
 		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
 	}
 
-	if mmRemoveReservedItems.defaultExpectation == nil {
-		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{}
+	if mmRemoveReservedItems.defaultExpectation == nil {
+		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{}
+	}
+
+	if mmRemoveReservedItems.defaultExpectation.params != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Expect")
+	}
+
+	if mmRemoveReservedItems.defaultExpectation.paramPtrs == nil {
+		mmRemoveReservedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRemoveReservedItemsParamPtrs{}
+	}
+	mmRemoveReservedItems.defaultExpectation.paramPtrs.ctx = &ctx
+	mmRemoveReservedItems.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmRemoveReservedItems
+}
+
+// ExpectTxParam2 sets up expected param tx for IStockRepository.RemoveReservedItems
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockRemoveReservedItems {
+	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+	}
+
+	if mmRemoveReservedItems.defaultExpectation == nil {
+		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{}
+	}
+
+	if mmRemoveReservedItems.defaultExpectation.params != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Expect")
+	}
+
+	if mmRemoveReservedItems.defaultExpectation.paramPtrs == nil {
+		mmRemoveReservedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRemoveReservedItemsParamPtrs{}
+	}
+	mmRemoveReservedItems.defaultExpectation.paramPtrs.tx = &tx
+	mmRemoveReservedItems.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmRemoveReservedItems
+}
+
+// ExpectItemsParam3 sets up expected param items for IStockRepository.RemoveReservedItems
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) ExpectItemsParam3(items []models.Item) *mIStockRepositoryMockRemoveReservedItems {
+	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+	}
+
+	if mmRemoveReservedItems.defaultExpectation == nil {
+		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{}
+	}
+
+	if mmRemoveReservedItems.defaultExpectation.params != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Expect")
+	}
+
+	if mmRemoveReservedItems.defaultExpectation.paramPtrs == nil {
+		mmRemoveReservedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRemoveReservedItemsParamPtrs{}
+	}
+	mmRemoveReservedItems.defaultExpectation.paramPtrs.items = &items
+	mmRemoveReservedItems.defaultExpectation.expectationOrigins.originItems = minimock.CallerInfo(1)
+
+	return mmRemoveReservedItems
+}
+
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.RemoveReservedItems
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Inspect(f func(ctx context.Context, tx pgx.Tx, items []models.Item)) *mIStockRepositoryMockRemoveReservedItems {
+	if mmRemoveReservedItems.mock.inspectFuncRemoveReservedItems != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.RemoveReservedItems")
+	}
+
+	mmRemoveReservedItems.mock.inspectFuncRemoveReservedItems = f
+
+	return mmRemoveReservedItems
+}
+
+// Return sets up results that will be returned by IStockRepository.RemoveReservedItems
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Return(err error) *IStockRepositoryMock {
+	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+	}
+
+	if mmRemoveReservedItems.defaultExpectation == nil {
+		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{mock: mmRemoveReservedItems.mock}
+	}
+	mmRemoveReservedItems.defaultExpectation.results = &IStockRepositoryMockRemoveReservedItemsResults{err}
+	mmRemoveReservedItems.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmRemoveReservedItems.mock
+}
+
+// Set uses given function f to mock the IStockRepository.RemoveReservedItems method
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Set(f func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)) *IStockRepositoryMock {
+	if mmRemoveReservedItems.defaultExpectation != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("Default expectation is already set for the IStockRepository.RemoveReservedItems method")
+	}
+
+	if len(mmRemoveReservedItems.expectations) > 0 {
+		mmRemoveReservedItems.mock.t.Fatalf("Some expectations are already set for the IStockRepository.RemoveReservedItems method")
+	}
+
+	mmRemoveReservedItems.mock.funcRemoveReservedItems = f
+	mmRemoveReservedItems.mock.funcRemoveReservedItemsOrigin = minimock.CallerInfo(1)
+	return mmRemoveReservedItems.mock
+}
+
+// When sets expectation for the IStockRepository.RemoveReservedItems which will trigger the result defined by the following
+// Then helper
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) When(ctx context.Context, tx pgx.Tx, items []models.Item) *IStockRepositoryMockRemoveReservedItemsExpectation {
+	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
+		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+	}
+
+	expectation := &IStockRepositoryMockRemoveReservedItemsExpectation{
+		mock:               mmRemoveReservedItems.mock,
+		params:             &IStockRepositoryMockRemoveReservedItemsParams{ctx, tx, items},
+		expectationOrigins: IStockRepositoryMockRemoveReservedItemsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmRemoveReservedItems.expectations = append(mmRemoveReservedItems.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IStockRepository.RemoveReservedItems return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockRemoveReservedItemsExpectation) Then(err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockRemoveReservedItemsResults{err}
+	return e.mock
+}
+
+// Times sets number of times IStockRepository.RemoveReservedItems should be invoked
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Times(n uint64) *mIStockRepositoryMockRemoveReservedItems {
+	if n == 0 {
+		mmRemoveReservedItems.mock.t.Fatalf("Times of IStockRepositoryMock.RemoveReservedItems mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmRemoveReservedItems.expectedInvocations, n)
+	mmRemoveReservedItems.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmRemoveReservedItems
+}
+
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) invocationsDone() bool {
+	if len(mmRemoveReservedItems.expectations) == 0 && mmRemoveReservedItems.defaultExpectation == nil && mmRemoveReservedItems.mock.funcRemoveReservedItems == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmRemoveReservedItems.mock.afterRemoveReservedItemsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmRemoveReservedItems.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// RemoveReservedItems implements mm_service.IStockRepository
+func (mmRemoveReservedItems *IStockRepositoryMock) RemoveReservedItems(ctx context.Context, tx pgx.Tx, items []models.Item) (err error) {
+	mm_atomic.AddUint64(&mmRemoveReservedItems.beforeRemoveReservedItemsCounter, 1)
+	defer mm_atomic.AddUint64(&mmRemoveReservedItems.afterRemoveReservedItemsCounter, 1)
+
+	mmRemoveReservedItems.t.Helper()
+
+	if mmRemoveReservedItems.inspectFuncRemoveReservedItems != nil {
+		mmRemoveReservedItems.inspectFuncRemoveReservedItems(ctx, tx, items)
+	}
+
+	mm_params := IStockRepositoryMockRemoveReservedItemsParams{ctx, tx, items}
+
+	// Record call args
+	mmRemoveReservedItems.RemoveReservedItemsMock.mutex.Lock()
+	mmRemoveReservedItems.RemoveReservedItemsMock.callArgs = append(mmRemoveReservedItems.RemoveReservedItemsMock.callArgs, &mm_params)
+	mmRemoveReservedItems.RemoveReservedItemsMock.mutex.Unlock()
+
+	for _, e := range mmRemoveReservedItems.RemoveReservedItemsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.Counter, 1)
+		mm_want := mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.params
+		mm_want_ptrs := mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.paramPtrs
+
+		mm_got := IStockRepositoryMockRemoveReservedItemsParams{ctx, tx, items}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmRemoveReservedItems.t.Errorf("IStockRepositoryMock.RemoveReservedItems got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmRemoveReservedItems.t.Errorf("IStockRepositoryMock.RemoveReservedItems got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.items != nil && !minimock.Equal(*mm_want_ptrs.items, mm_got.items) {
+				mmRemoveReservedItems.t.Errorf("IStockRepositoryMock.RemoveReservedItems got unexpected parameter items, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmRemoveReservedItems.t.Errorf("IStockRepositoryMock.RemoveReservedItems got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmRemoveReservedItems.t.Fatal("No results are set for the IStockRepositoryMock.RemoveReservedItems")
+		}
+		return (*mm_results).err
+	}
+	if mmRemoveReservedItems.funcRemoveReservedItems != nil {
+		return mmRemoveReservedItems.funcRemoveReservedItems(ctx, tx, items)
+	}
+	mmRemoveReservedItems.t.Fatalf("Unexpected call to IStockRepositoryMock.RemoveReservedItems. %v %v %v", ctx, tx, items)
+	return
+}
+
+// RemoveReservedItemsAfterCounter returns a count of finished IStockRepositoryMock.RemoveReservedItems invocations
+func (mmRemoveReservedItems *IStockRepositoryMock) RemoveReservedItemsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRemoveReservedItems.afterRemoveReservedItemsCounter)
+}
+
+// RemoveReservedItemsBeforeCounter returns a count of IStockRepositoryMock.RemoveReservedItems invocations
+func (mmRemoveReservedItems *IStockRepositoryMock) RemoveReservedItemsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRemoveReservedItems.beforeRemoveReservedItemsCounter)
+}
+
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.RemoveReservedItems.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Calls() []*IStockRepositoryMockRemoveReservedItemsParams {
+	mmRemoveReservedItems.mutex.RLock()
+
+	argCopy := make([]*IStockRepositoryMockRemoveReservedItemsParams, len(mmRemoveReservedItems.callArgs))
+	copy(argCopy, mmRemoveReservedItems.callArgs)
+
+	mmRemoveReservedItems.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockRemoveReservedItemsDone returns true if the count of the RemoveReservedItems invocations corresponds
+// the number of defined expectations
+func (m *IStockRepositoryMock) MinimockRemoveReservedItemsDone() bool {
+	if m.RemoveReservedItemsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.RemoveReservedItemsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.RemoveReservedItemsMock.invocationsDone()
+}
+
+// MinimockRemoveReservedItemsInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockRemoveReservedItemsInspect() {
+	for _, e := range m.RemoveReservedItemsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IStockRepositoryMock.RemoveReservedItems at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterRemoveReservedItemsCounter := mm_atomic.LoadUint64(&m.afterRemoveReservedItemsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.RemoveReservedItemsMock.defaultExpectation != nil && afterRemoveReservedItemsCounter < 1 {
+		if m.RemoveReservedItemsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.RemoveReservedItems at\n%s", m.RemoveReservedItemsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IStockRepositoryMock.RemoveReservedItems at\n%s with params: %#v", m.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.origin, *m.RemoveReservedItemsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcRemoveReservedItems != nil && afterRemoveReservedItemsCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.RemoveReservedItems at\n%s", m.funcRemoveReservedItemsOrigin)
+	}
+
+	if !m.RemoveReservedItemsMock.invocationsDone() && afterRemoveReservedItemsCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.RemoveReservedItems at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.RemoveReservedItemsMock.expectedInvocations), m.RemoveReservedItemsMock.expectedInvocationsOrigin, afterRemoveReservedItemsCounter)
+	}
+}
+
+type mIStockRepositoryMockReplenishStock struct {
+	optional           bool
+	mock               *IStockRepositoryMock
+	defaultExpectation *IStockRepositoryMockReplenishStockExpectation
+	expectations       []*IStockRepositoryMockReplenishStockExpectation
+
+	callArgs []*IStockRepositoryMockReplenishStockParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IStockRepositoryMockReplenishStockExpectation specifies expectation struct of the IStockRepository.ReplenishStock
+type IStockRepositoryMockReplenishStockExpectation struct {
+	mock               *IStockRepositoryMock
+	params             *IStockRepositoryMockReplenishStockParams
+	paramPtrs          *IStockRepositoryMockReplenishStockParamPtrs
+	expectationOrigins IStockRepositoryMockReplenishStockExpectationOrigins
+	results            *IStockRepositoryMockReplenishStockResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IStockRepositoryMockReplenishStockParams contains parameters of the IStockRepository.ReplenishStock
+type IStockRepositoryMockReplenishStockParams struct {
+	ctx   context.Context
+	tx    pgx.Tx
+	SKU   models.SKU
+	count uint64
+}
+
+// IStockRepositoryMockReplenishStockParamPtrs contains pointers to parameters of the IStockRepository.ReplenishStock
+type IStockRepositoryMockReplenishStockParamPtrs struct {
+	ctx   *context.Context
+	tx    *pgx.Tx
+	SKU   *models.SKU
+	count *uint64
+}
+
+// IStockRepositoryMockReplenishStockResults contains results of the IStockRepository.ReplenishStock
+type IStockRepositoryMockReplenishStockResults struct {
+	s1  models.Stock
+	err error
+}
+
+// IStockRepositoryMockReplenishStockOrigins contains origins of expectations of the IStockRepository.ReplenishStock
+type IStockRepositoryMockReplenishStockExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originTx    string
+	originSKU   string
+	originCount string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) Optional() *mIStockRepositoryMockReplenishStock {
+	mmReplenishStock.optional = true
+	return mmReplenishStock
+}
+
+// Expect sets up expected params for IStockRepository.ReplenishStock
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) Expect(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64) *mIStockRepositoryMockReplenishStock {
+	if mmReplenishStock.mock.funcReplenishStock != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Set")
+	}
+
+	if mmReplenishStock.defaultExpectation == nil {
+		mmReplenishStock.defaultExpectation = &IStockRepositoryMockReplenishStockExpectation{}
+	}
+
+	if mmReplenishStock.defaultExpectation.paramPtrs != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by ExpectParams functions")
+	}
+
+	mmReplenishStock.defaultExpectation.params = &IStockRepositoryMockReplenishStockParams{ctx, tx, SKU, count}
+	mmReplenishStock.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmReplenishStock.expectations {
+		if minimock.Equal(e.params, mmReplenishStock.defaultExpectation.params) {
+			mmReplenishStock.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReplenishStock.defaultExpectation.params)
+		}
+	}
+
+	return mmReplenishStock
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.ReplenishStock
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockReplenishStock {
+	if mmReplenishStock.mock.funcReplenishStock != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Set")
+	}
+
+	if mmReplenishStock.defaultExpectation == nil {
+		mmReplenishStock.defaultExpectation = &IStockRepositoryMockReplenishStockExpectation{}
+	}
+
+	if mmReplenishStock.defaultExpectation.params != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Expect")
+	}
+
+	if mmReplenishStock.defaultExpectation.paramPtrs == nil {
+		mmReplenishStock.defaultExpectation.paramPtrs = &IStockRepositoryMockReplenishStockParamPtrs{}
+	}
+	mmReplenishStock.defaultExpectation.paramPtrs.ctx = &ctx
+	mmReplenishStock.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmReplenishStock
+}
+
+// ExpectTxParam2 sets up expected param tx for IStockRepository.ReplenishStock
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockReplenishStock {
+	if mmReplenishStock.mock.funcReplenishStock != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Set")
+	}
+
+	if mmReplenishStock.defaultExpectation == nil {
+		mmReplenishStock.defaultExpectation = &IStockRepositoryMockReplenishStockExpectation{}
+	}
+
+	if mmReplenishStock.defaultExpectation.params != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Expect")
+	}
+
+	if mmReplenishStock.defaultExpectation.paramPtrs == nil {
+		mmReplenishStock.defaultExpectation.paramPtrs = &IStockRepositoryMockReplenishStockParamPtrs{}
+	}
+	mmReplenishStock.defaultExpectation.paramPtrs.tx = &tx
+	mmReplenishStock.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmReplenishStock
+}
+
+// ExpectSKUParam3 sets up expected param SKU for IStockRepository.ReplenishStock
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) ExpectSKUParam3(SKU models.SKU) *mIStockRepositoryMockReplenishStock {
+	if mmReplenishStock.mock.funcReplenishStock != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Set")
+	}
+
+	if mmReplenishStock.defaultExpectation == nil {
+		mmReplenishStock.defaultExpectation = &IStockRepositoryMockReplenishStockExpectation{}
+	}
+
+	if mmReplenishStock.defaultExpectation.params != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Expect")
+	}
+
+	if mmReplenishStock.defaultExpectation.paramPtrs == nil {
+		mmReplenishStock.defaultExpectation.paramPtrs = &IStockRepositoryMockReplenishStockParamPtrs{}
+	}
+	mmReplenishStock.defaultExpectation.paramPtrs.SKU = &SKU
+	mmReplenishStock.defaultExpectation.expectationOrigins.originSKU = minimock.CallerInfo(1)
+
+	return mmReplenishStock
+}
+
+// ExpectCountParam4 sets up expected param count for IStockRepository.ReplenishStock
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) ExpectCountParam4(count uint64) *mIStockRepositoryMockReplenishStock {
+	if mmReplenishStock.mock.funcReplenishStock != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Set")
+	}
+
+	if mmReplenishStock.defaultExpectation == nil {
+		mmReplenishStock.defaultExpectation = &IStockRepositoryMockReplenishStockExpectation{}
+	}
+
+	if mmReplenishStock.defaultExpectation.params != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Expect")
+	}
+
+	if mmReplenishStock.defaultExpectation.paramPtrs == nil {
+		mmReplenishStock.defaultExpectation.paramPtrs = &IStockRepositoryMockReplenishStockParamPtrs{}
+	}
+	mmReplenishStock.defaultExpectation.paramPtrs.count = &count
+	mmReplenishStock.defaultExpectation.expectationOrigins.originCount = minimock.CallerInfo(1)
+
+	return mmReplenishStock
+}
+
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.ReplenishStock
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) Inspect(f func(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64)) *mIStockRepositoryMockReplenishStock {
+	if mmReplenishStock.mock.inspectFuncReplenishStock != nil {
+		mmReplenishStock.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.ReplenishStock")
+	}
+
+	mmReplenishStock.mock.inspectFuncReplenishStock = f
+
+	return mmReplenishStock
+}
+
+// Return sets up results that will be returned by IStockRepository.ReplenishStock
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) Return(s1 models.Stock, err error) *IStockRepositoryMock {
+	if mmReplenishStock.mock.funcReplenishStock != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Set")
+	}
+
+	if mmReplenishStock.defaultExpectation == nil {
+		mmReplenishStock.defaultExpectation = &IStockRepositoryMockReplenishStockExpectation{mock: mmReplenishStock.mock}
+	}
+	mmReplenishStock.defaultExpectation.results = &IStockRepositoryMockReplenishStockResults{s1, err}
+	mmReplenishStock.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmReplenishStock.mock
+}
+
+// Set uses given function f to mock the IStockRepository.ReplenishStock method
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) Set(f func(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64) (s1 models.Stock, err error)) *IStockRepositoryMock {
+	if mmReplenishStock.defaultExpectation != nil {
+		mmReplenishStock.mock.t.Fatalf("Default expectation is already set for the IStockRepository.ReplenishStock method")
+	}
+
+	if len(mmReplenishStock.expectations) > 0 {
+		mmReplenishStock.mock.t.Fatalf("Some expectations are already set for the IStockRepository.ReplenishStock method")
+	}
+
+	mmReplenishStock.mock.funcReplenishStock = f
+	mmReplenishStock.mock.funcReplenishStockOrigin = minimock.CallerInfo(1)
+	return mmReplenishStock.mock
+}
+
+// When sets expectation for the IStockRepository.ReplenishStock which will trigger the result defined by the following
+// Then helper
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) When(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64) *IStockRepositoryMockReplenishStockExpectation {
+	if mmReplenishStock.mock.funcReplenishStock != nil {
+		mmReplenishStock.mock.t.Fatalf("IStockRepositoryMock.ReplenishStock mock is already set by Set")
+	}
+
+	expectation := &IStockRepositoryMockReplenishStockExpectation{
+		mock:               mmReplenishStock.mock,
+		params:             &IStockRepositoryMockReplenishStockParams{ctx, tx, SKU, count},
+		expectationOrigins: IStockRepositoryMockReplenishStockExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmReplenishStock.expectations = append(mmReplenishStock.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IStockRepository.ReplenishStock return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockReplenishStockExpectation) Then(s1 models.Stock, err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockReplenishStockResults{s1, err}
+	return e.mock
+}
+
+// Times sets number of times IStockRepository.ReplenishStock should be invoked
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) Times(n uint64) *mIStockRepositoryMockReplenishStock {
+	if n == 0 {
+		mmReplenishStock.mock.t.Fatalf("Times of IStockRepositoryMock.ReplenishStock mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmReplenishStock.expectedInvocations, n)
+	mmReplenishStock.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmReplenishStock
+}
+
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) invocationsDone() bool {
+	if len(mmReplenishStock.expectations) == 0 && mmReplenishStock.defaultExpectation == nil && mmReplenishStock.mock.funcReplenishStock == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmReplenishStock.mock.afterReplenishStockCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmReplenishStock.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ReplenishStock implements mm_service.IStockRepository
+func (mmReplenishStock *IStockRepositoryMock) ReplenishStock(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64) (s1 models.Stock, err error) {
+	mm_atomic.AddUint64(&mmReplenishStock.beforeReplenishStockCounter, 1)
+	defer mm_atomic.AddUint64(&mmReplenishStock.afterReplenishStockCounter, 1)
+
+	mmReplenishStock.t.Helper()
+
+	if mmReplenishStock.inspectFuncReplenishStock != nil {
+		mmReplenishStock.inspectFuncReplenishStock(ctx, tx, SKU, count)
+	}
+
+	mm_params := IStockRepositoryMockReplenishStockParams{ctx, tx, SKU, count}
+
+	// Record call args
+	mmReplenishStock.ReplenishStockMock.mutex.Lock()
+	mmReplenishStock.ReplenishStockMock.callArgs = append(mmReplenishStock.ReplenishStockMock.callArgs, &mm_params)
+	mmReplenishStock.ReplenishStockMock.mutex.Unlock()
+
+	for _, e := range mmReplenishStock.ReplenishStockMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.s1, e.results.err
+		}
+	}
+
+	if mmReplenishStock.ReplenishStockMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmReplenishStock.ReplenishStockMock.defaultExpectation.Counter, 1)
+		mm_want := mmReplenishStock.ReplenishStockMock.defaultExpectation.params
+		mm_want_ptrs := mmReplenishStock.ReplenishStockMock.defaultExpectation.paramPtrs
+
+		mm_got := IStockRepositoryMockReplenishStockParams{ctx, tx, SKU, count}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmReplenishStock.t.Errorf("IStockRepositoryMock.ReplenishStock got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReplenishStock.ReplenishStockMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmReplenishStock.t.Errorf("IStockRepositoryMock.ReplenishStock got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReplenishStock.ReplenishStockMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.SKU != nil && !minimock.Equal(*mm_want_ptrs.SKU, mm_got.SKU) {
+				mmReplenishStock.t.Errorf("IStockRepositoryMock.ReplenishStock got unexpected parameter SKU, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReplenishStock.ReplenishStockMock.defaultExpectation.expectationOrigins.originSKU, *mm_want_ptrs.SKU, mm_got.SKU, minimock.Diff(*mm_want_ptrs.SKU, mm_got.SKU))
+			}
+
+			if mm_want_ptrs.count != nil && !minimock.Equal(*mm_want_ptrs.count, mm_got.count) {
+				mmReplenishStock.t.Errorf("IStockRepositoryMock.ReplenishStock got unexpected parameter count, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReplenishStock.ReplenishStockMock.defaultExpectation.expectationOrigins.originCount, *mm_want_ptrs.count, mm_got.count, minimock.Diff(*mm_want_ptrs.count, mm_got.count))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmReplenishStock.t.Errorf("IStockRepositoryMock.ReplenishStock got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmReplenishStock.ReplenishStockMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmReplenishStock.ReplenishStockMock.defaultExpectation.results
+		if mm_results == nil {
+			mmReplenishStock.t.Fatal("No results are set for the IStockRepositoryMock.ReplenishStock")
+		}
+		return (*mm_results).s1, (*mm_results).err
+	}
+	if mmReplenishStock.funcReplenishStock != nil {
+		return mmReplenishStock.funcReplenishStock(ctx, tx, SKU, count)
+	}
+	mmReplenishStock.t.Fatalf("Unexpected call to IStockRepositoryMock.ReplenishStock. %v %v %v %v", ctx, tx, SKU, count)
+	return
+}
+
+// ReplenishStockAfterCounter returns a count of finished IStockRepositoryMock.ReplenishStock invocations
+func (mmReplenishStock *IStockRepositoryMock) ReplenishStockAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReplenishStock.afterReplenishStockCounter)
+}
+
+// ReplenishStockBeforeCounter returns a count of IStockRepositoryMock.ReplenishStock invocations
+func (mmReplenishStock *IStockRepositoryMock) ReplenishStockBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReplenishStock.beforeReplenishStockCounter)
+}
+
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.ReplenishStock.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmReplenishStock *mIStockRepositoryMockReplenishStock) Calls() []*IStockRepositoryMockReplenishStockParams {
+	mmReplenishStock.mutex.RLock()
+
+	argCopy := make([]*IStockRepositoryMockReplenishStockParams, len(mmReplenishStock.callArgs))
+	copy(argCopy, mmReplenishStock.callArgs)
+
+	mmReplenishStock.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockReplenishStockDone returns true if the count of the ReplenishStock invocations corresponds
+// the number of defined expectations
+func (m *IStockRepositoryMock) MinimockReplenishStockDone() bool {
+	if m.ReplenishStockMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ReplenishStockMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ReplenishStockMock.invocationsDone()
+}
+
+// MinimockReplenishStockInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockReplenishStockInspect() {
+	for _, e := range m.ReplenishStockMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ReplenishStock at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterReplenishStockCounter := mm_atomic.LoadUint64(&m.afterReplenishStockCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReplenishStockMock.defaultExpectation != nil && afterReplenishStockCounter < 1 {
+		if m.ReplenishStockMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ReplenishStock at\n%s", m.ReplenishStockMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ReplenishStock at\n%s with params: %#v", m.ReplenishStockMock.defaultExpectation.expectationOrigins.origin, *m.ReplenishStockMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReplenishStock != nil && afterReplenishStockCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.ReplenishStock at\n%s", m.funcReplenishStockOrigin)
+	}
+
+	if !m.ReplenishStockMock.invocationsDone() && afterReplenishStockCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.ReplenishStock at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ReplenishStockMock.expectedInvocations), m.ReplenishStockMock.expectedInvocationsOrigin, afterReplenishStockCounter)
+	}
+}
+
+type mIStockRepositoryMockReserveItems struct {
+	optional           bool
+	mock               *IStockRepositoryMock
+	defaultExpectation *IStockRepositoryMockReserveItemsExpectation
+	expectations       []*IStockRepositoryMockReserveItemsExpectation
+
+	callArgs []*IStockRepositoryMockReserveItemsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IStockRepositoryMockReserveItemsExpectation specifies expectation struct of the IStockRepository.ReserveItems
+type IStockRepositoryMockReserveItemsExpectation struct {
+	mock               *IStockRepositoryMock
+	params             *IStockRepositoryMockReserveItemsParams
+	paramPtrs          *IStockRepositoryMockReserveItemsParamPtrs
+	expectationOrigins IStockRepositoryMockReserveItemsExpectationOrigins
+	results            *IStockRepositoryMockReserveItemsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IStockRepositoryMockReserveItemsParams contains parameters of the IStockRepository.ReserveItems
+type IStockRepositoryMockReserveItemsParams struct {
+	ctx   context.Context
+	tx    pgx.Tx
+	items []models.Item
+}
+
+// IStockRepositoryMockReserveItemsParamPtrs contains pointers to parameters of the IStockRepository.ReserveItems
+type IStockRepositoryMockReserveItemsParamPtrs struct {
+	ctx   *context.Context
+	tx    *pgx.Tx
+	items *[]models.Item
+}
+
+// IStockRepositoryMockReserveItemsResults contains results of the IStockRepository.ReserveItems
+type IStockRepositoryMockReserveItemsResults struct {
+	err error
+}
+
+// IStockRepositoryMockReserveItemsOrigins contains origins of expectations of the IStockRepository.ReserveItems
+type IStockRepositoryMockReserveItemsExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originTx    string
+	originItems string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmReserveItems *mIStockRepositoryMockReserveItems) Optional() *mIStockRepositoryMockReserveItems {
+	mmReserveItems.optional = true
+	return mmReserveItems
+}
+
+// Expect sets up expected params for IStockRepository.ReserveItems
+func (mmReserveItems *mIStockRepositoryMockReserveItems) Expect(ctx context.Context, tx pgx.Tx, items []models.Item) *mIStockRepositoryMockReserveItems {
+	if mmReserveItems.mock.funcReserveItems != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+	}
+
+	if mmReserveItems.defaultExpectation == nil {
+		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{}
+	}
+
+	if mmReserveItems.defaultExpectation.paramPtrs != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by ExpectParams functions")
+	}
+
+	mmReserveItems.defaultExpectation.params = &IStockRepositoryMockReserveItemsParams{ctx, tx, items}
+	mmReserveItems.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmReserveItems.expectations {
+		if minimock.Equal(e.params, mmReserveItems.defaultExpectation.params) {
+			mmReserveItems.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReserveItems.defaultExpectation.params)
+		}
+	}
+
+	return mmReserveItems
+}
+
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.ReserveItems
+func (mmReserveItems *mIStockRepositoryMockReserveItems) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockReserveItems {
+	if mmReserveItems.mock.funcReserveItems != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+	}
+
+	if mmReserveItems.defaultExpectation == nil {
+		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{}
+	}
+
+	if mmReserveItems.defaultExpectation.params != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Expect")
+	}
+
+	if mmReserveItems.defaultExpectation.paramPtrs == nil {
+		mmReserveItems.defaultExpectation.paramPtrs = &IStockRepositoryMockReserveItemsParamPtrs{}
+	}
+	mmReserveItems.defaultExpectation.paramPtrs.ctx = &ctx
+	mmReserveItems.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmReserveItems
+}
+
+// ExpectTxParam2 sets up expected param tx for IStockRepository.ReserveItems
+func (mmReserveItems *mIStockRepositoryMockReserveItems) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockReserveItems {
+	if mmReserveItems.mock.funcReserveItems != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+	}
+
+	if mmReserveItems.defaultExpectation == nil {
+		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{}
+	}
+
+	if mmReserveItems.defaultExpectation.params != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Expect")
+	}
+
+	if mmReserveItems.defaultExpectation.paramPtrs == nil {
+		mmReserveItems.defaultExpectation.paramPtrs = &IStockRepositoryMockReserveItemsParamPtrs{}
+	}
+	mmReserveItems.defaultExpectation.paramPtrs.tx = &tx
+	mmReserveItems.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmReserveItems
+}
+
+// ExpectItemsParam3 sets up expected param items for IStockRepository.ReserveItems
+func (mmReserveItems *mIStockRepositoryMockReserveItems) ExpectItemsParam3(items []models.Item) *mIStockRepositoryMockReserveItems {
+	if mmReserveItems.mock.funcReserveItems != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+	}
+
+	if mmReserveItems.defaultExpectation == nil {
+		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{}
+	}
+
+	if mmReserveItems.defaultExpectation.params != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Expect")
+	}
+
+	if mmReserveItems.defaultExpectation.paramPtrs == nil {
+		mmReserveItems.defaultExpectation.paramPtrs = &IStockRepositoryMockReserveItemsParamPtrs{}
+	}
+	mmReserveItems.defaultExpectation.paramPtrs.items = &items
+	mmReserveItems.defaultExpectation.expectationOrigins.originItems = minimock.CallerInfo(1)
+
+	return mmReserveItems
+}
+
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.ReserveItems
+func (mmReserveItems *mIStockRepositoryMockReserveItems) Inspect(f func(ctx context.Context, tx pgx.Tx, items []models.Item)) *mIStockRepositoryMockReserveItems {
+	if mmReserveItems.mock.inspectFuncReserveItems != nil {
+		mmReserveItems.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.ReserveItems")
+	}
+
+	mmReserveItems.mock.inspectFuncReserveItems = f
+
+	return mmReserveItems
+}
+
+// Return sets up results that will be returned by IStockRepository.ReserveItems
+func (mmReserveItems *mIStockRepositoryMockReserveItems) Return(err error) *IStockRepositoryMock {
+	if mmReserveItems.mock.funcReserveItems != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+	}
+
+	if mmReserveItems.defaultExpectation == nil {
+		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{mock: mmReserveItems.mock}
+	}
+	mmReserveItems.defaultExpectation.results = &IStockRepositoryMockReserveItemsResults{err}
+	mmReserveItems.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmReserveItems.mock
+}
+
+// Set uses given function f to mock the IStockRepository.ReserveItems method
+func (mmReserveItems *mIStockRepositoryMockReserveItems) Set(f func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)) *IStockRepositoryMock {
+	if mmReserveItems.defaultExpectation != nil {
+		mmReserveItems.mock.t.Fatalf("Default expectation is already set for the IStockRepository.ReserveItems method")
+	}
+
+	if len(mmReserveItems.expectations) > 0 {
+		mmReserveItems.mock.t.Fatalf("Some expectations are already set for the IStockRepository.ReserveItems method")
+	}
+
+	mmReserveItems.mock.funcReserveItems = f
+	mmReserveItems.mock.funcReserveItemsOrigin = minimock.CallerInfo(1)
+	return mmReserveItems.mock
+}
+
+// When sets expectation for the IStockRepository.ReserveItems which will trigger the result defined by the following
+// Then helper
+func (mmReserveItems *mIStockRepositoryMockReserveItems) When(ctx context.Context, tx pgx.Tx, items []models.Item) *IStockRepositoryMockReserveItemsExpectation {
+	if mmReserveItems.mock.funcReserveItems != nil {
+		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+	}
+
+	expectation := &IStockRepositoryMockReserveItemsExpectation{
+		mock:               mmReserveItems.mock,
+		params:             &IStockRepositoryMockReserveItemsParams{ctx, tx, items},
+		expectationOrigins: IStockRepositoryMockReserveItemsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmReserveItems.expectations = append(mmReserveItems.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IStockRepository.ReserveItems return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockReserveItemsExpectation) Then(err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockReserveItemsResults{err}
+	return e.mock
+}
+
+// Times sets number of times IStockRepository.ReserveItems should be invoked
+func (mmReserveItems *mIStockRepositoryMockReserveItems) Times(n uint64) *mIStockRepositoryMockReserveItems {
+	if n == 0 {
+		mmReserveItems.mock.t.Fatalf("Times of IStockRepositoryMock.ReserveItems mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmReserveItems.expectedInvocations, n)
+	mmReserveItems.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmReserveItems
+}
+
+func (mmReserveItems *mIStockRepositoryMockReserveItems) invocationsDone() bool {
+	if len(mmReserveItems.expectations) == 0 && mmReserveItems.defaultExpectation == nil && mmReserveItems.mock.funcReserveItems == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmReserveItems.mock.afterReserveItemsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmReserveItems.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ReserveItems implements mm_service.IStockRepository
+func (mmReserveItems *IStockRepositoryMock) ReserveItems(ctx context.Context, tx pgx.Tx, items []models.Item) (err error) {
+	mm_atomic.AddUint64(&mmReserveItems.beforeReserveItemsCounter, 1)
+	defer mm_atomic.AddUint64(&mmReserveItems.afterReserveItemsCounter, 1)
+
+	mmReserveItems.t.Helper()
+
+	if mmReserveItems.inspectFuncReserveItems != nil {
+		mmReserveItems.inspectFuncReserveItems(ctx, tx, items)
+	}
+
+	mm_params := IStockRepositoryMockReserveItemsParams{ctx, tx, items}
+
+	// Record call args
+	mmReserveItems.ReserveItemsMock.mutex.Lock()
+	mmReserveItems.ReserveItemsMock.callArgs = append(mmReserveItems.ReserveItemsMock.callArgs, &mm_params)
+	mmReserveItems.ReserveItemsMock.mutex.Unlock()
+
+	for _, e := range mmReserveItems.ReserveItemsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmReserveItems.ReserveItemsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmReserveItems.ReserveItemsMock.defaultExpectation.Counter, 1)
+		mm_want := mmReserveItems.ReserveItemsMock.defaultExpectation.params
+		mm_want_ptrs := mmReserveItems.ReserveItemsMock.defaultExpectation.paramPtrs
+
+		mm_got := IStockRepositoryMockReserveItemsParams{ctx, tx, items}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmReserveItems.t.Errorf("IStockRepositoryMock.ReserveItems got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReserveItems.ReserveItemsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
+				mmReserveItems.t.Errorf("IStockRepositoryMock.ReserveItems got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReserveItems.ReserveItemsMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+			}
+
+			if mm_want_ptrs.items != nil && !minimock.Equal(*mm_want_ptrs.items, mm_got.items) {
+				mmReserveItems.t.Errorf("IStockRepositoryMock.ReserveItems got unexpected parameter items, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReserveItems.ReserveItemsMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmReserveItems.t.Errorf("IStockRepositoryMock.ReserveItems got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmReserveItems.ReserveItemsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmReserveItems.ReserveItemsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmReserveItems.t.Fatal("No results are set for the IStockRepositoryMock.ReserveItems")
+		}
+		return (*mm_results).err
+	}
+	if mmReserveItems.funcReserveItems != nil {
+		return mmReserveItems.funcReserveItems(ctx, tx, items)
+	}
+	mmReserveItems.t.Fatalf("Unexpected call to IStockRepositoryMock.ReserveItems. %v %v %v", ctx, tx, items)
+	return
+}
+
+// ReserveItemsAfterCounter returns a count of finished IStockRepositoryMock.ReserveItems invocations
+func (mmReserveItems *IStockRepositoryMock) ReserveItemsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReserveItems.afterReserveItemsCounter)
+}
+
+// ReserveItemsBeforeCounter returns a count of IStockRepositoryMock.ReserveItems invocations
+func (mmReserveItems *IStockRepositoryMock) ReserveItemsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReserveItems.beforeReserveItemsCounter)
+}
+
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.ReserveItems.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmReserveItems *mIStockRepositoryMockReserveItems) Calls() []*IStockRepositoryMockReserveItemsParams {
+	mmReserveItems.mutex.RLock()
+
+	argCopy := make([]*IStockRepositoryMockReserveItemsParams, len(mmReserveItems.callArgs))
+	copy(argCopy, mmReserveItems.callArgs)
+
+	mmReserveItems.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockReserveItemsDone returns true if the count of the ReserveItems invocations corresponds
+// the number of defined expectations
+func (m *IStockRepositoryMock) MinimockReserveItemsDone() bool {
+	if m.ReserveItemsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
 	}
 
-	if mmRemoveReservedItems.defaultExpectation.params != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Expect")
+	for _, e := range m.ReserveItemsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
 	}
 
-	if mmRemoveReservedItems.defaultExpectation.paramPtrs == nil {
-		mmRemoveReservedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRemoveReservedItemsParamPtrs{}
+	return m.ReserveItemsMock.invocationsDone()
+}
+
+// MinimockReserveItemsInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockReserveItemsInspect() {
+	for _, e := range m.ReserveItemsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ReserveItems at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
 	}
-	mmRemoveReservedItems.defaultExpectation.paramPtrs.ctx = &ctx
-	mmRemoveReservedItems.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmRemoveReservedItems
+	afterReserveItemsCounter := mm_atomic.LoadUint64(&m.afterReserveItemsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReserveItemsMock.defaultExpectation != nil && afterReserveItemsCounter < 1 {
+		if m.ReserveItemsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ReserveItems at\n%s", m.ReserveItemsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to IStockRepositoryMock.ReserveItems at\n%s with params: %#v", m.ReserveItemsMock.defaultExpectation.expectationOrigins.origin, *m.ReserveItemsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReserveItems != nil && afterReserveItemsCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.ReserveItems at\n%s", m.funcReserveItemsOrigin)
+	}
+
+	if !m.ReserveItemsMock.invocationsDone() && afterReserveItemsCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.ReserveItems at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ReserveItemsMock.expectedInvocations), m.ReserveItemsMock.expectedInvocationsOrigin, afterReserveItemsCounter)
+	}
 }
 
-// ExpectTxParam2 sets up expected param tx for IStockRepository.RemoveReservedItems
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockRemoveReservedItems {
-	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+type mIStockRepositoryMockRestoreRemovedItems struct {
+	optional           bool
+	mock               *IStockRepositoryMock
+	defaultExpectation *IStockRepositoryMockRestoreRemovedItemsExpectation
+	expectations       []*IStockRepositoryMockRestoreRemovedItemsExpectation
+
+	callArgs []*IStockRepositoryMockRestoreRemovedItemsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// IStockRepositoryMockRestoreRemovedItemsExpectation specifies expectation struct of the IStockRepository.RestoreRemovedItems
+type IStockRepositoryMockRestoreRemovedItemsExpectation struct {
+	mock               *IStockRepositoryMock
+	params             *IStockRepositoryMockRestoreRemovedItemsParams
+	paramPtrs          *IStockRepositoryMockRestoreRemovedItemsParamPtrs
+	expectationOrigins IStockRepositoryMockRestoreRemovedItemsExpectationOrigins
+	results            *IStockRepositoryMockRestoreRemovedItemsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// IStockRepositoryMockRestoreRemovedItemsParams contains parameters of the IStockRepository.RestoreRemovedItems
+type IStockRepositoryMockRestoreRemovedItemsParams struct {
+	ctx   context.Context
+	tx    pgx.Tx
+	items []models.Item
+}
+
+// IStockRepositoryMockRestoreRemovedItemsParamPtrs contains pointers to parameters of the IStockRepository.RestoreRemovedItems
+type IStockRepositoryMockRestoreRemovedItemsParamPtrs struct {
+	ctx   *context.Context
+	tx    *pgx.Tx
+	items *[]models.Item
+}
+
+// IStockRepositoryMockRestoreRemovedItemsResults contains results of the IStockRepository.RestoreRemovedItems
+type IStockRepositoryMockRestoreRemovedItemsResults struct {
+	err error
+}
+
+// IStockRepositoryMockRestoreRemovedItemsOrigins contains origins of expectations of the IStockRepository.RestoreRemovedItems
+type IStockRepositoryMockRestoreRemovedItemsExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originTx    string
+	originItems string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) Optional() *mIStockRepositoryMockRestoreRemovedItems {
+	mmRestoreRemovedItems.optional = true
+	return mmRestoreRemovedItems
+}
+
+// Expect sets up expected params for IStockRepository.RestoreRemovedItems
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) Expect(ctx context.Context, tx pgx.Tx, items []models.Item) *mIStockRepositoryMockRestoreRemovedItems {
+	if mmRestoreRemovedItems.mock.funcRestoreRemovedItems != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Set")
 	}
 
-	if mmRemoveReservedItems.defaultExpectation == nil {
-		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{}
+	if mmRestoreRemovedItems.defaultExpectation == nil {
+		mmRestoreRemovedItems.defaultExpectation = &IStockRepositoryMockRestoreRemovedItemsExpectation{}
 	}
 
-	if mmRemoveReservedItems.defaultExpectation.params != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Expect")
+	if mmRestoreRemovedItems.defaultExpectation.paramPtrs != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by ExpectParams functions")
 	}
 
-	if mmRemoveReservedItems.defaultExpectation.paramPtrs == nil {
-		mmRemoveReservedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRemoveReservedItemsParamPtrs{}
+	mmRestoreRemovedItems.defaultExpectation.params = &IStockRepositoryMockRestoreRemovedItemsParams{ctx, tx, items}
+	mmRestoreRemovedItems.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmRestoreRemovedItems.expectations {
+		if minimock.Equal(e.params, mmRestoreRemovedItems.defaultExpectation.params) {
+			mmRestoreRemovedItems.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmRestoreRemovedItems.defaultExpectation.params)
+		}
 	}
-	mmRemoveReservedItems.defaultExpectation.paramPtrs.tx = &tx
-	mmRemoveReservedItems.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
 
-	return mmRemoveReservedItems
+	return mmRestoreRemovedItems
 }
 
-// ExpectItemsParam3 sets up expected param items for IStockRepository.RemoveReservedItems
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) ExpectItemsParam3(items []models.Item) *mIStockRepositoryMockRemoveReservedItems {
-	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.RestoreRemovedItems
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockRestoreRemovedItems {
+	if mmRestoreRemovedItems.mock.funcRestoreRemovedItems != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Set")
 	}
 
-	if mmRemoveReservedItems.defaultExpectation == nil {
-		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{}
+	if mmRestoreRemovedItems.defaultExpectation == nil {
+		mmRestoreRemovedItems.defaultExpectation = &IStockRepositoryMockRestoreRemovedItemsExpectation{}
 	}
 
-	if mmRemoveReservedItems.defaultExpectation.params != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Expect")
+	if mmRestoreRemovedItems.defaultExpectation.params != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Expect")
 	}
 
-	if mmRemoveReservedItems.defaultExpectation.paramPtrs == nil {
-		mmRemoveReservedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRemoveReservedItemsParamPtrs{}
+	if mmRestoreRemovedItems.defaultExpectation.paramPtrs == nil {
+		mmRestoreRemovedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRestoreRemovedItemsParamPtrs{}
 	}
-	mmRemoveReservedItems.defaultExpectation.paramPtrs.items = &items
-	mmRemoveReservedItems.defaultExpectation.expectationOrigins.originItems = minimock.CallerInfo(1)
+	mmRestoreRemovedItems.defaultExpectation.paramPtrs.ctx = &ctx
+	mmRestoreRemovedItems.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmRemoveReservedItems
+	return mmRestoreRemovedItems
 }
 
-// Inspect accepts an inspector function that has same arguments as the IStockRepository.RemoveReservedItems
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Inspect(f func(ctx context.Context, tx pgx.Tx, items []models.Item)) *mIStockRepositoryMockRemoveReservedItems {
-	if mmRemoveReservedItems.mock.inspectFuncRemoveReservedItems != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.RemoveReservedItems")
+// ExpectTxParam2 sets up expected param tx for IStockRepository.RestoreRemovedItems
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockRestoreRemovedItems {
+	if mmRestoreRemovedItems.mock.funcRestoreRemovedItems != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Set")
 	}
 
-	mmRemoveReservedItems.mock.inspectFuncRemoveReservedItems = f
+	if mmRestoreRemovedItems.defaultExpectation == nil {
+		mmRestoreRemovedItems.defaultExpectation = &IStockRepositoryMockRestoreRemovedItemsExpectation{}
+	}
 
-	return mmRemoveReservedItems
+	if mmRestoreRemovedItems.defaultExpectation.params != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Expect")
+	}
+
+	if mmRestoreRemovedItems.defaultExpectation.paramPtrs == nil {
+		mmRestoreRemovedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRestoreRemovedItemsParamPtrs{}
+	}
+	mmRestoreRemovedItems.defaultExpectation.paramPtrs.tx = &tx
+	mmRestoreRemovedItems.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+
+	return mmRestoreRemovedItems
 }
 
-// Return sets up results that will be returned by IStockRepository.RemoveReservedItems
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Return(err error) *IStockRepositoryMock {
-	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+// ExpectItemsParam3 sets up expected param items for IStockRepository.RestoreRemovedItems
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) ExpectItemsParam3(items []models.Item) *mIStockRepositoryMockRestoreRemovedItems {
+	if mmRestoreRemovedItems.mock.funcRestoreRemovedItems != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Set")
 	}
 
-	if mmRemoveReservedItems.defaultExpectation == nil {
-		mmRemoveReservedItems.defaultExpectation = &IStockRepositoryMockRemoveReservedItemsExpectation{mock: mmRemoveReservedItems.mock}
+	if mmRestoreRemovedItems.defaultExpectation == nil {
+		mmRestoreRemovedItems.defaultExpectation = &IStockRepositoryMockRestoreRemovedItemsExpectation{}
 	}
-	mmRemoveReservedItems.defaultExpectation.results = &IStockRepositoryMockRemoveReservedItemsResults{err}
-	mmRemoveReservedItems.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmRemoveReservedItems.mock
+
+	if mmRestoreRemovedItems.defaultExpectation.params != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Expect")
+	}
+
+	if mmRestoreRemovedItems.defaultExpectation.paramPtrs == nil {
+		mmRestoreRemovedItems.defaultExpectation.paramPtrs = &IStockRepositoryMockRestoreRemovedItemsParamPtrs{}
+	}
+	mmRestoreRemovedItems.defaultExpectation.paramPtrs.items = &items
+	mmRestoreRemovedItems.defaultExpectation.expectationOrigins.originItems = minimock.CallerInfo(1)
+
+	return mmRestoreRemovedItems
 }
 
-// Set uses given function f to mock the IStockRepository.RemoveReservedItems method
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Set(f func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)) *IStockRepositoryMock {
-	if mmRemoveReservedItems.defaultExpectation != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("Default expectation is already set for the IStockRepository.RemoveReservedItems method")
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.RestoreRemovedItems
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) Inspect(f func(ctx context.Context, tx pgx.Tx, items []models.Item)) *mIStockRepositoryMockRestoreRemovedItems {
+	if mmRestoreRemovedItems.mock.inspectFuncRestoreRemovedItems != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.RestoreRemovedItems")
 	}
 
-	if len(mmRemoveReservedItems.expectations) > 0 {
-		mmRemoveReservedItems.mock.t.Fatalf("Some expectations are already set for the IStockRepository.RemoveReservedItems method")
+	mmRestoreRemovedItems.mock.inspectFuncRestoreRemovedItems = f
+
+	return mmRestoreRemovedItems
+}
+
+// Return sets up results that will be returned by IStockRepository.RestoreRemovedItems
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) Return(err error) *IStockRepositoryMock {
+	if mmRestoreRemovedItems.mock.funcRestoreRemovedItems != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Set")
 	}
 
-	mmRemoveReservedItems.mock.funcRemoveReservedItems = f
-	mmRemoveReservedItems.mock.funcRemoveReservedItemsOrigin = minimock.CallerInfo(1)
-	return mmRemoveReservedItems.mock
+	if mmRestoreRemovedItems.defaultExpectation == nil {
+		mmRestoreRemovedItems.defaultExpectation = &IStockRepositoryMockRestoreRemovedItemsExpectation{mock: mmRestoreRemovedItems.mock}
+	}
+	mmRestoreRemovedItems.defaultExpectation.results = &IStockRepositoryMockRestoreRemovedItemsResults{err}
+	mmRestoreRemovedItems.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmRestoreRemovedItems.mock
 }
 
-// When sets expectation for the IStockRepository.RemoveReservedItems which will trigger the result defined by the following
+// Set uses given function f to mock the IStockRepository.RestoreRemovedItems method
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) Set(f func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)) *IStockRepositoryMock {
+	if mmRestoreRemovedItems.defaultExpectation != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("Default expectation is already set for the IStockRepository.RestoreRemovedItems method")
+	}
+
+	if len(mmRestoreRemovedItems.expectations) > 0 {
+		mmRestoreRemovedItems.mock.t.Fatalf("Some expectations are already set for the IStockRepository.RestoreRemovedItems method")
+	}
+
+	mmRestoreRemovedItems.mock.funcRestoreRemovedItems = f
+	mmRestoreRemovedItems.mock.funcRestoreRemovedItemsOrigin = minimock.CallerInfo(1)
+	return mmRestoreRemovedItems.mock
+}
+
+// When sets expectation for the IStockRepository.RestoreRemovedItems which will trigger the result defined by the following
 // Then helper
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) When(ctx context.Context, tx pgx.Tx, items []models.Item) *IStockRepositoryMockRemoveReservedItemsExpectation {
-	if mmRemoveReservedItems.mock.funcRemoveReservedItems != nil {
-		mmRemoveReservedItems.mock.t.Fatalf("IStockRepositoryMock.RemoveReservedItems mock is already set by Set")
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) When(ctx context.Context, tx pgx.Tx, items []models.Item) *IStockRepositoryMockRestoreRemovedItemsExpectation {
+	if mmRestoreRemovedItems.mock.funcRestoreRemovedItems != nil {
+		mmRestoreRemovedItems.mock.t.Fatalf("IStockRepositoryMock.RestoreRemovedItems mock is already set by Set")
 	}
 
-	expectation := &IStockRepositoryMockRemoveReservedItemsExpectation{
-		mock:               mmRemoveReservedItems.mock,
-		params:             &IStockRepositoryMockRemoveReservedItemsParams{ctx, tx, items},
-		expectationOrigins: IStockRepositoryMockRemoveReservedItemsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &IStockRepositoryMockRestoreRemovedItemsExpectation{
+		mock:               mmRestoreRemovedItems.mock,
+		params:             &IStockRepositoryMockRestoreRemovedItemsParams{ctx, tx, items},
+		expectationOrigins: IStockRepositoryMockRestoreRemovedItemsExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmRemoveReservedItems.expectations = append(mmRemoveReservedItems.expectations, expectation)
+	mmRestoreRemovedItems.expectations = append(mmRestoreRemovedItems.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IStockRepository.RemoveReservedItems return parameters for the expectation previously defined by the When method
-func (e *IStockRepositoryMockRemoveReservedItemsExpectation) Then(err error) *IStockRepositoryMock {
-	e.results = &IStockRepositoryMockRemoveReservedItemsResults{err}
+// Then sets up IStockRepository.RestoreRemovedItems return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockRestoreRemovedItemsExpectation) Then(err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockRestoreRemovedItemsResults{err}
 	return e.mock
 }
 
-// Times sets number of times IStockRepository.RemoveReservedItems should be invoked
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Times(n uint64) *mIStockRepositoryMockRemoveReservedItems {
+// Times sets number of times IStockRepository.RestoreRemovedItems should be invoked
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) Times(n uint64) *mIStockRepositoryMockRestoreRemovedItems {
 	if n == 0 {
-		mmRemoveReservedItems.mock.t.Fatalf("Times of IStockRepositoryMock.RemoveReservedItems mock can not be zero")
+		mmRestoreRemovedItems.mock.t.Fatalf("Times of IStockRepositoryMock.RestoreRemovedItems mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmRemoveReservedItems.expectedInvocations, n)
-	mmRemoveReservedItems.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmRemoveReservedItems
+	mm_atomic.StoreUint64(&mmRestoreRemovedItems.expectedInvocations, n)
+	mmRestoreRemovedItems.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmRestoreRemovedItems
 }
 
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) invocationsDone() bool {
-	if len(mmRemoveReservedItems.expectations) == 0 && mmRemoveReservedItems.defaultExpectation == nil && mmRemoveReservedItems.mock.funcRemoveReservedItems == nil {
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) invocationsDone() bool {
+	if len(mmRestoreRemovedItems.expectations) == 0 && mmRestoreRemovedItems.defaultExpectation == nil && mmRestoreRemovedItems.mock.funcRestoreRemovedItems == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmRemoveReservedItems.mock.afterRemoveReservedItemsCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmRemoveReservedItems.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmRestoreRemovedItems.mock.afterRestoreRemovedItemsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmRestoreRemovedItems.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// RemoveReservedItems implements mm_service.IStockRepository
-func (mmRemoveReservedItems *IStockRepositoryMock) RemoveReservedItems(ctx context.Context, tx pgx.Tx, items []models.Item) (err error) {
-	mm_atomic.AddUint64(&mmRemoveReservedItems.beforeRemoveReservedItemsCounter, 1)
-	defer mm_atomic.AddUint64(&mmRemoveReservedItems.afterRemoveReservedItemsCounter, 1)
+// RestoreRemovedItems implements mm_service.IStockRepository
+func (mmRestoreRemovedItems *IStockRepositoryMock) RestoreRemovedItems(ctx context.Context, tx pgx.Tx, items []models.Item) (err error) {
+	mm_atomic.AddUint64(&mmRestoreRemovedItems.beforeRestoreRemovedItemsCounter, 1)
+	defer mm_atomic.AddUint64(&mmRestoreRemovedItems.afterRestoreRemovedItemsCounter, 1)
 
-	mmRemoveReservedItems.t.Helper()
+	mmRestoreRemovedItems.t.Helper()
 
-	if mmRemoveReservedItems.inspectFuncRemoveReservedItems != nil {
-		mmRemoveReservedItems.inspectFuncRemoveReservedItems(ctx, tx, items)
+	if mmRestoreRemovedItems.inspectFuncRestoreRemovedItems != nil {
+		mmRestoreRemovedItems.inspectFuncRestoreRemovedItems(ctx, tx, items)
 	}
 
-	mm_params := IStockRepositoryMockRemoveReservedItemsParams{ctx, tx, items}
+	mm_params := IStockRepositoryMockRestoreRemovedItemsParams{ctx, tx, items}
 
 	// Record call args
-	mmRemoveReservedItems.RemoveReservedItemsMock.mutex.Lock()
-	mmRemoveReservedItems.RemoveReservedItemsMock.callArgs = append(mmRemoveReservedItems.RemoveReservedItemsMock.callArgs, &mm_params)
-	mmRemoveReservedItems.RemoveReservedItemsMock.mutex.Unlock()
+	mmRestoreRemovedItems.RestoreRemovedItemsMock.mutex.Lock()
+	mmRestoreRemovedItems.RestoreRemovedItemsMock.callArgs = append(mmRestoreRemovedItems.RestoreRemovedItemsMock.callArgs, &mm_params)
+	mmRestoreRemovedItems.RestoreRemovedItemsMock.mutex.Unlock()
 
-	for _, e := range mmRemoveReservedItems.RemoveReservedItemsMock.expectations {
+	for _, e := range mmRestoreRemovedItems.RestoreRemovedItemsMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
 			return e.results.err
 		}
 	}
 
-	if mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.Counter, 1)
-		mm_want := mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.params
-		mm_want_ptrs := mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.paramPtrs
+	if mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation.Counter, 1)
+		mm_want := mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation.params
+		mm_want_ptrs := mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation.paramPtrs
 
-		mm_got := IStockRepositoryMockRemoveReservedItemsParams{ctx, tx, items}
+		mm_got := IStockRepositoryMockRestoreRemovedItemsParams{ctx, tx, items}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmRemoveReservedItems.t.Errorf("IStockRepositoryMock.RemoveReservedItems got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmRestoreRemovedItems.t.Errorf("IStockRepositoryMock.RestoreRemovedItems got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
 			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
-				mmRemoveReservedItems.t.Errorf("IStockRepositoryMock.RemoveReservedItems got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+				mmRestoreRemovedItems.t.Errorf("IStockRepositoryMock.RestoreRemovedItems got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
 			}
 
 			if mm_want_ptrs.items != nil && !minimock.Equal(*mm_want_ptrs.items, mm_got.items) {
-				mmRemoveReservedItems.t.Errorf("IStockRepositoryMock.RemoveReservedItems got unexpected parameter items, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
+				mmRestoreRemovedItems.t.Errorf("IStockRepositoryMock.RestoreRemovedItems got unexpected parameter items, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmRemoveReservedItems.t.Errorf("IStockRepositoryMock.RemoveReservedItems got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmRestoreRemovedItems.t.Errorf("IStockRepositoryMock.RestoreRemovedItems got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmRemoveReservedItems.RemoveReservedItemsMock.defaultExpectation.results
+		mm_results := mmRestoreRemovedItems.RestoreRemovedItemsMock.defaultExpectation.results
 		if mm_results == nil {
-			mmRemoveReservedItems.t.Fatal("No results are set for the IStockRepositoryMock.RemoveReservedItems")
+			mmRestoreRemovedItems.t.Fatal("No results are set for the IStockRepositoryMock.RestoreRemovedItems")
 		}
 		return (*mm_results).err
 	}
-	if mmRemoveReservedItems.funcRemoveReservedItems != nil {
-		return mmRemoveReservedItems.funcRemoveReservedItems(ctx, tx, items)
+	if mmRestoreRemovedItems.funcRestoreRemovedItems != nil {
+		return mmRestoreRemovedItems.funcRestoreRemovedItems(ctx, tx, items)
 	}
-	mmRemoveReservedItems.t.Fatalf("Unexpected call to IStockRepositoryMock.RemoveReservedItems. %v %v %v", ctx, tx, items)
+	mmRestoreRemovedItems.t.Fatalf("Unexpected call to IStockRepositoryMock.RestoreRemovedItems. %v %v %v", ctx, tx, items)
 	return
 }
 
-// RemoveReservedItemsAfterCounter returns a count of finished IStockRepositoryMock.RemoveReservedItems invocations
-func (mmRemoveReservedItems *IStockRepositoryMock) RemoveReservedItemsAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmRemoveReservedItems.afterRemoveReservedItemsCounter)
+// RestoreRemovedItemsAfterCounter returns a count of finished IStockRepositoryMock.RestoreRemovedItems invocations
+func (mmRestoreRemovedItems *IStockRepositoryMock) RestoreRemovedItemsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRestoreRemovedItems.afterRestoreRemovedItemsCounter)
 }
 
-// RemoveReservedItemsBeforeCounter returns a count of IStockRepositoryMock.RemoveReservedItems invocations
-func (mmRemoveReservedItems *IStockRepositoryMock) RemoveReservedItemsBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmRemoveReservedItems.beforeRemoveReservedItemsCounter)
+// RestoreRemovedItemsBeforeCounter returns a count of IStockRepositoryMock.RestoreRemovedItems invocations
+func (mmRestoreRemovedItems *IStockRepositoryMock) RestoreRemovedItemsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRestoreRemovedItems.beforeRestoreRemovedItemsCounter)
 }
 
-// Calls returns a list of arguments used in each call to IStockRepositoryMock.RemoveReservedItems.
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.RestoreRemovedItems.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmRemoveReservedItems *mIStockRepositoryMockRemoveReservedItems) Calls() []*IStockRepositoryMockRemoveReservedItemsParams {
-	mmRemoveReservedItems.mutex.RLock()
+func (mmRestoreRemovedItems *mIStockRepositoryMockRestoreRemovedItems) Calls() []*IStockRepositoryMockRestoreRemovedItemsParams {
+	mmRestoreRemovedItems.mutex.RLock()
 
-	argCopy := make([]*IStockRepositoryMockRemoveReservedItemsParams, len(mmRemoveReservedItems.callArgs))
-	copy(argCopy, mmRemoveReservedItems.callArgs)
+	argCopy := make([]*IStockRepositoryMockRestoreRemovedItemsParams, len(mmRestoreRemovedItems.callArgs))
+	copy(argCopy, mmRestoreRemovedItems.callArgs)
 
-	mmRemoveReservedItems.mutex.RUnlock()
+	mmRestoreRemovedItems.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockRemoveReservedItemsDone returns true if the count of the RemoveReservedItems invocations corresponds
+// MinimockRestoreRemovedItemsDone returns true if the count of the RestoreRemovedItems invocations corresponds
 // the number of defined expectations
-func (m *IStockRepositoryMock) MinimockRemoveReservedItemsDone() bool {
-	if m.RemoveReservedItemsMock.optional {
+func (m *IStockRepositoryMock) MinimockRestoreRemovedItemsDone() bool {
+	if m.RestoreRemovedItemsMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.RemoveReservedItemsMock.expectations {
+	for _, e := range m.RestoreRemovedItemsMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.RemoveReservedItemsMock.invocationsDone()
+	return m.RestoreRemovedItemsMock.invocationsDone()
 }
 
-// MinimockRemoveReservedItemsInspect logs each unmet expectation
-func (m *IStockRepositoryMock) MinimockRemoveReservedItemsInspect() {
-	for _, e := range m.RemoveReservedItemsMock.expectations {
+// MinimockRestoreRemovedItemsInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockRestoreRemovedItemsInspect() {
+	for _, e := range m.RestoreRemovedItemsMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IStockRepositoryMock.RemoveReservedItems at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to IStockRepositoryMock.RestoreRemovedItems at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterRemoveReservedItemsCounter := mm_atomic.LoadUint64(&m.afterRemoveReservedItemsCounter)
+	afterRestoreRemovedItemsCounter := mm_atomic.LoadUint64(&m.afterRestoreRemovedItemsCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.RemoveReservedItemsMock.defaultExpectation != nil && afterRemoveReservedItemsCounter < 1 {
-		if m.RemoveReservedItemsMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to IStockRepositoryMock.RemoveReservedItems at\n%s", m.RemoveReservedItemsMock.defaultExpectation.returnOrigin)
+	if m.RestoreRemovedItemsMock.defaultExpectation != nil && afterRestoreRemovedItemsCounter < 1 {
+		if m.RestoreRemovedItemsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.RestoreRemovedItems at\n%s", m.RestoreRemovedItemsMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to IStockRepositoryMock.RemoveReservedItems at\n%s with params: %#v", m.RemoveReservedItemsMock.defaultExpectation.expectationOrigins.origin, *m.RemoveReservedItemsMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IStockRepositoryMock.RestoreRemovedItems at\n%s with params: %#v", m.RestoreRemovedItemsMock.defaultExpectation.expectationOrigins.origin, *m.RestoreRemovedItemsMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcRemoveReservedItems != nil && afterRemoveReservedItemsCounter < 1 {
-		m.t.Errorf("Expected call to IStockRepositoryMock.RemoveReservedItems at\n%s", m.funcRemoveReservedItemsOrigin)
+	if m.funcRestoreRemovedItems != nil && afterRestoreRemovedItemsCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.RestoreRemovedItems at\n%s", m.funcRestoreRemovedItemsOrigin)
 	}
 
-	if !m.RemoveReservedItemsMock.invocationsDone() && afterRemoveReservedItemsCounter > 0 {
-		m.t.Errorf("Expected %d calls to IStockRepositoryMock.RemoveReservedItems at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.RemoveReservedItemsMock.expectedInvocations), m.RemoveReservedItemsMock.expectedInvocationsOrigin, afterRemoveReservedItemsCounter)
+	if !m.RestoreRemovedItemsMock.invocationsDone() && afterRestoreRemovedItemsCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.RestoreRemovedItems at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.RestoreRemovedItemsMock.expectedInvocations), m.RestoreRemovedItemsMock.expectedInvocationsOrigin, afterRestoreRemovedItemsCounter)
 	}
 }
 
-type mIStockRepositoryMockReserveItems struct {
+type mIStockRepositoryMockSetStockTotalCount struct {
 	optional           bool
 	mock               *IStockRepositoryMock
-	defaultExpectation *IStockRepositoryMockReserveItemsExpectation
-	expectations       []*IStockRepositoryMockReserveItemsExpectation
+	defaultExpectation *IStockRepositoryMockSetStockTotalCountExpectation
+	expectations       []*IStockRepositoryMockSetStockTotalCountExpectation
 
-	callArgs []*IStockRepositoryMockReserveItemsParams
+	callArgs []*IStockRepositoryMockSetStockTotalCountParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// IStockRepositoryMockReserveItemsExpectation specifies expectation struct of the IStockRepository.ReserveItems
-type IStockRepositoryMockReserveItemsExpectation struct {
+// IStockRepositoryMockSetStockTotalCountExpectation specifies expectation struct of the IStockRepository.SetStockTotalCount
+type IStockRepositoryMockSetStockTotalCountExpectation struct {
 	mock               *IStockRepositoryMock
-	params             *IStockRepositoryMockReserveItemsParams
-	paramPtrs          *IStockRepositoryMockReserveItemsParamPtrs
-	expectationOrigins IStockRepositoryMockReserveItemsExpectationOrigins
-	results            *IStockRepositoryMockReserveItemsResults
+	params             *IStockRepositoryMockSetStockTotalCountParams
+	paramPtrs          *IStockRepositoryMockSetStockTotalCountParamPtrs
+	expectationOrigins IStockRepositoryMockSetStockTotalCountExpectationOrigins
+	results            *IStockRepositoryMockSetStockTotalCountResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// IStockRepositoryMockReserveItemsParams contains parameters of the IStockRepository.ReserveItems
-type IStockRepositoryMockReserveItemsParams struct {
-	ctx   context.Context
-	tx    pgx.Tx
-	items []models.Item
+// IStockRepositoryMockSetStockTotalCountParams contains parameters of the IStockRepository.SetStockTotalCount
+type IStockRepositoryMockSetStockTotalCountParams struct {
+	ctx        context.Context
+	tx         pgx.Tx
+	SKU        models.SKU
+	totalCount uint64
 }
 
-// IStockRepositoryMockReserveItemsParamPtrs contains pointers to parameters of the IStockRepository.ReserveItems
-type IStockRepositoryMockReserveItemsParamPtrs struct {
-	ctx   *context.Context
-	tx    *pgx.Tx
-	items *[]models.Item
+// IStockRepositoryMockSetStockTotalCountParamPtrs contains pointers to parameters of the IStockRepository.SetStockTotalCount
+type IStockRepositoryMockSetStockTotalCountParamPtrs struct {
+	ctx        *context.Context
+	tx         *pgx.Tx
+	SKU        *models.SKU
+	totalCount *uint64
 }
 
-// IStockRepositoryMockReserveItemsResults contains results of the IStockRepository.ReserveItems
-type IStockRepositoryMockReserveItemsResults struct {
+// IStockRepositoryMockSetStockTotalCountResults contains results of the IStockRepository.SetStockTotalCount
+type IStockRepositoryMockSetStockTotalCountResults struct {
+	s1  models.Stock
 	err error
 }
 
-// IStockRepositoryMockReserveItemsOrigins contains origins of expectations of the IStockRepository.ReserveItems
-type IStockRepositoryMockReserveItemsExpectationOrigins struct {
-	origin      string
-	originCtx   string
-	originTx    string
-	originItems string
+// IStockRepositoryMockSetStockTotalCountOrigins contains origins of expectations of the IStockRepository.SetStockTotalCount
+type IStockRepositoryMockSetStockTotalCountExpectationOrigins struct {
+	origin           string
+	originCtx        string
+	originTx         string
+	originSKU        string
+	originTotalCount string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -1219,320 +3940,348 @@ type IStockRepositoryMockReserveItemsExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmReserveItems *mIStockRepositoryMockReserveItems) Optional() *mIStockRepositoryMockReserveItems {
-	mmReserveItems.optional = true
-	return mmReserveItems
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) Optional() *mIStockRepositoryMockSetStockTotalCount {
+	mmSetStockTotalCount.optional = true
+	return mmSetStockTotalCount
 }
 
-// Expect sets up expected params for IStockRepository.ReserveItems
-func (mmReserveItems *mIStockRepositoryMockReserveItems) Expect(ctx context.Context, tx pgx.Tx, items []models.Item) *mIStockRepositoryMockReserveItems {
-	if mmReserveItems.mock.funcReserveItems != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+// Expect sets up expected params for IStockRepository.SetStockTotalCount
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) Expect(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64) *mIStockRepositoryMockSetStockTotalCount {
+	if mmSetStockTotalCount.mock.funcSetStockTotalCount != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Set")
 	}
 
-	if mmReserveItems.defaultExpectation == nil {
-		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{}
+	if mmSetStockTotalCount.defaultExpectation == nil {
+		mmSetStockTotalCount.defaultExpectation = &IStockRepositoryMockSetStockTotalCountExpectation{}
 	}
 
-	if mmReserveItems.defaultExpectation.paramPtrs != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by ExpectParams functions")
+	if mmSetStockTotalCount.defaultExpectation.paramPtrs != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by ExpectParams functions")
 	}
 
-	mmReserveItems.defaultExpectation.params = &IStockRepositoryMockReserveItemsParams{ctx, tx, items}
-	mmReserveItems.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmReserveItems.expectations {
-		if minimock.Equal(e.params, mmReserveItems.defaultExpectation.params) {
-			mmReserveItems.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReserveItems.defaultExpectation.params)
+	mmSetStockTotalCount.defaultExpectation.params = &IStockRepositoryMockSetStockTotalCountParams{ctx, tx, SKU, totalCount}
+	mmSetStockTotalCount.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmSetStockTotalCount.expectations {
+		if minimock.Equal(e.params, mmSetStockTotalCount.defaultExpectation.params) {
+			mmSetStockTotalCount.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetStockTotalCount.defaultExpectation.params)
 		}
 	}
 
-	return mmReserveItems
+	return mmSetStockTotalCount
 }
 
-// ExpectCtxParam1 sets up expected param ctx for IStockRepository.ReserveItems
-func (mmReserveItems *mIStockRepositoryMockReserveItems) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockReserveItems {
-	if mmReserveItems.mock.funcReserveItems != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for IStockRepository.SetStockTotalCount
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) ExpectCtxParam1(ctx context.Context) *mIStockRepositoryMockSetStockTotalCount {
+	if mmSetStockTotalCount.mock.funcSetStockTotalCount != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Set")
 	}
 
-	if mmReserveItems.defaultExpectation == nil {
-		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{}
+	if mmSetStockTotalCount.defaultExpectation == nil {
+		mmSetStockTotalCount.defaultExpectation = &IStockRepositoryMockSetStockTotalCountExpectation{}
 	}
 
-	if mmReserveItems.defaultExpectation.params != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Expect")
+	if mmSetStockTotalCount.defaultExpectation.params != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Expect")
 	}
 
-	if mmReserveItems.defaultExpectation.paramPtrs == nil {
-		mmReserveItems.defaultExpectation.paramPtrs = &IStockRepositoryMockReserveItemsParamPtrs{}
+	if mmSetStockTotalCount.defaultExpectation.paramPtrs == nil {
+		mmSetStockTotalCount.defaultExpectation.paramPtrs = &IStockRepositoryMockSetStockTotalCountParamPtrs{}
 	}
-	mmReserveItems.defaultExpectation.paramPtrs.ctx = &ctx
-	mmReserveItems.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+	mmSetStockTotalCount.defaultExpectation.paramPtrs.ctx = &ctx
+	mmSetStockTotalCount.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmReserveItems
+	return mmSetStockTotalCount
 }
 
-// ExpectTxParam2 sets up expected param tx for IStockRepository.ReserveItems
-func (mmReserveItems *mIStockRepositoryMockReserveItems) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockReserveItems {
-	if mmReserveItems.mock.funcReserveItems != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+// ExpectTxParam2 sets up expected param tx for IStockRepository.SetStockTotalCount
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) ExpectTxParam2(tx pgx.Tx) *mIStockRepositoryMockSetStockTotalCount {
+	if mmSetStockTotalCount.mock.funcSetStockTotalCount != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Set")
 	}
 
-	if mmReserveItems.defaultExpectation == nil {
-		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{}
+	if mmSetStockTotalCount.defaultExpectation == nil {
+		mmSetStockTotalCount.defaultExpectation = &IStockRepositoryMockSetStockTotalCountExpectation{}
 	}
 
-	if mmReserveItems.defaultExpectation.params != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Expect")
+	if mmSetStockTotalCount.defaultExpectation.params != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Expect")
 	}
 
-	if mmReserveItems.defaultExpectation.paramPtrs == nil {
-		mmReserveItems.defaultExpectation.paramPtrs = &IStockRepositoryMockReserveItemsParamPtrs{}
+	if mmSetStockTotalCount.defaultExpectation.paramPtrs == nil {
+		mmSetStockTotalCount.defaultExpectation.paramPtrs = &IStockRepositoryMockSetStockTotalCountParamPtrs{}
 	}
-	mmReserveItems.defaultExpectation.paramPtrs.tx = &tx
-	mmReserveItems.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
+	mmSetStockTotalCount.defaultExpectation.paramPtrs.tx = &tx
+	mmSetStockTotalCount.defaultExpectation.expectationOrigins.originTx = minimock.CallerInfo(1)
 
-	return mmReserveItems
+	return mmSetStockTotalCount
 }
 
-// ExpectItemsParam3 sets up expected param items for IStockRepository.ReserveItems
-func (mmReserveItems *mIStockRepositoryMockReserveItems) ExpectItemsParam3(items []models.Item) *mIStockRepositoryMockReserveItems {
-	if mmReserveItems.mock.funcReserveItems != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+// ExpectSKUParam3 sets up expected param SKU for IStockRepository.SetStockTotalCount
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) ExpectSKUParam3(SKU models.SKU) *mIStockRepositoryMockSetStockTotalCount {
+	if mmSetStockTotalCount.mock.funcSetStockTotalCount != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Set")
 	}
 
-	if mmReserveItems.defaultExpectation == nil {
-		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{}
+	if mmSetStockTotalCount.defaultExpectation == nil {
+		mmSetStockTotalCount.defaultExpectation = &IStockRepositoryMockSetStockTotalCountExpectation{}
 	}
 
-	if mmReserveItems.defaultExpectation.params != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Expect")
+	if mmSetStockTotalCount.defaultExpectation.params != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Expect")
 	}
 
-	if mmReserveItems.defaultExpectation.paramPtrs == nil {
-		mmReserveItems.defaultExpectation.paramPtrs = &IStockRepositoryMockReserveItemsParamPtrs{}
+	if mmSetStockTotalCount.defaultExpectation.paramPtrs == nil {
+		mmSetStockTotalCount.defaultExpectation.paramPtrs = &IStockRepositoryMockSetStockTotalCountParamPtrs{}
 	}
-	mmReserveItems.defaultExpectation.paramPtrs.items = &items
-	mmReserveItems.defaultExpectation.expectationOrigins.originItems = minimock.CallerInfo(1)
+	mmSetStockTotalCount.defaultExpectation.paramPtrs.SKU = &SKU
+	mmSetStockTotalCount.defaultExpectation.expectationOrigins.originSKU = minimock.CallerInfo(1)
 
-	return mmReserveItems
+	return mmSetStockTotalCount
 }
 
-// Inspect accepts an inspector function that has same arguments as the IStockRepository.ReserveItems
-func (mmReserveItems *mIStockRepositoryMockReserveItems) Inspect(f func(ctx context.Context, tx pgx.Tx, items []models.Item)) *mIStockRepositoryMockReserveItems {
-	if mmReserveItems.mock.inspectFuncReserveItems != nil {
-		mmReserveItems.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.ReserveItems")
+// ExpectTotalCountParam4 sets up expected param totalCount for IStockRepository.SetStockTotalCount
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) ExpectTotalCountParam4(totalCount uint64) *mIStockRepositoryMockSetStockTotalCount {
+	if mmSetStockTotalCount.mock.funcSetStockTotalCount != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Set")
 	}
 
-	mmReserveItems.mock.inspectFuncReserveItems = f
+	if mmSetStockTotalCount.defaultExpectation == nil {
+		mmSetStockTotalCount.defaultExpectation = &IStockRepositoryMockSetStockTotalCountExpectation{}
+	}
 
-	return mmReserveItems
+	if mmSetStockTotalCount.defaultExpectation.params != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Expect")
+	}
+
+	if mmSetStockTotalCount.defaultExpectation.paramPtrs == nil {
+		mmSetStockTotalCount.defaultExpectation.paramPtrs = &IStockRepositoryMockSetStockTotalCountParamPtrs{}
+	}
+	mmSetStockTotalCount.defaultExpectation.paramPtrs.totalCount = &totalCount
+	mmSetStockTotalCount.defaultExpectation.expectationOrigins.originTotalCount = minimock.CallerInfo(1)
+
+	return mmSetStockTotalCount
 }
 
-// Return sets up results that will be returned by IStockRepository.ReserveItems
-func (mmReserveItems *mIStockRepositoryMockReserveItems) Return(err error) *IStockRepositoryMock {
-	if mmReserveItems.mock.funcReserveItems != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+// Inspect accepts an inspector function that has same arguments as the IStockRepository.SetStockTotalCount
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) Inspect(f func(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64)) *mIStockRepositoryMockSetStockTotalCount {
+	if mmSetStockTotalCount.mock.inspectFuncSetStockTotalCount != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("Inspect function is already set for IStockRepositoryMock.SetStockTotalCount")
 	}
 
-	if mmReserveItems.defaultExpectation == nil {
-		mmReserveItems.defaultExpectation = &IStockRepositoryMockReserveItemsExpectation{mock: mmReserveItems.mock}
+	mmSetStockTotalCount.mock.inspectFuncSetStockTotalCount = f
+
+	return mmSetStockTotalCount
+}
+
+// Return sets up results that will be returned by IStockRepository.SetStockTotalCount
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) Return(s1 models.Stock, err error) *IStockRepositoryMock {
+	if mmSetStockTotalCount.mock.funcSetStockTotalCount != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Set")
 	}
-	mmReserveItems.defaultExpectation.results = &IStockRepositoryMockReserveItemsResults{err}
-	mmReserveItems.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmReserveItems.mock
+
+	if mmSetStockTotalCount.defaultExpectation == nil {
+		mmSetStockTotalCount.defaultExpectation = &IStockRepositoryMockSetStockTotalCountExpectation{mock: mmSetStockTotalCount.mock}
+	}
+	mmSetStockTotalCount.defaultExpectation.results = &IStockRepositoryMockSetStockTotalCountResults{s1, err}
+	mmSetStockTotalCount.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmSetStockTotalCount.mock
 }
 
-// Set uses given function f to mock the IStockRepository.ReserveItems method
-func (mmReserveItems *mIStockRepositoryMockReserveItems) Set(f func(ctx context.Context, tx pgx.Tx, items []models.Item) (err error)) *IStockRepositoryMock {
-	if mmReserveItems.defaultExpectation != nil {
-		mmReserveItems.mock.t.Fatalf("Default expectation is already set for the IStockRepository.ReserveItems method")
+// Set uses given function f to mock the IStockRepository.SetStockTotalCount method
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) Set(f func(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64) (s1 models.Stock, err error)) *IStockRepositoryMock {
+	if mmSetStockTotalCount.defaultExpectation != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("Default expectation is already set for the IStockRepository.SetStockTotalCount method")
 	}
 
-	if len(mmReserveItems.expectations) > 0 {
-		mmReserveItems.mock.t.Fatalf("Some expectations are already set for the IStockRepository.ReserveItems method")
+	if len(mmSetStockTotalCount.expectations) > 0 {
+		mmSetStockTotalCount.mock.t.Fatalf("Some expectations are already set for the IStockRepository.SetStockTotalCount method")
 	}
 
-	mmReserveItems.mock.funcReserveItems = f
-	mmReserveItems.mock.funcReserveItemsOrigin = minimock.CallerInfo(1)
-	return mmReserveItems.mock
+	mmSetStockTotalCount.mock.funcSetStockTotalCount = f
+	mmSetStockTotalCount.mock.funcSetStockTotalCountOrigin = minimock.CallerInfo(1)
+	return mmSetStockTotalCount.mock
 }
 
-// When sets expectation for the IStockRepository.ReserveItems which will trigger the result defined by the following
+// When sets expectation for the IStockRepository.SetStockTotalCount which will trigger the result defined by the following
 // Then helper
-func (mmReserveItems *mIStockRepositoryMockReserveItems) When(ctx context.Context, tx pgx.Tx, items []models.Item) *IStockRepositoryMockReserveItemsExpectation {
-	if mmReserveItems.mock.funcReserveItems != nil {
-		mmReserveItems.mock.t.Fatalf("IStockRepositoryMock.ReserveItems mock is already set by Set")
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) When(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64) *IStockRepositoryMockSetStockTotalCountExpectation {
+	if mmSetStockTotalCount.mock.funcSetStockTotalCount != nil {
+		mmSetStockTotalCount.mock.t.Fatalf("IStockRepositoryMock.SetStockTotalCount mock is already set by Set")
 	}
 
-	expectation := &IStockRepositoryMockReserveItemsExpectation{
-		mock:               mmReserveItems.mock,
-		params:             &IStockRepositoryMockReserveItemsParams{ctx, tx, items},
-		expectationOrigins: IStockRepositoryMockReserveItemsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &IStockRepositoryMockSetStockTotalCountExpectation{
+		mock:               mmSetStockTotalCount.mock,
+		params:             &IStockRepositoryMockSetStockTotalCountParams{ctx, tx, SKU, totalCount},
+		expectationOrigins: IStockRepositoryMockSetStockTotalCountExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmReserveItems.expectations = append(mmReserveItems.expectations, expectation)
+	mmSetStockTotalCount.expectations = append(mmSetStockTotalCount.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IStockRepository.ReserveItems return parameters for the expectation previously defined by the When method
-func (e *IStockRepositoryMockReserveItemsExpectation) Then(err error) *IStockRepositoryMock {
-	e.results = &IStockRepositoryMockReserveItemsResults{err}
+// Then sets up IStockRepository.SetStockTotalCount return parameters for the expectation previously defined by the When method
+func (e *IStockRepositoryMockSetStockTotalCountExpectation) Then(s1 models.Stock, err error) *IStockRepositoryMock {
+	e.results = &IStockRepositoryMockSetStockTotalCountResults{s1, err}
 	return e.mock
 }
 
-// Times sets number of times IStockRepository.ReserveItems should be invoked
-func (mmReserveItems *mIStockRepositoryMockReserveItems) Times(n uint64) *mIStockRepositoryMockReserveItems {
+// Times sets number of times IStockRepository.SetStockTotalCount should be invoked
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) Times(n uint64) *mIStockRepositoryMockSetStockTotalCount {
 	if n == 0 {
-		mmReserveItems.mock.t.Fatalf("Times of IStockRepositoryMock.ReserveItems mock can not be zero")
+		mmSetStockTotalCount.mock.t.Fatalf("Times of IStockRepositoryMock.SetStockTotalCount mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmReserveItems.expectedInvocations, n)
-	mmReserveItems.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmReserveItems
+	mm_atomic.StoreUint64(&mmSetStockTotalCount.expectedInvocations, n)
+	mmSetStockTotalCount.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmSetStockTotalCount
 }
 
-func (mmReserveItems *mIStockRepositoryMockReserveItems) invocationsDone() bool {
-	if len(mmReserveItems.expectations) == 0 && mmReserveItems.defaultExpectation == nil && mmReserveItems.mock.funcReserveItems == nil {
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) invocationsDone() bool {
+	if len(mmSetStockTotalCount.expectations) == 0 && mmSetStockTotalCount.defaultExpectation == nil && mmSetStockTotalCount.mock.funcSetStockTotalCount == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmReserveItems.mock.afterReserveItemsCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmReserveItems.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmSetStockTotalCount.mock.afterSetStockTotalCountCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSetStockTotalCount.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// ReserveItems implements mm_service.IStockRepository
-func (mmReserveItems *IStockRepositoryMock) ReserveItems(ctx context.Context, tx pgx.Tx, items []models.Item) (err error) {
-	mm_atomic.AddUint64(&mmReserveItems.beforeReserveItemsCounter, 1)
-	defer mm_atomic.AddUint64(&mmReserveItems.afterReserveItemsCounter, 1)
+// SetStockTotalCount implements mm_service.IStockRepository
+func (mmSetStockTotalCount *IStockRepositoryMock) SetStockTotalCount(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64) (s1 models.Stock, err error) {
+	mm_atomic.AddUint64(&mmSetStockTotalCount.beforeSetStockTotalCountCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetStockTotalCount.afterSetStockTotalCountCounter, 1)
 
-	mmReserveItems.t.Helper()
+	mmSetStockTotalCount.t.Helper()
 
-	if mmReserveItems.inspectFuncReserveItems != nil {
-		mmReserveItems.inspectFuncReserveItems(ctx, tx, items)
+	if mmSetStockTotalCount.inspectFuncSetStockTotalCount != nil {
+		mmSetStockTotalCount.inspectFuncSetStockTotalCount(ctx, tx, SKU, totalCount)
 	}
 
-	mm_params := IStockRepositoryMockReserveItemsParams{ctx, tx, items}
+	mm_params := IStockRepositoryMockSetStockTotalCountParams{ctx, tx, SKU, totalCount}
 
 	// Record call args
-	mmReserveItems.ReserveItemsMock.mutex.Lock()
-	mmReserveItems.ReserveItemsMock.callArgs = append(mmReserveItems.ReserveItemsMock.callArgs, &mm_params)
-	mmReserveItems.ReserveItemsMock.mutex.Unlock()
+	mmSetStockTotalCount.SetStockTotalCountMock.mutex.Lock()
+	mmSetStockTotalCount.SetStockTotalCountMock.callArgs = append(mmSetStockTotalCount.SetStockTotalCountMock.callArgs, &mm_params)
+	mmSetStockTotalCount.SetStockTotalCountMock.mutex.Unlock()
 
-	for _, e := range mmReserveItems.ReserveItemsMock.expectations {
+	for _, e := range mmSetStockTotalCount.SetStockTotalCountMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.s1, e.results.err
 		}
 	}
 
-	if mmReserveItems.ReserveItemsMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmReserveItems.ReserveItemsMock.defaultExpectation.Counter, 1)
-		mm_want := mmReserveItems.ReserveItemsMock.defaultExpectation.params
-		mm_want_ptrs := mmReserveItems.ReserveItemsMock.defaultExpectation.paramPtrs
+	if mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.params
+		mm_want_ptrs := mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.paramPtrs
 
-		mm_got := IStockRepositoryMockReserveItemsParams{ctx, tx, items}
+		mm_got := IStockRepositoryMockSetStockTotalCountParams{ctx, tx, SKU, totalCount}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmReserveItems.t.Errorf("IStockRepositoryMock.ReserveItems got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmReserveItems.ReserveItemsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmSetStockTotalCount.t.Errorf("IStockRepositoryMock.SetStockTotalCount got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
 			if mm_want_ptrs.tx != nil && !minimock.Equal(*mm_want_ptrs.tx, mm_got.tx) {
-				mmReserveItems.t.Errorf("IStockRepositoryMock.ReserveItems got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmReserveItems.ReserveItemsMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
+				mmSetStockTotalCount.t.Errorf("IStockRepositoryMock.SetStockTotalCount got unexpected parameter tx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.expectationOrigins.originTx, *mm_want_ptrs.tx, mm_got.tx, minimock.Diff(*mm_want_ptrs.tx, mm_got.tx))
 			}
 
-			if mm_want_ptrs.items != nil && !minimock.Equal(*mm_want_ptrs.items, mm_got.items) {
-				mmReserveItems.t.Errorf("IStockRepositoryMock.ReserveItems got unexpected parameter items, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmReserveItems.ReserveItemsMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
+			if mm_want_ptrs.SKU != nil && !minimock.Equal(*mm_want_ptrs.SKU, mm_got.SKU) {
+				mmSetStockTotalCount.t.Errorf("IStockRepositoryMock.SetStockTotalCount got unexpected parameter SKU, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.expectationOrigins.originSKU, *mm_want_ptrs.SKU, mm_got.SKU, minimock.Diff(*mm_want_ptrs.SKU, mm_got.SKU))
+			}
+
+			if mm_want_ptrs.totalCount != nil && !minimock.Equal(*mm_want_ptrs.totalCount, mm_got.totalCount) {
+				mmSetStockTotalCount.t.Errorf("IStockRepositoryMock.SetStockTotalCount got unexpected parameter totalCount, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.expectationOrigins.originTotalCount, *mm_want_ptrs.totalCount, mm_got.totalCount, minimock.Diff(*mm_want_ptrs.totalCount, mm_got.totalCount))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmReserveItems.t.Errorf("IStockRepositoryMock.ReserveItems got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmReserveItems.ReserveItemsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmSetStockTotalCount.t.Errorf("IStockRepositoryMock.SetStockTotalCount got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmReserveItems.ReserveItemsMock.defaultExpectation.results
+		mm_results := mmSetStockTotalCount.SetStockTotalCountMock.defaultExpectation.results
 		if mm_results == nil {
-			mmReserveItems.t.Fatal("No results are set for the IStockRepositoryMock.ReserveItems")
+			mmSetStockTotalCount.t.Fatal("No results are set for the IStockRepositoryMock.SetStockTotalCount")
 		}
-		return (*mm_results).err
+		return (*mm_results).s1, (*mm_results).err
 	}
-	if mmReserveItems.funcReserveItems != nil {
-		return mmReserveItems.funcReserveItems(ctx, tx, items)
+	if mmSetStockTotalCount.funcSetStockTotalCount != nil {
+		return mmSetStockTotalCount.funcSetStockTotalCount(ctx, tx, SKU, totalCount)
 	}
-	mmReserveItems.t.Fatalf("Unexpected call to IStockRepositoryMock.ReserveItems. %v %v %v", ctx, tx, items)
+	mmSetStockTotalCount.t.Fatalf("Unexpected call to IStockRepositoryMock.SetStockTotalCount. %v %v %v %v", ctx, tx, SKU, totalCount)
 	return
 }
 
-// ReserveItemsAfterCounter returns a count of finished IStockRepositoryMock.ReserveItems invocations
-func (mmReserveItems *IStockRepositoryMock) ReserveItemsAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmReserveItems.afterReserveItemsCounter)
+// SetStockTotalCountAfterCounter returns a count of finished IStockRepositoryMock.SetStockTotalCount invocations
+func (mmSetStockTotalCount *IStockRepositoryMock) SetStockTotalCountAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetStockTotalCount.afterSetStockTotalCountCounter)
 }
 
-// ReserveItemsBeforeCounter returns a count of IStockRepositoryMock.ReserveItems invocations
-func (mmReserveItems *IStockRepositoryMock) ReserveItemsBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmReserveItems.beforeReserveItemsCounter)
+// SetStockTotalCountBeforeCounter returns a count of IStockRepositoryMock.SetStockTotalCount invocations
+func (mmSetStockTotalCount *IStockRepositoryMock) SetStockTotalCountBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetStockTotalCount.beforeSetStockTotalCountCounter)
 }
 
-// Calls returns a list of arguments used in each call to IStockRepositoryMock.ReserveItems.
+// Calls returns a list of arguments used in each call to IStockRepositoryMock.SetStockTotalCount.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmReserveItems *mIStockRepositoryMockReserveItems) Calls() []*IStockRepositoryMockReserveItemsParams {
-	mmReserveItems.mutex.RLock()
+func (mmSetStockTotalCount *mIStockRepositoryMockSetStockTotalCount) Calls() []*IStockRepositoryMockSetStockTotalCountParams {
+	mmSetStockTotalCount.mutex.RLock()
 
-	argCopy := make([]*IStockRepositoryMockReserveItemsParams, len(mmReserveItems.callArgs))
-	copy(argCopy, mmReserveItems.callArgs)
+	argCopy := make([]*IStockRepositoryMockSetStockTotalCountParams, len(mmSetStockTotalCount.callArgs))
+	copy(argCopy, mmSetStockTotalCount.callArgs)
 
-	mmReserveItems.mutex.RUnlock()
+	mmSetStockTotalCount.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockReserveItemsDone returns true if the count of the ReserveItems invocations corresponds
+// MinimockSetStockTotalCountDone returns true if the count of the SetStockTotalCount invocations corresponds
 // the number of defined expectations
-func (m *IStockRepositoryMock) MinimockReserveItemsDone() bool {
-	if m.ReserveItemsMock.optional {
+func (m *IStockRepositoryMock) MinimockSetStockTotalCountDone() bool {
+	if m.SetStockTotalCountMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.ReserveItemsMock.expectations {
+	for _, e := range m.SetStockTotalCountMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.ReserveItemsMock.invocationsDone()
+	return m.SetStockTotalCountMock.invocationsDone()
 }
 
-// MinimockReserveItemsInspect logs each unmet expectation
-func (m *IStockRepositoryMock) MinimockReserveItemsInspect() {
-	for _, e := range m.ReserveItemsMock.expectations {
+// MinimockSetStockTotalCountInspect logs each unmet expectation
+func (m *IStockRepositoryMock) MinimockSetStockTotalCountInspect() {
+	for _, e := range m.SetStockTotalCountMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IStockRepositoryMock.ReserveItems at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to IStockRepositoryMock.SetStockTotalCount at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterReserveItemsCounter := mm_atomic.LoadUint64(&m.afterReserveItemsCounter)
+	afterSetStockTotalCountCounter := mm_atomic.LoadUint64(&m.afterSetStockTotalCountCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ReserveItemsMock.defaultExpectation != nil && afterReserveItemsCounter < 1 {
-		if m.ReserveItemsMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to IStockRepositoryMock.ReserveItems at\n%s", m.ReserveItemsMock.defaultExpectation.returnOrigin)
+	if m.SetStockTotalCountMock.defaultExpectation != nil && afterSetStockTotalCountCounter < 1 {
+		if m.SetStockTotalCountMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to IStockRepositoryMock.SetStockTotalCount at\n%s", m.SetStockTotalCountMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to IStockRepositoryMock.ReserveItems at\n%s with params: %#v", m.ReserveItemsMock.defaultExpectation.expectationOrigins.origin, *m.ReserveItemsMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IStockRepositoryMock.SetStockTotalCount at\n%s with params: %#v", m.SetStockTotalCountMock.defaultExpectation.expectationOrigins.origin, *m.SetStockTotalCountMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcReserveItems != nil && afterReserveItemsCounter < 1 {
-		m.t.Errorf("Expected call to IStockRepositoryMock.ReserveItems at\n%s", m.funcReserveItemsOrigin)
+	if m.funcSetStockTotalCount != nil && afterSetStockTotalCountCounter < 1 {
+		m.t.Errorf("Expected call to IStockRepositoryMock.SetStockTotalCount at\n%s", m.funcSetStockTotalCountOrigin)
 	}
 
-	if !m.ReserveItemsMock.invocationsDone() && afterReserveItemsCounter > 0 {
-		m.t.Errorf("Expected %d calls to IStockRepositoryMock.ReserveItems at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.ReserveItemsMock.expectedInvocations), m.ReserveItemsMock.expectedInvocationsOrigin, afterReserveItemsCounter)
+	if !m.SetStockTotalCountMock.invocationsDone() && afterSetStockTotalCountCounter > 0 {
+		m.t.Errorf("Expected %d calls to IStockRepositoryMock.SetStockTotalCount at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.SetStockTotalCountMock.expectedInvocations), m.SetStockTotalCountMock.expectedInvocationsOrigin, afterSetStockTotalCountCounter)
 	}
 }
 
@@ -1540,13 +4289,27 @@ func (m *IStockRepositoryMock) MinimockReserveItemsInspect() {
 func (m *IStockRepositoryMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
+			m.MinimockAdjustStockTotalCountInspect()
+
 			m.MinimockCancelReservedItemsInspect()
 
 			m.MinimockGetAvailableStockBySKUInspect()
 
+			m.MinimockGetStocksBySKUsInspect()
+
+			m.MinimockGetStocksForUpdateInspect()
+
+			m.MinimockImportStockInspect()
+
 			m.MinimockRemoveReservedItemsInspect()
 
+			m.MinimockReplenishStockInspect()
+
 			m.MinimockReserveItemsInspect()
+
+			m.MinimockRestoreRemovedItemsInspect()
+
+			m.MinimockSetStockTotalCountInspect()
 		}
 	})
 }
@@ -1570,8 +4333,15 @@ func (m *IStockRepositoryMock) MinimockWait(timeout mm_time.Duration) {
 func (m *IStockRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
+		m.MinimockAdjustStockTotalCountDone() &&
 		m.MinimockCancelReservedItemsDone() &&
 		m.MinimockGetAvailableStockBySKUDone() &&
+		m.MinimockGetStocksBySKUsDone() &&
+		m.MinimockGetStocksForUpdateDone() &&
+		m.MinimockImportStockDone() &&
 		m.MinimockRemoveReservedItemsDone() &&
-		m.MinimockReserveItemsDone()
+		m.MinimockReplenishStockDone() &&
+		m.MinimockReserveItemsDone() &&
+		m.MinimockRestoreRemovedItemsDone() &&
+		m.MinimockSetStockTotalCountDone()
 }
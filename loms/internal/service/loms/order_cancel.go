@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"route256/loms/internal/models"
 	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/replica"
 
 	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
@@ -21,10 +22,7 @@ func (s *LomsService) OrderCancel(ctx context.Context, req *models.OrderCancelRe
 		return err
 	}
 
-	// Use WithTx for transaction
-	return s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		return s.processOrderCancel(ctx, tx, req)
-	})
+	return s.processOrderCancel(ctx, req)
 }
 
 // validateOrderCancelRequest validates OrderCancelRequest.
@@ -35,10 +33,15 @@ func (s *LomsService) validateOrderCancelRequest(req *models.OrderCancelRequest)
 	return nil
 }
 
-// processOrderCancel processes cancel within transaction.
-func (s *LomsService) processOrderCancel(ctx context.Context, tx pgx.Tx, req *models.OrderCancelRequest) error {
-	// Get order by orderID
-	order, err := s.orderRepository.GetByID(ctx, req.OrderID)
+// processOrderCancel processes cancel of order.
+func (s *LomsService) processOrderCancel(ctx context.Context, req *models.OrderCancelRequest) error {
+	return s.cancelOrder(ctx, req.OrderID, models.OrderEventCancelled)
+}
+
+// cancelOrder sets order status "cancelled" and releases reserved stock within saga, eventType is written in outbox.
+func (s *LomsService) cancelOrder(ctx context.Context, orderID models.OID, eventType string) error {
+	// Get order by orderID, status is changed below so stale replica is not used
+	order, err := s.orderRepository.GetByID(replica.WithPrimary(ctx), orderID)
 	if err != nil {
 		return fmt.Errorf("failed to get order: %w", err)
 	}
@@ -48,22 +51,15 @@ func (s *LomsService) processOrderCancel(ctx context.Context, tx pgx.Tx, req *mo
 		return err
 	}
 
-	// Cancel reserved stock
-	if err := s.cancelReservedStock(ctx, tx, order.Items); err != nil {
-		return err
-	}
-
-	// Set order status to "cancelled"
-	if err := s.updateOrderStatus(ctx, req.OrderID, models.OrderStatusCancelled); err != nil {
-		return err
-	}
-
-	// Write event in outbox
-	if err := s.createOutboxEvent(ctx, tx, req.OrderID, models.OrderStatusCancelled, "OrderCancelled"); err != nil {
-		return err
-	}
-
-	return nil
+	// Set order status to "cancelled" unless it was changed concurrently, then cancel reserved stock
+	return s.runSaga(ctx, models.Saga{
+		OrderID:    orderID,
+		Type:       models.SagaTypeCancel,
+		FromStatus: order.Status,
+		ToStatus:   models.OrderStatusCancelled,
+		EventType:  eventType,
+		Items:      order.Items,
+	})
 }
 
 // validateOrderStatusForCancel check if order can be cancelled.
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"route256/loms/internal/models"
@@ -11,6 +12,8 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
+const maxIdempotencyKeyLength = 128
+
 // OrderCreate function.
 func (s *LomsService) OrderCreate(ctx context.Context, req *models.OrderCreateRequest) (*models.OrderCreateResponse, error) {
 	// Tracer
@@ -22,17 +25,37 @@ func (s *LomsService) OrderCreate(ctx context.Context, req *models.OrderCreateRe
 		return nil, err
 	}
 
+	// Replay returns order created earlier with the same idempotency key
+	idempotencyKey := s.newIdempotencyKey(req)
+	if idempotencyKey != nil {
+		orderID, err := s.findIdempotentOrder(ctx, idempotencyKey)
+		if err == nil {
+			return &models.OrderCreateResponse{OrderID: orderID}, nil
+		}
+		if !errors.Is(err, internal_errors.ErrNotFound) {
+			return nil, err
+		}
+	}
+
 	// Create order with status "new" and write event in outbox
-	orderID, err := s.createOrderWithEvent(ctx, req, "OrderCreated")
+	orderID, err := s.createOrder(ctx, req, idempotencyKey, models.OrderEventCreated)
+	if errors.Is(err, internal_errors.ErrIdempotencyKeyExists) {
+		// Concurrent request with the same key has created order first
+		orderID, err := s.findIdempotentOrder(ctx, idempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		return &models.OrderCreateResponse{OrderID: orderID}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	// Reserve stocks and update order
-	err = s.reserveStocksAndUpdateOrder(ctx, orderID, req.Items, "OrderAwaitingPayment")
+	err = s.reserveStocksAndUpdateOrder(ctx, orderID, req.Items, models.OrderEventAwaitingPayment)
 	if err != nil {
 		// Set order status "failed"
-		errUpdate := s.updateOrderStatusAndCreateEvent(ctx, orderID, models.OrderStatusFailed, "OrderFailed")
+		errUpdate := s.updateOrderStatus(ctx, orderID, models.OrderStatusFailed, models.OrderEventFailed)
 		if errUpdate != nil {
 			return nil, fmt.Errorf("%w : %w", err, errUpdate)
 		}
@@ -45,70 +68,35 @@ func (s *LomsService) OrderCreate(ctx context.Context, req *models.OrderCreateRe
 	}, nil
 }
 
-// createOrderWithEvent creates an order and writes an event to the outbox.
-func (s *LomsService) createOrderWithEvent(ctx context.Context, req *models.OrderCreateRequest, eventType string) (models.OID, error) {
-	// Create order
-	orderID, err := s.createOrder(ctx, req)
-	if err != nil {
-		return orderID, err
-	}
-
-	// Write event in outbox
-	if err := s.createOutboxEvent(ctx, nil, orderID, models.OrderStatusNew, eventType); err != nil {
-		return orderID, err
-	}
-
-	return orderID, err
-}
-
-// reserveStocksAndUpdateOrder reserves stocks and updates order status.
+// reserveStocksAndUpdateOrder reserves stocks and updates order status within saga.
 func (s *LomsService) reserveStocksAndUpdateOrder(ctx context.Context, orderID models.OID, items []models.Item, eventType string) error {
-	return s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		// Reserve stocks
-		if err := s.reserveStocks(ctx, tx, items); err != nil {
-			return fmt.Errorf("reserve stocks: %w", err)
-		}
-
-		// Update order status
-		if err := s.updateOrderStatus(ctx, orderID, models.OrderStatusAwaitingPayment); err != nil {
-			return fmt.Errorf("update order status: %w", err)
-		}
-
-		// Write event in outbox
-		if err := s.createOutboxEvent(ctx, tx, orderID, models.OrderStatusAwaitingPayment, eventType); err != nil {
-			return err
-		}
-
-		return nil
-	})
-}
-
-// updateOrderStatusAndCreateEvent updates order status and writes event within transaction.
-func (s *LomsService) updateOrderStatusAndCreateEvent(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) error {
-	return s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		// Update order status
-		if err := s.updateOrderStatus(ctx, orderID, status); err != nil {
-			return fmt.Errorf("update order status: %w", err)
-		}
-
-		// Write event in outbox
-		if err := s.createOutboxEvent(ctx, tx, orderID, status, eventType); err != nil {
-			return err
-		}
-
-		return nil
+	return s.runSaga(ctx, models.Saga{
+		OrderID:    orderID,
+		Type:       models.SagaTypeReserve,
+		FromStatus: models.OrderStatusNew,
+		ToStatus:   models.OrderStatusAwaitingPayment,
+		EventType:  eventType,
+		Items:      items,
 	})
 }
 
-// createOrder handles order creation and returns the created order ID.
-func (s *LomsService) createOrder(ctx context.Context, req *models.OrderCreateRequest) (models.OID, error) {
+// createOrder handles order creation with event of eventType and returns the created order ID.
+func (s *LomsService) createOrder(ctx context.Context, req *models.OrderCreateRequest, idempotencyKey *models.IdempotencyKey, eventType string) (models.OID, error) {
 	order := models.Order{
 		Status: models.OrderStatusNew,
 		UserID: req.User,
 		Items:  req.Items,
 	}
 
-	orderID, err := s.orderRepository.Create(ctx, order)
+	if idempotencyKey != nil {
+		orderID, err := s.orderRepository.CreateWithIdempotencyKey(ctx, order, *idempotencyKey, eventType)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create order: %w", err)
+		}
+		return orderID, nil
+	}
+
+	orderID, err := s.orderRepository.Create(ctx, order, eventType)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -134,6 +122,10 @@ func validateOrderCreateRequest(req *models.OrderCreateRequest) error {
 		return fmt.Errorf("order must contain at least one item: %w", internal_errors.ErrBadRequest)
 	}
 
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key must not exceed %d characters: %w", maxIdempotencyKeyLength, internal_errors.ErrBadRequest)
+	}
+
 	for _, item := range req.Items {
 		if item.SKU < 1 {
 			return fmt.Errorf("SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+const expiredOrdersBatchSize uint32 = 100
+
+// CancelExpiredOrders cancels orders awaiting payment longer than paymentTimeout and returns count of cancelled orders.
+func (s *LomsService) CancelExpiredOrders(ctx context.Context, paymentTimeout time.Duration) (int, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "CancelExpiredOrders")
+	defer span.End()
+
+	// Validate input data
+	if paymentTimeout <= 0 {
+		return 0, fmt.Errorf("payment timeout must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Get oldest orders awaiting payment longer than timeout on all shards
+	orders, err := s.orderRepository.GetExpiredOrders(ctx, paymentTimeout, expiredOrdersBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired orders: %w", err)
+	}
+
+	// Failed order does not stop cancellation of the rest of batch
+	cancelled := 0
+	var errs []error
+	for _, order := range orders {
+		err := s.cancelOrder(ctx, order.OrderID, models.OrderEventExpired)
+		// Order was paid or cancelled by another request or replica
+		if errors.Is(err, internal_errors.ErrInvalidOrderStatus) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to cancel expired order %d: %w", order.OrderID, err))
+			continue
+		}
+		cancelled++
+	}
+
+	return cancelled, errors.Join(errs...)
+}
@@ -28,8 +28,11 @@ func (s *LomsService) OrderInfo(ctx context.Context, req *models.OrderInfoReques
 
 	// Return order
 	return &models.OrderInfoResponse{
-		Status: order.Status,
-		User:   order.UserID,
-		Items:  order.Items,
+		Status:    order.Status,
+		User:      order.UserID,
+		Items:     order.Items,
+		CreatedAt: order.CreatedAt,
+		UpdatedAt: order.UpdatedAt,
+		History:   order.History,
 	}, nil
 }
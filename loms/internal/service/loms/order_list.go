@@ -2,22 +2,146 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"strconv"
 
 	"go.opentelemetry.io/otel"
 )
 
-// OrderList returns all orders.
-func (s *LomsService) OrderList(ctx context.Context) ([]models.Order, error) {
+const (
+	defaultOrderListPageSize uint32 = 50
+	maxOrderListPageSize     uint32 = 1000
+)
+
+// OrderList returns page of orders matching request filters.
+func (s *LomsService) OrderList(ctx context.Context, req *models.OrderListRequest) (*models.OrderListResponse, error) {
 	// Tracer
 	ctx, span := otel.Tracer("LomsService").Start(ctx, "OrderList")
 	defer span.End()
 
-	orders, err := s.orderRepository.GetOrders(ctx)
+	// Validate input data
+	if err := validateOrderListRequest(req); err != nil {
+		return nil, err
+	}
+
+	filter, err := toOrderFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultOrderListPageSize
+	}
+
+	// Request one extra order to know whether next page exists
+	page, err := s.getOrdersPage(ctx, filter, pageSize+1, req.AllowPartial)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get orders: %w", err)
 	}
 
-	return orders, nil
+	orders := page.Orders
+	res := &models.OrderListResponse{
+		Orders:            orders,
+		UnavailableShards: page.UnavailableShards,
+	}
+	if uint32(len(orders)) > pageSize {
+		res.Orders = orders[:pageSize]
+		res.NextCursor = encodeOrderCursor(res.Orders[pageSize-1].OrderID)
+	}
+
+	return res, nil
+}
+
+// getOrdersPage returns orders of all shards, unavailable shards are skipped if partial is set.
+func (s *LomsService) getOrdersPage(ctx context.Context, filter models.OrderFilter, limit uint32, partial bool) (models.OrdersPage, error) {
+	if partial {
+		return s.orderRepository.GetOrdersPartial(ctx, filter, limit)
+	}
+
+	orders, err := s.orderRepository.GetOrders(ctx, filter, limit)
+	if err != nil {
+		return models.OrdersPage{}, err
+	}
+	return models.OrdersPage{Orders: orders}, nil
+}
+
+// validateOrderListRequest validates OrderListRequest.
+func validateOrderListRequest(req *models.OrderListRequest) error {
+	if req.UserID < 0 {
+		return fmt.Errorf("userID must not be negative: %w", internal_errors.ErrBadRequest)
+	}
+
+	if req.PageSize > maxOrderListPageSize {
+		return fmt.Errorf("page size must not exceed %d: %w", maxOrderListPageSize, internal_errors.ErrBadRequest)
+	}
+
+	if !req.CreatedFrom.IsZero() && !req.CreatedTo.IsZero() && !req.CreatedFrom.Before(req.CreatedTo) {
+		return fmt.Errorf("createdFrom must be before createdTo: %w", internal_errors.ErrBadRequest)
+	}
+
+	for _, status := range req.Statuses {
+		if !isKnownOrderStatus(status) {
+			return fmt.Errorf("unknown order status %q: %w", status, internal_errors.ErrBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// toOrderFilter converts request to repository filter.
+func toOrderFilter(req *models.OrderListRequest) (models.OrderFilter, error) {
+	filter := models.OrderFilter{
+		UserID:      req.UserID,
+		Statuses:    req.Statuses,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+
+	if req.Cursor != "" {
+		afterID, err := decodeOrderCursor(req.Cursor)
+		if err != nil {
+			return models.OrderFilter{}, err
+		}
+		filter.AfterID = afterID
+	}
+
+	return filter, nil
+}
+
+// encodeOrderCursor encodes orderID of last order on page into opaque cursor.
+func encodeOrderCursor(orderID models.OID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(orderID, 10)))
+}
+
+// decodeOrderCursor decodes cursor into orderID of last order on previous page.
+func decodeOrderCursor(cursor string) (models.OID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %w", internal_errors.ErrBadRequest)
+	}
+
+	orderID, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || orderID < 1 {
+		return 0, fmt.Errorf("invalid cursor: %w", internal_errors.ErrBadRequest)
+	}
+
+	return orderID, nil
+}
+
+// isKnownOrderStatus check status is one of order statuses.
+func isKnownOrderStatus(status models.OrderStatus) bool {
+	switch status {
+	case models.OrderStatusNew,
+		models.OrderStatusAwaitingPayment,
+		models.OrderStatusFailed,
+		models.OrderStatusPayed,
+		models.OrderStatusCancelled:
+		return true
+	default:
+		return false
+	}
 }
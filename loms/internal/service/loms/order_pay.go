@@ -7,6 +7,7 @@ import (
 	"route256/loms/internal/models"
 
 	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/replica"
 
 	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
@@ -23,10 +24,7 @@ func (s *LomsService) OrderPay(ctx context.Context, req *models.OrderPayRequest)
 		return err
 	}
 
-	// Use WithTx for transaction
-	return s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		return s.processOrderPayment(ctx, tx, req)
-	})
+	return s.processOrderPayment(ctx, req)
 }
 
 // validateOrderPayRequest validates the OrderPayRequest.
@@ -37,10 +35,10 @@ func (s *LomsService) validateOrderPayRequest(req *models.OrderPayRequest) error
 	return nil
 }
 
-// processOrderPayment processes payment of order within transaction.
-func (s *LomsService) processOrderPayment(ctx context.Context, tx pgx.Tx, req *models.OrderPayRequest) error {
-	// Get info about order
-	order, err := s.orderRepository.GetByID(ctx, req.OrderID)
+// processOrderPayment removes reserved stock and sets order status "payed" within saga.
+func (s *LomsService) processOrderPayment(ctx context.Context, req *models.OrderPayRequest) error {
+	// Get info about order, status is changed below so stale replica is not used
+	order, err := s.orderRepository.GetByID(replica.WithPrimary(ctx), req.OrderID)
 	if err != nil {
 		return fmt.Errorf("failed to get order: %w", err)
 	}
@@ -50,22 +48,15 @@ func (s *LomsService) processOrderPayment(ctx context.Context, tx pgx.Tx, req *m
 		return err
 	}
 
-	// Remove reserved stock
-	if err := s.removeReservedStock(ctx, tx, order.Items); err != nil {
-		return err
-	}
-
-	// Set order status to "payed"
-	if err := s.updateOrderStatus(ctx, req.OrderID, models.OrderStatusPayed); err != nil {
-		return err
-	}
-
-	// Write event in outbox
-	if err := s.createOutboxEvent(ctx, tx, req.OrderID, models.OrderStatusPayed, "OrderPayed"); err != nil {
-		return err
-	}
-
-	return nil
+	// Remove reserved stock and set order status to "payed" unless it was changed concurrently
+	return s.runSaga(ctx, models.Saga{
+		OrderID:    req.OrderID,
+		Type:       models.SagaTypePay,
+		FromStatus: models.OrderStatusAwaitingPayment,
+		ToStatus:   models.OrderStatusPayed,
+		EventType:  models.OrderEventPayed,
+		Items:      order.Items,
+	})
 }
 
 // validateOrderStatus checks if order is in correct status for payment.
@@ -5,59 +5,187 @@ import (
 	"errors"
 	"fmt"
 	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
 )
 
-// writeEventInOutbox writes event to outbox table.
-func (s *LomsService) writeEventInOutbox(ctx context.Context, tx pgx.Tx, eventType string, orderID models.OID, status models.OrderStatus, additional string) error {
-	event := models.OrderEvent{
-		OrderID:    orderID,
-		Status:     status,
-		Time:       time.Now(),
-		Additional: additional,
+// ProcessOutbox sends outbox events to Kafka in batches by concurrent workers on each outbox shard until outboxes are empty,
+// returns count of sent events. Failed events are retried with exponential backoff
+// and become dead after opts.MaxAttempts attempts.
+func (s *LomsService) ProcessOutbox(ctx context.Context, opts models.OutboxRelayOptions) (int, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "ProcessOutbox")
+	defer span.End()
+
+	// Validate input data
+	if err := validateOutboxRelayOptions(opts); err != nil {
+		return 0, err
 	}
 
-	err := s.outboxRepository.CreateEvent(ctx, tx, eventType, event)
-	if err != nil {
-		return fmt.Errorf("failed to create outbox event: %w", err)
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		total int
+		errs  []error
+	)
+
+	// Shards are drained in parallel, workers of shard lock different rows with SKIP LOCKED, so batches do not overlap
+	for _, shard := range s.outboxRepository.Shards() {
+		for i := 0; i < opts.Concurrency; i++ {
+			wg.Add(1)
+			go func(shard models.OutboxShard) {
+				defer wg.Done()
+				for ctx.Err() == nil {
+					sent, fetched, err := s.processOutboxBatch(ctx, shard, opts)
+
+					mu.Lock()
+					total += sent
+					if err != nil {
+						errs = append(errs, err)
+					}
+					mu.Unlock()
+
+					if err != nil || fetched == 0 {
+						return
+					}
+				}
+			}(shard)
+		}
 	}
+	wg.Wait()
 
-	return nil
+	return total, errors.Join(errs...)
 }
 
-// ProcessOutbox continuously processes outbox events, sending them to Kafka and marking them as processed.
-func (s *LomsService) ProcessOutbox(ctx context.Context) error {
-	for {
-		err := s.processNextOutboxEvent(ctx)
+// CleanupOutbox removes events processed longer than retention ago from all outbox shards and returns count of removed events.
+func (s *LomsService) CleanupOutbox(ctx context.Context, retention time.Duration) (int64, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "CleanupOutbox")
+	defer span.End()
+
+	// Validate input data
+	if retention <= 0 {
+		return 0, fmt.Errorf("retention must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	// Failed shard does not stop cleanup of the rest
+	processedBefore := time.Now().Add(-retention)
+	var total int64
+	var errs []error
+	for _, shard := range s.outboxRepository.Shards() {
+		deleted, err := s.outboxRepository.DeleteProcessed(ctx, shard, processedBefore)
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return nil
-			}
-			return err
+			errs = append(errs, fmt.Errorf("failed to delete processed outbox events of shard %d: %w", shard, err))
+			continue
 		}
+		total += deleted
 	}
+
+	return total, errors.Join(errs...)
 }
 
-// processNextOutboxEvent processes the next outbox event.
-func (s *LomsService) processNextOutboxEvent(ctx context.Context) error {
-	return s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		event, err := s.outboxRepository.FetchNextMsg(ctx, tx)
+// processOutboxBatch sends one batch of events of outbox shard, marks delivered events as processed
+// and schedules retry of failed events, returns counts of sent and fetched events.
+func (s *LomsService) processOutboxBatch(ctx context.Context, shard models.OutboxShard, opts models.OutboxRelayOptions) (int, int, error) {
+	var sent, fetched int
+	var errSend error
+	err := s.outboxRepository.WithShardTx(ctx, shard, func(ctx context.Context, tx pgx.Tx) error {
+		events, err := s.outboxRepository.FetchBatch(ctx, tx, opts.BatchSize)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to fetch outbox events: %w", err)
+		}
+		fetched = len(events)
+		if fetched == 0 {
+			return nil
 		}
 
-		// Send event to Kafka
-		if err := s.producer.SendOutboxEvent(ctx, event); err != nil {
-			return fmt.Errorf("failed to send event to Kafka: %w", err)
+		// Send events to Kafka, unconfirmed events stay in outbox for next batch
+		var delivery map[int64]error
+		delivery, errSend = s.producer.SendOutboxEvents(ctx, events)
+
+		sentIDs := make([]int64, 0, len(delivery))
+		var failed []error
+		for _, event := range events {
+			sendErr, ok := delivery[event.ID]
+			if !ok {
+				continue
+			}
+			if sendErr == nil {
+				sentIDs = append(sentIDs, event.ID)
+				continue
+			}
+
+			// Schedule next attempt of failed event or give up on it
+			nextAttemptAt, state := nextOutboxAttempt(event.Attempts, opts)
+			if err := s.outboxRepository.MarkAsFailed(ctx, tx, event.ID, sendErr, nextAttemptAt, state); err != nil {
+				return fmt.Errorf("failed to mark event %d as failed: %w", event.ID, err)
+			}
+			failed = append(failed, fmt.Errorf("event %d: %w", event.ID, sendErr))
 		}
+		errSend = errors.Join(append(failed, errSend)...)
 
-		// Mark event as sent
-		if err := s.outboxRepository.MarkAsSent(ctx, tx, event.ID); err != nil {
-			return fmt.Errorf("failed to mark event as sent: %w", err)
+		// Mark delivered events as sent
+		if len(sentIDs) > 0 {
+			if err := s.outboxRepository.MarkAsSent(ctx, tx, sentIDs); err != nil {
+				return fmt.Errorf("failed to mark events as sent: %w", err)
+			}
+			sent = len(sentIDs)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return 0, fetched, err
+	}
+
+	// Delivered events and failed attempts are committed even if part of batch failed
+	if errSend != nil {
+		return sent, fetched, fmt.Errorf("failed to send events to Kafka: %w", errSend)
+	}
+
+	return sent, fetched, nil
+}
+
+// nextOutboxAttempt returns time of next delivery attempt of event failed after attempts previous attempts
+// and state of event, event becomes dead when attempts are exhausted.
+func nextOutboxAttempt(attempts int32, opts models.OutboxRelayOptions) (time.Time, models.OutboxEventState) {
+	if attempts+1 >= opts.MaxAttempts {
+		return time.Now(), models.OutboxEventStateDead
+	}
+
+	// Delay doubles with each attempt up to RetryMaxDelay
+	delay := opts.RetryBaseDelay
+	for i := int32(0); i < attempts && delay < opts.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > opts.RetryMaxDelay {
+		delay = opts.RetryMaxDelay
+	}
+
+	return time.Now().Add(delay), models.OutboxEventStatePending
+}
+
+// validateOutboxRelayOptions validates OutboxRelayOptions.
+func validateOutboxRelayOptions(opts models.OutboxRelayOptions) error {
+	if opts.BatchSize == 0 {
+		return fmt.Errorf("batch size must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	if opts.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	if opts.MaxAttempts < 1 {
+		return fmt.Errorf("max attempts must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	if opts.RetryBaseDelay <= 0 || opts.RetryMaxDelay < opts.RetryBaseDelay {
+		return fmt.Errorf("retry delays must be positive and max delay not less than base delay: %w", internal_errors.ErrBadRequest)
+	}
+
+	return nil
 }
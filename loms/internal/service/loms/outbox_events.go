@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultOutboxEventListPageSize uint32 = 50
+	maxOutboxEventListPageSize     uint32 = 1000
+)
+
+// OutboxEventList returns page of events of outbox shard matching request filters.
+func (s *LomsService) OutboxEventList(ctx context.Context, req *models.OutboxEventListRequest) (*models.OutboxEventListResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "OutboxEventList")
+	defer span.End()
+
+	// Validate input data
+	if err := validateOutboxEventListRequest(req); err != nil {
+		return nil, err
+	}
+	if err := s.validateOutboxShard(req.Shard); err != nil {
+		return nil, err
+	}
+
+	filter := models.OutboxEventFilter{
+		IDs:         req.IDs,
+		States:      req.States,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+	if req.Cursor != "" {
+		afterID, err := decodeOutboxEventCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.AfterID = afterID
+	}
+
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultOutboxEventListPageSize
+	}
+
+	// Request one extra event to know whether next page exists
+	events, err := s.outboxRepository.List(ctx, req.Shard, filter, pageSize+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox events: %w", err)
+	}
+
+	res := &models.OutboxEventListResponse{
+		Events: events,
+	}
+	if uint32(len(events)) > pageSize {
+		res.Events = events[:pageSize]
+		res.NextCursor = encodeOutboxEventCursor(res.Events[pageSize-1].ID)
+	}
+
+	return res, nil
+}
+
+// OutboxEventRequeue queues dead outbox events for sending again, returns count of queued events.
+func (s *LomsService) OutboxEventRequeue(ctx context.Context, req *models.OutboxEventResetRequest) (*models.OutboxEventResetResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "OutboxEventRequeue")
+	defer span.End()
+
+	return s.resetOutboxEvents(ctx, req, models.OutboxEventStateDead)
+}
+
+// OutboxEventReplay queues already processed outbox events for sending again, returns count of queued events.
+func (s *LomsService) OutboxEventReplay(ctx context.Context, req *models.OutboxEventResetRequest) (*models.OutboxEventResetResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "OutboxEventReplay")
+	defer span.End()
+
+	return s.resetOutboxEvents(ctx, req, models.OutboxEventStateProcessed)
+}
+
+// resetOutboxEvents moves events in fromState selected by request back to pending state.
+func (s *LomsService) resetOutboxEvents(ctx context.Context, req *models.OutboxEventResetRequest, fromState models.OutboxEventState) (*models.OutboxEventResetResponse, error) {
+	// Validate input data
+	if err := validateOutboxEventResetRequest(req); err != nil {
+		return nil, err
+	}
+	if err := s.validateOutboxShard(req.Shard); err != nil {
+		return nil, err
+	}
+
+	filter := models.OutboxEventFilter{
+		IDs:         req.IDs,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+
+	count, err := s.outboxRepository.Reset(ctx, req.Shard, filter, []models.OutboxEventState{fromState})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset %s outbox events: %w", fromState, err)
+	}
+
+	return &models.OutboxEventResetResponse{Count: count}, nil
+}
+
+// validateOutboxEventListRequest validates OutboxEventListRequest.
+func validateOutboxEventListRequest(req *models.OutboxEventListRequest) error {
+	if req.PageSize > maxOutboxEventListPageSize {
+		return fmt.Errorf("page size must not exceed %d: %w", maxOutboxEventListPageSize, internal_errors.ErrBadRequest)
+	}
+
+	if !req.CreatedFrom.IsZero() && !req.CreatedTo.IsZero() && !req.CreatedFrom.Before(req.CreatedTo) {
+		return fmt.Errorf("createdFrom must be before createdTo: %w", internal_errors.ErrBadRequest)
+	}
+
+	for _, id := range req.IDs {
+		if id < 1 {
+			return fmt.Errorf("event ID must be greater than zero: %w", internal_errors.ErrBadRequest)
+		}
+	}
+
+	for _, state := range req.States {
+		if !isKnownOutboxEventState(state) {
+			return fmt.Errorf("unknown outbox event state %q: %w", state, internal_errors.ErrBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// validateOutboxEventResetRequest validates OutboxEventResetRequest, events must be selected
+// by IDs or creation time range, so whole outbox can not be reset by mistake.
+func validateOutboxEventResetRequest(req *models.OutboxEventResetRequest) error {
+	if len(req.IDs) == 0 && req.CreatedFrom.IsZero() && req.CreatedTo.IsZero() {
+		return fmt.Errorf("event IDs or creation time range must be set: %w", internal_errors.ErrBadRequest)
+	}
+
+	if !req.CreatedFrom.IsZero() && !req.CreatedTo.IsZero() && !req.CreatedFrom.Before(req.CreatedTo) {
+		return fmt.Errorf("createdFrom must be before createdTo: %w", internal_errors.ErrBadRequest)
+	}
+
+	for _, id := range req.IDs {
+		if id < 1 {
+			return fmt.Errorf("event ID must be greater than zero: %w", internal_errors.ErrBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// validateOutboxShard checks shard is one of outbox shards.
+func (s *LomsService) validateOutboxShard(shard models.OutboxShard) error {
+	for _, known := range s.outboxRepository.Shards() {
+		if known == shard {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outbox shard %d: %w", shard, internal_errors.ErrBadRequest)
+}
+
+// encodeOutboxEventCursor encodes ID of last event on page into opaque cursor.
+func encodeOutboxEventCursor(eventID int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(eventID, 10)))
+}
+
+// decodeOutboxEventCursor decodes cursor into ID of last event on previous page.
+func decodeOutboxEventCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %w", internal_errors.ErrBadRequest)
+	}
+
+	eventID, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || eventID < 1 {
+		return 0, fmt.Errorf("invalid cursor: %w", internal_errors.ErrBadRequest)
+	}
+
+	return eventID, nil
+}
+
+// isKnownOutboxEventState check state is one of outbox event states.
+func isKnownOutboxEventState(state models.OutboxEventState) bool {
+	switch state {
+	case models.OutboxEventStatePending,
+		models.OutboxEventStateProcessed,
+		models.OutboxEventStateDead:
+		return true
+	default:
+		return false
+	}
+}
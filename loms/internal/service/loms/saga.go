@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/replica"
+	"route256/utils/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const sagaRecoveryBatchSize uint32 = 100
+
+// Order status and its outbox event live on order shard while stocks live in the main database, so they can not
+// be changed in one transaction. Saga makes the change durable:
+//  1. saga is created together with stock changes of prepare step in main database;
+//  2. order status is changed on shard with compare and set together with outbox event;
+//  3. saga is completed together with stock changes of commit step in main database.
+//
+// If order status can not be changed, prepare step is compensated. Crash between steps leaves saga started,
+// RecoverSagas finishes it depending on actual order status.
+
+// runSaga creates saga and runs it until order status is changed.
+func (s *LomsService) runSaga(ctx context.Context, saga models.Saga) error {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "runSaga")
+	defer span.End()
+
+	// Create saga and prepare stocks
+	err := s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		sagaID, err := s.sagaRepository.Create(ctx, tx, saga)
+		if err != nil {
+			return fmt.Errorf("failed to start saga: %w", err)
+		}
+		saga.ID = sagaID
+
+		return s.prepareSaga(ctx, tx, saga)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Change order status on shard
+	err = s.compareAndSetOrderStatus(ctx, saga.OrderID, saga.FromStatus, saga.ToStatus, saga.EventType)
+	if errors.Is(err, internal_errors.ErrInvalidOrderStatus) {
+		return s.finishChangedSaga(ctx, saga, err)
+	}
+	if err != nil {
+		// Status may be changed or not, saga is finished by recovery
+		s.recordSagaAttempt(ctx, saga, err)
+		return err
+	}
+
+	// Complete saga
+	if err := s.completeSaga(ctx, saga); err != nil {
+		// Order status is already changed, saga is completed by recovery
+		s.recordSagaAttempt(ctx, saga, err)
+		logger.Errorw(ctx, "Failed to complete saga", "sagaID", saga.ID, "orderID", saga.OrderID, "error", err)
+	}
+
+	return nil
+}
+
+// finishChangedSaga finishes saga whose order status was changed concurrently. Only one saga of order is started,
+// so order in target status was changed by recovery of this saga and saga is completed, otherwise stocks are
+// returned to state before saga.
+func (s *LomsService) finishChangedSaga(ctx context.Context, saga models.Saga, statusErr error) error {
+	order, err := s.orderRepository.GetByID(replica.WithPrimary(ctx), saga.OrderID)
+	if err != nil {
+		// Saga is finished by recovery
+		s.recordSagaAttempt(ctx, saga, err)
+		return fmt.Errorf("%w : failed to get order: %w", statusErr, err)
+	}
+
+	if order.Status == saga.ToStatus {
+		err := s.completeSaga(ctx, saga)
+		// Saga was completed by recovery
+		if errors.Is(err, internal_errors.ErrSagaFinished) {
+			return nil
+		}
+		if err != nil {
+			// Order status is already changed, saga is completed by recovery
+			s.recordSagaAttempt(ctx, saga, err)
+			logger.Errorw(ctx, "Failed to complete saga", "sagaID", saga.ID, "orderID", saga.OrderID, "error", err)
+		}
+		return nil
+	}
+
+	if err := s.compensateSaga(ctx, saga); err != nil {
+		s.recordSagaAttempt(ctx, saga, err)
+		return fmt.Errorf("%w : %w", statusErr, err)
+	}
+	return statusErr
+}
+
+// completeSaga applies commit step in one transaction with saga state.
+func (s *LomsService) completeSaga(ctx context.Context, saga models.Saga) error {
+	return s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		// Saga row is locked, so it is finished only once
+		if err := s.sagaRepository.SetState(ctx, tx, saga.ID, models.SagaStateCompleted); err != nil {
+			return fmt.Errorf("failed to complete saga: %w", err)
+		}
+
+		return s.commitSaga(ctx, tx, saga)
+	})
+}
+
+// compensateSaga undoes prepare step in one transaction with saga state.
+func (s *LomsService) compensateSaga(ctx context.Context, saga models.Saga) error {
+	return s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		// Saga row is locked, so it is finished only once
+		if err := s.sagaRepository.SetState(ctx, tx, saga.ID, models.SagaStateCompensated); err != nil {
+			return fmt.Errorf("failed to compensate saga: %w", err)
+		}
+
+		return s.undoPrepareSaga(ctx, tx, saga)
+	})
+}
+
+// prepareSaga changes stocks before order status is changed.
+func (s *LomsService) prepareSaga(ctx context.Context, tx pgx.Tx, saga models.Saga) error {
+	switch saga.Type {
+	case models.SagaTypeReserve:
+		if err := s.reserveStocks(ctx, tx, saga.Items); err != nil {
+			return fmt.Errorf("reserve stocks: %w", err)
+		}
+	case models.SagaTypePay:
+		return s.removeReservedStock(ctx, tx, saga.Items)
+	}
+	return nil
+}
+
+// undoPrepareSaga returns stocks changed by prepare step.
+func (s *LomsService) undoPrepareSaga(ctx context.Context, tx pgx.Tx, saga models.Saga) error {
+	switch saga.Type {
+	case models.SagaTypeReserve:
+		return s.cancelReservedStock(ctx, tx, saga.Items)
+	case models.SagaTypePay:
+		if err := s.stockRepository.RestoreRemovedItems(ctx, tx, saga.Items); err != nil {
+			return fmt.Errorf("failed to restore removed stock: %w", err)
+		}
+	}
+	return nil
+}
+
+// commitSaga changes stocks after order status is changed.
+func (s *LomsService) commitSaga(ctx context.Context, tx pgx.Tx, saga models.Saga) error {
+	// Only order awaiting payment has reserved stock
+	if saga.Type == models.SagaTypeCancel && saga.FromStatus == models.OrderStatusAwaitingPayment {
+		return s.cancelReservedStock(ctx, tx, saga.Items)
+	}
+	return nil
+}
+
+// RecoverSagas finishes sagas not updated for staleAfter and returns count of finished sagas.
+func (s *LomsService) RecoverSagas(ctx context.Context, staleAfter time.Duration) (int, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "RecoverSagas")
+	defer span.End()
+
+	// Validate input data
+	if staleAfter <= 0 {
+		return 0, fmt.Errorf("stale interval must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	sagas, err := s.sagaRepository.GetStarted(ctx, time.Now().Add(-staleAfter), sagaRecoveryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get started sagas: %w", err)
+	}
+
+	// Failed saga does not stop recovery of the rest of batch
+	recovered := 0
+	var errs []error
+	for _, saga := range sagas {
+		err := s.recoverSaga(ctx, saga)
+		// Saga was finished by request or another replica
+		if errors.Is(err, internal_errors.ErrSagaFinished) {
+			continue
+		}
+		if err != nil {
+			s.recordSagaAttempt(ctx, saga, err)
+			errs = append(errs, fmt.Errorf("failed to recover saga %d: %w", saga.ID, err))
+			continue
+		}
+		recovered++
+	}
+
+	return recovered, errors.Join(errs...)
+}
+
+// recoverSaga moves saga forward or compensates it depending on order status.
+func (s *LomsService) recoverSaga(ctx context.Context, saga models.Saga) error {
+	order, err := s.orderRepository.GetByID(replica.WithPrimary(ctx), saga.OrderID)
+	if errors.Is(err, internal_errors.ErrNotFound) {
+		return s.compensateSaga(ctx, saga)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	switch order.Status {
+	case saga.ToStatus:
+		return s.completeSaga(ctx, saga)
+	case saga.FromStatus:
+		// Status was not changed, request is repeated
+		if err := s.compareAndSetOrderStatus(ctx, saga.OrderID, saga.FromStatus, saga.ToStatus, saga.EventType); err != nil {
+			return err
+		}
+		return s.completeSaga(ctx, saga)
+	default:
+		return s.compensateSaga(ctx, saga)
+	}
+}
+
+// recordSagaAttempt stores error of saga, failure is only logged to keep original error.
+func (s *LomsService) recordSagaAttempt(ctx context.Context, saga models.Saga, sagaErr error) {
+	if err := s.sagaRepository.RecordAttempt(ctx, saga.ID, sagaErr); err != nil {
+		logger.Errorw(ctx, "Failed to record saga attempt", "sagaID", saga.ID, "error", err)
+	}
+}
@@ -3,22 +3,43 @@ package service
 import (
 	"context"
 	"route256/loms/internal/models"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
 type IOrderRepository interface {
-	Create(ctx context.Context, order models.Order) (models.OID, error)
+	Create(ctx context.Context, order models.Order, eventType string) (models.OID, error)
+	CreateWithIdempotencyKey(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string) (models.OID, error)
+	GetIdempotencyKey(ctx context.Context, userID models.UID, key string) (models.IdempotencyKey, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
 	GetByID(ctx context.Context, orderID models.OID) (models.Order, error)
-	SetStatus(ctx context.Context, orderID models.OID, status models.OrderStatus) error
-	GetOrders(ctx context.Context) ([]models.Order, error)
+	SetStatus(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) error
+	CompareAndSetStatus(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error
+	GetOrders(ctx context.Context, filter models.OrderFilter, limit uint32) ([]models.Order, error)
+	GetOrdersPartial(ctx context.Context, filter models.OrderFilter, limit uint32) (models.OrdersPage, error)
+	GetExpiredOrders(ctx context.Context, paymentTimeout time.Duration, limit uint32) ([]models.Order, error)
 }
 
 type IStockRepository interface {
 	GetAvailableStockBySKU(ctx context.Context, SKU models.SKU) (uint64, error)
+	GetStocksBySKUs(ctx context.Context, SKUs []models.SKU) ([]models.Stock, error)
 	ReserveItems(ctx context.Context, tx pgx.Tx, items []models.Item) error
 	RemoveReservedItems(ctx context.Context, tx pgx.Tx, items []models.Item) error
 	CancelReservedItems(ctx context.Context, tx pgx.Tx, items []models.Item) error
+	RestoreRemovedItems(ctx context.Context, tx pgx.Tx, items []models.Item) error
+	ReplenishStock(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64) (models.Stock, error)
+	SetStockTotalCount(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64) (models.Stock, error)
+	AdjustStockTotalCount(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64) (models.Stock, error)
+	GetStocksForUpdate(ctx context.Context, tx pgx.Tx, SKUs []models.SKU) ([]models.Stock, error)
+	ImportStock(ctx context.Context, tx pgx.Tx, stock models.Stock) (models.Stock, error)
+}
+
+type ISagaRepository interface {
+	Create(ctx context.Context, tx pgx.Tx, saga models.Saga) (int64, error)
+	SetState(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error
+	RecordAttempt(ctx context.Context, sagaID int64, lastErr error) error
+	GetStarted(ctx context.Context, updatedBefore time.Time, limit uint32) ([]models.Saga, error)
 }
 
 type WithTxFunc func(ctx context.Context, tx pgx.Tx) error
@@ -28,30 +49,42 @@ type ITxManager interface {
 }
 
 type IProducer interface {
-	SendOutboxEvent(ctx context.Context, event *models.OutboxEvent) error
+	SendOutboxEvents(ctx context.Context, events []models.OutboxEvent) (map[int64]error, error)
 }
 
 type IOutboxRepository interface {
+	Shards() []models.OutboxShard
+	WithShardTx(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error
 	CreateEvent(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error
-	FetchNextMsg(ctx context.Context, tx pgx.Tx) (*models.OutboxEvent, error)
-	MarkAsSent(ctx context.Context, tx pgx.Tx, eventID int64) error
+	FetchBatch(ctx context.Context, tx pgx.Tx, limit uint32) ([]models.OutboxEvent, error)
+	MarkAsSent(ctx context.Context, tx pgx.Tx, eventIDs []int64) error
+	MarkAsFailed(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) error
+	DeleteProcessed(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) (int64, error)
+	List(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) ([]models.OutboxEvent, error)
+	Reset(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) (int64, error)
 }
 
 type LomsService struct {
 	orderRepository  IOrderRepository
 	stockRepository  IStockRepository
 	outboxRepository IOutboxRepository
+	sagaRepository   ISagaRepository
 	txManager        ITxManager
 	producer         IProducer
+
+	idempotencyKeyRetention time.Duration
 }
 
 // NewService return instance of LomsService.
-func NewService(orderRepository IOrderRepository, stockRepository IStockRepository, outboxRepository IOutboxRepository, txManager ITxManager, producer IProducer) *LomsService {
+func NewService(orderRepository IOrderRepository, stockRepository IStockRepository, outboxRepository IOutboxRepository, sagaRepository ISagaRepository, txManager ITxManager, producer IProducer, idempotencyKeyRetention time.Duration) *LomsService {
 	return &LomsService{
 		orderRepository:  orderRepository,
 		stockRepository:  stockRepository,
 		outboxRepository: outboxRepository,
+		sagaRepository:   sagaRepository,
 		txManager:        txManager,
 		producer:         producer,
+
+		idempotencyKeyRetention: idempotencyKeyRetention,
 	}
 }
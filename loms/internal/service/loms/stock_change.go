@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const maxStockChangeCommentLength = 255
+
+// StockReplenish adds items to stock and writes StockReplenished event in outbox.
+func (s *LomsService) StockReplenish(ctx context.Context, req *models.StockReplenishRequest) (*models.StockChangeResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "StockReplenish")
+	defer span.End()
+
+	// Validate input data
+	if req.Count < 1 {
+		return nil, fmt.Errorf("count must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+	if err := validateStockChange(req.SKU, req.Reason, req.Comment); err != nil {
+		return nil, err
+	}
+
+	var stock models.Stock
+	err := s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		stock, err = s.stockRepository.ReplenishStock(ctx, tx, req.SKU, req.Count)
+		if err != nil {
+			return fmt.Errorf("failed to replenish stock: %w", err)
+		}
+
+		return s.writeStockEventInOutbox(ctx, tx, models.StockEventReplenished, stock, int64(req.Count), req.Reason, req.Comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StockChangeResponse{Stock: stock}, nil
+}
+
+// StockSet sets total count of stock and writes StockSet event in outbox.
+func (s *LomsService) StockSet(ctx context.Context, req *models.StockSetRequest) (*models.StockChangeResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "StockSet")
+	defer span.End()
+
+	// Validate input data
+	if err := validateStockChange(req.SKU, req.Reason, req.Comment); err != nil {
+		return nil, err
+	}
+
+	var stock models.Stock
+	err := s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		stock, err = s.stockRepository.SetStockTotalCount(ctx, tx, req.SKU, req.TotalCount)
+		if err != nil {
+			return fmt.Errorf("failed to set stock: %w", err)
+		}
+
+		return s.writeStockEventInOutbox(ctx, tx, models.StockEventSet, stock, 0, req.Reason, req.Comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StockChangeResponse{Stock: stock}, nil
+}
+
+// StockAdjust changes total count of stock by delta and writes StockAdjusted event in outbox.
+func (s *LomsService) StockAdjust(ctx context.Context, req *models.StockAdjustRequest) (*models.StockChangeResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "StockAdjust")
+	defer span.End()
+
+	// Validate input data
+	if req.Delta == 0 {
+		return nil, fmt.Errorf("delta must not be zero: %w", internal_errors.ErrBadRequest)
+	}
+	if err := validateStockChange(req.SKU, req.Reason, req.Comment); err != nil {
+		return nil, err
+	}
+
+	var stock models.Stock
+	err := s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		stock, err = s.stockRepository.AdjustStockTotalCount(ctx, tx, req.SKU, req.Delta)
+		if err != nil {
+			return fmt.Errorf("failed to adjust stock: %w", err)
+		}
+
+		return s.writeStockEventInOutbox(ctx, tx, models.StockEventAdjusted, stock, req.Delta, req.Reason, req.Comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StockChangeResponse{Stock: stock}, nil
+}
+
+// writeStockEventInOutbox writes stock change event to outbox table.
+func (s *LomsService) writeStockEventInOutbox(ctx context.Context, tx pgx.Tx, eventType string, stock models.Stock, delta int64, reason models.StockChangeReason, comment string) error {
+	event := models.StockEvent{
+		SKU:        stock.SKU,
+		TotalCount: stock.TotalCount,
+		Reserved:   stock.Reserved,
+		Delta:      delta,
+		Reason:     reason,
+		Comment:    comment,
+		Time:       time.Now(),
+	}
+
+	if err := s.outboxRepository.CreateEvent(ctx, tx, eventType, event); err != nil {
+		return fmt.Errorf("failed to create outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// validateStockChange validates fields common for stock change requests.
+func validateStockChange(SKU models.SKU, reason models.StockChangeReason, comment string) error {
+	if SKU < 1 {
+		return fmt.Errorf("SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+	}
+
+	if !isKnownStockChangeReason(reason) {
+		return fmt.Errorf("unknown stock change reason %q: %w", reason, internal_errors.ErrBadRequest)
+	}
+
+	if len(comment) > maxStockChangeCommentLength {
+		return fmt.Errorf("comment must not exceed %d characters: %w", maxStockChangeCommentLength, internal_errors.ErrBadRequest)
+	}
+
+	return nil
+}
+
+// isKnownStockChangeReason check reason is one of stock change reasons.
+func isKnownStockChangeReason(reason models.StockChangeReason) bool {
+	switch reason {
+	case models.StockChangeReasonDelivery,
+		models.StockChangeReasonReturn,
+		models.StockChangeReasonInventory,
+		models.StockChangeReasonDamage,
+		models.StockChangeReasonCorrection:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/stock_import"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+// StockImport upserts stocks and writes StockSet events in outbox, nothing is written on dry run.
+// New stocks are not reserved and reserved count of existing stock is preserved, rows with total count less than
+// reserved are rejected.
+func (s *LomsService) StockImport(ctx context.Context, req *models.StockImportRequest) (*models.StockImportResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "StockImport")
+	defer span.End()
+
+	// Validate input data
+	if len(req.Rows) == 0 {
+		return nil, fmt.Errorf("no stocks to import: %w", internal_errors.ErrBadRequest)
+	}
+
+	resp := &models.StockImportResponse{Applied: !req.DryRun}
+	err := s.txManager.WithTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		SKUs := make([]models.SKU, len(req.Rows))
+		for i, row := range req.Rows {
+			SKUs[i] = row.Stock.SKU
+		}
+
+		// Lock existing stocks, so diff stays actual until commit
+		stocks, err := s.stockRepository.GetStocksForUpdate(ctx, tx, SKUs)
+		if err != nil {
+			return fmt.Errorf("failed to get stocks: %w", err)
+		}
+
+		current := make(map[models.SKU]models.Stock, len(stocks))
+		for _, stock := range stocks {
+			current[stock.SKU] = stock
+		}
+
+		// Diff
+		var rowErrs stock_import.Errors
+		changes := make([]models.StockImportChange, 0, len(req.Rows))
+		for _, row := range req.Rows {
+			change, err := diffStock(row, current)
+			if err != nil {
+				rowErrs = append(rowErrs, stock_import.RowError{Line: row.Line, Message: err.Error()})
+				continue
+			}
+			changes = append(changes, change)
+		}
+		if len(rowErrs) > 0 {
+			return rowErrs
+		}
+
+		resp.Changes = changes
+		for _, change := range changes {
+			switch change.Action {
+			case models.StockImportActionCreate:
+				resp.Created++
+			case models.StockImportActionUpdate:
+				resp.Updated++
+			default:
+				resp.Unchanged++
+			}
+		}
+
+		if req.DryRun {
+			return nil
+		}
+
+		// Apply
+		for i, change := range changes {
+			if change.Action == models.StockImportActionUnchanged {
+				continue
+			}
+
+			stock, err := s.stockRepository.ImportStock(ctx, tx, req.Rows[i].Stock)
+			if err != nil {
+				return fmt.Errorf("failed to import stock on line %d: %w", change.Line, err)
+			}
+
+			if err := s.writeStockEventInOutbox(ctx, tx, models.StockEventSet, stock, 0, models.StockChangeReasonImport, ""); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// diffStock compares imported row with current stock.
+func diffStock(row models.StockImportRow, current map[models.SKU]models.Stock) (models.StockImportChange, error) {
+	change := models.StockImportChange{
+		Line:          row.Line,
+		SKU:           row.Stock.SKU,
+		NewTotalCount: row.Stock.TotalCount,
+	}
+
+	// Only orders reserve stock
+	if row.Stock.Reserved != 0 {
+		return models.StockImportChange{}, fmt.Errorf("reserved %d must be 0, stock is reserved only by orders", row.Stock.Reserved)
+	}
+
+	stock, ok := current[row.Stock.SKU]
+	if !ok {
+		change.Action = models.StockImportActionCreate
+		return change, nil
+	}
+
+	if row.Stock.TotalCount < stock.Reserved {
+		return models.StockImportChange{}, fmt.Errorf("total_count %d is less than reserved %d", row.Stock.TotalCount, stock.Reserved)
+	}
+
+	change.OldTotalCount = stock.TotalCount
+	change.Reserved = stock.Reserved
+	change.Action = models.StockImportActionUpdate
+	if stock.TotalCount == row.Stock.TotalCount {
+		change.Action = models.StockImportActionUnchanged
+	}
+
+	return change, nil
+}
@@ -9,6 +9,8 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
+const maxStocksInfoBatchSize = 100
+
 // StocksInfo function.
 func (s *LomsService) StocksInfo(ctx context.Context, req *models.StocksInfoRequest) (*models.StocksInfoResponse, error) {
 	// Tracer
@@ -30,3 +32,65 @@ func (s *LomsService) StocksInfo(ctx context.Context, req *models.StocksInfoRequ
 		Count: count,
 	}, nil
 }
+
+// StocksInfoBatch returns stocks of several SKUs with single query, SKUs without stock are reported separately.
+func (s *LomsService) StocksInfoBatch(ctx context.Context, req *models.StocksInfoBatchRequest) (*models.StocksInfoBatchResponse, error) {
+	// Tracer
+	ctx, span := otel.Tracer("LomsService").Start(ctx, "StocksInfoBatch")
+	defer span.End()
+
+	// Validate input data
+	if len(req.SKUs) == 0 || len(req.SKUs) > maxStocksInfoBatchSize {
+		return nil, fmt.Errorf("count of SKUs must be in range [1, %d]: %w", maxStocksInfoBatchSize, internal_errors.ErrBadRequest)
+	}
+
+	SKUs := make([]models.SKU, 0, len(req.SKUs))
+	seen := make(map[models.SKU]struct{}, len(req.SKUs))
+	for _, SKU := range req.SKUs {
+		if SKU < 1 {
+			return nil, fmt.Errorf("SKU must be greater than zero: %w", internal_errors.ErrBadRequest)
+		}
+		if _, ok := seen[SKU]; ok {
+			continue
+		}
+		seen[SKU] = struct{}{}
+		SKUs = append(SKUs, SKU)
+	}
+
+	// Get stocks by SKUs
+	stocks, err := s.stockRepository.GetStocksBySKUs(ctx, SKUs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocks: %w", err)
+	}
+
+	found := make(map[models.SKU]models.Stock, len(stocks))
+	for _, stock := range stocks {
+		found[stock.SKU] = stock
+	}
+
+	// Keep order of request
+	resp := &models.StocksInfoBatchResponse{
+		Stocks: make([]models.StockInfo, 0, len(stocks)),
+	}
+	for _, SKU := range SKUs {
+		stock, ok := found[SKU]
+		if !ok {
+			resp.UnknownSKUs = append(resp.UnknownSKUs, SKU)
+			continue
+		}
+
+		var available uint64
+		if stock.TotalCount > stock.Reserved {
+			available = stock.TotalCount - stock.Reserved
+		}
+
+		resp.Stocks = append(resp.Stocks, models.StockInfo{
+			SKU:        stock.SKU,
+			Available:  available,
+			TotalCount: stock.TotalCount,
+			Reserved:   stock.Reserved,
+		})
+	}
+
+	return resp, nil
+}
@@ -21,7 +21,7 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 		name       string
 		req        *models.OrderCancelRequest
 		setupMocks func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-			stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+			stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 			txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest)
 		expectedErr   error
 		errorContains string
@@ -32,10 +32,10 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 				OrderID: 1,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest) {
 				order := models.Order{
-					Status: models.OrderStatusNew,
+					Status: models.OrderStatusAwaitingPayment,
 					UserID: 1,
 					Items:  []models.Item{{SKU: 1001, Count: 2}},
 				}
@@ -45,28 +45,62 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 					return order, nil
 				})
 
-				stockRepoMock.CancelReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
-					require.Equal(t, order.Items, items)
-					return nil
+				sagaRepoMock.CreateMock.Set(func(ctx context.Context, tx pgx.Tx, saga models.Saga) (int64, error) {
+					require.Equal(t, models.Saga{
+						OrderID:    1,
+						Type:       models.SagaTypeCancel,
+						FromStatus: models.OrderStatusAwaitingPayment,
+						ToStatus:   models.OrderStatusCancelled,
+						EventType:  "OrderCancelled",
+						Items:      order.Items,
+					}, saga)
+					return 10, nil
 				})
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+					require.Equal(t, order.Status, expected)
 					require.Equal(t, models.OID(1), orderID)
 					require.Equal(t, models.OrderStatusCancelled, status)
+					require.Equal(t, "OrderCancelled", eventType)
 					return nil
 				})
 
-				EventType := "OrderCancelled"
-				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
-					require.Equal(t, EventType, eventType)
-					event, ok := payload.(models.OrderEvent)
-					require.True(t, ok)
-					require.Equal(t, event.OrderID, req.OrderID)
-					require.Equal(t, event.Status, models.OrderStatusCancelled)
-					require.Equal(t, event.Additional, EventType)
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, int64(10), sagaID)
+					require.Equal(t, models.SagaStateCompleted, state)
 					return nil
 				})
 
+				stockRepoMock.CancelReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
+					require.Equal(t, order.Items, items)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedErr:   nil,
+			errorContains: "",
+		},
+		{
+			name: "successful cancel of order without reservation",
+			req: &models.OrderCancelRequest{
+				OrderID: 1,
+			},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest) {
+				orderRepoMock.GetByIDMock.Return(models.Order{
+					Status: models.OrderStatusFailed,
+					UserID: 1,
+					Items:  []models.Item{{SKU: 1001, Count: 2}},
+				}, nil)
+
+				sagaRepoMock.CreateMock.Return(10, nil)
+				orderRepoMock.CompareAndSetStatusMock.Return(nil)
+				sagaRepoMock.SetStateMock.Return(nil)
+
 				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
 					return fn(ctx, txMock)
 				})
@@ -80,7 +114,7 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 				OrderID: 0,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest) {
 
 			},
@@ -93,62 +127,40 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 				OrderID: 2,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest) {
 
 				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
 					require.Equal(t, models.OID(2), orderID)
 					return models.Order{}, errors.New("db error")
 				})
-
-				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
-					return fn(ctx, txMock)
-				})
 			},
 			expectedErr:   internal_errors.ErrInternalServerError,
 			errorContains: "failed to get order",
 		},
 		{
-			name: "error cancel reservation",
+			name: "order in final state",
 			req: &models.OrderCancelRequest{
 				OrderID: 3,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest) {
-				order := models.Order{
-					Status: models.OrderStatusNew,
-					UserID: 1,
-					Items:  []models.Item{{SKU: 1003, Count: 1}},
-				}
-
-				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
-					require.Equal(t, models.OID(3), orderID)
-					return order, nil
-				})
-
-				stockRepoMock.CancelReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
-					require.Equal(t, order.Items, items)
-					return errors.New("reserve cancel error")
-				})
-
-				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
-					return fn(ctx, txMock)
-				})
+				orderRepoMock.GetByIDMock.Return(models.Order{Status: models.OrderStatusPayed}, nil)
 			},
-			expectedErr:   internal_errors.ErrInternalServerError,
-			errorContains: "failed to cancel stock reservation",
+			expectedErr:   internal_errors.ErrInvalidOrderStatus,
+			errorContains: "order is already in a final state",
 		},
 		{
-			name: "error updating order status",
+			name: "error updating order status leaves saga for recovery",
 			req: &models.OrderCancelRequest{
 				OrderID: 4,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest) {
 				order := models.Order{
-					Status: models.OrderStatusNew,
+					Status: models.OrderStatusAwaitingPayment,
 					UserID: 1,
 					Items:  []models.Item{{SKU: 1004, Count: 5}},
 				}
@@ -158,17 +170,17 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 					return order, nil
 				})
 
-				stockRepoMock.CancelReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
-					require.Equal(t, order.Items, items)
-					return nil
-				})
+				sagaRepoMock.CreateMock.Return(10, nil)
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+					require.Equal(t, order.Status, expected)
 					require.Equal(t, models.OID(4), orderID)
 					require.Equal(t, models.OrderStatusCancelled, status)
 					return errors.New("update status error")
 				})
 
+				sagaRepoMock.RecordAttemptMock.Return(nil)
+
 				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
 					return fn(ctx, txMock)
 				})
@@ -177,53 +189,68 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 			errorContains: "failed to set order status",
 		},
 		{
-			name: "error writing event to outbox",
+			name: "order status changed concurrently finishes saga without stock changes",
 			req: &models.OrderCancelRequest{
 				OrderID: 5,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest) {
+				orderRepoMock.GetByIDMock.Return(models.Order{
+					Status: models.OrderStatusAwaitingPayment,
+					Items:  []models.Item{{SKU: 1005, Count: 3}},
+				}, nil)
+
+				sagaRepoMock.CreateMock.Return(10, nil)
+				orderRepoMock.CompareAndSetStatusMock.Return(internal_errors.ErrInvalidOrderStatus)
+
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, models.SagaStateCompensated, state)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedErr:   internal_errors.ErrInvalidOrderStatus,
+			errorContains: "failed to set order status",
+		},
+		{
+			name: "error cancel reservation leaves saga for recovery",
+			req: &models.OrderCancelRequest{
+				OrderID: 6,
+			},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCancelRequest) {
 				order := models.Order{
-					Status: models.OrderStatusNew,
+					Status: models.OrderStatusAwaitingPayment,
 					UserID: 1,
-					Items:  []models.Item{{SKU: 1005, Count: 3}},
+					Items:  []models.Item{{SKU: 1003, Count: 1}},
 				}
 
-				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
-					require.Equal(t, models.OID(5), orderID)
-					return order, nil
-				})
+				orderRepoMock.GetByIDMock.Return(order, nil)
+				sagaRepoMock.CreateMock.Return(10, nil)
+				orderRepoMock.CompareAndSetStatusMock.Return(nil)
+				sagaRepoMock.SetStateMock.Return(nil)
 
 				stockRepoMock.CancelReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 					require.Equal(t, order.Items, items)
-					return nil
+					return errors.New("reserve cancel error")
 				})
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
-					require.Equal(t, models.OID(5), orderID)
-					require.Equal(t, models.OrderStatusCancelled, status)
+				sagaRepoMock.RecordAttemptMock.Set(func(ctx context.Context, sagaID int64, lastErr error) error {
+					require.ErrorContains(t, lastErr, "failed to cancel stock reservation")
 					return nil
 				})
 
-				EventType := "OrderCancelled"
-				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
-					require.Equal(t, EventType, eventType)
-
-					event, ok := payload.(models.OrderEvent)
-					require.True(t, ok)
-					require.Equal(t, event.OrderID, req.OrderID)
-					require.Equal(t, event.Status, models.OrderStatusCancelled)
-					require.Equal(t, event.Additional, EventType)
-					return errors.New("outbox error")
-				})
-
 				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
 					return fn(ctx, txMock)
 				})
 			},
-			expectedErr:   internal_errors.ErrInternalServerError,
-			errorContains: "write event in outbox",
+			expectedErr:   nil,
+			errorContains: "",
 		},
 	}
 
@@ -232,10 +259,10 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 			t.Parallel()
 
 			ctx := context.Background()
-			orderRepoMock, stockRepoMock, outboxRepoMock, _, txManagerMock, service := setup(t)
+			orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, _, txManagerMock, service := setup(t)
 			txMock := mock.NewTxMock(t)
 
-			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, outboxRepoMock, txManagerMock, txMock, tt.req)
+			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, txManagerMock, txMock, tt.req)
 
 			err := service.OrderCancel(ctx, tt.req)
 			if tt.expectedErr != nil {
@@ -249,6 +276,7 @@ func TestLomsService_OrderCancel_Table(t *testing.T) {
 			orderRepoMock.MinimockFinish()
 			stockRepoMock.MinimockFinish()
 			outboxRepoMock.MinimockFinish()
+			sagaRepoMock.MinimockFinish()
 			txManagerMock.MinimockFinish()
 		})
 	}
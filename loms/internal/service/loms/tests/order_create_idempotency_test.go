@@ -0,0 +1,142 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	loms_service "route256/loms/internal/service/loms"
+	"route256/loms/internal/service/loms/mock"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for OrderCreate method of LomsService with idempotency key.
+func TestLomsService_OrderCreate_IdempotencyKey_Table(t *testing.T) {
+	tests := []struct {
+		name string
+		// req is sent twice, replay is sent second if set
+		req    *models.OrderCreateRequest
+		replay *models.OrderCreateRequest
+		// concurrent emulates key stored by concurrent request between lookup and insert
+		concurrent    bool
+		expectedResp  *models.OrderCreateResponse
+		expectedErr   error
+		expectedCalls uint64
+	}{
+		{
+			name: "replay returns original order",
+			req: &models.OrderCreateRequest{
+				User:           1,
+				Items:          []models.Item{{SKU: 1001, Count: 2}, {SKU: 1002, Count: 1}},
+				IdempotencyKey: "key-1",
+			},
+			replay: &models.OrderCreateRequest{
+				User:           1,
+				Items:          []models.Item{{SKU: 1002, Count: 1}, {SKU: 1001, Count: 2}},
+				IdempotencyKey: "key-1",
+			},
+			expectedResp:  &models.OrderCreateResponse{OrderID: 1},
+			expectedCalls: 1,
+		},
+		{
+			name: "key reused with different payload",
+			req: &models.OrderCreateRequest{
+				User:           1,
+				Items:          []models.Item{{SKU: 1001, Count: 2}},
+				IdempotencyKey: "key-2",
+			},
+			replay: &models.OrderCreateRequest{
+				User:           1,
+				Items:          []models.Item{{SKU: 1001, Count: 3}},
+				IdempotencyKey: "key-2",
+			},
+			expectedErr:   internal_errors.ErrIdempotencyKeyReused,
+			expectedCalls: 1,
+		},
+		{
+			name: "concurrent request with the same key",
+			req: &models.OrderCreateRequest{
+				User:           1,
+				Items:          []models.Item{{SKU: 1001, Count: 2}},
+				IdempotencyKey: "key-3",
+			},
+			concurrent:    true,
+			expectedResp:  &models.OrderCreateResponse{OrderID: 7},
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			orderRepoMock, stockRepoMock, _, sagaRepoMock, _, txManagerMock, service := setup(t)
+			txMock := mock.NewTxMock(t)
+
+			var mu sync.Mutex
+			var stored *models.IdempotencyKey
+
+			orderRepoMock.GetIdempotencyKeyMock.Set(func(ctx context.Context, userID models.UID, key string) (models.IdempotencyKey, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if stored == nil || stored.UserID != userID || stored.Key != key {
+					return models.IdempotencyKey{}, internal_errors.ErrNotFound
+				}
+				return *stored, nil
+			})
+
+			orderRepoMock.CreateWithIdempotencyKeyMock.Set(func(ctx context.Context, order models.Order, key models.IdempotencyKey, eventType string) (models.OID, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				require.Equal(t, tt.req.User, key.UserID)
+				require.Equal(t, tt.req.IdempotencyKey, key.Key)
+				require.NotEmpty(t, key.RequestHash)
+				require.WithinDuration(t, time.Now().Add(idempotencyKeyRetention), key.ExpiresAt, time.Minute)
+
+				if tt.concurrent {
+					key.OrderID = 7
+					stored = &key
+					return 0, internal_errors.ErrIdempotencyKeyExists
+				}
+				key.OrderID = 1
+				stored = &key
+				return 1, nil
+			})
+
+			// Order processing after creation is not checked here
+			stockRepoMock.ReserveItemsMock.Optional().Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
+				return nil
+			})
+			orderRepoMock.SetStatusMock.Optional().Return(nil)
+			orderRepoMock.CompareAndSetStatusMock.Optional().Return(nil)
+			sagaRepoMock.CreateMock.Optional().Return(1, nil)
+			sagaRepoMock.SetStateMock.Optional().Return(nil)
+			txManagerMock.WithTxMock.Optional().Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+				return fn(ctx, txMock)
+			})
+
+			resp, err := service.OrderCreate(ctx, tt.req)
+			if tt.replay != nil {
+				require.NoError(t, err)
+				resp, err = service.OrderCreate(ctx, tt.replay)
+			}
+
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr), "error must be %v", tt.expectedErr)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResp, resp)
+			}
+			require.Equal(t, tt.expectedCalls, orderRepoMock.CreateWithIdempotencyKeyAfterCounter())
+		})
+	}
+}
@@ -21,7 +21,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 		name       string
 		req        *models.OrderCreateRequest
 		setupMocks func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-			stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+			stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 			txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest)
 		expectedResp  *models.OrderCreateResponse
 		expectedErr   error
@@ -36,7 +36,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 
 				newOrder := models.Order{
@@ -45,46 +45,40 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 					Items:  req.Items,
 				}
 
-				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order) (models.OID, error) {
+				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order, eventType string) (models.OID, error) {
+					require.Equal(t, "OrderCreated", eventType)
 					require.Equal(t, order, newOrder)
 					return models.OID(1), nil
 				})
 
-				callCount := 0
-
-				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
-					callCount++
-					switch callCount {
-					case 1:
-						event, ok := payload.(models.OrderEvent)
-						require.True(t, ok)
-						require.Equal(t, "OrderCreated", eventType)
-						require.Equal(t, models.OID(1), event.OrderID)
-						require.Equal(t, models.OrderStatusNew, event.Status)
-						require.Equal(t, "OrderCreated", event.Additional)
-						require.False(t, event.Time.IsZero())
-					case 2:
-						event, ok := payload.(models.OrderEvent)
-						require.True(t, ok)
-						require.Equal(t, "OrderAwaitingPayment", eventType)
-						require.Equal(t, models.OID(1), event.OrderID)
-						require.Equal(t, models.OrderStatusAwaitingPayment, event.Status)
-						require.Equal(t, "OrderAwaitingPayment", event.Additional)
-						require.False(t, event.Time.IsZero())
-					default:
-						t.Errorf("unexpected number of CreateEvent calls: %d", callCount)
-					}
-					return nil
-				})
-
 				stockRepoMock.ReserveItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 					require.Equal(t, newOrder.Items, items)
 					return nil
 				})
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
+				sagaRepoMock.CreateMock.Set(func(ctx context.Context, tx pgx.Tx, saga models.Saga) (int64, error) {
+					require.Equal(t, models.Saga{
+						OrderID:    1,
+						Type:       models.SagaTypeReserve,
+						FromStatus: models.OrderStatusNew,
+						ToStatus:   models.OrderStatusAwaitingPayment,
+						EventType:  "OrderAwaitingPayment",
+						Items:      newOrder.Items,
+					}, saga)
+					return 10, nil
+				})
+
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
 					require.Equal(t, models.OID(1), orderID)
+					require.Equal(t, models.OrderStatusNew, expected)
 					require.Equal(t, models.OrderStatusAwaitingPayment, status)
+					require.Equal(t, "OrderAwaitingPayment", eventType)
+					return nil
+				})
+
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, int64(10), sagaID)
+					require.Equal(t, models.SagaStateCompleted, state)
 					return nil
 				})
 
@@ -107,7 +101,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 			},
 			expectedResp:  nil,
@@ -121,7 +115,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				Items: []models.Item{},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 			},
 			expectedResp:  nil,
@@ -137,7 +131,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 			},
 			expectedResp:  nil,
@@ -153,7 +147,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 			},
 			expectedResp:  nil,
@@ -169,7 +163,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 
 				newOrder := models.Order{
@@ -178,7 +172,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 					Items:  req.Items,
 				}
 
-				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order) (models.OID, error) {
+				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order, eventType string) (models.OID, error) {
 					require.Equal(t, order, newOrder)
 					return models.OID(0), errors.New("create order error")
 				})
@@ -197,7 +191,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 
 				newOrder := models.Order{
@@ -206,46 +200,24 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 					Items:  req.Items,
 				}
 
-				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order) (models.OID, error) {
+				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order, eventType string) (models.OID, error) {
+					require.Equal(t, "OrderCreated", eventType)
 					require.Equal(t, order, newOrder)
 					return models.OID(2), nil
 				})
 
-				callCount := 0
-
-				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
-					callCount++
-					switch callCount {
-					case 1:
-						event, ok := payload.(models.OrderEvent)
-						require.True(t, ok)
-						require.Equal(t, "OrderCreated", eventType)
-						require.Equal(t, models.OID(2), event.OrderID)
-						require.Equal(t, models.OrderStatusNew, event.Status)
-						require.Equal(t, "OrderCreated", event.Additional)
-						require.False(t, event.Time.IsZero())
-					case 2:
-						event, ok := payload.(models.OrderEvent)
-						require.True(t, ok)
-						require.Equal(t, "OrderFailed", eventType)
-						require.Equal(t, models.OID(2), event.OrderID)
-						require.Equal(t, models.OrderStatusFailed, event.Status)
-						require.Equal(t, "OrderFailed", event.Additional)
-						require.False(t, event.Time.IsZero())
-					default:
-						t.Errorf("unexpected number of CreateEvent calls: %d", callCount)
-					}
-					return nil
-				})
-
 				stockRepoMock.ReserveItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 					require.Equal(t, newOrder.Items, items)
 					return errors.New("reserve items error")
 				})
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
+				// Saga is rolled back together with failed reservation
+				sagaRepoMock.CreateMock.Return(10, nil)
+
+				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) error {
 					require.Equal(t, models.OID(2), orderID)
 					require.Equal(t, models.OrderStatusFailed, status)
+					require.Equal(t, "OrderFailed", eventType)
 					return nil
 				})
 
@@ -266,7 +238,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 
 				newOrder := models.Order{
@@ -275,51 +247,28 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 					Items:  req.Items,
 				}
 
-				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order) (models.OID, error) {
+				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order, eventType string) (models.OID, error) {
+					require.Equal(t, "OrderCreated", eventType)
 					require.Equal(t, order, newOrder)
 					return models.OID(3), nil
 				})
 
-				callCount := 0
-
-				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
-					callCount++
-					switch callCount {
-					case 1:
-						event, ok := payload.(models.OrderEvent)
-						require.True(t, ok)
-						require.Equal(t, "OrderCreated", eventType)
-						require.Equal(t, models.OID(3), event.OrderID)
-						require.Equal(t, models.OrderStatusNew, event.Status)
-						require.Equal(t, "OrderCreated", event.Additional)
-						require.False(t, event.Time.IsZero())
-					case 2:
-						event, ok := payload.(models.OrderEvent)
-						require.True(t, ok)
-						require.Equal(t, "OrderFailed", eventType)
-						require.Equal(t, models.OID(3), event.OrderID)
-						require.Equal(t, models.OrderStatusFailed, event.Status)
-						require.Equal(t, "OrderFailed", event.Additional)
-						require.False(t, event.Time.IsZero())
-						return errors.New("write outbox failed")
-					default:
-						t.Errorf("unexpected number of CreateEvent calls: %d", callCount)
-					}
-					return nil
-				})
-
 				stockRepoMock.ReserveItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 					require.Equal(t, newOrder.Items, items)
 					return errors.New("reserve items error")
 				})
 
+				// Saga is rolled back together with failed reservation
+				sagaRepoMock.CreateMock.Return(10, nil)
+
 				callCountSetStatus := 0
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
+				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) error {
 					callCountSetStatus++
 					switch callCountSetStatus {
 					case 1:
 						require.Equal(t, models.OrderStatusFailed, status)
+						require.Equal(t, "OrderFailed", eventType)
 						return errors.New("set status failed")
 					default:
 						t.Errorf("unexpected number of SetStatus calls: %d", callCountSetStatus)
@@ -344,7 +293,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 				},
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderCreateRequest) {
 
 				newOrder := models.Order{
@@ -353,57 +302,34 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 					Items:  req.Items,
 				}
 
-				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order) (models.OID, error) {
+				orderRepoMock.CreateMock.Set(func(ctx context.Context, order models.Order, eventType string) (models.OID, error) {
+					require.Equal(t, "OrderCreated", eventType)
 					require.Equal(t, order, newOrder)
 					return models.OID(4), nil
 				})
 
-				callCount := 0
-
-				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
-					callCount++
-					switch callCount {
-					case 1:
-						event, ok := payload.(models.OrderEvent)
-						require.True(t, ok)
-						require.Equal(t, "OrderCreated", eventType)
-						require.Equal(t, models.OID(4), event.OrderID)
-						require.Equal(t, models.OrderStatusNew, event.Status)
-						require.Equal(t, "OrderCreated", event.Additional)
-						require.False(t, event.Time.IsZero())
-					case 2:
-						event, ok := payload.(models.OrderEvent)
-						require.True(t, ok)
-						require.Equal(t, "OrderFailed", eventType)
-						require.Equal(t, models.OID(4), event.OrderID)
-						require.Equal(t, models.OrderStatusFailed, event.Status)
-						require.Equal(t, "OrderFailed", event.Additional)
-						require.False(t, event.Time.IsZero())
-						return errors.New("write outbox failed")
-					default:
-						t.Errorf("unexpected number of CreateEvent calls: %d", callCount)
-					}
+				stockRepoMock.ReserveItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
+					require.Equal(t, newOrder.Items, items)
 					return nil
 				})
 
-				stockRepoMock.ReserveItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
-					require.Equal(t, newOrder.Items, items)
+				sagaRepoMock.CreateMock.Return(10, nil)
+
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+					require.Equal(t, models.OrderStatusAwaitingPayment, status)
+					return errors.New("set status awaiting payment error")
+				})
+
+				// Reservation is released by saga recovery after order is failed
+				sagaRepoMock.RecordAttemptMock.Set(func(ctx context.Context, sagaID int64, lastErr error) error {
+					require.Equal(t, int64(10), sagaID)
+					require.ErrorContains(t, lastErr, "set status awaiting payment error")
 					return nil
 				})
 
-				callCountSetStatus := 0
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
-					callCountSetStatus++
-					switch callCountSetStatus {
-					case 1:
-						require.Equal(t, models.OrderStatusAwaitingPayment, status)
-						return errors.New("set status awaiting payment error")
-					case 2:
-						require.Equal(t, models.OrderStatusFailed, status)
-						return nil
-					default:
-						t.Errorf("unexpected number of SetStatus calls: %d", callCountSetStatus)
-					}
+				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) error {
+					require.Equal(t, models.OrderStatusFailed, status)
+					require.Equal(t, "OrderFailed", eventType)
 					return nil
 				})
 
@@ -422,10 +348,10 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 			t.Parallel()
 
 			ctx := context.Background()
-			orderRepoMock, stockRepoMock, outboxRepoMock, _, txManagerMock, service := setup(t)
+			orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, _, txManagerMock, service := setup(t)
 			txMock := mock.NewTxMock(t)
 
-			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, outboxRepoMock, txManagerMock, txMock, tt.req)
+			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, txManagerMock, txMock, tt.req)
 
 			resp, err := service.OrderCreate(ctx, tt.req)
 			if tt.expectedErr != nil {
@@ -441,6 +367,7 @@ func TestLomsService_OrderCreate_Table(t *testing.T) {
 			orderRepoMock.MinimockFinish()
 			stockRepoMock.MinimockFinish()
 			outboxRepoMock.MinimockFinish()
+			sagaRepoMock.MinimockFinish()
 			txManagerMock.MinimockFinish()
 		})
 	}
@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	loms_service "route256/loms/internal/service/loms"
+	"route256/loms/internal/service/loms/mock"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for CancelExpiredOrders method of LomsService.
+func TestLomsService_CancelExpiredOrders_Table(t *testing.T) {
+	const paymentTimeout = 15 * time.Minute
+
+	tests := []struct {
+		name           string
+		paymentTimeout time.Duration
+		setupMocks     func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+			stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+			txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock)
+		expectedCancelled int
+		expectedErr       error
+		errorContains     string
+	}{
+		{
+			name:           "expired orders cancelled",
+			paymentTimeout: paymentTimeout,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				orderRepoMock.GetExpiredOrdersMock.Set(func(ctx context.Context, timeout time.Duration, limit uint32) ([]models.Order, error) {
+					require.Equal(t, paymentTimeout, timeout)
+					require.Equal(t, uint32(100), limit)
+					return []models.Order{{OrderID: 1}, {OrderID: 2}}, nil
+				})
+
+				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
+					return models.Order{
+						OrderID: orderID,
+						Status:  models.OrderStatusAwaitingPayment,
+						UserID:  1,
+						Items:   []models.Item{{SKU: 1001, Count: 2}},
+					}, nil
+				})
+
+				sagaRepoMock.CreateMock.Set(func(ctx context.Context, tx pgx.Tx, saga models.Saga) (int64, error) {
+					require.Equal(t, models.SagaTypeCancel, saga.Type)
+					require.Equal(t, "OrderExpired", saga.EventType)
+					return int64(saga.OrderID), nil
+				})
+
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, models.SagaStateCompleted, state)
+					return nil
+				})
+
+				stockRepoMock.CancelReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
+					require.Equal(t, []models.Item{{SKU: 1001, Count: 2}}, items)
+					return nil
+				})
+
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+					require.Equal(t, "OrderExpired", eventType)
+					require.Equal(t, models.OrderStatusAwaitingPayment, expected)
+					require.Equal(t, models.OrderStatusCancelled, status)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedCancelled: 2,
+		},
+		{
+			name:           "order changed by another replica is skipped",
+			paymentTimeout: paymentTimeout,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				orderRepoMock.GetExpiredOrdersMock.Return([]models.Order{{OrderID: 1}}, nil)
+
+				orderRepoMock.GetByIDMock.Return(models.Order{
+					OrderID: 1,
+					Status:  models.OrderStatusAwaitingPayment,
+					Items:   []models.Item{{SKU: 1001, Count: 2}},
+				}, nil)
+
+				sagaRepoMock.CreateMock.Return(10, nil)
+
+				orderRepoMock.CompareAndSetStatusMock.Return(fmt.Errorf("order is not in awaiting payment status: %w", internal_errors.ErrInvalidOrderStatus))
+
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, models.SagaStateCompensated, state)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedCancelled: 0,
+		},
+		{
+			name:           "error cancelling one order",
+			paymentTimeout: paymentTimeout,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				orderRepoMock.GetExpiredOrdersMock.Return([]models.Order{{OrderID: 2}, {OrderID: 1}}, nil)
+
+				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
+					if orderID == 2 {
+						return models.Order{}, errors.New("db error")
+					}
+					return models.Order{
+						OrderID: orderID,
+						Status:  models.OrderStatusAwaitingPayment,
+						Items:   []models.Item{{SKU: 1001, Count: 2}},
+					}, nil
+				})
+
+				sagaRepoMock.CreateMock.Return(10, nil)
+				orderRepoMock.CompareAndSetStatusMock.Return(nil)
+				sagaRepoMock.SetStateMock.Return(nil)
+				stockRepoMock.CancelReservedItemsMock.Return(nil)
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedCancelled: 1,
+			expectedErr:       internal_errors.ErrInternalServerError,
+			errorContains:     "failed to cancel expired order 2",
+		},
+		{
+			name:           "error getting orders",
+			paymentTimeout: paymentTimeout,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				orderRepoMock.GetExpiredOrdersMock.Return(nil, errors.New("db error"))
+			},
+			expectedErr:   internal_errors.ErrInternalServerError,
+			errorContains: "failed to get expired orders",
+		},
+		{
+			name:           "invalid payment timeout",
+			paymentTimeout: 0,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "payment timeout must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, _, txManagerMock, service := setup(t)
+			txMock := mock.NewTxMock(t)
+
+			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, txManagerMock, txMock)
+
+			cancelled, err := service.CancelExpiredOrders(ctx, tt.paymentTimeout)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedCancelled, cancelled)
+
+			orderRepoMock.MinimockFinish()
+			stockRepoMock.MinimockFinish()
+			outboxRepoMock.MinimockFinish()
+			sagaRepoMock.MinimockFinish()
+			txManagerMock.MinimockFinish()
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"route256/loms/internal/models"
 	internal_errors "route256/loms/internal/pkg/errors"
@@ -15,6 +16,13 @@ import (
 
 // Test function for OrderInfo method of LomsService.
 func TestLomsService_OrderInfo_Table(t *testing.T) {
+	createdAt := time.Date(2024, 11, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Second)
+	history := []models.OrderStatusChange{
+		{Status: models.OrderStatusNew, Time: createdAt},
+		{Status: models.OrderStatusAwaitingPayment, Time: updatedAt},
+	}
+
 	tests := []struct {
 		name          string
 		req           *models.OrderInfoRequest
@@ -30,9 +38,13 @@ func TestLomsService_OrderInfo_Table(t *testing.T) {
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, stockRepoMock *mock.IStockRepositoryMock, txManagerMock *mock.ITxManagerMock, req *models.OrderInfoRequest) {
 				order := models.Order{
-					Status: models.OrderStatusNew,
-					UserID: 1,
-					Items:  []models.Item{{SKU: 1001, Count: 2}},
+					OrderID:   1,
+					Status:    models.OrderStatusAwaitingPayment,
+					UserID:    1,
+					Items:     []models.Item{{SKU: 1001, Count: 2}},
+					CreatedAt: createdAt,
+					UpdatedAt: updatedAt,
+					History:   history,
 				}
 				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
 					require.Equal(t, models.OID(1), orderID)
@@ -40,9 +52,12 @@ func TestLomsService_OrderInfo_Table(t *testing.T) {
 				})
 			},
 			expectedResp: &models.OrderInfoResponse{
-				Status: models.OrderStatusNew,
-				User:   1,
-				Items:  []models.Item{{SKU: 1001, Count: 2}},
+				Status:    models.OrderStatusAwaitingPayment,
+				User:      1,
+				Items:     []models.Item{{SKU: 1001, Count: 2}},
+				CreatedAt: createdAt,
+				UpdatedAt: updatedAt,
+				History:   history,
 			},
 			expectedErr:   nil,
 			errorContains: "",
@@ -81,7 +96,7 @@ func TestLomsService_OrderInfo_Table(t *testing.T) {
 			t.Parallel()
 
 			ctx := context.Background()
-			orderRepoMock, stockRepoMock, _, _, txManagerMock, service := setup(t)
+			orderRepoMock, stockRepoMock, _, _, _, txManagerMock, service := setup(t)
 
 			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, txManagerMock, tt.req)
 
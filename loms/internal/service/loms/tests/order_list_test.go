@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/service/loms/mock"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for OrderList method of LomsService.
+func TestLomsService_OrderList_Table(t *testing.T) {
+	createdFrom := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		req           *models.OrderListRequest
+		setupMocks    func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest)
+		expectedResp  *models.OrderListResponse
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "last page without next cursor",
+			req: &models.OrderListRequest{
+				UserID:   1,
+				PageSize: 2,
+			},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {
+				orderRepoMock.GetOrdersMock.Set(func(ctx context.Context, filter models.OrderFilter, limit uint32) ([]models.Order, error) {
+					require.Equal(t, models.OrderFilter{UserID: 1}, filter)
+					require.Equal(t, uint32(3), limit)
+					return []models.Order{{OrderID: 2001, UserID: 1}, {OrderID: 1000, UserID: 1}}, nil
+				})
+			},
+			expectedResp: &models.OrderListResponse{
+				Orders: []models.Order{{OrderID: 2001, UserID: 1}, {OrderID: 1000, UserID: 1}},
+			},
+		},
+		{
+			name: "full page with next cursor",
+			req: &models.OrderListRequest{
+				Statuses:    []models.OrderStatus{models.OrderStatusPayed},
+				CreatedFrom: createdFrom,
+				CreatedTo:   createdTo,
+				PageSize:    1,
+			},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {
+				orderRepoMock.GetOrdersMock.Set(func(ctx context.Context, filter models.OrderFilter, limit uint32) ([]models.Order, error) {
+					require.Equal(t, models.OrderFilter{
+						Statuses:    []models.OrderStatus{models.OrderStatusPayed},
+						CreatedFrom: createdFrom,
+						CreatedTo:   createdTo,
+					}, filter)
+					require.Equal(t, uint32(2), limit)
+					return []models.Order{{OrderID: 1000, UserID: 1}, {OrderID: 1, UserID: 2}}, nil
+				})
+			},
+			expectedResp: &models.OrderListResponse{
+				Orders:     []models.Order{{OrderID: 1000, UserID: 1}},
+				NextCursor: "MTAwMA",
+			},
+		},
+		{
+			name: "cursor and default page size",
+			req: &models.OrderListRequest{
+				Cursor: "MTAwMA",
+			},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {
+				orderRepoMock.GetOrdersMock.Set(func(ctx context.Context, filter models.OrderFilter, limit uint32) ([]models.Order, error) {
+					require.Equal(t, models.OrderFilter{AfterID: 1000}, filter)
+					require.Equal(t, uint32(51), limit)
+					return nil, nil
+				})
+			},
+			expectedResp: &models.OrderListResponse{},
+		},
+		{
+			name: "partial page of available shards",
+			req: &models.OrderListRequest{
+				UserID:       1,
+				PageSize:     1,
+				AllowPartial: true,
+			},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {
+				orderRepoMock.GetOrdersPartialMock.Set(func(ctx context.Context, filter models.OrderFilter, limit uint32) (models.OrdersPage, error) {
+					require.Equal(t, models.OrderFilter{UserID: 1}, filter)
+					require.Equal(t, uint32(2), limit)
+					return models.OrdersPage{
+						Orders:            []models.Order{{OrderID: 2001, UserID: 1}, {OrderID: 1001, UserID: 1}},
+						UnavailableShards: []int{0},
+					}, nil
+				})
+			},
+			expectedResp: &models.OrderListResponse{
+				Orders:            []models.Order{{OrderID: 2001, UserID: 1}},
+				NextCursor:        "MjAwMQ",
+				UnavailableShards: []int{0},
+			},
+		},
+		{
+			name: "unavailable shard without partial",
+			req:  &models.OrderListRequest{},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {
+				orderRepoMock.GetOrdersMock.Return(nil, internal_errors.ErrShardUnavailable)
+			},
+			expectedErr:   internal_errors.ErrShardUnavailable,
+			errorContains: "failed to get orders",
+		},
+		{
+			name: "invalid cursor",
+			req: &models.OrderListRequest{
+				Cursor: "not a cursor",
+			},
+			setupMocks:    func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "invalid cursor",
+		},
+		{
+			name: "unknown status",
+			req: &models.OrderListRequest{
+				Statuses: []models.OrderStatus{"unknown"},
+			},
+			setupMocks:    func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "unknown order status",
+		},
+		{
+			name: "page size too large",
+			req: &models.OrderListRequest{
+				PageSize: 1001,
+			},
+			setupMocks:    func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "page size must not exceed",
+		},
+		{
+			name: "invalid created range",
+			req: &models.OrderListRequest{
+				CreatedFrom: createdTo,
+				CreatedTo:   createdFrom,
+			},
+			setupMocks:    func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "createdFrom must be before createdTo",
+		},
+		{
+			name: "error getting orders",
+			req:  &models.OrderListRequest{},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, req *models.OrderListRequest) {
+				orderRepoMock.GetOrdersMock.Set(func(ctx context.Context, filter models.OrderFilter, limit uint32) ([]models.Order, error) {
+					return nil, errors.New("db error")
+				})
+			},
+			expectedErr:   internal_errors.ErrInternalServerError,
+			errorContains: "failed to get orders",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			orderRepoMock, _, _, _, _, _, service := setup(t)
+
+			tt.setupMocks(ctx, orderRepoMock, tt.req)
+
+			resp, err := service.OrderList(ctx, tt.req)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResp, resp)
+			}
+
+			orderRepoMock.MinimockFinish()
+		})
+	}
+}
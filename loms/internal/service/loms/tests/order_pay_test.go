@@ -21,7 +21,7 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 		name       string
 		req        *models.OrderPayRequest
 		setupMocks func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-			stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+			stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 			txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest)
 		expectedErr   error
 		errorContains string
@@ -31,7 +31,9 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 			req: &models.OrderPayRequest{
 				OrderID: 1,
 			},
-			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock, stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
 				order := models.Order{
 					Status: models.OrderStatusAwaitingPayment,
 					UserID: 1,
@@ -43,25 +45,34 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 					return order, nil
 				})
 
+				sagaRepoMock.CreateMock.Set(func(ctx context.Context, tx pgx.Tx, saga models.Saga) (int64, error) {
+					require.Equal(t, models.Saga{
+						OrderID:    1,
+						Type:       models.SagaTypePay,
+						FromStatus: models.OrderStatusAwaitingPayment,
+						ToStatus:   models.OrderStatusPayed,
+						EventType:  "OrderPayed",
+						Items:      order.Items,
+					}, saga)
+					return 10, nil
+				})
+
 				stockRepoMock.RemoveReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 					require.Equal(t, order.Items, items)
 					return nil
 				})
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+					require.Equal(t, "OrderPayed", eventType)
+					require.Equal(t, models.OrderStatusAwaitingPayment, expected)
 					require.Equal(t, models.OID(1), orderID)
 					require.Equal(t, models.OrderStatusPayed, status)
 					return nil
 				})
 
-				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
-					event, ok := payload.(models.OrderEvent)
-					require.True(t, ok)
-					require.Equal(t, "OrderPayed", eventType)
-					require.Equal(t, models.OID(req.OrderID), event.OrderID)
-					require.Equal(t, models.OrderStatusPayed, event.Status)
-					require.Equal(t, "OrderPayed", event.Additional)
-					require.False(t, event.Time.IsZero())
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, int64(10), sagaID)
+					require.Equal(t, models.SagaStateCompleted, state)
 					return nil
 				})
 
@@ -78,7 +89,7 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 				OrderID: 0,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
 			},
 			expectedErr:   internal_errors.ErrBadRequest,
@@ -90,17 +101,13 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 				OrderID: 2,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
 
 				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
 					require.Equal(t, models.OID(2), orderID)
 					return models.Order{}, errors.New("db error")
 				})
-
-				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
-					return fn(ctx, txMock)
-				})
 			},
 			expectedErr:   internal_errors.ErrInternalServerError,
 			errorContains: "failed to get order",
@@ -111,7 +118,7 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 				OrderID: 3,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
 				order := models.Order{
 					Status: models.OrderStatusNew,
@@ -123,13 +130,31 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 					require.Equal(t, models.OID(3), orderID)
 					return order, nil
 				})
+			},
+			expectedErr:   internal_errors.ErrInvalidOrderStatus,
+			errorContains: "order is not in awaiting payment status",
+		},
+		{
+			name: "another operation on order is in progress",
+			req: &models.OrderPayRequest{
+				OrderID: 4,
+			},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
+				orderRepoMock.GetByIDMock.Return(models.Order{
+					Status: models.OrderStatusAwaitingPayment,
+					Items:  []models.Item{{SKU: 1003, Count: 3}},
+				}, nil)
+
+				sagaRepoMock.CreateMock.Return(0, internal_errors.ErrSagaInProgress)
 
 				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
 					return fn(ctx, txMock)
 				})
 			},
-			expectedErr:   internal_errors.ErrInvalidOrderStatus,
-			errorContains: "order is not in awaiting payment status",
+			expectedErr:   internal_errors.ErrSagaInProgress,
+			errorContains: "failed to start saga",
 		},
 		{
 			name: "error removing reserved stock",
@@ -137,7 +162,7 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 				OrderID: 4,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
 				order := models.Order{
 					Status: models.OrderStatusAwaitingPayment,
@@ -150,6 +175,8 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 					return order, nil
 				})
 
+				sagaRepoMock.CreateMock.Return(10, nil)
+
 				stockRepoMock.RemoveReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 					require.Equal(t, order.Items, items)
 					return errors.New("reserve remove error")
@@ -163,12 +190,12 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 			errorContains: "failed to remove reserved stock",
 		},
 		{
-			name: "error setting order status to payed",
+			name: "error setting order status to payed leaves saga for recovery",
 			req: &models.OrderPayRequest{
 				OrderID: 5,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
 				order := models.Order{
 					Status: models.OrderStatusAwaitingPayment,
@@ -181,17 +208,26 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 					return order, nil
 				})
 
+				sagaRepoMock.CreateMock.Return(10, nil)
+
 				stockRepoMock.RemoveReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 					require.Equal(t, order.Items, items)
 					return nil
 				})
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+					require.Equal(t, models.OrderStatusAwaitingPayment, expected)
 					require.Equal(t, models.OID(5), orderID)
 					require.Equal(t, models.OrderStatusPayed, status)
 					return errors.New("set status payed error")
 				})
 
+				sagaRepoMock.RecordAttemptMock.Set(func(ctx context.Context, sagaID int64, lastErr error) error {
+					require.Equal(t, int64(10), sagaID)
+					require.ErrorContains(t, lastErr, "set status payed error")
+					return nil
+				})
+
 				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
 					return fn(ctx, txMock)
 				})
@@ -200,12 +236,12 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 			errorContains: "failed to set order status",
 		},
 		{
-			name: "error writing event to outbox",
+			name: "order status changed concurrently compensates removed stock",
 			req: &models.OrderPayRequest{
 				OrderID: 6,
 			},
 			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
-				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
 				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
 				order := models.Order{
 					Status: models.OrderStatusAwaitingPayment,
@@ -213,39 +249,64 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 					Items:  []models.Item{{SKU: 1005, Count: 5}},
 				}
 
-				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
-					require.Equal(t, models.OID(6), orderID)
-					return order, nil
+				orderRepoMock.GetByIDMock.Return(order, nil)
+				sagaRepoMock.CreateMock.Return(10, nil)
+				stockRepoMock.RemoveReservedItemsMock.Return(nil)
+				orderRepoMock.CompareAndSetStatusMock.Return(internal_errors.ErrInvalidOrderStatus)
+
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, int64(10), sagaID)
+					require.Equal(t, models.SagaStateCompensated, state)
+					return nil
 				})
 
-				stockRepoMock.RemoveReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
+				stockRepoMock.RestoreRemovedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
 					require.Equal(t, order.Items, items)
 					return nil
 				})
 
-				orderRepoMock.SetStatusMock.Set(func(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
-					require.Equal(t, models.OID(6), orderID)
-					require.Equal(t, models.OrderStatusPayed, status)
-					return nil
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
 				})
+			},
+			expectedErr:   internal_errors.ErrInvalidOrderStatus,
+			errorContains: "failed to set order status",
+		},
+		{
+			name: "error writing order status with event on shard leaves saga for recovery",
+			req: &models.OrderPayRequest{
+				OrderID: 7,
+			},
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock, req *models.OrderPayRequest) {
+				order := models.Order{
+					Status: models.OrderStatusAwaitingPayment,
+					UserID: 1,
+					Items:  []models.Item{{SKU: 1005, Count: 5}},
+				}
 
-				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
-					event, ok := payload.(models.OrderEvent)
-					require.True(t, ok)
+				orderRepoMock.GetByIDMock.Return(order, nil)
+				sagaRepoMock.CreateMock.Return(10, nil)
+				stockRepoMock.RemoveReservedItemsMock.Return(nil)
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
 					require.Equal(t, "OrderPayed", eventType)
-					require.Equal(t, models.OID(req.OrderID), event.OrderID)
-					require.Equal(t, models.OrderStatusPayed, event.Status)
-					require.Equal(t, "OrderPayed", event.Additional)
-					require.False(t, event.Time.IsZero())
 					return errors.New("outbox write error")
 				})
 
+				// Status may be changed or not, so saga is finished by recovery
+				sagaRepoMock.RecordAttemptMock.Set(func(ctx context.Context, sagaID int64, lastErr error) error {
+					require.Equal(t, int64(10), sagaID)
+					require.ErrorContains(t, lastErr, "outbox write error")
+					return nil
+				})
+
 				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
 					return fn(ctx, txMock)
 				})
 			},
-			expectedErr:   internal_errors.ErrInternalServerError,
-			errorContains: "write event in outbox",
+			expectedErr:   errors.New("failed to set order status payed"),
+			errorContains: "outbox write error",
 		},
 	}
 
@@ -255,10 +316,10 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 
 			ctx := context.Background()
 
-			orderRepoMock, stockRepoMock, outboxRepoMock, _, txManagerMock, service := setup(t)
+			orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, _, txManagerMock, service := setup(t)
 			txMock := mock.NewTxMock(t)
 
-			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, outboxRepoMock, txManagerMock, txMock, tt.req)
+			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, txManagerMock, txMock, tt.req)
 
 			err := service.OrderPay(ctx, tt.req)
 			if tt.expectedErr != nil {
@@ -272,6 +333,7 @@ func TestLomsService_OrderPay_Table(t *testing.T) {
 			orderRepoMock.MinimockFinish()
 			stockRepoMock.MinimockFinish()
 			outboxRepoMock.MinimockFinish()
+			sagaRepoMock.MinimockFinish()
 			txManagerMock.MinimockFinish()
 		})
 	}
@@ -0,0 +1,248 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/service/loms/mock"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for OutboxEventList method of LomsService.
+func TestLomsService_OutboxEventList_Table(t *testing.T) {
+	createdFrom := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 11, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		req           *models.OutboxEventListRequest
+		setupMocks    func(outboxRepoMock *mock.IOutboxRepositoryMock)
+		expectedResp  *models.OutboxEventListResponse
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "full page with next cursor",
+			req: &models.OutboxEventListRequest{
+				States:      []models.OutboxEventState{models.OutboxEventStateDead},
+				CreatedFrom: createdFrom,
+				CreatedTo:   createdTo,
+				PageSize:    1,
+			},
+			setupMocks: func(outboxRepoMock *mock.IOutboxRepositoryMock) {
+				outboxRepoMock.ListMock.Set(func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) ([]models.OutboxEvent, error) {
+					require.Equal(t, models.OutboxEventFilter{
+						States:      []models.OutboxEventState{models.OutboxEventStateDead},
+						CreatedFrom: createdFrom,
+						CreatedTo:   createdTo,
+					}, filter)
+					require.Equal(t, uint32(2), limit)
+					return []models.OutboxEvent{{ID: 100}, {ID: 101}}, nil
+				})
+			},
+			expectedResp: &models.OutboxEventListResponse{
+				Events:     []models.OutboxEvent{{ID: 100}},
+				NextCursor: "MTAw",
+			},
+		},
+		{
+			name: "cursor and default page size",
+			req: &models.OutboxEventListRequest{
+				IDs:    []int64{100, 101},
+				Cursor: "MTAw",
+			},
+			setupMocks: func(outboxRepoMock *mock.IOutboxRepositoryMock) {
+				outboxRepoMock.ListMock.Set(func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, limit uint32) ([]models.OutboxEvent, error) {
+					require.Equal(t, models.OutboxEventFilter{AfterID: 100, IDs: []int64{100, 101}}, filter)
+					require.Equal(t, uint32(51), limit)
+					return []models.OutboxEvent{{ID: 101, Shard: 1}}, nil
+				})
+			},
+			expectedResp: &models.OutboxEventListResponse{
+				Events: []models.OutboxEvent{{ID: 101, Shard: 1}},
+			},
+		},
+		{
+			name: "error getting events",
+			req:  &models.OutboxEventListRequest{},
+			setupMocks: func(outboxRepoMock *mock.IOutboxRepositoryMock) {
+				outboxRepoMock.ListMock.Return(nil, errors.New("db error"))
+			},
+			expectedErr:   errors.New("failed to get outbox events"),
+			errorContains: "failed to get outbox events",
+		},
+		{
+			name:          "unknown state",
+			req:           &models.OutboxEventListRequest{States: []models.OutboxEventState{"lost"}},
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "unknown outbox event state",
+		},
+		{
+			name:          "page size too large",
+			req:           &models.OutboxEventListRequest{PageSize: 1001},
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "page size must not exceed",
+		},
+		{
+			name:          "invalid cursor",
+			req:           &models.OutboxEventListRequest{Cursor: "!!!"},
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "invalid cursor",
+		},
+		{
+			name:          "unknown shard",
+			req:           &models.OutboxEventListRequest{Shard: 2},
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "unknown outbox shard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			_, _, outboxRepoMock, _, _, _, service := setup(t)
+
+			// Main database and two shards
+			outboxRepoMock.ShardsMock.Optional().Return([]models.OutboxShard{models.OutboxShardMain, 0, 1})
+			tt.setupMocks(outboxRepoMock)
+
+			resp, err := service.OutboxEventList(ctx, tt.req)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResp, resp)
+			}
+
+			outboxRepoMock.MinimockFinish()
+		})
+	}
+}
+
+// Test function for OutboxEventRequeue and OutboxEventReplay methods of LomsService.
+func TestLomsService_OutboxEventReset_Table(t *testing.T) {
+	createdFrom := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 11, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		replay        bool
+		req           *models.OutboxEventResetRequest
+		setupMocks    func(outboxRepoMock *mock.IOutboxRepositoryMock)
+		expectedCount int64
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "dead events requeued by IDs",
+			req:  &models.OutboxEventResetRequest{IDs: []int64{1, 2}, Shard: 0},
+			setupMocks: func(outboxRepoMock *mock.IOutboxRepositoryMock) {
+				outboxRepoMock.ResetMock.Set(func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) (int64, error) {
+					require.Equal(t, models.OutboxShard(0), shard)
+					require.Equal(t, models.OutboxEventFilter{IDs: []int64{1, 2}}, filter)
+					require.Equal(t, []models.OutboxEventState{models.OutboxEventStateDead}, fromStates)
+					return 2, nil
+				})
+			},
+			expectedCount: 2,
+		},
+		{
+			name:   "processed events replayed by time range",
+			replay: true,
+			req:    &models.OutboxEventResetRequest{CreatedFrom: createdFrom, CreatedTo: createdTo, Shard: models.OutboxShardMain},
+			setupMocks: func(outboxRepoMock *mock.IOutboxRepositoryMock) {
+				outboxRepoMock.ResetMock.Set(func(ctx context.Context, shard models.OutboxShard, filter models.OutboxEventFilter, fromStates []models.OutboxEventState) (int64, error) {
+					require.Equal(t, models.OutboxShardMain, shard)
+					require.Equal(t, models.OutboxEventFilter{CreatedFrom: createdFrom, CreatedTo: createdTo}, filter)
+					require.Equal(t, []models.OutboxEventState{models.OutboxEventStateProcessed}, fromStates)
+					return 10, nil
+				})
+			},
+			expectedCount: 10,
+		},
+		{
+			name: "error resetting events",
+			req:  &models.OutboxEventResetRequest{IDs: []int64{1}},
+			setupMocks: func(outboxRepoMock *mock.IOutboxRepositoryMock) {
+				outboxRepoMock.ResetMock.Return(0, errors.New("db error"))
+			},
+			expectedErr:   errors.New("failed to reset dead outbox events"),
+			errorContains: "failed to reset dead outbox events",
+		},
+		{
+			name:          "events not selected",
+			replay:        true,
+			req:           &models.OutboxEventResetRequest{},
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "event IDs or creation time range must be set",
+		},
+		{
+			name:          "invalid time range",
+			req:           &models.OutboxEventResetRequest{CreatedFrom: createdTo, CreatedTo: createdFrom},
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "createdFrom must be before createdTo",
+		},
+		{
+			name:          "invalid event ID",
+			req:           &models.OutboxEventResetRequest{IDs: []int64{0}},
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "event ID must be greater than zero",
+		},
+		{
+			name:          "unknown shard",
+			replay:        true,
+			req:           &models.OutboxEventResetRequest{IDs: []int64{1}, Shard: -2},
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "unknown outbox shard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			_, _, outboxRepoMock, _, _, _, service := setup(t)
+
+			// Main database and two shards
+			outboxRepoMock.ShardsMock.Optional().Return([]models.OutboxShard{models.OutboxShardMain, 0, 1})
+			tt.setupMocks(outboxRepoMock)
+
+			var resp *models.OutboxEventResetResponse
+			var err error
+			if tt.replay {
+				resp, err = service.OutboxEventReplay(ctx, tt.req)
+			} else {
+				resp, err = service.OutboxEventRequeue(ctx, tt.req)
+			}
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedCount, resp.Count)
+			}
+
+			outboxRepoMock.MinimockFinish()
+		})
+	}
+}
@@ -0,0 +1,449 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/service/loms/mock"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// shardKey is context key of outbox shard used by mocks.
+type shardKey struct{}
+
+// Test function for ProcessOutbox method of LomsService.
+func TestLomsService_ProcessOutbox_Table(t *testing.T) {
+	const batchSize uint32 = 2
+
+	opts := models.OutboxRelayOptions{
+		BatchSize:      batchSize,
+		Concurrency:    1,
+		MaxAttempts:    3,
+		RetryBaseDelay: time.Second,
+		RetryMaxDelay:  time.Minute,
+	}
+	withOpts := func(change func(o *models.OutboxRelayOptions)) models.OutboxRelayOptions {
+		o := opts
+		change(&o)
+		return o
+	}
+
+	batch := []models.OutboxEvent{
+		{ID: 1, EventType: "OrderCreated", OrderID: 10, Sequence: 1},
+		{ID: 2, EventType: "OrderCreated", OrderID: 11, Sequence: 1},
+	}
+
+	tests := []struct {
+		name          string
+		opts          models.OutboxRelayOptions
+		shards        []models.OutboxShard
+		setupMocks    func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock)
+		expectedSent  int
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "batches sent until outbox is empty",
+			opts: opts,
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				var calls atomic.Int32
+				outboxRepoMock.FetchBatchMock.Set(func(ctx context.Context, tx pgx.Tx, limit uint32) ([]models.OutboxEvent, error) {
+					require.Equal(t, batchSize, limit)
+					switch calls.Add(1) {
+					case 1:
+						return batch, nil
+					case 2:
+						return []models.OutboxEvent{{ID: 3, EventType: "OrderPayed", OrderID: 10, Sequence: 2}}, nil
+					default:
+						return nil, nil
+					}
+				})
+
+				producerMock.SendOutboxEventsMock.Set(func(ctx context.Context, events []models.OutboxEvent) (map[int64]error, error) {
+					delivery := make(map[int64]error, len(events))
+					for _, event := range events {
+						delivery[event.ID] = nil
+					}
+					return delivery, nil
+				})
+
+				var marked atomic.Int32
+				outboxRepoMock.MarkAsSentMock.Set(func(ctx context.Context, tx pgx.Tx, eventIDs []int64) error {
+					switch marked.Add(1) {
+					case 1:
+						require.Equal(t, []int64{1, 2}, eventIDs)
+					case 2:
+						require.Equal(t, []int64{3}, eventIDs)
+					}
+					return nil
+				})
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedSent: 3,
+		},
+		{
+			name: "concurrent workers share outbox",
+			opts: withOpts(func(o *models.OutboxRelayOptions) { o.Concurrency = 3 }),
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				// Rows locked by one worker are skipped by others
+				var calls atomic.Int32
+				outboxRepoMock.FetchBatchMock.Set(func(ctx context.Context, tx pgx.Tx, limit uint32) ([]models.OutboxEvent, error) {
+					if calls.Add(1) == 1 {
+						return batch, nil
+					}
+					return nil, nil
+				})
+
+				producerMock.SendOutboxEventsMock.Return(map[int64]error{1: nil, 2: nil}, nil)
+				outboxRepoMock.MarkAsSentMock.Return(nil)
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedSent: 2,
+		},
+		{
+			name:   "shards drained in parallel",
+			opts:   opts,
+			shards: []models.OutboxShard{models.OutboxShardMain, 0, 1},
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				// Each shard has one event, event IDs of different shards are the same
+				var drained sync.Map
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					if _, loaded := drained.LoadOrStore(shard, true); loaded {
+						return nil
+					}
+					return fn(context.WithValue(ctx, shardKey{}, shard), txMock)
+				})
+
+				outboxRepoMock.FetchBatchMock.Set(func(ctx context.Context, tx pgx.Tx, limit uint32) ([]models.OutboxEvent, error) {
+					shard := ctx.Value(shardKey{}).(models.OutboxShard)
+					return []models.OutboxEvent{{ID: 1, Shard: shard}}, nil
+				})
+
+				producerMock.SendOutboxEventsMock.Set(func(ctx context.Context, events []models.OutboxEvent) (map[int64]error, error) {
+					require.Len(t, events, 1)
+					return map[int64]error{1: nil}, nil
+				})
+
+				outboxRepoMock.MarkAsSentMock.Set(func(ctx context.Context, tx pgx.Tx, eventIDs []int64) error {
+					require.Equal(t, []int64{1}, eventIDs)
+					return nil
+				})
+			},
+			expectedSent: 3,
+		},
+		{
+			name:   "failed shard does not stop other shards",
+			opts:   opts,
+			shards: []models.OutboxShard{models.OutboxShardMain, 0},
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				var calls atomic.Int32
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					if shard == 0 {
+						return errors.New("shard unavailable")
+					}
+					if calls.Add(1) > 1 {
+						return nil
+					}
+					return fn(ctx, txMock)
+				})
+
+				outboxRepoMock.FetchBatchMock.Return(batch, nil)
+				producerMock.SendOutboxEventsMock.Return(map[int64]error{1: nil, 2: nil}, nil)
+				outboxRepoMock.MarkAsSentMock.Return(nil)
+			},
+			expectedSent:  2,
+			expectedErr:   errors.New("shard unavailable"),
+			errorContains: "shard unavailable",
+		},
+		{
+			name: "failed event is retried with backoff",
+			opts: opts,
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				outboxRepoMock.FetchBatchMock.Return([]models.OutboxEvent{
+					{ID: 1, EventType: "OrderCreated", OrderID: 10, Sequence: 1, Attempts: 1},
+					{ID: 2, EventType: "OrderCreated", OrderID: 11, Sequence: 1},
+				}, nil)
+
+				producerMock.SendOutboxEventsMock.Return(map[int64]error{1: errors.New("broker down"), 2: nil}, nil)
+
+				// Second attempt waits twice the base delay
+				outboxRepoMock.MarkAsFailedMock.Set(func(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) error {
+					require.Equal(t, int64(1), eventID)
+					require.EqualError(t, sendErr, "broker down")
+					require.Equal(t, models.OutboxEventStatePending, state)
+					require.WithinDuration(t, time.Now().Add(2*time.Second), nextAttemptAt, time.Second)
+					return nil
+				})
+
+				outboxRepoMock.MarkAsSentMock.Set(func(ctx context.Context, tx pgx.Tx, eventIDs []int64) error {
+					require.Equal(t, []int64{2}, eventIDs)
+					return nil
+				})
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedSent:  1,
+			expectedErr:   errors.New("failed to send events to Kafka"),
+			errorContains: "event 1: broker down",
+		},
+		{
+			name: "backoff is capped by max delay",
+			opts: withOpts(func(o *models.OutboxRelayOptions) { o.MaxAttempts = 100 }),
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				outboxRepoMock.FetchBatchMock.Return([]models.OutboxEvent{{ID: 1, Attempts: 50}}, nil)
+
+				producerMock.SendOutboxEventsMock.Return(map[int64]error{1: errors.New("broker down")}, nil)
+
+				outboxRepoMock.MarkAsFailedMock.Set(func(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) error {
+					require.Equal(t, models.OutboxEventStatePending, state)
+					require.WithinDuration(t, time.Now().Add(time.Minute), nextAttemptAt, time.Second)
+					return nil
+				})
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedSent:  0,
+			expectedErr:   errors.New("failed to send events to Kafka"),
+			errorContains: "broker down",
+		},
+		{
+			name: "event becomes dead after max attempts",
+			opts: opts,
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				outboxRepoMock.FetchBatchMock.Return([]models.OutboxEvent{{ID: 1, Attempts: 2}}, nil)
+
+				producerMock.SendOutboxEventsMock.Return(map[int64]error{1: errors.New("message too large")}, nil)
+
+				outboxRepoMock.MarkAsFailedMock.Set(func(ctx context.Context, tx pgx.Tx, eventID int64, sendErr error, nextAttemptAt time.Time, state models.OutboxEventState) error {
+					require.Equal(t, models.OutboxEventStateDead, state)
+					return nil
+				})
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedSent:  0,
+			expectedErr:   errors.New("failed to send events to Kafka"),
+			errorContains: "message too large",
+		},
+		{
+			name: "unconfirmed events stay pending",
+			opts: opts,
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				outboxRepoMock.FetchBatchMock.Return(batch, nil)
+
+				producerMock.SendOutboxEventsMock.Return(map[int64]error{2: nil}, context.DeadlineExceeded)
+
+				outboxRepoMock.MarkAsSentMock.Set(func(ctx context.Context, tx pgx.Tx, eventIDs []int64) error {
+					require.Equal(t, []int64{2}, eventIDs)
+					return nil
+				})
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedSent:  1,
+			expectedErr:   context.DeadlineExceeded,
+			errorContains: "failed to send events to Kafka",
+		},
+		{
+			name: "error marking event as failed",
+			opts: opts,
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				outboxRepoMock.FetchBatchMock.Return(batch, nil)
+				producerMock.SendOutboxEventsMock.Return(map[int64]error{1: errors.New("broker down"), 2: nil}, nil)
+				outboxRepoMock.MarkAsFailedMock.Return(errors.New("db error"))
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedSent:  0,
+			expectedErr:   errors.New("failed to mark event 1 as failed"),
+			errorContains: "failed to mark event 1 as failed",
+		},
+		{
+			name: "error marking events",
+			opts: opts,
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				outboxRepoMock.FetchBatchMock.Return(batch, nil)
+				producerMock.SendOutboxEventsMock.Return(map[int64]error{1: nil, 2: nil}, nil)
+				outboxRepoMock.MarkAsSentMock.Return(errors.New("db error"))
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedSent:  0,
+			expectedErr:   errors.New("failed to mark events as sent"),
+			errorContains: "failed to mark events as sent",
+		},
+		{
+			name: "error fetching events",
+			opts: opts,
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+				outboxRepoMock.FetchBatchMock.Return(nil, errors.New("db error"))
+
+				outboxRepoMock.WithShardTxMock.Set(func(ctx context.Context, shard models.OutboxShard, fn func(ctx context.Context, tx pgx.Tx) error) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedErr:   errors.New("failed to fetch outbox events"),
+			errorContains: "failed to fetch outbox events",
+		},
+		{
+			name: "invalid batch size",
+			opts: withOpts(func(o *models.OutboxRelayOptions) { o.BatchSize = 0 }),
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "batch size must be greater than zero",
+		},
+		{
+			name: "invalid concurrency",
+			opts: withOpts(func(o *models.OutboxRelayOptions) { o.Concurrency = 0 }),
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "concurrency must be greater than zero",
+		},
+		{
+			name: "invalid max attempts",
+			opts: withOpts(func(o *models.OutboxRelayOptions) { o.MaxAttempts = 0 }),
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "max attempts must be greater than zero",
+		},
+		{
+			name: "invalid retry delays",
+			opts: withOpts(func(o *models.OutboxRelayOptions) { o.RetryMaxDelay = time.Millisecond }),
+			setupMocks: func(ctx context.Context, outboxRepoMock *mock.IOutboxRepositoryMock, producerMock *mock.IProducerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "retry delays must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			_, _, outboxRepoMock, _, producerMock, _, service := setup(t)
+			txMock := mock.NewTxMock(t)
+
+			shards := tt.shards
+			if shards == nil {
+				shards = []models.OutboxShard{models.OutboxShardMain}
+			}
+			outboxRepoMock.ShardsMock.Optional().Return(shards)
+			tt.setupMocks(ctx, outboxRepoMock, producerMock, txMock)
+
+			sent, err := service.ProcessOutbox(ctx, tt.opts)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedSent, sent)
+
+			outboxRepoMock.MinimockFinish()
+			producerMock.MinimockFinish()
+		})
+	}
+}
+
+// Test function for CleanupOutbox method of LomsService.
+func TestLomsService_CleanupOutbox_Table(t *testing.T) {
+	tests := []struct {
+		name            string
+		retention       time.Duration
+		setupMocks      func(outboxRepoMock *mock.IOutboxRepositoryMock)
+		expectedDeleted int64
+		expectedErr     error
+		errorContains   string
+	}{
+		{
+			name:      "processed events older than retention removed",
+			retention: 72 * time.Hour,
+			setupMocks: func(outboxRepoMock *mock.IOutboxRepositoryMock) {
+				outboxRepoMock.ShardsMock.Return([]models.OutboxShard{models.OutboxShardMain, 0, 1})
+				outboxRepoMock.DeleteProcessedMock.Set(func(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) (int64, error) {
+					require.WithinDuration(t, time.Now().Add(-72*time.Hour), processedBefore, time.Second)
+					return int64(shard) + 2, nil
+				})
+			},
+			expectedDeleted: 6,
+		},
+		{
+			name:      "error deleting events",
+			retention: time.Hour,
+			setupMocks: func(outboxRepoMock *mock.IOutboxRepositoryMock) {
+				outboxRepoMock.ShardsMock.Return([]models.OutboxShard{models.OutboxShardMain, 0, 1})
+				outboxRepoMock.DeleteProcessedMock.Set(func(ctx context.Context, shard models.OutboxShard, processedBefore time.Time) (int64, error) {
+					if shard == 0 {
+						return 0, errors.New("db error")
+					}
+					return 3, nil
+				})
+			},
+			expectedDeleted: 6,
+			expectedErr:     errors.New("failed to delete processed outbox events of shard 0"),
+			errorContains:   "failed to delete processed outbox events of shard 0",
+		},
+		{
+			name:          "invalid retention",
+			retention:     0,
+			setupMocks:    func(outboxRepoMock *mock.IOutboxRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "retention must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			_, _, outboxRepoMock, _, _, _, service := setup(t)
+
+			tt.setupMocks(outboxRepoMock)
+
+			deleted, err := service.CleanupOutbox(ctx, tt.retention)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedDeleted, deleted)
+
+			outboxRepoMock.MinimockFinish()
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	loms_service "route256/loms/internal/service/loms"
+	"route256/loms/internal/service/loms/mock"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for RecoverSagas method of LomsService.
+func TestLomsService_RecoverSagas_Table(t *testing.T) {
+	const staleAfter = 30 * time.Second
+
+	payedSaga := models.Saga{
+		ID:         10,
+		OrderID:    1,
+		Type:       models.SagaTypePay,
+		FromStatus: models.OrderStatusAwaitingPayment,
+		ToStatus:   models.OrderStatusPayed,
+		EventType:  "OrderPayed",
+		Items:      []models.Item{{SKU: 1001, Count: 2}},
+		State:      models.SagaStateStarted,
+	}
+
+	tests := []struct {
+		name       string
+		staleAfter time.Duration
+		setupMocks func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+			stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+			txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock)
+		expectedRecovered int
+		expectedErr       error
+		errorContains     string
+	}{
+		{
+			name:       "order status changed before crash completes saga",
+			staleAfter: staleAfter,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				sagaRepoMock.GetStartedMock.Set(func(ctx context.Context, updatedBefore time.Time, limit uint32) ([]models.Saga, error) {
+					require.WithinDuration(t, time.Now().Add(-staleAfter), updatedBefore, time.Minute)
+					require.Equal(t, uint32(100), limit)
+					return []models.Saga{payedSaga}, nil
+				})
+
+				orderRepoMock.GetByIDMock.Return(models.Order{OrderID: 1, Status: models.OrderStatusPayed}, nil)
+
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, int64(10), sagaID)
+					require.Equal(t, models.SagaStateCompleted, state)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedRecovered: 1,
+		},
+		{
+			name:       "order status not changed repeats saga",
+			staleAfter: staleAfter,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				sagaRepoMock.GetStartedMock.Return([]models.Saga{payedSaga}, nil)
+
+				orderRepoMock.GetByIDMock.Return(models.Order{OrderID: 1, Status: models.OrderStatusAwaitingPayment}, nil)
+
+				orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+					require.Equal(t, models.OID(1), orderID)
+					require.Equal(t, models.OrderStatusAwaitingPayment, expected)
+					require.Equal(t, models.OrderStatusPayed, status)
+					require.Equal(t, "OrderPayed", eventType)
+					return nil
+				})
+
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, models.SagaStateCompleted, state)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedRecovered: 1,
+		},
+		{
+			name:       "order moved to other status compensates saga",
+			staleAfter: staleAfter,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				sagaRepoMock.GetStartedMock.Return([]models.Saga{payedSaga}, nil)
+
+				orderRepoMock.GetByIDMock.Return(models.Order{OrderID: 1, Status: models.OrderStatusCancelled}, nil)
+
+				sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+					require.Equal(t, models.SagaStateCompensated, state)
+					return nil
+				})
+
+				stockRepoMock.RestoreRemovedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
+					require.Equal(t, payedSaga.Items, items)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedRecovered: 1,
+		},
+		{
+			name:       "missing order compensates saga",
+			staleAfter: staleAfter,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				sagaRepoMock.GetStartedMock.Return([]models.Saga{{
+					ID:         11,
+					OrderID:    2,
+					Type:       models.SagaTypeReserve,
+					FromStatus: models.OrderStatusNew,
+					ToStatus:   models.OrderStatusAwaitingPayment,
+					Items:      []models.Item{{SKU: 1002, Count: 1}},
+				}}, nil)
+
+				orderRepoMock.GetByIDMock.Return(models.Order{}, internal_errors.ErrNotFound)
+
+				sagaRepoMock.SetStateMock.Return(nil)
+
+				stockRepoMock.CancelReservedItemsMock.Set(func(ctx context.Context, tx pgx.Tx, items []models.Item) error {
+					require.Equal(t, []models.Item{{SKU: 1002, Count: 1}}, items)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedRecovered: 1,
+		},
+		{
+			name:       "saga finished by another replica is skipped",
+			staleAfter: staleAfter,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				sagaRepoMock.GetStartedMock.Return([]models.Saga{payedSaga}, nil)
+
+				orderRepoMock.GetByIDMock.Return(models.Order{OrderID: 1, Status: models.OrderStatusPayed}, nil)
+
+				sagaRepoMock.SetStateMock.Return(internal_errors.ErrSagaFinished)
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedRecovered: 0,
+		},
+		{
+			name:       "error recovering one saga",
+			staleAfter: staleAfter,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				failedSaga := payedSaga
+				failedSaga.ID = 12
+				failedSaga.OrderID = 3
+				sagaRepoMock.GetStartedMock.Return([]models.Saga{failedSaga, payedSaga}, nil)
+
+				orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
+					if orderID == 3 {
+						return models.Order{}, errors.New("shard unavailable")
+					}
+					return models.Order{OrderID: orderID, Status: models.OrderStatusPayed}, nil
+				})
+
+				sagaRepoMock.RecordAttemptMock.Set(func(ctx context.Context, sagaID int64, lastErr error) error {
+					require.Equal(t, int64(12), sagaID)
+					require.ErrorContains(t, lastErr, "shard unavailable")
+					return nil
+				})
+
+				sagaRepoMock.SetStateMock.Return(nil)
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedRecovered: 1,
+			expectedErr:       errors.New("failed to recover saga 12"),
+			errorContains:     "failed to recover saga 12",
+		},
+		{
+			name:       "error getting sagas",
+			staleAfter: staleAfter,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				sagaRepoMock.GetStartedMock.Return(nil, errors.New("db error"))
+			},
+			expectedErr:   errors.New("failed to get started sagas"),
+			errorContains: "failed to get started sagas",
+		},
+		{
+			name:       "invalid stale interval",
+			staleAfter: 0,
+			setupMocks: func(ctx context.Context, orderRepoMock *mock.IOrderRepositoryMock,
+				stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, sagaRepoMock *mock.ISagaRepositoryMock,
+				txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "stale interval must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, _, txManagerMock, service := setup(t)
+			txMock := mock.NewTxMock(t)
+
+			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, txManagerMock, txMock)
+
+			recovered, err := service.RecoverSagas(ctx, tt.staleAfter)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedRecovered, recovered)
+
+			orderRepoMock.MinimockFinish()
+			stockRepoMock.MinimockFinish()
+			outboxRepoMock.MinimockFinish()
+			sagaRepoMock.MinimockFinish()
+			txManagerMock.MinimockFinish()
+		})
+	}
+}
+
+// Test recovery changing order status while request is changing it does not compensate saga.
+func TestLomsService_OrderPay_RecoveredConcurrently(t *testing.T) {
+	ctx := context.Background()
+	orderRepoMock, stockRepoMock, _, sagaRepoMock, _, txManagerMock, service := setup(t)
+	txMock := mock.NewTxMock(t)
+
+	items := []models.Item{{SKU: 1001, Count: 2}}
+	status := models.OrderStatusAwaitingPayment
+	sagaState := models.SagaStateStarted
+	var saga models.Saga
+
+	orderRepoMock.GetByIDMock.Set(func(ctx context.Context, orderID models.OID) (models.Order, error) {
+		return models.Order{OrderID: orderID, Status: status, Items: items}, nil
+	})
+
+	sagaRepoMock.CreateMock.Set(func(ctx context.Context, tx pgx.Tx, created models.Saga) (int64, error) {
+		saga = created
+		saga.ID = 10
+		return saga.ID, nil
+	})
+	stockRepoMock.RemoveReservedItemsMock.Return(nil)
+
+	// Recovery changes order status while request is waiting for shard, then fails before saga is completed
+	recovering := false
+	orderRepoMock.CompareAndSetStatusMock.Set(func(ctx context.Context, orderID models.OID, expected, to models.OrderStatus, eventType string) error {
+		if !recovering {
+			recovering = true
+			sagaRepoMock.GetStartedMock.Return([]models.Saga{saga}, nil)
+			_, err := service.RecoverSagas(ctx, time.Second)
+			require.ErrorContains(t, err, "db error")
+		}
+		if status != expected {
+			return fmt.Errorf("order is not in %s status: %w", expected, internal_errors.ErrInvalidOrderStatus)
+		}
+		status = to
+		return nil
+	})
+
+	setStateCalls := 0
+	sagaRepoMock.SetStateMock.Set(func(ctx context.Context, tx pgx.Tx, sagaID int64, state models.SagaState) error {
+		setStateCalls++
+		if setStateCalls == 1 {
+			return errors.New("db error")
+		}
+		if sagaState != models.SagaStateStarted {
+			return internal_errors.ErrSagaFinished
+		}
+		sagaState = state
+		return nil
+	})
+	sagaRepoMock.RecordAttemptMock.Return(nil)
+
+	txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+		return fn(ctx, txMock)
+	})
+
+	// Run test
+	err := service.OrderPay(ctx, &models.OrderPayRequest{OrderID: 1})
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusPayed, status)
+	require.Equal(t, models.SagaStateCompleted, sagaState)
+	require.Zero(t, stockRepoMock.RestoreRemovedItemsAfterCounter())
+}
@@ -6,25 +6,29 @@ import (
 	"route256/loms/internal/service/loms/mock"
 
 	"testing"
+	"time"
 
 	"github.com/gojuno/minimock/v3"
 )
 
 var ErrRepository = errors.New("repository error")
 
+const idempotencyKeyRetention = 24 * time.Hour
+
 // setup function for setup initializes the mocks and the CartService for the tests.
-func setup(t *testing.T) (*mock.IOrderRepositoryMock, *mock.IStockRepositoryMock, *mock.IOutboxRepositoryMock, *mock.IProducerMock, *mock.ITxManagerMock, *loms_service.LomsService) {
+func setup(t *testing.T) (*mock.IOrderRepositoryMock, *mock.IStockRepositoryMock, *mock.IOutboxRepositoryMock, *mock.ISagaRepositoryMock, *mock.IProducerMock, *mock.ITxManagerMock, *loms_service.LomsService) {
 	ctrl := minimock.NewController(t)
 
 	// Create mocks for ICartRepository and IProductService
 	orderRepoMock := mock.NewIOrderRepositoryMock(ctrl)
 	stockRepoMock := mock.NewIStockRepositoryMock(ctrl)
 	outboxRepoMock := mock.NewIOutboxRepositoryMock(ctrl)
+	sagaRepoMock := mock.NewISagaRepositoryMock(ctrl)
 	txManagerMock := mock.NewITxManagerMock(ctrl)
 	producerMock := mock.NewIProducerMock(ctrl)
 
 	// Initialize the service with the mocks
-	service := loms_service.NewService(orderRepoMock, stockRepoMock, outboxRepoMock, txManagerMock, producerMock)
+	service := loms_service.NewService(orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, txManagerMock, producerMock, idempotencyKeyRetention)
 
-	return orderRepoMock, stockRepoMock, outboxRepoMock, producerMock, txManagerMock, service
+	return orderRepoMock, stockRepoMock, outboxRepoMock, sagaRepoMock, producerMock, txManagerMock, service
 }
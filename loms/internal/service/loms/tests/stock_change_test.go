@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	loms_service "route256/loms/internal/service/loms"
+	"route256/loms/internal/service/loms/mock"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for StockReplenish, StockSet and StockAdjust methods of LomsService.
+func TestLomsService_StockChange_Table(t *testing.T) {
+	tests := []struct {
+		name       string
+		call       func(ctx context.Context, service *loms_service.LomsService) (*models.StockChangeResponse, error)
+		setupMocks func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+			outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock)
+		expectedResp  *models.StockChangeResponse
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "successful replenish",
+			call: func(ctx context.Context, service *loms_service.LomsService) (*models.StockChangeResponse, error) {
+				return service.StockReplenish(ctx, &models.StockReplenishRequest{
+					SKU:     1001,
+					Count:   10,
+					Reason:  models.StockChangeReasonDelivery,
+					Comment: "delivery",
+				})
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.ReplenishStockMock.Set(func(ctx context.Context, tx pgx.Tx, SKU models.SKU, count uint64) (models.Stock, error) {
+					require.Equal(t, models.SKU(1001), SKU)
+					require.Equal(t, uint64(10), count)
+					return models.Stock{SKU: 1001, TotalCount: 110, Reserved: 5}, nil
+				})
+
+				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
+					require.Equal(t, models.StockEventReplenished, eventType)
+					event, ok := payload.(models.StockEvent)
+					require.True(t, ok)
+					require.Equal(t, models.SKU(1001), event.SKU)
+					require.Equal(t, uint64(110), event.TotalCount)
+					require.Equal(t, int64(10), event.Delta)
+					require.Equal(t, models.StockChangeReasonDelivery, event.Reason)
+					require.Equal(t, "delivery", event.Comment)
+					require.False(t, event.Time.IsZero())
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedResp: &models.StockChangeResponse{
+				Stock: models.Stock{SKU: 1001, TotalCount: 110, Reserved: 5},
+			},
+		},
+		{
+			name: "successful set",
+			call: func(ctx context.Context, service *loms_service.LomsService) (*models.StockChangeResponse, error) {
+				return service.StockSet(ctx, &models.StockSetRequest{
+					SKU:        1001,
+					TotalCount: 50,
+					Reason:     models.StockChangeReasonInventory,
+				})
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.SetStockTotalCountMock.Set(func(ctx context.Context, tx pgx.Tx, SKU models.SKU, totalCount uint64) (models.Stock, error) {
+					require.Equal(t, models.SKU(1001), SKU)
+					require.Equal(t, uint64(50), totalCount)
+					return models.Stock{SKU: 1001, TotalCount: 50, Reserved: 5}, nil
+				})
+
+				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
+					require.Equal(t, models.StockEventSet, eventType)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedResp: &models.StockChangeResponse{
+				Stock: models.Stock{SKU: 1001, TotalCount: 50, Reserved: 5},
+			},
+		},
+		{
+			name: "successful adjust",
+			call: func(ctx context.Context, service *loms_service.LomsService) (*models.StockChangeResponse, error) {
+				return service.StockAdjust(ctx, &models.StockAdjustRequest{
+					SKU:    1001,
+					Delta:  -3,
+					Reason: models.StockChangeReasonDamage,
+				})
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.AdjustStockTotalCountMock.Set(func(ctx context.Context, tx pgx.Tx, SKU models.SKU, delta int64) (models.Stock, error) {
+					require.Equal(t, models.SKU(1001), SKU)
+					require.Equal(t, int64(-3), delta)
+					return models.Stock{SKU: 1001, TotalCount: 47, Reserved: 5}, nil
+				})
+
+				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
+					require.Equal(t, models.StockEventAdjusted, eventType)
+					event, ok := payload.(models.StockEvent)
+					require.True(t, ok)
+					require.Equal(t, int64(-3), event.Delta)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedResp: &models.StockChangeResponse{
+				Stock: models.Stock{SKU: 1001, TotalCount: 47, Reserved: 5},
+			},
+		},
+		{
+			name: "unknown reason",
+			call: func(ctx context.Context, service *loms_service.LomsService) (*models.StockChangeResponse, error) {
+				return service.StockReplenish(ctx, &models.StockReplenishRequest{SKU: 1001, Count: 1})
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "unknown stock change reason",
+		},
+		{
+			name: "zero delta",
+			call: func(ctx context.Context, service *loms_service.LomsService) (*models.StockChangeResponse, error) {
+				return service.StockAdjust(ctx, &models.StockAdjustRequest{SKU: 1001, Reason: models.StockChangeReasonCorrection})
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "delta must not be zero",
+		},
+		{
+			name: "set total count below reserved",
+			call: func(ctx context.Context, service *loms_service.LomsService) (*models.StockChangeResponse, error) {
+				return service.StockSet(ctx, &models.StockSetRequest{SKU: 1001, TotalCount: 1, Reason: models.StockChangeReasonInventory})
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.SetStockTotalCountMock.Return(models.Stock{}, internal_errors.ErrPreconditionFailed)
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedErr:   internal_errors.ErrPreconditionFailed,
+			errorContains: "failed to set stock",
+		},
+		{
+			name: "error writing event to outbox",
+			call: func(ctx context.Context, service *loms_service.LomsService) (*models.StockChangeResponse, error) {
+				return service.StockReplenish(ctx, &models.StockReplenishRequest{SKU: 1001, Count: 1, Reason: models.StockChangeReasonReturn})
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.ReplenishStockMock.Return(models.Stock{SKU: 1001, TotalCount: 1}, nil)
+				outboxRepoMock.CreateEventMock.Return(errors.New("outbox error"))
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedErr:   internal_errors.ErrInternalServerError,
+			errorContains: "failed to create outbox event",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			_, stockRepoMock, outboxRepoMock, _, _, txManagerMock, service := setup(t)
+			txMock := mock.NewTxMock(t)
+
+			tt.setupMocks(ctx, stockRepoMock, outboxRepoMock, txManagerMock, txMock)
+
+			resp, err := tt.call(ctx, service)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResp, resp)
+			}
+
+			stockRepoMock.MinimockFinish()
+			outboxRepoMock.MinimockFinish()
+			txManagerMock.MinimockFinish()
+		})
+	}
+}
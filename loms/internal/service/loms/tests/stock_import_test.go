@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/stock_import"
+	loms_service "route256/loms/internal/service/loms"
+	"route256/loms/internal/service/loms/mock"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for StockImport method of LomsService.
+func TestLomsService_StockImport_Table(t *testing.T) {
+	rows := []models.StockImportRow{
+		{Line: 2, Stock: models.Stock{SKU: 1001, TotalCount: 100, Reserved: 0}},
+		{Line: 3, Stock: models.Stock{SKU: 1002, TotalCount: 50, Reserved: 0}},
+		{Line: 4, Stock: models.Stock{SKU: 1003, TotalCount: 30, Reserved: 0}},
+	}
+	existing := []models.Stock{
+		{SKU: 1001, TotalCount: 80, Reserved: 10},
+		{SKU: 1003, TotalCount: 30, Reserved: 5},
+	}
+	changes := []models.StockImportChange{
+		{Line: 2, SKU: 1001, Action: models.StockImportActionUpdate, OldTotalCount: 80, NewTotalCount: 100, Reserved: 10},
+		{Line: 3, SKU: 1002, Action: models.StockImportActionCreate, NewTotalCount: 50, Reserved: 0},
+		{Line: 4, SKU: 1003, Action: models.StockImportActionUnchanged, OldTotalCount: 30, NewTotalCount: 30, Reserved: 5},
+	}
+
+	tests := []struct {
+		name       string
+		req        *models.StockImportRequest
+		setupMocks func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+			outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock)
+		expectedResp  *models.StockImportResponse
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "successful import",
+			req:  &models.StockImportRequest{Rows: rows},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.GetStocksForUpdateMock.Set(func(ctx context.Context, tx pgx.Tx, SKUs []models.SKU) ([]models.Stock, error) {
+					require.Equal(t, []models.SKU{1001, 1002, 1003}, SKUs)
+					return existing, nil
+				})
+
+				stockRepoMock.ImportStockMock.Set(func(ctx context.Context, tx pgx.Tx, stock models.Stock) (models.Stock, error) {
+					switch stock.SKU {
+					case 1001:
+						return models.Stock{SKU: 1001, TotalCount: 100, Reserved: 10}, nil
+					case 1002:
+						return models.Stock{SKU: 1002, TotalCount: 50, Reserved: 0}, nil
+					}
+					require.Failf(t, "unexpected import", "SKU %d is unchanged", stock.SKU)
+					return models.Stock{}, nil
+				})
+
+				outboxRepoMock.CreateEventMock.Set(func(ctx context.Context, tx pgx.Tx, eventType string, payload interface{}) error {
+					require.Equal(t, models.StockEventSet, eventType)
+					event, ok := payload.(models.StockEvent)
+					require.True(t, ok)
+					require.Equal(t, models.StockChangeReasonImport, event.Reason)
+					return nil
+				})
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedResp: &models.StockImportResponse{
+				Changes:   changes,
+				Created:   1,
+				Updated:   1,
+				Unchanged: 1,
+				Applied:   true,
+			},
+		},
+		{
+			name: "dry run does not write",
+			req:  &models.StockImportRequest{Rows: rows, DryRun: true},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.GetStocksForUpdateMock.Return(existing, nil)
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedResp: &models.StockImportResponse{
+				Changes:   changes,
+				Created:   1,
+				Updated:   1,
+				Unchanged: 1,
+			},
+		},
+		{
+			name: "total count less than reserved",
+			req: &models.StockImportRequest{Rows: []models.StockImportRow{
+				{Line: 2, Stock: models.Stock{SKU: 1001, TotalCount: 5}},
+				{Line: 3, Stock: models.Stock{SKU: 1003, TotalCount: 4}},
+			}},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.GetStocksForUpdateMock.Return(existing, nil)
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "line 3: total_count 4 is less than reserved 5",
+		},
+		{
+			name: "reserved new stock",
+			req: &models.StockImportRequest{Rows: []models.StockImportRow{
+				{Line: 2, Stock: models.Stock{SKU: 1002, TotalCount: 50, Reserved: 7}},
+			}},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.GetStocksForUpdateMock.Return(existing, nil)
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "line 2: reserved 7 must be 0, stock is reserved only by orders",
+		},
+		{
+			name: "no rows",
+			req:  &models.StockImportRequest{},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock, outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+			},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "no stocks to import",
+		},
+		{
+			name: "error importing stock",
+			req:  &models.StockImportRequest{Rows: rows[:1]},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock,
+				outboxRepoMock *mock.IOutboxRepositoryMock, txManagerMock *mock.ITxManagerMock, txMock *mock.TxMock) {
+				stockRepoMock.GetStocksForUpdateMock.Return(nil, nil)
+				stockRepoMock.ImportStockMock.Return(models.Stock{}, errors.New("db error"))
+
+				txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+					return fn(ctx, txMock)
+				})
+			},
+			expectedErr:   internal_errors.ErrInternalServerError,
+			errorContains: "failed to import stock on line 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			_, stockRepoMock, outboxRepoMock, _, _, txManagerMock, service := setup(t)
+			txMock := mock.NewTxMock(t)
+
+			tt.setupMocks(ctx, stockRepoMock, outboxRepoMock, txManagerMock, txMock)
+
+			resp, err := service.StockImport(ctx, tt.req)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResp, resp)
+			}
+
+			stockRepoMock.MinimockFinish()
+			outboxRepoMock.MinimockFinish()
+			txManagerMock.MinimockFinish()
+		})
+	}
+}
+
+// Test function for line-level errors of StockImport method of LomsService.
+func TestLomsService_StockImport_RowErrors(t *testing.T) {
+	ctx := context.Background()
+	_, stockRepoMock, _, _, _, txManagerMock, service := setup(t)
+	txMock := mock.NewTxMock(t)
+
+	stockRepoMock.GetStocksForUpdateMock.Return([]models.Stock{{SKU: 1001, TotalCount: 10, Reserved: 10}}, nil)
+	txManagerMock.WithTxMock.Set(func(ctx context.Context, fn loms_service.WithTxFunc) error {
+		return fn(ctx, txMock)
+	})
+
+	_, err := service.StockImport(ctx, &models.StockImportRequest{
+		Rows: []models.StockImportRow{{Line: 7, Stock: models.Stock{SKU: 1001, TotalCount: 9}}},
+	})
+
+	var rowErrs stock_import.Errors
+	require.ErrorAs(t, err, &rowErrs)
+	require.Equal(t, stock_import.Errors{{Line: 7, Message: "total_count 9 is less than reserved 10"}}, rowErrs)
+}
@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/service/loms/mock"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for StocksInfoBatch method of LomsService.
+func TestLomsService_StocksInfoBatch_Table(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *models.StocksInfoBatchRequest
+		setupMocks    func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock)
+		expectedResp  *models.StocksInfoBatchResponse
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "successful stocks retrieval with unknown SKUs",
+			req: &models.StocksInfoBatchRequest{
+				SKUs: []models.SKU{1003, 1001, 9999, 1003},
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock) {
+				stockRepoMock.GetStocksBySKUsMock.Set(func(ctx context.Context, SKUs []models.SKU) ([]models.Stock, error) {
+					require.Equal(t, []models.SKU{1003, 1001, 9999}, SKUs)
+					return []models.Stock{
+						{SKU: 1001, TotalCount: 100, Reserved: 10},
+						{SKU: 1003, TotalCount: 5, Reserved: 5},
+					}, nil
+				})
+			},
+			expectedResp: &models.StocksInfoBatchResponse{
+				Stocks: []models.StockInfo{
+					{SKU: 1003, Available: 0, TotalCount: 5, Reserved: 5},
+					{SKU: 1001, Available: 90, TotalCount: 100, Reserved: 10},
+				},
+				UnknownSKUs: []models.SKU{9999},
+			},
+		},
+		{
+			name:          "empty SKUs",
+			req:           &models.StocksInfoBatchRequest{},
+			setupMocks:    func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "count of SKUs must be in range",
+		},
+		{
+			name: "too many SKUs",
+			req: &models.StocksInfoBatchRequest{
+				SKUs: make([]models.SKU, 101),
+			},
+			setupMocks:    func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "count of SKUs must be in range",
+		},
+		{
+			name: "invalid SKU",
+			req: &models.StocksInfoBatchRequest{
+				SKUs: []models.SKU{1001, 0},
+			},
+			setupMocks:    func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock) {},
+			expectedErr:   internal_errors.ErrBadRequest,
+			errorContains: "SKU must be greater than zero",
+		},
+		{
+			name: "error getting stocks",
+			req: &models.StocksInfoBatchRequest{
+				SKUs: []models.SKU{1001},
+			},
+			setupMocks: func(ctx context.Context, stockRepoMock *mock.IStockRepositoryMock) {
+				stockRepoMock.GetStocksBySKUsMock.Return(nil, errors.New("db error"))
+			},
+			expectedErr:   internal_errors.ErrInternalServerError,
+			errorContains: "failed to get stocks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			_, stockRepoMock, _, _, _, _, service := setup(t)
+
+			tt.setupMocks(ctx, stockRepoMock)
+
+			resp, err := service.StocksInfoBatch(ctx, tt.req)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.expectedErr) || (tt.errorContains != "" && strings.Contains(err.Error(), tt.errorContains)),
+					"error must be %v or contain message: %s", tt.expectedErr, tt.errorContains)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResp, resp)
+			}
+
+			stockRepoMock.MinimockFinish()
+		})
+	}
+}
@@ -72,7 +72,7 @@ func TestLomsService_StocksInfo_Table(t *testing.T) {
 			t.Parallel()
 
 			ctx := context.Background()
-			orderRepoMock, stockRepoMock, _, _, txManagerMock, service := setup(t)
+			orderRepoMock, stockRepoMock, _, _, _, txManagerMock, service := setup(t)
 
 			tt.setupMocks(ctx, orderRepoMock, stockRepoMock, txManagerMock, tt.req)
 
@@ -4,22 +4,21 @@ import (
 	"context"
 	"fmt"
 	"route256/loms/internal/models"
-
-	"github.com/jackc/pgx/v5"
 )
 
-// updateOrderStatus updates order status in repository.
-func (s *LomsService) updateOrderStatus(ctx context.Context, orderID models.OID, status models.OrderStatus) error {
-	if err := s.orderRepository.SetStatus(ctx, orderID, status); err != nil {
+// updateOrderStatus updates order status in repository, event is written in outbox of order shard.
+func (s *LomsService) updateOrderStatus(ctx context.Context, orderID models.OID, status models.OrderStatus, eventType string) error {
+	if err := s.orderRepository.SetStatus(ctx, orderID, status, eventType); err != nil {
 		return fmt.Errorf("failed to set order status %s: %w", status, err)
 	}
 	return nil
 }
 
-// createOutboxEvent writes event to outbox.
-func (s *LomsService) createOutboxEvent(ctx context.Context, tx pgx.Tx, orderID models.OID, status models.OrderStatus, eventType string) error {
-	if err := s.writeEventInOutbox(ctx, tx, eventType, orderID, status, eventType); err != nil {
-		return fmt.Errorf("write event in outbox: %w", err)
+// compareAndSetOrderStatus updates order status in repository if current status is expected,
+// event is written in outbox of order shard.
+func (s *LomsService) compareAndSetOrderStatus(ctx context.Context, orderID models.OID, expected, status models.OrderStatus, eventType string) error {
+	if err := s.orderRepository.CompareAndSetStatus(ctx, orderID, expected, status, eventType); err != nil {
+		return fmt.Errorf("failed to set order status %s: %w", status, err)
 	}
 	return nil
 }
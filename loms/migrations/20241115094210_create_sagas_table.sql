@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE sagas (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    from_status VARCHAR(32) NOT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    items JSONB NOT NULL,
+    state VARCHAR(16) NOT NULL DEFAULT 'started',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Only one unfinished saga per order
+CREATE UNIQUE INDEX sagas_order_id_started_idx ON sagas (order_id) WHERE state = 'started';
+CREATE INDEX sagas_state_updated_at_idx ON sagas (state, updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE sagas;
+-- +goose StatementEnd
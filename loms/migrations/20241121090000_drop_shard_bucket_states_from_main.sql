@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Bucket states are used only in order shards
+DROP TABLE IF EXISTS shard_bucket_states;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE shard_bucket_states (
+    bucket INT PRIMARY KEY,
+    state VARCHAR(16) NOT NULL,
+    shard INT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
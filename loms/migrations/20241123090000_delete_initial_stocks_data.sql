@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Stocks are imported with stock_import, seed rows not changed since seed are deleted
+DELETE FROM stocks WHERE (sku, total_count, reserved) IN (
+    (1076963, 200, 20),
+    (1148162, 250, 30),
+    (773297411, 150, 10),
+    (1002, 200, 20),
+    (1003, 250, 30),
+    (1004, 300, 40),
+    (1005, 350, 50)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+INSERT INTO stocks (sku, total_count, reserved) VALUES
+    (1076963, 200, 20),
+    (1148162, 250, 30),
+    (773297411, 150, 10),
+    (1002, 200, 20),
+    (1003, 250, 30),
+    (1004, 300, 40),
+    (1005, 350, 50)
+ON CONFLICT (sku) DO NOTHING;
+-- +goose StatementEnd
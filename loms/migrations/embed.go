@@ -4,3 +4,31 @@ import "embed"
 
 //go:embed *.sql
 var EmbedFS embed.FS
+
+// Target is kind of database migrations are applied to.
+type Target string
+
+const (
+	// TargetMain is main database with stocks, sagas and bucket map.
+	TargetMain Target = "main"
+	// TargetShard is order shard.
+	TargetShard Target = "shard"
+)
+
+// Migrations added before targets were introduced are applied to every database.
+// New migrations used only by one target are listed here.
+var targetOnly = map[string]Target{
+	"20241121090000_drop_shard_bucket_states_from_main.sql": TargetMain,
+	"20241121090001_drop_shard_buckets_from_shards.sql":     TargetShard,
+}
+
+// Excludes returns names of migrations not applied to target.
+func Excludes(target Target) []string {
+	var names []string
+	for name, only := range targetOnly {
+		if only != target {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Outbox relay listens on channel "outbox", notification is delivered on commit
+CREATE FUNCTION notify_outbox_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('outbox', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER outbox_notify_trigger
+AFTER INSERT ON outbox
+FOR EACH STATEMENT
+EXECUTE FUNCTION notify_outbox_event();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER outbox_notify_trigger ON outbox;
+DROP FUNCTION notify_outbox_event();
+-- +goose StatementEnd
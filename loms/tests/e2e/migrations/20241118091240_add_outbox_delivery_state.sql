@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE outbox ADD COLUMN state VARCHAR(16) NOT NULL DEFAULT 'pending';
+ALTER TABLE outbox ADD COLUMN attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE outbox ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE outbox ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE outbox ADD COLUMN processed_at TIMESTAMP WITH TIME ZONE;
+UPDATE outbox SET state = 'processed', processed_at = created_at WHERE processed;
+
+DROP INDEX outbox_order_id_sequence_idx;
+ALTER TABLE outbox DROP COLUMN processed;
+
+CREATE INDEX outbox_order_id_sequence_idx ON outbox (order_id, sequence) WHERE state = 'pending';
+CREATE INDEX outbox_next_attempt_at_idx ON outbox (next_attempt_at) WHERE state = 'pending';
+CREATE INDEX outbox_processed_at_idx ON outbox (processed_at) WHERE state = 'processed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX outbox_processed_at_idx;
+DROP INDEX outbox_next_attempt_at_idx;
+DROP INDEX outbox_order_id_sequence_idx;
+
+ALTER TABLE outbox ADD COLUMN processed BOOLEAN DEFAULT FALSE;
+UPDATE outbox SET processed = state <> 'pending';
+
+ALTER TABLE outbox DROP COLUMN processed_at;
+ALTER TABLE outbox DROP COLUMN next_attempt_at;
+ALTER TABLE outbox DROP COLUMN last_error;
+ALTER TABLE outbox DROP COLUMN attempts;
+ALTER TABLE outbox DROP COLUMN state;
+
+CREATE INDEX outbox_order_id_sequence_idx ON outbox (order_id, sequence) WHERE processed = FALSE;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- W3C traceparent of request which created event
+ALTER TABLE outbox ADD COLUMN trace_parent TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox DROP COLUMN trace_parent;
+-- +goose StatementEnd
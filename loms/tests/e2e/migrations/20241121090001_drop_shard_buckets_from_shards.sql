@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Bucket map is used only in main database
+DROP TABLE IF EXISTS shard_buckets;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE shard_buckets (
+    bucket INT PRIMARY KEY,
+    shard INT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Expired orders are selected by status oldest first
+CREATE INDEX orders_status_id_created_at_idx ON orders (status_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX orders_status_id_created_at_idx;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status_id INTEGER NOT NULL REFERENCES statuses(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX order_status_history_order_id_idx ON order_status_history (order_id);
+
+INSERT INTO order_status_history (order_id, status_id, created_at)
+SELECT id, status_id, COALESCE(created_at, CURRENT_TIMESTAMP) FROM orders;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE order_status_history;
+-- +goose StatementEnd
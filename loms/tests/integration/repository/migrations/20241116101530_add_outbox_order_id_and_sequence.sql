@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE outbox ADD COLUMN order_id BIGINT;
+ALTER TABLE outbox ADD COLUMN sequence BIGINT;
+UPDATE outbox SET order_id = (payload::jsonb->>'order_id')::BIGINT WHERE payload::jsonb ? 'order_id';
+
+-- Next event of order is not sent until previous one is processed
+CREATE INDEX outbox_order_id_sequence_idx ON outbox (order_id, sequence) WHERE processed = FALSE;
+
+-- Last sequence number of order events
+CREATE TABLE order_event_sequences (
+    order_id BIGINT PRIMARY KEY,
+    last_sequence BIGINT NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE order_event_sequences;
+DROP INDEX outbox_order_id_sequence_idx;
+ALTER TABLE outbox DROP COLUMN sequence;
+ALTER TABLE outbox DROP COLUMN order_id;
+-- +goose StatementEnd
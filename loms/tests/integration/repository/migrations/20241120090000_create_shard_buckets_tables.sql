@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Owner shard of each virtual bucket, used in main database
+CREATE TABLE shard_buckets (
+    bucket INT PRIMARY KEY,
+    shard INT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Buckets being moved to or from shard, used in order shards. Orders of importing bucket are
+-- not visible in lists until move is finished, moved bucket redirects requests to new shard.
+CREATE TABLE shard_bucket_states (
+    bucket INT PRIMARY KEY,
+    state VARCHAR(16) NOT NULL,
+    shard INT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE shard_bucket_states;
+DROP TABLE shard_buckets;
+-- +goose StatementEnd
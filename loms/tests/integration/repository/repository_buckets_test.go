@@ -0,0 +1,147 @@
+package repository_test
+
+import (
+	"context"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/shard_manager"
+	ordersRepository "route256/loms/internal/repository/orders"
+	outboxRepository "route256/loms/internal/repository/outbox"
+	"route256/loms/internal/repository/sqlc"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// Test for MoveBucket.
+func TestMoveBucket(t *testing.T) {
+	// Own bucket map, so moved bucket does not change routing of other tests
+	manager := shard_manager.NewShardManager(
+		shard_manager.GetMurmur3BucketFn(ShardBucketCount),
+		shardManager.GetShards(),
+		shard_manager.DefaultBuckets(ShardBucketCount, ShardCount),
+	)
+	orderRepo := ordersRepository.NewOrderRepository(manager)
+	outboxRepo := outboxRepository.NewOutboxRepository(connTests, manager)
+
+	ctx := context.Background()
+	const userID = 1016
+	order := models.Order{
+		UserID: userID,
+		Status: "awaiting payment",
+		Items: []models.Item{
+			{SKU: 1, Count: 2},
+			{SKU: 2, Count: 1},
+		},
+	}
+	key := models.IdempotencyKey{
+		UserID:      userID,
+		Key:         "move-bucket-key",
+		RequestHash: "hash",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	orderID, err := orderRepo.CreateWithIdempotencyKey(ctx, order, key, "OrderCreated")
+	require.NoError(t, err)
+	err = orderRepo.CompareAndSetStatus(ctx, orderID, "awaiting payment", "paid", "OrderPayed")
+	require.NoError(t, err)
+
+	bucket := manager.GetBucketFromID(orderID)
+	source := manager.GetBucketShard(bucket)
+	target := (source + 1) % ShardCount
+	sourcePool, err := manager.GetShard(source)
+	require.NoError(t, err)
+	targetPool, err := manager.GetShard(target)
+	require.NoError(t, err)
+
+	// Bucket with unsent events is not moved
+	_, err = orderRepo.MoveBucket(ctx, bucket, source, target)
+	require.ErrorIs(t, err, internal_errors.ErrBucketBusy)
+
+	markEventsSent(t, outboxRepo, models.OutboxShard(source))
+
+	// Run test
+	moved, err := orderRepo.MoveBucket(ctx, bucket, source, target)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, moved, int64(1))
+
+	// Order rows are moved from source to target
+	_, err = sqlc.New(sourcePool).GetOrderByID(ctx, orderID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = sqlc.New(targetPool).GetOrderByID(ctx, orderID)
+	require.NoError(t, err)
+
+	// Read with stale bucket map is redirected to target with items and history
+	require.Equal(t, source, manager.GetBucketShard(bucket))
+	stored, err := orderRepo.GetByID(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, target, manager.GetBucketShard(bucket))
+	require.Equal(t, models.OrderStatus("paid"), stored.Status)
+	require.ElementsMatch(t, order.Items, stored.Items)
+	require.Len(t, stored.History, 2)
+
+	storedKey, err := orderRepo.GetIdempotencyKey(ctx, userID, "move-bucket-key")
+	require.NoError(t, err)
+	require.Equal(t, orderID, storedKey.OrderID)
+
+	// Write with stale bucket map is redirected to target
+	manager.SetBucketShard(bucket, source)
+	err = orderRepo.CompareAndSetStatus(ctx, orderID, "paid", "cancelled", "OrderCancelled")
+	require.NoError(t, err)
+	require.Equal(t, target, manager.GetBucketShard(bucket))
+
+	newOrderID, err := orderRepo.Create(ctx, order, "OrderCreated")
+	require.NoError(t, err)
+	require.Equal(t, bucket, manager.GetBucketFromID(newOrderID))
+	require.Greater(t, newOrderID, orderID)
+	_, err = sqlc.New(targetPool).GetOrderByID(ctx, newOrderID)
+	require.NoError(t, err)
+	_, err = sqlc.New(sourcePool).GetOrderByID(ctx, newOrderID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// Events of moved orders are written to outbox of target
+	events, err := outboxRepo.List(ctx, models.OutboxShard(target), models.OutboxEventFilter{}, 1000)
+	require.NoError(t, err)
+	var eventTypes []string
+	for _, event := range events {
+		if event.OrderID == orderID {
+			eventTypes = append(eventTypes, event.EventType)
+		}
+	}
+	require.Equal(t, []string{"OrderCancelled"}, eventTypes)
+
+	// Bucket is moved back, so shards are left as other tests expect
+	markEventsSent(t, outboxRepo, models.OutboxShard(target))
+	moved, err = orderRepo.MoveBucket(ctx, bucket, target, source)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, moved, int64(2))
+	_, err = sqlc.New(sourcePool).GetOrderByID(ctx, newOrderID)
+	require.NoError(t, err)
+}
+
+// markEventsSent marks all pending events in outbox of shard as sent.
+func markEventsSent(t *testing.T, outboxRepo *outboxRepository.OutboxRepository, shard models.OutboxShard) {
+	ctx := context.Background()
+	for {
+		var fetched int
+		err := outboxRepo.WithShardTx(ctx, shard, func(ctx context.Context, tx pgx.Tx) error {
+			events, err := outboxRepo.FetchBatch(ctx, tx, 1000)
+			if err != nil {
+				return err
+			}
+			fetched = len(events)
+
+			eventIDs := make([]int64, len(events))
+			for i, event := range events {
+				eventIDs[i] = event.ID
+			}
+			return outboxRepo.MarkAsSent(ctx, tx, eventIDs)
+		})
+		require.NoError(t, err)
+		if fetched == 0 {
+			return
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"route256/loms/tests/integration/repository/migrations"
 	"testing"
 
+	loms_migrations "route256/loms/migrations"
+
 	"route256/loms/internal/pkg/shard_manager"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -53,10 +55,11 @@ func TestMain(m *testing.M) {
 	}
 
 	// Initialize ShardManager
-	shardFn := shard_manager.GetMurmur3ShardFn(len(shardPools))
-	shardManager = shard_manager.NewShardManager(shardFn, shardPools, ShardBucketCount)
+	bucketFn := shard_manager.GetMurmur3BucketFn(ShardBucketCount)
+	buckets := shard_manager.DefaultBuckets(ShardBucketCount, len(shardPools))
+	shardManager = shard_manager.NewShardManager(bucketFn, shardPools, buckets)
 
-	// Initialize goose pools
+	// Initialize goose pools, main database goes first
 	dsns := []string{DSN, ShardDSN1, ShardDSN2}
 	gooseConns := make([]*sql.DB, len(dsns))
 	for i, dsn := range dsns {
@@ -70,11 +73,12 @@ func TestMain(m *testing.M) {
 
 	// Run migrations
 	for i, conn := range gooseConns {
-		if err := runMigrations(conn, "up"); err != nil {
+		target := migrationTarget(i)
+		if err := runMigrations(conn, target, "up"); err != nil {
 			log.Fatalf("Failed to run migrations on shard %d: %v", i, err)
 		}
 		defer func(c *sql.DB, i int) {
-			if err := runMigrations(c, "down"); err != nil {
+			if err := runMigrations(c, target, "down"); err != nil {
 				log.Printf("Failed to rollback migrations on shard %d: %v", i, err)
 			}
 		}(conn, i)
@@ -85,29 +89,29 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
-// runMigrations
-func runMigrations(db *sql.DB, action string) error {
+// runMigrations applies action to database of target, migrations of other targets are skipped
+func runMigrations(db *sql.DB, target loms_migrations.Target, action string) error {
 	// Configure goose
-	goose.SetBaseFS(migrations.EmbedFS)
-
-	// Init version
-	if err := goose.SetDialect("postgres"); err != nil {
-		log.Fatalf("Error setting goose dialect: %v", err)
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.EmbedFS,
+		goose.WithExcludeNames(loms_migrations.Excludes(target)),
+	)
+	if err != nil {
+		log.Fatalf("Error loading migrations: %v", err)
 		return err
 	}
 
 	// Run migration
-	var err error
+	ctx := context.Background()
 	switch action {
 	case "up":
-		err = goose.Up(db, ".")
+		_, err = provider.Up(ctx)
 		if err != nil {
 			log.Fatalf("Error applying migrations up: %v", err)
 			return err
 		}
 		log.Println("Migrations successfully applied up.")
 	case "down":
-		err = goose.DownTo(db, ".", 0)
+		_, err = provider.DownTo(ctx, 0)
 		if err != nil {
 			log.Fatalf("Error rolling back migrations down: %v", err)
 			return err
@@ -119,3 +123,11 @@ func runMigrations(db *sql.DB, action string) error {
 	}
 	return nil
 }
+
+// migrationTarget returns target of goose connection, first connection is main database.
+func migrationTarget(i int) loms_migrations.Target {
+	if i == 0 {
+		return loms_migrations.TargetMain
+	}
+	return loms_migrations.TargetShard
+}
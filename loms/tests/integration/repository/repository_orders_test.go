@@ -3,8 +3,12 @@ package repository_test
 import (
 	"context"
 	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	"route256/loms/internal/pkg/shard_manager"
 	ordersRepository "route256/loms/internal/repository/orders"
+	outboxRepository "route256/loms/internal/repository/outbox"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -22,7 +26,7 @@ func TestCreateOrder(t *testing.T) {
 	}
 	ctx := context.Background()
 	// Run test
-	orderID, err := orderRepo.Create(ctx, order)
+	orderID, err := orderRepo.Create(ctx, order, "OrderCreated")
 	require.NoError(t, err)
 	require.Greater(t, orderID, int64(0))
 }
@@ -40,16 +44,158 @@ func TestGetOrderByID(t *testing.T) {
 	require.Equal(t, order.Items[0].SKU, models.SKU(1))
 }
 
+// Test for circuit of shard is not opened by requests for missing orders.
+func TestGetOrderByID_NotFoundKeepsCircuitClosed(t *testing.T) {
+	orderRepo := ordersRepository.NewOrderRepository(shardManager)
+
+	ctx := context.Background()
+	orderID := models.OID(999999999)
+	shard := shardManager.GetShardIndexFromID(orderID)
+
+	for i := 0; i < 5; i++ {
+		_, err := orderRepo.GetByID(ctx, orderID)
+		require.ErrorIs(t, err, internal_errors.ErrNotFound)
+	}
+	require.Equal(t, shard_manager.CircuitClosed, shardManager.CircuitState(shard))
+}
+
 // Test for SetOrderStatus.
 func TestSetOrderStatus(t *testing.T) {
 	orderRepo := ordersRepository.NewOrderRepository(shardManager)
 
 	ctx := context.Background()
 
-	err := orderRepo.SetStatus(ctx, 1, "paid")
+	err := orderRepo.SetStatus(ctx, 1, "paid", "OrderPayed")
 	require.NoError(t, err)
 
 	order, err := orderRepo.GetByID(ctx, 1)
 	require.NoError(t, err)
 	require.Equal(t, order.Status, models.OrderStatus("paid"))
+	require.NotEmpty(t, order.History)
+	require.Equal(t, order.History[len(order.History)-1].Status, models.OrderStatus("paid"))
+	require.False(t, order.UpdatedAt.Before(order.CreatedAt))
+}
+
+// Test for GetOrders.
+func TestGetOrders(t *testing.T) {
+	orderRepo := ordersRepository.NewOrderRepository(shardManager)
+
+	ctx := context.Background()
+
+	orders, err := orderRepo.GetOrders(ctx, models.OrderFilter{UserID: 1}, 1)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, orders[0].UserID, int64(1))
+}
+
+// Test for CreateWithIdempotencyKey and GetIdempotencyKey.
+func TestCreateWithIdempotencyKey(t *testing.T) {
+	orderRepo := ordersRepository.NewOrderRepository(shardManager)
+
+	order := models.Order{
+		UserID: 1,
+		Status: "new",
+		Items: []models.Item{
+			{SKU: 1, Count: 1},
+		},
+	}
+	key := models.IdempotencyKey{
+		UserID:      1,
+		Key:         "integration-key",
+		RequestHash: "hash",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	ctx := context.Background()
+
+	// Run test
+	orderID, err := orderRepo.CreateWithIdempotencyKey(ctx, order, key, "OrderCreated")
+	require.NoError(t, err)
+
+	stored, err := orderRepo.GetIdempotencyKey(ctx, 1, "integration-key")
+	require.NoError(t, err)
+	require.Equal(t, orderID, stored.OrderID)
+	require.Equal(t, "hash", stored.RequestHash)
+
+	_, err = orderRepo.CreateWithIdempotencyKey(ctx, order, key, "OrderCreated")
+	require.ErrorIs(t, err, internal_errors.ErrIdempotencyKeyExists)
+}
+
+// Test for CompareAndSetStatus.
+func TestCompareAndSetOrderStatus(t *testing.T) {
+	orderRepo := ordersRepository.NewOrderRepository(shardManager)
+
+	ctx := context.Background()
+	orderID, err := orderRepo.Create(ctx, models.Order{
+		UserID: 1,
+		Status: "awaiting payment",
+		Items: []models.Item{
+			{SKU: 1, Count: 1},
+		},
+	}, "OrderCreated")
+	require.NoError(t, err)
+
+	// Run test
+	err = orderRepo.CompareAndSetStatus(ctx, orderID, "awaiting payment", "cancelled", "OrderCancelled")
+	require.NoError(t, err)
+
+	err = orderRepo.CompareAndSetStatus(ctx, orderID, "awaiting payment", "paid", "OrderPayed")
+	require.ErrorIs(t, err, internal_errors.ErrInvalidOrderStatus)
+
+	order, err := orderRepo.GetByID(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, order.Status, models.OrderStatus("cancelled"))
+
+	// Only successful status changes have events in outbox of order shard
+	outboxRepo := outboxRepository.NewOutboxRepository(connTests, shardManager)
+	events, err := outboxRepo.List(ctx, models.OutboxShard(shardManager.GetShardIndexFromID(orderID)), models.OutboxEventFilter{}, 1000)
+	require.NoError(t, err)
+
+	var eventTypes []string
+	for _, event := range events {
+		if event.OrderID == orderID {
+			eventTypes = append(eventTypes, event.EventType)
+		}
+	}
+	require.Equal(t, []string{"OrderCreated", "OrderCancelled"}, eventTypes)
+}
+
+// Test for GetExpiredOrders.
+func TestGetExpiredOrders(t *testing.T) {
+	orderRepo := ordersRepository.NewOrderRepository(shardManager)
+
+	ctx := context.Background()
+	var orderIDs []models.OID
+	for i := 0; i < 2; i++ {
+		orderID, err := orderRepo.Create(ctx, models.Order{
+			UserID: 1,
+			Status: "awaiting payment",
+			Items: []models.Item{
+				{SKU: 1, Count: 1},
+			},
+		}, "OrderCreated")
+		require.NoError(t, err)
+		orderIDs = append(orderIDs, orderID)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// Run test
+	orders, err := orderRepo.GetExpiredOrders(ctx, time.Millisecond, 1000)
+	require.NoError(t, err)
+
+	// Oldest orders come first
+	var expiredIDs []models.OID
+	for i, order := range orders {
+		require.Equal(t, models.OrderStatusAwaitingPayment, order.Status)
+		if i > 0 {
+			require.False(t, order.CreatedAt.Before(orders[i-1].CreatedAt))
+		}
+		expiredIDs = append(expiredIDs, order.OrderID)
+	}
+	require.Subset(t, expiredIDs, orderIDs)
+
+	orders, err = orderRepo.GetExpiredOrders(ctx, time.Hour, 1000)
+	require.NoError(t, err)
+	for _, order := range orders {
+		require.NotContains(t, orderIDs, order.OrderID)
+	}
 }
@@ -0,0 +1,316 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"route256/loms/internal/models"
+	repository "route256/loms/internal/repository/outbox"
+	"route256/loms/internal/repository/sqlc"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// Test for InsertOrderEvent, FetchBatch and MarkAsSent.
+func TestInsertOrderEvent(t *testing.T) {
+	outboxRepo := repository.NewOutboxRepository(connTests, shardManager)
+
+	ctx := context.Background()
+	orderID := models.OID(9101)
+	shard := orderOutboxShard(orderID)
+
+	// Sequence grows for each event of order
+	for i, status := range []models.OrderStatus{models.OrderStatusNew, models.OrderStatusAwaitingPayment} {
+		sequence := insertOrderEvent(t, outboxRepo, models.OrderEvent{
+			OrderID: orderID,
+			Status:  status,
+			Time:    time.Now(),
+		})
+		require.Equal(t, int64(i+1), sequence)
+	}
+
+	// Event is written to outbox on shard of order, not to main database
+	main, err := outboxRepo.List(ctx, models.OutboxShardMain, models.OutboxEventFilter{}, 1000)
+	require.NoError(t, err)
+	for _, event := range main {
+		require.NotEqual(t, orderID, event.OrderID)
+	}
+
+	err = outboxRepo.WithShardTx(ctx, shard, func(ctx context.Context, tx pgx.Tx) error {
+		// Only first event of order is fetched while it is not processed
+		events, err := outboxRepo.FetchBatch(ctx, tx, 1000)
+		require.NoError(t, err)
+
+		var orderEvents []models.OutboxEvent
+		for _, event := range events {
+			if event.OrderID == orderID {
+				orderEvents = append(orderEvents, event)
+			}
+		}
+		require.Len(t, orderEvents, 1)
+		require.Equal(t, int64(1), orderEvents[0].Sequence)
+
+		var payload models.OrderEvent
+		err = json.Unmarshal([]byte(orderEvents[0].Payload), &payload)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), payload.Sequence)
+		require.Equal(t, models.OrderStatusNew, payload.Status)
+
+		// Mark batch as sent
+		eventIDs := make([]int64, 0, len(events))
+		for _, event := range events {
+			eventIDs = append(eventIDs, event.ID)
+		}
+		return outboxRepo.MarkAsSent(ctx, tx, eventIDs)
+	})
+	require.NoError(t, err)
+
+	// Next event of order is fetched after previous one is processed
+	next := findOrderOutboxEvent(t, outboxRepo, shard, orderID)
+	require.NotNil(t, next)
+	require.Equal(t, int64(2), next.Sequence)
+}
+
+// Test for ListenNewEvents.
+func TestListenNewEvents(t *testing.T) {
+	outboxRepo := repository.NewOutboxRepository(connTests, shardManager)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orderID := models.OID(9102)
+
+	wakeup := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- outboxRepo.ListenNewEvents(ctx, orderOutboxShard(orderID), wakeup)
+	}()
+
+	// Insert until listener is subscribed and notified
+	require.Eventually(t, func() bool {
+		insertOrderEvent(t, outboxRepo, models.OrderEvent{OrderID: orderID, Time: time.Now()})
+		select {
+		case <-wakeup:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// Test for MarkAsFailed, List and Reset.
+func TestOutboxEventDeadAndRequeue(t *testing.T) {
+	outboxRepo := repository.NewOutboxRepository(connTests, shardManager)
+
+	ctx := context.Background()
+	orderID := models.OID(9103)
+	shard := orderOutboxShard(orderID)
+
+	for _, status := range []models.OrderStatus{models.OrderStatusNew, models.OrderStatusAwaitingPayment} {
+		insertOrderEvent(t, outboxRepo, models.OrderEvent{OrderID: orderID, Status: status, Time: time.Now()})
+	}
+
+	events, err := outboxRepo.List(ctx, shard, models.OutboxEventFilter{States: []models.OutboxEventState{models.OutboxEventStatePending}}, 1000)
+	require.NoError(t, err)
+
+	var orderEvents []models.OutboxEvent
+	for _, event := range events {
+		if event.OrderID == orderID {
+			orderEvents = append(orderEvents, event)
+		}
+	}
+	require.Len(t, orderEvents, 2)
+	first, second := orderEvents[0], orderEvents[1]
+
+	// Failed attempt with delay hides event from relay
+	err = outboxRepo.WithShardTx(ctx, shard, func(ctx context.Context, tx pgx.Tx) error {
+		return outboxRepo.MarkAsFailed(ctx, tx, first.ID, errors.New("broker down"), time.Now().Add(time.Hour), models.OutboxEventStatePending)
+	})
+	require.NoError(t, err)
+	require.Nil(t, findOrderOutboxEvent(t, outboxRepo, shard, orderID))
+
+	// Dead event does not block next event of order
+	err = outboxRepo.WithShardTx(ctx, shard, func(ctx context.Context, tx pgx.Tx) error {
+		return outboxRepo.MarkAsFailed(ctx, tx, first.ID, errors.New("message too large"), time.Now(), models.OutboxEventStateDead)
+	})
+	require.NoError(t, err)
+	next := findOrderOutboxEvent(t, outboxRepo, shard, orderID)
+	require.NotNil(t, next)
+	require.Equal(t, second.ID, next.ID)
+
+	dead, err := outboxRepo.List(ctx, shard, models.OutboxEventFilter{IDs: []int64{first.ID}}, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, models.OutboxEventStateDead, dead[0].State)
+	require.Equal(t, shard, dead[0].Shard)
+	require.Equal(t, int32(2), dead[0].Attempts)
+	require.Equal(t, "message too large", dead[0].LastError)
+
+	// Requeue dead event
+	count, err := outboxRepo.Reset(ctx, shard, models.OutboxEventFilter{IDs: []int64{first.ID, second.ID}}, []models.OutboxEventState{models.OutboxEventStateDead})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	requeued, err := outboxRepo.List(ctx, shard, models.OutboxEventFilter{IDs: []int64{first.ID}}, 10)
+	require.NoError(t, err)
+	require.Len(t, requeued, 1)
+	require.Equal(t, models.OutboxEventStatePending, requeued[0].State)
+	require.Equal(t, int32(0), requeued[0].Attempts)
+}
+
+// Test for DeleteProcessed.
+func TestDeleteProcessedOutboxEvents(t *testing.T) {
+	outboxRepo := repository.NewOutboxRepository(connTests, shardManager)
+
+	ctx := context.Background()
+	orderID := models.OID(9104)
+	shard := orderOutboxShard(orderID)
+
+	insertOrderEvent(t, outboxRepo, models.OrderEvent{OrderID: orderID, Time: time.Now()})
+
+	events, err := outboxRepo.List(ctx, shard, models.OutboxEventFilter{States: []models.OutboxEventState{models.OutboxEventStatePending}}, 1000)
+	require.NoError(t, err)
+
+	var eventID int64
+	for _, event := range events {
+		if event.OrderID == orderID {
+			eventID = event.ID
+		}
+	}
+	require.NotZero(t, eventID)
+
+	err = outboxRepo.WithShardTx(ctx, shard, func(ctx context.Context, tx pgx.Tx) error {
+		return outboxRepo.MarkAsSent(ctx, tx, []int64{eventID})
+	})
+	require.NoError(t, err)
+
+	// Recently processed event is kept
+	_, err = outboxRepo.DeleteProcessed(ctx, shard, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	kept, err := outboxRepo.List(ctx, shard, models.OutboxEventFilter{IDs: []int64{eventID}}, 10)
+	require.NoError(t, err)
+	require.Len(t, kept, 1)
+
+	deleted, err := outboxRepo.DeleteProcessed(ctx, shard, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+	removed, err := outboxRepo.List(ctx, shard, models.OutboxEventFilter{IDs: []int64{eventID}}, 10)
+	require.NoError(t, err)
+	require.Empty(t, removed)
+}
+
+// Test for MoveMainOrderEvents.
+func TestMoveMainOrderEvents(t *testing.T) {
+	outboxRepo := repository.NewOutboxRepository(connTests, shardManager)
+
+	ctx := context.Background()
+	orderID := models.OID(9105)
+	shard := orderOutboxShard(orderID)
+
+	// Events of order written to main database by previous version of service, first one is sent
+	for _, status := range []models.OrderStatus{models.OrderStatusNew, models.OrderStatusAwaitingPayment} {
+		_, err := repository.InsertOrderEvent(ctx, sqlc.New(connTests), "OrderEvent", models.OrderEvent{
+			OrderID: orderID,
+			Status:  status,
+			Time:    time.Now(),
+		})
+		require.NoError(t, err)
+	}
+	first := findMainOrderEvents(t, outboxRepo, orderID)
+	require.Len(t, first, 2)
+	err := outboxRepo.WithShardTx(ctx, models.OutboxShardMain, func(ctx context.Context, tx pgx.Tx) error {
+		return outboxRepo.MarkAsSent(ctx, tx, []int64{first[0].ID})
+	})
+	require.NoError(t, err)
+
+	for {
+		moved, err := outboxRepo.MoveMainOrderEvents(ctx, 10)
+		require.NoError(t, err)
+		if moved == 0 {
+			break
+		}
+	}
+
+	// Undelivered event is moved to shard of order, sent event stays in main database
+	left := findMainOrderEvents(t, outboxRepo, orderID)
+	require.Len(t, left, 1)
+	require.Equal(t, models.OutboxEventStateProcessed, left[0].State)
+
+	next := findOrderOutboxEvent(t, outboxRepo, shard, orderID)
+	require.NotNil(t, next)
+	require.Equal(t, int64(2), next.Sequence)
+	require.Equal(t, first[1].Payload, next.Payload)
+
+	// Sequence of order continues on shard
+	sequence := insertOrderEvent(t, outboxRepo, models.OrderEvent{OrderID: orderID, Time: time.Now()})
+	require.Equal(t, int64(3), sequence)
+}
+
+// insertOrderEvent writes event to outbox on shard of order and returns sequence number of event.
+func insertOrderEvent(t *testing.T, outboxRepo *repository.OutboxRepository, event models.OrderEvent) int64 {
+	t.Helper()
+
+	var sequence int64
+	err := outboxRepo.WithShardTx(context.Background(), orderOutboxShard(event.OrderID), func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		sequence, err = repository.InsertOrderEvent(ctx, sqlc.New(tx), "OrderEvent", event)
+		return err
+	})
+	require.NoError(t, err)
+
+	return sequence
+}
+
+// orderOutboxShard returns outbox shard holding events of order.
+func orderOutboxShard(orderID models.OID) models.OutboxShard {
+	return models.OutboxShard(shardManager.GetShardIndexFromID(orderID))
+}
+
+// findOrderOutboxEvent returns event of order fetched by relay from outbox shard, nil if there is none.
+func findOrderOutboxEvent(t *testing.T, outboxRepo *repository.OutboxRepository, shard models.OutboxShard, orderID models.OID) *models.OutboxEvent {
+	t.Helper()
+
+	var found *models.OutboxEvent
+	err := outboxRepo.WithShardTx(context.Background(), shard, func(ctx context.Context, tx pgx.Tx) error {
+		events, err := outboxRepo.FetchBatch(ctx, tx, 1000)
+		if err != nil {
+			return err
+		}
+		for i := range events {
+			if events[i].OrderID == orderID {
+				found = &events[i]
+			}
+		}
+		// Locks are released without changes
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	return found
+}
+
+// findMainOrderEvents returns events of order in outbox of main database ordered by ID.
+func findMainOrderEvents(t *testing.T, outboxRepo *repository.OutboxRepository, orderID models.OID) []models.OutboxEvent {
+	t.Helper()
+
+	events, err := outboxRepo.List(context.Background(), models.OutboxShardMain, models.OutboxEventFilter{}, 10000)
+	require.NoError(t, err)
+
+	var found []models.OutboxEvent
+	for _, event := range events {
+		if event.OrderID == orderID {
+			found = append(found, event)
+		}
+	}
+
+	return found
+}
+
+// errRollback rolls back transaction of test.
+var errRollback = errors.New("rollback")
@@ -0,0 +1,79 @@
+package repository_test
+
+import (
+	"context"
+	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
+	repository "route256/loms/internal/repository/sagas"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test for Create, SetState and GetStarted.
+func TestSagaLifecycle(t *testing.T) {
+	sagaRepo := repository.NewSagaRepository(connTests)
+
+	saga := models.Saga{
+		OrderID:    9001,
+		Type:       models.SagaTypePay,
+		FromStatus: models.OrderStatusAwaitingPayment,
+		ToStatus:   models.OrderStatusPayed,
+		EventType:  "OrderPayed",
+		Items:      []models.Item{{SKU: 1, Count: 2}},
+	}
+
+	ctx := context.Background()
+
+	// Run tx
+	tx, err := connTests.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	// Create
+	sagaID, err := sagaRepo.Create(ctx, tx, saga)
+	require.NoError(t, err)
+	require.Greater(t, sagaID, int64(0))
+
+	// Only one started saga per order
+	_, err = sagaRepo.Create(ctx, tx, saga)
+	require.ErrorIs(t, err, internal_errors.ErrSagaInProgress)
+
+	// Commit
+	err = tx.Commit(ctx)
+	require.NoError(t, err)
+
+	// Record attempt
+	err = sagaRepo.RecordAttempt(ctx, sagaID, internal_errors.ErrInternalServerError)
+	require.NoError(t, err)
+
+	// Started saga is returned for recovery
+	sagas, err := sagaRepo.GetStarted(ctx, time.Now().Add(time.Minute), 100)
+	require.NoError(t, err)
+	var started *models.Saga
+	for i := range sagas {
+		if sagas[i].ID == sagaID {
+			started = &sagas[i]
+		}
+	}
+	require.NotNil(t, started)
+	require.Equal(t, saga.Items, started.Items)
+	require.Equal(t, models.SagaStateStarted, started.State)
+	require.Equal(t, int32(1), started.Attempts)
+
+	// Finish saga
+	tx, err = connTests.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	err = sagaRepo.SetState(ctx, tx, sagaID, models.SagaStateCompleted)
+	require.NoError(t, err)
+
+	// Saga is finished only once
+	err = sagaRepo.SetState(ctx, tx, sagaID, models.SagaStateCompensated)
+	require.ErrorIs(t, err, internal_errors.ErrSagaFinished)
+
+	err = tx.Commit(ctx)
+	require.NoError(t, err)
+}
@@ -2,8 +2,12 @@ package repository_test
 
 import (
 	"context"
+	"errors"
 	"route256/loms/internal/models"
+	internal_errors "route256/loms/internal/pkg/errors"
 	repository "route256/loms/internal/repository/stocks"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -44,6 +48,115 @@ func TestReserveItems(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test ReserveItems reserves nothing if any item has not enough stock.
+func TestReserveItems_NotEnoughStock(t *testing.T) {
+	stockRepo := repository.NewStockRepository(connTests)
+
+	ctx := context.Background()
+
+	// Run tx
+	tx, err := connTests.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	_, err = stockRepo.SetStockTotalCount(ctx, tx, models.SKU(995), 10)
+	require.NoError(t, err)
+	_, err = stockRepo.SetStockTotalCount(ctx, tx, models.SKU(996), 10)
+	require.NoError(t, err)
+
+	// Duplicate SKUs are merged
+	err = stockRepo.ReserveItems(ctx, tx, []models.Item{{SKU: 995, Count: 5}, {SKU: 996, Count: 6}, {SKU: 996, Count: 5}})
+	require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+
+	// Unknown SKU
+	err = stockRepo.ReserveItems(ctx, tx, []models.Item{{SKU: 995, Count: 5}, {SKU: 994, Count: 1}})
+	require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+
+	stocks, err := stockRepo.GetStocksForUpdate(ctx, tx, []models.SKU{995, 996})
+	require.NoError(t, err)
+	require.Equal(t, []models.Stock{{SKU: 995, TotalCount: 10}, {SKU: 996, TotalCount: 10}}, stocks)
+
+	err = stockRepo.ReserveItems(ctx, tx, []models.Item{{SKU: 996, Count: 5}, {SKU: 995, Count: 5}, {SKU: 996, Count: 5}})
+	require.NoError(t, err)
+
+	stocks, err = stockRepo.GetStocksForUpdate(ctx, tx, []models.SKU{995, 996})
+	require.NoError(t, err)
+	require.Equal(t, []models.Stock{{SKU: 995, TotalCount: 10, Reserved: 5}, {SKU: 996, TotalCount: 10, Reserved: 10}}, stocks)
+}
+
+// Test concurrent ReserveItems never reserves more than total count and does not deadlock.
+func TestReserveItems_Concurrent(t *testing.T) {
+	const (
+		workers    = 50
+		totalCount = 30
+	)
+
+	stockRepo := repository.NewStockRepository(connTests)
+
+	ctx := context.Background()
+
+	skus := []models.SKU{993, 992}
+	for _, SKU := range skus {
+		_, err := stockRepo.SetStockTotalCount(ctx, nil, SKU, totalCount)
+		require.NoError(t, err)
+	}
+	t.Cleanup(func() {
+		_, _ = connTests.Exec(ctx, "DELETE FROM stocks WHERE sku = ANY($1)", []int32{993, 992})
+	})
+
+	var (
+		wg       sync.WaitGroup
+		reserved atomic.Int64
+	)
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Half of orders list SKUs in reverse order
+			items := []models.Item{{SKU: skus[i%2], Count: 1}, {SKU: skus[(i+1)%2], Count: 1}}
+
+			tx, err := connTests.Begin(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer tx.Rollback(ctx)
+
+			err = stockRepo.ReserveItems(ctx, tx, items)
+			if errors.Is(err, internal_errors.ErrPreconditionFailed) {
+				return
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			if err := tx.Commit(ctx); err != nil {
+				errs <- err
+				return
+			}
+			reserved.Add(1)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	stocks, err := stockRepo.GetStocksBySKUs(ctx, skus)
+	require.NoError(t, err)
+	require.Len(t, stocks, len(skus))
+	for _, stock := range stocks {
+		require.LessOrEqual(t, stock.Reserved, stock.TotalCount)
+		require.Equal(t, uint64(reserved.Load()), stock.Reserved)
+	}
+	require.Equal(t, int64(totalCount), reserved.Load())
+}
+
 // Test for RemoveReservedItems.
 func TestRemoveReservedItems(t *testing.T) {
 	stockRepo := repository.NewStockRepository(connTests)
@@ -91,3 +204,78 @@ func TestCancelReservedItems(t *testing.T) {
 	err = tx.Commit(ctx)
 	require.NoError(t, err)
 }
+
+// Test for ReplenishStock, SetStockTotalCount and AdjustStockTotalCount.
+func TestStockChange(t *testing.T) {
+	stockRepo := repository.NewStockRepository(connTests)
+
+	ctx := context.Background()
+
+	// Run tx
+	tx, err := connTests.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	stock, err := stockRepo.SetStockTotalCount(ctx, tx, models.SKU(999), 10)
+	require.NoError(t, err)
+	require.Equal(t, models.Stock{SKU: 999, TotalCount: 10}, stock)
+
+	stock, err = stockRepo.ReplenishStock(ctx, tx, models.SKU(999), 5)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), stock.TotalCount)
+
+	stock, err = stockRepo.AdjustStockTotalCount(ctx, tx, models.SKU(999), -3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), stock.TotalCount)
+
+	_, err = stockRepo.AdjustStockTotalCount(ctx, tx, models.SKU(999), -13)
+	require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+}
+
+// Test for ImportStock and GetStocksForUpdate.
+func TestImportStock(t *testing.T) {
+	stockRepo := repository.NewStockRepository(connTests)
+
+	ctx := context.Background()
+
+	// Run tx
+	tx, err := connTests.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	// Imported stock is never reserved
+	_, err = stockRepo.ImportStock(ctx, tx, models.Stock{SKU: 998, TotalCount: 10, Reserved: 4})
+	require.ErrorIs(t, err, internal_errors.ErrBadRequest)
+
+	stock, err := stockRepo.ImportStock(ctx, tx, models.Stock{SKU: 998, TotalCount: 10})
+	require.NoError(t, err)
+	require.Equal(t, models.Stock{SKU: 998, TotalCount: 10}, stock)
+
+	err = stockRepo.ReserveItems(ctx, tx, []models.Item{{SKU: 998, Count: 4}})
+	require.NoError(t, err)
+
+	// Reserved of existing stock is preserved
+	stock, err = stockRepo.ImportStock(ctx, tx, models.Stock{SKU: 998, TotalCount: 20})
+	require.NoError(t, err)
+	require.Equal(t, models.Stock{SKU: 998, TotalCount: 20, Reserved: 4}, stock)
+
+	_, err = stockRepo.ImportStock(ctx, tx, models.Stock{SKU: 998, TotalCount: 3})
+	require.ErrorIs(t, err, internal_errors.ErrPreconditionFailed)
+
+	stocks, err := stockRepo.GetStocksForUpdate(ctx, tx, []models.SKU{998, 997})
+	require.NoError(t, err)
+	require.Equal(t, []models.Stock{{SKU: 998, TotalCount: 20, Reserved: 4}}, stocks)
+}
+
+// Test for GetStocksBySKUs.
+func TestGetStocksBySKUs(t *testing.T) {
+	stockRepo := repository.NewStockRepository(connTests)
+
+	ctx := context.Background()
+
+	stocks, err := stockRepo.GetStocksBySKUs(ctx, []models.SKU{1076963, 1148162, 999999})
+	require.NoError(t, err)
+	require.Len(t, stocks, 2)
+	require.Equal(t, models.SKU(1076963), stocks[0].SKU)
+	require.Equal(t, models.SKU(1148162), stocks[1].SKU)
+}
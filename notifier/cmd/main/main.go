@@ -9,6 +9,7 @@ import (
 	"route256/notifier/internal/config"
 	"route256/notifier/internal/consumer"
 	service "route256/notifier/internal/service/notifier"
+	"route256/utils/tracer"
 	"syscall"
 
 	"log"
@@ -42,6 +43,17 @@ func main() {
 	// Cfg
 	log.Printf("Cfg: %v", cfg)
 
+	// Create context with cancel
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Tracer
+	tp, err := tracer.InitTracer(ctx, cfg.Project.GetName(), cfg.Jaeger.GetURI())
+	if err != nil {
+		log.Printf("Failed init tracer, err: %s", err)
+		os.Exit(1)
+	}
+
 	// Service
 	notifierService := service.NewNotifierService()
 
@@ -55,10 +67,6 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Create context with cancel
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	// Run consumer
 	go func() {
 		if err := kafkaConsumer.Start(ctx); err != nil {
@@ -77,4 +85,9 @@ func main() {
 	cancel()
 
 	kafkaConsumer.Wait()
+
+	// Flush spans of processed messages
+	if err := tp.Shutdown(context.Background()); err != nil {
+		log.Printf("Failed shutdown tracer, err: %s", err)
+	}
 }
@@ -1,15 +1,15 @@
 package handler
 
 import (
-	"encoding/json"
-
-	"route256/notifier/internal/models"
+	"context"
+	"route256/utils/events"
+	pb "route256/utils/events/pkg/api/events/v1"
 
 	"github.com/IBM/sarama"
 )
 
 type INotifierService interface {
-	ProcessOrderEvent(event *models.OrderEvent) error
+	ProcessOrderEvent(ctx context.Context, event *pb.OrderEvent) error
 }
 
 type MessageHandler struct {
@@ -23,16 +23,27 @@ func NewMessageHandler(service INotifierService) *MessageHandler {
 	}
 }
 
-// HandleMessage processes the incoming Kafka message.
-func (h *MessageHandler) HandleMessage(msg *sarama.ConsumerMessage) error {
-	var event models.OrderEvent
-	if err := json.Unmarshal(msg.Value, &event); err != nil {
+// HandleMessage processes the incoming Kafka message, both CloudEvents and legacy messages are accepted.
+func (h *MessageHandler) HandleMessage(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	event, err := events.DecodeOrderEvent(headers(msg), msg.Value)
+	if err != nil {
 		return err
 	}
 
-	if err := h.notifierService.ProcessOrderEvent(&event); err != nil {
+	if err := h.notifierService.ProcessOrderEvent(ctx, event); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// headers returns headers of message by key, legacy messages have no headers.
+func headers(msg *sarama.ConsumerMessage) map[string]string {
+	res := make(map[string]string, len(msg.Headers))
+	for _, header := range msg.Headers {
+		if header != nil {
+			res[string(header.Key)] = string(header.Value)
+		}
+	}
+	return res
+}
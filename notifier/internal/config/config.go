@@ -60,10 +60,20 @@ func (k *Kafka) GetTopics() []string {
 	return k.Topics
 }
 
+// Jaeger - contains parameters for jaeger.
+type Jaeger struct {
+	URI string `yaml:"uri" mapstructure:"uri"`
+}
+
+func (j *Jaeger) GetURI() string {
+	return j.URI
+}
+
 // Config - contains all configuration parameters in config package
 type Config struct {
 	Project Project `yaml:"project" mapstructure:"project"`
 	Kafka   Kafka   `yaml:"kafka" mapstructure:"kafka"`
+	Jaeger  Jaeger  `yaml:"jaeger" mapstructure:"jaeger"`
 }
 
 func NewConfig() *Config {
@@ -114,6 +124,8 @@ func setDefaultValues() {
 	viper.SetDefault("kafka.brokers", "localhost:9092")
 	viper.SetDefault("kafka.consumerGroup", "notifier_group")
 	viper.SetDefault("kafka.topics", "loms.order-events")
+	// Jaeger
+	viper.SetDefault("jaeger.uri", "localhost:4318")
 }
 
 // bindEnvVariables function for bind env variables with config name.
@@ -127,6 +139,8 @@ func (c *Config) bindEnvVariables() error {
 		"kafka.brokers":       "KAFKA_BROKERS",
 		"kafka.consumerGroup": "KAFKA_CONSUMER_GROUP",
 		"kafka.topics":        "KAFKA_TOPICS",
+		// Jaeger
+		"jaeger.uri": "JAEGER_URI",
 	}
 
 	for key, env := range envVars {
@@ -3,10 +3,14 @@ package consumer
 import (
 	"context"
 	"log"
+	"route256/utils/tracer"
 	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -24,7 +28,7 @@ type IKafkaCfg interface {
 }
 
 type IHandlerMessage interface {
-	HandleMessage(msg *sarama.ConsumerMessage) error
+	HandleMessage(ctx context.Context, msg *sarama.ConsumerMessage) error
 }
 
 type KafkaConsumer struct {
@@ -137,7 +141,7 @@ func (kc *KafkaConsumer) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sa
 			if !ok {
 				return nil
 			}
-			if err := kc.handler.HandleMessage(message); err != nil {
+			if err := kc.handleMessage(sess.Context(), message); err != nil {
 				log.Printf("Error handling message: %v", err)
 				continue
 			}
@@ -147,3 +151,29 @@ func (kc *KafkaConsumer) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sa
 		}
 	}
 }
+
+// handleMessage handles message in consumer span, which continues trace of producer from message headers.
+func (kc *KafkaConsumer) handleMessage(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	ctx = tracer.ContextWithTraceParent(ctx, traceParent(msg))
+	ctx, span := otel.Tracer("KafkaConsumer").Start(ctx, "HandleMessage",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	)
+	defer span.End()
+
+	err := kc.handler.HandleMessage(ctx, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// traceParent returns W3C traceparent header of message, messages sent without trace have no header.
+func traceParent(msg *sarama.ConsumerMessage) string {
+	for _, header := range msg.Headers {
+		if header != nil && string(header.Key) == tracer.HeaderTraceParent {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
@@ -1,7 +1,5 @@
 package models
 
-import "time"
-
 // User ID.
 type UID = int64
 
@@ -15,11 +13,3 @@ const (
 	OrderStatusPayed           OrderStatus = "paid"
 	OrderStatusCancelled       OrderStatus = "cancelled"
 )
-
-// OrderEvent represents kafka message.
-type OrderEvent struct {
-	OrderID    UID         `json:"order_id"`
-	Status     OrderStatus `json:"status"`
-	Time       time.Time   `json:"time"`
-	Additional string      `json:"additional"`
-}
@@ -1,20 +1,62 @@
 package service
 
 import (
+	"context"
 	"log"
 	"route256/notifier/internal/models"
+	"sync"
+
+	pb "route256/utils/events/pkg/api/events/v1"
+
+	"go.opentelemetry.io/otel"
 )
 
 type NotifierService struct {
+	mu            sync.Mutex
+	lastSequences map[models.UID]int64
 }
 
 // NewNotifierService create new instance of NotifierService.
 func NewNotifierService() *NotifierService {
-	return &NotifierService{}
+	return &NotifierService{
+		lastSequences: make(map[models.UID]int64),
+	}
 }
 
 // ProcessOrderEvent function for processing OrderEvent.
-func (s *NotifierService) ProcessOrderEvent(event *models.OrderEvent) error {
+func (s *NotifierService) ProcessOrderEvent(ctx context.Context, event *pb.OrderEvent) error {
+	// Tracer
+	_, span := otel.Tracer("NotifierService").Start(ctx, "ProcessOrderEvent")
+	defer span.End()
+
+	s.checkSequence(event)
 	log.Printf("Order event: %v", event)
 	return nil
 }
+
+// checkSequence logs gaps and reordering of order events, events without sequence are not checked.
+func (s *NotifierService) checkSequence(event *pb.OrderEvent) {
+	if event.Sequence == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, ok := s.lastSequences[event.OrderId]
+	switch {
+	case ok && event.Sequence <= last:
+		log.Printf("Order %d event %d is duplicated or reordered, last processed event %d", event.OrderId, event.Sequence, last)
+		return
+	case ok && event.Sequence > last+1:
+		log.Printf("Order %d events %d-%d are missing", event.OrderId, last+1, event.Sequence-1)
+	}
+
+	// No more events are expected for order in final status
+	switch models.OrderStatus(event.NewStatus) {
+	case models.OrderStatusPayed, models.OrderStatusCancelled, models.OrderStatusFailed:
+		delete(s.lastSequences, event.OrderId)
+	default:
+		s.lastSequences[event.OrderId] = event.Sequence
+	}
+}
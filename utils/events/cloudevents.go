@@ -0,0 +1,91 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Headers of CloudEvents 1.0 Kafka protocol binding in binary content mode,
+// data content type is passed in HeaderContentType.
+const (
+	HeaderCloudEventsSpecVersion = "ce_specversion"
+	HeaderCloudEventsID          = "ce_id"
+	HeaderCloudEventsSource      = "ce_source"
+	HeaderCloudEventsType        = "ce_type"
+	HeaderCloudEventsTime        = "ce_time"
+	HeaderCloudEventsSubject     = "ce_subject"
+)
+
+// CloudEventsSpecVersion is supported version of CloudEvents specification.
+const CloudEventsSpecVersion = "1.0"
+
+// CloudEventTypePrefix prefixes names of event types in CloudEvents type attribute.
+const CloudEventTypePrefix = "route256.loms."
+
+// CloudEvent holds CloudEvents context attributes of message.
+type CloudEvent struct {
+	ID      string
+	Source  string
+	Type    string
+	Time    time.Time
+	Subject string
+}
+
+// CloudEventType returns CloudEvents type attribute for event type name.
+func CloudEventType(name string) string {
+	return CloudEventTypePrefix + name
+}
+
+// Headers returns Kafka headers of event in binary content mode, empty optional attributes are omitted.
+func (e CloudEvent) Headers() map[string]string {
+	headers := map[string]string{
+		HeaderCloudEventsSpecVersion: CloudEventsSpecVersion,
+		HeaderCloudEventsID:          e.ID,
+		HeaderCloudEventsSource:      e.Source,
+		HeaderCloudEventsType:        e.Type,
+	}
+	if !e.Time.IsZero() {
+		headers[HeaderCloudEventsTime] = e.Time.UTC().Format(time.RFC3339Nano)
+	}
+	if e.Subject != "" {
+		headers[HeaderCloudEventsSubject] = e.Subject
+	}
+	return headers
+}
+
+// ParseCloudEvent reads CloudEvents attributes from Kafka headers, ok is false when message
+// is not CloudEvent. Unsupported spec version and missing required attributes are errors.
+func ParseCloudEvent(headers map[string]string) (event CloudEvent, ok bool, err error) {
+	specVersion, ok := headers[HeaderCloudEventsSpecVersion]
+	if !ok {
+		return CloudEvent{}, false, nil
+	}
+	if specVersion != CloudEventsSpecVersion {
+		return CloudEvent{}, true, fmt.Errorf("unsupported CloudEvents spec version %q", specVersion)
+	}
+
+	event = CloudEvent{
+		ID:      headers[HeaderCloudEventsID],
+		Source:  headers[HeaderCloudEventsSource],
+		Type:    headers[HeaderCloudEventsType],
+		Subject: headers[HeaderCloudEventsSubject],
+	}
+	if event.ID == "" || event.Source == "" || event.Type == "" {
+		return CloudEvent{}, true, fmt.Errorf("CloudEvent must have id, source and type")
+	}
+
+	if value, found := headers[HeaderCloudEventsTime]; found {
+		event.Time, err = time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return CloudEvent{}, true, fmt.Errorf("invalid CloudEvent time %q: %w", value, err)
+		}
+	}
+
+	return event, true, nil
+}
+
+// eventTypeName returns event type name from CloudEvents type attribute.
+func (e CloudEvent) eventTypeName() string {
+	return strings.TrimPrefix(e.Type, CloudEventTypePrefix)
+}
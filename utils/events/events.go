@@ -0,0 +1,113 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	pb "route256/utils/events/pkg/api/events/v1"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// HeaderContentType is Kafka header with format of message value.
+const HeaderContentType = "content-type"
+
+// Formats of message value.
+const (
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeJSON     = "application/json"
+)
+
+// OrderEventSchemaVersion is version of OrderEvent schema written by producers.
+const OrderEventSchemaVersion uint32 = 1
+
+// Names of order event types used in outbox and legacy JSON events.
+var orderEventTypeNames = map[string]pb.OrderEventType{
+	"OrderCreated":         pb.OrderEventType_ORDER_EVENT_TYPE_CREATED,
+	"OrderAwaitingPayment": pb.OrderEventType_ORDER_EVENT_TYPE_AWAITING_PAYMENT,
+	"OrderFailed":          pb.OrderEventType_ORDER_EVENT_TYPE_FAILED,
+	"OrderPayed":           pb.OrderEventType_ORDER_EVENT_TYPE_PAYED,
+	"OrderCancelled":       pb.OrderEventType_ORDER_EVENT_TYPE_CANCELLED,
+	"OrderExpired":         pb.OrderEventType_ORDER_EVENT_TYPE_EXPIRED,
+}
+
+// legacyOrderEvent is order event sent as JSON before protobuf schema, it is accepted during migration.
+type legacyOrderEvent struct {
+	OrderID    int64     `json:"order_id"`
+	Sequence   int64     `json:"sequence"`
+	Status     string    `json:"status"`
+	Time       time.Time `json:"time"`
+	Additional string    `json:"additional"`
+}
+
+// OrderEventTypeFromName returns event type by its name, unknown name gives unspecified type.
+func OrderEventTypeFromName(name string) pb.OrderEventType {
+	return orderEventTypeNames[name]
+}
+
+// MarshalOrderEvent encodes order event with protobuf, returns value and its content type.
+func MarshalOrderEvent(event *pb.OrderEvent) ([]byte, string, error) {
+	value, err := proto.Marshal(event)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal order event: %w", err)
+	}
+	return value, ContentTypeProtobuf, nil
+}
+
+// DecodeOrderEvent decodes order event from Kafka message headers and value. CloudEvent in binary
+// content mode and legacy message are accepted, attributes of CloudEvent fill fields missing in data.
+func DecodeOrderEvent(headers map[string]string, value []byte) (*pb.OrderEvent, error) {
+	cloudEvent, ok, err := ParseCloudEvent(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := UnmarshalOrderEvent(headers[HeaderContentType], value)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return event, nil
+	}
+
+	if event.EventId == "" {
+		event.EventId = cloudEvent.ID
+	}
+	if event.EventType == pb.OrderEventType_ORDER_EVENT_TYPE_UNSPECIFIED {
+		event.EventType = OrderEventTypeFromName(cloudEvent.eventTypeName())
+	}
+	if (event.Time == nil || event.Time.AsTime().IsZero()) && !cloudEvent.Time.IsZero() {
+		event.Time = timestamppb.New(cloudEvent.Time)
+	}
+
+	return event, nil
+}
+
+// UnmarshalOrderEvent decodes order event by content type of message. Message without content type
+// is legacy JSON event, it is converted to schema with zero version.
+func UnmarshalOrderEvent(contentType string, value []byte) (*pb.OrderEvent, error) {
+	switch contentType {
+	case ContentTypeProtobuf:
+		event := &pb.OrderEvent{}
+		if err := proto.Unmarshal(value, event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal order event: %w", err)
+		}
+		return event, nil
+	case ContentTypeJSON, "":
+		var legacy legacyOrderEvent
+		if err := json.Unmarshal(value, &legacy); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal legacy order event: %w", err)
+		}
+		return &pb.OrderEvent{
+			EventType: OrderEventTypeFromName(legacy.Additional),
+			OrderId:   legacy.OrderID,
+			NewStatus: legacy.Status,
+			Sequence:  legacy.Sequence,
+			Time:      timestamppb.New(legacy.Time),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content type of order event %q", contentType)
+	}
+}
@@ -0,0 +1,178 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"route256/utils/events"
+	pb "route256/utils/events/pkg/api/events/v1"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Test for MarshalOrderEvent and UnmarshalOrderEvent.
+func TestOrderEventCodec(t *testing.T) {
+	eventTime := time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	event := &pb.OrderEvent{
+		SchemaVersion: events.OrderEventSchemaVersion,
+		EventId:       "5f0c7d1e-1c1a-4b8e-9a59-8c3b9d7f1a2b",
+		EventType:     pb.OrderEventType_ORDER_EVENT_TYPE_PAYED,
+		OrderId:       1001,
+		UserId:        1,
+		Items:         []*pb.OrderItem{{Sku: 1005, Count: 5}},
+		OldStatus:     "awaiting payment",
+		NewStatus:     "paid",
+		Sequence:      3,
+		Time:          timestamppb.New(eventTime),
+	}
+
+	value, contentType, err := events.MarshalOrderEvent(event)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if contentType != events.ContentTypeProtobuf {
+		t.Fatalf("content type = %q, want %q", contentType, events.ContentTypeProtobuf)
+	}
+
+	decoded, err := events.UnmarshalOrderEvent(contentType, value)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !proto.Equal(event, decoded) {
+		t.Fatalf("decoded event = %v, want %v", decoded, event)
+	}
+}
+
+// Test for UnmarshalOrderEvent of legacy JSON event.
+func TestUnmarshalLegacyOrderEvent(t *testing.T) {
+	value := []byte(`{"order_id":1001,"sequence":2,"status":"cancelled","time":"2024-11-01T12:00:00Z","additional":"OrderCancelled"}`)
+
+	for _, contentType := range []string{"", events.ContentTypeJSON} {
+		event, err := events.UnmarshalOrderEvent(contentType, value)
+		if err != nil {
+			t.Fatalf("unmarshal with content type %q: %v", contentType, err)
+		}
+
+		want := &pb.OrderEvent{
+			EventType: pb.OrderEventType_ORDER_EVENT_TYPE_CANCELLED,
+			OrderId:   1001,
+			NewStatus: "cancelled",
+			Sequence:  2,
+			Time:      timestamppb.New(time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC)),
+		}
+		if !proto.Equal(want, event) {
+			t.Fatalf("event = %v, want %v", event, want)
+		}
+	}
+
+	if _, err := events.UnmarshalOrderEvent("text/plain", value); err == nil {
+		t.Fatal("unsupported content type must fail")
+	}
+}
+
+// Test for DecodeOrderEvent of CloudEvents and legacy messages.
+func TestDecodeOrderEvent(t *testing.T) {
+	eventTime := time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	value, contentType, err := events.MarshalOrderEvent(&pb.OrderEvent{
+		SchemaVersion: events.OrderEventSchemaVersion,
+		EventId:       "e1",
+		EventType:     pb.OrderEventType_ORDER_EVENT_TYPE_CREATED,
+		OrderId:       1001,
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	legacy := []byte(`{"order_id":1001,"sequence":1,"status":"new"}`)
+
+	cloudEvent := events.CloudEvent{
+		ID:      "e2",
+		Source:  "/loms",
+		Type:    events.CloudEventType("OrderCancelled"),
+		Time:    eventTime,
+		Subject: "1001",
+	}
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		value    []byte
+		expected *pb.OrderEvent
+		wantErr  bool
+	}{
+		{
+			name:    "CloudEvent with protobuf data",
+			headers: withContentType(cloudEvent.Headers(), contentType),
+			value:   value,
+			expected: &pb.OrderEvent{
+				SchemaVersion: events.OrderEventSchemaVersion,
+				EventId:       "e1",
+				EventType:     pb.OrderEventType_ORDER_EVENT_TYPE_CREATED,
+				OrderId:       1001,
+				Time:          timestamppb.New(eventTime),
+			},
+		},
+		{
+			name:    "CloudEvent attributes fill legacy data",
+			headers: withContentType(cloudEvent.Headers(), events.ContentTypeJSON),
+			value:   legacy,
+			expected: &pb.OrderEvent{
+				EventId:   "e2",
+				EventType: pb.OrderEventType_ORDER_EVENT_TYPE_CANCELLED,
+				OrderId:   1001,
+				NewStatus: "new",
+				Sequence:  1,
+				Time:      timestamppb.New(eventTime),
+			},
+		},
+		{
+			name:    "legacy message without headers",
+			headers: map[string]string{},
+			value:   legacy,
+			expected: &pb.OrderEvent{
+				OrderId:   1001,
+				NewStatus: "new",
+				Sequence:  1,
+				Time:      timestamppb.New(time.Time{}),
+			},
+		},
+		{
+			name:    "unsupported spec version",
+			headers: map[string]string{events.HeaderCloudEventsSpecVersion: "0.3"},
+			value:   legacy,
+			wantErr: true,
+		},
+		{
+			name:    "CloudEvent without id",
+			headers: map[string]string{events.HeaderCloudEventsSpecVersion: "1.0", events.HeaderCloudEventsSource: "/loms", events.HeaderCloudEventsType: "t"},
+			value:   legacy,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := events.DecodeOrderEvent(tt.headers, tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("error expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !proto.Equal(tt.expected, event) {
+				t.Fatalf("event = %v, want %v", event, tt.expected)
+			}
+		})
+	}
+}
+
+// withContentType adds content type header to headers.
+func withContentType(headers map[string]string, contentType string) map[string]string {
+	headers[events.HeaderContentType] = contentType
+	return headers
+}
@@ -0,0 +1,5 @@
+module route256/utils/events
+
+go 1.22.5
+
+require google.golang.org/protobuf v1.35.1
@@ -0,0 +1,27 @@
+package tracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// HeaderTraceParent is W3C Trace Context header with trace and parent span of request.
+const HeaderTraceParent = "traceparent"
+
+// TraceParent returns W3C traceparent of span in ctx, it is empty when ctx has no valid span.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get(HeaderTraceParent)
+}
+
+// ContextWithTraceParent returns ctx with remote span from W3C traceparent,
+// ctx is returned unchanged when traceParent is empty or invalid.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{HeaderTraceParent: traceParent}
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}